@@ -12,6 +12,7 @@ import (
 	"github.com/grafana/grafana/pkg/api/response"
 	"github.com/grafana/grafana/pkg/api/routing"
 	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/expr"
 	"github.com/grafana/grafana/pkg/middleware/requestmeta"
 	"github.com/grafana/grafana/pkg/services/apiserver/endpoints/request"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
@@ -76,14 +77,39 @@ func (hs *HTTPServer) QueryMetricsV2(c *contextmodel.ReqContext) response.Respon
 		return response.Error(http.StatusBadRequest, "bad request data", err)
 	}
 
-	resp, err := hs.queryDataService.QueryData(c.Req.Context(), c.SignedInUser, c.SkipDSCache, reqDTO)
+	ctx := c.Req.Context()
+	if reqDTO.Debug {
+		// record how the expressions of the request are executed
+		ctx = expr.WithExplanation(ctx)
+	}
+
+	resp, err := hs.queryDataService.QueryData(ctx, c.SignedInUser, c.SkipDSCache, reqDTO)
 	if err != nil {
 		return hs.handleQueryMetricsError(err)
 	}
-	return hs.toJsonStreamingResponse(c.Req.Context(), resp)
+	if explanation := expr.ExplanationFromContext(ctx); explanation != nil && explanation.Order != nil {
+		return response.JSONStreaming(queryDataStatusCode(ctx, resp), queryDataResponseWithExplanation{
+			Results: resp.Responses,
+			Explain: explanation,
+		})
+	}
+	return hs.toJsonStreamingResponse(ctx, resp)
+}
+
+// queryDataResponseWithExplanation is the response of a query in debug mode,
+// which has the explanation of the execution of its expressions.
+type queryDataResponseWithExplanation struct {
+	Results backend.Responses `json:"results"`
+	Explain *expr.Explanation `json:"explain"`
 }
 
 func (hs *HTTPServer) toJsonStreamingResponse(ctx context.Context, qdr *backend.QueryDataResponse) response.Response {
+	return response.JSONStreaming(queryDataStatusCode(ctx, qdr), qdr)
+}
+
+// queryDataStatusCode returns the status code of the response of a query,
+// which is 400 if the response of any query has an error.
+func queryDataStatusCode(ctx context.Context, qdr *backend.QueryDataResponse) int {
 	statusCode := http.StatusOK
 	for _, res := range qdr.Responses {
 		if res.Error != nil {
@@ -96,8 +122,7 @@ func (hs *HTTPServer) toJsonStreamingResponse(ctx context.Context, qdr *backend.
 		// an error in the response we treat as downstream.
 		requestmeta.WithDownstreamStatusSource(ctx)
 	}
-
-	return response.JSONStreaming(statusCode, qdr)
+	return statusCode
 }
 
 // swagger:parameters queryMetricsWithExpressions
@@ -77,6 +77,7 @@ type MetricRequest struct {
 	// required: true
 	// example: [ { "refId": "A", "intervalMs": 86400000, "maxDataPoints": 1092, "datasource":{ "uid":"PD8C576611E62080A" }, "rawSql": "SELECT 1 as valueOne, 2 as valueTwo", "format": "table" } ]
 	Queries []*simplejson.Json `json:"queries"`
+	// Debug returns, for requests with expressions, how the queries and expressions were executed in the field explain.
 	// required: false
 	Debug bool `json:"debug"`
 }
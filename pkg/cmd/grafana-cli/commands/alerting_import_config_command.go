@@ -0,0 +1,151 @@
+package commands
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+
+	"github.com/prometheus/alertmanager/config"
+
+	"github.com/grafana/grafana/pkg/cmd/grafana-cli/utils"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/notifier"
+)
+
+var errAlertmanagerImportFailed = errors.New("parts of the Alertmanager configuration cannot be imported")
+
+// importAlertmanagerConfigCommand converts the configuration file of a Prometheus Alertmanager to a Grafana
+// Alertmanager configuration. With --url, the configuration is imported to a running Grafana server instead.
+func importAlertmanagerConfigCommand(c utils.CommandLine) error {
+	file := c.Args().First()
+	if file == "" {
+		return errors.New("missing Alertmanager configuration file argument")
+	}
+	raw, err := os.ReadFile(filepath.Clean(file))
+	if err != nil {
+		return fmt.Errorf("failed to read Alertmanager configuration: %w", err)
+	}
+	templates, err := readUpstreamTemplates(string(raw), filepath.Dir(file))
+	if err != nil {
+		return err
+	}
+
+	if u := c.String("url"); u != "" {
+		return importAlertmanagerConfigToServer(c, u, definitions.PostableAlertmanagerImport{
+			AlertmanagerConfig: string(raw),
+			TemplateFiles:      templates,
+		})
+	}
+
+	cfg, diags := notifier.ConvertUpstreamConfig(context.Background(), string(raw), templates)
+	printImportDiagnostics(diags)
+	if cfg == nil {
+		return errAlertmanagerImportFailed
+	}
+	b, err := json.MarshalIndent(cfg, "", "  ")
+	if err != nil {
+		return err
+	}
+	if output := c.String("output"); output != "" {
+		if err := os.WriteFile(output, b, 0600); err != nil {
+			return fmt.Errorf("failed to write Grafana Alertmanager configuration: %w", err)
+		}
+	} else {
+		fmt.Println(string(b))
+	}
+	if diags.HasErrors() && !c.Bool("force") {
+		return errAlertmanagerImportFailed
+	}
+	return nil
+}
+
+// readUpstreamTemplates reads the template files that the configuration refers to. Relative paths are resolved
+// against the directory of the configuration file, as the Prometheus Alertmanager does.
+func readUpstreamTemplates(raw string, dir string) (map[string]string, error) {
+	upstream, err := config.Load(raw)
+	if err != nil {
+		// The error is reported by the conversion.
+		return nil, nil
+	}
+	templates := make(map[string]string)
+	for _, glob := range upstream.Templates {
+		if !filepath.IsAbs(glob) {
+			glob = filepath.Join(dir, glob)
+		}
+		files, err := filepath.Glob(glob)
+		if err != nil {
+			return nil, fmt.Errorf("invalid template pattern %q: %w", glob, err)
+		}
+		for _, f := range files {
+			content, err := os.ReadFile(filepath.Clean(f))
+			if err != nil {
+				return nil, fmt.Errorf("failed to read template file: %w", err)
+			}
+			templates[filepath.Base(f)] = string(content)
+		}
+	}
+	return templates, nil
+}
+
+func importAlertmanagerConfigToServer(c utils.CommandLine, server string, body definitions.PostableAlertmanagerImport) error {
+	b, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	query := url.Values{}
+	query.Set("dryRun", strconv.FormatBool(c.Bool("dry-run")))
+	query.Set("force", strconv.FormatBool(c.Bool("force")))
+	u := strings.TrimSuffix(server, "/") + "/api/alertmanager/grafana/config/api/v1/import?" + query.Encode()
+
+	req, err := http.NewRequest(http.MethodPost, u, bytes.NewReader(b))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if token := c.String("token"); token != "" {
+		req.Header.Set("Authorization", "Bearer "+token)
+	}
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return fmt.Errorf("failed to import Alertmanager configuration: %w", err)
+	}
+	defer func() { _ = resp.Body.Close() }()
+	respBody, err := io.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+
+	var result definitions.AlertmanagerImportResult
+	if err := json.Unmarshal(respBody, &result); err != nil || result.Diagnostics == nil {
+		return fmt.Errorf("failed to import Alertmanager configuration: %s: %s", resp.Status, strings.TrimSpace(string(respBody)))
+	}
+	printImportDiagnostics(result.Diagnostics)
+	switch {
+	case result.Applied:
+		fmt.Println("The configuration was imported")
+	case resp.StatusCode == http.StatusOK:
+		fmt.Println("The configuration can be imported")
+	default:
+		return errAlertmanagerImportFailed
+	}
+	return nil
+}
+
+func printImportDiagnostics(diags definitions.AlertmanagerImportDiagnostics) {
+	for _, d := range diags {
+		if d.Path == "" {
+			fmt.Fprintf(os.Stderr, "%s: %s\n", d.Severity, d.Message)
+			continue
+		}
+		fmt.Fprintf(os.Stderr, "%s: %s: %s\n", d.Severity, d.Path, d.Message)
+	}
+}
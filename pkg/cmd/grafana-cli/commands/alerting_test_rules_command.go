@@ -0,0 +1,31 @@
+package commands
+
+import (
+	"context"
+	"errors"
+	"os"
+
+	"github.com/grafana/grafana/pkg/cmd/grafana-cli/utils"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/services/ngalert/ruletest"
+)
+
+var errAlertRuleTestsFailed = errors.New("alert rule tests failed")
+
+func testAlertRulesCommand(c utils.CommandLine) error {
+	files := c.Args().Slice()
+	if len(files) == 0 {
+		return errors.New("missing test file argument")
+	}
+
+	logger := log.NewNopLogger()
+	if c.Bool("debug") {
+		logger = log.New("ngalert.ruletest")
+	}
+	runner := ruletest.NewRunner(logger, tracing.NewNoopTracerService())
+	if !runner.RunFiles(context.Background(), os.Stdout, files...) {
+		return errAlertRuleTestsFailed
+	}
+	return nil
+}
@@ -169,6 +169,44 @@ var adminCommands = []*cli.Command{
 	},
 }
 
+var alertingCommands = []*cli.Command{
+	{
+		Name:      "test-rules",
+		Usage:     "Runs the unit tests of Grafana-managed alert rules",
+		ArgsUsage: "<test file>...",
+		Action:    runPluginCommand(testAlertRulesCommand),
+	},
+	{
+		Name:      "import-alertmanager-config",
+		Usage:     "Converts the configuration of a Prometheus Alertmanager to a Grafana Alertmanager configuration, or imports it to a Grafana server with --url",
+		ArgsUsage: "<alertmanager.yml>",
+		Action:    runPluginCommand(importAlertmanagerConfigCommand),
+		Flags: []cli.Flag{
+			&cli.StringFlag{
+				Name:  "output",
+				Usage: "Write the converted configuration to a file instead of stdout. It contains the secrets of contact points",
+			},
+			&cli.StringFlag{
+				Name:  "url",
+				Usage: "URL of the Grafana server to import the configuration to",
+			},
+			&cli.StringFlag{
+				Name:    "token",
+				Usage:   "Service account token to authenticate to the Grafana server",
+				EnvVars: []string{"GRAFANA_TOKEN"},
+			},
+			&cli.BoolFlag{
+				Name:  "dry-run",
+				Usage: "Validate the import on the Grafana server without applying the configuration",
+			},
+			&cli.BoolFlag{
+				Name:  "force",
+				Usage: "Import the configuration even if parts of it cannot be imported",
+			},
+		},
+	},
+}
+
 var Commands = []*cli.Command{
 	{
 		Name:        "plugins",
@@ -180,4 +218,9 @@ var Commands = []*cli.Command{
 		Usage:       "Grafana admin commands",
 		Subcommands: adminCommands,
 	},
+	{
+		Name:        "alerting",
+		Usage:       "Grafana Alerting commands",
+		Subcommands: alertingCommands,
+	},
 }
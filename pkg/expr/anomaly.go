@@ -0,0 +1,148 @@
+package expr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/expr/ml"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+)
+
+// AnomalyOutput is the series the anomaly detection returns for each input series
+// +enum
+type AnomalyOutput string
+
+const (
+	// The anomaly flag and the lower and upper bands, distinguished by the label "anomaly"
+	AnomalyOutputAll AnomalyOutput = ""
+
+	// 1 when the value is outside the band, 0 when it is inside
+	AnomalyOutputFlag AnomalyOutput = "flag"
+
+	// The lower bound of the expected values
+	AnomalyOutputLower AnomalyOutput = "lower"
+
+	// The upper bound of the expected values
+	AnomalyOutputUpper AnomalyOutput = "upper"
+)
+
+// anomalyLabel is the label that tells the output series apart when all of them are returned.
+const anomalyLabel = "anomaly"
+
+// AnomalyCommand is an expression command that detects anomalies in time series in-process,
+// using one of the algorithms of ml.NewDetector.
+type AnomalyCommand struct {
+	VarToDetect string
+	Config      ml.AnomalyConfiguration
+	Output      AnomalyOutput
+	refID       string
+	detector    ml.Detector
+}
+
+// NewAnomalyCommand creates a new AnomalyCommand.
+func NewAnomalyCommand(refID, varToDetect string, cfg ml.AnomalyConfiguration, output AnomalyOutput) (*AnomalyCommand, error) {
+	switch output {
+	case AnomalyOutputAll, AnomalyOutputFlag, AnomalyOutputLower, AnomalyOutputUpper:
+	default:
+		return nil, fmt.Errorf("anomaly output '%s' is not supported. Supported only: [flag,lower,upper] or empty for all", output)
+	}
+	detector, err := ml.NewDetector(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &AnomalyCommand{
+		VarToDetect: varToDetect,
+		Config:      cfg,
+		Output:      output,
+		refID:       refID,
+		detector:    detector,
+	}, nil
+}
+
+// UnmarshalAnomalyCommand creates an AnomalyCommand from Grafana's frontend query.
+func UnmarshalAnomalyCommand(rn *rawNode) (*AnomalyCommand, error) {
+	q := AnomalyQuery{}
+	if err := json.Unmarshal(rn.QueryRaw, &q); err != nil {
+		return nil, fmt.Errorf("failed to parse the anomaly command: %w", err)
+	}
+	varToDetect, err := getReferenceVar(q.Expression, rn.RefID)
+	if err != nil {
+		return nil, err
+	}
+	return NewAnomalyCommand(rn.RefID, varToDetect, q.configuration(), q.Output)
+}
+
+// NeedsVars returns the variable names (refIds) that are dependencies
+// to execute the command and allows the command to fulfill the Command interface.
+func (gr *AnomalyCommand) NeedsVars() []string {
+	return []string{gr.VarToDetect}
+}
+
+// Execute runs the command and returns the results or an error if the command
+// failed to execute.
+func (gr *AnomalyCommand) Execute(ctx context.Context, _ time.Time, vars mathexp.Vars, tracer tracing.Tracer) (mathexp.Results, error) {
+	_, span := tracer.Start(ctx, "SSE.ExecuteAnomaly")
+	defer span.End()
+	newRes := mathexp.Results{}
+	for _, val := range vars[gr.VarToDetect].Values {
+		if val == nil {
+			continue
+		}
+		switch v := val.(type) {
+		case mathexp.Series:
+			newRes.Values = append(newRes.Values, gr.detect(v)...)
+		case mathexp.NoData:
+			newRes.Values = append(newRes.Values, v.New())
+			return newRes, nil
+		default:
+			return newRes, fmt.Errorf("can only detect anomalies in type series, got type %v", val.Type())
+		}
+	}
+	return newRes, nil
+}
+
+// detect returns the output series for s.
+func (gr *AnomalyCommand) detect(s mathexp.Series) mathexp.Values {
+	values := make([]*float64, s.Len())
+	for i := range values {
+		values[i] = s.GetValue(i)
+	}
+	lower, upper, flag := ml.DetectAnomalies(gr.detector, values)
+
+	outputs := []struct {
+		output AnomalyOutput
+		values []*float64
+	}{
+		{AnomalyOutputFlag, flag},
+		{AnomalyOutputLower, lower},
+		{AnomalyOutputUpper, upper},
+	}
+	res := mathexp.Values{}
+	for _, o := range outputs {
+		if gr.Output != AnomalyOutputAll && gr.Output != o.output {
+			continue
+		}
+		labels := s.GetLabels().Copy()
+		if gr.Output == AnomalyOutputAll {
+			if labels == nil {
+				labels = data.Labels{}
+			}
+			labels[anomalyLabel] = string(o.output)
+		}
+		newSeries := mathexp.NewSeries(gr.refID, labels, s.Len())
+		for i := 0; i < s.Len(); i++ {
+			newSeries.SetPoint(i, s.GetTime(i), o.values[i])
+		}
+		res = append(res, newSeries)
+	}
+	return res
+}
+
+func (gr *AnomalyCommand) Type() string {
+	return TypeAnomaly.String()
+}
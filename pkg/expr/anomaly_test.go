@@ -0,0 +1,92 @@
+package expr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/expr/ml"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+)
+
+func TestNewAnomalyCommand(t *testing.T) {
+	t.Run("fails for unknown output", func(t *testing.T) {
+		_, err := NewAnomalyCommand("B", "A", ml.AnomalyConfiguration{Algorithm: ml.AnomalyZScore}, "score")
+		require.Error(t, err)
+	})
+
+	t.Run("fails for unknown algorithm", func(t *testing.T) {
+		_, err := NewAnomalyCommand("B", "A", ml.AnomalyConfiguration{Algorithm: "unknown"}, AnomalyOutputFlag)
+		require.Error(t, err)
+	})
+
+	t.Run("reads the command from the query", func(t *testing.T) {
+		cmd, err := UnmarshalAnomalyCommand(&rawNode{
+			RefID:    "B",
+			QueryRaw: []byte(`{"type": "anomaly", "expression": "$A", "algorithm": "mad", "window": 10, "output": "upper"}`),
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"A"}, cmd.NeedsVars())
+		require.Equal(t, ml.AnomalyMAD, cmd.Config.Algorithm)
+		require.Equal(t, 10, cmd.Config.Window)
+		require.Equal(t, AnomalyOutputUpper, cmd.Output)
+	})
+}
+
+func TestAnomalyCommandExecute(t *testing.T) {
+	start := time.Unix(0, 0)
+	series := mathexp.NewSeries("A", data.Labels{"host": "a"}, 6)
+	for i, v := range []float64{1, 3, 1, 3, 2, 10} {
+		series.SetPoint(i, start.Add(time.Duration(i)*time.Minute), fp(v))
+	}
+	vars := mathexp.Vars{"A": mathexp.Results{Values: mathexp.Values{series}}}
+
+	t.Run("returns flag and bands labeled by output", func(t *testing.T) {
+		cmd, err := NewAnomalyCommand("B", "A", ml.AnomalyConfiguration{Algorithm: ml.AnomalyZScore, Sensitivity: 2}, AnomalyOutputAll)
+		require.NoError(t, err)
+		res, err := cmd.Execute(context.Background(), time.Now(), vars, tracing.InitializeTracerForTest())
+		require.NoError(t, err)
+		require.Len(t, res.Values, 3)
+
+		byOutput := map[string]mathexp.Series{}
+		for _, v := range res.Values {
+			s := v.(mathexp.Series)
+			require.Equal(t, "a", s.GetLabels()["host"])
+			byOutput[s.GetLabels()[anomalyLabel]] = s
+		}
+		require.Equal(t, fp(1), byOutput["flag"].GetValue(5))
+		require.Equal(t, fp(0), byOutput["flag"].GetValue(4))
+		require.Equal(t, fp(0), byOutput["lower"].GetValue(4))
+		require.Equal(t, fp(4), byOutput["upper"].GetValue(4))
+		require.Nil(t, byOutput["upper"].GetValue(0))
+		require.Equal(t, start.Add(5*time.Minute), byOutput["flag"].GetTime(5))
+	})
+
+	t.Run("returns a single output with the labels of the input", func(t *testing.T) {
+		cmd, err := NewAnomalyCommand("B", "A", ml.AnomalyConfiguration{Algorithm: ml.AnomalyZScore, Sensitivity: 2}, AnomalyOutputFlag)
+		require.NoError(t, err)
+		res, err := cmd.Execute(context.Background(), time.Now(), vars, tracing.InitializeTracerForTest())
+		require.NoError(t, err)
+		require.Len(t, res.Values, 1)
+		require.Equal(t, data.Labels{"host": "a"}, res.Values[0].GetLabels())
+	})
+
+	t.Run("passes NoData through and fails for numbers", func(t *testing.T) {
+		cmd, err := NewAnomalyCommand("B", "A", ml.AnomalyConfiguration{Algorithm: ml.AnomalyZScore}, AnomalyOutputFlag)
+		require.NoError(t, err)
+		res, err := cmd.Execute(context.Background(), time.Now(), mathexp.Vars{
+			"A": mathexp.Results{Values: mathexp.Values{mathexp.NoData{}.New()}},
+		}, tracing.InitializeTracerForTest())
+		require.NoError(t, err)
+		require.Len(t, res.Values, 1)
+
+		_, err = cmd.Execute(context.Background(), time.Now(), mathexp.Vars{
+			"A": mathexp.Results{Values: mathexp.Values{mathexp.NewNumber("A", nil)}},
+		}, tracing.InitializeTracerForTest())
+		require.Error(t, err)
+	})
+}
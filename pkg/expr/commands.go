@@ -84,13 +84,14 @@ func (gm *MathCommand) Type() string {
 type ReduceCommand struct {
 	Reducer      mathexp.ReducerID
 	VarToReduce  string
+	Params       []float64
 	refID        string
 	seriesMapper mathexp.ReduceMapper
 }
 
-// NewReduceCommand creates a new ReduceCMD.
-func NewReduceCommand(refID string, reducer mathexp.ReducerID, varToReduce string, mapper mathexp.ReduceMapper) (*ReduceCommand, error) {
-	_, err := mathexp.GetReduceFunc(reducer)
+// NewReduceCommand creates a new ReduceCMD. Params are the parameters of the reducer, such as the percentile.
+func NewReduceCommand(refID string, reducer mathexp.ReducerID, varToReduce string, mapper mathexp.ReduceMapper, params ...float64) (*ReduceCommand, error) {
+	_, err := mathexp.GetReduceFunc(reducer, params...)
 	if err != nil {
 		return nil, err
 	}
@@ -98,6 +99,7 @@ func NewReduceCommand(refID string, reducer mathexp.ReducerID, varToReduce strin
 	return &ReduceCommand{
 		Reducer:      reducer,
 		VarToReduce:  varToReduce,
+		Params:       params,
 		refID:        refID,
 		seriesMapper: mapper,
 	}, nil
@@ -125,6 +127,21 @@ func UnmarshalReduceCommand(rn *rawNode) (*ReduceCommand, error) {
 	}
 	redFunc := mathexp.ReducerID(strings.ToLower(redString))
 
+	var params []float64
+	if rawParams, ok := rn.Query["params"]; ok {
+		list, ok := rawParams.([]any)
+		if !ok {
+			return nil, fmt.Errorf("expected params to be an array, got %T", rawParams)
+		}
+		for _, p := range list {
+			f, ok := p.(float64)
+			if !ok {
+				return nil, fmt.Errorf("expected reducer param to be a number, got %T", p)
+			}
+			params = append(params, f)
+		}
+	}
+
 	var mapper mathexp.ReduceMapper = nil
 	settings, ok := rn.Query["settings"]
 	if ok {
@@ -154,7 +171,7 @@ func UnmarshalReduceCommand(rn *rawNode) (*ReduceCommand, error) {
 			return nil, fmt.Errorf("field settings must be an object, got %T for refId %v", s, rn.RefID)
 		}
 	}
-	return NewReduceCommand(rn.RefID, redFunc, varToReduce, mapper)
+	return NewReduceCommand(rn.RefID, redFunc, varToReduce, mapper, params...)
 }
 
 // NeedsVars returns the variable names (refIds) that are dependencies
@@ -175,7 +192,7 @@ func (gr *ReduceCommand) Execute(ctx context.Context, _ time.Time, vars mathexp.
 	for i, val := range vars[gr.VarToReduce].Values {
 		switch v := val.(type) {
 		case mathexp.Series:
-			num, err := v.Reduce(gr.refID, gr.Reducer, gr.seriesMapper)
+			num, err := v.Reduce(gr.refID, gr.Reducer, gr.seriesMapper, gr.Params...)
 			if err != nil {
 				return newRes, err
 			}
@@ -217,7 +234,13 @@ type ResampleCommand struct {
 	Downsampler   mathexp.ReducerID
 	Upsampler     mathexp.Upsampler
 	TimeRange     TimeRange
-	refID         string
+	// MaxGap is the number of windows a gap can span and still be filled by the step upsampler.
+	// When unset, mathexp.DefaultStepMaxGap is used.
+	MaxGap int
+	// AlignOffset, when set, aligns the samples to multiples of the window since the Unix epoch
+	// shifted by the offset, instead of to the start of the time range.
+	AlignOffset *time.Duration
+	refID       string
 }
 
 // NewResampleCommand creates a new ResampleCMD.
@@ -237,6 +260,41 @@ func NewResampleCommand(refID, rawWindow, varToResample string, downsampler math
 	}, nil
 }
 
+// WithMaxGap sets the max gap of the step upsampler, in number of windows.
+func (gr *ResampleCommand) WithMaxGap(maxGap int) (*ResampleCommand, error) {
+	if maxGap < 1 {
+		return nil, fmt.Errorf("resample max gap must be at least 1, got %d", maxGap)
+	}
+	gr.MaxGap = maxGap
+	return gr, nil
+}
+
+// WithAlignOffset aligns the samples to the wall clock, at multiples of the window shifted by rawOffset.
+func (gr *ResampleCommand) WithAlignOffset(rawOffset string) (*ResampleCommand, error) {
+	offset, err := gtime.ParseDuration(rawOffset)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to parse resample "alignOffset" duration field %q: %w`, rawOffset, err)
+	}
+	gr.AlignOffset = &offset
+	return gr, nil
+}
+
+// alignedFrom returns the first time at or after from that is a multiple of the window since
+// the Unix epoch, shifted by the align offset. If no offset is set it returns from.
+func (gr *ResampleCommand) alignedFrom(from time.Time) time.Time {
+	if gr.AlignOffset == nil || gr.Window <= 0 {
+		return from
+	}
+	rem := (from.UnixNano() - gr.AlignOffset.Nanoseconds()) % gr.Window.Nanoseconds()
+	if rem < 0 {
+		rem += gr.Window.Nanoseconds()
+	}
+	if rem == 0 {
+		return from
+	}
+	return from.Add(gr.Window - time.Duration(rem))
+}
+
 // UnmarshalResampleCommand creates a ResampleCMD from Grafana's frontend query.
 func UnmarshalResampleCommand(rn *rawNode) (*ResampleCommand, error) {
 	if rn.TimeRange == nil {
@@ -280,11 +338,35 @@ func UnmarshalResampleCommand(rn *rawNode) (*ResampleCommand, error) {
 		return nil, fmt.Errorf("expected resample downsampler to be a string, got type %T", upsampler)
 	}
 
-	return NewResampleCommand(rn.RefID, window,
+	cmd, err := NewResampleCommand(rn.RefID, window,
 		varToResample,
 		mathexp.ReducerID(downsampler),
 		mathexp.Upsampler(upsampler),
 		rn.TimeRange)
+	if err != nil {
+		return nil, err
+	}
+
+	if rawMaxGap, ok := rn.Query["maxGap"]; ok {
+		maxGap, ok := rawMaxGap.(float64)
+		if !ok {
+			return nil, fmt.Errorf("expected resample maxGap to be a number, got type %T", rawMaxGap)
+		}
+		if cmd, err = cmd.WithMaxGap(int(maxGap)); err != nil {
+			return nil, err
+		}
+	}
+
+	if rawOffset, ok := rn.Query["alignOffset"]; ok {
+		offset, ok := rawOffset.(string)
+		if !ok {
+			return nil, fmt.Errorf("expected resample alignOffset to be a string, got type %T", rawOffset)
+		}
+		if cmd, err = cmd.WithAlignOffset(offset); err != nil {
+			return nil, err
+		}
+	}
+	return cmd, nil
 }
 
 // NeedsVars returns the variable names (refIds) that are dependencies
@@ -300,13 +382,14 @@ func (gr *ResampleCommand) Execute(ctx context.Context, now time.Time, vars math
 	defer span.End()
 	newRes := mathexp.Results{}
 	timeRange := gr.TimeRange.AbsoluteTime(now)
+	timeRange.From = gr.alignedFrom(timeRange.From)
 	for _, val := range vars[gr.VarToResample].Values {
 		if val == nil {
 			continue
 		}
 		switch v := val.(type) {
 		case mathexp.Series:
-			num, err := v.Resample(gr.refID, gr.Window, gr.Downsampler, gr.Upsampler, timeRange.From, timeRange.To)
+			num, err := v.Resample(gr.refID, gr.Window, gr.Downsampler, gr.Upsampler, timeRange.From, timeRange.To, gr.MaxGap)
 			if err != nil {
 				return newRes, err
 			}
@@ -343,6 +426,12 @@ const (
 	TypeThreshold
 	// TypeSQL is the CMDType for running SQL expressions
 	TypeSQL
+	// TypeAnomaly is the CMDType for detecting anomalies in time series
+	TypeAnomaly
+	// TypeForecast is the CMDType for predicting the values of time series
+	TypeForecast
+	// TypeTransformation is the CMDType for applying dashboard transformations to results
+	TypeTransformation
 )
 
 func (gt CommandType) String() string {
@@ -359,6 +448,12 @@ func (gt CommandType) String() string {
 		return "threshold"
 	case TypeSQL:
 		return "sql"
+	case TypeAnomaly:
+		return "anomaly"
+	case TypeForecast:
+		return "forecast"
+	case TypeTransformation:
+		return "transformation"
 	default:
 		return "unknown"
 	}
@@ -379,6 +474,12 @@ func ParseCommandType(s string) (CommandType, error) {
 		return TypeThreshold, nil
 	case "sql":
 		return TypeSQL, nil
+	case "anomaly":
+		return TypeAnomaly, nil
+	case "forecast":
+		return TypeForecast, nil
+	case "transformation":
+		return TypeTransformation, nil
 	default:
 		return TypeUnknown, fmt.Errorf("'%v' is not a recognized expression type", s)
 	}
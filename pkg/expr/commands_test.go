@@ -211,7 +211,12 @@ func TestReduceExecute(t *testing.T) {
 }
 
 func randomReduceFunc() mathexp.ReducerID {
-	res := mathexp.GetSupportedReduceFuncs()
+	res := []mathexp.ReducerID{}
+	for _, r := range mathexp.GetSupportedReduceFuncs() {
+		if r.NumParams() == 0 {
+			res = append(res, r)
+		}
+	}
 	return res[rand.Intn(len(res))]
 }
 
@@ -273,3 +278,51 @@ func TestResampleCommand_Execute(t *testing.T) {
 		require.NoError(t, err)
 	})
 }
+
+func TestResampleCommand_AlignOffset(t *testing.T) {
+	varToResample := util.GenerateShortUID()
+	now := time.Date(2024, 1, 1, 10, 7, 30, 0, time.UTC)
+	tr := RelativeTimeRange{
+		From: -time.Hour,
+		To:   0,
+	}
+	series := mathexp.NewSeries(varToResample, nil, 1)
+	series.SetPoint(0, now.Add(-time.Hour), util.Pointer(1.0))
+	vars := mathexp.Vars{
+		varToResample: mathexp.Results{Values: mathexp.Values{series}},
+	}
+
+	t.Run("should start at the time range if no offset is set", func(t *testing.T) {
+		cmd, err := NewResampleCommand(util.GenerateShortUID(), "15m", varToResample, "last", "pad", tr)
+		require.NoError(t, err)
+		result, err := cmd.Execute(context.Background(), now, vars, tracing.InitializeTracerForTest())
+		require.NoError(t, err)
+		require.Equal(t, now.Add(-time.Hour), result.Values[0].(mathexp.Series).GetTime(0))
+	})
+
+	t.Run("should align to multiples of the window shifted by the offset", func(t *testing.T) {
+		cmd, err := NewResampleCommand(util.GenerateShortUID(), "15m", varToResample, "last", "pad", tr)
+		require.NoError(t, err)
+		cmd, err = cmd.WithAlignOffset("5m")
+		require.NoError(t, err)
+		result, err := cmd.Execute(context.Background(), now, vars, tracing.InitializeTracerForTest())
+		require.NoError(t, err)
+		resampled := result.Values[0].(mathexp.Series)
+		require.Equal(t, time.Date(2024, 1, 1, 9, 20, 0, 0, time.UTC), resampled.GetTime(0))
+		require.Equal(t, time.Date(2024, 1, 1, 9, 35, 0, 0, time.UTC), resampled.GetTime(1))
+	})
+
+	t.Run("should fail if max gap is negative", func(t *testing.T) {
+		cmd, err := NewResampleCommand(util.GenerateShortUID(), "15m", varToResample, "last", "step", tr)
+		require.NoError(t, err)
+		_, err = cmd.WithMaxGap(-1)
+		require.Error(t, err)
+	})
+
+	t.Run("should fail if max gap is zero", func(t *testing.T) {
+		cmd, err := NewResampleCommand(util.GenerateShortUID(), "15m", varToResample, "last", "step", tr)
+		require.NoError(t, err)
+		_, err = cmd.WithMaxGap(0)
+		require.Error(t, err)
+	})
+}
@@ -30,7 +30,10 @@ func (c *ResultConverter) Convert(ctx context.Context,
 	}
 
 	var dt data.FrameType
-	dt, useDataplane, _ := shouldUseDataplane(frames, logger, c.Features.IsEnabled(ctx, featuremgmt.FlagDisableSSEDataplane))
+	dt, useDataplane, err := shouldUseDataplane(frames, logger, c.Features.IsEnabled(ctx, featuremgmt.FlagDisableSSEDataplane))
+	if err != nil {
+		addConversionWarning(ctx, "dataplane data detected but read as frames without dataplane: %v", err)
+	}
 	if useDataplane {
 		logger.Debug("Handling SSE data source query through dataplane", "datatype", dt)
 		result, err := handleDataplaneFrames(ctx, c.Tracer, c.Features, dt, frames)
@@ -77,6 +80,7 @@ func (c *ResultConverter) Convert(ctx context.Context,
 		// This check should be removed once inconsistencies in data source responses are solved.
 		if schema.Type == data.TimeSeriesTypeNot && datasourceType == datasources.DS_INFLUXDB {
 			logger.Warn("Ignoring InfluxDB data frame due to missing numeric fields")
+			addConversionWarning(ctx, "ignored InfluxDB data frame %q due to missing numeric fields", frame.Name)
 			continue
 		}
 
@@ -0,0 +1,186 @@
+package expr
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+)
+
+// Explanation describes how the nodes of a pipeline were executed, for debugging expressions.
+// It is recorded by ExecutePipeline when the context was returned by WithExplanation.
+type Explanation struct {
+	// Order is the refIDs of the nodes in the order of execution, where each node
+	// comes after the nodes it depends on.
+	Order []string `json:"order"`
+	// Nodes describes the execution of each node, in the order of execution.
+	Nodes []*NodeExplanation `json:"nodes"`
+
+	mu sync.Mutex
+}
+
+// NodeExplanation describes the execution of a node of a pipeline.
+type NodeExplanation struct {
+	RefID string `json:"refId"`
+	// NodeType is the type of the node, e.g. Expression or Datasource.
+	NodeType string `json:"nodeType"`
+	// Kind is the command of an expression, e.g. math or reduce, or the type of a data source.
+	Kind string `json:"kind,omitempty"`
+	// DependsOn is the refIDs of the nodes whose results are the inputs of the node.
+	DependsOn []string `json:"dependsOn,omitempty"`
+	// DurationMs is how long the node took to execute, in milliseconds. Data source queries that
+	// are executed together, grouped by data source, all have the duration of the request.
+	DurationMs float64 `json:"durationMs"`
+	// ResponseType is how the response of a data source was read, e.g. "vector" or "dataplane-timeseries-multi".
+	ResponseType string `json:"responseType,omitempty"`
+	// Inputs are the results of the nodes in DependsOn, as they were passed to the node.
+	Inputs []ResultsSummary `json:"inputs,omitempty"`
+	// Output is the result of the node.
+	Output ResultsSummary `json:"output"`
+	// Warnings are the problems found when the response of a data source or the frames
+	// of a transformation were converted to series or numbers.
+	Warnings []string `json:"warnings,omitempty"`
+	// Skipped is true if the node was not executed because a node it depends on failed.
+	Skipped bool `json:"skipped,omitempty"`
+}
+
+// ResultsSummary describes the shape of the results of a node.
+type ResultsSummary struct {
+	RefID string `json:"refId"`
+	// Count is the number of values, not counting no data.
+	Count int `json:"count"`
+	// Types is the number of values of each type, e.g. seriesSet or numberSet.
+	Types map[string]int `json:"types,omitempty"`
+	// Labels is the labels of each value.
+	Labels []string `json:"labels,omitempty"`
+	// NoData is true if the results have no data.
+	NoData bool `json:"noData,omitempty"`
+	// Notices are the texts of the notices of the values, e.g. why items were dropped from a union.
+	Notices []string `json:"notices,omitempty"`
+	Error   string   `json:"error,omitempty"`
+}
+
+type explanationKey struct{}
+
+type nodeExplanationKey struct{}
+
+// WithExplanation returns a context that makes ExecutePipeline record an Explanation,
+// which can be read with ExplanationFromContext after the pipeline was executed.
+func WithExplanation(ctx context.Context) context.Context {
+	return context.WithValue(ctx, explanationKey{}, &Explanation{})
+}
+
+// ExplanationFromContext returns the Explanation recorded in the context,
+// or nil if the context was not returned by WithExplanation.
+func ExplanationFromContext(ctx context.Context) *Explanation {
+	e, _ := ctx.Value(explanationKey{}).(*Explanation)
+	return e
+}
+
+// start resets the explanation for the execution of the pipeline.
+func (e *Explanation) start(dp DataPipeline) {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	e.Order = make([]string, 0, len(dp))
+	for _, node := range dp {
+		e.Order = append(e.Order, node.RefID())
+	}
+	e.Nodes = make([]*NodeExplanation, 0, len(dp))
+}
+
+// node adds the explanation of the node, or returns it if it has already been added.
+func (e *Explanation) node(n Node) *NodeExplanation {
+	e.mu.Lock()
+	defer e.mu.Unlock()
+	for _, ne := range e.Nodes {
+		if ne.RefID == n.RefID() {
+			return ne
+		}
+	}
+	ne := &NodeExplanation{
+		RefID:     n.RefID(),
+		NodeType:  n.NodeType().String(),
+		DependsOn: n.NeedsVars(),
+	}
+	switch t := n.(type) {
+	case *CMDNode:
+		ne.Kind = t.Command.Type()
+	case *DSNode:
+		ne.Kind = t.String()
+	}
+	e.Nodes = append(e.Nodes, ne)
+	return ne
+}
+
+// explainNode returns a context for the execution of the node that collects its conversion warnings,
+// and the explanation of the node, which is nil if no explanation is recorded.
+func explainNode(ctx context.Context, n Node) (context.Context, *NodeExplanation) {
+	e := ExplanationFromContext(ctx)
+	if e == nil {
+		return ctx, nil
+	}
+	ne := e.node(n)
+	return context.WithValue(ctx, nodeExplanationKey{}, ne), ne
+}
+
+// nodeExplanationFromContext returns the explanation of the node executed with the context, or nil.
+func nodeExplanationFromContext(ctx context.Context) *NodeExplanation {
+	ne, _ := ctx.Value(nodeExplanationKey{}).(*NodeExplanation)
+	return ne
+}
+
+// addConversionWarning adds a warning to the explanation of the node executed with the context, if any.
+func addConversionWarning(ctx context.Context, format string, args ...any) {
+	if ne := nodeExplanationFromContext(ctx); ne != nil {
+		ne.Warnings = append(ne.Warnings, fmt.Sprintf(format, args...))
+	}
+}
+
+// setResponseType records how the response of the data source queried with the context was read.
+func setResponseType(ctx context.Context, responseType string) {
+	if ne := nodeExplanationFromContext(ctx); ne != nil {
+		ne.ResponseType = responseType
+	}
+}
+
+// finish records the inputs, the output and the duration of the node.
+func (ne *NodeExplanation) finish(start time.Time, vars mathexp.Vars, res mathexp.Results) {
+	if ne == nil {
+		return
+	}
+	ne.DurationMs = float64(time.Since(start).Nanoseconds()) / float64(time.Millisecond)
+	ne.Inputs = make([]ResultsSummary, 0, len(ne.DependsOn))
+	for _, refID := range ne.DependsOn {
+		ne.Inputs = append(ne.Inputs, summarizeResults(refID, vars[refID]))
+	}
+	ne.Output = summarizeResults(ne.RefID, res)
+}
+
+func summarizeResults(refID string, res mathexp.Results) ResultsSummary {
+	s := ResultsSummary{RefID: refID, NoData: res.IsNoData()}
+	if res.Error != nil {
+		s.Error = res.Error.Error()
+	}
+	for _, v := range res.Values {
+		if v == nil {
+			continue
+		}
+		if frame := v.AsDataFrame(); frame != nil && frame.Meta != nil {
+			for _, notice := range frame.Meta.Notices {
+				s.Notices = append(s.Notices, notice.Text)
+			}
+		}
+		if _, ok := v.(mathexp.NoData); ok {
+			continue
+		}
+		if s.Types == nil {
+			s.Types = map[string]int{}
+		}
+		s.Count++
+		s.Types[v.Type().String()]++
+		s.Labels = append(s.Labels, v.GetLabels().String())
+	}
+	return s
+}
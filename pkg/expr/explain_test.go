@@ -0,0 +1,159 @@
+package expr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/plugins"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	datafakes "github.com/grafana/grafana/pkg/services/datasources/fakes"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/services/pluginsintegration/pluginconfig"
+	"github.com/grafana/grafana/pkg/services/pluginsintegration/plugincontext"
+	"github.com/grafana/grafana/pkg/services/pluginsintegration/pluginstore"
+	"github.com/grafana/grafana/pkg/services/user"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestExplainPipeline(t *testing.T) {
+	hostFrame := func(host string, v float64) *data.Frame {
+		return data.NewFrame("",
+			data.NewField("time", nil, []time.Time{time.Unix(1, 0)}),
+			data.NewField("value", data.Labels{"host": host}, []*float64{fp(v)}))
+	}
+	me := &mockEndpoint{
+		Responses: map[string]backend.DataResponse{
+			"A": {Frames: data.Frames{hostFrame("a", 1), hostFrame("b", 2)}},
+			"E": {Error: fmt.Errorf("womp womp")},
+		},
+	}
+
+	pCtxProvider := plugincontext.ProvideService(setting.NewCfg(), nil, &pluginstore.FakePluginStore{
+		PluginList: []pluginstore.Plugin{
+			{JSONData: plugins.JSONData{ID: "test"}},
+		},
+	}, &datafakes.FakeCacheService{}, &datafakes.FakeDataSourceService{}, nil, pluginconfig.NewFakePluginRequestConfigProvider())
+
+	newService := func(features featuremgmt.FeatureToggles) *Service {
+		return &Service{
+			cfg:          setting.NewCfg(),
+			dataService:  me,
+			pCtxProvider: pCtxProvider,
+			features:     features,
+			tracer:       tracing.InitializeTracerForTest(),
+			metrics:      newMetrics(nil),
+			converter: &ResultConverter{
+				Features: features,
+				Tracer:   tracing.InitializeTracerForTest(),
+			},
+		}
+	}
+
+	dsQuery := func(refID string) Query {
+		return Query{
+			RefID: refID,
+			DataSource: &datasources.DataSource{
+				OrgID: 1,
+				UID:   "test",
+				Type:  "test",
+			},
+			JSON:      json.RawMessage(`{ "datasource": { "uid": "1" }, "intervalMs": 1000, "maxDataPoints": 1000 }`),
+			TimeRange: AbsoluteTimeRange{},
+		}
+	}
+	exprQuery := func(refID, model string) Query {
+		return Query{
+			RefID:      refID,
+			DataSource: dataSourceModel(),
+			JSON:       json.RawMessage(model),
+		}
+	}
+	queries := []Query{
+		exprQuery("C", `{ "type": "math", "expression": "$B > 1" }`),
+		exprQuery("B", `{ "type": "reduce", "expression": "$A", "reducer": "last" }`),
+		dsQuery("A"),
+		dsQuery("E"),
+		exprQuery("F", `{ "type": "math", "expression": "$E * 2" }`),
+	}
+
+	for _, features := range []featuremgmt.FeatureToggles{featuremgmt.WithFeatures(), featuremgmt.WithFeatures(featuremgmt.FlagSseGroupByDatasource)} {
+		s := newService(features)
+		pl, err := s.BuildPipeline(&Request{Queries: queries, User: &user.SignedInUser{}})
+		require.NoError(t, err)
+
+		ctx := WithExplanation(context.Background())
+		_, err = s.ExecutePipeline(ctx, time.Now(), pl)
+		require.NoError(t, err)
+
+		e := ExplanationFromContext(ctx)
+		require.NotNil(t, e)
+		require.Len(t, e.Order, 5)
+		require.Less(t, slices.Index(e.Order, "A"), slices.Index(e.Order, "B"))
+		require.Less(t, slices.Index(e.Order, "B"), slices.Index(e.Order, "C"))
+		require.Less(t, slices.Index(e.Order, "E"), slices.Index(e.Order, "F"))
+		require.Len(t, e.Nodes, 5)
+
+		nodes := map[string]*NodeExplanation{}
+		for _, ne := range e.Nodes {
+			nodes[ne.RefID] = ne
+		}
+
+		a := nodes["A"]
+		require.Equal(t, TypeDatasourceNode.String(), a.NodeType)
+		require.Equal(t, "test", a.Kind)
+		require.Equal(t, "multi frame series", a.ResponseType)
+		require.Equal(t, 2, a.Output.Count)
+		require.Equal(t, []string{"host=a", "host=b"}, a.Output.Labels)
+
+		b := nodes["B"]
+		require.Equal(t, "reduce", b.Kind)
+		require.Equal(t, []string{"A"}, b.DependsOn)
+		require.Equal(t, []ResultsSummary{a.Output}, b.Inputs)
+		require.Equal(t, map[string]int{"numberSet": 2}, b.Output.Types)
+
+		require.Equal(t, "math", nodes["C"].Kind)
+		require.Equal(t, 2, nodes["C"].Output.Count)
+
+		require.Contains(t, nodes["E"].Output.Error, "womp womp")
+		require.True(t, nodes["F"].Skipped)
+		require.NotEmpty(t, nodes["F"].Output.Error)
+	}
+
+	t.Run("is not recorded without WithExplanation", func(t *testing.T) {
+		s := newService(featuremgmt.WithFeatures())
+		pl, err := s.BuildPipeline(&Request{Queries: queries, User: &user.SignedInUser{}})
+		require.NoError(t, err)
+		ctx := context.Background()
+		_, err = s.ExecutePipeline(ctx, time.Now(), pl)
+		require.NoError(t, err)
+		require.Nil(t, ExplanationFromContext(ctx))
+	})
+}
+
+func TestSummarizeResults(t *testing.T) {
+	n := mathexp.NewNumber("A", data.Labels{"host": "a"})
+	n.SetValue(fp(1))
+	n.AddNotice(data.Notice{Text: "1 items dropped from union(s)"})
+
+	s := summarizeResults("A", mathexp.Results{Values: mathexp.Values{n}})
+	require.Equal(t, ResultsSummary{
+		RefID:   "A",
+		Count:   1,
+		Types:   map[string]int{"numberSet": 1},
+		Labels:  []string{"host=a"},
+		Notices: []string{"1 items dropped from union(s)"},
+	}, s)
+
+	s = summarizeResults("B", mathexp.Results{Values: mathexp.Values{mathexp.NewNoData()}})
+	require.Equal(t, ResultsSummary{RefID: "B", NoData: true}, s)
+}
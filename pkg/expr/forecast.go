@@ -0,0 +1,215 @@
+package expr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/expr/ml"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+)
+
+// ForecastOutput is what the forecast returns for each input series
+// +enum
+type ForecastOutput string
+
+const (
+	// A series of the predicted values after the last point, up to the horizon
+	ForecastOutputSeries ForecastOutput = ""
+
+	// A number of seconds from now until the predicted values reach the threshold
+	ForecastOutputTimeUntilThreshold ForecastOutput = "time_until_threshold"
+)
+
+const (
+	// forecastMaxPoints is the maximum number of points that are predicted for a series,
+	// the same as the default maximum number of data points of an alert query (12 hours at a 1 second step).
+	forecastMaxPoints = 43200
+
+	// forecastMaxHorizon is the maximum horizon of a forecast.
+	forecastMaxHorizon = 366 * 24 * time.Hour
+)
+
+// ForecastCommand is an expression command that predicts the values of time series.
+type ForecastCommand struct {
+	VarToForecast string
+	Config        ml.ForecastConfiguration
+	Horizon       time.Duration
+	Output        ForecastOutput
+	// ThresholdFunc and Threshold are the condition of the time until threshold output,
+	// ThresholdIsAbove for rising values and ThresholdIsBelow for falling values.
+	ThresholdFunc ThresholdType
+	Threshold     float64
+	refID         string
+	forecaster    ml.Forecaster
+}
+
+// NewForecastCommand creates a new ForecastCommand.
+func NewForecastCommand(refID, varToForecast string, cfg ml.ForecastConfiguration, rawHorizon string, output ForecastOutput, thresholdFunc ThresholdType, threshold float64) (*ForecastCommand, error) {
+	horizon, err := gtime.ParseDuration(rawHorizon)
+	if err != nil {
+		return nil, fmt.Errorf(`failed to parse forecast "horizon" duration field %q: %w`, rawHorizon, err)
+	}
+	if horizon <= 0 {
+		return nil, fmt.Errorf("forecast horizon must be positive, got %v", horizon)
+	}
+	if horizon > forecastMaxHorizon {
+		return nil, fmt.Errorf("forecast horizon must not be greater than %v, got %v", forecastMaxHorizon, horizon)
+	}
+	switch output {
+	case ForecastOutputSeries:
+	case ForecastOutputTimeUntilThreshold:
+		if thresholdFunc != ThresholdIsAbove && thresholdFunc != ThresholdIsBelow {
+			return nil, fmt.Errorf("forecast threshold function '%s' is not supported. Supported only: [%s,%s]", thresholdFunc, ThresholdIsAbove, ThresholdIsBelow)
+		}
+	default:
+		return nil, fmt.Errorf("forecast output '%s' is not supported. Supported only: [%s] or empty for the forecast series", output, ForecastOutputTimeUntilThreshold)
+	}
+	forecaster, err := ml.NewForecaster(cfg)
+	if err != nil {
+		return nil, err
+	}
+	return &ForecastCommand{
+		VarToForecast: varToForecast,
+		Config:        cfg,
+		Horizon:       horizon,
+		Output:        output,
+		ThresholdFunc: thresholdFunc,
+		Threshold:     threshold,
+		refID:         refID,
+		forecaster:    forecaster,
+	}, nil
+}
+
+// UnmarshalForecastCommand creates a ForecastCommand from Grafana's frontend query.
+func UnmarshalForecastCommand(rn *rawNode) (*ForecastCommand, error) {
+	q := ForecastQuery{}
+	if err := json.Unmarshal(rn.QueryRaw, &q); err != nil {
+		return nil, fmt.Errorf("failed to parse the forecast command: %w", err)
+	}
+	varToForecast, err := getReferenceVar(q.Expression, rn.RefID)
+	if err != nil {
+		return nil, err
+	}
+	return q.command(rn.RefID, varToForecast)
+}
+
+// NeedsVars returns the variable names (refIds) that are dependencies
+// to execute the command and allows the command to fulfill the Command interface.
+func (gr *ForecastCommand) NeedsVars() []string {
+	return []string{gr.VarToForecast}
+}
+
+// Execute runs the command and returns the results or an error if the command
+// failed to execute.
+func (gr *ForecastCommand) Execute(ctx context.Context, now time.Time, vars mathexp.Vars, tracer tracing.Tracer) (mathexp.Results, error) {
+	_, span := tracer.Start(ctx, "SSE.ExecuteForecast")
+	defer span.End()
+	newRes := mathexp.Results{}
+	for _, val := range vars[gr.VarToForecast].Values {
+		if val == nil {
+			continue
+		}
+		switch v := val.(type) {
+		case mathexp.Series:
+			if gr.Output == ForecastOutputTimeUntilThreshold {
+				n, err := gr.timeUntilThreshold(now, v)
+				if err != nil {
+					return newRes, err
+				}
+				newRes.Values = append(newRes.Values, n)
+				continue
+			}
+			forecast, err := gr.forecast(v)
+			if err != nil {
+				return newRes, err
+			}
+			newRes.Values = append(newRes.Values, forecast)
+		case mathexp.NoData:
+			newRes.Values = append(newRes.Values, v.New())
+			return newRes, nil
+		default:
+			return newRes, fmt.Errorf("can only forecast type series, got type %v", val.Type())
+		}
+	}
+	return newRes, nil
+}
+
+// predict returns the step between the points of s and the forecast for the horizon.
+// The forecast is empty if s does not have enough points. It returns an error if the
+// horizon spans more than forecastMaxPoints steps.
+func (gr *ForecastCommand) predict(s mathexp.Series) (time.Duration, []float64, error) {
+	times := make([]time.Time, s.Len())
+	values := make([]*float64, s.Len())
+	for i := 0; i < s.Len(); i++ {
+		times[i], values[i] = s.GetPoint(i)
+	}
+	step := ml.MedianStep(times)
+	if step <= 0 {
+		return step, nil, nil
+	}
+	steps := math.Ceil(float64(gr.Horizon) / float64(step))
+	if steps > forecastMaxPoints {
+		return step, nil, fmt.Errorf("forecast horizon %v is too long for series %s with a step of %v: it would predict %.0f points, more than the maximum of %d", gr.Horizon, s.GetLabels(), step, steps, forecastMaxPoints)
+	}
+	forecast, err := gr.forecaster.Forecast(times, values, step, int(steps))
+	if err != nil {
+		logger.Debug("Cannot forecast series", "refID", gr.refID, "labels", s.GetLabels(), "error", err)
+		return step, nil, nil
+	}
+	return step, forecast, nil
+}
+
+// forecast returns the series of the predicted values after the last point of s.
+func (gr *ForecastCommand) forecast(s mathexp.Series) (mathexp.Series, error) {
+	step, forecast, err := gr.predict(s)
+	if err != nil {
+		return mathexp.Series{}, err
+	}
+	newSeries := mathexp.NewSeries(gr.refID, s.GetLabels(), len(forecast))
+	for i := range forecast {
+		newSeries.SetPoint(i, s.GetTime(s.Len()-1).Add(time.Duration(i+1)*step), &forecast[i])
+	}
+	return newSeries, nil
+}
+
+// timeUntilThreshold returns the number of seconds from now until the forecast of s reaches the threshold.
+// It is +Inf if the threshold is not reached within the horizon, and null if s cannot be forecast.
+func (gr *ForecastCommand) timeUntilThreshold(now time.Time, s mathexp.Series) (mathexp.Number, error) {
+	n := mathexp.NewNumber(gr.refID, s.GetLabels())
+	step, forecast, err := gr.predict(s)
+	if err != nil {
+		return n, err
+	}
+	if forecast == nil {
+		n.SetValue(nil)
+		return n, nil
+	}
+	// the forecast starts at the last point, use the last known value for it
+	var lastValue *float64
+	for i := s.Len() - 1; i >= 0 && lastValue == nil; i-- {
+		if v := s.GetValue(i); v != nil && !math.IsNaN(*v) {
+			lastValue = v
+		}
+	}
+	if lastValue == nil {
+		n.SetValue(nil)
+		return n, nil
+	}
+	d, ok := ml.TimeUntil(now, s.GetTime(s.Len()-1), *lastValue, forecast, step, gr.Threshold, gr.ThresholdFunc == ThresholdIsAbove)
+	f := math.Inf(1)
+	if ok {
+		f = d.Seconds()
+	}
+	n.SetValue(&f)
+	return n, nil
+}
+
+func (gr *ForecastCommand) Type() string {
+	return TypeForecast.String()
+}
@@ -0,0 +1,129 @@
+package expr
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/expr/ml"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+)
+
+func TestNewForecastCommand(t *testing.T) {
+	linear := ml.ForecastConfiguration{Method: ml.ForecastLinear}
+
+	t.Run("fails for invalid horizon", func(t *testing.T) {
+		_, err := NewForecastCommand("B", "A", linear, "soon", ForecastOutputSeries, "", 0)
+		require.Error(t, err)
+		_, err = NewForecastCommand("B", "A", linear, "-1h", ForecastOutputSeries, "", 0)
+		require.Error(t, err)
+		_, err = NewForecastCommand("B", "A", linear, "10y", ForecastOutputSeries, "", 0)
+		require.Error(t, err)
+	})
+
+	t.Run("fails for unsupported threshold function", func(t *testing.T) {
+		_, err := NewForecastCommand("B", "A", linear, "1h", ForecastOutputTimeUntilThreshold, ThresholdIsWithinRange, 0)
+		require.Error(t, err)
+	})
+
+	t.Run("reads the command from the query", func(t *testing.T) {
+		cmd, err := UnmarshalForecastCommand(&rawNode{
+			RefID:    "B",
+			QueryRaw: []byte(`{"type": "forecast", "expression": "$A", "method": "linear", "horizon": "4h", "output": "time_until_threshold", "evaluator": {"type": "gt", "params": [90]}}`),
+		})
+		require.NoError(t, err)
+		require.Equal(t, []string{"A"}, cmd.NeedsVars())
+		require.Equal(t, 4*time.Hour, cmd.Horizon)
+		require.Equal(t, ThresholdIsAbove, cmd.ThresholdFunc)
+		require.Equal(t, 90.0, cmd.Threshold)
+	})
+
+	t.Run("requires an evaluator for time until threshold", func(t *testing.T) {
+		_, err := UnmarshalForecastCommand(&rawNode{
+			RefID:    "B",
+			QueryRaw: []byte(`{"type": "forecast", "expression": "$A", "method": "linear", "horizon": "4h", "output": "time_until_threshold"}`),
+		})
+		require.Error(t, err)
+	})
+}
+
+func TestForecastCommandExecute(t *testing.T) {
+	start := time.Unix(0, 0)
+	// disk usage growing 1% per minute, at 80% after 10 minutes
+	series := mathexp.NewSeries("A", data.Labels{"disk": "sda"}, 11)
+	for i := 0; i <= 10; i++ {
+		series.SetPoint(i, start.Add(time.Duration(i)*time.Minute), fp(70+float64(i)))
+	}
+	vars := mathexp.Vars{"A": mathexp.Results{Values: mathexp.Values{series}}}
+	now := start.Add(10 * time.Minute)
+	linear := ml.ForecastConfiguration{Method: ml.ForecastLinear}
+
+	t.Run("returns the forecast series up to the horizon", func(t *testing.T) {
+		cmd, err := NewForecastCommand("B", "A", linear, "5m", ForecastOutputSeries, "", 0)
+		require.NoError(t, err)
+		res, err := cmd.Execute(context.Background(), now, vars, tracing.InitializeTracerForTest())
+		require.NoError(t, err)
+		require.Len(t, res.Values, 1)
+		forecast := res.Values[0].(mathexp.Series)
+		require.Equal(t, data.Labels{"disk": "sda"}, forecast.GetLabels())
+		require.Equal(t, 5, forecast.Len())
+		require.Equal(t, start.Add(11*time.Minute), forecast.GetTime(0))
+		require.InDelta(t, 85, *forecast.GetValue(4), 1e-9)
+	})
+
+	t.Run("returns the seconds until the threshold", func(t *testing.T) {
+		cmd, err := NewForecastCommand("B", "A", linear, "1h", ForecastOutputTimeUntilThreshold, ThresholdIsAbove, 95)
+		require.NoError(t, err)
+		res, err := cmd.Execute(context.Background(), now, vars, tracing.InitializeTracerForTest())
+		require.NoError(t, err)
+		require.Len(t, res.Values, 1)
+		n := res.Values[0].(mathexp.Number)
+		require.Equal(t, data.Labels{"disk": "sda"}, n.GetLabels())
+		require.InDelta(t, 15*60, *n.GetFloat64Value(), 1e-6)
+	})
+
+	t.Run("returns +Inf if the threshold is not reached within the horizon", func(t *testing.T) {
+		cmd, err := NewForecastCommand("B", "A", linear, "10m", ForecastOutputTimeUntilThreshold, ThresholdIsAbove, 95)
+		require.NoError(t, err)
+		res, err := cmd.Execute(context.Background(), now, vars, tracing.InitializeTracerForTest())
+		require.NoError(t, err)
+		require.True(t, math.IsInf(*res.Values[0].(mathexp.Number).GetFloat64Value(), 1))
+	})
+
+	t.Run("fails if the horizon spans too many steps", func(t *testing.T) {
+		secondly := mathexp.NewSeries("A", nil, 3)
+		for i := 0; i < 3; i++ {
+			secondly.SetPoint(i, start.Add(time.Duration(i)*time.Second), fp(float64(i)))
+		}
+		secondlyVars := mathexp.Vars{"A": mathexp.Results{Values: mathexp.Values{secondly}}}
+		for _, output := range []ForecastOutput{ForecastOutputSeries, ForecastOutputTimeUntilThreshold} {
+			cmd, err := NewForecastCommand("B", "A", linear, "1d", output, ThresholdIsAbove, 95)
+			require.NoError(t, err)
+			_, err = cmd.Execute(context.Background(), now, secondlyVars, tracing.InitializeTracerForTest())
+			require.ErrorContains(t, err, "too long")
+		}
+
+		cmd, err := NewForecastCommand("B", "A", linear, "12h", ForecastOutputSeries, "", 0)
+		require.NoError(t, err)
+		res, err := cmd.Execute(context.Background(), now, secondlyVars, tracing.InitializeTracerForTest())
+		require.NoError(t, err)
+		require.Equal(t, forecastMaxPoints, res.Values[0].(mathexp.Series).Len())
+	})
+
+	t.Run("returns null if the series cannot be forecast", func(t *testing.T) {
+		cmd, err := NewForecastCommand("B", "A", linear, "1h", ForecastOutputTimeUntilThreshold, ThresholdIsAbove, 95)
+		require.NoError(t, err)
+		single := mathexp.NewSeries("A", nil, 1)
+		single.SetPoint(0, start, fp(1))
+		res, err := cmd.Execute(context.Background(), now, mathexp.Vars{
+			"A": mathexp.Results{Values: mathexp.Values{single}},
+		}, tracing.InitializeTracerForTest())
+		require.NoError(t, err)
+		require.Nil(t, res.Values[0].(mathexp.Number).GetFloat64Value())
+	})
+}
@@ -77,7 +77,7 @@ func (dp *DataPipeline) execute(c context.Context, now time.Time, s *Service) (m
 		executeDSNodesGrouped(c, now, vars, s, dsNodes)
 	}
 
-	s.allowLongFrames = hasSqlExpression(*dp)
+	s.allowLongFrames = hasTableExpression(*dp)
 
 	for _, node := range *dp {
 		if groupByDSFlag && node.NodeType() == TypeDatasourceNode {
@@ -99,6 +99,10 @@ func (dp *DataPipeline) execute(c context.Context, now time.Time, s *Service) (m
 			}
 		}
 		if hasDepError {
+			if _, ne := explainNode(c, node); ne != nil {
+				ne.Skipped = true
+				ne.finish(time.Now(), vars, vars[node.RefID()])
+			}
 			continue
 		}
 
@@ -115,10 +119,13 @@ func (dp *DataPipeline) execute(c context.Context, now time.Time, s *Service) (m
 			return vars, makeUnexpectedNodeTypeError(node.RefID(), node.NodeType().String())
 		}
 
-		res, err := execNode.Execute(c, now, vars, s)
+		nodeCtx, ne := explainNode(c, node)
+		start := time.Now()
+		res, err := execNode.Execute(nodeCtx, now, vars, s)
 		if err != nil {
 			res.Error = err
 		}
+		ne.finish(start, vars, res)
 
 		vars[node.RefID()] = res
 	}
@@ -371,12 +378,14 @@ func GetCommandsFromPipeline[T Command](pipeline DataPipeline) []T {
 	return results
 }
 
-func hasSqlExpression(dp DataPipeline) bool {
+// hasTableExpression returns true if the pipeline has an expression that works on tables,
+// so the results of data source queries do not have to be time series.
+func hasTableExpression(dp DataPipeline) bool {
 	for _, node := range dp {
 		if node.NodeType() == TypeCMDNode {
 			cmdNode := node.(*CMDNode)
-			_, ok := cmdNode.Command.(*SQLCommand)
-			if ok {
+			switch cmdNode.Command.(type) {
+			case *SQLCommand, *TransformationCommand:
 				return true
 			}
 		}
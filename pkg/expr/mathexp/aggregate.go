@@ -0,0 +1,161 @@
+package mathexp
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp/parse"
+)
+
+// aggregateGroup holds the values of an aggregation that have the same grouping labels.
+type aggregateGroup struct {
+	labels data.Labels
+	values Values
+}
+
+// walkAggregate aggregates the values of the argument of node that have the same values of the
+// grouping labels into one value with these labels. Numbers are aggregated into a number, and
+// series into a series with the aggregate of the values of all series at each time.
+func (e *State) walkAggregate(node *parse.AggregateNode) (Results, error) {
+	res := Results{Values: Values{}}
+	ar, err := e.walk(node.Arg)
+	if err != nil {
+		return res, err
+	}
+
+	groups := []*aggregateGroup{}
+	byKey := map[string]*aggregateGroup{}
+	for _, val := range ar.Values {
+		if val.Type() == parse.TypeNoData {
+			continue
+		}
+		labels := groupingLabels(val.GetLabels(), node.Grouping)
+		key := labels.String()
+		g, ok := byKey[key]
+		if !ok {
+			g = &aggregateGroup{labels: labels}
+			byKey[key] = g
+			groups = append(groups, g)
+		}
+		g.values = append(g.values, val)
+	}
+	if len(groups) == 0 {
+		return ar, nil
+	}
+
+	for _, g := range groups {
+		var value Value
+		switch v := g.values[0].(type) {
+		case Number:
+			value, err = e.aggregateNumbers(node.Op, g)
+		case Series:
+			value, err = e.aggregateSeries(node.Op, g)
+		default:
+			return res, fmt.Errorf("can not aggregate type %v", v.Type())
+		}
+		if err != nil {
+			return res, err
+		}
+		res.Values = append(res.Values, value)
+	}
+	return res, nil
+}
+
+// groupingLabels returns the labels of l that are in the grouping.
+func groupingLabels(l data.Labels, grouping []string) data.Labels {
+	res := data.Labels{}
+	for _, name := range grouping {
+		if v, ok := l[name]; ok {
+			res[name] = v
+		}
+	}
+	return res
+}
+
+func (e *State) aggregateNumbers(op string, g *aggregateGroup) (Number, error) {
+	values := make([]*float64, 0, len(g.values))
+	for _, val := range g.values {
+		n, ok := val.(Number)
+		if !ok {
+			return Number{}, fmt.Errorf("can not aggregate type %v with type %v", g.values[0].Type(), val.Type())
+		}
+		values = append(values, n.GetFloat64Value())
+	}
+	f, err := aggregate(op, values)
+	if err != nil {
+		return Number{}, err
+	}
+	n := NewNumber(e.RefID, g.labels)
+	n.SetValue(f)
+	return n, nil
+}
+
+func (e *State) aggregateSeries(op string, g *aggregateGroup) (Series, error) {
+	times := []time.Time{}
+	points := map[int64][]*float64{}
+	for _, val := range g.values {
+		s, ok := val.(Series)
+		if !ok {
+			return Series{}, fmt.Errorf("can not aggregate type %v with type %v", g.values[0].Type(), val.Type())
+		}
+		for i := 0; i < s.Len(); i++ {
+			t, f := s.GetPoint(i)
+			if _, ok := points[t.UnixNano()]; !ok {
+				times = append(times, t)
+			}
+			points[t.UnixNano()] = append(points[t.UnixNano()], f)
+		}
+	}
+	sort.Slice(times, func(i, j int) bool { return times[i].Before(times[j]) })
+
+	newSeries := NewSeries(e.RefID, g.labels, len(times))
+	for i, t := range times {
+		f, err := aggregate(op, points[t.UnixNano()])
+		if err != nil {
+			return newSeries, err
+		}
+		newSeries.SetPoint(i, t, f)
+	}
+	return newSeries, nil
+}
+
+// aggregate performs an aggregation on the values that are not null. The result is null,
+// or 0 for count, if all values are null. NaN values make the result NaN, except for count.
+func aggregate(op string, values []*float64) (*float64, error) {
+	var r float64
+	var n int
+	for _, v := range values {
+		if v == nil {
+			continue
+		}
+		switch op {
+		case "sum", "avg":
+			r += *v
+		case "max":
+			if n == 0 || *v > r || math.IsNaN(*v) {
+				r = *v
+			}
+		case "min":
+			if n == 0 || *v < r || math.IsNaN(*v) {
+				r = *v
+			}
+		case "count":
+		default:
+			return nil, fmt.Errorf("expr: unknown aggregation %s", op)
+		}
+		n++
+	}
+	switch {
+	case op == "count":
+		r = float64(n)
+	case n == 0:
+		return nil, nil
+	case op == "avg":
+		r /= float64(n)
+	}
+	return &r, nil
+}
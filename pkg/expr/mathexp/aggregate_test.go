@@ -0,0 +1,252 @@
+package mathexp
+
+import (
+	"math"
+	"testing"
+	"time"
+
+	"github.com/google/go-cmp/cmp"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/assert"
+
+	"github.com/grafana/grafana/pkg/infra/tracing"
+)
+
+func TestAggregateExpr(t *testing.T) {
+	hostSeries := Vars{
+		"A": resultValuesNoErr(
+			makeSeries("A", data.Labels{"host": "a", "dc": "eu"}, tp{
+				time.Unix(5, 0), float64Pointer(1),
+			}, tp{
+				time.Unix(10, 0), float64Pointer(2),
+			}),
+			makeSeries("A", data.Labels{"host": "b", "dc": "eu"}, tp{
+				time.Unix(5, 0), float64Pointer(3),
+			}, tp{
+				time.Unix(10, 0), nil,
+			}, tp{
+				time.Unix(15, 0), float64Pointer(5),
+			}),
+			makeSeries("A", data.Labels{"host": "c", "dc": "us"}, tp{
+				time.Unix(5, 0), float64Pointer(10),
+			}),
+		),
+	}
+	hostNumbers := Vars{
+		"A": resultValuesNoErr(
+			makeNumber("A", data.Labels{"host": "a", "dc": "eu"}, float64Pointer(1)),
+			makeNumber("A", data.Labels{"host": "b", "dc": "eu"}, float64Pointer(4)),
+			makeNumber("A", data.Labels{"host": "c", "dc": "us"}, float64Pointer(10)),
+			makeNumber("A", data.Labels{"host": "d"}, nil),
+		),
+	}
+
+	var tests = []struct {
+		name      string
+		expr      string
+		vars      Vars
+		newErrIs  assert.ErrorAssertionFunc
+		execErrIs assert.ErrorAssertionFunc
+		results   Results
+	}{
+		{
+			name:      "sum by label of series",
+			expr:      "sum by(dc) ($A)",
+			vars:      hostSeries,
+			newErrIs:  assert.NoError,
+			execErrIs: assert.NoError,
+			results: resultValuesNoErr(
+				makeSeries("", data.Labels{"dc": "eu"}, tp{
+					time.Unix(5, 0), float64Pointer(4),
+				}, tp{
+					time.Unix(10, 0), float64Pointer(2),
+				}, tp{
+					time.Unix(15, 0), float64Pointer(5),
+				}),
+				makeSeries("", data.Labels{"dc": "us"}, tp{
+					time.Unix(5, 0), float64Pointer(10),
+				}),
+			),
+		},
+		{
+			name:      "count of all series",
+			expr:      "count($A)",
+			vars:      hostSeries,
+			newErrIs:  assert.NoError,
+			execErrIs: assert.NoError,
+			results: resultValuesNoErr(
+				makeSeries("", data.Labels{}, tp{
+					time.Unix(5, 0), float64Pointer(3),
+				}, tp{
+					time.Unix(10, 0), float64Pointer(1),
+				}, tp{
+					time.Unix(15, 0), float64Pointer(1),
+				}),
+			),
+		},
+		{
+			name:      "avg by label of numbers",
+			expr:      "avg by(dc) ($A)",
+			vars:      hostNumbers,
+			newErrIs:  assert.NoError,
+			execErrIs: assert.NoError,
+			results: resultValuesNoErr(
+				makeNumber("", data.Labels{"dc": "eu"}, float64Pointer(2.5)),
+				makeNumber("", data.Labels{"dc": "us"}, float64Pointer(10)),
+				makeNumber("", data.Labels{}, nil),
+			),
+		},
+		{
+			name:      "max and min by quoted label of an expression",
+			expr:      `max by("dc") ($A * 2) - min by(dc) ($A)`,
+			vars:      Vars{"A": resultValuesNoErr(hostNumbers["A"].Values[:3]...)},
+			newErrIs:  assert.NoError,
+			execErrIs: assert.NoError,
+			results: resultValuesNoErr(
+				makeNumber("", data.Labels{"dc": "eu"}, float64Pointer(7)),
+				makeNumber("", data.Labels{"dc": "us"}, float64Pointer(10)),
+			),
+		},
+		{
+			name:      "aggregation as a function argument",
+			expr:      "abs(min($A))",
+			vars:      Vars{"A": resultValuesNoErr(hostNumbers["A"].Values[:3]...)},
+			newErrIs:  assert.NoError,
+			execErrIs: assert.NoError,
+			results:   resultValuesNoErr(makeNumber("", data.Labels{}, float64Pointer(1))),
+		},
+		{
+			name:      "NaN values make the aggregate NaN",
+			expr:      "sum($A)",
+			vars:      Vars{"A": resultValuesNoErr(makeNumber("A", nil, float64Pointer(1)), makeNumber("A", nil, NaN))},
+			newErrIs:  assert.NoError,
+			execErrIs: assert.NoError,
+			results:   resultValuesNoErr(makeNumber("", data.Labels{}, float64Pointer(math.NaN()))),
+		},
+		{
+			name:      "no data is passed through",
+			expr:      "sum by(dc) ($A)",
+			vars:      Vars{"A": resultValuesNoErr(NewNoData())},
+			newErrIs:  assert.NoError,
+			execErrIs: assert.NoError,
+			results:   resultValuesNoErr(NewNoData()),
+		},
+		{
+			name:     "aggregation of a scalar fails",
+			expr:     "sum(1)",
+			vars:     hostNumbers,
+			newErrIs: assert.Error,
+		},
+		{
+			name:     "by requires a label",
+			expr:     "sum by() ($A)",
+			vars:     hostNumbers,
+			newErrIs: assert.Error,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := New(tt.expr)
+			tt.newErrIs(t, err)
+			if e != nil {
+				res, err := e.Execute("", tt.vars, tracing.InitializeTracerForTest())
+				tt.execErrIs(t, err)
+				if diff := cmp.Diff(tt.results, res, data.FrameTestCompareOptions()...); diff != "" {
+					t.Errorf("Result mismatch (-want +got):\n%s", diff)
+				}
+			}
+		})
+	}
+}
+
+func TestVectorMatchingExpr(t *testing.T) {
+	vars := Vars{
+		"A": resultValuesNoErr(
+			makeNumber("A", data.Labels{"host": "a", "dc": "eu"}, float64Pointer(1)),
+			makeNumber("A", data.Labels{"host": "b", "dc": "eu"}, float64Pointer(2)),
+		),
+		"B": resultValuesNoErr(
+			makeNumber("B", data.Labels{"host": "a", "env": "prod"}, float64Pointer(10)),
+			makeNumber("B", data.Labels{"host": "b", "env": "prod"}, float64Pointer(20)),
+		),
+		"C": resultValuesNoErr(makeNumber("C", data.Labels{"host": "a"}, float64Pointer(1))),
+		"D": resultValuesNoErr(makeNumber("D", data.Labels{"host": "b"}, float64Pointer(2))),
+		// E has two items in the match group {host=a} of on(host).
+		"E": resultValuesNoErr(
+			makeNumber("E", data.Labels{"host": "a", "dc": "eu"}, float64Pointer(1)),
+			makeNumber("E", data.Labels{"host": "a", "dc": "us"}, float64Pointer(2)),
+		),
+	}
+	matched := resultValuesNoErr(
+		makeNumber("", data.Labels{"host": "a"}, float64Pointer(11)),
+		makeNumber("", data.Labels{"host": "b"}, float64Pointer(22)),
+	)
+
+	var tests = []struct {
+		name    string
+		expr    string
+		results Results
+		execErr string
+	}{
+		{
+			name:    "labels that differ by one key do not match",
+			expr:    "$A + $B",
+			results: Results{Values: Values{}},
+		},
+		{
+			name:    "on matches the listed labels",
+			expr:    "$A + on(host) $B",
+			results: matched,
+		},
+		{
+			name:    "ignoring matches all but the listed labels",
+			expr:    "$A + ignoring(dc, env) $B",
+			results: matched,
+		},
+		{
+			name: "matching applies to its operator only",
+			expr: "($A + on(host) $B) > ignoring(dc, env) 11",
+			results: resultValuesNoErr(
+				makeNumber("", data.Labels{"host": "a"}, float64Pointer(0)),
+				makeNumber("", data.Labels{"host": "b"}, float64Pointer(1)),
+			),
+		},
+		{
+			name:    "single values are not combined if the matching does not match",
+			expr:    "$C + on(host) $D",
+			results: Results{Values: Values{}},
+		},
+		{
+			name:    "duplicate series on the left side are an error",
+			expr:    "$E + on(host) $B",
+			execErr: "found duplicate series for the match group {host=a} on the left side of $E + on(host) $B, many-to-many matching is not allowed",
+		},
+		{
+			name:    "duplicate series on the right side are an error",
+			expr:    "$B + on(host) $E",
+			execErr: "found duplicate series for the match group {host=a} on the right side of $B + on(host) $E, many-to-many matching is not allowed",
+		},
+		{
+			name:    "many-to-many matching is an error",
+			expr:    "$E + ignoring(dc) $E",
+			execErr: "found duplicate series for the match group {host=a} on the right side of $E + ignoring(dc) $E, many-to-many matching is not allowed",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := New(tt.expr)
+			assert.NoError(t, err)
+			res, err := e.Execute("", vars, tracing.InitializeTracerForTest())
+			if tt.execErr != "" {
+				assert.EqualError(t, err, tt.execErr)
+				return
+			}
+			assert.NoError(t, err)
+			if diff := cmp.Diff(tt.results, res, data.FrameTestCompareOptions()...); diff != "" {
+				t.Errorf("Result mismatch (-want +got):\n%s", diff)
+			}
+		})
+	}
+}
@@ -98,6 +98,8 @@ func (e *State) walk(node parse.Node) (res Results, err error) {
 		res, err = e.walkUnary(node)
 	case *parse.FuncNode:
 		res, err = e.walkFunc(node)
+	case *parse.AggregateNode:
+		res, err = e.walkAggregate(node)
 	default:
 		return res, fmt.Errorf("expr: can not walk node type: %s", node.Type())
 	}
@@ -201,8 +203,11 @@ type Union struct {
 // union creates Union objects based on the Labels attached to each Series or Number
 // within a collection of Series or Numbers. The Unions are used with binary
 // operations. The labels of the Union will the taken from result with a greater
-// number of tags.
-func (e *State) union(aResults, bResults Results, biNode *parse.BinaryNode) []*Union {
+// number of tags. If the binary operation has an on() or ignoring() matching, the
+// values are matched on the labels it selects instead, and the labels of the Union
+// are the selected labels, as in Prometheus. Since the matching is one-to-one, it
+// returns an error if a value matches more than one value of the other side.
+func (e *State) union(aResults, bResults Results, biNode *parse.BinaryNode) ([]*Union, error) {
 	unions := []*Union{}
 	appendUnions := func(u *Union) {
 		unions = append(unions, u)
@@ -241,7 +246,7 @@ func (e *State) union(aResults, bResults Results, biNode *parse.BinaryNode) []*U
 	aValueLen := len(aResults.Values)
 	bValueLen := len(bResults.Values)
 	if aValueLen == 0 || bValueLen == 0 {
-		return unions
+		return unions, nil
 	}
 
 	if aValueLen == 1 || bValueLen == 1 {
@@ -254,16 +259,34 @@ func (e *State) union(aResults, bResults Results, biNode *parse.BinaryNode) []*U
 				B:      bResults.Values[0],
 			})
 			collectDrops()
-			return unions
+			return unions, nil
 		}
 	}
 
+	// The indexes of the values that matched each match group, to detect many-to-many matching.
+	aMatchGroups := map[string]int{}
+	bMatchGroups := map[string]int{}
 	for iA, a := range aResults.Values {
 		for iB, b := range bResults.Values {
 			var labels data.Labels
 			aLabels := a.GetLabels()
 			bLabels := b.GetLabels()
 			switch {
+			case biNode.Matching != nil && len(aLabels) != 0 && len(bLabels) != 0:
+				aMatch := matchLabels(aLabels, biNode.Matching)
+				if !aMatch.Equals(matchLabels(bLabels, biNode.Matching)) {
+					continue
+				}
+				group := aMatch.String()
+				if i, ok := aMatchGroups[group]; ok && i != iA {
+					return nil, fmt.Errorf("found duplicate series for the match group {%s} on the left side of %s, many-to-many matching is not allowed", group, biNode.String())
+				}
+				if i, ok := bMatchGroups[group]; ok && i != iB {
+					return nil, fmt.Errorf("found duplicate series for the match group {%s} on the right side of %s, many-to-many matching is not allowed", group, biNode.String())
+				}
+				aMatchGroups[group] = iA
+				bMatchGroups[group] = iB
+				labels = aMatch
 			case aLabels.Equals(bLabels) || len(aLabels) == 0 || len(bLabels) == 0:
 				l := aLabels
 				if len(aLabels) == 0 {
@@ -290,7 +313,7 @@ func (e *State) union(aResults, bResults Results, biNode *parse.BinaryNode) []*U
 		}
 	}
 
-	if len(unions) == 0 && len(aResults.Values) == 1 && len(bResults.Values) == 1 {
+	if len(unions) == 0 && biNode.Matching == nil && len(aResults.Values) == 1 && len(bResults.Values) == 1 {
 		// In the case of only 1 thing on each side of the operator, we combine them
 		// and strip the tags.
 		// This isn't ideal for understanding behavior, but will make more stuff work when
@@ -303,7 +326,22 @@ func (e *State) union(aResults, bResults Results, biNode *parse.BinaryNode) []*U
 	}
 
 	collectDrops()
-	return unions
+	return unions, nil
+}
+
+// matchLabels returns the labels of l that the matching compares.
+func matchLabels(l data.Labels, m *parse.VectorMatching) data.Labels {
+	selected := make(map[string]bool, len(m.Labels))
+	for _, name := range m.Labels {
+		selected[name] = true
+	}
+	res := data.Labels{}
+	for k, v := range l {
+		if selected[k] == m.On {
+			res[k] = v
+		}
+	}
+	return res
 }
 
 func (e *State) walkBinary(node *parse.BinaryNode) (Results, error) {
@@ -316,7 +354,10 @@ func (e *State) walkBinary(node *parse.BinaryNode) (Results, error) {
 	if err != nil {
 		return res, err
 	}
-	unions := e.union(ar, br, node)
+	unions, err := e.union(ar, br, node)
+	if err != nil {
+		return res, err
+	}
 	for _, uni := range unions {
 		var value Value
 		switch at := uni.A.(type) {
@@ -561,6 +602,10 @@ func (e *State) walkFunc(node *parse.FuncNode) (Results, error) {
 
 	in := make([]reflect.Value, len(node.Args))
 	for i, a := range node.Args {
+		if d, ok := parse.DurationValue(a); ok {
+			in[i] = reflect.ValueOf(d)
+			continue
+		}
 		var v any
 		switch t := a.(type) {
 		case *parse.StringNode:
@@ -575,6 +620,8 @@ func (e *State) walkFunc(node *parse.FuncNode) (Results, error) {
 			v, err = e.walkUnary(t)
 		case *parse.BinaryNode:
 			v, err = e.walkBinary(t)
+		case *parse.AggregateNode:
+			v, err = e.walkAggregate(t)
 		default:
 			return res, fmt.Errorf("expr: unknown func arg type: %T", t)
 		}
@@ -1,7 +1,11 @@
 package mathexp
 
 import (
+	"fmt"
 	"math"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
 
 	"github.com/grafana/grafana/pkg/expr/mathexp/parse"
 )
@@ -68,6 +72,75 @@ var builtins = map[string]parse.Func{
 		VariantReturn: true,
 		F:             floor,
 	},
+	"clamp_min": {
+		Args:          []parse.ReturnType{parse.TypeVariantSet, parse.TypeScalar},
+		VariantReturn: true,
+		F:             clampMin,
+	},
+	"clamp_max": {
+		Args:          []parse.ReturnType{parse.TypeVariantSet, parse.TypeScalar},
+		VariantReturn: true,
+		F:             clampMax,
+	},
+	"moving_avg": {
+		Args:   []parse.ReturnType{parse.TypeSeriesSet, parse.TypeDuration},
+		Return: parse.TypeSeriesSet,
+		F:      movingAvg,
+	},
+	"rate": {
+		Args:   []parse.ReturnType{parse.TypeSeriesSet},
+		Return: parse.TypeSeriesSet,
+		F:      rate,
+	},
+	"delta": {
+		Args:   []parse.ReturnType{parse.TypeSeriesSet},
+		Return: parse.TypeSeriesSet,
+		F:      delta,
+	},
+	"derivative": {
+		Args:   []parse.ReturnType{parse.TypeSeriesSet},
+		Return: parse.TypeSeriesSet,
+		F:      derivative,
+	},
+	"cumulative_sum": {
+		Args:   []parse.ReturnType{parse.TypeSeriesSet},
+		Return: parse.TypeSeriesSet,
+		F:      cumulativeSum,
+	},
+	"shift": {
+		Args:   []parse.ReturnType{parse.TypeSeriesSet, parse.TypeDuration},
+		Return: parse.TypeSeriesSet,
+		F:      shift,
+	},
+	"offset": {
+		Args:   []parse.ReturnType{parse.TypeSeriesSet, parse.TypeDuration},
+		Return: parse.TypeSeriesSet,
+		F:      shift,
+	},
+	"if": {
+		Args:          []parse.ReturnType{parse.TypeVariantSet, parse.TypeVariantSet, parse.TypeVariantSet},
+		VariantReturn: true,
+		F:             ifFunc,
+	},
+	"label_replace": {
+		Args:          []parse.ReturnType{parse.TypeVariantSet, parse.TypeString, parse.TypeString, parse.TypeString, parse.TypeString},
+		VariantReturn: true,
+		F:             labelReplace,
+		Check:         checkLabelReplace,
+	},
+	"label_join": {
+		Args:          []parse.ReturnType{parse.TypeVariantSet, parse.TypeString, parse.TypeString, parse.TypeString},
+		VariantReturn: true,
+		Variadic:      true,
+		F:             labelJoin,
+		Check:         checkLabelJoin,
+	},
+	"label_drop": {
+		Args:          []parse.ReturnType{parse.TypeVariantSet, parse.TypeString},
+		VariantReturn: true,
+		Variadic:      true,
+		F:             labelDrop,
+	},
 }
 
 // abs returns the absolute value for each result in NumberSet, SeriesSet, or Scalar
@@ -307,3 +380,196 @@ func floor(e *State, varSet Results) (Results, error) {
 	}
 	return newRes, nil
 }
+
+// clampMin returns the value for each result in NumberSet, SeriesSet, or Scalar,
+// or min if the value is lower than min. Null values stay null.
+func clampMin(e *State, varSet Results, minRes Results) (Results, error) {
+	limit, err := scalarArg("clamp_min", minRes)
+	if err != nil {
+		return Results{}, err
+	}
+	return clamp(e, varSet, func(f float64) float64 { return math.Max(f, limit) })
+}
+
+// clampMax returns the value for each result in NumberSet, SeriesSet, or Scalar,
+// or max if the value is greater than max. Null values stay null.
+func clampMax(e *State, varSet Results, maxRes Results) (Results, error) {
+	limit, err := scalarArg("clamp_max", maxRes)
+	if err != nil {
+		return Results{}, err
+	}
+	return clamp(e, varSet, func(f float64) float64 { return math.Min(f, limit) })
+}
+
+func clamp(e *State, varSet Results, clampF func(f float64) float64) (Results, error) {
+	newRes := Results{}
+	for _, res := range varSet.Values {
+		newVal, err := perNullableFloat(e, res, func(f *float64) *float64 {
+			if f == nil {
+				return nil
+			}
+			nF := clampF(*f)
+			return &nF
+		})
+		if err != nil {
+			return newRes, err
+		}
+		newRes.Values = append(newRes.Values, newVal)
+	}
+	return newRes, nil
+}
+
+// ifFunc returns, for each value of cond, the matching value of a where cond is not 0, and the
+// matching value of b where cond is 0. If cond is a time series, the selection is done for each
+// point, with the value of a or b at the time of the point. A single value, or a value with labels
+// that are equal to, a subset of, or a superset of the labels of cond, matches. The result is null
+// where cond is null or NaN, or where there is no value to select. The result has the labels of
+// cond, or of the selected value if cond has no labels.
+func ifFunc(e *State, cond, a, b Results) (Results, error) {
+	newRes := Results{}
+	for _, c := range cond.Values {
+		switch c := c.(type) {
+		case Scalar:
+			f := c.GetFloat64Value()
+			switch {
+			case f == nil || math.IsNaN(*f):
+				newRes.Values = append(newRes.Values, NewScalar(e.RefID, nil))
+			case *f != 0:
+				newRes.Values = append(newRes.Values, a.Values...)
+			default:
+				newRes.Values = append(newRes.Values, b.Values...)
+			}
+		case Number:
+			f := c.GetFloat64Value()
+			var selected Value
+			switch {
+			case f == nil || math.IsNaN(*f):
+			case *f != 0:
+				selected = matchValue(c.GetLabels(), a)
+			default:
+				selected = matchValue(c.GetLabels(), b)
+			}
+			newVal, err := selectValue(e, c.GetLabels(), selected)
+			if err != nil {
+				return newRes, err
+			}
+			newRes.Values = append(newRes.Values, newVal)
+		case Series:
+			aV, bV := matchValue(c.GetLabels(), a), matchValue(c.GetLabels(), b)
+			labels := c.GetLabels()
+			for _, v := range []Value{aV, bV} {
+				if len(labels) == 0 && v != nil {
+					labels = v.GetLabels()
+				}
+			}
+			aAt, err := valueAt(aV)
+			if err != nil {
+				return newRes, err
+			}
+			bAt, err := valueAt(bV)
+			if err != nil {
+				return newRes, err
+			}
+			newSeries := NewSeries(e.RefID, labels, c.Len())
+			for i := 0; i < c.Len(); i++ {
+				t, f := c.GetPoint(i)
+				switch {
+				case f == nil || math.IsNaN(*f):
+					newSeries.SetPoint(i, t, nil)
+				case *f != 0:
+					newSeries.SetPoint(i, t, aAt(t))
+				default:
+					newSeries.SetPoint(i, t, bAt(t))
+				}
+			}
+			newRes.Values = append(newRes.Values, newSeries)
+		case NoData:
+			newRes.Values = append(newRes.Values, c.New())
+		default:
+			return newRes, fmt.Errorf("if condition can not be of type %v", c.Type())
+		}
+	}
+	return newRes, nil
+}
+
+// matchValue returns the value of res that matches the labels, or nil if there is none.
+func matchValue(labels data.Labels, res Results) Value {
+	if len(res.Values) == 1 {
+		return res.Values[0]
+	}
+	for _, v := range res.Values {
+		l := v.GetLabels()
+		if labels.Contains(l) || l.Contains(labels) {
+			return v
+		}
+	}
+	return nil
+}
+
+// selectValue returns the value selected by a number condition with the labels.
+func selectValue(e *State, labels data.Labels, selected Value) (Value, error) {
+	if selected != nil && len(labels) == 0 {
+		labels = selected.GetLabels()
+	}
+	switch v := selected.(type) {
+	case nil:
+		return NewNumber(e.RefID, labels), nil
+	case Scalar:
+		n := NewNumber(e.RefID, labels)
+		n.SetValue(v.GetFloat64Value())
+		return n, nil
+	case Number:
+		n := NewNumber(e.RefID, labels)
+		n.SetValue(v.GetFloat64Value())
+		return n, nil
+	case Series:
+		newSeries := NewSeries(e.RefID, labels, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			t, f := v.GetPoint(i)
+			newSeries.SetPoint(i, t, f)
+		}
+		return newSeries, nil
+	case NoData:
+		return v.New(), nil
+	default:
+		return nil, fmt.Errorf("if can not select a value of type %v", v.Type())
+	}
+}
+
+// valueAt returns a function that returns the value of v at a time. For a time series, it is the value
+// of the point at that time, or null if there is no point at that time.
+func valueAt(v Value) (func(t time.Time) *float64, error) {
+	switch v := v.(type) {
+	case nil, NoData:
+		return func(time.Time) *float64 { return nil }, nil
+	case Scalar:
+		return func(time.Time) *float64 { return v.GetFloat64Value() }, nil
+	case Number:
+		return func(time.Time) *float64 { return v.GetFloat64Value() }, nil
+	case Series:
+		points := make(map[int64]*float64, v.Len())
+		for i := 0; i < v.Len(); i++ {
+			t, f := v.GetPoint(i)
+			points[t.UnixNano()] = f
+		}
+		return func(t time.Time) *float64 { return points[t.UnixNano()] }, nil
+	default:
+		return nil, fmt.Errorf("if can not select a value of type %v", v.Type())
+	}
+}
+
+// scalarArg returns the value of a scalar function argument.
+func scalarArg(name string, res Results) (float64, error) {
+	if len(res.Values) != 1 {
+		return 0, fmt.Errorf("%s expects a single number argument", name)
+	}
+	s, ok := res.Values[0].(Scalar)
+	if !ok {
+		return 0, fmt.Errorf("%s expects a number argument, got %v", name, res.Values[0].Type())
+	}
+	f := s.GetFloat64Value()
+	if f == nil {
+		return 0, fmt.Errorf("%s expects a number argument, got null", name)
+	}
+	return *f, nil
+}
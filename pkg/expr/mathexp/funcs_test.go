@@ -5,6 +5,8 @@ import (
 	"testing"
 	"time"
 
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
 	"github.com/grafana/grafana/pkg/infra/tracing"
 	"github.com/stretchr/testify/require"
 )
@@ -140,3 +142,336 @@ func TestIsNumberFunc(t *testing.T) {
 		})
 	}
 }
+
+func TestSeriesFuncs(t *testing.T) {
+	counter := Vars{
+		"A": resultValuesNoErr(
+			makeSeries("", data.Labels{"host": "a"},
+				tp{time.Unix(0, 0), float64Pointer(10)},
+				tp{time.Unix(10, 0), float64Pointer(30)},
+				tp{time.Unix(20, 0), float64Pointer(60)},
+				tp{time.Unix(30, 0), float64Pointer(20)},
+			),
+		),
+	}
+
+	var tests = []struct {
+		name    string
+		expr    string
+		vars    Vars
+		results Results
+	}{
+		{
+			name: "moving_avg averages the points in the window",
+			expr: "moving_avg($A, 20s)",
+			vars: counter,
+			results: resultValuesNoErr(
+				makeSeries("", data.Labels{"host": "a"},
+					tp{time.Unix(0, 0), float64Pointer(10)},
+					tp{time.Unix(10, 0), float64Pointer(20)},
+					tp{time.Unix(20, 0), float64Pointer(45)},
+					tp{time.Unix(30, 0), float64Pointer(40)},
+				),
+			),
+		},
+		{
+			name: "delta returns the difference to the previous point",
+			expr: "delta($A)",
+			vars: counter,
+			results: resultValuesNoErr(
+				makeSeries("", data.Labels{"host": "a"},
+					tp{time.Unix(10, 0), float64Pointer(20)},
+					tp{time.Unix(20, 0), float64Pointer(30)},
+					tp{time.Unix(30, 0), float64Pointer(-40)},
+				),
+			),
+		},
+		{
+			name: "derivative returns the change per second",
+			expr: "derivative($A)",
+			vars: counter,
+			results: resultValuesNoErr(
+				makeSeries("", data.Labels{"host": "a"},
+					tp{time.Unix(10, 0), float64Pointer(2)},
+					tp{time.Unix(20, 0), float64Pointer(3)},
+					tp{time.Unix(30, 0), float64Pointer(-4)},
+				),
+			),
+		},
+		{
+			name: "rate handles counter resets",
+			expr: "rate($A)",
+			vars: counter,
+			results: resultValuesNoErr(
+				makeSeries("", data.Labels{"host": "a"},
+					tp{time.Unix(10, 0), float64Pointer(2)},
+					tp{time.Unix(20, 0), float64Pointer(3)},
+					tp{time.Unix(30, 0), float64Pointer(2)},
+				),
+			),
+		},
+		{
+			name: "cumulative_sum keeps nulls",
+			expr: "cumulative_sum($A)",
+			vars: Vars{
+				"A": resultValuesNoErr(
+					makeSeries("", nil,
+						tp{time.Unix(0, 0), float64Pointer(1)},
+						tp{time.Unix(10, 0), nil},
+						tp{time.Unix(20, 0), float64Pointer(2)},
+					),
+				),
+			},
+			results: resultValuesNoErr(
+				makeSeries("", nil,
+					tp{time.Unix(0, 0), float64Pointer(1)},
+					tp{time.Unix(10, 0), nil},
+					tp{time.Unix(20, 0), float64Pointer(3)},
+				),
+			),
+		},
+		{
+			name: "shift moves points forward in time",
+			expr: "shift($A, 1m)",
+			vars: Vars{
+				"A": resultValuesNoErr(makeSeries("", nil, tp{time.Unix(0, 0), float64Pointer(1)})),
+			},
+			results: resultValuesNoErr(makeSeries("", nil, tp{time.Unix(60, 0), float64Pointer(1)})),
+		},
+		{
+			name: "offset with a negative duration moves points back in time",
+			expr: "offset($A, -1m)",
+			vars: Vars{
+				"A": resultValuesNoErr(makeSeries("", nil, tp{time.Unix(60, 0), float64Pointer(1)})),
+			},
+			results: resultValuesNoErr(makeSeries("", nil, tp{time.Unix(0, 0), float64Pointer(1)})),
+		},
+		{
+			name: "clamp_min and clamp_max bound series values",
+			expr: "clamp_max(clamp_min($A, 20), 50)",
+			vars: counter,
+			results: resultValuesNoErr(
+				makeSeries("", data.Labels{"host": "a"},
+					tp{time.Unix(0, 0), float64Pointer(20)},
+					tp{time.Unix(10, 0), float64Pointer(30)},
+					tp{time.Unix(20, 0), float64Pointer(50)},
+					tp{time.Unix(30, 0), float64Pointer(20)},
+				),
+			),
+		},
+		{
+			name:    "clamp_min on number",
+			expr:    "clamp_min($A, 0)",
+			vars:    Vars{"A": resultValuesNoErr(makeNumber("", nil, float64Pointer(-3)))},
+			results: resultValuesNoErr(makeNumber("", nil, float64Pointer(0))),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := New(tt.expr)
+			require.NoError(t, err)
+			res, err := e.Execute("", tt.vars, tracing.InitializeTracerForTest())
+			require.NoError(t, err)
+			require.Equal(t, tt.results, res)
+		})
+	}
+
+	t.Run("series functions fail on numbers", func(t *testing.T) {
+		e, err := New("rate($A)")
+		require.NoError(t, err)
+		_, err = e.Execute("", Vars{"A": resultValuesNoErr(makeNumber("", nil, float64Pointer(1)))}, tracing.InitializeTracerForTest())
+		require.Error(t, err)
+	})
+
+	t.Run("derivative and rate are NaN for points at the time of the previous point", func(t *testing.T) {
+		vars := Vars{
+			"A": resultValuesNoErr(
+				makeSeries("", nil,
+					tp{time.Unix(0, 0), float64Pointer(0)},
+					tp{time.Unix(10, 0), float64Pointer(10)},
+					tp{time.Unix(10, 0), float64Pointer(20)},
+					tp{time.Unix(20, 0), float64Pointer(30)},
+				),
+			),
+		}
+		for _, expr := range []string{"derivative($A)", "rate($A)"} {
+			e, err := New(expr)
+			require.NoError(t, err)
+			res, err := e.Execute("", vars, tracing.InitializeTracerForTest())
+			require.NoError(t, err)
+			s := res.Values[0].(Series)
+			require.Equal(t, 3, s.Len(), expr)
+			require.Equal(t, 1.0, *s.GetValue(0), expr)
+			require.True(t, math.IsNaN(*s.GetValue(1)), expr)
+			require.Equal(t, 1.0, *s.GetValue(2), expr)
+		}
+	})
+
+	t.Run("durations are only valid as function arguments", func(t *testing.T) {
+		for _, expr := range []string{"5m", "$A + 5m", "moving_avg($A, 5)", "abs(5m)"} {
+			_, err := New(expr)
+			require.Error(t, err, expr)
+		}
+	})
+}
+
+func TestIfFunc(t *testing.T) {
+	series := Vars{
+		"A": resultValuesNoErr(
+			makeSeries("", data.Labels{"host": "a"},
+				tp{time.Unix(0, 0), float64Pointer(1)},
+				tp{time.Unix(10, 0), float64Pointer(8)},
+				tp{time.Unix(20, 0), nil},
+			),
+		),
+	}
+	numbers := Vars{
+		"A": resultValuesNoErr(
+			makeNumber("", data.Labels{"host": "a"}, float64Pointer(1)),
+			makeNumber("", data.Labels{"host": "b"}, float64Pointer(0)),
+		),
+		"B": resultValuesNoErr(
+			makeNumber("", data.Labels{"host": "b", "dc": "eu"}, float64Pointer(20)),
+			makeNumber("", data.Labels{"host": "a", "dc": "eu"}, float64Pointer(10)),
+		),
+	}
+
+	var tests = []struct {
+		name    string
+		expr    string
+		vars    Vars
+		results Results
+	}{
+		{
+			name: "masks the points of a series",
+			expr: "if($A > 5, $A, null())",
+			vars: series,
+			results: resultValuesNoErr(
+				makeSeries("", data.Labels{"host": "a"},
+					tp{time.Unix(0, 0), nil},
+					tp{time.Unix(10, 0), float64Pointer(8)},
+					tp{time.Unix(20, 0), nil},
+				),
+			),
+		},
+		{
+			name: "selects the matching values for numbers",
+			expr: "if($A, $B, -1)",
+			vars: numbers,
+			results: resultValuesNoErr(
+				makeNumber("", data.Labels{"host": "a"}, float64Pointer(10)),
+				makeNumber("", data.Labels{"host": "b"}, float64Pointer(-1)),
+			),
+		},
+		{
+			name:    "selects all values with a scalar condition",
+			expr:    "if(0, $A, $B)",
+			vars:    numbers,
+			results: numbers["B"],
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := New(tt.expr)
+			require.NoError(t, err)
+			res, err := e.Execute("", tt.vars, tracing.InitializeTracerForTest())
+			require.NoError(t, err)
+			require.Equal(t, tt.results, res)
+		})
+	}
+
+	t.Run("requires three arguments", func(t *testing.T) {
+		_, err := New("if($A, 1)")
+		require.Error(t, err)
+	})
+}
+
+func TestLabelFuncs(t *testing.T) {
+	vars := Vars{
+		"A": resultValuesNoErr(
+			makeNumber("", data.Labels{"instance": "web01:9090", "dc": "eu", "env": "prod"}, float64Pointer(1)),
+		),
+		"B": resultValuesNoErr(
+			makeSeries("", data.Labels{"instance": "db01:5432"}, tp{time.Unix(0, 0), float64Pointer(2)}),
+		),
+	}
+
+	var tests = []struct {
+		name    string
+		expr    string
+		results Results
+	}{
+		{
+			name: "label_replace sets the destination from the capture groups",
+			expr: `label_replace($A, "host", "$1", "instance", "(.*):.*")`,
+			results: resultValuesNoErr(
+				makeNumber("", data.Labels{"instance": "web01:9090", "host": "web01", "dc": "eu", "env": "prod"}, float64Pointer(1)),
+			),
+		},
+		{
+			name: "label_replace does not change values that do not match",
+			expr: `label_replace($A, "host", "$1", "instance", "web")`,
+			results: resultValuesNoErr(
+				makeNumber("", data.Labels{"instance": "web01:9090", "dc": "eu", "env": "prod"}, float64Pointer(1)),
+			),
+		},
+		{
+			name: "label_replace with an empty replacement removes the destination",
+			expr: `label_replace($A, "env", "", "env", ".*")`,
+			results: resultValuesNoErr(
+				makeNumber("", data.Labels{"instance": "web01:9090", "dc": "eu"}, float64Pointer(1)),
+			),
+		},
+		{
+			name: "label_join joins the source labels",
+			expr: `label_join($A, "key", "/", "dc", "env")`,
+			results: resultValuesNoErr(
+				makeNumber("", data.Labels{"instance": "web01:9090", "dc": "eu", "env": "prod", "key": "eu/prod"}, float64Pointer(1)),
+			),
+		},
+		{
+			name: "label_drop removes labels of series",
+			expr: `label_drop($B, "instance", "missing")`,
+			results: resultValuesNoErr(
+				makeSeries("", data.Labels{}, tp{time.Unix(0, 0), float64Pointer(2)}),
+			),
+		},
+		{
+			name: "label functions normalise labels before a binary operation",
+			expr: `label_drop($A, "instance", "env") + label_replace($B, "dc", "eu", "instance", "db.*")`,
+			results: resultValuesNoErr(
+				makeSeries("", data.Labels{"instance": "db01:5432", "dc": "eu"}, tp{time.Unix(0, 0), float64Pointer(3)}),
+			),
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			e, err := New(tt.expr)
+			require.NoError(t, err)
+			res, err := e.Execute("", vars, tracing.InitializeTracerForTest())
+			require.NoError(t, err)
+			require.Equal(t, tt.results, res)
+		})
+	}
+
+	t.Run("do not modify the input labels", func(t *testing.T) {
+		e, err := New(`label_drop($A, "dc")`)
+		require.NoError(t, err)
+		_, err = e.Execute("", vars, tracing.InitializeTracerForTest())
+		require.NoError(t, err)
+		require.Equal(t, "eu", vars["A"].Values[0].GetLabels()["dc"])
+	})
+
+	t.Run("fail to parse invalid arguments", func(t *testing.T) {
+		for _, expr := range []string{
+			`label_replace($A, "host", "$1", "instance", "(")`,
+			`label_replace($A, "", "$1", "instance", ".*")`,
+			`label_join($A, "key", "/")`,
+			`label_drop($A)`,
+			`label_drop($A, 1)`,
+		} {
+			_, err := New(expr)
+			require.Error(t, err, expr)
+		}
+	})
+}
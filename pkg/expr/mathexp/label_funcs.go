@@ -0,0 +1,119 @@
+package mathexp
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp/parse"
+)
+
+// perLabels returns a copy of each value in varSet with the labels returned by labelsF, which is passed
+// a copy of the labels of the value. Scalars, which have no labels, and NoData are passed through.
+func perLabels(e *State, varSet Results, labelsF func(l data.Labels) data.Labels) Results {
+	newRes := Results{}
+	for _, res := range varSet.Values {
+		var newVal Value
+		switch v := res.(type) {
+		case Number:
+			n := NewNumber(e.RefID, labelsF(v.GetLabels().Copy()))
+			n.SetValue(v.GetFloat64Value())
+			newVal = n
+		case Series:
+			newSeries := NewSeries(e.RefID, labelsF(v.GetLabels().Copy()), v.Len())
+			for i := 0; i < v.Len(); i++ {
+				t, f := v.GetPoint(i)
+				newSeries.SetPoint(i, t, f)
+			}
+			newVal = newSeries
+		default:
+			newVal = res
+		}
+		newRes.Values = append(newRes.Values, newVal)
+	}
+	return newRes
+}
+
+// labelReplace sets the label dst of each value in varSet to the replacement if the value of the label src
+// matches the regex. The regex is anchored, and the replacement may refer to its capture groups with $1 etc.
+// If the replacement is empty, dst is removed. Values where src does not match are not changed.
+func labelReplace(e *State, varSet Results, dst, replacement, src, regex string) (Results, error) {
+	re, err := anchoredRegexp(regex)
+	if err != nil {
+		return Results{}, err
+	}
+	return perLabels(e, varSet, func(l data.Labels) data.Labels {
+		value := l[src]
+		match := re.FindStringSubmatchIndex(value)
+		if match == nil {
+			return l
+		}
+		res := string(re.ExpandString(nil, replacement, value, match))
+		if res == "" {
+			delete(l, dst)
+			return l
+		}
+		l[dst] = res
+		return l
+	}), nil
+}
+
+// labelJoin sets the label dst of each value in varSet to the values of the labels src, in order, joined by
+// the separator. If the result is empty, dst is removed.
+func labelJoin(e *State, varSet Results, dst, separator string, src ...string) (Results, error) {
+	return perLabels(e, varSet, func(l data.Labels) data.Labels {
+		values := make([]string, len(src))
+		for i, name := range src {
+			values[i] = l[name]
+		}
+		res := strings.Join(values, separator)
+		if res == "" {
+			delete(l, dst)
+			return l
+		}
+		l[dst] = res
+		return l
+	}), nil
+}
+
+// labelDrop removes the labels from each value in varSet.
+func labelDrop(e *State, varSet Results, labels ...string) (Results, error) {
+	return perLabels(e, varSet, func(l data.Labels) data.Labels {
+		for _, name := range labels {
+			delete(l, name)
+		}
+		return l
+	}), nil
+}
+
+// anchoredRegexp compiles the regex so that it must match the whole value.
+func anchoredRegexp(regex string) (*regexp.Regexp, error) {
+	re, err := regexp.Compile("^(?:" + regex + ")$")
+	if err != nil {
+		return nil, fmt.Errorf("invalid regular expression %q: %w", regex, err)
+	}
+	return re, nil
+}
+
+// checkLabelReplace validates the regex and the destination label of label_replace at parse time.
+func checkLabelReplace(_ *parse.Tree, f *parse.FuncNode) error {
+	if dst, ok := f.Args[1].(*parse.StringNode); ok && dst.Text == "" {
+		return fmt.Errorf("parse: label_replace requires a destination label")
+	}
+	if regex, ok := f.Args[4].(*parse.StringNode); ok {
+		if _, err := anchoredRegexp(regex.Text); err != nil {
+			return fmt.Errorf("parse: label_replace: %w", err)
+		}
+	}
+	return nil
+}
+
+// checkLabelJoin validates the destination label of label_join at parse time.
+func checkLabelJoin(_ *parse.Tree, f *parse.FuncNode) error {
+	if dst, ok := f.Args[1].(*parse.StringNode); ok && dst.Text == "" {
+		return fmt.Errorf("parse: label_join requires a destination label")
+	}
+	return nil
+}
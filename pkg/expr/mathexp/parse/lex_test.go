@@ -33,6 +33,7 @@ var itemName = map[itemType]string{
 	itemRightParen: ")",
 	itemString:     "string",
 	itemFunc:       "func",
+	itemDuration:   "duration",
 }
 
 func (i itemType) String() string {
@@ -113,6 +114,34 @@ var lexTests = []lexTest{
 		{itemVar, 0, "$A"},
 		tEOF,
 	}},
+	{"durations", "5m 1h30m 2d 500ms", []item{
+		{itemDuration, 0, "5m"},
+		{itemDuration, 0, "1h30m"},
+		{itemDuration, 0, "2d"},
+		{itemDuration, 0, "500ms"},
+		tEOF,
+	}},
+	{"function with duration", "shift($A, 1h)", []item{
+		{itemFunc, 0, "shift"},
+		{itemLeftParen, 0, "("},
+		{itemVar, 0, "$A"},
+		{itemComma, 0, ","},
+		{itemDuration, 0, "1h"},
+		{itemRightParen, 0, ")"},
+		tEOF,
+	}},
+	{"matching with label names", "$A + on(host, dc1) $B", []item{
+		{itemVar, 0, "$A"},
+		tPlus,
+		{itemFunc, 0, "on"},
+		{itemLeftParen, 0, "("},
+		{itemFunc, 0, "host"},
+		{itemComma, 0, ","},
+		{itemFunc, 0, "dc1"},
+		{itemRightParen, 0, ")"},
+		{itemVar, 0, "$B"},
+		tEOF,
+	}},
 	// errors
 	{"unclosed quote", "\"", []item{
 		{itemError, 0, "unterminated string"},
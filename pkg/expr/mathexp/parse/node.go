@@ -9,6 +9,11 @@ package parse
 import (
 	"fmt"
 	"strconv"
+	"strings"
+	"time"
+	"unicode"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
 )
 
 // A Node is an element in the parse tree. The interface is trivial.
@@ -62,6 +67,10 @@ const (
 	NodeNumber
 	// NodeVar is variable: $A
 	NodeVar
+	// NodeDuration is a duration constant: 5m
+	NodeDuration
+	// NodeAggregate is an aggregation: sum by(host) ($A)
+	NodeAggregate
 )
 
 // String returns the string representation of the NodeType
@@ -79,6 +88,10 @@ func (t NodeType) String() string {
 		return "NodeNumber"
 	case NodeVar:
 		return "NodeVar"
+	case NodeDuration:
+		return "NodeDuration"
+	case NodeAggregate:
+		return "NodeAggregate"
 	default:
 		return "NodeUnknown"
 	}
@@ -165,12 +178,12 @@ func (f *FuncNode) StringAST() string {
 func (f *FuncNode) Check(t *Tree) error {
 	if len(f.Args) < len(f.F.Args) {
 		return fmt.Errorf("parse: not enough arguments for %s", f.Name)
-	} else if len(f.Args) > len(f.F.Args) {
+	} else if len(f.Args) > len(f.F.Args) && !f.F.Variadic {
 		return fmt.Errorf("parse: too many arguments for %s", f.Name)
 	}
 
 	for i, arg := range f.Args {
-		funcType := f.F.Args[i]
+		funcType := f.F.Args[min(i, len(f.F.Args)-1)]
 		argType := arg.Return()
 		// if funcType == TypeNumberSet && argType == TypeScalar {
 		// 	argType = TypeNumberSet
@@ -261,6 +274,59 @@ func (n *ScalarNode) Return() ReturnType {
 	return TypeScalar
 }
 
+// DurationNode holds a duration constant such as 5m or 1h30m.
+type DurationNode struct {
+	NodeType
+	Pos
+	Duration time.Duration // The parsed duration.
+	Text     string        // The original textual representation from the input.
+}
+
+func newDuration(pos Pos, text string) (*DurationNode, error) {
+	d, err := gtime.ParseDuration(text)
+	if err != nil {
+		return nil, fmt.Errorf("illegal duration syntax: %q", text)
+	}
+	return &DurationNode{NodeType: NodeDuration, Pos: pos, Duration: d, Text: text}, nil
+}
+
+// String returns the string representation of the DurationNode so it fulfills the Node interface.
+func (d *DurationNode) String() string {
+	return d.Text
+}
+
+// StringAST returns the string representation of abstract syntax tree of the DurationNode so it fulfills the Node interface.
+func (d *DurationNode) StringAST() string {
+	return d.String()
+}
+
+// Check performs parse time checking on the DurationNode so it fulfills the Node interface.
+func (d *DurationNode) Check(*Tree) error {
+	return nil
+}
+
+// Return returns the result type of the DurationNode so it fulfills the Node interface.
+func (d *DurationNode) Return() ReturnType {
+	return TypeDuration
+}
+
+// DurationValue returns the duration of a duration literal, which may be negated with a unary minus.
+// It returns false if the node is not a duration.
+func DurationValue(n Node) (time.Duration, bool) {
+	switch n := n.(type) {
+	case *DurationNode:
+		return n.Duration, true
+	case *UnaryNode:
+		if n.OpStr != "-" {
+			return 0, false
+		}
+		if d, ok := DurationValue(n.Arg); ok {
+			return -d, true
+		}
+	}
+	return 0, false
+}
+
 // StringNode holds a string constant. The value has been "unquoted".
 type StringNode struct {
 	NodeType
@@ -293,6 +359,21 @@ func (s *StringNode) Return() ReturnType {
 	return TypeString
 }
 
+// VectorMatching holds the labels the arguments of a binary operation are matched on.
+type VectorMatching struct {
+	// On is true to match only the Labels, and false to match all the labels but the Labels.
+	On     bool
+	Labels []string
+}
+
+// String returns the string representation of the VectorMatching.
+func (m *VectorMatching) String() string {
+	if m.On {
+		return "on" + labelsString(m.Labels)
+	}
+	return "ignoring" + labelsString(m.Labels)
+}
+
 // BinaryNode holds two arguments and an operator.
 type BinaryNode struct {
 	NodeType
@@ -300,6 +381,7 @@ type BinaryNode struct {
 	Args     [2]Node
 	Operator item
 	OpStr    string
+	Matching *VectorMatching // nil unless on() or ignoring() follows the operator
 }
 
 func newBinary(operator item, arg1, arg2 Node) *BinaryNode {
@@ -308,16 +390,27 @@ func newBinary(operator item, arg1, arg2 Node) *BinaryNode {
 
 // String returns the string representation of the BinaryNode so it fulfills the Node interface.
 func (b *BinaryNode) String() string {
+	if b.Matching != nil {
+		return fmt.Sprintf("%s %s %s %s", b.Args[0], b.Operator.val, b.Matching, b.Args[1])
+	}
 	return fmt.Sprintf("%s %s %s", b.Args[0], b.Operator.val, b.Args[1])
 }
 
 // StringAST returns the string representation of abstract syntax tree of the BinaryNode so it fulfills the Node interface.
 func (b *BinaryNode) StringAST() string {
+	if b.Matching != nil {
+		return fmt.Sprintf("%s %s(%s, %s)", b.Operator.val, b.Matching, b.Args[0], b.Args[1])
+	}
 	return fmt.Sprintf("%s(%s, %s)", b.Operator.val, b.Args[0], b.Args[1])
 }
 
 // Check performs parse time checking on the BinaryNode so it fulfills the Node interface.
 func (b *BinaryNode) Check(t *Tree) error {
+	for _, arg := range b.Args {
+		if arg.Return() == TypeDuration {
+			return fmt.Errorf("parse: type error in %s, durations can only be used as function arguments", b)
+		}
+	}
 	return nil
 }
 
@@ -359,6 +452,11 @@ func (u *UnaryNode) Check(t *Tree) error {
 	switch rt := u.Arg.Return(); rt {
 	case TypeNumberSet, TypeSeriesSet, TypeScalar:
 		return u.Arg.Check(t)
+	case TypeDuration:
+		if u.OpStr != "-" {
+			return fmt.Errorf(`parse: type error in %s, expected "number", got %s`, u, rt)
+		}
+		return u.Arg.Check(t)
 	default:
 		return fmt.Errorf(`parse: type error in %s, expected "number", got %s`, u, rt)
 	}
@@ -369,6 +467,86 @@ func (u *UnaryNode) Return() ReturnType {
 	return u.Arg.Return()
 }
 
+// aggregations are the operations of an AggregateNode.
+var aggregations = map[string]bool{
+	"sum":   true,
+	"avg":   true,
+	"max":   true,
+	"min":   true,
+	"count": true,
+}
+
+// IsAggregation returns true if name is the operation of an aggregation rather than a function.
+func IsAggregation(name string) bool {
+	return aggregations[name]
+}
+
+// AggregateNode holds an aggregation of the values of its argument that have the same grouping labels.
+type AggregateNode struct {
+	NodeType
+	Pos
+	Op       string   // sum, avg, max, min or count
+	Grouping []string // The labels of by(), all values are aggregated together if empty.
+	Arg      Node
+}
+
+func newAggregate(pos Pos, op string) *AggregateNode {
+	return &AggregateNode{NodeType: NodeAggregate, Pos: pos, Op: op}
+}
+
+// String returns the string representation of the AggregateNode so it fulfills the Node interface.
+func (a *AggregateNode) String() string {
+	if len(a.Grouping) > 0 {
+		return fmt.Sprintf("%s by%s (%s)", a.Op, labelsString(a.Grouping), a.Arg)
+	}
+	return fmt.Sprintf("%s(%s)", a.Op, a.Arg)
+}
+
+// StringAST returns the string representation of abstract syntax tree of the AggregateNode so it fulfills the Node interface.
+func (a *AggregateNode) StringAST() string {
+	if len(a.Grouping) > 0 {
+		return fmt.Sprintf("%s by%s (%s)", a.Op, labelsString(a.Grouping), a.Arg.StringAST())
+	}
+	return fmt.Sprintf("%s(%s)", a.Op, a.Arg.StringAST())
+}
+
+// Check performs parse time checking on the AggregateNode so it fulfills the Node interface.
+func (a *AggregateNode) Check(t *Tree) error {
+	switch rt := a.Arg.Return(); rt {
+	case TypeNumberSet, TypeSeriesSet, TypeVariantSet:
+		return a.Arg.Check(t)
+	default:
+		return fmt.Errorf("parse: type error in %s, expected %v or %v, got %v", a, TypeNumberSet, TypeSeriesSet, rt)
+	}
+}
+
+// Return returns the result type of the AggregateNode so it fulfills the Node interface.
+func (a *AggregateNode) Return() ReturnType {
+	return a.Arg.Return()
+}
+
+// labelsString returns the labels in parentheses, quoting the labels that are not names.
+func labelsString(labels []string) string {
+	quoted := make([]string, len(labels))
+	for i, l := range labels {
+		quoted[i] = l
+		if !isLabelName(l) {
+			quoted[i] = strconv.Quote(l)
+		}
+	}
+	return "(" + strings.Join(quoted, ", ") + ")"
+}
+
+// isLabelName returns true if the lexer scans l as a single name.
+func isLabelName(l string) bool {
+	for i, r := range l {
+		if !(unicode.IsLetter(r) || (i > 0 && (r == '_' || unicode.IsDigit(r)))) {
+			return false
+		}
+	}
+	return l != ""
+}
+
 // Walk invokes f on n and sub-nodes of n.
 func Walk(n Node, f func(Node)) {
 	f(n)
@@ -380,10 +558,12 @@ func Walk(n Node, f func(Node)) {
 		for _, a := range n.Args {
 			Walk(a, f)
 		}
-	case *ScalarNode, *StringNode:
+	case *ScalarNode, *StringNode, *DurationNode:
 		// Ignore since these node types have no sub nodes.
 	case *UnaryNode:
 		Walk(n.Arg, f)
+	case *AggregateNode:
+		Walk(n.Arg, f)
 	default:
 		panic(fmt.Errorf("other type: %T", n))
 	}
@@ -407,6 +587,8 @@ const (
 	TypeNoData
 	// TypeTableData is a tabular data response.
 	TypeTableData
+	// TypeDuration is a duration constant.
+	TypeDuration
 )
 
 // String returns a string representation of the ReturnType.
@@ -426,6 +608,8 @@ func (f ReturnType) String() string {
 		return "noData"
 	case TypeTableData:
 		return "tableData"
+	case TypeDuration:
+		return "duration"
 	default:
 		return "unknown"
 	}
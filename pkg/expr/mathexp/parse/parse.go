@@ -34,6 +34,7 @@ type Func struct {
 	Return        ReturnType
 	F             interface{}
 	VariantReturn bool
+	Variadic      bool // the last argument may be repeated
 	Check         func(*Tree, *FuncNode) error
 }
 
@@ -162,19 +163,26 @@ func (t *Tree) parse() {
 	if err := t.Root.Check(t); err != nil {
 		t.error(err)
 	}
+	if t.Root.Return() == TypeDuration {
+		t.errorf("a duration can only be used as a function argument")
+	}
 }
 
 /* Grammar:
-O -> A {"||" A}
-A -> C {"&&" C}
-C -> P {( "==" | "!=" | ">" | ">=" | "<" | "<=") P}
-P -> M {( "+" | "-" ) M}
-M -> E {( "*" | "/" ) F}
-E -> F {( "**" ) F}
+O -> A {"||" [match] A}
+A -> C {"&&" [match] C}
+C -> P {( "==" | "!=" | ">" | ">=" | "<" | "<=") [match] P}
+P -> M {( "+" | "-" ) [match] M}
+M -> E {( "*" | "/" ) [match] F}
+E -> F {( "**" ) [match] F}
 F -> v | "(" O ")" | "!" O | "-" O
-v -> number | func(..) | queryVar
+v -> number | duration | func(..) | agg(..) | queryVar
 Func -> name "(" param {"," param} ")"
-param -> number | "string" | queryVar
+param -> number | duration | "string" | queryVar
+Agg -> ( "sum" | "avg" | "max" | "min" | "count" ) ["by" labels] "(" O ")"
+match -> ( "on" | "ignoring" ) labels
+labels -> "(" label {"," label} ")"
+label -> name | "string"
 */
 
 // expr:
@@ -185,7 +193,7 @@ func (t *Tree) O() Node {
 	for {
 		switch t.peek().typ {
 		case itemOr:
-			n = newBinary(t.next(), n, t.A())
+			n = t.binary(t.next(), n, t.A)
 		default:
 			return n
 		}
@@ -198,7 +206,7 @@ func (t *Tree) A() Node {
 	for {
 		switch t.peek().typ {
 		case itemAnd:
-			n = newBinary(t.next(), n, t.C())
+			n = t.binary(t.next(), n, t.C)
 		default:
 			return n
 		}
@@ -211,7 +219,7 @@ func (t *Tree) C() Node {
 	for {
 		switch t.peek().typ {
 		case itemEq, itemNotEq, itemGreater, itemGreaterEq, itemLess, itemLessEq:
-			n = newBinary(t.next(), n, t.P())
+			n = t.binary(t.next(), n, t.P)
 		default:
 			return n
 		}
@@ -224,7 +232,7 @@ func (t *Tree) P() Node {
 	for {
 		switch t.peek().typ {
 		case itemPlus, itemMinus:
-			n = newBinary(t.next(), n, t.M())
+			n = t.binary(t.next(), n, t.M)
 		default:
 			return n
 		}
@@ -237,7 +245,7 @@ func (t *Tree) M() Node {
 	for {
 		switch t.peek().typ {
 		case itemMult, itemDiv, itemMod:
-			n = newBinary(t.next(), n, t.E())
+			n = t.binary(t.next(), n, t.E)
 		default:
 			return n
 		}
@@ -250,17 +258,35 @@ func (t *Tree) E() Node {
 	for {
 		switch t.peek().typ {
 		case itemPow:
-			n = newBinary(t.next(), n, t.F())
+			n = t.binary(t.next(), n, t.F)
 		default:
 			return n
 		}
 	}
 }
 
+// binary parses the optional match of a binary operation, after its operator, and its right argument.
+func (t *Tree) binary(operator item, left Node, right func() Node) Node {
+	matching := t.match()
+	n := newBinary(operator, left, right())
+	n.Matching = matching
+	return n
+}
+
+// match is ( "on" | "ignoring" ) labels in the grammar. It returns nil if the next token is not a match.
+func (t *Tree) match() *VectorMatching {
+	token := t.peek()
+	if token.typ != itemFunc || (token.val != "on" && token.val != "ignoring") {
+		return nil
+	}
+	t.next()
+	return &VectorMatching{On: token.val == "on", Labels: t.labels(token.val)}
+}
+
 // F is v | "(" O ")" | "!" O | "-" O in the grammar.
 func (t *Tree) F() Node {
 	switch token := t.peek(); token.typ {
-	case itemNumber, itemFunc, itemVar:
+	case itemNumber, itemDuration, itemFunc, itemVar:
 		return t.v()
 	case itemNot, itemMinus:
 		return newUnary(t.next(), t.F())
@@ -275,7 +301,7 @@ func (t *Tree) F() Node {
 	return nil
 }
 
-// V is number | func(..) | queryVar in the grammar.
+// V is number | duration | func(..) | agg(..) | queryVar in the grammar.
 func (t *Tree) v() Node {
 	switch token := t.next(); token.typ {
 	case itemNumber:
@@ -284,8 +310,17 @@ func (t *Tree) v() Node {
 			t.error(err)
 		}
 		return n
+	case itemDuration:
+		d, err := newDuration(token.pos, token.val)
+		if err != nil {
+			t.error(err)
+		}
+		return d
 	case itemFunc:
 		t.backup()
+		if IsAggregation(token.val) {
+			return t.Agg()
+		}
 		return t.Func()
 	case itemVar:
 		t.backup()
@@ -329,12 +364,59 @@ func (t *Tree) Func() (f *FuncNode) {
 				t.errorf("Unquoting error: %s", err)
 			}
 			f.append(newString(token.pos, token.val, s))
+		case itemComma:
+			if len(f.Args) == 0 {
+				t.unexpected(token, "func")
+			}
 		case itemRightParen:
 			return
 		}
 	}
 }
 
+// Agg parses an AggregateNode.
+func (t *Tree) Agg() *AggregateNode {
+	token := t.next()
+	a := newAggregate(token.pos, token.val)
+	if next := t.peek(); next.typ == itemFunc && next.val == "by" {
+		t.next()
+		a.Grouping = t.labels("by")
+	}
+	t.expect(itemLeftParen, "aggregation")
+	a.Arg = t.O()
+	t.expect(itemRightParen, "aggregation")
+	return a
+}
+
+// labels is "(" label {"," label} ")" in the grammar.
+func (t *Tree) labels(context string) []string {
+	t.expect(itemLeftParen, context)
+	var labels []string
+	for {
+		switch token := t.next(); token.typ {
+		case itemFunc:
+			labels = append(labels, token.val)
+		case itemString:
+			s, err := strconv.Unquote(token.val)
+			if err != nil {
+				t.errorf("Unquoting error: %s", err)
+			}
+			labels = append(labels, s)
+		case itemComma:
+			if len(labels) == 0 {
+				t.unexpected(token, context)
+			}
+		case itemRightParen:
+			if len(labels) == 0 {
+				t.errorf("%s requires at least one label", context)
+			}
+			return labels
+		default:
+			t.unexpected(token, context)
+		}
+	}
+}
+
 // GetFunction gets a parsed Func from the functions available on the tree's func property.
 func (t *Tree) GetFunction(name string) (v Func, ok bool) {
 	for _, funcMap := range t.funcs {
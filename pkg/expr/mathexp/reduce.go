@@ -15,18 +15,44 @@ type ReducerFunc = func(fv *Float64Field) *float64
 type ReducerID string
 
 const (
-	ReducerSum    ReducerID = "sum"
-	ReducerMean   ReducerID = "mean"
-	ReducerMin    ReducerID = "min"
-	ReducerMax    ReducerID = "max"
-	ReducerCount  ReducerID = "count"
-	ReducerLast   ReducerID = "last"
-	ReducerMedian ReducerID = "median"
+	ReducerSum            ReducerID = "sum"
+	ReducerMean           ReducerID = "mean"
+	ReducerMin            ReducerID = "min"
+	ReducerMax            ReducerID = "max"
+	ReducerCount          ReducerID = "count"
+	ReducerLast           ReducerID = "last"
+	ReducerMedian         ReducerID = "median"
+	ReducerFirst          ReducerID = "first"
+	ReducerStdDev         ReducerID = "stddev"
+	ReducerVariance       ReducerID = "variance"
+	ReducerRange          ReducerID = "range"
+	ReducerDiff           ReducerID = "diff"
+	ReducerDiffAbs        ReducerID = "diff_abs"
+	ReducerPercentDiff    ReducerID = "percent_diff"
+	ReducerPercentDiffAbs ReducerID = "percent_diff_abs"
+	ReducerCountNonNull   ReducerID = "count_non_null"
+	ReducerDelta          ReducerID = "delta"
+	ReducerChangeCount    ReducerID = "change_count"
+	// Takes the percentile, between 0 and 100, as its only parameter
+	ReducerPercentile ReducerID = "percentile"
 )
 
 // GetSupportedReduceFuncs returns collection of supported function names
 func GetSupportedReduceFuncs() []ReducerID {
-	return []ReducerID{ReducerSum, ReducerMean, ReducerMin, ReducerMax, ReducerCount, ReducerLast, ReducerMedian}
+	return []ReducerID{
+		ReducerSum, ReducerMean, ReducerMin, ReducerMax, ReducerCount, ReducerLast, ReducerMedian,
+		ReducerFirst, ReducerStdDev, ReducerVariance, ReducerRange, ReducerDiff, ReducerDiffAbs,
+		ReducerPercentDiff, ReducerPercentDiffAbs, ReducerCountNonNull, ReducerDelta, ReducerChangeCount,
+		ReducerPercentile,
+	}
+}
+
+// NumParams returns the number of parameters the reduction function takes.
+func (r ReducerID) NumParams() int {
+	if r == ReducerPercentile {
+		return 1
+	}
+	return 0
 }
 
 func Sum(fv *Float64Field) *float64 {
@@ -126,7 +152,164 @@ func Median(fv *Float64Field) *float64 {
 	}
 }
 
-func GetReduceFunc(rFunc ReducerID) (ReducerFunc, error) {
+func First(fv *Float64Field) *float64 {
+	var f float64
+	if fv.Len() == 0 {
+		f = math.NaN()
+		return &f
+	}
+	return fv.GetValue(0)
+}
+
+// numbers returns the values of the field, and false if the field is empty or has a null or NaN value.
+func numbers(fv *Float64Field) ([]float64, bool) {
+	values := make([]float64, 0, fv.Len())
+	for i := 0; i < fv.Len(); i++ {
+		v := fv.GetValue(i)
+		if v == nil || math.IsNaN(*v) {
+			return nil, false
+		}
+		values = append(values, *v)
+	}
+	return values, len(values) > 0
+}
+
+func Variance(fv *Float64Field) *float64 {
+	values, ok := numbers(fv)
+	if !ok {
+		nan := math.NaN()
+		return &nan
+	}
+	var mean float64
+	for _, v := range values {
+		mean += v
+	}
+	mean /= float64(len(values))
+	var variance float64
+	for _, v := range values {
+		variance += (v - mean) * (v - mean)
+	}
+	variance /= float64(len(values))
+	return &variance
+}
+
+func StdDev(fv *Float64Field) *float64 {
+	f := math.Sqrt(*Variance(fv))
+	return &f
+}
+
+func Range(fv *Float64Field) *float64 {
+	f := *Max(fv) - *Min(fv)
+	return &f
+}
+
+// diffFunc returns a reduction function that applies diffF to the oldest and the newest value that are not null or NaN.
+// Like the diff reducers of classic conditions, the result is NaN if there is no such value after the first point,
+// for example if the series is empty or has a single point.
+func diffFunc(diffF func(newest, oldest float64) float64) ReducerFunc {
+	return func(fv *Float64Field) *float64 {
+		f := math.NaN()
+		newest := fv.Len() - 1
+		for ; newest >= 0 && nilOrNaN(fv.GetValue(newest)); newest-- {
+		}
+		if newest < 1 {
+			return &f
+		}
+		oldest := 0
+		for ; nilOrNaN(fv.GetValue(oldest)); oldest++ {
+		}
+		f = diffF(*fv.GetValue(newest), *fv.GetValue(oldest))
+		return &f
+	}
+}
+
+func nilOrNaN(f *float64) bool {
+	return f == nil || math.IsNaN(*f)
+}
+
+var (
+	Diff = diffFunc(func(newest, oldest float64) float64 {
+		return newest - oldest
+	})
+	DiffAbs = diffFunc(func(newest, oldest float64) float64 {
+		return math.Abs(newest - oldest)
+	})
+	PercentDiff = diffFunc(func(newest, oldest float64) float64 {
+		return (newest - oldest) / math.Abs(oldest) * 100
+	})
+	PercentDiffAbs = diffFunc(func(newest, oldest float64) float64 {
+		return math.Abs((newest - oldest) / oldest * 100)
+	})
+)
+
+func CountNonNull(fv *Float64Field) *float64 {
+	var f float64
+	for i := 0; i < fv.Len(); i++ {
+		v := fv.GetValue(i)
+		if v != nil && !math.IsNaN(*v) {
+			f++
+		}
+	}
+	return &f
+}
+
+// Delta returns the total increase of a counter, a decrease in value is a counter reset.
+func Delta(fv *Float64Field) *float64 {
+	values, ok := numbers(fv)
+	if !ok {
+		nan := math.NaN()
+		return &nan
+	}
+	var f float64
+	for i := 1; i < len(values); i++ {
+		if values[i] < values[i-1] {
+			f += values[i]
+			continue
+		}
+		f += values[i] - values[i-1]
+	}
+	return &f
+}
+
+func ChangeCount(fv *Float64Field) *float64 {
+	values, ok := numbers(fv)
+	if !ok {
+		nan := math.NaN()
+		return &nan
+	}
+	var f float64
+	for i := 1; i < len(values); i++ {
+		if values[i] != values[i-1] {
+			f++
+		}
+	}
+	return &f
+}
+
+// Percentile returns a reduction function for the p-th percentile, with linear
+// interpolation between the closest ranks.
+func Percentile(p float64) ReducerFunc {
+	return func(fv *Float64Field) *float64 {
+		values, ok := numbers(fv)
+		if !ok {
+			nan := math.NaN()
+			return &nan
+		}
+		sort.Float64s(values)
+		rank := p / 100 * float64(len(values)-1)
+		lower := int(math.Floor(rank))
+		upper := int(math.Ceil(rank))
+		f := values[lower] + (values[upper]-values[lower])*(rank-float64(lower))
+		return &f
+	}
+}
+
+// GetReduceFunc returns the reduction function for rFunc. Params are the parameters of
+// the reduction functions that take them, see ReducerID.NumParams.
+func GetReduceFunc(rFunc ReducerID, params ...float64) (ReducerFunc, error) {
+	if len(params) != rFunc.NumParams() {
+		return nil, fmt.Errorf("reduction %v expects %d parameter(s), got %d", rFunc, rFunc.NumParams(), len(params))
+	}
 	switch rFunc {
 	case ReducerSum:
 		return Sum, nil
@@ -142,6 +325,33 @@ func GetReduceFunc(rFunc ReducerID) (ReducerFunc, error) {
 		return Last, nil
 	case ReducerMedian:
 		return Median, nil
+	case ReducerFirst:
+		return First, nil
+	case ReducerStdDev:
+		return StdDev, nil
+	case ReducerVariance:
+		return Variance, nil
+	case ReducerRange:
+		return Range, nil
+	case ReducerDiff:
+		return Diff, nil
+	case ReducerDiffAbs:
+		return DiffAbs, nil
+	case ReducerPercentDiff:
+		return PercentDiff, nil
+	case ReducerPercentDiffAbs:
+		return PercentDiffAbs, nil
+	case ReducerCountNonNull:
+		return CountNonNull, nil
+	case ReducerDelta:
+		return Delta, nil
+	case ReducerChangeCount:
+		return ChangeCount, nil
+	case ReducerPercentile:
+		if params[0] < 0 || params[0] > 100 {
+			return nil, fmt.Errorf("percentile must be between 0 and 100, got %v", params[0])
+		}
+		return Percentile(params[0]), nil
 	default:
 		return nil, fmt.Errorf("reduction %v not implemented", rFunc)
 	}
@@ -150,7 +360,8 @@ func GetReduceFunc(rFunc ReducerID) (ReducerFunc, error) {
 // Reduce turns the Series into a Number based on the given reduction function
 // if ReduceMapper is defined it applies it to the provided series and performs reduction of the resulting series.
 // Otherwise, the reduction operation is done against the original series.
-func (s Series) Reduce(refID string, rFunc ReducerID, mapper ReduceMapper) (Number, error) {
+// Params are passed to the reduction functions that take parameters.
+func (s Series) Reduce(refID string, rFunc ReducerID, mapper ReduceMapper, params ...float64) (Number, error) {
 	var l data.Labels
 	if s.GetLabels() != nil {
 		l = s.GetLabels().Copy()
@@ -163,7 +374,7 @@ func (s Series) Reduce(refID string, rFunc ReducerID, mapper ReduceMapper) (Numb
 	}
 	fVec := series.Frame.Fields[seriesTypeValIdx]
 	floatField := Float64Field(*fVec)
-	reduceFunc, err := GetReduceFunc(rFunc)
+	reduceFunc, err := GetReduceFunc(rFunc, params...)
 	if err != nil {
 		return number, fmt.Errorf("invalid expression '%s': %w", refID, err)
 	}
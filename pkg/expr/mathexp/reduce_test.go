@@ -28,10 +28,21 @@ var seriesEmpty = Vars{
 	),
 }
 
+var counterSeries = Vars{
+	"A": resultValuesNoErr(
+		makeSeries("temp", nil,
+			tp{time.Unix(5, 0), float64Pointer(1)},
+			tp{time.Unix(10, 0), float64Pointer(4)},
+			tp{time.Unix(15, 0), float64Pointer(2)},
+			tp{time.Unix(20, 0), float64Pointer(5)}),
+	),
+}
+
 func TestSeriesReduce(t *testing.T) {
 	var tests = []struct {
 		name        string
 		red         ReducerID
+		params      []float64
 		vars        Vars
 		varToReduce string
 		errIs       require.ErrorAssertionFunc
@@ -274,6 +285,280 @@ func TestSeriesReduce(t *testing.T) {
 			resultsIs:   require.Equal,
 			results:     resultValuesNoErr(makeNumber("", nil, nil)),
 		},
+		{
+			name:        "first series",
+			red:         "first",
+			varToReduce: "A",
+			vars:        counterSeries,
+			errIs:       require.NoError,
+			resultsIs:   require.Equal,
+			results:     resultValuesNoErr(makeNumber("", nil, float64Pointer(1))),
+		},
+		{
+			name:        "first empty series",
+			red:         "first",
+			varToReduce: "A",
+			vars:        seriesEmpty,
+			errIs:       require.NoError,
+			resultsIs:   require.Equal,
+			results:     resultValuesNoErr(makeNumber("", nil, NaN)),
+		},
+		{
+			name:        "stddev series",
+			red:         "stddev",
+			varToReduce: "A",
+			vars:        counterSeries,
+			errIs:       require.NoError,
+			resultsIs:   require.Equal,
+			results:     resultValuesNoErr(makeNumber("", nil, float64Pointer(math.Sqrt(2.5)))),
+		},
+		{
+			name:        "variance series",
+			red:         "variance",
+			varToReduce: "A",
+			vars:        counterSeries,
+			errIs:       require.NoError,
+			resultsIs:   require.Equal,
+			results:     resultValuesNoErr(makeNumber("", nil, float64Pointer(2.5))),
+		},
+		{
+			name:        "variance series with a nil value",
+			red:         "variance",
+			varToReduce: "A",
+			vars:        seriesWithNil,
+			errIs:       require.NoError,
+			resultsIs:   require.Equal,
+			results:     resultValuesNoErr(makeNumber("", nil, NaN)),
+		},
+		{
+			name:        "range series",
+			red:         "range",
+			varToReduce: "A",
+			vars:        counterSeries,
+			errIs:       require.NoError,
+			resultsIs:   require.Equal,
+			results:     resultValuesNoErr(makeNumber("", nil, float64Pointer(4))),
+		},
+		{
+			name:        "diff series",
+			red:         "diff",
+			varToReduce: "A",
+			vars:        aSeries,
+			errIs:       require.NoError,
+			resultsIs:   require.Equal,
+			results:     resultValuesNoErr(makeNumber("", nil, float64Pointer(-1))),
+		},
+		{
+			name:        "diff_abs series",
+			red:         "diff_abs",
+			varToReduce: "A",
+			vars:        aSeries,
+			errIs:       require.NoError,
+			resultsIs:   require.Equal,
+			results:     resultValuesNoErr(makeNumber("", nil, float64Pointer(1))),
+		},
+		{
+			name:        "percent_diff series",
+			red:         "percent_diff",
+			varToReduce: "A",
+			vars:        aSeries,
+			errIs:       require.NoError,
+			resultsIs:   require.Equal,
+			results:     resultValuesNoErr(makeNumber("", nil, float64Pointer(-50))),
+		},
+		{
+			name:        "percent_diff_abs series",
+			red:         "percent_diff_abs",
+			varToReduce: "A",
+			vars:        aSeries,
+			errIs:       require.NoError,
+			resultsIs:   require.Equal,
+			results:     resultValuesNoErr(makeNumber("", nil, float64Pointer(50))),
+		},
+		{
+			name:        "diff series with a nil value",
+			red:         "diff",
+			varToReduce: "A",
+			vars:        seriesWithNil,
+			errIs:       require.NoError,
+			resultsIs:   require.Equal,
+			results:     resultValuesNoErr(makeNumber("", nil, NaN)),
+		},
+		{
+			name:        "diff series with nil edges",
+			red:         "diff",
+			varToReduce: "A",
+			vars: Vars{
+				"A": resultValuesNoErr(
+					makeSeries("temp", nil,
+						tp{time.Unix(5, 0), nil},
+						tp{time.Unix(10, 0), float64Pointer(2)},
+						tp{time.Unix(15, 0), float64Pointer(3)},
+						tp{time.Unix(20, 0), nil},
+					),
+				),
+			},
+			errIs:     require.NoError,
+			resultsIs: require.Equal,
+			results:   resultValuesNoErr(makeNumber("", nil, float64Pointer(1))),
+		},
+		{
+			name:        "diff series with NaN edges",
+			red:         "diff",
+			varToReduce: "A",
+			vars: Vars{
+				"A": resultValuesNoErr(
+					makeSeries("temp", nil,
+						tp{time.Unix(5, 0), NaN},
+						tp{time.Unix(10, 0), float64Pointer(2)},
+						tp{time.Unix(15, 0), float64Pointer(3)},
+						tp{time.Unix(20, 0), NaN},
+					),
+				),
+			},
+			errIs:     require.NoError,
+			resultsIs: require.Equal,
+			results:   resultValuesNoErr(makeNumber("", nil, float64Pointer(1))),
+		},
+		{
+			name:        "percent_diff series with nil edges",
+			red:         "percent_diff",
+			varToReduce: "A",
+			vars: Vars{
+				"A": resultValuesNoErr(
+					makeSeries("temp", nil,
+						tp{time.Unix(5, 0), nil},
+						tp{time.Unix(10, 0), float64Pointer(2)},
+						tp{time.Unix(15, 0), float64Pointer(6)},
+						tp{time.Unix(20, 0), nil},
+					),
+				),
+			},
+			errIs:     require.NoError,
+			resultsIs: require.Equal,
+			results:   resultValuesNoErr(makeNumber("", nil, float64Pointer(200))),
+		},
+		{
+			name:        "diff series with a single point",
+			red:         "diff",
+			varToReduce: "A",
+			vars: Vars{
+				"A": resultValuesNoErr(
+					makeSeries("temp", nil,
+						tp{time.Unix(5, 0), float64Pointer(2)},
+					),
+				),
+			},
+			errIs:     require.NoError,
+			resultsIs: require.Equal,
+			results:   resultValuesNoErr(makeNumber("", nil, NaN)),
+		},
+		{
+			name:        "diff series with a single value after the first point",
+			red:         "diff",
+			varToReduce: "A",
+			vars: Vars{
+				"A": resultValuesNoErr(
+					makeSeries("temp", nil,
+						tp{time.Unix(5, 0), nil},
+						tp{time.Unix(10, 0), float64Pointer(2)},
+					),
+				),
+			},
+			errIs:     require.NoError,
+			resultsIs: require.Equal,
+			results:   resultValuesNoErr(makeNumber("", nil, float64Pointer(0))),
+		},
+		{
+			name:        "diff series with only nil values",
+			red:         "diff",
+			varToReduce: "A",
+			vars: Vars{
+				"A": resultValuesNoErr(
+					makeSeries("temp", nil,
+						tp{time.Unix(5, 0), nil},
+						tp{time.Unix(10, 0), nil},
+					),
+				),
+			},
+			errIs:     require.NoError,
+			resultsIs: require.Equal,
+			results:   resultValuesNoErr(makeNumber("", nil, NaN)),
+		},
+		{
+			name:        "count_non_null series with a nil value",
+			red:         "count_non_null",
+			varToReduce: "A",
+			vars:        seriesWithNil,
+			errIs:       require.NoError,
+			resultsIs:   require.Equal,
+			results:     resultValuesNoErr(makeNumber("", nil, float64Pointer(1))),
+		},
+		{
+			name:        "delta series with a counter reset",
+			red:         "delta",
+			varToReduce: "A",
+			vars:        counterSeries,
+			errIs:       require.NoError,
+			resultsIs:   require.Equal,
+			results:     resultValuesNoErr(makeNumber("", nil, float64Pointer(8))),
+		},
+		{
+			name:        "change_count series",
+			red:         "change_count",
+			varToReduce: "A",
+			vars:        counterSeries,
+			errIs:       require.NoError,
+			resultsIs:   require.Equal,
+			results:     resultValuesNoErr(makeNumber("", nil, float64Pointer(3))),
+		},
+		{
+			name:        "percentile series",
+			red:         "percentile",
+			params:      []float64{25},
+			varToReduce: "A",
+			vars:        counterSeries,
+			errIs:       require.NoError,
+			resultsIs:   require.Equal,
+			results:     resultValuesNoErr(makeNumber("", nil, float64Pointer(1.75))),
+		},
+		{
+			name:        "percentile 100 is the max",
+			red:         "percentile",
+			params:      []float64{100},
+			varToReduce: "A",
+			vars:        counterSeries,
+			errIs:       require.NoError,
+			resultsIs:   require.Equal,
+			results:     resultValuesNoErr(makeNumber("", nil, float64Pointer(5))),
+		},
+		{
+			name:        "percentile empty series",
+			red:         "percentile",
+			params:      []float64{50},
+			varToReduce: "A",
+			vars:        seriesEmpty,
+			errIs:       require.NoError,
+			resultsIs:   require.Equal,
+			results:     resultValuesNoErr(makeNumber("", nil, NaN)),
+		},
+		{
+			name:        "percentile without a parameter will error",
+			red:         "percentile",
+			varToReduce: "A",
+			vars:        counterSeries,
+			errIs:       require.Error,
+			resultsIs:   require.Equal,
+		},
+		{
+			name:        "percentile out of range will error",
+			red:         "percentile",
+			params:      []float64{101},
+			varToReduce: "A",
+			vars:        counterSeries,
+			errIs:       require.Error,
+			resultsIs:   require.Equal,
+		},
 	}
 
 	for _, tt := range tests {
@@ -281,7 +566,7 @@ func TestSeriesReduce(t *testing.T) {
 			results := Results{}
 			seriesSet := tt.vars[tt.varToReduce]
 			for _, series := range seriesSet.Values {
-				ns, err := series.Value().(*Series).Reduce("", tt.red, nil)
+				ns, err := series.Value().(*Series).Reduce("", tt.red, nil, tt.params...)
 				tt.errIs(t, err)
 				if err != nil {
 					return
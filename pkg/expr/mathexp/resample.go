@@ -20,14 +20,32 @@ const (
 
 	// Do not fill values (nill)
 	UpsamplerFillNA Upsampler = "fillna"
+
+	// Interpolate linearly between the last seen and the next value
+	UpsamplerLinear Upsampler = "linear"
+
+	// Use the value closest in time, the last seen value on a tie
+	UpsamplerNearest Upsampler = "nearest"
+
+	// Use the last seen value, unless the gap to the next value is longer than the max gap
+	UpsamplerStep Upsampler = "step"
 )
 
-// Resample turns the Series into a Number based on the given reduction function
-func (s Series) Resample(refID string, interval time.Duration, downsampler ReducerID, upsampler Upsampler, from, to time.Time) (Series, error) {
+// DefaultStepMaxGap is the max gap of the step upsampler when none is set.
+const DefaultStepMaxGap = 1
+
+// Resample turns the Series into a Number based on the given reduction function.
+// maxGap is the number of intervals a gap between two points can span and still be filled
+// by the step upsampler, it is ignored by the other upsamplers. A maxGap below 1 is replaced
+// by DefaultStepMaxGap, as a gap of zero intervals would leave every gap empty like fillna.
+func (s Series) Resample(refID string, interval time.Duration, downsampler ReducerID, upsampler Upsampler, from, to time.Time, maxGap int) (Series, error) {
 	newSeriesLength := int(float64(to.Sub(from).Nanoseconds()) / float64(interval.Nanoseconds()))
 	if newSeriesLength <= 0 {
 		return s, fmt.Errorf("the series cannot be sampled further; the time range is shorter than the interval")
 	}
+	if maxGap < 1 {
+		maxGap = DefaultStepMaxGap
+	}
 	resampled := NewSeries(refID, s.GetLabels(), newSeriesLength+1)
 	bookmark := 0
 	var lastSeen *float64
@@ -66,6 +84,12 @@ func (s Series) Resample(refID string, interval time.Duration, downsampler Reduc
 				}
 			case UpsamplerFillNA:
 				value = nil
+			case UpsamplerLinear:
+				value = s.interpolate(sIdx, t)
+			case UpsamplerNearest:
+				value = s.nearest(sIdx, t)
+			case UpsamplerStep:
+				value = s.step(sIdx, t, time.Duration(maxGap)*interval)
 			default:
 				return s, fmt.Errorf("upsampling %v not implemented", upsampler)
 			}
@@ -97,3 +121,55 @@ func (s Series) Resample(refID string, interval time.Duration, downsampler Reduc
 	}
 	return resampled, nil
 }
+
+// interpolate returns the value at t on the line between the points before and at next,
+// or nil if either point does not exist or has no value.
+func (s Series) interpolate(next int, t time.Time) *float64 {
+	if next == 0 || next == s.Len() {
+		return nil
+	}
+	prevT, prev := s.GetPoint(next - 1)
+	nextT, nextV := s.GetPoint(next)
+	if prev == nil || nextV == nil {
+		return nil
+	}
+	f := *prev + (*nextV-*prev)*float64(t.Sub(prevT))/float64(nextT.Sub(prevT))
+	return &f
+}
+
+// nearest returns the value of the point before next or the point at next, whichever is closer to t.
+func (s Series) nearest(next int, t time.Time) *float64 {
+	if next == 0 && next == s.Len() {
+		return nil
+	}
+	if next == s.Len() {
+		return s.GetValue(next - 1)
+	}
+	if next == 0 {
+		return s.GetValue(next)
+	}
+	prevT, prev := s.GetPoint(next - 1)
+	nextT, nextV := s.GetPoint(next)
+	if t.Sub(prevT) <= nextT.Sub(t) {
+		return prev
+	}
+	return nextV
+}
+
+// step returns the value of the point before next, or nil if there is no such point or the gap
+// from it to the point at next is longer than maxGap. When there is no next point the gap
+// extends to t.
+func (s Series) step(next int, t time.Time, maxGap time.Duration) *float64 {
+	if next == 0 {
+		return nil
+	}
+	prevT, prev := s.GetPoint(next - 1)
+	gapEnd := t
+	if next < s.Len() {
+		gapEnd = s.GetTime(next)
+	}
+	if gapEnd.Sub(prevT) > maxGap {
+		return nil
+	}
+	return prev
+}
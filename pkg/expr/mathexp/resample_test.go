@@ -15,6 +15,7 @@ func TestResampleSeries(t *testing.T) {
 		interval         time.Duration
 		downsampler      ReducerID
 		upsampler        Upsampler
+		maxGap           int
 		timeRange        backend.TimeRange
 		seriesToResample Series
 		series           Series
@@ -277,10 +278,123 @@ func TestResampleSeries(t *testing.T) {
 				time.Unix(9, 0), float64Pointer(0),
 			}),
 		},
+		{
+			name:        "resample series: upsampling (mean / linear )",
+			interval:    time.Second * 2,
+			downsampler: "mean",
+			upsampler:   "linear",
+			timeRange: backend.TimeRange{
+				From: time.Unix(0, 0),
+				To:   time.Unix(11, 0),
+			},
+			seriesToResample: makeSeries("", nil, tp{
+				time.Unix(2, 0), float64Pointer(2),
+			}, tp{
+				time.Unix(8, 0), float64Pointer(8),
+			}),
+			series: makeSeries("", nil, tp{
+				time.Unix(0, 0), nil,
+			}, tp{
+				time.Unix(2, 0), float64Pointer(2),
+			}, tp{
+				time.Unix(4, 0), float64Pointer(4),
+			}, tp{
+				time.Unix(6, 0), float64Pointer(6),
+			}, tp{
+				time.Unix(8, 0), float64Pointer(8),
+			}, tp{
+				time.Unix(10, 0), nil,
+			}),
+		},
+		{
+			name:        "resample series: upsampling (mean / nearest )",
+			interval:    time.Second * 2,
+			downsampler: "mean",
+			upsampler:   "nearest",
+			timeRange: backend.TimeRange{
+				From: time.Unix(0, 0),
+				To:   time.Unix(11, 0),
+			},
+			seriesToResample: makeSeries("", nil, tp{
+				time.Unix(2, 0), float64Pointer(2),
+			}, tp{
+				time.Unix(8, 0), float64Pointer(8),
+			}),
+			series: makeSeries("", nil, tp{
+				time.Unix(0, 0), float64Pointer(2),
+			}, tp{
+				time.Unix(2, 0), float64Pointer(2),
+			}, tp{
+				time.Unix(4, 0), float64Pointer(2),
+			}, tp{
+				time.Unix(6, 0), float64Pointer(8),
+			}, tp{
+				time.Unix(8, 0), float64Pointer(8),
+			}, tp{
+				time.Unix(10, 0), float64Pointer(8),
+			}),
+		},
+		{
+			name:        "resample series: upsampling (mean / step with max gap )",
+			interval:    time.Second * 2,
+			downsampler: "mean",
+			upsampler:   "step",
+			maxGap:      2,
+			timeRange: backend.TimeRange{
+				From: time.Unix(0, 0),
+				To:   time.Unix(11, 0),
+			},
+			seriesToResample: makeSeries("", nil, tp{
+				time.Unix(2, 0), float64Pointer(2),
+			}, tp{
+				time.Unix(8, 0), float64Pointer(8),
+			}),
+			series: makeSeries("", nil, tp{
+				time.Unix(0, 0), nil,
+			}, tp{
+				time.Unix(2, 0), float64Pointer(2),
+			}, tp{
+				time.Unix(4, 0), nil,
+			}, tp{
+				time.Unix(6, 0), nil,
+			}, tp{
+				time.Unix(8, 0), float64Pointer(8),
+			}, tp{
+				time.Unix(10, 0), float64Pointer(8),
+			}),
+		},
+		{
+			name:        "resample series: upsampling (mean / step without max gap uses the default)",
+			interval:    time.Second * 2,
+			downsampler: "mean",
+			upsampler:   "step",
+			timeRange: backend.TimeRange{
+				From: time.Unix(0, 0),
+				To:   time.Unix(11, 0),
+			},
+			seriesToResample: makeSeries("", nil, tp{
+				time.Unix(2, 0), float64Pointer(2),
+			}, tp{
+				time.Unix(8, 0), float64Pointer(8),
+			}),
+			series: makeSeries("", nil, tp{
+				time.Unix(0, 0), nil,
+			}, tp{
+				time.Unix(2, 0), float64Pointer(2),
+			}, tp{
+				time.Unix(4, 0), nil,
+			}, tp{
+				time.Unix(6, 0), nil,
+			}, tp{
+				time.Unix(8, 0), float64Pointer(8),
+			}, tp{
+				time.Unix(10, 0), float64Pointer(8),
+			}),
+		},
 	}
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			series, err := tt.seriesToResample.Resample("", tt.interval, tt.downsampler, tt.upsampler, tt.timeRange.From, tt.timeRange.To)
+			series, err := tt.seriesToResample.Resample("", tt.interval, tt.downsampler, tt.upsampler, tt.timeRange.From, tt.timeRange.To, tt.maxGap)
 			if tt.series.Frame == nil {
 				require.Error(t, err)
 			} else {
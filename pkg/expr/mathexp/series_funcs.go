@@ -0,0 +1,154 @@
+package mathexp
+
+import (
+	"fmt"
+	"math"
+	"time"
+)
+
+// perSeries passes each Series in varSet to seriesF. NoData is passed through,
+// any other type of value is an error since the function needs points over time.
+func perSeries(e *State, name string, varSet Results, seriesF func(s Series) Series) (Results, error) {
+	newRes := Results{}
+	for _, res := range varSet.Values {
+		switch v := res.(type) {
+		case Series:
+			newRes.Values = append(newRes.Values, seriesF(v))
+		case NoData:
+			newRes.Values = append(newRes.Values, v.New())
+		default:
+			return newRes, fmt.Errorf("%s can only be applied to time series, got %v", name, res.Type())
+		}
+	}
+	return newRes, nil
+}
+
+// movingAvg returns, for each point of each series, the average of the non-null values
+// of the points in the preceding window, including the point itself.
+// Points with no values in the window are null.
+func movingAvg(e *State, varSet Results, window time.Duration) (Results, error) {
+	if window <= 0 {
+		return Results{}, fmt.Errorf("moving_avg window must be positive, got %v", window)
+	}
+	return perSeries(e, "moving_avg", varSet, func(s Series) Series {
+		newSeries := NewSeries(e.RefID, s.GetLabels(), s.Len())
+		start := 0
+		sum, count := 0.0, 0
+		for i := 0; i < s.Len(); i++ {
+			t, f := s.GetPoint(i)
+			if f != nil {
+				sum += *f
+				count++
+			}
+			for ; start < i && !s.GetTime(start).After(t.Add(-window)); start++ {
+				if v := s.GetValue(start); v != nil {
+					sum -= *v
+					count--
+				}
+			}
+			if count == 0 {
+				newSeries.SetPoint(i, t, nil)
+				continue
+			}
+			avg := sum / float64(count)
+			newSeries.SetPoint(i, t, &avg)
+		}
+		return newSeries
+	})
+}
+
+// pairwise builds a series with one point less than s, where each point is the result of
+// pointF for the point and its predecessor. If either value is null the point is null.
+func pairwise(e *State, s Series, pointF func(prevT time.Time, prev float64, t time.Time, cur float64) float64) Series {
+	if s.Len() < 2 {
+		return NewSeries(e.RefID, s.GetLabels(), 0)
+	}
+	newSeries := NewSeries(e.RefID, s.GetLabels(), s.Len()-1)
+	for i := 1; i < s.Len(); i++ {
+		prevT, prev := s.GetPoint(i - 1)
+		t, cur := s.GetPoint(i)
+		if prev == nil || cur == nil {
+			newSeries.SetPoint(i-1, t, nil)
+			continue
+		}
+		nF := pointF(prevT, *prev, t, *cur)
+		newSeries.SetPoint(i-1, t, &nF)
+	}
+	return newSeries
+}
+
+// delta returns the difference between each point and the previous point of each series.
+func delta(e *State, varSet Results) (Results, error) {
+	return perSeries(e, "delta", varSet, func(s Series) Series {
+		return pairwise(e, s, func(_ time.Time, prev float64, _ time.Time, cur float64) float64 {
+			return cur - prev
+		})
+	})
+}
+
+// derivative returns the per-second change between each point and the previous point of each series.
+func derivative(e *State, varSet Results) (Results, error) {
+	return perSeries(e, "derivative", varSet, func(s Series) Series {
+		return pairwise(e, s, func(prevT time.Time, prev float64, t time.Time, cur float64) float64 {
+			return perSecond(cur-prev, prevT, t)
+		})
+	})
+}
+
+// rate returns the per-second increase between each point and the previous point of each
+// series, where the series is a counter. A decrease is treated as a counter reset, so the
+// increase is the value of the point itself.
+func rate(e *State, varSet Results) (Results, error) {
+	return perSeries(e, "rate", varSet, func(s Series) Series {
+		return pairwise(e, s, func(prevT time.Time, prev float64, t time.Time, cur float64) float64 {
+			increase := cur - prev
+			if cur < prev {
+				increase = cur
+			}
+			return perSecond(increase, prevT, t)
+		})
+	})
+}
+
+// perSecond returns change divided by the seconds from prevT to t. It is NaN if t is not after
+// prevT, such as for points with duplicate timestamps, as there is no elapsed time to divide by.
+func perSecond(change float64, prevT, t time.Time) float64 {
+	if !t.After(prevT) {
+		return math.NaN()
+	}
+	return change / t.Sub(prevT).Seconds()
+}
+
+// cumulativeSum returns the running total of each series. Null values are kept as null
+// and do not change the total.
+func cumulativeSum(e *State, varSet Results) (Results, error) {
+	return perSeries(e, "cumulative_sum", varSet, func(s Series) Series {
+		newSeries := NewSeries(e.RefID, s.GetLabels(), s.Len())
+		total := 0.0
+		for i := 0; i < s.Len(); i++ {
+			t, f := s.GetPoint(i)
+			if f == nil {
+				newSeries.SetPoint(i, t, nil)
+				continue
+			}
+			total += *f
+			nF := total
+			newSeries.SetPoint(i, t, &nF)
+		}
+		return newSeries
+	})
+}
+
+// shift moves every point of each series forward in time by d, so that the value of
+// a point in the result is the value of the series at d before that time.
+// A negative duration moves the points back in time.
+func shift(e *State, varSet Results, d time.Duration) (Results, error) {
+	return perSeries(e, "shift", varSet, func(s Series) Series {
+		newSeries := NewSeries(e.RefID, s.GetLabels(), s.Len())
+		for i := 0; i < s.Len(); i++ {
+			t, f := s.GetPoint(i)
+			newSeries.SetPoint(i, t.Add(d), f)
+		}
+		return newSeries
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 	"github.com/grafana/grafana/pkg/expr/mathexp/parse"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_union(t *testing.T) {
@@ -295,7 +296,8 @@ func Test_union(t *testing.T) {
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
 			fakeNode := &parse.BinaryNode{Args: [2]parse.Node{&parse.VarNode{}, &parse.VarNode{}}}
-			unions := (&State{}).union(tt.aResults, tt.bResults, fakeNode)
+			unions, err := (&State{}).union(tt.aResults, tt.bResults, fakeNode)
+			require.NoError(t, err)
 			tt.unionsAre(t, tt.unions, unions)
 		})
 	}
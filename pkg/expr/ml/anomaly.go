@@ -0,0 +1,228 @@
+package ml
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"sync"
+)
+
+// AnomalyAlgorithm is the name of an algorithm that detects anomalies in a series
+// +enum
+type AnomalyAlgorithm string
+
+const (
+	// Band of the mean plus or minus the standard deviation multiplied by the sensitivity
+	AnomalyZScore AnomalyAlgorithm = "zscore"
+
+	// Band of the median plus or minus the scaled median absolute deviation multiplied by the sensitivity
+	AnomalyMAD AnomalyAlgorithm = "mad"
+
+	// Band around the Holt-Winters prediction, using the standard deviation of the prediction errors
+	AnomalyHoltWinters AnomalyAlgorithm = "holt_winters"
+)
+
+const (
+	defaultSensitivity = 3.0
+
+	// madScale makes the median absolute deviation a consistent estimator of the standard deviation of normally distributed values
+	madScale = 1.4826
+)
+
+// AnomalyConfiguration configures the local anomaly detection.
+type AnomalyConfiguration struct {
+	Algorithm AnomalyAlgorithm `json:"algorithm"`
+	// Sensitivity is the number of deviations the band spans on each side of the expected value. Defaults to 3.
+	Sensitivity float64 `json:"sensitivity,omitempty"`
+	// Window is the number of preceding points the zscore and mad algorithms use. 0 uses all preceding points.
+	Window int `json:"window,omitempty"`
+	// Season is the number of points in a season for holt_winters. Less than 2 disables seasonality.
+	Season int `json:"season,omitempty"`
+	// Alpha, Beta and Gamma are the holt_winters smoothing factors of the level, trend and season.
+	Alpha float64 `json:"alpha,omitempty"`
+	Beta  float64 `json:"beta,omitempty"`
+	Gamma float64 `json:"gamma,omitempty"`
+}
+
+// Detector calculates the band of expected values for the points of a series.
+type Detector interface {
+	// Bands returns the lower and upper bound for every value. A bound is nil if it cannot be calculated.
+	Bands(values []*float64) (lower, upper []*float64)
+}
+
+// DetectorFactory creates a Detector from the configuration.
+type DetectorFactory func(cfg AnomalyConfiguration) (Detector, error)
+
+var (
+	detectorsMu sync.RWMutex
+	detectors   = map[AnomalyAlgorithm]DetectorFactory{
+		AnomalyZScore: func(cfg AnomalyConfiguration) (Detector, error) {
+			return zScoreDetector{sensitivity: cfg.Sensitivity, window: cfg.Window}, nil
+		},
+		AnomalyMAD: func(cfg AnomalyConfiguration) (Detector, error) {
+			return madDetector{sensitivity: cfg.Sensitivity, window: cfg.Window}, nil
+		},
+		AnomalyHoltWinters: func(cfg AnomalyConfiguration) (Detector, error) {
+			params, err := newHoltWintersParams(cfg.Alpha, cfg.Beta, cfg.Gamma, cfg.Season)
+			if err != nil {
+				return nil, err
+			}
+			return holtWintersDetector{params: params, sensitivity: cfg.Sensitivity}, nil
+		},
+	}
+)
+
+// RegisterDetector makes an anomaly detection algorithm available to NewDetector.
+// It replaces a previously registered algorithm with the same name.
+func RegisterDetector(algorithm AnomalyAlgorithm, factory DetectorFactory) {
+	detectorsMu.Lock()
+	defer detectorsMu.Unlock()
+	detectors[algorithm] = factory
+}
+
+// NewDetector validates the configuration and creates the Detector of the configured algorithm.
+func NewDetector(cfg AnomalyConfiguration) (Detector, error) {
+	detectorsMu.RLock()
+	factory, ok := detectors[cfg.Algorithm]
+	detectorsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("unsupported anomaly detection algorithm '%s'", cfg.Algorithm)
+	}
+	if cfg.Sensitivity < 0 {
+		return nil, fmt.Errorf("sensitivity must not be negative, got %v", cfg.Sensitivity)
+	}
+	if cfg.Sensitivity == 0 {
+		cfg.Sensitivity = defaultSensitivity
+	}
+	if cfg.Window < 0 {
+		return nil, fmt.Errorf("window must not be negative, got %d", cfg.Window)
+	}
+	return factory(cfg)
+}
+
+// DetectAnomalies returns the bands of the detector and a flag for every value, which is 1 if
+// the value is outside the band, 0 if it is inside and nil if the value or the band is missing.
+func DetectAnomalies(d Detector, values []*float64) (lower, upper, flag []*float64) {
+	lower, upper = d.Bands(values)
+	flag = make([]*float64, len(values))
+	for i, v := range values {
+		if v == nil || math.IsNaN(*v) || lower[i] == nil || upper[i] == nil {
+			continue
+		}
+		f := 0.0
+		if *v < *lower[i] || *v > *upper[i] {
+			f = 1
+		}
+		flag[i] = &f
+	}
+	return lower, upper, flag
+}
+
+// preceding returns the set values among the window points before index i, or all of them if window is 0.
+func preceding(values []*float64, i, window int) []float64 {
+	start := 0
+	if window > 0 && i-window > 0 {
+		start = i - window
+	}
+	res := make([]float64, 0, i-start)
+	for _, v := range values[start:i] {
+		if v != nil && !math.IsNaN(*v) {
+			res = append(res, *v)
+		}
+	}
+	return res
+}
+
+// band returns the pointers to center minus and plus width.
+func band(center, width float64) (*float64, *float64) {
+	l, u := center-width, center+width
+	return &l, &u
+}
+
+type zScoreDetector struct {
+	sensitivity float64
+	window      int
+}
+
+func (d zScoreDetector) Bands(values []*float64) (lower, upper []*float64) {
+	lower, upper = make([]*float64, len(values)), make([]*float64, len(values))
+	for i := range values {
+		prev := preceding(values, i, d.window)
+		if len(prev) < 2 {
+			continue
+		}
+		var mean, variance float64
+		for _, v := range prev {
+			mean += v
+		}
+		mean /= float64(len(prev))
+		for _, v := range prev {
+			variance += (v - mean) * (v - mean)
+		}
+		variance /= float64(len(prev))
+		lower[i], upper[i] = band(mean, d.sensitivity*math.Sqrt(variance))
+	}
+	return lower, upper
+}
+
+type madDetector struct {
+	sensitivity float64
+	window      int
+}
+
+func (d madDetector) Bands(values []*float64) (lower, upper []*float64) {
+	lower, upper = make([]*float64, len(values)), make([]*float64, len(values))
+	for i := range values {
+		prev := preceding(values, i, d.window)
+		if len(prev) < 2 {
+			continue
+		}
+		med := median(prev)
+		deviations := make([]float64, len(prev))
+		for j, v := range prev {
+			deviations[j] = math.Abs(v - med)
+		}
+		lower[i], upper[i] = band(med, d.sensitivity*madScale*median(deviations))
+	}
+	return lower, upper
+}
+
+// median sorts values and returns the middle value.
+func median(values []float64) float64 {
+	sort.Float64s(values)
+	mid := len(values) / 2
+	if len(values)%2 == 0 {
+		return (values[mid-1] + values[mid]) / 2
+	}
+	return values[mid]
+}
+
+type holtWintersDetector struct {
+	params      holtWintersParams
+	sensitivity float64
+}
+
+// Bands predicts every value from the preceding values. The band is the prediction plus or
+// minus the standard deviation of the errors of the previous predictions. There are no bands
+// for the first two seasons, which initialize the smoothing.
+func (d holtWintersDetector) Bands(values []*float64) (lower, upper []*float64) {
+	lower, upper = make([]*float64, len(values)), make([]*float64, len(values))
+	hw, err := d.params.init(values)
+	if err != nil {
+		return lower, upper
+	}
+	var sumSq float64
+	var count int
+	for i := hw.seasonLength(); i < len(values); i++ {
+		prediction := hw.forecast(1)
+		if i >= 2*hw.seasonLength() && count >= 2 {
+			lower[i], upper[i] = band(prediction, d.sensitivity*math.Sqrt(sumSq/float64(count)))
+		}
+		if v := values[i]; v != nil && !math.IsNaN(*v) {
+			sumSq += (*v - prediction) * (*v - prediction)
+			count++
+		}
+		hw.update(values[i])
+	}
+	return lower, upper
+}
@@ -0,0 +1,136 @@
+package ml
+
+import (
+	"math"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+var nan = math.NaN()
+
+func values(vals ...float64) []*float64 {
+	res := make([]*float64, len(vals))
+	for i := range vals {
+		if !math.IsNaN(vals[i]) {
+			res[i] = &vals[i]
+		}
+	}
+	return res
+}
+
+func TestNewDetector(t *testing.T) {
+	t.Run("fails for unknown algorithm", func(t *testing.T) {
+		_, err := NewDetector(AnomalyConfiguration{Algorithm: "prophet"})
+		require.ErrorContains(t, err, "unsupported anomaly detection algorithm")
+	})
+
+	t.Run("fails for negative sensitivity", func(t *testing.T) {
+		_, err := NewDetector(AnomalyConfiguration{Algorithm: AnomalyZScore, Sensitivity: -1})
+		require.Error(t, err)
+	})
+
+	t.Run("fails for invalid smoothing factor", func(t *testing.T) {
+		_, err := NewDetector(AnomalyConfiguration{Algorithm: AnomalyHoltWinters, Alpha: 1.5})
+		require.ErrorContains(t, err, "alpha")
+	})
+
+	t.Run("uses registered detectors", func(t *testing.T) {
+		RegisterDetector("fixed", func(cfg AnomalyConfiguration) (Detector, error) {
+			return fixedDetector{}, nil
+		})
+		t.Cleanup(func() {
+			detectorsMu.Lock()
+			delete(detectors, "fixed")
+			detectorsMu.Unlock()
+		})
+		d, err := NewDetector(AnomalyConfiguration{Algorithm: "fixed"})
+		require.NoError(t, err)
+		lower, upper, flag := DetectAnomalies(d, values(0, 5, math.NaN()))
+		require.Equal(t, 1.0, *lower[0])
+		require.Equal(t, 2.0, *upper[0])
+		require.Equal(t, 1.0, *flag[0])
+		require.Equal(t, 1.0, *flag[1])
+		require.Nil(t, flag[2])
+	})
+}
+
+type fixedDetector struct{}
+
+func (fixedDetector) Bands(values []*float64) (lower, upper []*float64) {
+	lower, upper = make([]*float64, len(values)), make([]*float64, len(values))
+	for i := range values {
+		lower[i], upper[i] = band(1.5, 0.5)
+	}
+	return lower, upper
+}
+
+func TestDetectAnomalies(t *testing.T) {
+	t.Run("zscore flags values far from the mean", func(t *testing.T) {
+		d, err := NewDetector(AnomalyConfiguration{Algorithm: AnomalyZScore, Sensitivity: 2})
+		require.NoError(t, err)
+		lower, upper, flag := DetectAnomalies(d, values(1, 3, 1, 3, 2, 10))
+
+		require.Nil(t, lower[0])
+		require.Nil(t, upper[1])
+		require.Nil(t, flag[1])
+		// mean 2, standard deviation 1
+		assert.Equal(t, 0.0, *lower[4])
+		assert.Equal(t, 4.0, *upper[4])
+		assert.Equal(t, 0.0, *flag[4])
+		assert.Equal(t, 1.0, *flag[5])
+	})
+
+	t.Run("zscore uses the window of preceding points", func(t *testing.T) {
+		d, err := NewDetector(AnomalyConfiguration{Algorithm: AnomalyZScore, Window: 2})
+		require.NoError(t, err)
+		lower, upper, flag := DetectAnomalies(d, values(100, 1, 1, 1))
+		assert.Equal(t, 1.0, *lower[3])
+		assert.Equal(t, 1.0, *upper[3])
+		assert.Equal(t, 0.0, *flag[3])
+	})
+
+	t.Run("mad is robust to outliers", func(t *testing.T) {
+		d, err := NewDetector(AnomalyConfiguration{Algorithm: AnomalyMAD})
+		require.NoError(t, err)
+		lower, upper, flag := DetectAnomalies(d, values(1, 2, 1000, 2, 3, 3))
+		// median 2, MAD 1
+		assert.InDelta(t, 2-3*madScale, *lower[5], 1e-9)
+		assert.InDelta(t, 2+3*madScale, *upper[5], 1e-9)
+		assert.Equal(t, 0.0, *flag[5])
+	})
+
+	t.Run("holt_winters follows the season", func(t *testing.T) {
+		d, err := NewDetector(AnomalyConfiguration{Algorithm: AnomalyHoltWinters, Season: 4, Sensitivity: 2})
+		require.NoError(t, err)
+		season := []float64{10, 20, 30, 20}
+		vals := []float64{}
+		for i := 0; i < 6; i++ {
+			for j, v := range season {
+				// small noise so that the band is not empty
+				vals = append(vals, v+float64((i+j)%2)*0.5)
+			}
+		}
+		vals = append(vals, 10, 20, 90)
+		lower, upper, flag := DetectAnomalies(d, values(vals...))
+
+		for i := 0; i < 8; i++ {
+			require.Nil(t, lower[i], "no band while initializing, point %d", i)
+		}
+		for i := 8; i < len(vals)-1; i++ {
+			require.NotNil(t, upper[i])
+			require.Equal(t, 0.0, *flag[i], "point %d with value %v is not in [%v, %v]", i, vals[i], *lower[i], *upper[i])
+		}
+		require.Equal(t, 1.0, *flag[len(vals)-1])
+	})
+
+	t.Run("holt_winters has no bands if the series is too short", func(t *testing.T) {
+		d, err := NewDetector(AnomalyConfiguration{Algorithm: AnomalyHoltWinters, Season: 4})
+		require.NoError(t, err)
+		lower, upper, flag := DetectAnomalies(d, values(1, 2, 3))
+		require.Equal(t, []*float64{nil, nil, nil}, lower)
+		require.Equal(t, []*float64{nil, nil, nil}, upper)
+		require.Equal(t, []*float64{nil, nil, nil}, flag)
+	})
+}
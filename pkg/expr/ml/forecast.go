@@ -0,0 +1,150 @@
+package ml
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"time"
+)
+
+// ForecastMethod is the name of a method that predicts the future values of a series
+// +enum
+type ForecastMethod string
+
+const (
+	// Least squares linear regression over the points of the series
+	ForecastLinear ForecastMethod = "linear"
+
+	// Additive Holt-Winters (triple exponential) smoothing
+	ForecastHoltWinters ForecastMethod = "holt_winters"
+)
+
+// ForecastConfiguration configures the forecast.
+type ForecastConfiguration struct {
+	Method ForecastMethod `json:"method"`
+	// Season is the number of points in a season for holt_winters. Less than 2 disables seasonality.
+	Season int `json:"season,omitempty"`
+	// Alpha, Beta and Gamma are the holt_winters smoothing factors of the level, trend and season.
+	Alpha float64 `json:"alpha,omitempty"`
+	Beta  float64 `json:"beta,omitempty"`
+	Gamma float64 `json:"gamma,omitempty"`
+}
+
+// Forecaster predicts the values of a series after its last point.
+type Forecaster interface {
+	// Forecast returns the values at every step after the last point, for the given number of steps.
+	Forecast(times []time.Time, values []*float64, step time.Duration, steps int) ([]float64, error)
+}
+
+// NewForecaster validates the configuration and creates the Forecaster of the configured method.
+func NewForecaster(cfg ForecastConfiguration) (Forecaster, error) {
+	switch cfg.Method {
+	case ForecastLinear:
+		return linearForecaster{}, nil
+	case ForecastHoltWinters:
+		params, err := newHoltWintersParams(cfg.Alpha, cfg.Beta, cfg.Gamma, cfg.Season)
+		if err != nil {
+			return nil, err
+		}
+		return holtWintersForecaster{params: params}, nil
+	default:
+		return nil, fmt.Errorf("unsupported forecast method '%s'", cfg.Method)
+	}
+}
+
+// MedianStep returns the median of the durations between consecutive times, or 0 if there are less than two.
+func MedianStep(times []time.Time) time.Duration {
+	if len(times) < 2 {
+		return 0
+	}
+	steps := make([]time.Duration, 0, len(times)-1)
+	for i := 1; i < len(times); i++ {
+		steps = append(steps, times[i].Sub(times[i-1]))
+	}
+	sort.Slice(steps, func(i, j int) bool { return steps[i] < steps[j] })
+	return steps[len(steps)/2]
+}
+
+// TimeUntil returns the time from `from` until the forecast first reaches the threshold, from below
+// if rising is true and from above otherwise. The forecast starts at `last`, the time of the last known
+// value, and the crossing time is interpolated linearly between the steps. It returns 0 if the last known
+// value has already reached the threshold, and false if the forecast does not reach it.
+func TimeUntil(from, last time.Time, lastValue float64, forecast []float64, step time.Duration, threshold float64, rising bool) (time.Duration, bool) {
+	reached := func(v float64) bool {
+		if rising {
+			return v >= threshold
+		}
+		return v <= threshold
+	}
+	if reached(lastValue) {
+		return 0, true
+	}
+	prevT, prev := last, lastValue
+	for i, v := range forecast {
+		t := last.Add(time.Duration(i+1) * step)
+		if reached(v) {
+			crossing := prevT.Add(time.Duration(float64(t.Sub(prevT)) * (threshold - prev) / (v - prev)))
+			if crossing.Before(from) {
+				return 0, true
+			}
+			return crossing.Sub(from), true
+		}
+		prevT, prev = t, v
+	}
+	return 0, false
+}
+
+type linearForecaster struct{}
+
+// Forecast fits a line to the points with values with least squares.
+func (linearForecaster) Forecast(times []time.Time, values []*float64, step time.Duration, steps int) ([]float64, error) {
+	var n, sumX, sumY, sumXX, sumXY float64
+	for i, v := range values {
+		if v == nil || math.IsNaN(*v) {
+			continue
+		}
+		x := times[i].Sub(times[0]).Seconds()
+		n++
+		sumX += x
+		sumY += *v
+		sumXX += x * x
+		sumXY += x * *v
+	}
+	if n < 2 {
+		return nil, fmt.Errorf("linear regression requires at least 2 points with values, got %v", n)
+	}
+	denominator := n*sumXX - sumX*sumX
+	if denominator == 0 {
+		return nil, fmt.Errorf("linear regression requires points at different times")
+	}
+	slope := (n*sumXY - sumX*sumY) / denominator
+	intercept := (sumY - slope*sumX) / n
+
+	last := times[len(times)-1]
+	res := make([]float64, steps)
+	for i := range res {
+		x := last.Add(time.Duration(i+1) * step).Sub(times[0]).Seconds()
+		res[i] = intercept + slope*x
+	}
+	return res, nil
+}
+
+type holtWintersForecaster struct {
+	params holtWintersParams
+}
+
+// Forecast smooths all values, which are expected to be evenly spaced, and extrapolates the smoothing.
+func (f holtWintersForecaster) Forecast(_ []time.Time, values []*float64, _ time.Duration, steps int) ([]float64, error) {
+	hw, err := f.params.init(values)
+	if err != nil {
+		return nil, err
+	}
+	for i := hw.seasonLength(); i < len(values); i++ {
+		hw.update(values[i])
+	}
+	res := make([]float64, steps)
+	for i := range res {
+		res[i] = hw.forecast(i + 1)
+	}
+	return res, nil
+}
@@ -0,0 +1,92 @@
+package ml
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func minutes(n int) []time.Time {
+	start := time.Unix(0, 0)
+	res := make([]time.Time, n)
+	for i := range res {
+		res[i] = start.Add(time.Duration(i) * time.Minute)
+	}
+	return res
+}
+
+func TestNewForecaster(t *testing.T) {
+	_, err := NewForecaster(ForecastConfiguration{Method: "arima"})
+	require.ErrorContains(t, err, "unsupported forecast method")
+
+	_, err = NewForecaster(ForecastConfiguration{Method: ForecastHoltWinters, Gamma: 2})
+	require.ErrorContains(t, err, "gamma")
+}
+
+func TestForecast(t *testing.T) {
+	t.Run("linear extrapolates the trend", func(t *testing.T) {
+		f, err := NewForecaster(ForecastConfiguration{Method: ForecastLinear})
+		require.NoError(t, err)
+		res, err := f.Forecast(minutes(4), values(1, 2, 3, 4), time.Minute, 3)
+		require.NoError(t, err)
+		assert.InDeltaSlice(t, []float64{5, 6, 7}, res, 1e-9)
+	})
+
+	t.Run("linear ignores missing values", func(t *testing.T) {
+		f, err := NewForecaster(ForecastConfiguration{Method: ForecastLinear})
+		require.NoError(t, err)
+		res, err := f.Forecast(minutes(4), values(10, nan, 30, 40), time.Minute, 1)
+		require.NoError(t, err)
+		assert.InDeltaSlice(t, []float64{50}, res, 1e-9)
+	})
+
+	t.Run("linear requires two points", func(t *testing.T) {
+		f, err := NewForecaster(ForecastConfiguration{Method: ForecastLinear})
+		require.NoError(t, err)
+		_, err = f.Forecast(minutes(2), values(1, nan), time.Minute, 1)
+		require.Error(t, err)
+	})
+
+	t.Run("holt_winters repeats the season", func(t *testing.T) {
+		f, err := NewForecaster(ForecastConfiguration{Method: ForecastHoltWinters, Season: 3})
+		require.NoError(t, err)
+		res, err := f.Forecast(minutes(12), values(1, 5, 3, 1, 5, 3, 1, 5, 3, 1, 5, 3), time.Minute, 4)
+		require.NoError(t, err)
+		assert.InDeltaSlice(t, []float64{1, 5, 3, 1}, res, 1e-6)
+	})
+}
+
+func TestTimeUntil(t *testing.T) {
+	last := time.Unix(600, 0)
+
+	t.Run("interpolates the crossing", func(t *testing.T) {
+		d, ok := TimeUntil(last, last, 80, []float64{85, 90, 95, 100}, time.Minute, 97.5, true)
+		require.True(t, ok)
+		require.Equal(t, 3*time.Minute+30*time.Second, d)
+	})
+
+	t.Run("counts from the given time", func(t *testing.T) {
+		d, ok := TimeUntil(last.Add(time.Minute), last, 80, []float64{85, 90, 95, 100}, time.Minute, 95, true)
+		require.True(t, ok)
+		require.Equal(t, 2*time.Minute, d)
+	})
+
+	t.Run("is zero if the threshold is already reached", func(t *testing.T) {
+		d, ok := TimeUntil(last, last, 10, []float64{5}, time.Minute, 20, false)
+		require.True(t, ok)
+		require.Zero(t, d)
+	})
+
+	t.Run("is false if the threshold is not reached", func(t *testing.T) {
+		_, ok := TimeUntil(last, last, 80, []float64{79, 78}, time.Minute, 90, true)
+		require.False(t, ok)
+	})
+}
+
+func TestMedianStep(t *testing.T) {
+	require.Zero(t, MedianStep(minutes(1)))
+	times := append(minutes(4), time.Unix(3600, 0))
+	require.Equal(t, time.Minute, MedianStep(times))
+}
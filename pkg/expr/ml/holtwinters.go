@@ -0,0 +1,115 @@
+package ml
+
+import (
+	"fmt"
+	"math"
+)
+
+const (
+	defaultHoltWintersAlpha = 0.3
+	defaultHoltWintersBeta  = 0.1
+	defaultHoltWintersGamma = 0.3
+)
+
+// holtWinters is the state of additive triple exponential smoothing. With a season of
+// one point it is Holt's linear (double exponential) smoothing.
+type holtWinters struct {
+	alpha, beta, gamma float64
+	level, trend       float64
+	seasonal           []float64
+	// n is the index of the next point
+	n int
+}
+
+// holtWintersParams are the smoothing factors and the season length of the smoothing.
+type holtWintersParams struct {
+	alpha, beta, gamma float64
+	season             int
+}
+
+// newHoltWintersParams applies the default to factors that are 0 and validates them. A season
+// shorter than two points disables the seasonal component.
+func newHoltWintersParams(alpha, beta, gamma float64, season int) (holtWintersParams, error) {
+	if alpha == 0 {
+		alpha = defaultHoltWintersAlpha
+	}
+	if beta == 0 {
+		beta = defaultHoltWintersBeta
+	}
+	if gamma == 0 {
+		gamma = defaultHoltWintersGamma
+	}
+	for i, v := range []float64{alpha, beta, gamma} {
+		if v < 0 || v > 1 {
+			return holtWintersParams{}, fmt.Errorf("holt-winters %s must be between 0 and 1, got %v", []string{"alpha", "beta", "gamma"}[i], v)
+		}
+	}
+	if season < 2 {
+		season = 1
+		gamma = 0
+	}
+	return holtWintersParams{alpha: alpha, beta: beta, gamma: gamma, season: season}, nil
+}
+
+// init initializes the smoothing from the first two seasons of values, which must all be set.
+func (p holtWintersParams) init(values []*float64) (*holtWinters, error) {
+	season := p.season
+	if len(values) < 2*season {
+		return nil, fmt.Errorf("holt-winters requires at least %d points, got %d", 2*season, len(values))
+	}
+
+	var first, second float64
+	for i := 0; i < 2*season; i++ {
+		if values[i] == nil || math.IsNaN(*values[i]) {
+			return nil, fmt.Errorf("holt-winters requires the first %d points to have values", 2*season)
+		}
+		if i < season {
+			first += *values[i]
+		} else {
+			second += *values[i]
+		}
+	}
+	first /= float64(season)
+	second /= float64(season)
+
+	hw := &holtWinters{
+		alpha:    p.alpha,
+		beta:     p.beta,
+		gamma:    p.gamma,
+		level:    first,
+		trend:    (second - first) / float64(season),
+		seasonal: make([]float64, season),
+		n:        season,
+	}
+	if p.gamma > 0 {
+		for i := 0; i < season; i++ {
+			hw.seasonal[i] = *values[i] - first
+		}
+	}
+	return hw, nil
+}
+
+// seasonLength returns the number of points in a season.
+func (hw *holtWinters) seasonLength() int {
+	return len(hw.seasonal)
+}
+
+// forecast returns the predicted value h points after the last point.
+func (hw *holtWinters) forecast(h int) float64 {
+	return hw.level + float64(h)*hw.trend + hw.seasonal[(hw.n+h-1)%len(hw.seasonal)]
+}
+
+// update adds the next point to the smoothing. A missing value is replaced by its forecast.
+func (hw *holtWinters) update(v *float64) {
+	x := hw.forecast(1)
+	if v != nil && !math.IsNaN(*v) {
+		x = *v
+	}
+	idx := hw.n % len(hw.seasonal)
+	s := hw.seasonal[idx]
+	prevLevel := hw.level
+	hw.level = hw.alpha*(x-s) + (1-hw.alpha)*(hw.level+hw.trend)
+	hw.trend = hw.beta*(hw.level-prevLevel) + (1-hw.beta)*hw.trend
+	hw.seasonal[idx] = hw.gamma*(x-hw.level) + (1-hw.gamma)*s
+	hw.n++
+}
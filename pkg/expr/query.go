@@ -2,9 +2,11 @@ package expr
 
 import (
 	"embed"
+	"fmt"
 
 	"github.com/grafana/grafana/pkg/expr/classic"
 	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/expr/ml"
 )
 
 // Supported expression types
@@ -29,6 +31,15 @@ const (
 
 	// SQL query via DuckDB
 	QueryTypeSQL QueryType = "sql"
+
+	// Detect anomalies in time series
+	QueryTypeAnomaly QueryType = "anomaly"
+
+	// Predict the values of time series
+	QueryTypeForecast QueryType = "forecast"
+
+	// Apply dashboard transformations to query results
+	QueryTypeTransformation QueryType = "transformation"
 )
 
 type MathQuery struct {
@@ -43,6 +54,9 @@ type ReduceQuery struct {
 	// The reducer
 	Reducer mathexp.ReducerID `json:"reducer"`
 
+	// Parameters of the reducer, e.g. the percentile for percentile
+	Params []float64 `json:"params,omitempty"`
+
 	// Reducer Options
 	Settings *ReduceSettings `json:"settings,omitempty"`
 }
@@ -60,6 +74,13 @@ type ResampleQuery struct {
 
 	// The upsample function
 	Upsampler mathexp.Upsampler `json:"upsampler"`
+
+	// The number of windows a gap can span and still be filled, for the step upsampler (defaults to 1)
+	MaxGap int `json:"maxGap,omitempty" jsonschema:"minimum=1"`
+
+	// Align the samples to multiples of the window since the Unix epoch, shifted by this
+	// duration, instead of to the start of the time range
+	AlignOffset string `json:"alignOffset,omitempty" jsonschema:"example=0s,example=15m"`
 }
 
 type ThresholdQuery struct {
@@ -79,6 +100,95 @@ type SQLExpression struct {
 	Expression string `json:"expression" jsonschema:"minLength=1,example=SELECT * FROM A LIMIT 1"`
 }
 
+type AnomalyQuery struct {
+	// Reference to the time series to detect anomalies in
+	Expression string `json:"expression" jsonschema:"minLength=1,example=$A"`
+
+	// The detection algorithm
+	Algorithm ml.AnomalyAlgorithm `json:"algorithm"`
+
+	// The number of deviations the band spans on each side of the expected value, 3 by default
+	Sensitivity float64 `json:"sensitivity,omitempty" jsonschema:"minimum=0"`
+
+	// The number of preceding points used by zscore and mad, all of them when 0
+	Window int `json:"window,omitempty" jsonschema:"minimum=0"`
+
+	// The number of points in a season for holt_winters, no seasonality when less than 2
+	Season int `json:"season,omitempty" jsonschema:"minimum=0"`
+
+	// The holt_winters smoothing factor of the level
+	Alpha float64 `json:"alpha,omitempty" jsonschema:"minimum=0,maximum=1"`
+
+	// The holt_winters smoothing factor of the trend
+	Beta float64 `json:"beta,omitempty" jsonschema:"minimum=0,maximum=1"`
+
+	// The holt_winters smoothing factor of the season
+	Gamma float64 `json:"gamma,omitempty" jsonschema:"minimum=0,maximum=1"`
+
+	// The series to return, the flag and both bands when empty
+	Output AnomalyOutput `json:"output,omitempty"`
+}
+
+func (q AnomalyQuery) configuration() ml.AnomalyConfiguration {
+	return ml.AnomalyConfiguration{
+		Algorithm:   q.Algorithm,
+		Sensitivity: q.Sensitivity,
+		Window:      q.Window,
+		Season:      q.Season,
+		Alpha:       q.Alpha,
+		Beta:        q.Beta,
+		Gamma:       q.Gamma,
+	}
+}
+
+type ForecastQuery struct {
+	// Reference to the time series to forecast
+	Expression string `json:"expression" jsonschema:"minLength=1,example=$A"`
+
+	// The forecast method
+	Method ml.ForecastMethod `json:"method"`
+
+	// How far after the last point to forecast
+	Horizon string `json:"horizon" jsonschema:"minLength=1,example=4h,example=7d"`
+
+	// The number of points in a season for holt_winters, no seasonality when less than 2
+	Season int `json:"season,omitempty" jsonschema:"minimum=0"`
+
+	// The holt_winters smoothing factor of the level
+	Alpha float64 `json:"alpha,omitempty" jsonschema:"minimum=0,maximum=1"`
+
+	// The holt_winters smoothing factor of the trend
+	Beta float64 `json:"beta,omitempty" jsonschema:"minimum=0,maximum=1"`
+
+	// The holt_winters smoothing factor of the season
+	Gamma float64 `json:"gamma,omitempty" jsonschema:"minimum=0,maximum=1"`
+
+	// What to return for each series, the forecast series when empty
+	Output ForecastOutput `json:"output,omitempty"`
+
+	// The threshold condition of the time_until_threshold output, gt for rising and lt for falling values
+	Evaluator *ConditionEvalJSON `json:"evaluator,omitempty"`
+}
+
+func (q ForecastQuery) command(refID, varToForecast string) (*ForecastCommand, error) {
+	cfg := ml.ForecastConfiguration{
+		Method: q.Method,
+		Season: q.Season,
+		Alpha:  q.Alpha,
+		Beta:   q.Beta,
+		Gamma:  q.Gamma,
+	}
+	var thresholdFunc ThresholdType
+	var threshold float64
+	if q.Output == ForecastOutputTimeUntilThreshold {
+		if q.Evaluator == nil || len(q.Evaluator.Params) != 1 {
+			return nil, fmt.Errorf("output %s requires an evaluator with one parameter", ForecastOutputTimeUntilThreshold)
+		}
+		thresholdFunc, threshold = q.Evaluator.Type, q.Evaluator.Params[0]
+	}
+	return NewForecastCommand(refID, varToForecast, cfg, q.Horizon, q.Output, thresholdFunc, threshold)
+}
+
 //-------------------------------
 // Non-query commands
 //-------------------------------
@@ -112,3 +222,23 @@ var f embed.FS
 func QueryTypeDefinitionListJSON() ([]byte, error) {
 	return f.ReadFile("query.types.json")
 }
+
+type TransformationQuery struct {
+	// The refIds of the query results to transform
+	Inputs []string `json:"inputs" jsonschema:"minItems=1,example=A"`
+
+	// The transformations to apply to the frames of the inputs, in order
+	Transformations []DataTransformerConfig `json:"transformations"`
+}
+
+// DataTransformerConfig is a transformation as it is saved in a panel of the dashboard JSON
+type DataTransformerConfig struct {
+	// The id of the transformation, e.g. organize, joinByField, groupBy, merge, filterByValue or calculateField
+	ID string `json:"id" jsonschema:"minLength=1"`
+
+	// Disabled transformations are skipped
+	Disabled bool `json:"disabled,omitempty"`
+
+	// The options of the transformation
+	Options map[string]any `json:"options,omitempty"`
+}
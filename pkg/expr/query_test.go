@@ -11,6 +11,7 @@ import (
 
 	"github.com/grafana/grafana/pkg/expr/classic"
 	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/expr/ml"
 )
 
 func TestQueryTypeDefinitions(t *testing.T) {
@@ -27,6 +28,10 @@ func TestQueryTypeDefinitions(t *testing.T) {
 				reflect.TypeOf(ReduceModeDrop),       // pick an example value (not the root)
 				reflect.TypeOf(ThresholdIsAbove),
 				reflect.TypeOf(classic.ConditionOperatorAnd),
+				reflect.TypeOf(ml.AnomalyZScore),
+				reflect.TypeOf(AnomalyOutputFlag),
+				reflect.TypeOf(ml.ForecastLinear),
+				reflect.TypeOf(ForecastOutputTimeUntilThreshold),
 			},
 		})
 	require.NoError(t, err)
@@ -92,6 +97,75 @@ func TestQueryTypeDefinitions(t *testing.T) {
 				},
 			},
 		},
+		schemabuilder.QueryTypeInfo{
+			Discriminators: data.NewDiscriminators("type", QueryTypeAnomaly),
+			GoType:         reflect.TypeOf(&AnomalyQuery{}),
+			Examples: []data.QueryExample{
+				{
+					Name: "Flag values of A outside of 3 standard deviations",
+					SaveModel: data.AsUnstructured(AnomalyQuery{
+						Expression: "$A",
+						Algorithm:  ml.AnomalyZScore,
+						Output:     AnomalyOutputFlag,
+					}),
+				},
+				{
+					Name: "Daily seasonal bands of hourly data",
+					SaveModel: data.AsUnstructured(AnomalyQuery{
+						Expression: "$A",
+						Algorithm:  ml.AnomalyHoltWinters,
+						Season:     24,
+					}),
+				},
+			},
+		},
+		schemabuilder.QueryTypeInfo{
+			Discriminators: data.NewDiscriminators("type", QueryTypeForecast),
+			GoType:         reflect.TypeOf(&ForecastQuery{}),
+			Examples: []data.QueryExample{
+				{
+					Name: "Forecast A for the next day",
+					SaveModel: data.AsUnstructured(ForecastQuery{
+						Expression: "$A",
+						Method:     ml.ForecastLinear,
+						Horizon:    "1d",
+					}),
+				},
+				{
+					Name: "Seconds until A is above 95 within 4 hours",
+					SaveModel: data.AsUnstructured(ForecastQuery{
+						Expression: "$A",
+						Method:     ml.ForecastLinear,
+						Horizon:    "4h",
+						Output:     ForecastOutputTimeUntilThreshold,
+						Evaluator: &ConditionEvalJSON{
+							Type:   ThresholdIsAbove,
+							Params: []float64{95},
+						},
+					}),
+				},
+			},
+		},
+		schemabuilder.QueryTypeInfo{
+			Discriminators: data.NewDiscriminators("type", QueryTypeTransformation),
+			GoType:         reflect.TypeOf(&TransformationQuery{}),
+			Examples: []data.QueryExample{
+				{
+					Name: "Join A and B by time and add the sum of their values",
+					SaveModel: data.AsUnstructured(TransformationQuery{
+						Inputs: []string{"A", "B"},
+						Transformations: []DataTransformerConfig{
+							{ID: "joinByField", Options: map[string]any{"byField": "time"}},
+							{ID: "calculateField", Options: map[string]any{
+								"mode":   "reduceRow",
+								"reduce": map[string]any{"reducer": "sum"},
+								"alias":  "total",
+							}},
+						},
+					}),
+				},
+			},
+		},
 		schemabuilder.QueryTypeInfo{
 			Discriminators: data.NewDiscriminators("type", QueryTypeClassic),
 			GoType:         reflect.TypeOf(&ClassicQuery{}),
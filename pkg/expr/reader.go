@@ -90,7 +90,7 @@ func (h *ExpressionQueryReader) ReadQuery(
 		if err == nil {
 			eq.Properties = q
 			eq.Command, err = NewReduceCommand(common.RefID,
-				q.Reducer, referenceVar, mapper)
+				q.Reducer, referenceVar, mapper, q.Params...)
 		}
 
 	case QueryTypeResample:
@@ -116,6 +116,12 @@ func (h *ExpressionQueryReader) ReadQuery(
 				},
 			)
 		}
+		if err == nil && q.MaxGap != 0 {
+			eq.Command, err = eq.Command.(*ResampleCommand).WithMaxGap(q.MaxGap)
+		}
+		if err == nil && q.AlignOffset != "" {
+			eq.Command, err = eq.Command.(*ResampleCommand).WithAlignOffset(q.AlignOffset)
+		}
 
 	case QueryTypeClassic:
 		q := &ClassicQuery{}
@@ -137,6 +143,36 @@ func (h *ExpressionQueryReader) ReadQuery(
 			eq.Command, err = NewSQLCommand(common.RefID, q.Expression)
 		}
 
+	case QueryTypeAnomaly:
+		q := &AnomalyQuery{}
+		err = iter.ReadVal(q)
+		if err == nil {
+			referenceVar, err = getReferenceVar(q.Expression, common.RefID)
+		}
+		if err == nil {
+			eq.Properties = q
+			eq.Command, err = NewAnomalyCommand(common.RefID, referenceVar, q.configuration(), q.Output)
+		}
+
+	case QueryTypeForecast:
+		q := &ForecastQuery{}
+		err = iter.ReadVal(q)
+		if err == nil {
+			referenceVar, err = getReferenceVar(q.Expression, common.RefID)
+		}
+		if err == nil {
+			eq.Properties = q
+			eq.Command, err = q.command(common.RefID, referenceVar)
+		}
+
+	case QueryTypeTransformation:
+		q := &TransformationQuery{}
+		err = iter.ReadVal(q)
+		if err == nil {
+			eq.Properties = q
+			eq.Command, err = NewTransformationCommand(common.RefID, q.Inputs, q.Transformations, h.features)
+		}
+
 	case QueryTypeThreshold:
 		q := &ThresholdQuery{}
 		err = iter.ReadVal(q)
@@ -192,9 +228,5 @@ func getReferenceVar(exp string, refId string) (string, error) {
 }
 
 func enableSqlExpressions(h *ExpressionQueryReader) bool {
-	enabled := !h.features.IsEnabledGlobally(featuremgmt.FlagSqlExpressions)
-	if enabled {
-		return false
-	}
-	return false
+	return h.features.IsEnabledGlobally(featuremgmt.FlagSqlExpressions)
 }
@@ -91,6 +91,45 @@ func ProvideService(cfg *setting.Cfg, pluginClient plugins.Client, pCtxProvider
 	}
 }
 
+// NewOfflineService returns a Service that answers the queries of data source nodes with the given handler instead
+// of the data source plugins. It is intended for running expressions on synthetic data, for example in the unit tests
+// of alert rules. Machine learning nodes are not supported.
+func NewOfflineService(cfg *setting.Cfg, dataService backend.QueryDataHandler, features featuremgmt.FeatureToggles, tracer tracing.Tracer) *Service {
+	return &Service{
+		cfg:          cfg,
+		dataService:  dataService,
+		pCtxProvider: offlinePluginContextProvider{},
+		features:     features,
+		tracer:       tracer,
+		metrics:      newMetrics(nil),
+		converter: &ResultConverter{
+			Features: features,
+			Tracer:   tracer,
+		},
+	}
+}
+
+// offlinePluginContextProvider builds plugin contexts without looking up plugins or decrypting data source secrets.
+type offlinePluginContextProvider struct{}
+
+// Get is only used by machine learning nodes, which require the plugin.
+func (offlinePluginContextProvider) Get(_ context.Context, _ string, _ identity.Requester, _ int64) (backend.PluginContext, error) {
+	return backend.PluginContext{}, plugins.ErrPluginNotRegistered
+}
+
+func (offlinePluginContextProvider) GetWithDataSource(_ context.Context, pluginID string, _ identity.Requester, ds *datasources.DataSource) (backend.PluginContext, error) {
+	return backend.PluginContext{
+		PluginID: pluginID,
+		OrgID:    ds.OrgID,
+		DataSourceInstanceSettings: &backend.DataSourceInstanceSettings{
+			ID:   ds.ID,
+			UID:  ds.UID,
+			Type: ds.Type,
+			Name: ds.Name,
+		},
+	}, nil
+}
+
 func (s *Service) isDisabled() bool {
 	if s.cfg == nil {
 		return true
@@ -107,6 +146,9 @@ func (s *Service) BuildPipeline(req *Request) (DataPipeline, error) {
 func (s *Service) ExecutePipeline(ctx context.Context, now time.Time, pipeline DataPipeline) (*backend.QueryDataResponse, error) {
 	ctx, span := s.tracer.Start(ctx, "SSE.ExecutePipeline")
 	defer span.End()
+	if e := ExplanationFromContext(ctx); e != nil {
+		e.start(pipeline)
+	}
 	res := backend.NewQueryDataResponse()
 	vars, err := pipeline.execute(ctx, now, s)
 	if err != nil {
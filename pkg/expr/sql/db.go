@@ -1,22 +1,508 @@
 package sql
 
 import (
-	"errors"
+	"context"
+	gosql "database/sql"
+	"encoding/json"
+	"fmt"
+	"maps"
+	"math"
+	"slices"
+	"strings"
+	"sync"
+	"time"
 
+	"github.com/grafana/grafana-plugin-sdk-go/backend/gtime"
 	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/mattn/go-sqlite3"
 )
 
+// driverName is the name of the SQLite driver registered with the SQL expression functions.
+const driverName = "sqlite3_sql_expr"
+
+// timeFormat is the format in which time values are stored in, and read back from, the engine.
+var timeFormat = sqlite3.SQLiteTimestampFormats[0]
+
+var registerDriver sync.Once
+
+// DB is an in-memory SQL engine, backed by SQLite, that runs SQL expressions
+// over data frames. Every frame is loaded as a table named by its RefID.
 type DB struct {
 }
 
-func (db *DB) RunCommands(commands []string) (string, error) {
-	return "", errors.New("not implemented")
+// RunCommands executes the commands in order, and returns the rows of the
+// last command as a JSON array of objects.
+func (db *DB) RunCommands(ctx context.Context, commands []string) (string, error) {
+	conn, err := db.open()
+	if err != nil {
+		return "", err
+	}
+	defer func() { _ = conn.Close() }()
+
+	if len(commands) == 0 {
+		return "", nil
+	}
+
+	for _, cmd := range commands[:len(commands)-1] {
+		if _, err := conn.ExecContext(ctx, cmd); err != nil {
+			return "", err
+		}
+	}
+
+	f, err := queryFrame(ctx, conn, "", commands[len(commands)-1])
+	if err != nil {
+		return "", err
+	}
+
+	rows := make([]map[string]any, f.Rows())
+	for i := range rows {
+		rows[i] = make(map[string]any, len(f.Fields))
+		for _, field := range f.Fields {
+			v, _ := field.ConcreteAt(i)
+			rows[i][field.Name] = v
+		}
+	}
+	b, err := json.Marshal(rows)
+	if err != nil {
+		return "", err
+	}
+	return string(b), nil
 }
 
-func (db *DB) QueryFramesInto(name string, query string, frames []*data.Frame, f *data.Frame) error {
-	return errors.New("not implemented")
+// QueryFramesInto loads the frames into tables named by their RefID, runs the query
+// and writes the result into f. Frames sharing a RefID are loaded into the same table.
+func (db *DB) QueryFramesInto(ctx context.Context, name string, query string, frames []*data.Frame, f *data.Frame) error {
+	conn, err := db.open()
+	if err != nil {
+		return err
+	}
+	defer func() { _ = conn.Close() }()
+
+	tables := map[string][]*data.Frame{}
+	order := []string{}
+	for _, frame := range frames {
+		if _, ok := tables[frame.RefID]; !ok {
+			order = append(order, frame.RefID)
+		}
+		tables[frame.RefID] = append(tables[frame.RefID], frame)
+	}
+	for _, table := range order {
+		if err := loadTable(ctx, conn, table, tables[table]); err != nil {
+			return fmt.Errorf("failed to load table %s: %w", table, err)
+		}
+	}
+
+	result, err := queryFrame(ctx, conn, name, query)
+	if err != nil {
+		return err
+	}
+	*f = *result
+	return nil
 }
 
+// NewInMemoryDB creates a new DB. Every call to RunCommands or QueryFramesInto
+// runs against a fresh, empty database.
 func NewInMemoryDB() *DB {
 	return &DB{}
 }
+
+func (db *DB) open() (*gosql.DB, error) {
+	registerDriver.Do(func() {
+		gosql.Register(driverName, &sqlite3.SQLiteDriver{
+			ConnectHook: func(conn *sqlite3.SQLiteConn) error {
+				conn.RegisterAuthorizer(authorize)
+				return conn.RegisterFunc("time_bucket", timeBucket, true)
+			},
+		})
+	})
+
+	conn, err := gosql.Open(driverName, ":memory:")
+	if err != nil {
+		return nil, err
+	}
+	// every connection to :memory: is a different database, so make sure there is only one
+	conn.SetMaxOpenConns(1)
+	return conn, nil
+}
+
+// authorize is the SQLite authorizer of the engine. It denies the statements that reach outside of the in-memory
+// database, such as attaching a database file, or change the configuration of the engine.
+func authorize(action int, _, _, _ string) int {
+	switch action {
+	case sqlite3.SQLITE_ATTACH, sqlite3.SQLITE_DETACH, sqlite3.SQLITE_PRAGMA:
+		return sqlite3.SQLITE_DENY
+	default:
+		return sqlite3.SQLITE_OK
+	}
+}
+
+type column struct {
+	name    string
+	sqlType string
+}
+
+// loadTable creates a table from the frames and inserts their rows. The columns are
+// the union of the field names of all the frames. Field labels are added as text
+// columns so that series of the same query can be told apart.
+func loadTable(ctx context.Context, conn *gosql.DB, table string, frames []*data.Frame) error {
+	columns := []column{}
+	index := map[string]int{}
+	addColumn := func(name, sqlType string) {
+		if i, ok := index[name]; ok {
+			if columns[i].sqlType != sqlType {
+				columns[i].sqlType = ""
+			}
+			return
+		}
+		index[name] = len(columns)
+		columns = append(columns, column{name: name, sqlType: sqlType})
+	}
+
+	for _, frame := range frames {
+		for i, field := range frame.Fields {
+			addColumn(fieldColumnName(field, i), sqlType(field.Type()))
+		}
+	}
+	labelKeys := map[string]bool{}
+	for _, frame := range frames {
+		for _, field := range frame.Fields {
+			for k := range field.Labels {
+				if _, ok := index[k]; !ok {
+					labelKeys[k] = true
+				}
+			}
+		}
+	}
+	for _, k := range slices.Sorted(maps.Keys(labelKeys)) {
+		addColumn(k, "TEXT")
+	}
+	if len(columns) == 0 {
+		return nil
+	}
+
+	defs := make([]string, len(columns))
+	placeholders := make([]string, len(columns))
+	for i, c := range columns {
+		defs[i] = quoteIdent(c.name) + " " + c.sqlType
+		placeholders[i] = "?"
+	}
+	if _, err := conn.ExecContext(ctx, fmt.Sprintf("CREATE TABLE %s (%s)", quoteIdent(table), strings.Join(defs, ", "))); err != nil {
+		return err
+	}
+
+	tx, err := conn.BeginTx(ctx, nil)
+	if err != nil {
+		return err
+	}
+	stmt, err := tx.PrepareContext(ctx, fmt.Sprintf("INSERT INTO %s VALUES (%s)", quoteIdent(table), strings.Join(placeholders, ", ")))
+	if err != nil {
+		_ = tx.Rollback()
+		return err
+	}
+	defer func() { _ = stmt.Close() }()
+
+	for _, frame := range frames {
+		rows, _ := frame.RowLen()
+		for r := 0; r < rows; r++ {
+			args := make([]any, len(columns))
+			for i, field := range frame.Fields {
+				args[index[fieldColumnName(field, i)]] = sqlValue(field, r)
+			}
+			for _, field := range frame.Fields {
+				for k, v := range field.Labels {
+					if labelKeys[k] {
+						args[index[k]] = v
+					}
+				}
+			}
+			if _, err := stmt.ExecContext(ctx, args...); err != nil {
+				_ = tx.Rollback()
+				return err
+			}
+		}
+	}
+	return tx.Commit()
+}
+
+func fieldColumnName(field *data.Field, idx int) string {
+	if field.Name != "" {
+		return field.Name
+	}
+	if field.Type().Time() {
+		return "time"
+	}
+	if idx == 1 {
+		return "value"
+	}
+	return fmt.Sprintf("field_%d", idx)
+}
+
+func sqlType(t data.FieldType) string {
+	switch {
+	case t.Time():
+		return "TIMESTAMP"
+	case t == data.FieldTypeBool || t == data.FieldTypeNullableBool:
+		return "BOOLEAN"
+	case t == data.FieldTypeFloat32 || t == data.FieldTypeNullableFloat32 ||
+		t == data.FieldTypeFloat64 || t == data.FieldTypeNullableFloat64:
+		return "REAL"
+	case t.Numeric():
+		return "INTEGER"
+	default:
+		return "TEXT"
+	}
+}
+
+func sqlValue(field *data.Field, idx int) any {
+	v, ok := field.ConcreteAt(idx)
+	if !ok {
+		return nil
+	}
+	switch val := v.(type) {
+	case time.Time:
+		return val.UTC().Format(timeFormat)
+	case float64:
+		if math.IsNaN(val) || math.IsInf(val, 0) {
+			return nil
+		}
+		return val
+	case float32:
+		if math.IsNaN(float64(val)) || math.IsInf(float64(val), 0) {
+			return nil
+		}
+		return float64(val)
+	case uint64:
+		if val > math.MaxInt64 {
+			return float64(val)
+		}
+		return int64(val)
+	case json.RawMessage:
+		return string(val)
+	default:
+		return val
+	}
+}
+
+func quoteIdent(s string) string {
+	return `"` + strings.ReplaceAll(s, `"`, `""`) + `"`
+}
+
+// queryFrame runs the statement and converts the result to a frame. The type of each field is
+// taken from the values in the column, since SQLite only knows the declared type of
+// columns that are read directly from a table.
+func queryFrame(ctx context.Context, conn *gosql.DB, name string, q string) (*data.Frame, error) {
+	rows, err := conn.QueryContext(ctx, q)
+	if err != nil {
+		return nil, err
+	}
+	defer func() { _ = rows.Close() }()
+
+	names, err := rows.Columns()
+	if err != nil {
+		return nil, err
+	}
+
+	values := make([][]any, len(names))
+	for rows.Next() {
+		row := make([]any, len(names))
+		ptrs := make([]any, len(names))
+		for i := range row {
+			ptrs[i] = &row[i]
+		}
+		if err := rows.Scan(ptrs...); err != nil {
+			return nil, err
+		}
+		for i, v := range row {
+			values[i] = append(values[i], v)
+		}
+	}
+	if err := rows.Err(); err != nil {
+		return nil, err
+	}
+
+	f := data.NewFrame(name)
+	for i, n := range names {
+		f.Fields = append(f.Fields, toField(n, values[i]))
+	}
+	return f, nil
+}
+
+// toField converts a column of values returned by the driver to a nullable field.
+func toField(name string, values []any) *data.Field {
+	kind := columnKind(values)
+	switch kind {
+	case data.FieldTypeNullableTime:
+		field := data.NewField(name, nil, make([]*time.Time, len(values)))
+		for i, v := range values {
+			switch t := v.(type) {
+			case time.Time:
+				t = t.UTC()
+				field.Set(i, &t)
+			case string:
+				if parsed, err := time.Parse(timeFormat, t); err == nil {
+					parsed = parsed.UTC()
+					field.Set(i, &parsed)
+				}
+			}
+		}
+		return field
+	case data.FieldTypeNullableInt64:
+		field := data.NewField(name, nil, make([]*int64, len(values)))
+		for i, v := range values {
+			if n, ok := v.(int64); ok {
+				field.Set(i, &n)
+			}
+		}
+		return field
+	case data.FieldTypeNullableFloat64:
+		field := data.NewField(name, nil, make([]*float64, len(values)))
+		for i, v := range values {
+			switch n := v.(type) {
+			case int64:
+				f := float64(n)
+				field.Set(i, &f)
+			case float64:
+				field.Set(i, &n)
+			}
+		}
+		return field
+	case data.FieldTypeNullableBool:
+		field := data.NewField(name, nil, make([]*bool, len(values)))
+		for i, v := range values {
+			if b, ok := v.(bool); ok {
+				field.Set(i, &b)
+			}
+		}
+		return field
+	default:
+		field := data.NewField(name, nil, make([]*string, len(values)))
+		for i, v := range values {
+			var s string
+			switch t := v.(type) {
+			case nil:
+				continue
+			case string:
+				s = t
+			case []byte:
+				s = string(t)
+			case time.Time:
+				s = t.UTC().Format(timeFormat)
+			default:
+				s = fmt.Sprintf("%v", t)
+			}
+			field.Set(i, &s)
+		}
+		return field
+	}
+}
+
+// columnKind returns the field type that can hold all the values of the column.
+// Text values in the engine's time format are returned as time, so that results
+// of time functions, e.g. time_bucket or max(time), stay time fields.
+func columnKind(values []any) data.FieldType {
+	kind := data.FieldTypeUnknown
+	merge := func(t data.FieldType) {
+		switch {
+		case kind == data.FieldTypeUnknown:
+			kind = t
+		case kind == t:
+		case (kind == data.FieldTypeNullableInt64 && t == data.FieldTypeNullableFloat64) ||
+			(kind == data.FieldTypeNullableFloat64 && t == data.FieldTypeNullableInt64):
+			kind = data.FieldTypeNullableFloat64
+		default:
+			kind = data.FieldTypeNullableString
+		}
+	}
+
+	for _, v := range values {
+		switch t := v.(type) {
+		case nil:
+		case time.Time:
+			merge(data.FieldTypeNullableTime)
+		case int64:
+			merge(data.FieldTypeNullableInt64)
+		case float64:
+			merge(data.FieldTypeNullableFloat64)
+		case bool:
+			merge(data.FieldTypeNullableBool)
+		case string:
+			if _, err := time.Parse(timeFormat, t); err == nil {
+				merge(data.FieldTypeNullableTime)
+			} else {
+				merge(data.FieldTypeNullableString)
+			}
+		default:
+			merge(data.FieldTypeNullableString)
+		}
+	}
+
+	if kind == data.FieldTypeUnknown {
+		return data.FieldTypeNullableString
+	}
+	return kind
+}
+
+// timeBucket implements the time_bucket(width, time) SQL function. It truncates time to a
+// multiple of width, which is either a duration string such as '5m' or a number of seconds.
+func timeBucket(width any, ts any) (any, error) {
+	if ts == nil {
+		return nil, nil
+	}
+
+	var d time.Duration
+	switch w := width.(type) {
+	case string:
+		var err error
+		if d, err = gtime.ParseDuration(w); err != nil {
+			return nil, fmt.Errorf("time_bucket: invalid width %q: %w", w, err)
+		}
+	case []byte:
+		var err error
+		if d, err = gtime.ParseDuration(string(w)); err != nil {
+			return nil, fmt.Errorf("time_bucket: invalid width %q: %w", w, err)
+		}
+	case int64:
+		d = time.Duration(w) * time.Second
+	case float64:
+		d = time.Duration(w * float64(time.Second))
+	default:
+		return nil, fmt.Errorf("time_bucket: width must be a duration or a number of seconds, got %T", width)
+	}
+	if d <= 0 {
+		return nil, fmt.Errorf("time_bucket: width must be positive")
+	}
+
+	var t time.Time
+	switch v := ts.(type) {
+	case string:
+		parsed, err := parseTime(v)
+		if err != nil {
+			return nil, fmt.Errorf("time_bucket: %w", err)
+		}
+		t = parsed
+	case []byte:
+		parsed, err := parseTime(string(v))
+		if err != nil {
+			return nil, fmt.Errorf("time_bucket: %w", err)
+		}
+		t = parsed
+	case int64:
+		t = time.Unix(v, 0)
+	case float64:
+		t = time.Unix(0, int64(v*float64(time.Second)))
+	default:
+		return nil, fmt.Errorf("time_bucket: time must be a timestamp, got %T", ts)
+	}
+
+	return t.UTC().Truncate(d).Format(timeFormat), nil
+}
+
+func parseTime(s string) (time.Time, error) {
+	s = strings.TrimSuffix(s, "Z")
+	for _, format := range sqlite3.SQLiteTimestampFormats {
+		if t, err := time.ParseInLocation(format, s, time.UTC); err == nil {
+			return t, nil
+		}
+	}
+	return time.Time{}, fmt.Errorf("invalid time %q", s)
+}
@@ -0,0 +1,130 @@
+package sql
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func seriesFrame(refID string, labels data.Labels, start time.Time, values ...float64) *data.Frame {
+	times := make([]time.Time, len(values))
+	for i := range values {
+		times[i] = start.Add(time.Duration(i) * time.Minute)
+	}
+	f := data.NewFrame("",
+		data.NewField("time", nil, times),
+		data.NewField("value", labels, values),
+	)
+	f.RefID = refID
+	return f
+}
+
+func TestQueryFramesInto(t *testing.T) {
+	start := time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+
+	t.Run("loads series with labels as a table named by refID", func(t *testing.T) {
+		frames := []*data.Frame{
+			seriesFrame("A", data.Labels{"host": "a"}, start, 1, 2, 3),
+			seriesFrame("A", data.Labels{"host": "b"}, start, 10, 20, 30),
+		}
+		f := &data.Frame{}
+		err := NewInMemoryDB().QueryFramesInto(context.Background(), "B", `SELECT host, sum(value) AS total FROM A GROUP BY host ORDER BY host`, frames, f)
+		require.NoError(t, err)
+
+		require.Equal(t, "B", f.Name)
+		require.Len(t, f.Fields, 2)
+		require.Equal(t, 2, f.Rows())
+		host, _ := f.Fields[0].ConcreteAt(0)
+		require.Equal(t, "a", host)
+		total, _ := f.Fields[1].ConcreteAt(1)
+		require.Equal(t, float64(60), total)
+	})
+
+	t.Run("joins tables from different refIDs", func(t *testing.T) {
+		a := data.NewFrame("",
+			data.NewField("host", nil, []string{"a", "b"}),
+			data.NewField("cpu", nil, []float64{0.5, 0.9}),
+		)
+		a.RefID = "A"
+		b := data.NewFrame("",
+			data.NewField("host", nil, []string{"a", "b"}),
+			data.NewField("owner", nil, []string{"team-1", "team-2"}),
+		)
+		b.RefID = "B"
+
+		f := &data.Frame{}
+		err := NewInMemoryDB().QueryFramesInto(context.Background(), "C", `SELECT A.host, cpu, owner FROM A JOIN B ON A.host = B.host WHERE cpu > 0.6`, []*data.Frame{a, b}, f)
+		require.NoError(t, err)
+
+		require.Equal(t, 1, f.Rows())
+		owner, _ := f.Fields[2].ConcreteAt(0)
+		require.Equal(t, "team-2", owner)
+	})
+
+	t.Run("supports window functions", func(t *testing.T) {
+		frames := []*data.Frame{seriesFrame("A", nil, start, 1, 2, 3, 4)}
+		f := &data.Frame{}
+		err := NewInMemoryDB().QueryFramesInto(context.Background(), "B", `SELECT time, sum(value) OVER (ORDER BY time) AS running FROM A ORDER BY time`, frames, f)
+		require.NoError(t, err)
+
+		require.Equal(t, data.FieldTypeNullableTime, f.Fields[0].Type())
+		running, _ := f.Fields[1].ConcreteAt(3)
+		require.Equal(t, float64(10), running)
+	})
+
+	t.Run("buckets time with time_bucket", func(t *testing.T) {
+		frames := []*data.Frame{seriesFrame("A", nil, start, 1, 2, 3, 4, 5, 6)}
+		f := &data.Frame{}
+		err := NewInMemoryDB().QueryFramesInto(context.Background(), "B", `SELECT time_bucket('2m', time) AS bucket, avg(value) AS avg FROM A GROUP BY bucket ORDER BY bucket`, frames, f)
+		require.NoError(t, err)
+
+		require.Equal(t, 3, f.Rows())
+		require.Equal(t, data.FieldTypeNullableTime, f.Fields[0].Type())
+		bucket, _ := f.Fields[0].ConcreteAt(1)
+		require.Equal(t, start.Add(2*time.Minute), bucket)
+		avg, _ := f.Fields[1].ConcreteAt(1)
+		require.Equal(t, 3.5, avg)
+	})
+
+	t.Run("returns an error for an invalid query", func(t *testing.T) {
+		frames := []*data.Frame{seriesFrame("A", nil, start, 1)}
+		err := NewInMemoryDB().QueryFramesInto(context.Background(), "B", `SELECT * FROM X`, frames, &data.Frame{})
+		require.Error(t, err)
+	})
+}
+
+func TestRunCommands(t *testing.T) {
+	ret, err := NewInMemoryDB().RunCommands(context.Background(), []string{
+		"CREATE TABLE foo (a INTEGER)",
+		"INSERT INTO foo VALUES (1), (2)",
+		"SELECT sum(a) AS total FROM foo",
+	})
+	require.NoError(t, err)
+	require.JSONEq(t, `[{"total": 3}]`, ret)
+}
+
+func TestQueryFramesIntoDeniesStatementsOutsideOfTheDatabase(t *testing.T) {
+	frames := []*data.Frame{seriesFrame("A", nil, time.Unix(0, 0), 1)}
+	for _, q := range []string{
+		`ATTACH DATABASE '/tmp/sql-expression.db' AS other`,
+		`DETACH DATABASE main`,
+		`PRAGMA table_info(A)`,
+		`SELECT * FROM pragma_table_info('A')`,
+	} {
+		t.Run(q, func(t *testing.T) {
+			err := NewInMemoryDB().QueryFramesInto(context.Background(), "B", q, frames, &data.Frame{})
+			require.ErrorContains(t, err, "not authorized")
+		})
+	}
+}
+
+func TestQueryFramesIntoStopsWhenTheContextIsCanceled(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	frames := []*data.Frame{seriesFrame("A", nil, time.Unix(0, 0), 1)}
+	err := NewInMemoryDB().QueryFramesInto(ctx, "B", `SELECT * FROM A`, frames, &data.Frame{})
+	require.ErrorIs(t, err, context.Canceled)
+}
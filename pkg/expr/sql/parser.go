@@ -1,82 +1,290 @@
 package sql
 
 import (
-	"encoding/json"
 	"fmt"
 	"sort"
 	"strings"
 
 	"github.com/grafana/grafana/pkg/infra/log"
-	"github.com/jeremywohl/flatten"
 )
 
+var logger = log.New("sql_expr")
+
+// clauseKeywords end a FROM clause, or a table reference inside one.
+var clauseKeywords = map[string]bool{
+	"where": true, "group": true, "order": true, "limit": true, "having": true,
+	"window": true, "union": true, "intersect": true, "except": true, "offset": true,
+	"on": true, "using": true, "join": true, "inner": true, "left": true, "right": true,
+	"full": true, "outer": true, "cross": true, "natural": true, "select": true,
+	"returning": true, "values": true, "set": true, "fetch": true, "qualify": true,
+}
+
+type tokenKind int
+
 const (
-	TABLE_NAME    = "table_name"
-	ERROR         = ".error"
-	ERROR_MESSAGE = ".error_message"
+	tokenIdent tokenKind = iota
+	tokenQuotedIdent
+	tokenString
+	tokenNumber
+	tokenPunct
 )
 
-var logger = log.New("sql_expr")
+type token struct {
+	kind  tokenKind
+	value string
+}
+
+func (t token) isKeyword(kw string) bool {
+	return t.kind == tokenIdent && strings.EqualFold(t.value, kw)
+}
+
+func (t token) isPunct(p string) bool {
+	return t.kind == tokenPunct && t.value == p
+}
 
 // TablesList returns a list of tables for the sql statement
 func TablesList(rawSQL string) ([]string, error) {
-	db := NewInMemoryDB()
-	rawSQL = strings.Replace(rawSQL, "'", "''", -1)
-	cmd := fmt.Sprintf("SELECT json_serialize_sql('%s')", rawSQL)
-	ret, err := db.RunCommands([]string{cmd})
+	tokens, err := tokenize(rawSQL)
 	if err != nil {
-		logger.Error("error serializing sql", "error", err.Error(), "sql", rawSQL, "cmd", cmd)
-		return nil, fmt.Errorf("error serializing sql: %s", err.Error())
+		logger.Error("error tokenizing sql", "error", err.Error(), "sql", rawSQL)
+		return nil, fmt.Errorf("error in sql: %s", err.Error())
 	}
 
-	ast := []map[string]any{}
-	err = json.Unmarshal([]byte(ret), &ast)
+	tables, err := tablesFromTokens(tokens)
 	if err != nil {
-		logger.Error("error converting json sql to ast", "error", err.Error(), "ret", ret)
-		return nil, fmt.Errorf("error converting json to ast: %s", err.Error())
+		logger.Error("error reading tables from sql", "error", err.Error(), "sql", rawSQL)
+		return nil, fmt.Errorf("error in sql: %s", err.Error())
 	}
+	sort.Strings(tables)
 
-	return tablesFromAST(ast)
-}
+	logger.Debug("tables found in sql", "tables", tables)
 
-// tablesFromAST returns a list of tables from the ast
-func tablesFromAST(ast []map[string]any) ([]string, error) {
-	flat, err := flatten.Flatten(ast[0], "", flatten.DotStyle)
-	if err != nil {
-		logger.Error("error flattening ast", "error", err.Error(), "ast", ast)
-		return nil, fmt.Errorf("error flattening ast: %s", err.Error())
-	}
+	return tables, nil
+}
 
+// tablesFromTokens walks the token stream and collects every name that is
+// referenced as a table in a FROM or JOIN clause, at any nesting level.
+func tablesFromTokens(tokens []token) ([]string, error) {
 	tables := []string{}
-	for k, v := range flat {
-		if strings.HasSuffix(k, ERROR) {
-			v, ok := v.(bool)
-			if ok && v {
-				logger.Error("error in sql", "error", k)
-				return nil, astError(k, flat)
+	// inFrom records, per parenthesis depth, whether we are inside a FROM clause.
+	inFrom := []bool{false}
+	// tableParens records, per parenthesis depth, whether the parenthesis was
+	// opened in place of a table reference, i.e. a subquery or a nested join.
+	tableParens := []bool{false}
+	expectTable := false
+
+	for i := 0; i < len(tokens); i++ {
+		tok := tokens[i]
+		depth := len(inFrom) - 1
+		switch {
+		case tok.isPunct("("):
+			// a nested join keeps expecting a table, a subquery resets it on SELECT
+			inFrom = append(inFrom, expectTable)
+			tableParens = append(tableParens, expectTable)
+			continue
+		case tok.isPunct(")"):
+			if depth == 0 {
+				return nil, fmt.Errorf("unbalanced parenthesis")
 			}
-		}
-		if strings.Contains(k, TABLE_NAME) {
-			table, ok := v.(string)
-			if ok && !existsInList(table, tables) {
-				tables = append(tables, v.(string))
+			wasTable := tableParens[depth]
+			inFrom = inFrom[:depth]
+			tableParens = tableParens[:depth]
+			expectTable = false
+			if wasTable {
+				next, err := skipAlias(tokens, i+1)
+				if err != nil {
+					return nil, err
+				}
+				i = next - 1
 			}
+			continue
+		case tok.isPunct(";"):
+			inFrom[depth] = false
+			expectTable = false
+			continue
+		case tok.isPunct(","):
+			if inFrom[depth] {
+				expectTable = true
+			}
+			continue
+		case tok.isKeyword("from") || tok.isKeyword("join"):
+			inFrom[depth] = true
+			expectTable = true
+			continue
+		case isClauseKeyword(tok):
+			// join modifiers and join conditions do not end the FROM clause
+			stay := tok.isKeyword("on") || tok.isKeyword("using") || isJoinModifier(tok)
+			inFrom[depth] = inFrom[depth] && stay
+			expectTable = false
+			continue
 		}
+
+		if !expectTable {
+			continue
+		}
+		expectTable = false
+
+		if tok.kind != tokenIdent && tok.kind != tokenQuotedIdent {
+			return nil, fmt.Errorf("unexpected %q in FROM clause", tok.value)
+		}
+
+		// qualified names, e.g. schema.table
+		name := tok.value
+		for i+2 < len(tokens) && tokens[i+1].isPunct(".") {
+			name = name + "." + tokens[i+2].value
+			i += 2
+		}
+
+		// a table-valued function call, e.g. generate_series(1, 10)
+		if i+1 < len(tokens) && tokens[i+1].isPunct("(") {
+			continue
+		}
+
+		if !existsInList(name, tables) {
+			tables = append(tables, name)
+		}
+
+		next, err := skipAlias(tokens, i+1)
+		if err != nil {
+			return nil, err
+		}
+		i = next - 1
 	}
-	sort.Strings(tables)
 
-	logger.Debug("tables found in sql", "tables", tables)
+	if len(inFrom) != 1 {
+		return nil, fmt.Errorf("unbalanced parenthesis")
+	}
 
 	return tables, nil
 }
 
-func astError(k string, flat map[string]any) error {
-	key := strings.Replace(k, ERROR, "", 1)
-	message, ok := flat[key+ERROR_MESSAGE]
-	if !ok {
-		message = "unknown error in sql"
+func isClauseKeyword(tok token) bool {
+	return tok.kind == tokenIdent && clauseKeywords[strings.ToLower(tok.value)]
+}
+
+func isJoinModifier(tok token) bool {
+	for _, kw := range []string{"inner", "left", "right", "full", "outer", "cross", "natural"} {
+		if tok.isKeyword(kw) {
+			return true
+		}
 	}
-	return fmt.Errorf("error in sql: %s", message)
+	return false
+}
+
+// skipAlias skips an optional table alias starting at position i and returns the
+// position of the next token. Anything other than a clause boundary after the
+// alias is a syntax error.
+func skipAlias(tokens []token, i int) (int, error) {
+	if i < len(tokens) && tokens[i].isKeyword("as") {
+		i++
+		if i >= len(tokens) || (tokens[i].kind != tokenIdent && tokens[i].kind != tokenQuotedIdent) {
+			return i, fmt.Errorf("missing alias after AS")
+		}
+		i++
+	} else if i < len(tokens) && isAliasToken(tokens[i]) {
+		i++
+	}
+
+	if i >= len(tokens) {
+		return i, nil
+	}
+	next := tokens[i]
+	if next.isPunct(",") || next.isPunct(")") || next.isPunct(";") {
+		return i, nil
+	}
+	if isClauseKeyword(next) {
+		return i, nil
+	}
+	return i, fmt.Errorf("unexpected %q after table reference", next.value)
+}
+
+func isAliasToken(tok token) bool {
+	if tok.kind == tokenQuotedIdent {
+		return true
+	}
+	return tok.kind == tokenIdent && !isClauseKeyword(tok)
+}
+
+// tokenize splits a SQL statement into identifiers, literals and punctuation,
+// dropping whitespace and comments.
+func tokenize(rawSQL string) ([]token, error) {
+	tokens := []token{}
+	runes := []rune(rawSQL)
+	for i := 0; i < len(runes); {
+		r := runes[i]
+		switch {
+		case r == ' ' || r == '\t' || r == '\n' || r == '\r':
+			i++
+		case r == '-' && i+1 < len(runes) && runes[i+1] == '-':
+			for i < len(runes) && runes[i] != '\n' {
+				i++
+			}
+		case r == '/' && i+1 < len(runes) && runes[i+1] == '*':
+			j := i + 2
+			for j+1 < len(runes) && (runes[j] != '*' || runes[j+1] != '/') {
+				j++
+			}
+			if j+1 >= len(runes) {
+				return nil, fmt.Errorf("unterminated comment")
+			}
+			i = j + 2
+		case r == '\'' || r == '"' || r == '`' || r == '[':
+			closing := r
+			kind := tokenQuotedIdent
+			switch r {
+			case '\'':
+				kind = tokenString
+			case '[':
+				closing = ']'
+			}
+			var sb strings.Builder
+			j := i + 1
+			for {
+				if j >= len(runes) {
+					return nil, fmt.Errorf("unterminated quoted string")
+				}
+				if runes[j] == closing {
+					// doubled quote characters are escapes
+					if j+1 < len(runes) && runes[j+1] == closing && closing != ']' {
+						sb.WriteRune(closing)
+						j += 2
+						continue
+					}
+					break
+				}
+				sb.WriteRune(runes[j])
+				j++
+			}
+			tokens = append(tokens, token{kind: kind, value: sb.String()})
+			i = j + 1
+		case isIdentStart(r):
+			j := i
+			for j < len(runes) && isIdentPart(runes[j]) {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenIdent, value: string(runes[i:j])})
+			i = j
+		case r >= '0' && r <= '9':
+			j := i
+			for j < len(runes) && (isIdentPart(runes[j]) || runes[j] == '.') {
+				j++
+			}
+			tokens = append(tokens, token{kind: tokenNumber, value: string(runes[i:j])})
+			i = j
+		default:
+			tokens = append(tokens, token{kind: tokenPunct, value: string(r)})
+			i++
+		}
+	}
+	return tokens, nil
+}
+
+func isIdentStart(r rune) bool {
+	return r == '_' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z') || r > 127
+}
+
+func isIdentPart(r rune) bool {
+	return isIdentStart(r) || (r >= '0' && r <= '9') || r == '$'
 }
 
 func existsInList(table string, list []string) bool {
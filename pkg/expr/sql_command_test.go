@@ -1,12 +1,19 @@
 package expr
 
 import (
+	"context"
 	"strings"
 	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/infra/tracing"
 )
 
 func TestNewCommand(t *testing.T) {
-	t.Skip()
 	cmd, err := NewSQLCommand("a", "select a from foo, bar")
 	if err != nil && strings.Contains(err.Error(), "feature is not enabled") {
 		return
@@ -25,3 +32,31 @@ func TestNewCommand(t *testing.T) {
 		return
 	}
 }
+
+func TestSQLCommandExecute(t *testing.T) {
+	cmd, err := NewSQLCommand("B", "SELECT host, max(A) AS peak FROM A GROUP BY host ORDER BY host")
+	require.NoError(t, err)
+	require.Equal(t, []string{"A"}, cmd.NeedsVars())
+
+	now := time.Now()
+	seriesA := mathexp.NewSeries("A", data.Labels{"host": "a"}, 2)
+	seriesA.SetPoint(0, now, fp(1))
+	seriesA.SetPoint(1, now.Add(time.Minute), fp(3))
+	seriesB := mathexp.NewSeries("A", data.Labels{"host": "b"}, 1)
+	seriesB.SetPoint(0, now, fp(5))
+	vars := mathexp.Vars{
+		"A": mathexp.Results{Values: mathexp.Values{seriesA, seriesB}},
+	}
+
+	res, err := cmd.Execute(context.Background(), now, vars, tracing.InitializeTracerForTest())
+	require.NoError(t, err)
+	require.NoError(t, res.Error)
+	require.Len(t, res.Values, 1)
+
+	table, ok := res.Values[0].(mathexp.TableData)
+	require.True(t, ok)
+	require.Equal(t, "B", table.Frame.RefID)
+	require.Equal(t, 2, table.Frame.Rows())
+	peak, _ := table.Frame.Fields[1].ConcreteAt(0)
+	require.Equal(t, float64(3), peak)
+}
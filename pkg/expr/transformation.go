@@ -0,0 +1,125 @@
+package expr
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/expr/transformations"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
+)
+
+// TransformationCommand is an expression command that applies dashboard transformations
+// to the frames of the results of other queries or expressions.
+type TransformationCommand struct {
+	Inputs          []string
+	Transformations []DataTransformerConfig
+	refID           string
+	features        featuremgmt.FeatureToggles
+	transformers    []transformations.Transformer
+}
+
+// NewTransformationCommand creates a new TransformationCommand. Disabled transformations are skipped.
+func NewTransformationCommand(refID string, inputs []string, configs []DataTransformerConfig, features featuremgmt.FeatureToggles) (*TransformationCommand, error) {
+	if len(inputs) == 0 {
+		return nil, fmt.Errorf("transformation expression requires at least one input")
+	}
+	refs := make([]string, len(inputs))
+	for i, input := range inputs {
+		ref, err := getReferenceVar(input, refID)
+		if err != nil {
+			return nil, err
+		}
+		refs[i] = ref
+	}
+	transformers := make([]transformations.Transformer, 0, len(configs))
+	for _, cfg := range configs {
+		if cfg.Disabled {
+			continue
+		}
+		var options json.RawMessage
+		if cfg.Options != nil {
+			var err error
+			if options, err = json.Marshal(cfg.Options); err != nil {
+				return nil, fmt.Errorf("failed to read the options of transformation '%s': %w", cfg.ID, err)
+			}
+		}
+		t, err := transformations.New(cfg.ID, options)
+		if err != nil {
+			return nil, err
+		}
+		transformers = append(transformers, t)
+	}
+	return &TransformationCommand{
+		Inputs:          refs,
+		Transformations: configs,
+		refID:           refID,
+		features:        features,
+		transformers:    transformers,
+	}, nil
+}
+
+// UnmarshalTransformationCommand creates a TransformationCommand from Grafana's frontend query.
+func UnmarshalTransformationCommand(rn *rawNode, features featuremgmt.FeatureToggles) (*TransformationCommand, error) {
+	q := TransformationQuery{}
+	if err := json.Unmarshal(rn.QueryRaw, &q); err != nil {
+		return nil, fmt.Errorf("failed to parse the transformation command: %w", err)
+	}
+	return NewTransformationCommand(rn.RefID, q.Inputs, q.Transformations, features)
+}
+
+// NeedsVars returns the variable names (refIds) that are dependencies
+// to execute the command and allows the command to fulfill the Command interface.
+func (gr *TransformationCommand) NeedsVars() []string {
+	return gr.Inputs
+}
+
+// Execute runs the command and returns the results or an error if the command
+// failed to execute.
+func (gr *TransformationCommand) Execute(ctx context.Context, now time.Time, vars mathexp.Vars, tracer tracing.Tracer) (mathexp.Results, error) {
+	ctx, span := tracer.Start(ctx, "SSE.ExecuteTransformation")
+	defer span.End()
+
+	frames := data.Frames{}
+	for _, ref := range gr.Inputs {
+		results, ok := vars[ref]
+		if !ok {
+			logger.Warn("no results found for", "ref", ref)
+			continue
+		}
+		for _, val := range results.Values {
+			if _, isNoData := val.(mathexp.NoData); val == nil || isNoData {
+				continue
+			}
+			frame := val.AsDataFrame()
+			frame.RefID = ref
+			frames = append(frames, frame)
+		}
+	}
+
+	for i, t := range gr.transformers {
+		var err error
+		if frames, err = t(frames); err != nil {
+			return mathexp.Results{}, fmt.Errorf("transformation %d of %s failed: %w", i+1, gr.refID, err)
+		}
+	}
+	for _, frame := range frames {
+		frame.RefID = gr.refID
+	}
+
+	converter := &ResultConverter{Features: gr.features, Tracer: tracer}
+	_, res, err := converter.Convert(ctx, TypeTransformation.String(), frames, true)
+	if err != nil {
+		return mathexp.Results{}, fmt.Errorf("failed to read the frames of transformation %s: %w", gr.refID, err)
+	}
+	return res, nil
+}
+
+func (gr *TransformationCommand) Type() string {
+	return TypeTransformation.String()
+}
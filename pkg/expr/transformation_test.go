@@ -0,0 +1,106 @@
+package expr
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
+)
+
+func TestNewTransformationCommand(t *testing.T) {
+	features := featuremgmt.WithFeatures()
+
+	t.Run("fails without inputs", func(t *testing.T) {
+		_, err := NewTransformationCommand("C", nil, nil, features)
+		require.Error(t, err)
+	})
+
+	t.Run("fails for unsupported transformation", func(t *testing.T) {
+		_, err := NewTransformationCommand("C", []string{"A"}, []DataTransformerConfig{{ID: "rowsToFields"}}, features)
+		require.ErrorContains(t, err, "rowsToFields")
+	})
+
+	t.Run("fails for invalid options", func(t *testing.T) {
+		_, err := NewTransformationCommand("C", []string{"A"}, []DataTransformerConfig{
+			{ID: "calculateField", Options: map[string]any{"mode": "reduceRow", "reduce": map[string]any{"reducer": "unknown"}}},
+		}, features)
+		require.Error(t, err)
+	})
+
+	t.Run("does not modify the inputs", func(t *testing.T) {
+		inputs := []string{"$A", "$B"}
+		cmd, err := NewTransformationCommand("C", inputs, []DataTransformerConfig{{ID: "merge"}}, features)
+		require.NoError(t, err)
+		require.Equal(t, []string{"A", "B"}, cmd.Inputs)
+		require.Equal(t, []string{"$A", "$B"}, inputs)
+	})
+
+	t.Run("skips disabled transformations", func(t *testing.T) {
+		cmd, err := NewTransformationCommand("C", []string{"A"}, []DataTransformerConfig{
+			{ID: "rowsToFields", Disabled: true},
+			{ID: "merge"},
+		}, features)
+		require.NoError(t, err)
+		require.Len(t, cmd.transformers, 1)
+	})
+
+	t.Run("reads the command from the query", func(t *testing.T) {
+		cmd, err := UnmarshalTransformationCommand(&rawNode{
+			RefID:    "C",
+			QueryRaw: []byte(`{"type": "transformation", "inputs": ["$A", "B"], "transformations": [{"id": "joinByField", "options": {"mode": "inner"}}]}`),
+		}, features)
+		require.NoError(t, err)
+		require.Equal(t, []string{"A", "B"}, cmd.NeedsVars())
+		require.Len(t, cmd.transformers, 1)
+	})
+}
+
+func TestTransformationCommandExecute(t *testing.T) {
+	newSeries := func(refID string, values ...float64) mathexp.Series {
+		s := mathexp.NewSeries(refID, data.Labels{"host": "a"}, len(values))
+		for i, v := range values {
+			s.SetPoint(i, time.Unix(int64(i*60), 0), fp(v))
+		}
+		return s
+	}
+	vars := mathexp.Vars{
+		"A": mathexp.Results{Values: mathexp.Values{newSeries("A", 1, 2)}},
+		"B": mathexp.Results{Values: mathexp.Values{newSeries("B", 10, 20)}},
+		"N": mathexp.Results{Values: mathexp.Values{mathexp.NewNoData()}},
+	}
+
+	t.Run("joins the inputs and calculates a field", func(t *testing.T) {
+		cmd, err := NewTransformationCommand("C", []string{"A", "B"}, []DataTransformerConfig{
+			{ID: "joinByField"},
+			{ID: "calculateField", Options: map[string]any{
+				"mode":          "reduceRow",
+				"reduce":        map[string]any{"reducer": "sum"},
+				"alias":         "total",
+				"replaceFields": true,
+			}},
+		}, featuremgmt.WithFeatures())
+		require.NoError(t, err)
+		res, err := cmd.Execute(context.Background(), time.Now(), vars, tracing.InitializeTracerForTest())
+		require.NoError(t, err)
+		require.Len(t, res.Values, 1)
+		s, ok := res.Values[0].(mathexp.Series)
+		require.True(t, ok, "expected series, got %T", res.Values[0])
+		require.Equal(t, 2, s.Len())
+		require.Equal(t, 11.0, *s.GetValue(0))
+		require.Equal(t, 22.0, *s.GetValue(1))
+	})
+
+	t.Run("returns no data if all inputs have no data", func(t *testing.T) {
+		cmd, err := NewTransformationCommand("C", []string{"N"}, []DataTransformerConfig{{ID: "merge"}}, featuremgmt.WithFeatures())
+		require.NoError(t, err)
+		res, err := cmd.Execute(context.Background(), time.Now(), vars, tracing.InitializeTracerForTest())
+		require.NoError(t, err)
+		require.True(t, res.IsNoData())
+	})
+}
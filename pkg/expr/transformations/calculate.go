@@ -0,0 +1,200 @@
+package transformations
+
+import (
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// CalculateFieldMode is how the calculateField transformation calculates the new field.
+type CalculateFieldMode string
+
+const (
+	// CalculateFieldModeBinary applies a binary operation to two fields, or a field and a number.
+	CalculateFieldModeBinary CalculateFieldMode = "binary"
+	// CalculateFieldModeReduceRow reduces the values of fields in each row.
+	CalculateFieldModeReduceRow CalculateFieldMode = "reduceRow"
+)
+
+// BinaryOptions are the options of the binary mode of the calculateField transformation.
+type BinaryOptions struct {
+	// Left and Right are the names of fields, or numbers.
+	Left  string `json:"left"`
+	Right string `json:"right"`
+	// Operator is one of +, -, *, /, % or ^.
+	Operator string `json:"operator"`
+}
+
+// ReduceOptions are the options of the reduceRow mode of the calculateField transformation.
+type ReduceOptions struct {
+	// Reducer is the id of the reducer, such as sum, mean or max.
+	Reducer string `json:"reducer"`
+	// Include are the names of the fields to reduce. All numeric fields are reduced if it is empty.
+	Include []string `json:"include,omitempty"`
+}
+
+// CalculateFieldOptions are the options of the calculateField transformation.
+type CalculateFieldOptions struct {
+	// Mode is reduceRow, the default, or binary.
+	Mode   CalculateFieldMode `json:"mode,omitempty"`
+	Binary BinaryOptions      `json:"binary,omitempty"`
+	Reduce ReduceOptions      `json:"reduce,omitempty"`
+	// Alias is the name of the new field. It defaults to the operation, or the reducer.
+	Alias string `json:"alias,omitempty"`
+	// ReplaceFields keeps only the time fields and the new field.
+	ReplaceFields bool `json:"replaceFields,omitempty"`
+}
+
+// rowCalculation returns the value of the new field in a row of the frame.
+type rowCalculation func(row int) (*float64, error)
+
+// newCalculateField creates the calculateField transformation, that adds a field with a value calculated
+// from the values of other fields in each row of each frame. A value is null if any of the values used to
+// calculate it is null, except for the reducers, which skip null values. Frames without the fields of the
+// calculation are not changed.
+func newCalculateField(options json.RawMessage) (Transformer, error) {
+	opts := CalculateFieldOptions{}
+	if err := parseOptions(options, &opts); err != nil {
+		return nil, err
+	}
+	var calculation func(frame *data.Frame) (rowCalculation, bool)
+	switch opts.Mode {
+	case "", CalculateFieldModeReduceRow:
+		if opts.Reduce.Reducer == "" {
+			opts.Reduce.Reducer = "sum"
+		}
+		reduce, err := reducer(opts.Reduce.Reducer)
+		if err != nil {
+			return nil, err
+		}
+		if opts.Alias == "" {
+			opts.Alias = opts.Reduce.Reducer
+		}
+		calculation = func(frame *data.Frame) (rowCalculation, bool) {
+			fields := reducedFields(frame, opts.Reduce.Include)
+			if len(fields) == 0 {
+				return nil, false
+			}
+			return func(row int) (*float64, error) {
+				values := make([]*float64, len(fields))
+				for i, f := range fields {
+					v, err := f.NullableFloatAt(row)
+					if err != nil {
+						return nil, err
+					}
+					values[i] = v
+				}
+				return reduce(values), nil
+			}, true
+		}
+	case CalculateFieldModeBinary:
+		op, err := binaryOperation(opts.Binary.Operator)
+		if err != nil {
+			return nil, err
+		}
+		if opts.Alias == "" {
+			opts.Alias = fmt.Sprintf("%s %s %s", opts.Binary.Left, opts.Binary.Operator, opts.Binary.Right)
+		}
+		calculation = func(frame *data.Frame) (rowCalculation, bool) {
+			left, lok := operand(frame, opts.Binary.Left)
+			right, rok := operand(frame, opts.Binary.Right)
+			if !lok || !rok {
+				return nil, false
+			}
+			return func(row int) (*float64, error) {
+				l, err := left(row)
+				if err != nil || l == nil {
+					return nil, err
+				}
+				r, err := right(row)
+				if err != nil || r == nil {
+					return nil, err
+				}
+				v := op(*l, *r)
+				return &v, nil
+			}, true
+		}
+	default:
+		return nil, fmt.Errorf("calculation mode '%s' is not supported. Supported only: [%s,%s]", opts.Mode, CalculateFieldModeReduceRow, CalculateFieldModeBinary)
+	}
+
+	return perFrame(func(frame *data.Frame) (*data.Frame, error) {
+		calc, ok := calculation(frame)
+		if !ok {
+			return frame, nil
+		}
+		nf := data.NewField(opts.Alias, nil, make([]*float64, frame.Rows()))
+		for row := 0; row < frame.Rows(); row++ {
+			v, err := calc(row)
+			if err != nil {
+				return nil, fmt.Errorf("can not calculate field %s: %w", opts.Alias, err)
+			}
+			nf.Set(row, v)
+		}
+
+		res := data.NewFrame(frame.Name)
+		res.RefID = frame.RefID
+		res.Meta = frame.Meta
+		for _, f := range frame.Fields {
+			if opts.ReplaceFields && !f.Type().Time() {
+				continue
+			}
+			res.Fields = append(res.Fields, f)
+		}
+		res.Fields = append(res.Fields, nf)
+		return res, nil
+	}), nil
+}
+
+// reducedFields returns the fields of the frame with the names, or all numeric fields if there are no names.
+func reducedFields(frame *data.Frame, names []string) []*data.Field {
+	var fields []*data.Field
+	if len(names) == 0 {
+		for _, f := range frame.Fields {
+			if f.Type().Numeric() {
+				fields = append(fields, f)
+			}
+		}
+		return fields
+	}
+	for _, name := range names {
+		if f := fieldByName(frame, name); f != nil {
+			fields = append(fields, f)
+		}
+	}
+	return fields
+}
+
+// operand returns the values of the field with the name, or the number if there is no such field.
+func operand(frame *data.Frame, nameOrNumber string) (func(row int) (*float64, error), bool) {
+	if f := fieldByName(frame, nameOrNumber); f != nil {
+		return f.NullableFloatAt, true
+	}
+	n, err := strconv.ParseFloat(nameOrNumber, 64)
+	if err != nil {
+		return nil, false
+	}
+	return func(int) (*float64, error) { return &n, nil }, true
+}
+
+func binaryOperation(operator string) (func(a, b float64) float64, error) {
+	switch operator {
+	case "+":
+		return func(a, b float64) float64 { return a + b }, nil
+	case "-":
+		return func(a, b float64) float64 { return a - b }, nil
+	case "*":
+		return func(a, b float64) float64 { return a * b }, nil
+	case "/":
+		return func(a, b float64) float64 { return a / b }, nil
+	case "%":
+		return math.Mod, nil
+	case "^", "**":
+		return math.Pow, nil
+	default:
+		return nil, fmt.Errorf("binary operator '%s' is not supported. Supported only: [+,-,*,/,%%,^]", operator)
+	}
+}
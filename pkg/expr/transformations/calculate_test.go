@@ -0,0 +1,43 @@
+package transformations
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCalculateField(t *testing.T) {
+	frame := data.NewFrame("",
+		data.NewField("time", nil, []time.Time{time.Unix(0, 0), time.Unix(10, 0)}),
+		data.NewField("used", nil, []float64{1, 3}),
+		data.NewField("free", nil, []*float64{fp(3), nil}),
+	)
+
+	t.Run("binary operation of fields", func(t *testing.T) {
+		res := transform(t, IDCalculateField, `{"mode": "binary", "binary": {"left": "used", "operator": "/", "right": "free"}, "alias": "ratio"}`, frame)
+		f := res[0].Fields[3]
+		require.Equal(t, "ratio", f.Name)
+		require.Equal(t, []*float64{fp(1.0 / 3), nil}, []*float64{f.At(0).(*float64), f.At(1).(*float64)})
+	})
+
+	t.Run("binary operation of a field and a number replacing fields", func(t *testing.T) {
+		res := transform(t, IDCalculateField, `{"mode": "binary", "binary": {"left": "used", "operator": "*", "right": "100"}, "replaceFields": true}`, frame)
+		require.Len(t, res[0].Fields, 2)
+		require.Equal(t, "used * 100", res[0].Fields[1].Name)
+		require.Equal(t, fp(300), res[0].Fields[1].At(1))
+	})
+
+	t.Run("reduce row skips nulls", func(t *testing.T) {
+		res := transform(t, IDCalculateField, `{"reduce": {"reducer": "sum"}}`, frame)
+		f := res[0].Fields[3]
+		require.Equal(t, "sum", f.Name)
+		require.Equal(t, []*float64{fp(4), fp(3)}, []*float64{f.At(0).(*float64), f.At(1).(*float64)})
+	})
+
+	t.Run("frames without the fields are not changed", func(t *testing.T) {
+		res := transform(t, IDCalculateField, `{"mode": "binary", "binary": {"left": "missing", "operator": "+", "right": "1"}}`, frame)
+		require.Equal(t, data.Frames{frame}, res)
+	})
+}
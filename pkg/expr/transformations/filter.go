@@ -0,0 +1,204 @@
+package transformations
+
+import (
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"strconv"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// FilterType is whether the filterByValue transformation keeps or removes the matching rows.
+type FilterType string
+
+const (
+	FilterTypeInclude FilterType = "include"
+	FilterTypeExclude FilterType = "exclude"
+)
+
+// FilterMatch is whether a row must match any or all filters of the filterByValue transformation.
+type FilterMatch string
+
+const (
+	FilterMatchAny FilterMatch = "any"
+	FilterMatchAll FilterMatch = "all"
+)
+
+// ValueMatcherOptions are the options of a value matcher, which are used depending on the matcher.
+type ValueMatcherOptions struct {
+	Value any     `json:"value,omitempty"`
+	From  float64 `json:"from,omitempty"`
+	To    float64 `json:"to,omitempty"`
+}
+
+// ValueMatcher is a condition on the value of a field.
+type ValueMatcher struct {
+	// ID is one of greater, greaterOrEqual, lower, lowerOrEqual, equal, notEqual, isNull, isNotNull, range or regex.
+	ID      string              `json:"id"`
+	Options ValueMatcherOptions `json:"options"`
+}
+
+// ValueFilter is a condition on the value of the field with the name.
+type ValueFilter struct {
+	FieldName string       `json:"fieldName"`
+	Config    ValueMatcher `json:"config"`
+}
+
+// FilterByValueOptions are the options of the filterByValue transformation.
+type FilterByValueOptions struct {
+	Filters []ValueFilter `json:"filters"`
+	// Type is include, the default, or exclude.
+	Type FilterType `json:"type,omitempty"`
+	// Match is any, the default, or all.
+	Match FilterMatch `json:"match,omitempty"`
+}
+
+// valueMatcher returns true if the value at index row of the field matches.
+type valueMatcher func(f *data.Field, row int) bool
+
+// newFilterByValue creates the filterByValue transformation, that keeps, or removes, the rows of each frame
+// whose values match any, or all, of the filters. Filters on fields that the frame does not have do not match.
+func newFilterByValue(options json.RawMessage) (Transformer, error) {
+	opts := FilterByValueOptions{}
+	if err := parseOptions(options, &opts); err != nil {
+		return nil, err
+	}
+	switch opts.Type {
+	case "":
+		opts.Type = FilterTypeInclude
+	case FilterTypeInclude, FilterTypeExclude:
+	default:
+		return nil, fmt.Errorf("filter type '%s' is not supported. Supported only: [%s,%s]", opts.Type, FilterTypeInclude, FilterTypeExclude)
+	}
+	switch opts.Match {
+	case "":
+		opts.Match = FilterMatchAny
+	case FilterMatchAny, FilterMatchAll:
+	default:
+		return nil, fmt.Errorf("filter match '%s' is not supported. Supported only: [%s,%s]", opts.Match, FilterMatchAny, FilterMatchAll)
+	}
+	matchers := make([]valueMatcher, len(opts.Filters))
+	for i, filter := range opts.Filters {
+		m, err := newValueMatcher(filter.Config)
+		if err != nil {
+			return nil, fmt.Errorf("filter on field %s: %w", filter.FieldName, err)
+		}
+		matchers[i] = m
+	}
+	if len(opts.Filters) == 0 {
+		return func(frames data.Frames) (data.Frames, error) { return frames, nil }, nil
+	}
+
+	return perFrame(func(frame *data.Frame) (*data.Frame, error) {
+		fields := make([]*data.Field, len(opts.Filters))
+		for i, filter := range opts.Filters {
+			fields[i] = fieldByName(frame, filter.FieldName)
+		}
+		var keep []int
+		for row := 0; row < frame.Rows(); row++ {
+			matched := opts.Match == FilterMatchAll
+			for i, m := range matchers {
+				ok := fields[i] != nil && m(fields[i], row)
+				if opts.Match == FilterMatchAny && ok {
+					matched = true
+					break
+				}
+				if opts.Match == FilterMatchAll && !ok {
+					matched = false
+					break
+				}
+			}
+			if matched == (opts.Type == FilterTypeInclude) {
+				keep = append(keep, row)
+			}
+		}
+
+		res := data.NewFrame(frame.Name)
+		res.RefID = frame.RefID
+		res.Meta = frame.Meta
+		for _, f := range frame.Fields {
+			nf := data.NewFieldFromFieldType(f.Type(), len(keep))
+			nf.Name = f.Name
+			nf.Labels = f.Labels
+			nf.Config = f.Config
+			for i, row := range keep {
+				nf.Set(i, f.CopyAt(row))
+			}
+			res.Fields = append(res.Fields, nf)
+		}
+		return res, nil
+	}), nil
+}
+
+func newValueMatcher(m ValueMatcher) (valueMatcher, error) {
+	compare := func(cmp func(a, b float64) bool) (valueMatcher, error) {
+		b, err := toFloat(m.Options.Value)
+		if err != nil {
+			return nil, err
+		}
+		return func(f *data.Field, row int) bool {
+			a, err := f.NullableFloatAt(row)
+			return err == nil && a != nil && cmp(*a, b)
+		}, nil
+	}
+	switch m.ID {
+	case "greater":
+		return compare(func(a, b float64) bool { return a > b })
+	case "greaterOrEqual":
+		return compare(func(a, b float64) bool { return a >= b })
+	case "lower":
+		return compare(func(a, b float64) bool { return a < b })
+	case "lowerOrEqual":
+		return compare(func(a, b float64) bool { return a <= b })
+	case "range":
+		return func(f *data.Field, row int) bool {
+			a, err := f.NullableFloatAt(row)
+			return err == nil && a != nil && *a > m.Options.From && *a < m.Options.To
+		}, nil
+	case "equal", "notEqual":
+		want := fmt.Sprint(m.Options.Value)
+		return func(f *data.Field, row int) bool {
+			v, ok := f.ConcreteAt(row)
+			equal := ok && fmt.Sprint(v) == want
+			return equal == (m.ID == "equal")
+		}, nil
+	case "isNull":
+		return func(f *data.Field, row int) bool {
+			_, ok := f.ConcreteAt(row)
+			return !ok
+		}, nil
+	case "isNotNull":
+		return func(f *data.Field, row int) bool {
+			_, ok := f.ConcreteAt(row)
+			return ok
+		}, nil
+	case "regex":
+		re, err := regexp.Compile(fmt.Sprint(m.Options.Value))
+		if err != nil {
+			return nil, fmt.Errorf("invalid regular expression: %w", err)
+		}
+		return func(f *data.Field, row int) bool {
+			v, ok := f.ConcreteAt(row)
+			return ok && re.MatchString(fmt.Sprint(v))
+		}, nil
+	default:
+		return nil, fmt.Errorf("value matcher '%s' is not supported", m.ID)
+	}
+}
+
+// toFloat returns the number, or the number in the string, of an option.
+func toFloat(v any) (float64, error) {
+	switch v := v.(type) {
+	case float64:
+		return v, nil
+	case string:
+		f, err := strconv.ParseFloat(v, 64)
+		if err != nil {
+			return 0, fmt.Errorf("expected a number, got %q", v)
+		}
+		return f, nil
+	default:
+		return 0, fmt.Errorf("expected a number, got %v", v)
+	}
+}
@@ -0,0 +1,60 @@
+package transformations
+
+import (
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestFilterByValue(t *testing.T) {
+	frame := data.NewFrame("",
+		data.NewField("host", nil, []string{"web01", "web02", "db01"}),
+		data.NewField("cpu", nil, []*float64{fp(10), fp(90), nil}),
+	)
+	hosts := func(frames data.Frames) []string {
+		require.Len(t, frames, 1)
+		res := []string{}
+		for i := 0; i < frames[0].Rows(); i++ {
+			res = append(res, frames[0].Fields[0].At(i).(string))
+		}
+		return res
+	}
+
+	tests := []struct {
+		name    string
+		options string
+		hosts   []string
+	}{
+		{
+			name:    "includes rows that match any filter",
+			options: `{"filters": [{"fieldName": "cpu", "config": {"id": "greater", "options": {"value": 50}}}, {"fieldName": "cpu", "config": {"id": "isNull"}}]}`,
+			hosts:   []string{"web02", "db01"},
+		},
+		{
+			name:    "includes rows that match all filters",
+			options: `{"match": "all", "filters": [{"fieldName": "host", "config": {"id": "regex", "options": {"value": "web.*"}}}, {"fieldName": "cpu", "config": {"id": "lower", "options": {"value": "50"}}}]}`,
+			hosts:   []string{"web01"},
+		},
+		{
+			name:    "excludes matching rows",
+			options: `{"type": "exclude", "filters": [{"fieldName": "host", "config": {"id": "equal", "options": {"value": "db01"}}}]}`,
+			hosts:   []string{"web01", "web02"},
+		},
+		{
+			name:    "filters on missing fields do not match",
+			options: `{"filters": [{"fieldName": "mem", "config": {"id": "isNull"}}]}`,
+			hosts:   []string{},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			require.Equal(t, tt.hosts, hosts(transform(t, IDFilterByValue, tt.options, frame)))
+		})
+	}
+
+	t.Run("fails for unknown matcher", func(t *testing.T) {
+		_, err := New(IDFilterByValue, []byte(`{"filters": [{"fieldName": "cpu", "config": {"id": "between"}}]}`))
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,123 @@
+package transformations
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// GroupByOperation is what the groupBy transformation does with a field.
+type GroupByOperation string
+
+const (
+	// GroupByOperationGroupBy groups the rows by the values of the field.
+	GroupByOperationGroupBy GroupByOperation = "groupby"
+	// GroupByOperationAggregate calculates the aggregations of the values of the field in each group.
+	GroupByOperationAggregate GroupByOperation = "aggregate"
+)
+
+// GroupByFieldOptions are the options of a field of the groupBy transformation.
+type GroupByFieldOptions struct {
+	Operation GroupByOperation `json:"operation,omitempty"`
+	// Aggregations are the ids of the reducers to calculate, such as sum, mean or count.
+	Aggregations []string `json:"aggregations,omitempty"`
+}
+
+// GroupByOptions are the options of the groupBy transformation.
+type GroupByOptions struct {
+	Fields map[string]GroupByFieldOptions `json:"fields"`
+}
+
+// newGroupBy creates the groupBy transformation, that replaces the rows of each frame with a row for each
+// combination of values of the grouped fields, in the order the combinations first appear. The row has the
+// values of the grouped fields and a field named "<field> (<aggregation>)" for each aggregation of the
+// aggregated fields. The other fields are removed. Frames without the grouped fields are dropped.
+func newGroupBy(options json.RawMessage) (Transformer, error) {
+	opts := GroupByOptions{}
+	if err := parseOptions(options, &opts); err != nil {
+		return nil, err
+	}
+	for name, f := range opts.Fields {
+		switch f.Operation {
+		case "", GroupByOperationGroupBy:
+		case GroupByOperationAggregate:
+			for _, id := range f.Aggregations {
+				if _, err := reducer(id); err != nil {
+					return nil, fmt.Errorf("field %s: %w", name, err)
+				}
+			}
+		default:
+			return nil, fmt.Errorf("field %s: operation '%s' is not supported. Supported only: [%s,%s]", name, f.Operation, GroupByOperationGroupBy, GroupByOperationAggregate)
+		}
+	}
+	return perFrame(func(frame *data.Frame) (*data.Frame, error) {
+		return groupBy(frame, opts)
+	}), nil
+}
+
+func groupBy(frame *data.Frame, opts GroupByOptions) (*data.Frame, error) {
+	var groupFields, aggFields []*data.Field
+	for _, f := range frame.Fields {
+		switch opts.Fields[f.Name].Operation {
+		case GroupByOperationGroupBy:
+			groupFields = append(groupFields, f)
+		case GroupByOperationAggregate:
+			aggFields = append(aggFields, f)
+		}
+	}
+	if len(groupFields) == 0 {
+		return nil, nil
+	}
+
+	var groups []int // first row of each group
+	var rows [][]int // rows of each group
+	byKey := map[string]int{}
+	for row := 0; row < frame.Rows(); row++ {
+		keyValues := make([]any, len(groupFields))
+		for i, f := range groupFields {
+			keyValues[i], _ = valueKey(f, row)
+		}
+		key := fmt.Sprintf("%#v", keyValues)
+		g, ok := byKey[key]
+		if !ok {
+			g = len(groups)
+			byKey[key] = g
+			groups = append(groups, row)
+			rows = append(rows, nil)
+		}
+		rows[g] = append(rows[g], row)
+	}
+
+	res := data.NewFrame(frame.Name)
+	res.RefID = frame.RefID
+	for _, f := range groupFields {
+		nf := emptyCopy(f, len(groups))
+		for g, row := range groups {
+			copyValue(nf, g, f, row)
+		}
+		res.Fields = append(res.Fields, nf)
+	}
+	for _, f := range aggFields {
+		for _, id := range opts.Fields[f.Name].Aggregations {
+			reduce, err := reducer(id)
+			if err != nil {
+				return nil, err
+			}
+			nf := data.NewField(fmt.Sprintf("%s (%s)", f.Name, id), f.Labels, make([]*float64, len(groups)))
+			for g := range groups {
+				values := make([]*float64, len(rows[g]))
+				for i, row := range rows[g] {
+					v, err := f.NullableFloatAt(row)
+					if err != nil {
+						return nil, fmt.Errorf("can not aggregate field %s: %w", f.Name, err)
+					}
+					values[i] = v
+				}
+				nf.Set(g, reduce(values))
+			}
+			res.Fields = append(res.Fields, nf)
+		}
+	}
+	return res, nil
+}
@@ -0,0 +1,36 @@
+package transformations
+
+import (
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGroupBy(t *testing.T) {
+	frame := data.NewFrame("",
+		data.NewField("dc", nil, []string{"eu", "us", "eu"}),
+		data.NewField("host", nil, []string{"a", "b", "c"}),
+		data.NewField("cpu", nil, []*float64{fp(1), fp(5), nil}),
+	)
+	res := transform(t, IDGroupBy, `{"fields": {
+		"dc": {"operation": "groupby"},
+		"cpu": {"operation": "aggregate", "aggregations": ["sum", "count"]}
+	}}`, frame)
+
+	expected := data.NewFrame("",
+		data.NewField("dc", nil, []*string{strP("eu"), strP("us")}),
+		data.NewField("cpu (sum)", nil, []*float64{fp(1), fp(5)}),
+		data.NewField("cpu (count)", nil, []*float64{fp(2), fp(1)}),
+	)
+	require.Equal(t, data.Frames{expected}, res)
+
+	t.Run("fails for unknown aggregation", func(t *testing.T) {
+		_, err := New(IDGroupBy, []byte(`{"fields": {"cpu": {"operation": "aggregate", "aggregations": ["p99"]}}}`))
+		require.Error(t, err)
+	})
+}
+
+func strP(s string) *string {
+	return &s
+}
@@ -0,0 +1,173 @@
+package transformations
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// JoinMode is how the joinByField transformation joins the rows of the frames.
+type JoinMode string
+
+const (
+	// JoinModeOuter keeps the values of the join field of all frames.
+	JoinModeOuter JoinMode = "outer"
+	// JoinModeInner keeps only the values of the join field that exist in every frame.
+	JoinModeInner JoinMode = "inner"
+)
+
+// JoinByFieldOptions are the options of the joinByField transformation.
+type JoinByFieldOptions struct {
+	// ByField is the name of the field to join on. The first time field of each frame is used if it is empty.
+	ByField string `json:"byField,omitempty"`
+	// Mode is outer, the default, or inner.
+	Mode JoinMode `json:"mode,omitempty"`
+}
+
+// newJoinByField creates the joinByField transformation, that joins all frames into one frame, with a row
+// for each value of the join field, and the other fields of all frames. Fields are null in the rows of values
+// that their frame does not have. Frames without the join field are dropped.
+func newJoinByField(options json.RawMessage) (Transformer, error) {
+	opts := JoinByFieldOptions{}
+	if err := parseOptions(options, &opts); err != nil {
+		return nil, err
+	}
+	switch opts.Mode {
+	case "":
+		opts.Mode = JoinModeOuter
+	case JoinModeOuter, JoinModeInner:
+	default:
+		return nil, fmt.Errorf("join mode '%s' is not supported. Supported only: [%s,%s]", opts.Mode, JoinModeOuter, JoinModeInner)
+	}
+	return func(frames data.Frames) (data.Frames, error) {
+		return joinByField(frames, opts)
+	}, nil
+}
+
+func joinByField(frames data.Frames, opts JoinByFieldOptions) (data.Frames, error) {
+	type joined struct {
+		frame *data.Frame
+		by    *data.Field
+		rows  map[any]int // row of the first occurrence of each key
+	}
+	var inputs []joined
+	var byField *data.Field
+	keys := []any{}
+	rowOfKey := map[any]int{} // row of the first occurrence of each key in its frame
+	frameOfKey := map[any]*data.Field{}
+	count := map[any]int{}
+	for _, frame := range frames {
+		by := firstTimeField(frame)
+		if opts.ByField != "" {
+			by = fieldByName(frame, opts.ByField)
+		}
+		if by == nil {
+			continue
+		}
+		if byField == nil {
+			byField = by
+		} else if by.Type().NonNullableType() != byField.Type().NonNullableType() {
+			return nil, fmt.Errorf("can not join field %s of type %s and type %s", by.Name, byField.Type(), by.Type())
+		}
+		in := joined{frame: frame, by: by, rows: map[any]int{}}
+		for row := 0; row < by.Len(); row++ {
+			key, ok := valueKey(by, row)
+			if !ok {
+				continue
+			}
+			if _, ok := in.rows[key]; ok {
+				continue
+			}
+			in.rows[key] = row
+			if _, ok := count[key]; !ok {
+				keys = append(keys, key)
+				rowOfKey[key] = row
+				frameOfKey[key] = by
+			}
+			count[key]++
+		}
+		inputs = append(inputs, in)
+	}
+	if byField == nil {
+		return data.Frames{}, nil
+	}
+
+	if opts.Mode == JoinModeInner {
+		inner := keys[:0]
+		for _, key := range keys {
+			if count[key] == len(inputs) {
+				inner = append(inner, key)
+			}
+		}
+		keys = inner
+	}
+	sortKeys(keys)
+
+	res := data.NewFrame("")
+	joinField := emptyCopy(byField, len(keys))
+	joinField.Labels = nil
+	for i, key := range keys {
+		copyValue(joinField, i, frameOfKey[key], rowOfKey[key])
+	}
+	if !byField.Nullable() {
+		var err error
+		joinField, err = notNullable(joinField)
+		if err != nil {
+			return nil, err
+		}
+	}
+	res.Fields = append(res.Fields, joinField)
+
+	for _, in := range inputs {
+		for _, f := range in.frame.Fields {
+			if f == in.by {
+				continue
+			}
+			nf := emptyCopy(f, len(keys))
+			for i, key := range keys {
+				if row, ok := in.rows[key]; ok {
+					copyValue(nf, i, f, row)
+				}
+			}
+			res.Fields = append(res.Fields, nf)
+		}
+	}
+	return data.Frames{res}, nil
+}
+
+// sortKeys sorts the keys if they are numbers, times or strings, and keeps their order otherwise.
+func sortKeys(keys []any) {
+	sort.SliceStable(keys, func(i, j int) bool {
+		switch a := keys[i].(type) {
+		case int64:
+			b, ok := keys[j].(int64)
+			return ok && a < b
+		case float64:
+			b, ok := keys[j].(float64)
+			return ok && a < b
+		case string:
+			b, ok := keys[j].(string)
+			return ok && a < b
+		default:
+			return false
+		}
+	})
+}
+
+// notNullable returns a copy of the nullable field f, which has no null values, with the non nullable type.
+func notNullable(f *data.Field) (*data.Field, error) {
+	res := data.NewFieldFromFieldType(f.Type().NonNullableType(), f.Len())
+	res.Name = f.Name
+	res.Labels = f.Labels
+	res.Config = f.Config
+	for i := 0; i < f.Len(); i++ {
+		v, ok := f.ConcreteAt(i)
+		if !ok {
+			return nil, fmt.Errorf("field %s has a null value", f.Name)
+		}
+		res.Set(i, v)
+	}
+	return res, nil
+}
@@ -0,0 +1,49 @@
+package transformations
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJoinByField(t *testing.T) {
+	a := data.NewFrame("a",
+		data.NewField("time", nil, []time.Time{time.Unix(20, 0), time.Unix(10, 0)}),
+		data.NewField("cpu", data.Labels{"host": "a"}, []float64{2, 1}),
+	)
+	b := data.NewFrame("b",
+		data.NewField("time", nil, []time.Time{time.Unix(20, 0), time.Unix(30, 0)}),
+		data.NewField("mem", nil, []float64{5, 6}),
+	)
+
+	t.Run("outer join keeps all times", func(t *testing.T) {
+		res := transform(t, IDJoinByField, ``, a, b)
+		require.Len(t, res, 1)
+		expected := data.NewFrame("",
+			data.NewField("time", nil, []time.Time{time.Unix(10, 0), time.Unix(20, 0), time.Unix(30, 0)}),
+			data.NewField("cpu", data.Labels{"host": "a"}, []*float64{fp(1), fp(2), nil}),
+			data.NewField("mem", nil, []*float64{nil, fp(5), fp(6)}),
+		)
+		require.Equal(t, expected, res[0])
+	})
+
+	t.Run("inner join keeps the times of all frames", func(t *testing.T) {
+		res := transform(t, IDJoinByField, `{"byField": "time", "mode": "inner"}`, a, b)
+		require.Len(t, res, 1)
+		require.Equal(t, 1, res[0].Rows())
+		require.Equal(t, fp(2), res[0].Fields[1].At(0))
+		require.Equal(t, fp(5), res[0].Fields[2].At(0))
+	})
+
+	t.Run("joins by a string field", func(t *testing.T) {
+		c := data.NewFrame("", data.NewField("host", nil, []string{"b", "a"}), data.NewField("up", nil, []float64{0, 1}))
+		d := data.NewFrame("", data.NewField("host", nil, []string{"a"}), data.NewField("dc", nil, []string{"eu"}))
+		res := transform(t, IDJoinByField, `{"byField": "host"}`, c, d)
+		require.Equal(t, []any{"a", "b"}, []any{res[0].Fields[0].At(0), res[0].Fields[0].At(1)})
+		v, ok := res[0].Fields[2].ConcreteAt(0)
+		require.True(t, ok)
+		require.Equal(t, "eu", v)
+	})
+}
@@ -0,0 +1,57 @@
+package transformations
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// newMerge creates the merge transformation, that merges all frames into one frame with the rows of all
+// frames, in order. The fields of the frames are matched by name, and are null in the rows of the frames
+// that do not have them.
+func newMerge(_ json.RawMessage) (Transformer, error) {
+	return merge, nil
+}
+
+func merge(frames data.Frames) (data.Frames, error) {
+	if len(frames) == 0 {
+		return data.Frames{}, nil
+	}
+	rows := 0
+	var fields []*data.Field
+	byName := map[string]*data.Field{}
+	for _, frame := range frames {
+		for _, f := range frame.Fields {
+			nf, ok := byName[f.Name]
+			if !ok {
+				nf = emptyCopy(f, 0)
+				byName[f.Name] = nf
+				fields = append(fields, nf)
+				continue
+			}
+			if nf.Type() != f.Type().NullableType() {
+				return nil, fmt.Errorf("can not merge field %s of type %s and type %s", f.Name, nf.Type(), f.Type())
+			}
+		}
+		rows += frame.Rows()
+	}
+
+	for _, nf := range fields {
+		nf.Extend(rows)
+	}
+	offset := 0
+	for _, frame := range frames {
+		for _, f := range frame.Fields {
+			nf := byName[f.Name]
+			for row := 0; row < f.Len(); row++ {
+				copyValue(nf, offset+row, f, row)
+			}
+		}
+		offset += frame.Rows()
+	}
+
+	res := data.NewFrame(frames[0].Name, fields...)
+	res.RefID = frames[0].RefID
+	return data.Frames{res}, nil
+}
@@ -0,0 +1,29 @@
+package transformations
+
+import (
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMerge(t *testing.T) {
+	a := data.NewFrame("a", data.NewField("host", nil, []string{"a"}), data.NewField("cpu", nil, []float64{1}))
+	b := data.NewFrame("b", data.NewField("host", nil, []string{"b"}), data.NewField("mem", nil, []float64{2}))
+	res := transform(t, IDMerge, ``, a, b)
+
+	expected := data.NewFrame("a",
+		data.NewField("host", nil, []*string{strP("a"), strP("b")}),
+		data.NewField("cpu", nil, []*float64{fp(1), nil}),
+		data.NewField("mem", nil, []*float64{nil, fp(2)}),
+	)
+	require.Equal(t, data.Frames{expected}, res)
+
+	t.Run("fails for fields with different types", func(t *testing.T) {
+		c := data.NewFrame("", data.NewField("host", nil, []float64{1}))
+		tr, err := New(IDMerge, nil)
+		require.NoError(t, err)
+		_, err = tr(data.Frames{a, c})
+		require.Error(t, err)
+	})
+}
@@ -0,0 +1,60 @@
+package transformations
+
+import (
+	"encoding/json"
+	"math"
+	"sort"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+// OrganizeOptions are the options of the organize transformation.
+type OrganizeOptions struct {
+	// ExcludeByName removes the fields with the names that are true.
+	ExcludeByName map[string]bool `json:"excludeByName,omitempty"`
+	// IncludeByName, if not empty, keeps only the fields with the names that are true.
+	IncludeByName map[string]bool `json:"includeByName,omitempty"`
+	// IndexByName orders the fields by their index. Fields without an index are moved to the end.
+	IndexByName map[string]int `json:"indexByName,omitempty"`
+	// RenameByName renames the fields to the new names that are not empty.
+	RenameByName map[string]string `json:"renameByName,omitempty"`
+}
+
+// newOrganize creates the organize transformation, that removes, orders and renames the fields of each frame.
+func newOrganize(options json.RawMessage) (Transformer, error) {
+	opts := OrganizeOptions{}
+	if err := parseOptions(options, &opts); err != nil {
+		return nil, err
+	}
+	return perFrame(func(frame *data.Frame) (*data.Frame, error) {
+		fields := make([]*data.Field, 0, len(frame.Fields))
+		for _, f := range frame.Fields {
+			if opts.ExcludeByName[f.Name] {
+				continue
+			}
+			if len(opts.IncludeByName) > 0 && !opts.IncludeByName[f.Name] {
+				continue
+			}
+			fields = append(fields, f)
+		}
+		index := func(f *data.Field) int {
+			if i, ok := opts.IndexByName[f.Name]; ok {
+				return i
+			}
+			return math.MaxInt
+		}
+		sort.SliceStable(fields, func(i, j int) bool { return index(fields[i]) < index(fields[j]) })
+
+		for i, f := range fields {
+			if name := opts.RenameByName[f.Name]; name != "" {
+				renamed := *f
+				renamed.Name = name
+				fields[i] = &renamed
+			}
+		}
+		res := data.NewFrame(frame.Name, fields...)
+		res.RefID = frame.RefID
+		res.Meta = frame.Meta
+		return res, nil
+	}), nil
+}
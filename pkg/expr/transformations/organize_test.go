@@ -0,0 +1,31 @@
+package transformations
+
+import (
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOrganize(t *testing.T) {
+	frame := data.NewFrame("",
+		data.NewField("a", nil, []float64{1}),
+		data.NewField("b", nil, []float64{2}),
+		data.NewField("c", nil, []float64{3}),
+		data.NewField("d", nil, []float64{4}),
+	)
+	res := transform(t, IDOrganize, `{
+		"excludeByName": {"b": true},
+		"indexByName": {"d": 0, "a": 1},
+		"renameByName": {"d": "renamed"}
+	}`, frame)
+
+	require.Len(t, res, 1)
+	names := []string{}
+	for _, f := range res[0].Fields {
+		names = append(names, f.Name)
+	}
+	require.Equal(t, []string{"renamed", "a", "c"}, names)
+	require.Equal(t, 4.0, res[0].Fields[0].At(0))
+	require.Equal(t, "d", frame.Fields[3].Name, "the input frame is not modified")
+}
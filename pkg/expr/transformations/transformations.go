@@ -0,0 +1,196 @@
+// Package transformations runs a subset of the dashboard data transformations on the server,
+// so that the frames of queries can be transformed the same way in the expression pipeline as in panels.
+// The ids and options of the transformations are the ones of the transformations in the dashboard JSON.
+package transformations
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/expr/mathexp"
+)
+
+// Transformer applies a configured transformation to frames. It must not modify the input frames.
+type Transformer func(frames data.Frames) (data.Frames, error)
+
+// TransformerFactory creates the Transformer of a transformation from its JSON options.
+type TransformerFactory func(options json.RawMessage) (Transformer, error)
+
+const (
+	IDOrganize       = "organize"
+	IDJoinByField    = "joinByField"
+	IDGroupBy        = "groupBy"
+	IDMerge          = "merge"
+	IDFilterByValue  = "filterByValue"
+	IDCalculateField = "calculateField"
+)
+
+var (
+	transformersMu sync.RWMutex
+	transformers   = map[string]TransformerFactory{
+		IDOrganize:       newOrganize,
+		IDJoinByField:    newJoinByField,
+		IDGroupBy:        newGroupBy,
+		IDMerge:          newMerge,
+		IDFilterByValue:  newFilterByValue,
+		IDCalculateField: newCalculateField,
+	}
+)
+
+// Register makes a transformation available to New.
+// It replaces a previously registered transformation with the same id.
+func Register(id string, factory TransformerFactory) {
+	transformersMu.Lock()
+	defer transformersMu.Unlock()
+	transformers[id] = factory
+}
+
+// Supported returns the sorted ids of the registered transformations.
+func Supported() []string {
+	transformersMu.RLock()
+	defer transformersMu.RUnlock()
+	ids := make([]string, 0, len(transformers))
+	for id := range transformers {
+		ids = append(ids, id)
+	}
+	sort.Strings(ids)
+	return ids
+}
+
+// New creates the Transformer of the transformation with the id from its JSON options.
+func New(id string, options json.RawMessage) (Transformer, error) {
+	transformersMu.RLock()
+	factory, ok := transformers[id]
+	transformersMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("transformation '%s' is not supported. Supported only: %v", id, Supported())
+	}
+	t, err := factory(options)
+	if err != nil {
+		return nil, fmt.Errorf("invalid options of transformation '%s': %w", id, err)
+	}
+	return t, nil
+}
+
+// parseOptions unmarshals the options into v, where no options leave v unchanged.
+func parseOptions(options json.RawMessage, v any) error {
+	if len(options) == 0 || string(options) == "null" {
+		return nil
+	}
+	return json.Unmarshal(options, v)
+}
+
+// perFrame returns a Transformer that transforms each frame on its own.
+func perFrame(frameF func(frame *data.Frame) (*data.Frame, error)) Transformer {
+	return func(frames data.Frames) (data.Frames, error) {
+		res := make(data.Frames, 0, len(frames))
+		for _, frame := range frames {
+			f, err := frameF(frame)
+			if err != nil {
+				return nil, err
+			}
+			if f != nil {
+				res = append(res, f)
+			}
+		}
+		return res, nil
+	}
+}
+
+// fieldByName returns the first field of the frame with the name, or nil.
+func fieldByName(frame *data.Frame, name string) *data.Field {
+	for _, f := range frame.Fields {
+		if f.Name == name {
+			return f
+		}
+	}
+	return nil
+}
+
+// firstTimeField returns the first time field of the frame, or nil.
+func firstTimeField(frame *data.Frame) *data.Field {
+	for _, f := range frame.Fields {
+		if f.Type().Time() {
+			return f
+		}
+	}
+	return nil
+}
+
+// emptyCopy returns a nullable field with the name, labels and config of f and n null values.
+func emptyCopy(f *data.Field, n int) *data.Field {
+	res := data.NewFieldFromFieldType(f.Type().NullableType(), n)
+	res.Name = f.Name
+	res.Labels = f.Labels
+	res.Config = f.Config
+	return res
+}
+
+// copyValue sets the value at index idx of dst, which is nullable, to the value at index row of src.
+func copyValue(dst *data.Field, idx int, src *data.Field, row int) {
+	if v, ok := src.ConcreteAt(row); ok {
+		dst.SetConcrete(idx, v)
+	}
+}
+
+// valueKey returns a comparable key of the value at index idx of f, and false if the value is null.
+func valueKey(f *data.Field, idx int) (any, bool) {
+	v, ok := f.ConcreteAt(idx)
+	if !ok {
+		return nil, false
+	}
+	if t, isTime := v.(time.Time); isTime {
+		return t.UnixNano(), true
+	}
+	return v, true
+}
+
+// reducers maps the ids of the reducers in the dashboard JSON to the reducers of math expressions.
+var reducers = map[string]mathexp.ReducerID{
+	"sum":         mathexp.ReducerSum,
+	"mean":        mathexp.ReducerMean,
+	"min":         mathexp.ReducerMin,
+	"max":         mathexp.ReducerMax,
+	"count":       mathexp.ReducerCount,
+	"last":        mathexp.ReducerLast,
+	"first":       mathexp.ReducerFirst,
+	"median":      mathexp.ReducerMedian,
+	"range":       mathexp.ReducerRange,
+	"diff":        mathexp.ReducerDiff,
+	"diffperc":    mathexp.ReducerPercentDiff,
+	"delta":       mathexp.ReducerDelta,
+	"stdDev":      mathexp.ReducerStdDev,
+	"variance":    mathexp.ReducerVariance,
+	"changeCount": mathexp.ReducerChangeCount,
+}
+
+// reducer returns a function that reduces values with the reducer with the id. Null values are
+// skipped, except by count, which counts all values.
+func reducer(id string) (func(values []*float64) *float64, error) {
+	rID, ok := reducers[id]
+	if !ok {
+		return nil, fmt.Errorf("reducer '%s' is not supported", id)
+	}
+	rFunc, err := mathexp.GetReduceFunc(rID)
+	if err != nil {
+		return nil, err
+	}
+	return func(values []*float64) *float64 {
+		if rID != mathexp.ReducerCount {
+			notNull := make([]*float64, 0, len(values))
+			for _, v := range values {
+				if v != nil {
+					notNull = append(notNull, v)
+				}
+			}
+			values = notNull
+		}
+		ff := mathexp.Float64Field(*data.NewField("", nil, values))
+		return rFunc(&ff)
+	}, nil
+}
@@ -0,0 +1,52 @@
+package transformations
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/stretchr/testify/require"
+)
+
+// transform creates the transformation with the options and applies it to the frames.
+func transform(t *testing.T, id, options string, frames ...*data.Frame) data.Frames {
+	t.Helper()
+	tr, err := New(id, json.RawMessage(options))
+	require.NoError(t, err)
+	res, err := tr(frames)
+	require.NoError(t, err)
+	return res
+}
+
+func fp(f float64) *float64 {
+	return &f
+}
+
+func TestNew(t *testing.T) {
+	t.Run("fails for unknown transformation", func(t *testing.T) {
+		_, err := New("seriesToRows", nil)
+		require.ErrorContains(t, err, "not supported")
+	})
+
+	t.Run("fails for invalid options", func(t *testing.T) {
+		_, err := New(IDJoinByField, json.RawMessage(`{"mode": "left"}`))
+		require.Error(t, err)
+		_, err = New(IDOrganize, json.RawMessage(`{"excludeByName": 1}`))
+		require.Error(t, err)
+	})
+
+	t.Run("registers transformations", func(t *testing.T) {
+		Register("noop", func(json.RawMessage) (Transformer, error) {
+			return func(frames data.Frames) (data.Frames, error) { return frames, nil }, nil
+		})
+		t.Cleanup(func() {
+			transformersMu.Lock()
+			delete(transformers, "noop")
+			transformersMu.Unlock()
+		})
+		require.Contains(t, Supported(), "noop")
+		frame := data.NewFrame("", data.NewField("time", nil, []time.Time{time.Unix(0, 0)}))
+		require.Equal(t, data.Frames{frame}, transform(t, "noop", "", frame))
+	})
+}
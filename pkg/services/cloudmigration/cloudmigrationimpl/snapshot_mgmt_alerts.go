@@ -143,6 +143,7 @@ type alertRule struct {
 	Labels               map[string]string                          `json:"labels,omitempty"`
 	Record               *definitions.Record                        `json:"record"`
 	NotificationSettings *definitions.AlertRuleNotificationSettings `json:"notification_settings"`
+	EvaluationPolicy     *definitions.EvaluationPolicy              `json:"evaluation_policy,omitempty"`
 	FolderUID            string                                     `json:"folderUID"`
 	RuleGroup            string                                     `json:"ruleGroup"`
 	NoDataState          string                                     `json:"noDataState"`
@@ -153,6 +154,7 @@ type alertRule struct {
 	Data                 []definitions.AlertQuery                   `json:"data"`
 	ID                   int64                                      `json:"id"`
 	For                  model.Duration                             `json:"for"`
+	KeepFiringFor        model.Duration                             `json:"keepFiringFor,omitempty"`
 	OrgID                int64                                      `json:"orgID"`
 	IsPaused             bool                                       `json:"isPaused"`
 }
@@ -178,6 +180,7 @@ func (s *Service) getAlertRules(ctx context.Context, signedInUser *user.SignedIn
 			RuleGroup:            rule.RuleGroup,
 			Title:                rule.Title,
 			For:                  model.Duration(rule.For),
+			KeepFiringFor:        model.Duration(rule.KeepFiringFor),
 			Condition:            rule.Condition,
 			Data:                 ngalertapi.ApiAlertQueriesFromAlertQueries(rule.Data),
 			Updated:              rule.Updated,
@@ -188,6 +191,7 @@ func (s *Service) getAlertRules(ctx context.Context, signedInUser *user.SignedIn
 			IsPaused:             rule.IsPaused,
 			NotificationSettings: ngalertapi.AlertRuleNotificationSettingsFromNotificationSettings(rule.NotificationSettings),
 			Record:               ngalertapi.ApiRecordFromModelRecord(rule.Record),
+			EvaluationPolicy:     ngalertapi.ApiEvaluationPolicyFromModel(rule.EvaluationPolicy),
 		})
 	}
 
@@ -69,8 +69,15 @@ type API struct {
 	ReceiverService      *notifier.ReceiverService
 	ContactPointService  *provisioning.ContactPointService
 	Templates            *provisioning.TemplateService
+	TemplateFixtures     *provisioning.TemplateFixtureService
 	MuteTimings          *provisioning.MuteTimingService
 	AlertRules           *provisioning.AlertRuleService
+	RuleTemplates        *provisioning.RuleTemplateService
+	RecurringSilences    *notifier.RecurringSilenceService
+	SilenceStore         notifier.SilenceStore
+	SilenceAuditStore    notifier.SilenceAuditStore
+	SilenceExpiry        *notifier.SilenceExpiryNotificationService
+	Deliveries           *notifier.NotificationDeliveryService
 	AlertsRouter         *sender.AlertsRouter
 	EvaluatorFactory     eval.EvaluatorFactory
 	ConditionValidator   *eval.ConditionValidator
@@ -106,11 +113,15 @@ func (api *API) RegisterAPIEndpoints(m *metrics.API) {
 				accesscontrol.NewSilenceService(api.AccessControl, api.RuleStore),
 				api.TransactionManager,
 				logger,
-				api.MultiOrgAlertmanager,
+				api.SilenceStore,
 				api.RuleStore,
 				ruleAuthzService,
+				api.SilenceAuditStore,
 			),
-			receiverAuthz: accesscontrol.NewReceiverAccess[ReceiverStatus](api.AccessControl, false),
+			recurringSilenceSvc: api.RecurringSilences,
+			silenceExpirySvc:    api.SilenceExpiry,
+			templateFixtureSvc:  api.TemplateFixtures,
+			receiverAuthz:       accesscontrol.NewReceiverAccess[ReceiverStatus](api.AccessControl, false),
 		},
 	), m)
 	// Register endpoints for proxying to Prometheus-compatible backends.
@@ -143,9 +154,10 @@ func (api *API) RegisterAPIEndpoints(m *metrics.API) {
 			DatasourceCache: api.DatasourceCache,
 			log:             logger,
 			authz:           ruleAuthzService,
+			ac:              api.AccessControl,
 			evaluator:       api.EvaluatorFactory,
 			cfg:             &api.Cfg.UnifiedAlerting,
-			backtesting:     backtesting.NewEngine(api.AppUrl, api.EvaluatorFactory, api.Tracer),
+			backtesting:     backtesting.NewEngine(api.AppUrl, api.EvaluatorFactory, api.Tracer, api.MultiOrgAlertmanager),
 			featureManager:  api.FeatureManager,
 			appUrl:          api.AppUrl,
 			tracer:          api.Tracer,
@@ -168,6 +180,7 @@ func (api *API) RegisterAPIEndpoints(m *metrics.API) {
 		templates:           api.Templates,
 		muteTimings:         api.MuteTimings,
 		alertRules:          api.AlertRules,
+		ruleTemplates:       api.RuleTemplates,
 		// XXX: Used to flag recording rules, remove when FT is removed
 		featureManager: api.FeatureManager,
 	}), m)
@@ -181,5 +194,6 @@ func (api *API) RegisterAPIEndpoints(m *metrics.API) {
 		logger:            logger,
 		receiverService:   api.ReceiverService,
 		muteTimingService: api.MuteTimings,
+		deliveryService:   api.Deliveries,
 	}), m)
 }
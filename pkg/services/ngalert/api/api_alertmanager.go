@@ -35,13 +35,16 @@ type receiversAuthz interface {
 }
 
 type AlertmanagerSrv struct {
-	log            log.Logger
-	ac             accesscontrol.AccessControl
-	mam            *notifier.MultiOrgAlertmanager
-	crypto         notifier.Crypto
-	silenceSvc     SilenceService
-	featureManager featuremgmt.FeatureToggles
-	receiverAuthz  receiversAuthz
+	log                 log.Logger
+	ac                  accesscontrol.AccessControl
+	mam                 *notifier.MultiOrgAlertmanager
+	crypto              notifier.Crypto
+	silenceSvc          SilenceService
+	recurringSilenceSvc RecurringSilenceService
+	silenceExpirySvc    SilenceExpiryNotificationService
+	templateFixtureSvc  TemplateFixtureService
+	featureManager      featuremgmt.FeatureToggles
+	receiverAuthz       receiversAuthz
 }
 
 type UnknownReceiverError struct {
@@ -189,6 +192,11 @@ func (srv AlertmanagerSrv) RoutePostGrafanaAlertingConfigHistoryActivate(c *cont
 }
 
 func (srv AlertmanagerSrv) RoutePostAlertingConfig(c *contextmodel.ReqContext, body apimodels.PostableUserConfig) response.Response {
+	return srv.postAlertingConfig(c, body, srv.mam.SaveAndApplyAlertmanagerConfiguration)
+}
+
+// postAlertingConfig checks that the configuration can replace the current one and saves it with save.
+func (srv AlertmanagerSrv) postAlertingConfig(c *contextmodel.ReqContext, body apimodels.PostableUserConfig, save func(ctx context.Context, org int64, config apimodels.PostableUserConfig) error) response.Response {
 	// Remove autogenerated config from the user config before checking provenance guard and eventually saving it.
 	// TODO: This and provenance guard should be moved to the notifier package.
 	notifier.RemoveAutogenConfigIfExists(body.AlertmanagerConfig.Route)
@@ -212,7 +220,7 @@ func (srv AlertmanagerSrv) RoutePostAlertingConfig(c *contextmodel.ReqContext, b
 			return ErrResp(http.StatusBadRequest, err, "")
 		}
 	}
-	err = srv.mam.SaveAndApplyAlertmanagerConfiguration(c.Req.Context(), c.SignedInUser.GetOrgID(), body)
+	err = save(c.Req.Context(), c.SignedInUser.GetOrgID(), body)
 	if err == nil {
 		return response.JSON(http.StatusAccepted, util.DynMap{"message": "configuration created"})
 	}
@@ -234,6 +242,42 @@ func (srv AlertmanagerSrv) RoutePostAlertingConfig(c *contextmodel.ReqContext, b
 	return response.ErrOrFallback(http.StatusInternalServerError, err.Error(), err)
 }
 
+func (srv AlertmanagerSrv) RoutePostAlertingConfigImport(c *contextmodel.ReqContext, body apimodels.PostableAlertmanagerImport) response.Response {
+	cfg, diags := notifier.ConvertUpstreamConfig(c.Req.Context(), body.AlertmanagerConfig, body.TemplateFiles)
+	result := apimodels.AlertmanagerImportResult{Diagnostics: diags}
+	if result.Diagnostics == nil {
+		result.Diagnostics = apimodels.AlertmanagerImportDiagnostics{}
+	}
+	if cfg == nil || (diags.HasErrors() && !c.QueryBool("force")) {
+		result.Config = withoutSecureSettings(cfg)
+		return response.JSON(http.StatusBadRequest, result)
+	}
+	if c.QueryBool("dryRun") {
+		result.Config = withoutSecureSettings(cfg)
+		return response.JSON(http.StatusOK, result)
+	}
+
+	if resp := srv.postAlertingConfig(c, *cfg, srv.mam.SaveAndApplyImportedAlertmanagerConfiguration); resp.Status() != http.StatusAccepted {
+		return resp
+	}
+	result.Config = withoutSecureSettings(cfg)
+	result.Applied = true
+	return response.JSON(http.StatusAccepted, result)
+}
+
+// withoutSecureSettings removes the secure settings of contact points from the configuration.
+func withoutSecureSettings(cfg *apimodels.PostableUserConfig) *apimodels.PostableUserConfig {
+	if cfg == nil {
+		return nil
+	}
+	for _, r := range cfg.AlertmanagerConfig.Receivers {
+		for _, gr := range r.GrafanaManagedReceivers {
+			gr.SecureSettings = nil
+		}
+	}
+	return cfg
+}
+
 func (srv AlertmanagerSrv) RouteGetReceivers(c *contextmodel.ReqContext) response.Response {
 	am, errResp := srv.AlertmanagerFor(c.SignedInUser.GetOrgID())
 	if errResp != nil {
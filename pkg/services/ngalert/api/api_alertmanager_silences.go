@@ -2,7 +2,10 @@ package api
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/go-openapi/strfmt"
 
@@ -23,8 +26,31 @@ type SilenceService interface {
 	DeleteSilence(ctx context.Context, user identity.Requester, silenceID string) error
 	WithAccessControlMetadata(ctx context.Context, user identity.Requester, silencesWithMetadata ...*models.SilenceWithMetadata) error
 	WithRuleMetadata(ctx context.Context, user identity.Requester, silences ...*models.SilenceWithMetadata) error
+	ListSilenceAudit(ctx context.Context, user identity.Requester, query models.ListSilenceAuditEntriesQuery) ([]*models.SilenceAuditEntry, error)
 }
 
+// RecurringSilenceService is the service for managing and authenticating recurring silences access in Grafana AM.
+type RecurringSilenceService interface {
+	GetRecurringSilence(ctx context.Context, user identity.Requester, uid string) (*models.RecurringSilence, error)
+	ListRecurringSilences(ctx context.Context, user identity.Requester) ([]*models.RecurringSilence, error)
+	CreateRecurringSilence(ctx context.Context, user identity.Requester, rs models.RecurringSilence) (*models.RecurringSilence, error)
+	UpdateRecurringSilence(ctx context.Context, user identity.Requester, rs models.RecurringSilence) (*models.RecurringSilence, error)
+	DeleteRecurringSilence(ctx context.Context, user identity.Requester, uid string) error
+}
+
+// SilenceExpiryNotificationService is the service for managing and authenticating the expiry notifications of silences
+// in Grafana AM.
+type SilenceExpiryNotificationService interface {
+	GetSilenceExpiryNotification(ctx context.Context, user identity.Requester, silenceID string) (*models.SilenceExpiryNotification, error)
+	SaveSilenceExpiryNotification(ctx context.Context, user identity.Requester, n models.SilenceExpiryNotification) (*models.SilenceExpiryNotification, error)
+	DeleteSilenceExpiryNotification(ctx context.Context, user identity.Requester, silenceID string) error
+}
+
+const (
+	defaultSilenceAuditLimit = 100
+	maxSilenceAuditLimit     = 1000
+)
+
 // RouteGetSilence is the single silence GET endpoint for Grafana AM.
 func (srv AlertmanagerSrv) RouteGetSilence(c *contextmodel.ReqContext, silenceID string) response.Response {
 	silence, err := srv.silenceSvc.GetSilence(c.Req.Context(), c.SignedInUser, silenceID)
@@ -99,6 +125,101 @@ func (srv AlertmanagerSrv) RouteDeleteSilence(c *contextmodel.ReqContext, silenc
 	return response.JSON(http.StatusOK, util.DynMap{"message": "silence deleted"})
 }
 
+// RouteGetRecurringSilence is the single recurring silence GET endpoint for Grafana AM.
+func (srv AlertmanagerSrv) RouteGetRecurringSilence(c *contextmodel.ReqContext, uid string) response.Response {
+	rs, err := srv.recurringSilenceSvc.GetRecurringSilence(c.Req.Context(), c.SignedInUser, uid)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get recurring silence", err)
+	}
+	return response.JSON(http.StatusOK, GettableRecurringSilenceFromRecurringSilence(*rs))
+}
+
+// RouteGetRecurringSilences is the recurring silence list GET endpoint for Grafana AM.
+func (srv AlertmanagerSrv) RouteGetRecurringSilences(c *contextmodel.ReqContext) response.Response {
+	silences, err := srv.recurringSilenceSvc.ListRecurringSilences(c.Req.Context(), c.SignedInUser)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to list recurring silences", err)
+	}
+	return response.JSON(http.StatusOK, GettableRecurringSilencesFromRecurringSilences(silences))
+}
+
+// RouteCreateRecurringSilence is the recurring silence POST (create + update) endpoint for Grafana AM.
+func (srv AlertmanagerSrv) RouteCreateRecurringSilence(c *contextmodel.ReqContext, postable apimodels.PostableRecurringSilence) response.Response {
+	action := srv.recurringSilenceSvc.UpdateRecurringSilence
+	if postable.UID == "" {
+		action = srv.recurringSilenceSvc.CreateRecurringSilence
+	}
+	rs, err := action(c.Req.Context(), c.SignedInUser, RecurringSilenceFromPostableRecurringSilence(postable))
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to create/update recurring silence", err)
+	}
+	return response.JSON(http.StatusAccepted, GettableRecurringSilenceFromRecurringSilence(*rs))
+}
+
+// RouteDeleteRecurringSilence is the recurring silence DELETE endpoint for Grafana AM.
+func (srv AlertmanagerSrv) RouteDeleteRecurringSilence(c *contextmodel.ReqContext, uid string) response.Response {
+	if err := srv.recurringSilenceSvc.DeleteRecurringSilence(c.Req.Context(), c.SignedInUser, uid); err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to delete recurring silence", err)
+	}
+	return response.JSON(http.StatusOK, util.DynMap{"message": "recurring silence deleted"})
+}
+
+// RouteGetSilenceAudit is the silence audit log GET endpoint for Grafana AM.
+func (srv AlertmanagerSrv) RouteGetSilenceAudit(c *contextmodel.ReqContext) response.Response {
+	query := models.ListSilenceAuditEntriesQuery{
+		SilenceID:  c.Query("silenceID"),
+		ActorLogin: c.Query("actor"),
+		Limit:      c.QueryInt("limit"),
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultSilenceAuditLimit
+	}
+	if query.Limit > maxSilenceAuditLimit {
+		return ErrResp(http.StatusBadRequest, fmt.Errorf("limit must not be greater than %d", maxSilenceAuditLimit), "")
+	}
+	if from := c.QueryInt64("from"); from > 0 {
+		query.From = time.Unix(from, 0)
+	}
+	if to := c.QueryInt64("to"); to > 0 {
+		query.To = time.Unix(to, 0)
+	}
+	if !query.From.IsZero() && !query.To.IsZero() && query.From.After(query.To) {
+		return ErrResp(http.StatusBadRequest, errors.New("from must not be after to"), "")
+	}
+
+	entries, err := srv.silenceSvc.ListSilenceAudit(c.Req.Context(), c.SignedInUser, query)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get silence audit log", err)
+	}
+	return response.JSON(http.StatusOK, GettableSilenceAuditEntriesFromSilenceAuditEntries(entries))
+}
+
+// RouteGetSilenceExpiryNotification is the silence expiry notification GET endpoint for Grafana AM.
+func (srv AlertmanagerSrv) RouteGetSilenceExpiryNotification(c *contextmodel.ReqContext, silenceID string) response.Response {
+	n, err := srv.silenceExpirySvc.GetSilenceExpiryNotification(c.Req.Context(), c.SignedInUser, silenceID)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get silence expiry notification", err)
+	}
+	return response.JSON(http.StatusOK, SilenceExpiryNotificationToAPI(*n))
+}
+
+// RouteCreateSilenceExpiryNotification is the silence expiry notification POST (create + replace) endpoint for Grafana AM.
+func (srv AlertmanagerSrv) RouteCreateSilenceExpiryNotification(c *contextmodel.ReqContext, postable apimodels.PostableSilenceExpiryNotification, silenceID string) response.Response {
+	n, err := srv.silenceExpirySvc.SaveSilenceExpiryNotification(c.Req.Context(), c.SignedInUser, SilenceExpiryNotificationFromPostable(postable, silenceID))
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to save silence expiry notification", err)
+	}
+	return response.JSON(http.StatusAccepted, SilenceExpiryNotificationToAPI(*n))
+}
+
+// RouteDeleteSilenceExpiryNotification is the silence expiry notification DELETE endpoint for Grafana AM.
+func (srv AlertmanagerSrv) RouteDeleteSilenceExpiryNotification(c *contextmodel.ReqContext, silenceID string) response.Response {
+	if err := srv.silenceExpirySvc.DeleteSilenceExpiryNotification(c.Req.Context(), c.SignedInUser, silenceID); err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to delete silence expiry notification", err)
+	}
+	return response.JSON(http.StatusOK, util.DynMap{"message": "silence expiry notification deleted"})
+}
+
 // withEmptyMetadata creates a slice of SilenceWithMetadata from a slice of Silence where the metadata for each silence
 // is empty.
 func withEmptyMetadata(silences ...*models.Silence) []*models.SilenceWithMetadata {
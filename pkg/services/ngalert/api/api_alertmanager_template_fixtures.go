@@ -0,0 +1,65 @@
+package api
+
+import (
+	"context"
+	"net/http"
+
+	"github.com/grafana/grafana/pkg/api/response"
+	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+// TemplateFixtureService is the service for managing the fixtures of notification templates and testing the templates
+// with them.
+type TemplateFixtureService interface {
+	GetTemplateFixtures(ctx context.Context, orgID int64) ([]models.TemplateFixture, error)
+	GetTemplateFixture(ctx context.Context, orgID int64, name string) (models.TemplateFixture, error)
+	SaveTemplateFixture(ctx context.Context, orgID int64, f models.TemplateFixture) (models.TemplateFixture, error)
+	DeleteTemplateFixture(ctx context.Context, orgID int64, name string) error
+	TestTemplateFixtures(ctx context.Context, orgID int64) ([]models.TemplateFixtureResult, error)
+}
+
+func (srv AlertmanagerSrv) RouteGetTemplateFixtures(c *contextmodel.ReqContext) response.Response {
+	fixtures, err := srv.templateFixtureSvc.GetTemplateFixtures(c.Req.Context(), c.SignedInUser.GetOrgID())
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get template fixtures", err)
+	}
+	result := make(apimodels.TemplateFixtures, 0, len(fixtures))
+	for _, f := range fixtures {
+		result = append(result, TemplateFixtureToAPI(f))
+	}
+	return response.JSON(http.StatusOK, result)
+}
+
+func (srv AlertmanagerSrv) RouteGetTemplateFixture(c *contextmodel.ReqContext, name string) response.Response {
+	f, err := srv.templateFixtureSvc.GetTemplateFixture(c.Req.Context(), c.SignedInUser.GetOrgID(), name)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get template fixture", err)
+	}
+	return response.JSON(http.StatusOK, TemplateFixtureToAPI(f))
+}
+
+func (srv AlertmanagerSrv) RoutePostTemplateFixture(c *contextmodel.ReqContext, fixture apimodels.TemplateFixture) response.Response {
+	f, err := srv.templateFixtureSvc.SaveTemplateFixture(c.Req.Context(), c.SignedInUser.GetOrgID(), TemplateFixtureFromAPI(fixture))
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to save template fixture", err)
+	}
+	return response.JSON(http.StatusAccepted, TemplateFixtureToAPI(f))
+}
+
+func (srv AlertmanagerSrv) RouteDeleteTemplateFixture(c *contextmodel.ReqContext, name string) response.Response {
+	if err := srv.templateFixtureSvc.DeleteTemplateFixture(c.Req.Context(), c.SignedInUser.GetOrgID(), name); err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to delete template fixture", err)
+	}
+	return response.JSON(http.StatusOK, util.DynMap{"message": "template fixture deleted"})
+}
+
+func (srv AlertmanagerSrv) RoutePostTestTemplateFixtures(c *contextmodel.ReqContext) response.Response {
+	results, err := srv.templateFixtureSvc.TestTemplateFixtures(c.Req.Context(), c.SignedInUser.GetOrgID())
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to test templates with fixtures", err)
+	}
+	return response.JSON(http.StatusOK, TemplateFixtureTestResultsToAPI(results))
+}
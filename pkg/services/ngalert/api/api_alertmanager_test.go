@@ -12,6 +12,7 @@ import (
 	"github.com/google/go-cmp/cmp/cmpopts"
 	"github.com/prometheus/alertmanager/pkg/labels"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/require"
 
 	"github.com/grafana/grafana/pkg/services/authz/zanzana"
@@ -555,6 +556,117 @@ func TestRoutePostTestTemplates(t *testing.T) {
 	})
 }
 
+func TestRoutePostAlertingConfigImport(t *testing.T) {
+	upstream := `
+route:
+  receiver: team-a
+  routes:
+    - receiver: team-a
+      match:
+        severity: critical
+receivers:
+  - name: team-a
+    webhook_configs:
+      - url: https://example.com/hook
+        http_config:
+          basic_auth:
+            username: user
+            password: secret
+`
+	withInhibitRule := upstream + `
+inhibit_rules:
+  - source_matchers: [severity="critical"]
+    target_matchers: [severity="warning"]
+`
+	requestCtx := func(t *testing.T, query string) *contextmodel.ReqContext {
+		req, err := http.NewRequest(http.MethodPost, "https://grafana.net?"+query, nil)
+		require.NoError(t, err)
+		rc := createRequestCtxInOrg(1)
+		rc.Req = req
+		return rc
+	}
+	parse := func(t *testing.T, r response.Response) apimodels.AlertmanagerImportResult {
+		var result apimodels.AlertmanagerImportResult
+		require.NoError(t, json.Unmarshal(r.Body(), &result))
+		return result
+	}
+
+	t.Run("assert 200 and configuration without secure settings on dry run", func(t *testing.T) {
+		sut := createSut(t)
+		r := sut.RoutePostAlertingConfigImport(requestCtx(t, "dryRun=true"), apimodels.PostableAlertmanagerImport{AlertmanagerConfig: upstream})
+		require.Equal(t, http.StatusOK, r.Status())
+		result := parse(t, r)
+		require.False(t, result.Applied)
+		require.Empty(t, result.Diagnostics)
+		require.NotNil(t, result.Config)
+		gr := result.Config.AlertmanagerConfig.Receivers[0].GrafanaManagedReceivers
+		require.Len(t, gr, 1)
+		require.Equal(t, "webhook", gr[0].Type)
+		require.Empty(t, gr[0].SecureSettings)
+
+		// The current configuration is not changed.
+		current, err := sut.mam.GetAlertmanagerConfiguration(context.Background(), 1, false)
+		require.NoError(t, err)
+		require.NotEqual(t, "team-a", current.AlertmanagerConfig.Route.Receiver)
+	})
+
+	t.Run("assert 400 and diagnostics when parts cannot be imported", func(t *testing.T) {
+		sut := createSut(t)
+		withSecretFile := upstream + `
+    pagerduty_configs:
+      - routing_key_file: /etc/alertmanager/pagerduty
+`
+		r := sut.RoutePostAlertingConfigImport(requestCtx(t, ""), apimodels.PostableAlertmanagerImport{AlertmanagerConfig: withSecretFile})
+		require.Equal(t, http.StatusBadRequest, r.Status())
+		result := parse(t, r)
+		require.False(t, result.Applied)
+		require.Len(t, result.Diagnostics, 1)
+		require.Equal(t, "receivers[team-a].pagerduty_configs[0]", result.Diagnostics[0].Path)
+	})
+
+	t.Run("assert 400 when configuration cannot be parsed", func(t *testing.T) {
+		sut := createSut(t)
+		r := sut.RoutePostAlertingConfigImport(requestCtx(t, "force=true"), apimodels.PostableAlertmanagerImport{AlertmanagerConfig: "route: ["})
+		require.Equal(t, http.StatusBadRequest, r.Status())
+		require.Nil(t, parse(t, r).Config)
+	})
+
+	t.Run("assert 202 and applied configuration with inhibition rules", func(t *testing.T) {
+		sut := createSut(t)
+		r := sut.RoutePostAlertingConfigImport(requestCtx(t, ""), apimodels.PostableAlertmanagerImport{AlertmanagerConfig: withInhibitRule})
+		require.Equal(t, http.StatusAccepted, r.Status())
+		result := parse(t, r)
+		require.True(t, result.Applied)
+		require.Empty(t, result.Diagnostics)
+		require.Empty(t, result.Config.AlertmanagerConfig.Receivers[0].GrafanaManagedReceivers[0].SecureSettings)
+
+		current, err := sut.mam.GetAlertmanagerConfiguration(context.Background(), 1, false)
+		require.NoError(t, err)
+		require.Equal(t, "team-a", current.AlertmanagerConfig.Route.Receiver)
+		gr := current.AlertmanagerConfig.Receivers[0].GrafanaManagedReceivers
+		require.Len(t, gr, 1)
+		require.True(t, gr[0].SecureFields["password"])
+		require.Len(t, current.AlertmanagerConfig.InhibitRules, 1)
+		require.Equal(t, `severity="critical"`, current.AlertmanagerConfig.InhibitRules[0].SourceMatchers[0].String())
+
+		t.Run("and keeps the imported inhibition rules when the configuration is posted", func(t *testing.T) {
+			var body apimodels.PostableUserConfig
+			b, err := json.Marshal(result.Config)
+			require.NoError(t, err)
+			require.NoError(t, json.Unmarshal(b, &body))
+			body.AlertmanagerConfig.Receivers[0].GrafanaManagedReceivers[0].UID = gr[0].UID
+			body.AlertmanagerConfig.Receivers[0].GrafanaManagedReceivers[0].SecureSettings = nil
+			r := sut.RoutePostAlertingConfig(createRequestCtxInOrg(1), body)
+			require.Equal(t, http.StatusAccepted, r.Status(), string(r.Body()))
+
+			body.AlertmanagerConfig.InhibitRules[0].Equal = model.LabelNames{"cluster"}
+			r = sut.RoutePostAlertingConfig(createRequestCtxInOrg(1), body)
+			require.NotEqual(t, http.StatusAccepted, r.Status())
+			require.Contains(t, string(r.Body()), "inhibition rules are not supported")
+		})
+	})
+}
+
 func createSut(t *testing.T) AlertmanagerSrv {
 	t.Helper()
 
@@ -574,7 +686,7 @@ func createSut(t *testing.T) AlertmanagerSrv {
 		ac:             ac,
 		log:            log,
 		featureManager: featuremgmt.WithFeatures(),
-		silenceSvc:     notifier.NewSilenceService(accesscontrol.NewSilenceService(ac, ruleStore), ruleStore, log, mam, ruleStore, ruleAuthzService),
+		silenceSvc:     notifier.NewSilenceService(accesscontrol.NewSilenceService(ac, ruleStore), ruleStore, log, notifier.NewAuditedSilenceStore(mam, &ngfakes.FakeSilenceAuditStore{}, mam, log), ruleStore, ruleAuthzService, &ngfakes.FakeSilenceAuditStore{}),
 	}
 }
 
@@ -2,19 +2,30 @@ package api
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"net/http"
+	"strconv"
+	"time"
 
 	"github.com/grafana/grafana/pkg/api/response"
 	"github.com/grafana/grafana/pkg/apimachinery/identity"
 	"github.com/grafana/grafana/pkg/infra/log"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
 )
 
+const (
+	defaultNotificationDeliveriesLimit = 100
+	maxNotificationDeliveriesLimit     = 1000
+)
+
 type NotificationSrv struct {
 	logger            log.Logger
 	receiverService   ReceiverService
 	muteTimingService MuteTimingService // defined in api_provisioning.go
+	deliveryService   NotificationDeliveryService
 }
 
 type ReceiverService interface {
@@ -22,6 +33,12 @@ type ReceiverService interface {
 	ListReceivers(ctx context.Context, q models.ListReceiversQuery, user identity.Requester) ([]*models.Receiver, error)
 }
 
+type NotificationDeliveryService interface {
+	GetNotificationDelivery(ctx context.Context, orgID int64, id int64) (*models.NotificationDelivery, error)
+	ListNotificationDeliveries(ctx context.Context, query models.ListNotificationDeliveriesQuery) ([]*models.NotificationDelivery, error)
+	ReplayNotificationDelivery(ctx context.Context, orgID int64, id int64) (models.NotificationReplayResult, error)
+}
+
 func (srv *NotificationSrv) RouteGetTimeInterval(c *contextmodel.ReqContext, name string) response.Response {
 	muteTimeInterval, err := srv.muteTimingService.GetMuteTiming(c.Req.Context(), name, c.OrgID)
 	if err != nil {
@@ -78,3 +95,66 @@ func (srv *NotificationSrv) RouteGetReceivers(c *contextmodel.ReqContext) respon
 
 	return response.JSON(http.StatusOK, gettables)
 }
+
+func (srv *NotificationSrv) RouteGetNotificationDeliveries(c *contextmodel.ReqContext) response.Response {
+	query := models.ListNotificationDeliveriesQuery{
+		OrgID:          c.SignedInUser.OrgID,
+		Receiver:       c.Query("receiver"),
+		IntegrationUID: c.Query("integrationUID"),
+		Status:         models.NotificationDeliveryStatus(c.Query("status")),
+		Limit:          c.QueryInt("limit"),
+	}
+	switch query.Status {
+	case "", models.NotificationDeliveryStatusSuccess, models.NotificationDeliveryStatusFailed:
+	default:
+		return ErrResp(http.StatusBadRequest, fmt.Errorf("unknown status %s", query.Status), "")
+	}
+	if query.Limit <= 0 {
+		query.Limit = defaultNotificationDeliveriesLimit
+	}
+	if query.Limit > maxNotificationDeliveriesLimit {
+		return ErrResp(http.StatusBadRequest, fmt.Errorf("limit must not be greater than %d", maxNotificationDeliveriesLimit), "")
+	}
+	if from := c.QueryInt64("from"); from > 0 {
+		query.From = time.Unix(from, 0)
+	}
+	if to := c.QueryInt64("to"); to > 0 {
+		query.To = time.Unix(to, 0)
+	}
+	if !query.From.IsZero() && !query.To.IsZero() && query.From.After(query.To) {
+		return ErrResp(http.StatusBadRequest, errors.New("from must not be after to"), "")
+	}
+
+	deliveries, err := srv.deliveryService.ListNotificationDeliveries(c.Req.Context(), query)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get notification deliveries", err)
+	}
+	return response.JSON(http.StatusOK, NotificationDeliveriesToAPI(deliveries))
+}
+
+func (srv *NotificationSrv) RouteGetNotificationDelivery(c *contextmodel.ReqContext, idParam string) response.Response {
+	id, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, fmt.Errorf("invalid notification delivery ID %s", idParam), "")
+	}
+	d, err := srv.deliveryService.GetNotificationDelivery(c.Req.Context(), c.SignedInUser.OrgID, id)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get notification delivery", err)
+	}
+	return response.JSON(http.StatusOK, NotificationDeliveryToAPI(*d))
+}
+
+func (srv *NotificationSrv) RouteReplayNotificationDelivery(c *contextmodel.ReqContext, idParam string) response.Response {
+	id, err := strconv.ParseInt(idParam, 10, 64)
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, fmt.Errorf("invalid notification delivery ID %s", idParam), "")
+	}
+	result, err := srv.deliveryService.ReplayNotificationDelivery(c.Req.Context(), c.SignedInUser.OrgID, id)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to replay notification delivery", err)
+	}
+	return response.JSON(http.StatusOK, definitions.NotificationReplayResult{
+		Status: string(result.Status),
+		Error:  result.Error,
+	})
+}
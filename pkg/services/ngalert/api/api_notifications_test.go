@@ -10,6 +10,7 @@ import (
 	"strconv"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/grafana/grafana/pkg/apimachinery/identity"
 	"github.com/grafana/grafana/pkg/infra/log"
@@ -384,6 +385,100 @@ func createNotificationSrvSutFromEnv(t *testing.T, env *testEnvironment) Notific
 	}
 }
 
+type fakeNotificationDeliveryService struct {
+	deliveries []*models.NotificationDelivery
+	query      models.ListNotificationDeliveriesQuery
+	replayed   []int64
+}
+
+func (f *fakeNotificationDeliveryService) GetNotificationDelivery(_ context.Context, orgID int64, id int64) (*models.NotificationDelivery, error) {
+	for _, d := range f.deliveries {
+		if d.OrgID == orgID && d.ID == id {
+			return d, nil
+		}
+	}
+	return nil, models.ErrNotificationDeliveryNotFound.Errorf("")
+}
+
+func (f *fakeNotificationDeliveryService) ListNotificationDeliveries(_ context.Context, query models.ListNotificationDeliveriesQuery) ([]*models.NotificationDelivery, error) {
+	f.query = query
+	return f.deliveries, nil
+}
+
+func (f *fakeNotificationDeliveryService) ReplayNotificationDelivery(ctx context.Context, orgID int64, id int64) (models.NotificationReplayResult, error) {
+	d, err := f.GetNotificationDelivery(ctx, orgID, id)
+	if err != nil {
+		return models.NotificationReplayResult{}, err
+	}
+	if err := d.Replayable(); err != nil {
+		return models.NotificationReplayResult{}, models.ErrNotificationDeliveryNotReplayable(err)
+	}
+	f.replayed = append(f.replayed, id)
+	return models.NotificationReplayResult{Status: models.NotificationDeliveryStatusFailed, Error: "503 Service Unavailable"}, nil
+}
+
+func TestRouteNotificationDeliveries(t *testing.T) {
+	deliveries := &fakeNotificationDeliveryService{deliveries: []*models.NotificationDelivery{
+		{ID: 1, OrgID: 1, Receiver: "pager", IntegrationUID: "pd", IntegrationType: "pagerduty", Attempt: 1, Status: models.NotificationDeliveryStatusFailed, Payload: `{"alerts":[]}`},
+		{ID: 2, OrgID: 1, Receiver: "pager", IntegrationUID: "pd", IntegrationType: "pagerduty", Attempt: 2, Status: models.NotificationDeliveryStatusSuccess, Payload: `{"alerts":[]}`},
+	}}
+	handler := NewNotificationsApi(&NotificationSrv{logger: log.NewNopLogger(), deliveryService: deliveries})
+
+	t.Run("lists the deliveries with the query of the request", func(t *testing.T) {
+		rc := testReqCtx("GET")
+		rc.Context.Req.Form.Set("receiver", "pager")
+		rc.Context.Req.Form.Set("status", "failed")
+		rc.Context.Req.Form.Set("from", "1000")
+		resp := handler.handleRouteGetNotificationDeliveries(&rc)
+		require.Equal(t, http.StatusOK, resp.Status())
+		require.Equal(t, models.ListNotificationDeliveriesQuery{
+			OrgID:    1,
+			Receiver: "pager",
+			Status:   models.NotificationDeliveryStatusFailed,
+			From:     time.Unix(1000, 0),
+			Limit:    defaultNotificationDeliveriesLimit,
+		}, deliveries.query)
+
+		var result definitions.GettableNotificationDeliveries
+		require.NoError(t, json.Unmarshal(resp.Body(), &result))
+		require.Len(t, result, 2)
+		require.Equal(t, "pd", result[0].IntegrationUID)
+	})
+
+	t.Run("rejects an unknown status", func(t *testing.T) {
+		rc := testReqCtx("GET")
+		rc.Context.Req.Form.Set("status", "pending")
+		resp := handler.handleRouteGetNotificationDeliveries(&rc)
+		require.Equal(t, http.StatusBadRequest, resp.Status())
+	})
+
+	t.Run("returns not found for an unknown delivery", func(t *testing.T) {
+		rc := testReqCtx("GET")
+		resp := handler.handleRouteGetNotificationDelivery(&rc, "3")
+		require.Equal(t, http.StatusNotFound, resp.Status())
+	})
+
+	t.Run("rejects an invalid ID", func(t *testing.T) {
+		rc := testReqCtx("POST")
+		resp := handler.handleRouteReplayNotificationDelivery(&rc, "abc")
+		require.Equal(t, http.StatusBadRequest, resp.Status())
+	})
+
+	t.Run("replays a failed delivery", func(t *testing.T) {
+		rc := testReqCtx("POST")
+		resp := handler.handleRouteReplayNotificationDelivery(&rc, "1")
+		require.Equal(t, http.StatusOK, resp.Status())
+		require.JSONEq(t, `{"status":"failed","error":"503 Service Unavailable"}`, string(resp.Body()))
+		require.Equal(t, []int64{1}, deliveries.replayed)
+	})
+
+	t.Run("rejects the replay of a successful delivery", func(t *testing.T) {
+		rc := testReqCtx("POST")
+		resp := handler.handleRouteReplayNotificationDelivery(&rc, "2")
+		require.Equal(t, http.StatusBadRequest, resp.Status())
+	})
+}
+
 func newNotificationSrv(receiverService ReceiverService) *NotificationSrv {
 	return &NotificationSrv{
 		logger:          log.NewNopLogger(),
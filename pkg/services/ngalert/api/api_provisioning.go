@@ -30,6 +30,7 @@ type ProvisioningSrv struct {
 	templates           TemplateService
 	muteTimings         MuteTimingService
 	alertRules          AlertRuleService
+	ruleTemplates       RuleTemplateService
 	folderSvc           folder.Service
 
 	// XXX: Used to flag recording rules, remove when FT is removed
@@ -64,6 +65,19 @@ type MuteTimingService interface {
 	DeleteMuteTiming(ctx context.Context, name string, orgID int64, provenance definitions.Provenance, version string) error
 }
 
+type RuleTemplateService interface {
+	GetRuleTemplates(ctx context.Context, orgID int64) ([]alerting_models.RuleTemplate, map[string]alerting_models.Provenance, error)
+	GetRuleTemplate(ctx context.Context, orgID int64, uid string) (alerting_models.RuleTemplate, alerting_models.Provenance, error)
+	GetRuleTemplateVersions(ctx context.Context, orgID int64, uid string) ([]alerting_models.RuleTemplate, error)
+	GetRuleTemplateInstances(ctx context.Context, orgID int64, uid string) ([]alerting_models.RuleTemplateInstance, error)
+	CreateRuleTemplate(ctx context.Context, orgID int64, t alerting_models.RuleTemplate, provenance alerting_models.Provenance) (alerting_models.RuleTemplate, error)
+	UpdateRuleTemplate(ctx context.Context, user identity.Requester, t alerting_models.RuleTemplate, provenance alerting_models.Provenance) (alerting_models.RuleTemplate, error)
+	DeleteRuleTemplate(ctx context.Context, orgID int64, uid string, provenance alerting_models.Provenance) error
+	GetRuleGroupTemplate(ctx context.Context, key alerting_models.AlertRuleGroupKey) (alerting_models.RuleTemplateInstance, error)
+	SetRuleGroupTemplate(ctx context.Context, user identity.Requester, instance alerting_models.RuleTemplateInstance, provenance alerting_models.Provenance) (alerting_models.RuleTemplateInstance, error)
+	DeleteRuleGroupTemplate(ctx context.Context, user identity.Requester, key alerting_models.AlertRuleGroupKey, provenance alerting_models.Provenance) error
+}
+
 type AlertRuleService interface {
 	GetAlertRules(ctx context.Context, user identity.Requester) ([]*alerting_models.AlertRule, map[string]alerting_models.Provenance, error)
 	GetAlertRule(ctx context.Context, user identity.Requester, ruleUID string) (alerting_models.AlertRule, alerting_models.Provenance, error)
@@ -529,6 +543,123 @@ func (srv *ProvisioningSrv) RouteDeleteAlertRuleGroup(c *contextmodel.ReqContext
 	return response.JSON(http.StatusNoContent, "")
 }
 
+func (srv *ProvisioningSrv) RouteGetRuleTemplates(c *contextmodel.ReqContext) response.Response {
+	templates, provenances, err := srv.ruleTemplates.GetRuleTemplates(c.Req.Context(), c.SignedInUser.GetOrgID())
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get rule templates", err)
+	}
+	return response.JSON(http.StatusOK, ApiRuleTemplatesFromRuleTemplates(templates, provenances))
+}
+
+func (srv *ProvisioningSrv) RouteGetRuleTemplate(c *contextmodel.ReqContext, UID string) response.Response {
+	t, provenance, err := srv.ruleTemplates.GetRuleTemplate(c.Req.Context(), c.SignedInUser.GetOrgID(), UID)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get rule template", err)
+	}
+	return response.JSON(http.StatusOK, ApiRuleTemplateFromRuleTemplate(t, provenance))
+}
+
+func (srv *ProvisioningSrv) RoutePostRuleTemplate(c *contextmodel.ReqContext, body definitions.RuleTemplate) response.Response {
+	t, err := RuleTemplateFromApiRuleTemplate(body)
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, err, "")
+	}
+	provenance := alerting_models.Provenance(determineProvenance(c))
+	created, err := srv.ruleTemplates.CreateRuleTemplate(c.Req.Context(), c.SignedInUser.GetOrgID(), t, provenance)
+	if err != nil {
+		return ruleTemplateErrorResponse(err)
+	}
+	return response.JSON(http.StatusCreated, ApiRuleTemplateFromRuleTemplate(created, provenance))
+}
+
+func (srv *ProvisioningSrv) RoutePutRuleTemplate(c *contextmodel.ReqContext, body definitions.RuleTemplate, UID string) response.Response {
+	t, err := RuleTemplateFromApiRuleTemplate(body)
+	if err != nil {
+		return ErrResp(http.StatusBadRequest, err, "")
+	}
+	t.UID = UID
+	provenance := alerting_models.Provenance(determineProvenance(c))
+	updated, err := srv.ruleTemplates.UpdateRuleTemplate(c.Req.Context(), c.SignedInUser, t, provenance)
+	if err != nil {
+		return ruleTemplateErrorResponse(err)
+	}
+	return response.JSON(http.StatusOK, ApiRuleTemplateFromRuleTemplate(updated, provenance))
+}
+
+func (srv *ProvisioningSrv) RouteDeleteRuleTemplate(c *contextmodel.ReqContext, UID string) response.Response {
+	provenance := alerting_models.Provenance(determineProvenance(c))
+	if err := srv.ruleTemplates.DeleteRuleTemplate(c.Req.Context(), c.SignedInUser.GetOrgID(), UID, provenance); err != nil {
+		return ruleTemplateErrorResponse(err)
+	}
+	return response.JSON(http.StatusNoContent, "")
+}
+
+func (srv *ProvisioningSrv) RouteGetRuleTemplateVersions(c *contextmodel.ReqContext, UID string) response.Response {
+	versions, err := srv.ruleTemplates.GetRuleTemplateVersions(c.Req.Context(), c.SignedInUser.GetOrgID(), UID)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get versions of rule template", err)
+	}
+	result := make(definitions.RuleTemplates, 0, len(versions))
+	for _, v := range versions {
+		result = append(result, ApiRuleTemplateFromRuleTemplate(v, alerting_models.ProvenanceNone))
+	}
+	return response.JSON(http.StatusOK, result)
+}
+
+func (srv *ProvisioningSrv) RouteGetRuleTemplateInstances(c *contextmodel.ReqContext, UID string) response.Response {
+	instances, err := srv.ruleTemplates.GetRuleTemplateInstances(c.Req.Context(), c.SignedInUser.GetOrgID(), UID)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get rule groups of rule template", err)
+	}
+	result := make(definitions.RuleGroupTemplates, 0, len(instances))
+	for _, i := range instances {
+		result = append(result, ApiRuleGroupTemplateFromRuleTemplateInstance(i))
+	}
+	return response.JSON(http.StatusOK, result)
+}
+
+func (srv *ProvisioningSrv) RouteGetRuleGroupTemplate(c *contextmodel.ReqContext, folderUID string, group string) response.Response {
+	key := alerting_models.AlertRuleGroupKey{OrgID: c.SignedInUser.GetOrgID(), NamespaceUID: folderUID, RuleGroup: group}
+	instance, err := srv.ruleTemplates.GetRuleGroupTemplate(c.Req.Context(), key)
+	if err != nil {
+		return response.ErrOrFallback(http.StatusInternalServerError, "failed to get rule template of rule group", err)
+	}
+	return response.JSON(http.StatusOK, ApiRuleGroupTemplateFromRuleTemplateInstance(instance))
+}
+
+func (srv *ProvisioningSrv) RoutePutRuleGroupTemplate(c *contextmodel.ReqContext, body definitions.RuleGroupTemplate, folderUID string, group string) response.Response {
+	instance := RuleTemplateInstanceFromApiRuleGroupTemplate(body, folderUID, group)
+	provenance := alerting_models.Provenance(determineProvenance(c))
+	result, err := srv.ruleTemplates.SetRuleGroupTemplate(c.Req.Context(), c.SignedInUser, instance, provenance)
+	if err != nil {
+		return ruleTemplateErrorResponse(err)
+	}
+	return response.JSON(http.StatusOK, ApiRuleGroupTemplateFromRuleTemplateInstance(result))
+}
+
+func (srv *ProvisioningSrv) RouteDeleteRuleGroupTemplate(c *contextmodel.ReqContext, folderUID string, group string) response.Response {
+	key := alerting_models.AlertRuleGroupKey{OrgID: c.SignedInUser.GetOrgID(), NamespaceUID: folderUID, RuleGroup: group}
+	provenance := alerting_models.Provenance(determineProvenance(c))
+	if err := srv.ruleTemplates.DeleteRuleGroupTemplate(c.Req.Context(), c.SignedInUser, key, provenance); err != nil {
+		return ruleTemplateErrorResponse(err)
+	}
+	return response.JSON(http.StatusNoContent, "")
+}
+
+// ruleTemplateErrorResponse maps the errors of writing the rule groups of rule templates that are not errutil errors.
+func ruleTemplateErrorResponse(err error) response.Response {
+	if errors.Is(err, alerting_models.ErrAlertRuleFailedValidation) || errors.Is(err, alerting_models.ErrAlertRuleUniqueConstraintViolation) {
+		return ErrResp(http.StatusBadRequest, err, "")
+	}
+	if errors.Is(err, store.ErrOptimisticLock) {
+		return ErrResp(http.StatusConflict, err, "")
+	}
+	if errors.Is(err, alerting_models.ErrQuotaReached) {
+		return ErrResp(http.StatusForbidden, err, "")
+	}
+	return response.ErrOrFallback(http.StatusInternalServerError, "", err)
+}
+
 func determineProvenance(ctx *contextmodel.ReqContext) definitions.Provenance {
 	if _, disabled := ctx.Req.Header[disableProvenanceHeaderName]; disabled {
 		return definitions.Provenance(alerting_models.ProvenanceNone)
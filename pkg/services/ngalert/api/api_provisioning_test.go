@@ -1517,6 +1517,96 @@ func TestProvisioningApi(t *testing.T) {
 	})
 }
 
+func TestProvisioningApiRuleTemplates(t *testing.T) {
+	createTestRuleTemplate := func() definitions.RuleTemplate {
+		rule := createTestAlertRule("latency", 1)
+		rule.Title = "${service} latency"
+		rule.Labels = map[string]string{"service": "${service}"}
+		rule.Data[0].RelativeTimeRange.From = definitions.Duration(10 * time.Minute)
+		return definitions.RuleTemplate{
+			UID:   "service",
+			Title: "Service",
+			Parameters: []definitions.RuleTemplateParameter{
+				{Name: "service", Type: "string"},
+			},
+			Rules: []definitions.ProvisionedAlertRule{rule},
+		}
+	}
+
+	t.Run("POST returns 400 if the template is invalid", func(t *testing.T) {
+		sut := createProvisioningSrvSut(t)
+		rc := createTestRequestCtx()
+		tmpl := createTestRuleTemplate()
+		tmpl.Parameters = nil
+
+		response := sut.RoutePostRuleTemplate(&rc, tmpl)
+
+		require.Equal(t, 400, response.Status())
+		require.Contains(t, string(response.Body()), "unknown parameter ${service}")
+	})
+
+	t.Run("GET returns 404 if the template does not exist", func(t *testing.T) {
+		sut := createProvisioningSrvSut(t)
+		rc := createTestRequestCtx()
+
+		response := sut.RouteGetRuleTemplate(&rc, "does-not-exist")
+
+		require.Equal(t, 404, response.Status())
+	})
+
+	t.Run("rule groups are updated with the template", func(t *testing.T) {
+		sut := createProvisioningSrvSut(t)
+		rc := createTestRequestCtx()
+
+		response := sut.RoutePostRuleTemplate(&rc, createTestRuleTemplate())
+		require.Equal(t, 201, response.Status())
+
+		response = sut.RoutePutRuleGroupTemplate(&rc, definitions.RuleGroupTemplate{
+			TemplateUID: "service",
+			Interval:    60,
+			Values:      definitions.RuleTemplateValues{"service": "checkout"},
+		}, "folder-uid", "checkout")
+		require.Equal(t, 200, response.Status())
+
+		response = sut.RouteGetAlertRuleGroup(&rc, "folder-uid", "checkout")
+		require.Equal(t, 200, response.Status())
+		var group definitions.AlertRuleGroup
+		require.NoError(t, json.Unmarshal(response.Body(), &group))
+		require.Len(t, group.Rules, 1)
+		require.Equal(t, "checkout latency", group.Rules[0].Title)
+		ruleUID := group.Rules[0].UID
+
+		tmpl := createTestRuleTemplate()
+		tmpl.Rules[0].Title = "${service} p99 latency"
+		response = sut.RoutePutRuleTemplate(&rc, tmpl, "service")
+		require.Equal(t, 200, response.Status())
+
+		response = sut.RouteGetAlertRuleGroup(&rc, "folder-uid", "checkout")
+		require.Equal(t, 200, response.Status())
+		require.NoError(t, json.Unmarshal(response.Body(), &group))
+		require.Len(t, group.Rules, 1)
+		require.Equal(t, "checkout p99 latency", group.Rules[0].Title)
+		require.Equal(t, ruleUID, group.Rules[0].UID)
+
+		response = sut.RouteGetRuleTemplateVersions(&rc, "service")
+		require.Equal(t, 200, response.Status())
+		var versions definitions.RuleTemplates
+		require.NoError(t, json.Unmarshal(response.Body(), &versions))
+		require.Len(t, versions, 2)
+
+		response = sut.RouteDeleteRuleTemplate(&rc, "service")
+		require.Equal(t, 409, response.Status())
+
+		response = sut.RouteDeleteRuleGroupTemplate(&rc, "folder-uid", "checkout")
+		require.Equal(t, 204, response.Status())
+		response = sut.RouteGetAlertRuleGroup(&rc, "folder-uid", "checkout")
+		require.Equal(t, 404, response.Status())
+
+		response = sut.RouteDeleteRuleTemplate(&rc, "service")
+		require.Equal(t, 204, response.Status())
+	})
+}
+
 func TestProvisioningApiContactPointExport(t *testing.T) {
 	createTestEnv := func(t *testing.T, testConfig string) testEnvironment {
 		env := createTestEnv(t, testConfig)
@@ -1948,13 +2038,15 @@ func createProvisioningSrvSutFromEnv(t *testing.T, env *testEnvironment) Provisi
 		ngalertfakes.NewFakeReceiverPermissionsService(),
 		tracer,
 	)
+	alertRules := provisioning.NewAlertRuleService(env.store, env.prov, env.folderService, env.quotas, env.xact, 60, 10, 100, env.log, &provisioning.NotificationSettingsValidatorProviderFake{}, env.rulesAuthz)
 	return ProvisioningSrv{
 		log:                 env.log,
 		policies:            newFakeNotificationPolicyService(),
 		contactPointService: provisioning.NewContactPointService(configStore, env.secrets, env.prov, env.xact, receiverSvc, env.log, env.store, ngalertfakes.NewFakeReceiverPermissionsService()),
-		templates:           provisioning.NewTemplateService(configStore, env.prov, env.xact, env.log),
+		templates:           provisioning.NewTemplateService(configStore, env.prov, env.xact, env.log, nil),
 		muteTimings:         provisioning.NewMuteTimingService(configStore, env.prov, env.xact, env.log, env.store),
-		alertRules:          provisioning.NewAlertRuleService(env.store, env.prov, env.folderService, env.quotas, env.xact, 60, 10, 100, env.log, &provisioning.NotificationSettingsValidatorProviderFake{}, env.rulesAuthz),
+		alertRules:          alertRules,
+		ruleTemplates:       provisioning.NewRuleTemplateService(env.store, env.prov, env.xact, alertRules, env.log),
 		folderSvc:           env.folderService,
 		featureManager:      env.features,
 	}
@@ -28,6 +28,7 @@ func (srv *HistorySrv) RouteQueryStateHistory(c *contextmodel.ReqContext) respon
 	from := c.QueryInt64("from")
 	to := c.QueryInt64("to")
 	limit := c.QueryInt("limit")
+	offset := c.QueryInt("offset")
 	ruleUID := c.Query("ruleUID")
 	dashUID := c.Query("dashboardUID")
 	panelID := c.QueryInt64("panelID")
@@ -48,11 +49,12 @@ func (srv *HistorySrv) RouteQueryStateHistory(c *contextmodel.ReqContext) respon
 		From:         time.Unix(from, 0),
 		To:           time.Unix(to, 0),
 		Limit:        limit,
+		Offset:       offset,
 		Labels:       labels,
 	}
 	frame, err := srv.hist.Query(c.Req.Context(), query)
 	if err != nil {
-		return ErrResp(http.StatusInternalServerError, err, "")
+		return errorToResponse(err)
 	}
 	return response.JSON(http.StatusOK, frame)
 }
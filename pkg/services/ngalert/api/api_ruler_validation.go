@@ -74,6 +74,11 @@ func validateRuleNode(
 		RuleGroup:       groupName,
 	}
 
+	newAlertRule.EvaluationPolicy, err = validateEvaluationPolicy(ruleNode.GrafanaManagedAlert.EvaluationPolicy, interval)
+	if err != nil {
+		return nil, err
+	}
+
 	if isRecordingRule {
 		newAlertRule, err = validateRecordingRuleFields(ruleNode, newAlertRule, limits, canPatch)
 	} else {
@@ -156,6 +161,11 @@ func validateAlertingRuleFields(in *apimodels.PostableExtendedRuleNode, newRule
 		return ngmodels.AlertRule{}, err
 	}
 
+	newRule.KeepFiringFor, err = validateKeepFiringFor(in)
+	if err != nil {
+		return ngmodels.AlertRule{}, err
+	}
+
 	return newRule, nil
 }
 
@@ -184,6 +194,7 @@ func validateRecordingRuleFields(in *apimodels.PostableExtendedRuleNode, newRule
 	newRule.ExecErrState = ""
 	newRule.Condition = ""
 	newRule.For = 0
+	newRule.KeepFiringFor = 0
 	newRule.NotificationSettings = nil
 
 	return newRule, nil
@@ -271,6 +282,21 @@ func validateForInterval(ruleNode *apimodels.PostableExtendedRuleNode) (time.Dur
 	return duration, nil
 }
 
+// validateKeepFiringFor validates ApiRuleNode.KeepFiringFor and converts it to time.Duration. If the field is not specified returns 0 if GrafanaManagedAlert.UID is empty and -1 if it is not.
+func validateKeepFiringFor(ruleNode *apimodels.PostableExtendedRuleNode) (time.Duration, error) {
+	if ruleNode.ApiRuleNode == nil || ruleNode.ApiRuleNode.KeepFiringFor == nil {
+		if ruleNode.GrafanaManagedAlert.UID != "" {
+			return -1, nil // will be patched later with the real value of the current version of the rule
+		}
+		return 0, nil
+	}
+	duration := time.Duration(*ruleNode.ApiRuleNode.KeepFiringFor)
+	if duration < 0 {
+		return 0, fmt.Errorf("field `keep_firing_for` cannot be negative [%v]. 0 or any positive duration are allowed", *ruleNode.ApiRuleNode.KeepFiringFor)
+	}
+	return duration, nil
+}
+
 // ValidateRuleGroup validates API model (definitions.PostableRuleGroupConfig) and converts it to a collection of models.AlertRule.
 // Returns a slice that contains all rules described by API model or error if either group specification or an alert definition is not valid.
 // It also returns a map containing current existing alerts that don't contain the is_paused field in the body of the call.
@@ -299,6 +325,11 @@ func ValidateRuleGroup(
 
 	// TODO should we validate that interval is >= cfg.MinInterval? Currently, we allow to save but fix the specified interval if it is < cfg.MinInterval
 
+	groupPolicy, err := validateEvaluationPolicy(ruleGroupConfig.EvaluationPolicy, interval)
+	if err != nil {
+		return nil, fmt.Errorf("invalid rule group evaluation policy: %w", err)
+	}
+
 	result := make([]*ngmodels.AlertRuleWithOptionals, 0, len(ruleGroupConfig.Rules))
 	uids := make(map[string]int, cap(result))
 	for idx := range ruleGroupConfig.Rules {
@@ -307,6 +338,12 @@ func ValidateRuleGroup(
 		if err != nil {
 			return nil, fmt.Errorf("invalid rule specification at index [%d]: %w", idx, err)
 		}
+		// the evaluation policy of the group applies to the rules that do not have their own,
+		// it is stored in these rules and not in the group
+		if rule.EvaluationPolicy == nil && groupPolicy != nil {
+			p := *groupPolicy
+			rule.EvaluationPolicy = &p
+		}
 		if rule.UID != "" {
 			if existingIdx, ok := uids[rule.UID]; ok {
 				return nil, fmt.Errorf("rule [%d] has UID %s that is already assigned to another rule at index %d", idx, rule.UID, existingIdx)
@@ -338,6 +375,21 @@ func ValidateRuleGroup(
 	return result, nil
 }
 
+// validateEvaluationPolicy validates the evaluation policy of a rule or group evaluated at the given interval and converts it to models.EvaluationPolicy.
+func validateEvaluationPolicy(p *apimodels.EvaluationPolicy, interval time.Duration) (*ngmodels.EvaluationPolicy, error) {
+	if p == nil {
+		return nil, nil
+	}
+	policy := ModelEvaluationPolicyFromApi(p)
+	if err := policy.Validate(); err != nil {
+		return nil, fmt.Errorf("%w: invalid evaluation policy: %s", ngmodels.ErrAlertRuleFailedValidation, err.Error())
+	}
+	if err := policy.ValidateInterval(interval); err != nil {
+		return nil, fmt.Errorf("%w: %s", ngmodels.ErrAlertRuleFailedValidation, err.Error())
+	}
+	return policy, nil
+}
+
 func validateNotificationSettings(n *apimodels.AlertRuleNotificationSettings) ([]ngmodels.NotificationSettings, error) {
 	s := ngmodels.NotificationSettings{
 		Receiver:          n.Receiver,
@@ -1122,3 +1122,58 @@ func TestValidateRuleNodeReservedLabels(t *testing.T) {
 		})
 	}
 }
+
+func TestValidateRuleGroupEvaluationPolicy(t *testing.T) {
+	orgId := rand.Int63()
+	folder := randFolder()
+	cfg := config(t)
+	limits := makeLimits(cfg)
+
+	groupPolicy := &apimodels.EvaluationPolicy{
+		Timeout:     model.Duration(cfg.BaseInterval / 4),
+		MaxAttempts: 2,
+	}
+
+	t.Run("should apply the group policy to rules without policy", func(t *testing.T) {
+		withPolicy := validRule()
+		withPolicy.GrafanaManagedAlert.EvaluationPolicy = &apimodels.EvaluationPolicy{MaxAttempts: 5, InitialBackoff: model.Duration(time.Second)}
+		g := validGroup(cfg, validRule(), withPolicy)
+		g.Interval = model.Duration(cfg.BaseInterval)
+		g.EvaluationPolicy = groupPolicy
+
+		alerts, err := ValidateRuleGroup(&g, orgId, folder.UID, limits)
+		require.NoError(t, err)
+		require.Len(t, alerts, 2)
+		require.Equal(t, &models.EvaluationPolicy{Timeout: cfg.BaseInterval / 4, MaxAttempts: 2}, alerts[0].EvaluationPolicy)
+		require.Equal(t, &models.EvaluationPolicy{MaxAttempts: 5, InitialBackoff: time.Second}, alerts[1].EvaluationPolicy)
+	})
+
+	t.Run("should fail if the timeout is longer than the interval", func(t *testing.T) {
+		g := validGroup(cfg, validRule())
+		g.Interval = model.Duration(cfg.BaseInterval)
+		g.EvaluationPolicy = &apimodels.EvaluationPolicy{Timeout: model.Duration(cfg.BaseInterval + time.Second)}
+
+		_, err := ValidateRuleGroup(&g, orgId, folder.UID, limits)
+		require.ErrorIs(t, err, models.ErrAlertRuleFailedValidation)
+	})
+
+	t.Run("should fail if the attempts are longer than the interval", func(t *testing.T) {
+		g := validGroup(cfg, validRule())
+		g.Interval = model.Duration(cfg.BaseInterval)
+		g.EvaluationPolicy = &apimodels.EvaluationPolicy{Timeout: model.Duration(cfg.BaseInterval / 2), MaxAttempts: 2}
+
+		_, err := ValidateRuleGroup(&g, orgId, folder.UID, limits)
+		require.ErrorIs(t, err, models.ErrAlertRuleFailedValidation)
+		require.ErrorContains(t, err, "longer than the evaluation interval")
+	})
+
+	t.Run("should fail if the rule policy is invalid", func(t *testing.T) {
+		r := validRule()
+		r.GrafanaManagedAlert.EvaluationPolicy = &apimodels.EvaluationPolicy{InitialBackoff: model.Duration(time.Minute), MaxBackoff: model.Duration(time.Second)}
+		g := validGroup(cfg, r)
+
+		_, err := ValidateRuleGroup(&g, orgId, folder.UID, limits)
+		require.ErrorIs(t, err, models.ErrAlertRuleFailedValidation)
+		require.ErrorContains(t, err, "invalid evaluation policy")
+	})
+}
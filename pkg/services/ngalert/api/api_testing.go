@@ -18,8 +18,10 @@ import (
 
 	"github.com/grafana/grafana/pkg/api/response"
 	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/expr"
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/infra/tracing"
+	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
 	contextmodel "github.com/grafana/grafana/pkg/services/contexthandler/model"
 	"github.com/grafana/grafana/pkg/services/dashboards"
 	"github.com/grafana/grafana/pkg/services/datasources"
@@ -44,6 +46,7 @@ type TestingApiSrv struct {
 	DatasourceCache datasources.CacheService
 	log             log.Logger
 	authz           RuleAccessControlService
+	ac              ac.AccessControl
 	evaluator       eval.EvaluatorFactory
 	cfg             *setting.UnifiedAlertingSettings
 	backtesting     *backtesting.Engine
@@ -83,13 +86,18 @@ func (srv TestingApiSrv) RouteTestGrafanaRuleConfig(c *contextmodel.ReqContext,
 		}
 	}
 
-	evaluator, err := srv.evaluator.Create(eval.NewContext(c.Req.Context(), c.SignedInUser), rule.GetEvalCondition().WithSource("preview"))
+	ctx := c.Req.Context()
+	if body.Debug {
+		ctx = expr.WithExplanation(ctx)
+	}
+
+	evaluator, err := srv.evaluator.Create(eval.NewContext(ctx, c.SignedInUser), rule.GetEvalCondition().WithSource("preview"))
 	if err != nil {
 		return ErrResp(http.StatusBadRequest, err, "Failed to build evaluator for queries and expressions")
 	}
 
 	now := time.Now()
-	results, err := evaluator.Evaluate(c.Req.Context(), now)
+	results, err := evaluator.Evaluate(ctx, now)
 	if err != nil {
 		return ErrResp(http.StatusInternalServerError, err, "Failed to evaluate queries")
 	}
@@ -120,9 +128,27 @@ func (srv TestingApiSrv) RouteTestGrafanaRuleConfig(c *contextmodel.ReqContext,
 		alerts = append(alerts, state.StateToPostableAlert(alertState, srv.appUrl))
 	}
 
+	if body.Debug {
+		return response.JSON(http.StatusOK, testGrafanaRuleResponseWithExplanation{
+			Alerts:  alerts,
+			Explain: expr.ExplanationFromContext(ctx),
+		})
+	}
 	return response.JSON(http.StatusOK, alerts)
 }
 
+// testGrafanaRuleResponseWithExplanation is the response of RouteTestGrafanaRuleConfig in debug mode.
+type testGrafanaRuleResponseWithExplanation struct {
+	Alerts  []*amv2.PostableAlert `json:"alerts"`
+	Explain *expr.Explanation     `json:"explain"`
+}
+
+// evalQueriesResponseWithExplanation is the response of RouteEvalQueries in debug mode.
+type evalQueriesResponseWithExplanation struct {
+	Results backend.Responses `json:"results"`
+	Explain *expr.Explanation `json:"explain"`
+}
+
 func (srv TestingApiSrv) RouteTestRuleConfig(c *contextmodel.ReqContext, body apimodels.TestRulePayload, datasourceUID string) response.Response {
 	if body.Type() != apimodels.LoTexRulerBackend {
 		return errorToResponse(backendTypeDoesNotMatchPayloadTypeError(apimodels.LoTexRulerBackend, body.Type().String()))
@@ -184,7 +210,12 @@ func (srv TestingApiSrv) RouteEvalQueries(c *contextmodel.ReqContext, cmd apimod
 		}
 	}
 
-	evaluator, err := srv.evaluator.Create(eval.NewContext(c.Req.Context(), c.SignedInUser), cond)
+	ctx := c.Req.Context()
+	if cmd.Debug {
+		ctx = expr.WithExplanation(ctx)
+	}
+
+	evaluator, err := srv.evaluator.Create(eval.NewContext(ctx, c.SignedInUser), cond)
 
 	if err != nil {
 		return ErrResp(http.StatusBadRequest, err, "Failed to build evaluator for queries and expressions")
@@ -195,13 +226,19 @@ func (srv TestingApiSrv) RouteEvalQueries(c *contextmodel.ReqContext, cmd apimod
 		now = timeNow()
 	}
 
-	evalResults, err := evaluator.EvaluateRaw(c.Req.Context(), now)
+	evalResults, err := evaluator.EvaluateRaw(ctx, now)
 
 	if err != nil {
 		return ErrResp(http.StatusInternalServerError, err, "Failed to evaluate queries and expressions")
 	}
 
 	addOptimizedQueryWarnings(evalResults, optimizations)
+	if cmd.Debug {
+		return response.JSONStreaming(http.StatusOK, evalQueriesResponseWithExplanation{
+			Results: evalResults.Responses,
+			Explain: expr.ExplanationFromContext(ctx),
+		})
+	}
 	return response.JSONStreaming(http.StatusOK, evalResults)
 }
 
@@ -234,10 +271,21 @@ func (srv TestingApiSrv) BacktestAlertRule(c *contextmodel.ReqContext, cmd apimo
 	if err != nil {
 		return ErrResp(400, err, "")
 	}
+	execErrState := ngmodels.ErrorErrState
+	if cmd.ExecErrState != "" {
+		execErrState, err = ngmodels.ErrStateFromString(string(cmd.ExecErrState))
+		if err != nil {
+			return ErrResp(400, err, "")
+		}
+	}
 	forInterval := time.Duration(cmd.For)
 	if forInterval < 0 {
 		return ErrResp(400, nil, "Bad For interval")
 	}
+	keepFiringFor := time.Duration(cmd.KeepFiringFor)
+	if keepFiringFor < 0 {
+		return ErrResp(400, nil, "Bad keep_firing_for interval")
+	}
 
 	intervalSeconds, err := validateInterval(time.Duration(cmd.Interval), srv.cfg.BaseInterval)
 	if err != nil {
@@ -249,30 +297,51 @@ func (srv TestingApiSrv) BacktestAlertRule(c *contextmodel.ReqContext, cmd apimo
 		return errorToResponse(err)
 	}
 
+	var folderTitle string
+	if cmd.NamespaceUID != "" {
+		folder, err := srv.folderService.GetNamespaceByUID(c.Req.Context(), cmd.NamespaceUID, c.SignedInUser.GetOrgID(), c.SignedInUser)
+		if err != nil {
+			return toNamespaceErrorResponse(dashboards.ErrFolderAccessDenied)
+		}
+		folderTitle = folder.Fullpath
+	}
+
+	notificationSettings := NotificationSettingsFromAlertRuleNotificationSettings(cmd.NotificationSettings)
+	for _, ns := range notificationSettings {
+		if err := ns.Validate(); err != nil {
+			return ErrResp(400, err, "Invalid notification settings")
+		}
+	}
+
 	rule := &ngmodels.AlertRule{
 		// ID:             0,
 		// Updated:        time.Time{},
 		// Version:        0,
-		// NamespaceUID:   "",
 		// DashboardUID:   nil,
 		// PanelID:        nil,
 		// RuleGroup:      "",
 		// RuleGroupIndex: 0,
-		// ExecErrState:   "",
 		Title: cmd.Title,
 		// prefix backtesting- is to distinguish between executions of regular rule and backtesting in logs (like expression engine, evaluator, state manager etc)
-		UID:             "backtesting-" + util.GenerateShortUID(),
-		OrgID:           c.SignedInUser.GetOrgID(),
-		Condition:       cmd.Condition,
-		Data:            queries,
-		IntervalSeconds: intervalSeconds,
-		NoDataState:     noDataState,
-		For:             forInterval,
-		Annotations:     cmd.Annotations,
-		Labels:          cmd.Labels,
-	}
-
-	result, err := srv.backtesting.Test(c.Req.Context(), c.SignedInUser, rule, cmd.From, cmd.To)
+		UID:                  "backtesting-" + util.GenerateShortUID(),
+		OrgID:                c.SignedInUser.GetOrgID(),
+		NamespaceUID:         cmd.NamespaceUID,
+		Condition:            cmd.Condition,
+		Data:                 queries,
+		IntervalSeconds:      intervalSeconds,
+		NoDataState:          noDataState,
+		ExecErrState:         execErrState,
+		For:                  forInterval,
+		KeepFiringFor:        keepFiringFor,
+		Annotations:          cmd.Annotations,
+		Labels:               cmd.Labels,
+		NotificationSettings: notificationSettings,
+	}
+
+	includeFolder := cmd.NamespaceUID != "" && !srv.cfg.ReservedLabels.IsReservedLabelDisabled(models.FolderTitleLabel)
+	extraLabels := state.GetRuleExtraLabels(srv.log, rule, folderTitle, includeFolder)
+
+	result, err := srv.backtesting.Test(c.Req.Context(), c.SignedInUser, rule, cmd.From, cmd.To, extraLabels)
 	if err != nil {
 		if errors.Is(err, backtesting.ErrInvalidInputData) {
 			return ErrResp(400, err, "Failed to evaluate")
@@ -280,9 +349,46 @@ func (srv TestingApiSrv) BacktestAlertRule(c *contextmodel.ReqContext, cmd apimo
 		return ErrResp(500, err, "Failed to evaluate")
 	}
 
-	body, err := data.FrameToJSON(result, data.IncludeAll)
+	frame := result.Frame
+	if frame.Meta == nil {
+		frame.Meta = &data.FrameMeta{}
+	}
+	// The simulated notifications reveal the contact points, which requires access to the notifications.
+	canReadNotifications, err := srv.ac.Evaluate(c.Req.Context(), c.SignedInUser, ac.EvalPermission(ac.ActionAlertingNotificationsRead))
+	if err != nil {
+		return ErrResp(500, err, "Failed to check access to the notifications")
+	}
+	if !canReadNotifications {
+		result.Notifications = nil
+	}
+	frame.Meta.Custom = backtestResultDetails(result)
+
+	body, err := data.FrameToJSON(frame, data.IncludeAll)
 	if err != nil {
 		return ErrResp(500, err, "Failed to convert frame to JSON")
 	}
 	return response.JSON(http.StatusOK, body)
 }
+
+func backtestResultDetails(result *backtesting.Result) apimodels.BacktestResultDetails {
+	details := apimodels.BacktestResultDetails{
+		Transitions:   make([]apimodels.BacktestTransition, 0, len(result.Transitions)),
+		Notifications: make([]apimodels.BacktestNotificationSummary, 0, len(result.Notifications)),
+	}
+	for _, t := range result.Transitions {
+		details.Transitions = append(details.Transitions, apimodels.BacktestTransition{
+			Time:          t.Time,
+			Labels:        t.Labels,
+			PreviousState: t.PreviousState,
+			State:         t.State,
+		})
+	}
+	for _, n := range result.Notifications {
+		details.Notifications = append(details.Notifications, apimodels.BacktestNotificationSummary{
+			Receiver:      n.Receiver,
+			Notifications: n.Notifications,
+			Resolved:      n.Resolved,
+		})
+	}
+	return details
+}
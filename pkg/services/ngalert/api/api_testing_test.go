@@ -1,6 +1,7 @@
 package api
 
 import (
+	"context"
 	"encoding/json"
 	"net/http"
 	"testing"
@@ -12,6 +13,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/grafana/grafana/pkg/expr"
 	"github.com/grafana/grafana/pkg/infra/tracing"
 	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
 	acMock "github.com/grafana/grafana/pkg/services/accesscontrol/mock"
@@ -314,6 +316,34 @@ func TestRouteEvalQueries(t *testing.T) {
 
 			evaluator.AssertCalled(t, "EvaluateRaw", mock.Anything, currentTime)
 		})
+
+		t.Run("should explain the evaluation in debug mode", func(t *testing.T) {
+			data1 := models.GenerateAlertQuery()
+
+			ac := acMock.New().WithPermissions([]ac.Permission{
+				{Action: datasources.ActionQuery, Scope: datasources.ScopeProvider.GetResourceScopeUID(data1.DatasourceUID)},
+			})
+
+			ds := &fakes.FakeCacheService{DataSources: []*datasources.DataSource{
+				{UID: data1.DatasourceUID},
+			}}
+
+			evaluator := &eval_mocks.ConditionEvaluatorMock{}
+			hasExplanation := mock.MatchedBy(func(ctx context.Context) bool {
+				return expr.ExplanationFromContext(ctx) != nil
+			})
+			evaluator.EXPECT().EvaluateRaw(hasExplanation, mock.Anything).Return(&backend.QueryDataResponse{}, nil)
+
+			srv := createTestingApiSrv(t, ds, ac, eval_mocks.NewEvaluatorFactory(evaluator), featuremgmt.WithFeatures(), fakes2.NewRuleStore(t))
+
+			response := srv.RouteEvalQueries(rc, definitions.EvalQueriesPayload{
+				Data:  ApiAlertQueriesFromAlertQueries([]models.AlertQuery{data1}),
+				Debug: true,
+			})
+
+			require.Equal(t, http.StatusOK, response.Status())
+			evaluator.AssertCalled(t, "EvaluateRaw", hasExplanation, mock.Anything)
+		})
 	})
 
 	t.Run("when query is optimizable", func(t *testing.T) {
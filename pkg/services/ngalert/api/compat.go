@@ -29,11 +29,13 @@ func AlertRuleFromProvisionedAlertRule(a definitions.ProvisionedAlertRule) (mode
 		NoDataState:          models.NoDataState(a.NoDataState),          // TODO there must be a validation
 		ExecErrState:         models.ExecutionErrorState(a.ExecErrState), // TODO there must be a validation
 		For:                  time.Duration(a.For),
+		KeepFiringFor:        time.Duration(a.KeepFiringFor),
 		Annotations:          a.Annotations,
 		Labels:               a.Labels,
 		IsPaused:             a.IsPaused,
 		NotificationSettings: NotificationSettingsFromAlertRuleNotificationSettings(a.NotificationSettings),
 		Record:               ModelRecordFromApiRecord(a.Record),
+		EvaluationPolicy:     ModelEvaluationPolicyFromApi(a.EvaluationPolicy),
 	}
 
 	if rule.Type() == models.RuleTypeRecording {
@@ -53,6 +55,7 @@ func ProvisionedAlertRuleFromAlertRule(rule models.AlertRule, provenance models.
 		RuleGroup:            rule.RuleGroup,
 		Title:                rule.Title,
 		For:                  model.Duration(rule.For),
+		KeepFiringFor:        model.Duration(rule.KeepFiringFor),
 		Condition:            rule.Condition,
 		Data:                 ApiAlertQueriesFromAlertQueries(rule.Data),
 		Updated:              rule.Updated,
@@ -64,6 +67,7 @@ func ProvisionedAlertRuleFromAlertRule(rule models.AlertRule, provenance models.
 		IsPaused:             rule.IsPaused,
 		NotificationSettings: AlertRuleNotificationSettingsFromNotificationSettings(rule.NotificationSettings),
 		Record:               ApiRecordFromModelRecord(rule.Record),
+		EvaluationPolicy:     ApiEvaluationPolicyFromModel(rule.EvaluationPolicy),
 	}
 }
 
@@ -206,6 +210,7 @@ func AlertRuleExportFromAlertRule(rule models.AlertRule) (definitions.AlertRuleE
 		UID:                  rule.UID,
 		Title:                rule.Title,
 		For:                  model.Duration(rule.For),
+		KeepFiringFor:        model.Duration(rule.KeepFiringFor),
 		Condition:            cPtr,
 		Data:                 data,
 		DashboardUID:         rule.DashboardUID,
@@ -215,10 +220,14 @@ func AlertRuleExportFromAlertRule(rule models.AlertRule) (definitions.AlertRuleE
 		IsPaused:             rule.IsPaused,
 		NotificationSettings: AlertRuleNotificationSettingsExportFromNotificationSettings(rule.NotificationSettings),
 		Record:               AlertRuleRecordExportFromRecord(rule.Record),
+		EvaluationPolicy:     AlertRuleEvaluationPolicyExportFromEvaluationPolicy(rule.EvaluationPolicy),
 	}
 	if rule.For.Seconds() > 0 {
 		result.ForString = util.Pointer(model.Duration(rule.For).String())
 	}
+	if rule.KeepFiringFor.Seconds() > 0 {
+		result.KeepFiringForString = util.Pointer(model.Duration(rule.KeepFiringFor).String())
+	}
 	if rule.Annotations != nil {
 		result.Annotations = &rule.Annotations
 	}
@@ -524,6 +533,42 @@ func ApiRecordFromModelRecord(r *models.Record) *definitions.Record {
 	}
 }
 
+func AlertRuleEvaluationPolicyExportFromEvaluationPolicy(p *models.EvaluationPolicy) *definitions.AlertRuleEvaluationPolicyExport {
+	if p == nil {
+		return nil
+	}
+	return &definitions.AlertRuleEvaluationPolicyExport{
+		Timeout:        model.Duration(p.Timeout),
+		MaxAttempts:    p.MaxAttempts,
+		InitialBackoff: model.Duration(p.InitialBackoff),
+		MaxBackoff:     model.Duration(p.MaxBackoff),
+	}
+}
+
+func ModelEvaluationPolicyFromApi(p *definitions.EvaluationPolicy) *models.EvaluationPolicy {
+	if p == nil {
+		return nil
+	}
+	return &models.EvaluationPolicy{
+		Timeout:        time.Duration(p.Timeout),
+		MaxAttempts:    p.MaxAttempts,
+		InitialBackoff: time.Duration(p.InitialBackoff),
+		MaxBackoff:     time.Duration(p.MaxBackoff),
+	}
+}
+
+func ApiEvaluationPolicyFromModel(p *models.EvaluationPolicy) *definitions.EvaluationPolicy {
+	if p == nil {
+		return nil
+	}
+	return &definitions.EvaluationPolicy{
+		Timeout:        model.Duration(p.Timeout),
+		MaxAttempts:    p.MaxAttempts,
+		InitialBackoff: model.Duration(p.InitialBackoff),
+		MaxBackoff:     model.Duration(p.MaxBackoff),
+	}
+}
+
 func GettableGrafanaReceiverFromReceiver(r *models.Integration, provenance models.Provenance) (definitions.GettableGrafanaReceiver, error) {
 	out := definitions.GettableGrafanaReceiver{
 		UID:                   r.UID,
@@ -576,3 +621,167 @@ func GettableApiReceiversFromReceivers(recvs []*models.Receiver) ([]*definitions
 	}
 	return out, nil
 }
+
+// RuleTemplateFromApiRuleTemplate converts definitions.RuleTemplate to models.RuleTemplate
+func RuleTemplateFromApiRuleTemplate(t definitions.RuleTemplate) (models.RuleTemplate, error) {
+	result := models.RuleTemplate{
+		UID:         t.UID,
+		Title:       t.Title,
+		Description: t.Description,
+		Parameters:  make([]models.RuleTemplateParameter, 0, len(t.Parameters)),
+		Rules:       make([]models.AlertRule, 0, len(t.Rules)),
+		Version:     t.Version,
+	}
+	for _, p := range t.Parameters {
+		result.Parameters = append(result.Parameters, models.RuleTemplateParameter{
+			Name:        p.Name,
+			Type:        models.RuleTemplateParameterType(p.Type),
+			Description: p.Description,
+			Default:     p.Default,
+		})
+	}
+	for _, r := range t.Rules {
+		rule, err := AlertRuleFromProvisionedAlertRule(r)
+		if err != nil {
+			return models.RuleTemplate{}, err
+		}
+		result.Rules = append(result.Rules, rule)
+	}
+	return result, nil
+}
+
+// ApiRuleTemplateFromRuleTemplate converts models.RuleTemplate to definitions.RuleTemplate and sets provided provenance status
+func ApiRuleTemplateFromRuleTemplate(t models.RuleTemplate, provenance models.Provenance) definitions.RuleTemplate {
+	result := definitions.RuleTemplate{
+		UID:         t.UID,
+		Title:       t.Title,
+		Description: t.Description,
+		Parameters:  make([]definitions.RuleTemplateParameter, 0, len(t.Parameters)),
+		Rules:       make([]definitions.ProvisionedAlertRule, 0, len(t.Rules)),
+		Version:     t.Version,
+		Updated:     t.Updated,
+		Provenance:  definitions.Provenance(provenance),
+	}
+	for _, p := range t.Parameters {
+		result.Parameters = append(result.Parameters, definitions.RuleTemplateParameter{
+			Name:        p.Name,
+			Type:        string(p.Type),
+			Description: p.Description,
+			Default:     p.Default,
+		})
+	}
+	for _, r := range t.Rules {
+		result.Rules = append(result.Rules, ProvisionedAlertRuleFromAlertRule(r, models.ProvenanceNone))
+	}
+	return result
+}
+
+// ApiRuleTemplatesFromRuleTemplates converts a collection of models.RuleTemplate to definitions.RuleTemplates
+func ApiRuleTemplatesFromRuleTemplates(templates []models.RuleTemplate, provenances map[string]models.Provenance) definitions.RuleTemplates {
+	result := make(definitions.RuleTemplates, 0, len(templates))
+	for _, t := range templates {
+		result = append(result, ApiRuleTemplateFromRuleTemplate(t, provenances[t.ResourceID()]))
+	}
+	return result
+}
+
+// RuleTemplateInstanceFromApiRuleGroupTemplate converts definitions.RuleGroupTemplate to models.RuleTemplateInstance of the given rule group
+func RuleTemplateInstanceFromApiRuleGroupTemplate(t definitions.RuleGroupTemplate, folderUID, group string) models.RuleTemplateInstance {
+	return models.RuleTemplateInstance{
+		NamespaceUID:    folderUID,
+		RuleGroup:       group,
+		TemplateUID:     t.TemplateUID,
+		IntervalSeconds: t.Interval,
+		Values:          t.Values,
+	}
+}
+
+// ApiRuleGroupTemplateFromRuleTemplateInstance converts models.RuleTemplateInstance to definitions.RuleGroupTemplate
+func ApiRuleGroupTemplateFromRuleTemplateInstance(i models.RuleTemplateInstance) definitions.RuleGroupTemplate {
+	return definitions.RuleGroupTemplate{
+		FolderUID:       i.NamespaceUID,
+		RuleGroup:       i.RuleGroup,
+		TemplateUID:     i.TemplateUID,
+		Interval:        i.IntervalSeconds,
+		Values:          i.Values,
+		TemplateVersion: i.TemplateVersion,
+		Updated:         i.Updated,
+	}
+}
+
+// TemplateFixtureFromAPI converts definitions.TemplateFixture to models.TemplateFixture
+func TemplateFixtureFromAPI(f definitions.TemplateFixture) models.TemplateFixture {
+	result := models.TemplateFixture{
+		Name:   f.Name,
+		Alerts: f.Alerts,
+	}
+	for _, e := range f.Expectations {
+		result.Expectations = append(result.Expectations, models.TemplateExpectation(e))
+	}
+	return result
+}
+
+// TemplateFixtureToAPI converts models.TemplateFixture to definitions.TemplateFixture
+func TemplateFixtureToAPI(f models.TemplateFixture) definitions.TemplateFixture {
+	result := definitions.TemplateFixture{
+		Name:   f.Name,
+		Alerts: f.Alerts,
+	}
+	for _, e := range f.Expectations {
+		result.Expectations = append(result.Expectations, definitions.TemplateExpectation(e))
+	}
+	return result
+}
+
+// TemplateFixtureTestResultsToAPI converts the results of testing templates with their fixtures to definitions.TemplateFixtureTestResults
+func TemplateFixtureTestResultsToAPI(results []models.TemplateFixtureResult) definitions.TemplateFixtureTestResults {
+	result := definitions.TemplateFixtureTestResults{
+		Passed:  true,
+		Results: make([]definitions.TemplateFixtureTestResult, 0, len(results)),
+	}
+	for _, r := range results {
+		passed := r.Passed()
+		result.Passed = result.Passed && passed
+		result.Results = append(result.Results, definitions.TemplateFixtureTestResult{
+			Fixture:    r.Fixture,
+			Template:   r.Template,
+			Definition: r.Definition,
+			Passed:     passed,
+			Expected:   r.Expected,
+			Actual:     r.Actual,
+			Diff:       r.Diff,
+			Error:      r.Error,
+		})
+	}
+	return result
+}
+
+// NotificationDeliveryToAPI converts models.NotificationDelivery to definitions.GettableNotificationDelivery
+func NotificationDeliveryToAPI(d models.NotificationDelivery) definitions.GettableNotificationDelivery {
+	return definitions.GettableNotificationDelivery{
+		ID:               d.ID,
+		Receiver:         d.Receiver,
+		IntegrationUID:   d.IntegrationUID,
+		IntegrationType:  d.IntegrationType,
+		GroupKey:         d.GroupKey,
+		Attempt:          d.Attempt,
+		Status:           string(d.Status),
+		Error:            d.Error,
+		LatencyMs:        d.Latency.Milliseconds(),
+		AlertCount:       d.AlertCount,
+		Payload:          d.Payload,
+		PayloadTruncated: d.PayloadTruncated,
+		ReplayOf:         d.ReplayOf,
+		ReplayedAt:       d.ReplayedAt,
+		Created:          d.Created,
+	}
+}
+
+// NotificationDeliveriesToAPI converts a collection of models.NotificationDelivery to definitions.GettableNotificationDeliveries
+func NotificationDeliveriesToAPI(deliveries []*models.NotificationDelivery) definitions.GettableNotificationDeliveries {
+	result := make(definitions.GettableNotificationDeliveries, 0, len(deliveries))
+	for _, d := range deliveries {
+		result = append(result, NotificationDeliveryToAPI(*d))
+	}
+	return result
+}
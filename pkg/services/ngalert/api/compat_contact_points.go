@@ -50,8 +50,8 @@ func ContactPointToContactPointExport(cp definitions.ContactPoint) (notify.APIRe
 		len(cp.Googlechat) + len(cp.Kafka) + len(cp.Line) + len(cp.Opsgenie) +
 		len(cp.Pagerduty) + len(cp.OnCall) + len(cp.Pushover) + len(cp.Sensugo) +
 		len(cp.Sns) + len(cp.Slack) + len(cp.Teams) + len(cp.Telegram) +
-		len(cp.Threema) + len(cp.Victorops) + len(cp.Webhook) + len(cp.Wecom) +
-		len(cp.Webex) + len(cp.Mqtt)
+		len(cp.TemplatedHTTP) + len(cp.Threema) + len(cp.Victorops) + len(cp.Webhook) +
+		len(cp.Wecom) + len(cp.Webex) + len(cp.Mqtt)
 
 	integration := make([]*notify.GrafanaIntegrationConfig, 0, contactPointsLength)
 
@@ -175,6 +175,13 @@ func ContactPointToContactPointExport(cp definitions.ContactPoint) (notify.APIRe
 		}
 		integration = append(integration, el)
 	}
+	for _, i := range cp.TemplatedHTTP {
+		el, err := marshallIntegration(j, "templated-http", i, i.DisableResolveMessage)
+		if err != nil {
+			errs = append(errs, err)
+		}
+		integration = append(integration, el)
+	}
 	for _, i := range cp.Threema {
 		el, err := marshallIntegration(j, "threema", i, i.DisableResolveMessage)
 		if err != nil {
@@ -331,6 +338,11 @@ func parseIntegration(json jsoniter.API, result *definitions.ContactPoint, recei
 		if err = json.Unmarshal(data, &integration); err == nil {
 			result.Telegram = append(result.Telegram, integration)
 		}
+	case "templated-http":
+		integration := definitions.TemplatedHTTPIntegration{DisableResolveMessage: disable}
+		if err = json.Unmarshal(data, &integration); err == nil {
+			result.TemplatedHTTP = append(result.TemplatedHTTP, integration)
+		}
 	case "threema":
 		integration := definitions.ThreemaIntegration{DisableResolveMessage: disable}
 		if err = json.Unmarshal(data, &integration); err == nil {
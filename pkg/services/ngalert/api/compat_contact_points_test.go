@@ -159,6 +159,36 @@ func TestContactPointFromContactPointExports(t *testing.T) {
 		require.Nil(t, result.Webhook[2].MaxAlerts)
 	})
 
+	t.Run("templated-http round trip", func(t *testing.T) {
+		export := definitions.ContactPointExport{
+			Name: "test",
+			Receivers: []definitions.ReceiverExport{
+				{
+					Type: "templated-http",
+					Settings: definitions.RawMessage(`{
+						"url": "http://localhost/{{ .CommonLabels.queue }}",
+						"httpMethod": "PUT",
+						"headers": {"X-Queue": "{{ .CommonLabels.queue }}"},
+						"body": "{{ .Status }}",
+						"hmacSecret": "secret",
+						"successStatusCodes": "200-204"
+					}`),
+				},
+			},
+		}
+		result, err := ContactPointFromContactPointExport(export)
+		require.NoError(t, err)
+		require.Len(t, result.TemplatedHTTP, 1)
+		require.Equal(t, "http://localhost/{{ .CommonLabels.queue }}", result.TemplatedHTTP[0].URL)
+		require.Equal(t, map[string]string{"X-Queue": "{{ .CommonLabels.queue }}"}, *result.TemplatedHTTP[0].Headers)
+
+		back, err := ContactPointToContactPointExport(result)
+		require.NoError(t, err)
+		require.Len(t, back.Integrations, 1)
+		require.Equal(t, "templated-http", back.Integrations[0].Type)
+		require.JSONEq(t, string(export.Receivers[0].Settings), string(back.Integrations[0].Settings))
+	})
+
 	t.Run("oncall with optional numbers as string", func(t *testing.T) {
 		export := definitions.ContactPointExport{
 			Name: "test",
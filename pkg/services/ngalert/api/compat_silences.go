@@ -2,6 +2,9 @@ package api
 
 import (
 	"fmt"
+	"time"
+
+	"github.com/prometheus/common/model"
 
 	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
@@ -67,3 +70,98 @@ func SilencePermissionToAPI(p models.SilencePermission) (definitions.SilencePerm
 		return "", fmt.Errorf("unknown permission: %s", p)
 	}
 }
+
+// RecurringSilenceFromPostableRecurringSilence converts definitions.PostableRecurringSilence to models.RecurringSilence
+func RecurringSilenceFromPostableRecurringSilence(s definitions.PostableRecurringSilence) models.RecurringSilence {
+	return models.RecurringSilence{
+		UID:           s.UID,
+		Version:       s.Version,
+		Matchers:      s.Matchers,
+		Comment:       s.Comment,
+		CreatedBy:     s.CreatedBy,
+		Cron:          s.Cron,
+		Duration:      time.Duration(s.Duration),
+		Timezone:      s.Timezone,
+		TimeIntervals: s.TimeIntervals,
+	}
+}
+
+// GettableRecurringSilenceFromRecurringSilence converts models.RecurringSilence to definitions.GettableRecurringSilence
+func GettableRecurringSilenceFromRecurringSilence(s models.RecurringSilence) definitions.GettableRecurringSilence {
+	result := definitions.GettableRecurringSilence{
+		PostableRecurringSilence: definitions.PostableRecurringSilence{
+			UID:           s.UID,
+			Version:       s.Version,
+			Matchers:      s.Matchers,
+			Comment:       s.Comment,
+			CreatedBy:     s.CreatedBy,
+			Cron:          s.Cron,
+			Duration:      model.Duration(s.Duration),
+			Timezone:      s.Timezone,
+			TimeIntervals: s.TimeIntervals,
+		},
+		Updated: s.Updated,
+	}
+	if !s.LastOccurrence.EndsAt.IsZero() {
+		result.LastOccurrence = &definitions.RecurringSilenceOccurrence{
+			StartsAt:  s.LastOccurrence.StartsAt,
+			EndsAt:    s.LastOccurrence.EndsAt,
+			SilenceID: s.LastOccurrence.SilenceID,
+		}
+	}
+	return result
+}
+
+// GettableRecurringSilencesFromRecurringSilences converts a collection of models.RecurringSilence to definitions.GettableRecurringSilences
+func GettableRecurringSilencesFromRecurringSilences(silences []*models.RecurringSilence) definitions.GettableRecurringSilences {
+	result := make(definitions.GettableRecurringSilences, 0, len(silences))
+	for _, s := range silences {
+		result = append(result, GettableRecurringSilenceFromRecurringSilence(*s))
+	}
+	return result
+}
+
+// GettableSilenceAuditEntriesFromSilenceAuditEntries converts a collection of models.SilenceAuditEntry to definitions.GettableSilenceAuditEntries
+func GettableSilenceAuditEntriesFromSilenceAuditEntries(entries []*models.SilenceAuditEntry) definitions.GettableSilenceAuditEntries {
+	result := make(definitions.GettableSilenceAuditEntries, 0, len(entries))
+	for _, e := range entries {
+		entry := definitions.GettableSilenceAuditEntry{
+			SilenceID:     e.SilenceID,
+			Action:        string(e.Action),
+			Actor:         e.ActorLogin,
+			ActorUID:      e.ActorUID,
+			Created:       e.Created,
+			Silence:       definitions.GettableSilence(e.Silence),
+			MatchedAlerts: e.MatchedAlerts,
+		}
+		for _, c := range e.Changes {
+			entry.Changes = append(entry.Changes, definitions.SilenceAuditChange{Field: c.Field, Old: c.Old, New: c.New})
+		}
+		result = append(result, entry)
+	}
+	return result
+}
+
+// SilenceExpiryNotificationFromPostable converts definitions.PostableSilenceExpiryNotification to models.SilenceExpiryNotification
+func SilenceExpiryNotificationFromPostable(n definitions.PostableSilenceExpiryNotification, silenceID string) models.SilenceExpiryNotification {
+	return models.SilenceExpiryNotification{
+		SilenceID: silenceID,
+		Receiver:  n.Receiver,
+		Before:    time.Duration(n.Before),
+	}
+}
+
+// SilenceExpiryNotificationToAPI converts models.SilenceExpiryNotification to definitions.SilenceExpiryNotification
+func SilenceExpiryNotificationToAPI(n models.SilenceExpiryNotification) definitions.SilenceExpiryNotification {
+	result := definitions.SilenceExpiryNotification{
+		PostableSilenceExpiryNotification: definitions.PostableSilenceExpiryNotification{
+			Receiver: n.Receiver,
+			Before:   model.Duration(n.Before),
+		},
+		SilenceID: n.SilenceID,
+	}
+	if !n.NotifiedEndsAt.IsZero() {
+		result.NotifiedEndsAt = &n.NotifiedEndsAt
+	}
+	return result
+}
@@ -175,6 +175,38 @@ func (f *AlertmanagerApiHandler) handleRouteGetGrafanaSilences(ctx *contextmodel
 	return f.GrafanaSvc.RouteGetSilences(ctx)
 }
 
+func (f *AlertmanagerApiHandler) handleRouteGetGrafanaRecurringSilence(ctx *contextmodel.ReqContext, uid string) response.Response {
+	return f.GrafanaSvc.RouteGetRecurringSilence(ctx, uid)
+}
+
+func (f *AlertmanagerApiHandler) handleRouteGetGrafanaRecurringSilences(ctx *contextmodel.ReqContext) response.Response {
+	return f.GrafanaSvc.RouteGetRecurringSilences(ctx)
+}
+
+func (f *AlertmanagerApiHandler) handleRouteCreateGrafanaRecurringSilence(ctx *contextmodel.ReqContext, body apimodels.PostableRecurringSilence) response.Response {
+	return f.GrafanaSvc.RouteCreateRecurringSilence(ctx, body)
+}
+
+func (f *AlertmanagerApiHandler) handleRouteDeleteGrafanaRecurringSilence(ctx *contextmodel.ReqContext, uid string) response.Response {
+	return f.GrafanaSvc.RouteDeleteRecurringSilence(ctx, uid)
+}
+
+func (f *AlertmanagerApiHandler) handleRouteGetGrafanaSilenceAudit(ctx *contextmodel.ReqContext) response.Response {
+	return f.GrafanaSvc.RouteGetSilenceAudit(ctx)
+}
+
+func (f *AlertmanagerApiHandler) handleRouteGetGrafanaSilenceExpiryNotification(ctx *contextmodel.ReqContext, id string) response.Response {
+	return f.GrafanaSvc.RouteGetSilenceExpiryNotification(ctx, id)
+}
+
+func (f *AlertmanagerApiHandler) handleRouteCreateGrafanaSilenceExpiryNotification(ctx *contextmodel.ReqContext, body apimodels.PostableSilenceExpiryNotification, id string) response.Response {
+	return f.GrafanaSvc.RouteCreateSilenceExpiryNotification(ctx, body, id)
+}
+
+func (f *AlertmanagerApiHandler) handleRouteDeleteGrafanaSilenceExpiryNotification(ctx *contextmodel.ReqContext, id string) response.Response {
+	return f.GrafanaSvc.RouteDeleteSilenceExpiryNotification(ctx, id)
+}
+
 func (f *AlertmanagerApiHandler) handleRoutePostGrafanaAlertingConfig(ctx *contextmodel.ReqContext, conf apimodels.PostableUserConfig) response.Response {
 	if !conf.AlertmanagerConfig.ReceiverType().Can(apimodels.GrafanaReceiverType) {
 		return errorToResponse(backendTypeDoesNotMatchPayloadTypeError(apimodels.GrafanaBackend, conf.AlertmanagerConfig.ReceiverType().String()))
@@ -182,6 +214,10 @@ func (f *AlertmanagerApiHandler) handleRoutePostGrafanaAlertingConfig(ctx *conte
 	return f.GrafanaSvc.RoutePostAlertingConfig(ctx, conf)
 }
 
+func (f *AlertmanagerApiHandler) handleRoutePostGrafanaAlertingConfigImport(ctx *contextmodel.ReqContext, conf apimodels.PostableAlertmanagerImport) response.Response {
+	return f.GrafanaSvc.RoutePostAlertingConfigImport(ctx, conf)
+}
+
 func (f *AlertmanagerApiHandler) handleRouteGetGrafanaReceivers(ctx *contextmodel.ReqContext) response.Response {
 	return f.GrafanaSvc.RouteGetReceivers(ctx)
 }
@@ -193,3 +229,23 @@ func (f *AlertmanagerApiHandler) handleRoutePostTestGrafanaReceivers(ctx *contex
 func (f *AlertmanagerApiHandler) handleRoutePostTestGrafanaTemplates(ctx *contextmodel.ReqContext, conf apimodels.TestTemplatesConfigBodyParams) response.Response {
 	return f.GrafanaSvc.RoutePostTestTemplates(ctx, conf)
 }
+
+func (f *AlertmanagerApiHandler) handleRouteGetGrafanaTemplateFixtures(ctx *contextmodel.ReqContext) response.Response {
+	return f.GrafanaSvc.RouteGetTemplateFixtures(ctx)
+}
+
+func (f *AlertmanagerApiHandler) handleRouteGetGrafanaTemplateFixture(ctx *contextmodel.ReqContext, name string) response.Response {
+	return f.GrafanaSvc.RouteGetTemplateFixture(ctx, name)
+}
+
+func (f *AlertmanagerApiHandler) handleRoutePostGrafanaTemplateFixture(ctx *contextmodel.ReqContext, fixture apimodels.TemplateFixture) response.Response {
+	return f.GrafanaSvc.RoutePostTemplateFixture(ctx, fixture)
+}
+
+func (f *AlertmanagerApiHandler) handleRouteDeleteGrafanaTemplateFixture(ctx *contextmodel.ReqContext, name string) response.Response {
+	return f.GrafanaSvc.RouteDeleteTemplateFixture(ctx, name)
+}
+
+func (f *AlertmanagerApiHandler) handleRoutePostTestGrafanaTemplateFixtures(ctx *contextmodel.ReqContext) response.Response {
+	return f.GrafanaSvc.RoutePostTestTemplateFixtures(ctx)
+}
@@ -19,12 +19,23 @@ import (
 )
 
 type NotificationsApi interface {
+	RouteGetNotificationDeliveries(*contextmodel.ReqContext) response.Response
+	RouteGetNotificationDelivery(*contextmodel.ReqContext) response.Response
 	RouteGetReceiver(*contextmodel.ReqContext) response.Response
 	RouteGetReceivers(*contextmodel.ReqContext) response.Response
 	RouteNotificationsGetTimeInterval(*contextmodel.ReqContext) response.Response
 	RouteNotificationsGetTimeIntervals(*contextmodel.ReqContext) response.Response
+	RouteReplayNotificationDelivery(*contextmodel.ReqContext) response.Response
 }
 
+func (f *NotificationsApiHandler) RouteGetNotificationDeliveries(ctx *contextmodel.ReqContext) response.Response {
+	return f.handleRouteGetNotificationDeliveries(ctx)
+}
+func (f *NotificationsApiHandler) RouteGetNotificationDelivery(ctx *contextmodel.ReqContext) response.Response {
+	// Parse Path Parameters
+	iDParam := web.Params(ctx.Req)[":ID"]
+	return f.handleRouteGetNotificationDelivery(ctx, iDParam)
+}
 func (f *NotificationsApiHandler) RouteGetReceiver(ctx *contextmodel.ReqContext) response.Response {
 	// Parse Path Parameters
 	nameParam := web.Params(ctx.Req)[":name"]
@@ -41,9 +52,38 @@ func (f *NotificationsApiHandler) RouteNotificationsGetTimeInterval(ctx *context
 func (f *NotificationsApiHandler) RouteNotificationsGetTimeIntervals(ctx *contextmodel.ReqContext) response.Response {
 	return f.handleRouteNotificationsGetTimeIntervals(ctx)
 }
+func (f *NotificationsApiHandler) RouteReplayNotificationDelivery(ctx *contextmodel.ReqContext) response.Response {
+	// Parse Path Parameters
+	iDParam := web.Params(ctx.Req)[":ID"]
+	return f.handleRouteReplayNotificationDelivery(ctx, iDParam)
+}
 
 func (api *API) RegisterNotificationsApiEndpoints(srv NotificationsApi, m *metrics.API) {
 	api.RouteRegister.Group("", func(group routing.RouteRegister) {
+		group.Get(
+			toMacaronPath("/api/v1/notifications/deliveries"),
+			requestmeta.SetOwner(requestmeta.TeamAlerting),
+			requestmeta.SetSLOGroup(requestmeta.SLOGroupHighSlow),
+			api.authorize(http.MethodGet, "/api/v1/notifications/deliveries"),
+			metrics.Instrument(
+				http.MethodGet,
+				"/api/v1/notifications/deliveries",
+				api.Hooks.Wrap(srv.RouteGetNotificationDeliveries),
+				m,
+			),
+		)
+		group.Get(
+			toMacaronPath("/api/v1/notifications/deliveries/{ID}"),
+			requestmeta.SetOwner(requestmeta.TeamAlerting),
+			requestmeta.SetSLOGroup(requestmeta.SLOGroupHighSlow),
+			api.authorize(http.MethodGet, "/api/v1/notifications/deliveries/{ID}"),
+			metrics.Instrument(
+				http.MethodGet,
+				"/api/v1/notifications/deliveries/{ID}",
+				api.Hooks.Wrap(srv.RouteGetNotificationDelivery),
+				m,
+			),
+		)
 		group.Get(
 			toMacaronPath("/api/v1/notifications/receivers/{Name}"),
 			requestmeta.SetOwner(requestmeta.TeamAlerting),
@@ -92,5 +132,17 @@ func (api *API) RegisterNotificationsApiEndpoints(srv NotificationsApi, m *metri
 				m,
 			),
 		)
+		group.Post(
+			toMacaronPath("/api/v1/notifications/deliveries/{ID}/replay"),
+			requestmeta.SetOwner(requestmeta.TeamAlerting),
+			requestmeta.SetSLOGroup(requestmeta.SLOGroupHighSlow),
+			api.authorize(http.MethodPost, "/api/v1/notifications/deliveries/{ID}/replay"),
+			metrics.Instrument(
+				http.MethodPost,
+				"/api/v1/notifications/deliveries/{ID}/replay",
+				api.Hooks.Wrap(srv.RouteReplayNotificationDelivery),
+				m,
+			),
+		)
 	}, middleware.ReqSignedIn)
 }
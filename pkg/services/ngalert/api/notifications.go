@@ -30,3 +30,15 @@ func (f *NotificationsApiHandler) handleRouteGetReceiver(ctx *contextmodel.ReqCo
 func (f *NotificationsApiHandler) handleRouteGetReceivers(ctx *contextmodel.ReqContext) response.Response {
 	return f.notificationSrv.RouteGetReceivers(ctx)
 }
+
+func (f *NotificationsApiHandler) handleRouteGetNotificationDeliveries(ctx *contextmodel.ReqContext) response.Response {
+	return f.notificationSrv.RouteGetNotificationDeliveries(ctx)
+}
+
+func (f *NotificationsApiHandler) handleRouteGetNotificationDelivery(ctx *contextmodel.ReqContext, id string) response.Response {
+	return f.notificationSrv.RouteGetNotificationDelivery(ctx, id)
+}
+
+func (f *NotificationsApiHandler) handleRouteReplayNotificationDelivery(ctx *contextmodel.ReqContext, id string) response.Response {
+	return f.notificationSrv.RouteReplayNotificationDelivery(ctx, id)
+}
@@ -139,3 +139,43 @@ func (f *ProvisioningApiHandler) handleRouteExportMuteTimings(ctx *contextmodel.
 func (f *ProvisioningApiHandler) handleRouteDeleteAlertRuleGroup(ctx *contextmodel.ReqContext, folderUID, group string) response.Response {
 	return f.svc.RouteDeleteAlertRuleGroup(ctx, folderUID, group)
 }
+
+func (f *ProvisioningApiHandler) handleRouteGetRuleTemplates(ctx *contextmodel.ReqContext) response.Response {
+	return f.svc.RouteGetRuleTemplates(ctx)
+}
+
+func (f *ProvisioningApiHandler) handleRouteGetRuleTemplate(ctx *contextmodel.ReqContext, UID string) response.Response {
+	return f.svc.RouteGetRuleTemplate(ctx, UID)
+}
+
+func (f *ProvisioningApiHandler) handleRoutePostRuleTemplate(ctx *contextmodel.ReqContext, t apimodels.RuleTemplate) response.Response {
+	return f.svc.RoutePostRuleTemplate(ctx, t)
+}
+
+func (f *ProvisioningApiHandler) handleRoutePutRuleTemplate(ctx *contextmodel.ReqContext, t apimodels.RuleTemplate, UID string) response.Response {
+	return f.svc.RoutePutRuleTemplate(ctx, t, UID)
+}
+
+func (f *ProvisioningApiHandler) handleRouteDeleteRuleTemplate(ctx *contextmodel.ReqContext, UID string) response.Response {
+	return f.svc.RouteDeleteRuleTemplate(ctx, UID)
+}
+
+func (f *ProvisioningApiHandler) handleRouteGetRuleTemplateVersions(ctx *contextmodel.ReqContext, UID string) response.Response {
+	return f.svc.RouteGetRuleTemplateVersions(ctx, UID)
+}
+
+func (f *ProvisioningApiHandler) handleRouteGetRuleTemplateInstances(ctx *contextmodel.ReqContext, UID string) response.Response {
+	return f.svc.RouteGetRuleTemplateInstances(ctx, UID)
+}
+
+func (f *ProvisioningApiHandler) handleRouteGetRuleGroupTemplate(ctx *contextmodel.ReqContext, folderUID, group string) response.Response {
+	return f.svc.RouteGetRuleGroupTemplate(ctx, folderUID, group)
+}
+
+func (f *ProvisioningApiHandler) handleRoutePutRuleGroupTemplate(ctx *contextmodel.ReqContext, t apimodels.RuleGroupTemplate, folderUID, group string) response.Response {
+	return f.svc.RoutePutRuleGroupTemplate(ctx, t, folderUID, group)
+}
+
+func (f *ProvisioningApiHandler) handleRouteDeleteRuleGroupTemplate(ctx *contextmodel.ReqContext, folderUID, group string) response.Response {
+	return f.svc.RouteDeleteRuleGroupTemplate(ctx, folderUID, group)
+}
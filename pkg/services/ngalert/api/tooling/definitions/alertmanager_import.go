@@ -0,0 +1,78 @@
+package definitions
+
+// swagger:route POST /alertmanager/grafana/config/api/v1/import alertmanager RoutePostGrafanaAlertingConfigImport
+//
+// Import the configuration of a Prometheus Alertmanager.
+//
+// The configuration is converted to a Grafana Alertmanager configuration that replaces the current one. It is not
+// applied if any part of it cannot be imported, unless force is set.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       200: AlertmanagerImportResult
+//       202: AlertmanagerImportResult
+//       400: AlertmanagerImportResult
+//       403: PermissionDenied
+
+// swagger:parameters RoutePostGrafanaAlertingConfigImport
+type AlertmanagerImportParams struct {
+	// in:body
+	Body PostableAlertmanagerImport
+	// Convert the configuration without applying it.
+	// in:query
+	// required:false
+	DryRun bool `json:"dryRun"`
+	// Apply the configuration even if parts of it cannot be imported.
+	// in:query
+	// required:false
+	Force bool `json:"force"`
+}
+
+// swagger:model
+type PostableAlertmanagerImport struct {
+	// The configuration file of the Prometheus Alertmanager.
+	// required: true
+	AlertmanagerConfig string `json:"alertmanager_config"`
+	// The template files that the configuration refers to, by file name.
+	TemplateFiles map[string]string `json:"template_files,omitempty"`
+}
+
+// swagger:model
+type AlertmanagerImportResult struct {
+	// The converted configuration. Secure settings of contact points are not returned.
+	Config *PostableUserConfig `json:"config,omitempty"`
+	// Diagnostics lists the parts of the configuration that could not be imported or were changed.
+	Diagnostics AlertmanagerImportDiagnostics `json:"diagnostics"`
+	// Applied is true if the configuration was applied.
+	Applied bool `json:"applied"`
+}
+
+const (
+	AlertmanagerImportError   = "error"
+	AlertmanagerImportWarning = "warning"
+)
+
+// AlertmanagerImportDiagnostic describes a part of an imported configuration that could not be imported or was changed.
+type AlertmanagerImportDiagnostic struct {
+	// Severity is error if the part could not be imported and warning if it was imported with changes.
+	// enum: error,warning
+	Severity string `json:"severity"`
+	// Path of the part in the imported configuration.
+	// example: receivers[team-a].slack_configs[0].actions
+	Path    string `json:"path"`
+	Message string `json:"message"`
+}
+
+type AlertmanagerImportDiagnostics []AlertmanagerImportDiagnostic
+
+// HasErrors returns true if any part of the configuration could not be imported.
+func (d AlertmanagerImportDiagnostics) HasErrors() bool {
+	for _, diag := range d {
+		if diag.Severity == AlertmanagerImportError {
+			return true
+		}
+	}
+	return false
+}
@@ -0,0 +1,111 @@
+package definitions
+
+import (
+	"time"
+
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/prometheus/common/model"
+)
+
+// swagger:route GET /alertmanager/grafana/api/v2/recurring-silences alertmanager RouteGetGrafanaRecurringSilences
+//
+// Get the recurring silences.
+//
+//     Responses:
+//       200: GettableRecurringSilences
+//       403: PermissionDenied
+
+// swagger:route POST /alertmanager/grafana/api/v2/recurring-silences alertmanager RouteCreateGrafanaRecurringSilence
+//
+// Create or update a recurring silence.
+//
+// A silence is created for every occurrence of the schedule. If the UID is set, the recurring silence with this UID
+// is updated and the silence of its current occurrence expires. An update must contain the current version of the
+// recurring silence.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       202: GettableRecurringSilence
+//       400: ValidationError
+//       403: PermissionDenied
+//       404: NotFound
+//       409: PublicError
+
+// swagger:route GET /alertmanager/grafana/api/v2/recurring-silence/{RecurringSilenceUID} alertmanager RouteGetGrafanaRecurringSilence
+//
+// Get a recurring silence.
+//
+//     Responses:
+//       200: GettableRecurringSilence
+//       403: PermissionDenied
+//       404: NotFound
+
+// swagger:route DELETE /alertmanager/grafana/api/v2/recurring-silence/{RecurringSilenceUID} alertmanager RouteDeleteGrafanaRecurringSilence
+//
+// Delete a recurring silence.
+//
+// The silence of its current occurrence expires. The silences of past occurrences are kept.
+//
+//     Responses:
+//       200: Ack
+//       403: PermissionDenied
+//       404: NotFound
+
+// swagger:parameters RouteCreateGrafanaRecurringSilence
+type CreateRecurringSilenceParams struct {
+	// in:body
+	Body PostableRecurringSilence
+}
+
+// swagger:parameters RouteGetGrafanaRecurringSilence RouteDeleteGrafanaRecurringSilence
+type GetDeleteRecurringSilenceParams struct {
+	// in:path
+	RecurringSilenceUID string
+}
+
+// swagger:model
+type PostableRecurringSilence struct {
+	// UID of the recurring silence to update. It is empty to create a recurring silence.
+	UID string `json:"uid,omitempty"`
+	// Version of the recurring silence to update. It must be the current version, otherwise the update fails with a
+	// conflict.
+	Version int64 `json:"version,omitempty"`
+	// required: true
+	Matchers amv2.Matchers `json:"matchers"`
+	// required: true
+	Comment string `json:"comment"`
+	// required: true
+	CreatedBy string `json:"createdBy"`
+	// Start of every occurrence in the standard cron format. Either cron or timeIntervals is required.
+	// example: 0 2 * * 0
+	Cron string `json:"cron,omitempty"`
+	// Duration of every occurrence of the cron schedule.
+	// example: 2h
+	Duration model.Duration `json:"duration,omitempty"`
+	// Timezone of the cron schedule. The default is UTC.
+	// example: Europe/Berlin
+	Timezone string `json:"timezone,omitempty"`
+	// Occurrences as time intervals, in the format of mute timings. Each occurrence lasts as long as the time is in one
+	// of the intervals.
+	TimeIntervals []timeinterval.TimeInterval `json:"timeIntervals,omitempty"`
+}
+
+// swagger:model
+type GettableRecurringSilence struct {
+	PostableRecurringSilence
+	Updated time.Time `json:"updated"`
+	// The last occurrence for which a silence was created.
+	LastOccurrence *RecurringSilenceOccurrence `json:"lastOccurrence,omitempty"`
+}
+
+type RecurringSilenceOccurrence struct {
+	StartsAt  time.Time `json:"startsAt"`
+	EndsAt    time.Time `json:"endsAt"`
+	SilenceID string    `json:"silenceID,omitempty"`
+}
+
+// swagger:model
+type GettableRecurringSilences []GettableRecurringSilence
@@ -0,0 +1,132 @@
+package definitions
+
+import (
+	"time"
+
+	"github.com/prometheus/common/model"
+)
+
+// swagger:route GET /alertmanager/grafana/api/v2/silences/audit alertmanager RouteGetGrafanaSilenceAudit
+//
+// Get the audit log of silence changes, the most recent first.
+//
+//     Responses:
+//       200: GettableSilenceAuditEntries
+//       400: ValidationError
+//       403: PermissionDenied
+
+// swagger:route GET /alertmanager/grafana/api/v2/silence/{SilenceId}/expiry-notification alertmanager RouteGetGrafanaSilenceExpiryNotification
+//
+// Get the notification that is sent before the silence expires.
+//
+//     Responses:
+//       200: SilenceExpiryNotification
+//       403: PermissionDenied
+//       404: NotFound
+
+// swagger:route POST /alertmanager/grafana/api/v2/silence/{SilenceId}/expiry-notification alertmanager RouteCreateGrafanaSilenceExpiryNotification
+//
+// Create or replace the notification that is sent to a contact point before the silence expires.
+//
+// If the silence is extended, the notification is sent again before its new end.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       202: SilenceExpiryNotification
+//       400: ValidationError
+//       403: PermissionDenied
+//       404: NotFound
+
+// swagger:route DELETE /alertmanager/grafana/api/v2/silence/{SilenceId}/expiry-notification alertmanager RouteDeleteGrafanaSilenceExpiryNotification
+//
+// Delete the notification that is sent before the silence expires.
+//
+//     Responses:
+//       200: Ack
+//       403: PermissionDenied
+//       404: NotFound
+
+// swagger:parameters RouteGetGrafanaSilenceAudit
+type GetSilenceAuditParams struct {
+	// Filter by silence ID.
+	// in:query
+	// required: false
+	SilenceID string `json:"silenceID"`
+	// Filter by the login of the user who changed the silence.
+	// in:query
+	// required: false
+	Actor string `json:"actor"`
+	// The Unix timestamp of the start of the time range.
+	// in:query
+	// required: false
+	From int64 `json:"from"`
+	// The Unix timestamp of the end of the time range.
+	// in:query
+	// required: false
+	To int64 `json:"to"`
+	// Limits the number of entries. The default is 100.
+	// in:query
+	// required: false
+	Limit int `json:"limit"`
+}
+
+// swagger:parameters RouteGetGrafanaSilenceExpiryNotification RouteDeleteGrafanaSilenceExpiryNotification
+type GetDeleteSilenceExpiryNotificationParams struct {
+	// in:path
+	SilenceId string
+}
+
+// swagger:parameters RouteCreateGrafanaSilenceExpiryNotification
+type CreateSilenceExpiryNotificationParams struct {
+	// in:path
+	SilenceId string
+	// in:body
+	Body PostableSilenceExpiryNotification
+}
+
+// swagger:model
+type GettableSilenceAuditEntry struct {
+	SilenceID string `json:"silenceID"`
+	// enum: create,update,expire
+	Action string `json:"action"`
+	// The login of the user who changed the silence.
+	Actor    string    `json:"actor"`
+	ActorUID string    `json:"actorUID,omitempty"`
+	Created  time.Time `json:"created"`
+	// The silence after the change. For an expired silence, it is the silence before it expired.
+	Silence GettableSilence `json:"silence"`
+	// The changed fields of an updated silence.
+	Changes []SilenceAuditChange `json:"changes,omitempty"`
+	// The labels of the alerts that the silence matched when it was created.
+	MatchedAlerts []map[string]string `json:"matchedAlerts,omitempty"`
+}
+
+type SilenceAuditChange struct {
+	Field string `json:"field"`
+	Old   string `json:"old"`
+	New   string `json:"new"`
+}
+
+// swagger:model
+type GettableSilenceAuditEntries []GettableSilenceAuditEntry
+
+// swagger:model
+type PostableSilenceExpiryNotification struct {
+	// The name of the contact point that receives the notification.
+	// required: true
+	Receiver string `json:"receiver"`
+	// How long before the silence expires the notification is sent.
+	// required: true
+	// example: 15m
+	Before model.Duration `json:"before"`
+}
+
+// swagger:model
+type SilenceExpiryNotification struct {
+	PostableSilenceExpiryNotification
+	SilenceID string `json:"silenceID"`
+	// The end of the silence when the notification was last sent.
+	NotifiedEndsAt *time.Time `json:"notifiedEndsAt,omitempty"`
+}
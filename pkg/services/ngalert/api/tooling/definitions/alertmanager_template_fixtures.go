@@ -0,0 +1,106 @@
+package definitions
+
+import (
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+)
+
+// swagger:route GET /alertmanager/grafana/config/api/v1/templates/fixtures alertmanager RouteGetGrafanaTemplateFixtures
+//
+// Get the fixtures that notification templates are tested with.
+//
+//     Responses:
+//       200: TemplateFixtures
+//       403: PermissionDenied
+
+// swagger:route GET /alertmanager/grafana/config/api/v1/templates/fixtures/{Name} alertmanager RouteGetGrafanaTemplateFixture
+//
+// Get a template fixture.
+//
+//     Responses:
+//       200: TemplateFixture
+//       403: PermissionDenied
+//       404: NotFound
+
+// swagger:route POST /alertmanager/grafana/config/api/v1/templates/fixtures alertmanager RoutePostGrafanaTemplateFixture
+//
+// Create a template fixture, or replace the fixture with the same name.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       202: TemplateFixture
+//       400: ValidationError
+//       403: PermissionDenied
+
+// swagger:route DELETE /alertmanager/grafana/config/api/v1/templates/fixtures/{Name} alertmanager RouteDeleteGrafanaTemplateFixture
+//
+// Delete a template fixture.
+//
+//     Responses:
+//       200: Ack
+//       403: PermissionDenied
+
+// swagger:route POST /alertmanager/grafana/config/api/v1/templates/fixtures/test alertmanager RoutePostTestGrafanaTemplateFixtures
+//
+// Render the notification templates with the alerts of every fixture and compare the outputs with the expected ones.
+//
+//     Responses:
+//       200: TemplateFixtureTestResults
+//       403: PermissionDenied
+
+// swagger:parameters RouteGetGrafanaTemplateFixture RouteDeleteGrafanaTemplateFixture
+type TemplateFixtureNameParams struct {
+	// in:path
+	Name string
+}
+
+// swagger:parameters RoutePostGrafanaTemplateFixture
+type TemplateFixtureParams struct {
+	// in:body
+	Body TemplateFixture
+}
+
+// swagger:model
+type TemplateFixture struct {
+	// required: true
+	Name string `json:"name"`
+	// The alerts that the templates are rendered with.
+	// required: true
+	Alerts []*amv2.PostableAlert `json:"alerts"`
+	// The outputs that the templates are expected to render with the alerts.
+	Expectations []TemplateExpectation `json:"expectations,omitempty"`
+}
+
+type TemplateExpectation struct {
+	// The name of the notification template.
+	// required: true
+	Template string `json:"template"`
+	// The name of the template defined in the notification template with {{ define }}.
+	// required: true
+	Definition string `json:"definition"`
+	Output     string `json:"output"`
+}
+
+// swagger:model
+type TemplateFixtures []TemplateFixture
+
+// swagger:model
+type TemplateFixtureTestResults struct {
+	// True if all templates render the expected outputs.
+	Passed  bool                        `json:"passed"`
+	Results []TemplateFixtureTestResult `json:"results"`
+}
+
+type TemplateFixtureTestResult struct {
+	Fixture    string `json:"fixture"`
+	Template   string `json:"template"`
+	Definition string `json:"definition"`
+	Passed     bool   `json:"passed"`
+	Expected   string `json:"expected"`
+	Actual     string `json:"actual"`
+	// The line diff between the expected and the actual output.
+	Diff string `json:"diff,omitempty"`
+	// The error that prevented the template from being rendered.
+	Error string `json:"error,omitempty"`
+}
@@ -253,6 +253,27 @@ type TeamsIntegration struct {
 	SectionTitle *string `json:"sectiontitle,omitempty" yaml:"sectiontitle,omitempty" hcl:"section_title"`
 }
 
+type TemplatedHTTPIntegration struct {
+	DisableResolveMessage *bool `json:"-" yaml:"-" hcl:"disable_resolve_message"`
+
+	URL string `json:"url" yaml:"url" hcl:"url"`
+
+	HTTPMethod               *string            `json:"httpMethod,omitempty" yaml:"httpMethod,omitempty" hcl:"http_method"`
+	Headers                  *map[string]string `json:"headers,omitempty" yaml:"headers,omitempty" hcl:"headers"`
+	SecureHeaders            *Secret            `json:"secureHeaders,omitempty" yaml:"secureHeaders,omitempty" hcl:"secure_headers"`
+	Body                     *string            `json:"body,omitempty" yaml:"body,omitempty" hcl:"body"`
+	ContentType              *string            `json:"contentType,omitempty" yaml:"contentType,omitempty" hcl:"content_type"`
+	User                     *string            `json:"username,omitempty" yaml:"username,omitempty" hcl:"basic_auth_user"`
+	Password                 *Secret            `json:"password,omitempty" yaml:"password,omitempty" hcl:"basic_auth_password"`
+	AuthorizationScheme      *string            `json:"authorization_scheme,omitempty" yaml:"authorization_scheme,omitempty" hcl:"authorization_scheme"`
+	AuthorizationCredentials *Secret            `json:"authorization_credentials,omitempty" yaml:"authorization_credentials,omitempty" hcl:"authorization_credentials"`
+	HMACSecret               *Secret            `json:"hmacSecret,omitempty" yaml:"hmacSecret,omitempty" hcl:"hmac_secret"`
+	HMACHeader               *string            `json:"hmacHeader,omitempty" yaml:"hmacHeader,omitempty" hcl:"hmac_header"`
+	HMACTimestampHeader      *string            `json:"hmacTimestampHeader,omitempty" yaml:"hmacTimestampHeader,omitempty" hcl:"hmac_timestamp_header"`
+	SuccessStatusCodes       *string            `json:"successStatusCodes,omitempty" yaml:"successStatusCodes,omitempty" hcl:"success_status_codes"`
+	TLSConfig                *TLSConfig         `json:"tlsConfig,omitempty" yaml:"tlsConfig,omitempty" hcl:"tlsConfig,block"`
+}
+
 type ThreemaIntegration struct {
 	DisableResolveMessage *bool `json:"-" yaml:"-" hcl:"disable_resolve_message"`
 
@@ -314,27 +335,28 @@ type WecomIntegration struct {
 }
 
 type ContactPoint struct {
-	Name         string                    `json:"name" yaml:"name" hcl:"name"`
-	Alertmanager []AlertmanagerIntegration `json:"alertmanager" yaml:"alertmanager" hcl:"alertmanager,block"`
-	Dingding     []DingdingIntegration     `json:"dingding" yaml:"dingding" hcl:"dingding,block"`
-	Discord      []DiscordIntegration      `json:"discord" yaml:"discord" hcl:"discord,block"`
-	Email        []EmailIntegration        `json:"email" yaml:"email" hcl:"email,block"`
-	Googlechat   []GooglechatIntegration   `json:"googlechat" yaml:"googlechat" hcl:"googlechat,block"`
-	Kafka        []KafkaIntegration        `json:"kafka" yaml:"kafka" hcl:"kafka,block"`
-	Line         []LineIntegration         `json:"line" yaml:"line" hcl:"line,block"`
-	Mqtt         []MqttIntegration         `json:"mqtt" yaml:"mqtt" hcl:"mqtt,block"`
-	Opsgenie     []OpsgenieIntegration     `json:"opsgenie" yaml:"opsgenie" hcl:"opsgenie,block"`
-	Pagerduty    []PagerdutyIntegration    `json:"pagerduty" yaml:"pagerduty" hcl:"pagerduty,block"`
-	OnCall       []OnCallIntegration       `json:"oncall" yaml:"oncall" hcl:"oncall,block"`
-	Pushover     []PushoverIntegration     `json:"pushover" yaml:"pushover" hcl:"pushover,block"`
-	Sensugo      []SensugoIntegration      `json:"sensugo" yaml:"sensugo" hcl:"sensugo,block"`
-	Slack        []SlackIntegration        `json:"slack" yaml:"slack" hcl:"slack,block"`
-	Sns          []SnsIntegration          `json:"sns" yaml:"sns" hcl:"sns,block"`
-	Teams        []TeamsIntegration        `json:"teams" yaml:"teams" hcl:"teams,block"`
-	Telegram     []TelegramIntegration     `json:"telegram" yaml:"telegram" hcl:"telegram,block"`
-	Threema      []ThreemaIntegration      `json:"threema" yaml:"threema" hcl:"threema,block"`
-	Victorops    []VictoropsIntegration    `json:"victorops" yaml:"victorops" hcl:"victorops,block"`
-	Webhook      []WebhookIntegration      `json:"webhook" yaml:"webhook" hcl:"webhook,block"`
-	Wecom        []WecomIntegration        `json:"wecom" yaml:"wecom" hcl:"wecom,block"`
-	Webex        []WebexIntegration        `json:"webex" yaml:"webex" hcl:"webex,block"`
+	Name          string                     `json:"name" yaml:"name" hcl:"name"`
+	Alertmanager  []AlertmanagerIntegration  `json:"alertmanager" yaml:"alertmanager" hcl:"alertmanager,block"`
+	Dingding      []DingdingIntegration      `json:"dingding" yaml:"dingding" hcl:"dingding,block"`
+	Discord       []DiscordIntegration       `json:"discord" yaml:"discord" hcl:"discord,block"`
+	Email         []EmailIntegration         `json:"email" yaml:"email" hcl:"email,block"`
+	Googlechat    []GooglechatIntegration    `json:"googlechat" yaml:"googlechat" hcl:"googlechat,block"`
+	Kafka         []KafkaIntegration         `json:"kafka" yaml:"kafka" hcl:"kafka,block"`
+	Line          []LineIntegration          `json:"line" yaml:"line" hcl:"line,block"`
+	Mqtt          []MqttIntegration          `json:"mqtt" yaml:"mqtt" hcl:"mqtt,block"`
+	Opsgenie      []OpsgenieIntegration      `json:"opsgenie" yaml:"opsgenie" hcl:"opsgenie,block"`
+	Pagerduty     []PagerdutyIntegration     `json:"pagerduty" yaml:"pagerduty" hcl:"pagerduty,block"`
+	OnCall        []OnCallIntegration        `json:"oncall" yaml:"oncall" hcl:"oncall,block"`
+	Pushover      []PushoverIntegration      `json:"pushover" yaml:"pushover" hcl:"pushover,block"`
+	Sensugo       []SensugoIntegration       `json:"sensugo" yaml:"sensugo" hcl:"sensugo,block"`
+	Slack         []SlackIntegration         `json:"slack" yaml:"slack" hcl:"slack,block"`
+	Sns           []SnsIntegration           `json:"sns" yaml:"sns" hcl:"sns,block"`
+	Teams         []TeamsIntegration         `json:"teams" yaml:"teams" hcl:"teams,block"`
+	Telegram      []TelegramIntegration      `json:"telegram" yaml:"telegram" hcl:"telegram,block"`
+	TemplatedHTTP []TemplatedHTTPIntegration `json:"templated_http" yaml:"templated_http" hcl:"templated_http,block"`
+	Threema       []ThreemaIntegration       `json:"threema" yaml:"threema" hcl:"threema,block"`
+	Victorops     []VictoropsIntegration     `json:"victorops" yaml:"victorops" hcl:"victorops,block"`
+	Webhook       []WebhookIntegration       `json:"webhook" yaml:"webhook" hcl:"webhook,block"`
+	Wecom         []WecomIntegration         `json:"wecom" yaml:"wecom" hcl:"wecom,block"`
+	Webex         []WebexIntegration         `json:"webex" yaml:"webex" hcl:"webex,block"`
 }
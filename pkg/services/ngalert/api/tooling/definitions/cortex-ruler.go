@@ -237,6 +237,9 @@ type PostableRuleGroupConfig struct {
 	Name     string                     `yaml:"name" json:"name"`
 	Interval model.Duration             `yaml:"interval,omitempty" json:"interval,omitempty"`
 	Rules    []PostableExtendedRuleNode `yaml:"rules" json:"rules"`
+	// EvaluationPolicy applies to the Grafana-managed rules of the group that do not have their own evaluation policy.
+	// It is stored in each of these rules, so the group that is returned by the API does not have it, and its rules do.
+	EvaluationPolicy *EvaluationPolicy `yaml:"evaluation_policy,omitempty" json:"evaluation_policy,omitempty"`
 
 	// fields below are used by Mimir/Loki rulers
 
@@ -519,6 +522,25 @@ type Record struct {
 	From string `json:"from" yaml:"from"`
 }
 
+// EvaluationPolicy overrides how the scheduler evaluates a rule. Unset fields use the settings of the scheduler.
+// swagger:model
+type EvaluationPolicy struct {
+	// Maximum duration of an evaluation attempt. Defaults to the evaluation_timeout setting.
+	// It cannot be longer than the evaluation interval of the rule.
+	// example: 10s
+	Timeout model.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	// Maximum number of attempts to evaluate the rule at every evaluation, up to 10. Defaults to the max_attempts setting.
+	// All attempts, max_attempts times the timeout and the delays between them, cannot take longer than the evaluation interval.
+	// example: 3
+	MaxAttempts int64 `json:"max_attempts,omitempty" yaml:"max_attempts,omitempty"`
+	// Delay before the first retry, doubled for every further retry. If it is not set, every retry is delayed by one second.
+	// example: 2s
+	InitialBackoff model.Duration `json:"initial_backoff,omitempty" yaml:"initial_backoff,omitempty"`
+	// Maximum delay between two attempts.
+	// example: 10s
+	MaxBackoff model.Duration `json:"max_backoff,omitempty" yaml:"max_backoff,omitempty"`
+}
+
 // swagger:model
 type PostableGrafanaRule struct {
 	Title                string                         `json:"title" yaml:"title"`
@@ -530,6 +552,7 @@ type PostableGrafanaRule struct {
 	IsPaused             *bool                          `json:"is_paused" yaml:"is_paused"`
 	NotificationSettings *AlertRuleNotificationSettings `json:"notification_settings" yaml:"notification_settings"`
 	Record               *Record                        `json:"record" yaml:"record"`
+	EvaluationPolicy     *EvaluationPolicy              `json:"evaluation_policy,omitempty" yaml:"evaluation_policy,omitempty"`
 	Metadata             *AlertRuleMetadata             `json:"metadata,omitempty" yaml:"metadata,omitempty"`
 }
 
@@ -552,6 +575,7 @@ type GettableGrafanaRule struct {
 	IsPaused             bool                           `json:"is_paused" yaml:"is_paused"`
 	NotificationSettings *AlertRuleNotificationSettings `json:"notification_settings,omitempty" yaml:"notification_settings,omitempty"`
 	Record               *Record                        `json:"record,omitempty" yaml:"record,omitempty"`
+	EvaluationPolicy     *EvaluationPolicy              `json:"evaluation_policy,omitempty" yaml:"evaluation_policy,omitempty"`
 	Metadata             *AlertRuleMetadata             `json:"metadata,omitempty" yaml:"metadata,omitempty"`
 }
 
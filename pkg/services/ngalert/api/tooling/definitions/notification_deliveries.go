@@ -0,0 +1,112 @@
+package definitions
+
+import (
+	"time"
+)
+
+// swagger:route GET /v1/notifications/deliveries notifications RouteGetNotificationDeliveries
+//
+// Get the attempts to send notifications, including retries, the most recent first.
+//
+//     Responses:
+//       200: GettableNotificationDeliveries
+//       400: ValidationError
+//       403: PermissionDenied
+
+// swagger:route GET /v1/notifications/deliveries/{ID} notifications RouteGetNotificationDelivery
+//
+// Get an attempt to send a notification.
+//
+//     Responses:
+//       200: GettableNotificationDelivery
+//       400: ValidationError
+//       403: PermissionDenied
+//       404: NotFound
+
+// swagger:route POST /v1/notifications/deliveries/{ID}/replay notifications RouteReplayNotificationDelivery
+//
+// Send the notification of a failed attempt again with the same integration.
+//
+// The integration is taken from the current configuration of the contact point. The replay is recorded as a new
+// attempt. Only failed attempts whose payload was recorded in full can be replayed, and only once.
+//
+//     Responses:
+//       200: NotificationReplayResult
+//       400: ValidationError
+//       403: PermissionDenied
+//       404: NotFound
+//       409: PublicError
+
+// swagger:parameters RouteGetNotificationDeliveries
+type GetNotificationDeliveriesParams struct {
+	// Filter by the name of the contact point.
+	// in:query
+	// required: false
+	Receiver string `json:"receiver"`
+	// Filter by the UID of the integration.
+	// in:query
+	// required: false
+	IntegrationUID string `json:"integrationUID"`
+	// Filter by the status of the attempt.
+	// in:query
+	// required: false
+	// enum: success,failed
+	Status string `json:"status"`
+	// The Unix timestamp of the start of the time range.
+	// in:query
+	// required: false
+	From int64 `json:"from"`
+	// The Unix timestamp of the end of the time range.
+	// in:query
+	// required: false
+	To int64 `json:"to"`
+	// Limits the number of attempts. The default is 100.
+	// in:query
+	// required: false
+	Limit int `json:"limit"`
+}
+
+// swagger:parameters RouteGetNotificationDelivery RouteReplayNotificationDelivery
+type NotificationDeliveryIDParams struct {
+	// in:path
+	// required: true
+	ID int64
+}
+
+// swagger:model
+type GettableNotificationDelivery struct {
+	ID       int64  `json:"id"`
+	Receiver string `json:"receiver"`
+	// The UID of the integration of the contact point. It is empty for the integrations of test notifications that
+	// are not saved.
+	IntegrationUID string `json:"integrationUID"`
+	// example: pagerduty
+	IntegrationType string `json:"integrationType"`
+	GroupKey        string `json:"groupKey"`
+	// The number of the attempt to send the notification, starting at 1. The attempts after the first one are retries.
+	Attempt int `json:"attempt"`
+	// enum: success,failed
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+	// The time it took the integration to send the notification, in milliseconds.
+	LatencyMs  int64 `json:"latencyMs"`
+	AlertCount int   `json:"alertCount"`
+	// The notification in JSON. It is truncated to 16 KiB.
+	Payload          string `json:"payload"`
+	PayloadTruncated bool   `json:"payloadTruncated"`
+	// The ID of the failed attempt that this attempt replays.
+	ReplayOf int64 `json:"replayOf,omitempty"`
+	// The time the failed attempt was replayed. A failed attempt can be replayed only once.
+	ReplayedAt *time.Time `json:"replayedAt,omitempty"`
+	Created    time.Time  `json:"created"`
+}
+
+// swagger:model
+type GettableNotificationDeliveries []GettableNotificationDelivery
+
+// swagger:model
+type NotificationReplayResult struct {
+	// enum: success,failed
+	Status string `json:"status"`
+	Error  string `json:"error,omitempty"`
+}
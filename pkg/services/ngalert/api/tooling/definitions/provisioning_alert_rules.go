@@ -158,6 +158,8 @@ type ProvisionedAlertRule struct {
 	// required: true
 	// swagger:strfmt duration
 	For model.Duration `json:"for"`
+	// swagger:strfmt duration
+	KeepFiringFor model.Duration `json:"keepFiringFor,omitempty"`
 	// example: {"runbook_url": "https://supercoolrunbook.com/page/13"}
 	Annotations map[string]string `json:"annotations,omitempty"`
 	// example: {"team": "sre-team-1"}
@@ -170,6 +172,8 @@ type ProvisionedAlertRule struct {
 	NotificationSettings *AlertRuleNotificationSettings `json:"notification_settings"`
 	//example: {"metric":"grafana_alerts_ratio", "from":"A"}
 	Record *Record `json:"record"`
+	// example: {"timeout":"10s","max_attempts":3,"initial_backoff":"2s"}
+	EvaluationPolicy *EvaluationPolicy `json:"evaluation_policy,omitempty"`
 }
 
 // swagger:route GET /v1/provisioning/folder/{FolderUID}/rule-groups/{Group} provisioning stable RouteGetAlertRuleGroup
@@ -215,13 +219,13 @@ type ProvisionedAlertRule struct {
 //       200: AlertRuleGroup
 //       400: ValidationError
 
-// swagger:parameters RouteGetAlertRuleGroup RoutePutAlertRuleGroup RouteGetAlertRuleGroupExport RouteDeleteAlertRuleGroup
+// swagger:parameters RouteGetAlertRuleGroup RoutePutAlertRuleGroup RouteGetAlertRuleGroupExport RouteDeleteAlertRuleGroup RouteGetRuleGroupTemplate RoutePutRuleGroupTemplate RouteDeleteRuleGroupTemplate
 type FolderUIDPathParam struct {
 	// in:path
 	FolderUID string `json:"FolderUID"`
 }
 
-// swagger:parameters RouteGetAlertRuleGroup RoutePutAlertRuleGroup RouteGetAlertRuleGroupExport RouteDeleteAlertRuleGroup
+// swagger:parameters RouteGetAlertRuleGroup RoutePutAlertRuleGroup RouteGetAlertRuleGroupExport RouteDeleteAlertRuleGroup RouteGetRuleGroupTemplate RoutePutRuleGroupTemplate RouteDeleteRuleGroupTemplate
 type RuleGroupPathParam struct {
 	// in:path
 	Group string `json:"Group"`
@@ -271,12 +275,17 @@ type AlertRuleExport struct {
 	// ForString is used to:
 	// - Only export the for field for HCL if it is non-zero.
 	// - Format the Prometheus model.Duration type properly for HCL.
-	ForString            *string                              `json:"-" yaml:"-" hcl:"for"`
+	ForString     *string        `json:"-" yaml:"-" hcl:"for"`
+	KeepFiringFor model.Duration `json:"keepFiringFor,omitempty" yaml:"keepFiringFor,omitempty"`
+	// KeepFiringForString is used to only export the keep_firing_for field for HCL if it is non-zero, formatted like ForString.
+	KeepFiringForString  *string                              `json:"-" yaml:"-" hcl:"keep_firing_for"`
 	Annotations          *map[string]string                   `json:"annotations,omitempty" yaml:"annotations,omitempty" hcl:"annotations"`
 	Labels               *map[string]string                   `json:"labels,omitempty" yaml:"labels,omitempty" hcl:"labels"`
 	IsPaused             bool                                 `json:"isPaused" yaml:"isPaused" hcl:"is_paused"`
 	NotificationSettings *AlertRuleNotificationSettingsExport `json:"notification_settings,omitempty" yaml:"notification_settings,omitempty" hcl:"notification_settings,block"`
 	Record               *AlertRuleRecordExport               `json:"record,omitempty" yaml:"record,omitempty" hcl:"record,block"`
+	// EvaluationPolicy is not supported by the Terraform provider, so it is not exported to HCL.
+	EvaluationPolicy *AlertRuleEvaluationPolicyExport `json:"evaluation_policy,omitempty" yaml:"evaluation_policy,omitempty"`
 }
 
 // AlertQueryExport is the provisioned export of models.AlertQuery.
@@ -306,6 +315,14 @@ type AlertRuleNotificationSettingsExport struct {
 	MuteTimeIntervals []string `yaml:"mute_time_intervals,omitempty" json:"mute_time_intervals,omitempty" hcl:"mute_timings"` // TF -> `mute_timings`
 }
 
+// AlertRuleEvaluationPolicyExport is the provisioned export of models.EvaluationPolicy.
+type AlertRuleEvaluationPolicyExport struct {
+	Timeout        model.Duration `json:"timeout,omitempty" yaml:"timeout,omitempty"`
+	MaxAttempts    int64          `json:"max_attempts,omitempty" yaml:"max_attempts,omitempty"`
+	InitialBackoff model.Duration `json:"initial_backoff,omitempty" yaml:"initial_backoff,omitempty"`
+	MaxBackoff     model.Duration `json:"max_backoff,omitempty" yaml:"max_backoff,omitempty"`
+}
+
 // Record is the provisioned export of models.Record.
 type AlertRuleRecordExport struct {
 	Metric string `json:"metric" yaml:"metric" hcl:"metric"`
@@ -0,0 +1,216 @@
+package definitions
+
+import (
+	"encoding/json"
+	"fmt"
+	"strconv"
+	"time"
+)
+
+// swagger:route GET /v1/provisioning/rule-templates provisioning stable RouteGetRuleTemplates
+//
+// Get all rule templates.
+//
+//     Responses:
+//       200: RuleTemplates
+
+// swagger:route POST /v1/provisioning/rule-templates provisioning stable RoutePostRuleTemplate
+//
+// Create a new rule template.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       201: RuleTemplate
+//       400: ValidationError
+
+// swagger:route GET /v1/provisioning/rule-templates/{UID} provisioning stable RouteGetRuleTemplate
+//
+// Get a rule template.
+//
+//     Responses:
+//       200: RuleTemplate
+//       404: PublicError
+
+// swagger:route PUT /v1/provisioning/rule-templates/{UID} provisioning stable RoutePutRuleTemplate
+//
+// Update a rule template and all rule groups created from it.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       200: RuleTemplate
+//       400: ValidationError
+//       404: PublicError
+//       409: PublicError
+
+// swagger:route DELETE /v1/provisioning/rule-templates/{UID} provisioning stable RouteDeleteRuleTemplate
+//
+// Delete a rule template that no rule group is created from.
+//
+//     Responses:
+//       204: description: The rule template was deleted successfully.
+//       404: PublicError
+//       409: PublicError
+
+// swagger:route GET /v1/provisioning/rule-templates/{UID}/versions provisioning stable RouteGetRuleTemplateVersions
+//
+// Get all versions of a rule template, the latest first.
+//
+//     Responses:
+//       200: RuleTemplates
+//       404: PublicError
+
+// swagger:route GET /v1/provisioning/rule-templates/{UID}/instances provisioning stable RouteGetRuleTemplateInstances
+//
+// Get the rule groups created from a rule template.
+//
+//     Responses:
+//       200: RuleGroupTemplates
+//       404: PublicError
+
+// swagger:route GET /v1/provisioning/folder/{FolderUID}/rule-groups/{Group}/template provisioning stable RouteGetRuleGroupTemplate
+//
+// Get the rule template and values that a rule group is created from.
+//
+//     Responses:
+//       200: RuleGroupTemplate
+//       404: PublicError
+
+// swagger:route PUT /v1/provisioning/folder/{FolderUID}/rule-groups/{Group}/template provisioning stable RoutePutRuleGroupTemplate
+//
+// Create or update a rule group from a rule template. The rule group is updated whenever the template is updated.
+//
+//     Consumes:
+//     - application/json
+//
+//     Responses:
+//       200: RuleGroupTemplate
+//       400: ValidationError
+//       404: PublicError
+
+// swagger:route DELETE /v1/provisioning/folder/{FolderUID}/rule-groups/{Group}/template provisioning stable RouteDeleteRuleGroupTemplate
+//
+// Delete a rule group created from a rule template.
+//
+//     Responses:
+//       204: description: The rule group was deleted successfully.
+//       404: PublicError
+
+// swagger:parameters RouteGetRuleTemplate RoutePutRuleTemplate RouteDeleteRuleTemplate RouteGetRuleTemplateVersions RouteGetRuleTemplateInstances
+type RuleTemplateUIDReference struct {
+	// Rule template UID
+	// in:path
+	UID string
+}
+
+// swagger:parameters RoutePostRuleTemplate RoutePutRuleTemplate
+type RuleTemplatePayload struct {
+	// in:body
+	Body RuleTemplate
+}
+
+// swagger:parameters RoutePutRuleGroupTemplate
+type RuleGroupTemplatePayload struct {
+	// in:body
+	Body RuleGroupTemplate
+}
+
+// swagger:parameters RoutePostRuleTemplate RoutePutRuleTemplate RouteDeleteRuleTemplate RoutePutRuleGroupTemplate RouteDeleteRuleGroupTemplate
+type RuleTemplateHeaders struct {
+	// in:header
+	XDisableProvenance string `json:"X-Disable-Provenance"`
+}
+
+// swagger:model
+type RuleTemplates []RuleTemplate
+
+// RuleTemplate is a rule group with parameters.
+// swagger:model
+type RuleTemplate struct {
+	// required: false
+	// minLength: 1
+	// maxLength: 40
+	// pattern: ^[a-zA-Z0-9-_]+$
+	UID string `json:"uid"`
+	// required: true
+	// example: Service SLOs
+	Title       string                  `json:"title"`
+	Description string                  `json:"description,omitempty"`
+	Parameters  []RuleTemplateParameter `json:"parameters,omitempty"`
+	// The rules of the rule groups created from the template. The UID of a rule identifies it in the template,
+	// and its folder and rule group are ignored. Titles, label and annotation values, data source UIDs, string
+	// values of query models and receivers can refer to parameters with ${name}.
+	// required: true
+	Rules []ProvisionedAlertRule `json:"rules"`
+	// Version of the template. If set on update, it must be the current version.
+	Version int64 `json:"version,omitempty"`
+	// readonly: true
+	Updated time.Time `json:"updated,omitempty"`
+	// readonly: true
+	Provenance Provenance `json:"provenance,omitempty"`
+}
+
+// swagger:model
+type RuleTemplateParameter struct {
+	// required: true
+	// pattern: ^[a-zA-Z_][a-zA-Z0-9_]*$
+	// example: service
+	Name string `json:"name"`
+	// required: true
+	// enum: string,number,boolean,duration
+	Type        string `json:"type"`
+	Description string `json:"description,omitempty"`
+	// Value of the parameter if a rule group does not set it. Parameters without a default are required.
+	Default *string `json:"default,omitempty"`
+}
+
+// swagger:model
+type RuleGroupTemplates []RuleGroupTemplate
+
+// RuleGroupTemplate refers to the rule template and values that a rule group is created from.
+// swagger:model
+type RuleGroupTemplate struct {
+	// readonly: true
+	FolderUID string `json:"folderUid,omitempty"`
+	// readonly: true
+	RuleGroup string `json:"ruleGroup,omitempty"`
+	// required: true
+	TemplateUID string `json:"templateUid"`
+	// required: true
+	Interval int64 `json:"interval"`
+	// example: {"service": "checkout", "threshold": 0.9}
+	Values RuleTemplateValues `json:"values,omitempty"`
+	// Version of the template that the rule group was last created from.
+	// readonly: true
+	TemplateVersion int64 `json:"templateVersion,omitempty"`
+	// readonly: true
+	Updated time.Time `json:"updated,omitempty"`
+}
+
+// RuleTemplateValues are the values of the parameters of a rule template. Numbers and booleans are accepted as well as strings.
+type RuleTemplateValues map[string]string
+
+func (v *RuleTemplateValues) UnmarshalJSON(b []byte) error {
+	var raw map[string]any
+	if err := json.Unmarshal(b, &raw); err != nil {
+		return err
+	}
+	values := make(RuleTemplateValues, len(raw))
+	for name, value := range raw {
+		switch t := value.(type) {
+		case string:
+			values[name] = t
+		case float64:
+			values[name] = strconv.FormatFloat(t, 'f', -1, 64)
+		case bool:
+			values[name] = strconv.FormatBool(t)
+		default:
+			return fmt.Errorf("value of parameter %s must be a string, a number or a boolean", name)
+		}
+	}
+	*v = values
+	return nil
+}
@@ -41,6 +41,10 @@ type StateHistoryParams struct {
 	// in:query
 	// required: false
 	Limit int `json:"limit"`
+	// Skips the given number of records, to be used together with limit to page through the results. Only supported by the sql backend, the other backends reject it.
+	// in:query
+	// required: false
+	Offset int `json:"offset"`
 	// Filter by rule UID. Required the state history is configured to use annotations for storage.
 	// in:query
 	// required: false
@@ -110,6 +110,8 @@ type PostableExtendedRuleNodeExtended struct {
 	NamespaceTitle string `json:"folderTitle"`
 	// example: eval_group_1
 	RuleGroup string `json:"ruleGroup"`
+	// Debug returns the alerts in the field alerts, and in the field explain how the queries and expressions were executed
+	Debug bool `json:"debug,omitempty"`
 }
 
 func (n *PostableExtendedRuleNodeExtended) UnmarshalJSON(b []byte) error {
@@ -131,6 +133,8 @@ type EvalQueriesPayload struct {
 	Condition string       `json:"condition"`
 	Data      []AlertQuery `json:"data"`
 	Now       time.Time    `json:"now"`
+	// Debug adds to the response how the queries and expressions were executed, in the field explain
+	Debug bool `json:"debug,omitempty"`
 }
 
 func (p *TestRulePayload) UnmarshalJSON(b []byte) error {
@@ -229,8 +233,40 @@ type BacktestConfig struct {
 	Labels      map[string]string `json:"labels,omitempty"`
 	Annotations map[string]string `json:"annotations,omitempty"`
 
-	NoDataState NoDataState `json:"no_data_state"`
+	NoDataState   NoDataState         `json:"no_data_state"`
+	ExecErrState  ExecutionErrorState `json:"exec_err_state,omitempty"`
+	KeepFiringFor model.Duration      `json:"keep_firing_for,omitempty"`
+
+	// NamespaceUID is the UID of the folder of the rule. It is used to add the folder labels to the alerts.
+	NamespaceUID         string                         `json:"namespace_uid,omitempty"`
+	NotificationSettings *AlertRuleNotificationSettings `json:"notification_settings,omitempty"`
 }
 
+// BacktestResult is a data frame with a field per alert instance that contains the state of the instance
+// at every evaluation. The custom metadata of the frame contains BacktestResultDetails.
 // swagger:model
 type BacktestResult data.Frame
+
+// swagger:model
+type BacktestResultDetails struct {
+	// Transitions are the changes of the state of the alert instances, in the order they happened.
+	Transitions []BacktestTransition `json:"transitions"`
+	// Notifications is the number of notifications each contact point would have received.
+	// It is estimated from the notification policies of the organization. Inhibition rules,
+	// mute timings and active time intervals are not taken into account. It is empty if the user
+	// cannot read the notifications, or the Alertmanager configuration cannot be loaded.
+	Notifications []BacktestNotificationSummary `json:"notifications"`
+}
+
+type BacktestTransition struct {
+	Time          time.Time         `json:"time"`
+	Labels        map[string]string `json:"labels"`
+	PreviousState string            `json:"previousState"`
+	State         string            `json:"state"`
+}
+
+type BacktestNotificationSummary struct {
+	Receiver      string `json:"receiver"`
+	Notifications int    `json:"notifications"`
+	Resolved      int    `json:"resolved"`
+}
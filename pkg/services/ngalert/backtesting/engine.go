@@ -42,11 +42,32 @@ type stateManager interface {
 type Engine struct {
 	evalFactory        eval.EvaluatorFactory
 	createStateManager func() stateManager
+	amConfigProvider   AlertmanagerConfigProvider
 }
 
-func NewEngine(appUrl *url.URL, evalFactory eval.EvaluatorFactory, tracer tracing.Tracer) *Engine {
+// Result is the outcome of testing a rule over a time range.
+type Result struct {
+	// Frame contains a field per alert instance with the state of the instance at every evaluation.
+	Frame *data.Frame
+	// Transitions are the changes of the state of the alert instances, in the order they happened.
+	Transitions []Transition
+	// Notifications is the number of notifications per contact point. It is nil if the notification
+	// policies of the organization are not available.
+	Notifications []NotificationSummary
+}
+
+// Transition is a change of the state of an alert instance.
+type Transition struct {
+	Time          time.Time
+	Labels        data.Labels
+	PreviousState string
+	State         string
+}
+
+func NewEngine(appUrl *url.URL, evalFactory eval.EvaluatorFactory, tracer tracing.Tracer, amConfigProvider AlertmanagerConfigProvider) *Engine {
 	return &Engine{
-		evalFactory: evalFactory,
+		evalFactory:      evalFactory,
+		amConfigProvider: amConfigProvider,
 		createStateManager: func() stateManager {
 			cfg := state.ManagerCfg{
 				Metrics:       nil,
@@ -63,7 +84,9 @@ func NewEngine(appUrl *url.URL, evalFactory eval.EvaluatorFactory, tracer tracin
 	}
 }
 
-func (e *Engine) Test(ctx context.Context, user identity.Requester, rule *models.AlertRule, from, to time.Time) (*data.Frame, error) {
+// Test evaluates the rule over the time range [from, to) and replays the results through a state manager.
+// The extraLabels are added to every alert instance, as the scheduler does.
+func (e *Engine) Test(ctx context.Context, user identity.Requester, rule *models.AlertRule, from, to time.Time, extraLabels data.Labels) (*Result, error) {
 	ruleCtx := models.WithRuleKey(ctx, rule.GetKey())
 	logger := logger.FromContext(ctx)
 
@@ -85,6 +108,13 @@ func (e *Engine) Test(ctx context.Context, user identity.Requester, rule *models
 		return nil, errors.Join(ErrInvalidInputData, err)
 	}
 
+	route, err := e.notificationRoute(ruleCtx, rule)
+	if err != nil {
+		return nil, err
+	}
+	notifications := newNotificationSimulator(route)
+	var transitions []Transition
+
 	logger.Info("Start testing alert rule", "from", from, "to", to, "interval", rule.IntervalSeconds, "evaluations", length)
 
 	start := time.Now()
@@ -97,9 +127,20 @@ func (e *Engine) Test(ctx context.Context, user identity.Requester, rule *models
 			logger.Info("Unexpected evaluation. Skipping", "from", from, "to", to, "interval", rule.IntervalSeconds, "evaluationTime", currentTime, "evaluationIndex", idx, "expectedEvaluations", length)
 			return nil
 		}
-		states := stateManager.ProcessEvalResults(ruleCtx, currentTime, rule, results, nil, nil)
+		notifications.flush(currentTime)
+		states := stateManager.ProcessEvalResults(ruleCtx, currentTime, rule, results, extraLabels, func(_ context.Context, toSend state.StateTransitions) {
+			notifications.add(currentTime, toSend)
+		})
 		tsField.Set(idx, currentTime)
 		for _, s := range states {
+			if s.Changed() {
+				transitions = append(transitions, Transition{
+					Time:          currentTime,
+					Labels:        s.Labels,
+					PreviousState: s.PreviousFormatted(),
+					State:         s.Formatted(),
+				})
+			}
 			field, ok := valueFields[s.CacheID]
 			if !ok {
 				field = data.NewField("", s.Labels, make([]*string, length))
@@ -126,8 +167,13 @@ func (e *Engine) Test(ctx context.Context, user identity.Requester, rule *models
 	if err != nil {
 		return nil, err
 	}
+	notifications.flush(to)
 	logger.Info("Rule testing finished successfully", "duration", time.Since(start))
-	return result, nil
+	return &Result{
+		Frame:         result,
+		Transitions:   transitions,
+		Notifications: notifications.summary(),
+	}, nil
 }
 
 func newBacktestingEvaluator(ctx context.Context, evalFactory eval.EvaluatorFactory, user identity.Requester, condition models.Condition, reader eval.AlertingResultsReader) (backtestingEvaluator, error) {
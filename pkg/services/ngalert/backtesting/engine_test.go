@@ -9,11 +9,15 @@ import (
 	"testing"
 	"time"
 
+	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/require"
 
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 
 	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	"github.com/grafana/grafana/pkg/services/ngalert/eval"
 	"github.com/grafana/grafana/pkg/services/ngalert/eval/eval_mocks"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
@@ -217,9 +221,10 @@ func TestEvaluatorTest(t *testing.T) {
 			return states
 		}
 
-		frame, err := engine.Test(context.Background(), nil, rule, from, to)
+		result, err := engine.Test(context.Background(), nil, rule, from, to, nil)
 
 		require.NoError(t, err)
+		frame := result.Frame
 		require.Len(t, frame.Fields, len(states)+1) // +1 - timestamp
 
 		t.Run("should contain field Time", func(t *testing.T) {
@@ -287,14 +292,14 @@ func TestEvaluatorTest(t *testing.T) {
 			return states
 		}
 
-		frame, err := engine.Test(context.Background(), nil, rule, from, to)
+		result, err := engine.Test(context.Background(), nil, rule, from, to, nil)
 		require.NoError(t, err)
-		expectedLen := frame.Rows()
+		expectedLen := result.Frame.Rows()
 		for i := 0; i < 100; i++ {
 			jitter := time.Duration(rand.Int63n(ruleInterval.Milliseconds())) * time.Millisecond
-			frame, err = engine.Test(context.Background(), nil, rule, from, to.Add(jitter))
+			result, err = engine.Test(context.Background(), nil, rule, from, to.Add(jitter), nil)
 			require.NoError(t, err)
-			require.Equalf(t, expectedLen, frame.Rows(), "jitter %v caused result to be different that base-line", jitter)
+			require.Equalf(t, expectedLen, result.Frame.Rows(), "jitter %v caused result to be different that base-line", jitter)
 		}
 	})
 
@@ -323,11 +328,11 @@ func TestEvaluatorTest(t *testing.T) {
 			return stateByTime[now]
 		}
 
-		frame, err := engine.Test(context.Background(), nil, rule, from, to)
+		result, err := engine.Test(context.Background(), nil, rule, from, to, nil)
 		require.NoError(t, err)
 
 		var field3 *data.Field
-		for _, field := range frame.Fields {
+		for _, field := range result.Frame.Fields {
 			if field.Labels.String() == state3.Labels.String() {
 				field3 = field
 				break
@@ -354,17 +359,17 @@ func TestEvaluatorTest(t *testing.T) {
 			from := time.Now()
 			t.Run("when from=to", func(t *testing.T) {
 				to := from
-				_, err := engine.Test(context.Background(), nil, rule, from, to)
+				_, err := engine.Test(context.Background(), nil, rule, from, to, nil)
 				require.ErrorIs(t, err, ErrInvalidInputData)
 			})
 			t.Run("when from > to", func(t *testing.T) {
 				to := from.Add(-ruleInterval)
-				_, err := engine.Test(context.Background(), nil, rule, from, to)
+				_, err := engine.Test(context.Background(), nil, rule, from, to, nil)
 				require.ErrorIs(t, err, ErrInvalidInputData)
 			})
 			t.Run("when to-from < interval", func(t *testing.T) {
 				to := from.Add(ruleInterval).Add(-time.Millisecond)
-				_, err := engine.Test(context.Background(), nil, rule, from, to)
+				_, err := engine.Test(context.Background(), nil, rule, from, to, nil)
 				require.ErrorIs(t, err, ErrInvalidInputData)
 			})
 		})
@@ -376,12 +381,150 @@ func TestEvaluatorTest(t *testing.T) {
 			}
 			from := time.Now()
 			to := from.Add(ruleInterval)
-			_, err := engine.Test(context.Background(), nil, rule, from, to)
+			_, err := engine.Test(context.Background(), nil, rule, from, to, nil)
 			require.ErrorIs(t, err, expectedError)
 		})
 	})
 }
 
+func TestEngineReplaysStateTransitions(t *testing.T) {
+	const config = `{
+		"alertmanager_config": {
+			"route": {
+				"receiver": "default",
+				"group_by": ["alertname"],
+				"group_wait": "30s",
+				"group_interval": "5m",
+				"repeat_interval": "4h",
+				"routes": [{"receiver": "team-b", "object_matchers": [["team", "=", "b"]]}]
+			},
+			"receivers": [{"name": "default"}, {"name": "team-b"}]
+		}
+	}`
+	var cfg definitions.GettableUserConfig
+	require.NoError(t, json.Unmarshal([]byte(config), &cfg))
+
+	// The instance is normal at the first evaluation, alerting from the second to the sixth, and normal again after.
+	evaluator := &fakeBacktestingEvaluator{
+		evalCallback: func(now time.Time) (eval.Results, error) {
+			s := eval.Normal
+			if minute := now.Unix() / 60; minute >= 1 && minute <= 5 {
+				s = eval.Alerting
+			}
+			return eval.Results{{Instance: data.Labels{"host": "a"}, State: s, EvaluatedAt: now}}, nil
+		},
+	}
+	backtestingEvaluatorFactory = func(ctx context.Context, evalFactory eval.EvaluatorFactory, user identity.Requester, condition models.Condition, r eval.AlertingResultsReader) (backtestingEvaluator, error) {
+		return evaluator, nil
+	}
+	t.Cleanup(func() {
+		backtestingEvaluatorFactory = newBacktestingEvaluator
+	})
+
+	engine := NewEngine(nil, nil, tracing.InitializeTracerForTest(), &fakeAlertmanagerConfigProvider{cfg: cfg})
+	from := time.Unix(0, 0)
+	to := from.Add(10 * time.Minute)
+	gen := models.RuleGen.With(
+		models.RuleMuts.WithInterval(time.Minute),
+		models.RuleMuts.WithFor(2*time.Minute),
+		models.RuleMuts.WithTitle("test"),
+		models.RuleMuts.WithLabels(data.Labels{"team": "a"}),
+		models.RuleMuts.WithNoNotificationSettings(),
+	)
+
+	t.Run("should report the transitions of the state manager", func(t *testing.T) {
+		rule := gen.GenerateRef()
+		result, err := engine.Test(context.Background(), nil, rule, from, to, state.GetRuleExtraLabels(log.NewNopLogger(), rule, "", false))
+		require.NoError(t, err)
+
+		require.Len(t, result.Transitions, 3)
+		expected := []struct {
+			minute    int
+			prev, cur string
+		}{
+			{1, "Normal", "Pending"},
+			{3, "Pending", "Alerting"},
+			{6, "Alerting", "Normal"},
+		}
+		for i, e := range expected {
+			tr := result.Transitions[i]
+			require.Equal(t, from.Add(time.Duration(e.minute)*time.Minute), tr.Time)
+			require.Equal(t, e.prev, tr.PreviousState)
+			require.Equal(t, e.cur, tr.State)
+			require.Equal(t, "a", tr.Labels["host"])
+		}
+	})
+
+	t.Run("should count notifications per contact point of the notification policies", func(t *testing.T) {
+		rule := gen.GenerateRef()
+		result, err := engine.Test(context.Background(), nil, rule, from, to, state.GetRuleExtraLabels(log.NewNopLogger(), rule, "", false))
+		require.NoError(t, err)
+
+		// The firing notification is sent after the group wait, and the resolved one after the group interval.
+		require.Equal(t, []NotificationSummary{{Receiver: "default", Notifications: 2, Resolved: 1}}, result.Notifications)
+
+		rule = gen.With(models.RuleMuts.WithLabels(data.Labels{"team": "b"})).GenerateRef()
+		result, err = engine.Test(context.Background(), nil, rule, from, to, state.GetRuleExtraLabels(log.NewNopLogger(), rule, "", false))
+		require.NoError(t, err)
+		require.Equal(t, []NotificationSummary{{Receiver: "team-b", Notifications: 2, Resolved: 1}}, result.Notifications)
+	})
+
+	t.Run("should count notifications of simplified routing", func(t *testing.T) {
+		groupInterval := model.Duration(10 * time.Minute)
+		rule := gen.With(models.RuleMuts.WithNotificationSettings(models.NotificationSettings{
+			Receiver:      "team-b",
+			GroupInterval: &groupInterval,
+		})).GenerateRef()
+		result, err := engine.Test(context.Background(), nil, rule, from, to, state.GetRuleExtraLabels(log.NewNopLogger(), rule, "", false))
+		require.NoError(t, err)
+		// The resolved notification would be sent after the end of the time range.
+		require.Equal(t, []NotificationSummary{{Receiver: "team-b", Notifications: 1}}, result.Notifications)
+	})
+
+	t.Run("should fail if contact point of simplified routing does not exist", func(t *testing.T) {
+		rule := gen.With(models.RuleMuts.WithNotificationSettings(models.NotificationSettings{Receiver: "unknown"})).GenerateRef()
+		_, err := engine.Test(context.Background(), nil, rule, from, to, state.GetRuleExtraLabels(log.NewNopLogger(), rule, "", false))
+		require.ErrorIs(t, err, ErrInvalidInputData)
+	})
+
+	t.Run("should not count notifications without Alertmanager configuration", func(t *testing.T) {
+		engine := NewEngine(nil, nil, tracing.InitializeTracerForTest(), nil)
+		rule := gen.GenerateRef()
+		result, err := engine.Test(context.Background(), nil, rule, from, to, nil)
+		require.NoError(t, err)
+		require.Len(t, result.Transitions, 3)
+		require.Nil(t, result.Notifications)
+	})
+
+	t.Run("should not count notifications if the Alertmanager configuration cannot be loaded", func(t *testing.T) {
+		engine := NewEngine(nil, nil, tracing.InitializeTracerForTest(), &fakeAlertmanagerConfigProvider{err: errors.New("failed")})
+		rule := gen.GenerateRef()
+		result, err := engine.Test(context.Background(), nil, rule, from, to, nil)
+		require.NoError(t, err)
+		require.Len(t, result.Transitions, 3)
+		require.Nil(t, result.Notifications)
+	})
+}
+
+type fakeAlertmanagerConfigProvider struct {
+	cfg definitions.GettableUserConfig
+	err error
+}
+
+func (f *fakeAlertmanagerConfigProvider) GetAlertmanagerConfiguration(_ context.Context, _ int64, _ bool) (definitions.GettableUserConfig, error) {
+	if f.err != nil {
+		return definitions.GettableUserConfig{}, f.err
+	}
+	// Copy the configuration because the engine adds the autogenerated routes to it.
+	var cfg definitions.GettableUserConfig
+	b, err := json.Marshal(f.cfg)
+	if err != nil {
+		return cfg, err
+	}
+	err = json.Unmarshal(b, &cfg)
+	return cfg, err
+}
+
 type fakeStateManager struct {
 	stateCallback func(now time.Time) []state.StateTransition
 }
@@ -0,0 +1,232 @@
+package backtesting
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/prometheus/alertmanager/dispatch"
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/notifier"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+)
+
+// AlertmanagerConfigProvider provides the configuration of the Grafana Alertmanager of an organization.
+type AlertmanagerConfigProvider interface {
+	GetAlertmanagerConfiguration(ctx context.Context, org int64, withAutogen bool) (definitions.GettableUserConfig, error)
+}
+
+// NotificationSummary is the number of notifications a contact point would have received during backtesting.
+type NotificationSummary struct {
+	Receiver string
+	// Notifications is the total number of notifications.
+	Notifications int
+	// Resolved is the number of notifications that contain only resolved alerts.
+	Resolved int
+}
+
+// notificationSettingsStore serves the notification settings of the backtested rule
+// in order to generate the routes of simplified routing.
+type notificationSettingsStore map[models.AlertRuleKey][]models.NotificationSettings
+
+func (s notificationSettingsStore) ListNotificationSettings(_ context.Context, _ models.ListNotificationSettingsQuery) (map[models.AlertRuleKey][]models.NotificationSettings, error) {
+	return s, nil
+}
+
+// notificationRoute returns the notification policy tree of the organization of the rule.
+// It returns nil if the engine does not have access to the Alertmanager configuration, or the configuration
+// cannot be loaded, so that the rule is backtested without simulating the notifications.
+func (e *Engine) notificationRoute(ctx context.Context, rule *models.AlertRule) (*dispatch.Route, error) {
+	if e.amConfigProvider == nil {
+		return nil, nil
+	}
+	cfg, err := e.amConfigProvider.GetAlertmanagerConfiguration(ctx, rule.OrgID, false)
+	if err != nil {
+		logger.FromContext(ctx).Error("Failed to get the Alertmanager configuration, notifications are not simulated", "error", err)
+		return nil, nil
+	}
+	if cfg.AlertmanagerConfig.Route == nil {
+		return nil, nil
+	}
+	if len(rule.NotificationSettings) > 0 {
+		store := notificationSettingsStore{rule.GetKey(): rule.NotificationSettings}
+		if err := notifier.AddAutogenConfig(ctx, logger, store, rule.OrgID, &cfg.AlertmanagerConfig, false); err != nil {
+			return nil, errors.Join(ErrInvalidInputData, err)
+		}
+	}
+	return dispatch.NewRoute(cfg.AlertmanagerConfig.Route.AsAMRoute(), nil), nil
+}
+
+// notificationSimulator estimates the notifications that the Alertmanager would send for the alerts of a rule.
+// It mimics the aggregation groups of the dispatcher and the deduplication done with the notification log,
+// assuming that all contact points send resolved notifications.
+// Inhibition rules, mute timings and active time intervals are not taken into account.
+type notificationSimulator struct {
+	route  *dispatch.Route
+	groups map[string]*simulatedGroup
+	// nflog contains the last notification per aggregation group. Like in the Alertmanager,
+	// it outlives the group, which is deleted once all its alerts are resolved.
+	nflog     map[string]*simulatedNotification
+	summaries map[string]*NotificationSummary
+}
+
+type simulatedGroup struct {
+	key       string
+	opts      dispatch.RouteOpts
+	alerts    map[model.Fingerprint]bool
+	nextFlush time.Time
+}
+
+type simulatedNotification struct {
+	firing   map[model.Fingerprint]struct{}
+	resolved map[model.Fingerprint]struct{}
+	sentAt   time.Time
+}
+
+func newNotificationSimulator(route *dispatch.Route) *notificationSimulator {
+	return &notificationSimulator{
+		route:     route,
+		groups:    make(map[string]*simulatedGroup),
+		nflog:     make(map[string]*simulatedNotification),
+		summaries: make(map[string]*NotificationSummary),
+	}
+}
+
+// add routes the alerts that the state manager sends at the given time to the aggregation groups.
+func (s *notificationSimulator) add(now time.Time, transitions state.StateTransitions) {
+	if s.route == nil {
+		return
+	}
+	for _, t := range transitions {
+		alert := state.StateToPostableAlert(t, nil)
+		lbls := make(model.LabelSet, len(alert.Labels))
+		for k, v := range alert.Labels {
+			lbls[model.LabelName(k)] = model.LabelValue(v)
+		}
+		fp := lbls.Fingerprint()
+		firing := t.State.State != eval.Normal
+
+		for _, r := range s.route.Match(lbls) {
+			groupLabels := model.LabelSet{}
+			for ln, lv := range lbls {
+				if _, ok := r.RouteOpts.GroupBy[ln]; ok || r.RouteOpts.GroupByAll {
+					groupLabels[ln] = lv
+				}
+			}
+			key := fmt.Sprintf("%s:%s", r.Key(), groupLabels)
+			g, ok := s.groups[key]
+			if !ok {
+				if !firing {
+					// The group was already flushed and deleted, the alert was resolved before.
+					continue
+				}
+				g = &simulatedGroup{
+					key:       key,
+					opts:      r.RouteOpts,
+					alerts:    make(map[model.Fingerprint]bool),
+					nextFlush: now.Add(r.RouteOpts.GroupWait),
+				}
+				s.groups[key] = g
+			}
+			g.alerts[fp] = firing
+		}
+	}
+}
+
+// flush sends the notifications of all aggregation groups that are due at or before the given time.
+func (s *notificationSimulator) flush(now time.Time) {
+	// Aggregation groups are independent of each other, so they can be flushed in any order.
+	for _, g := range s.groups {
+		for !g.nextFlush.After(now) {
+			if !s.flushGroup(g) {
+				break
+			}
+		}
+	}
+}
+
+// flushGroup sends the notification of the group if needed, and returns false if the group was deleted.
+func (s *notificationSimulator) flushGroup(g *simulatedGroup) bool {
+	at := g.nextFlush
+	firing := make(map[model.Fingerprint]struct{})
+	resolved := make(map[model.Fingerprint]struct{})
+	for fp, isFiring := range g.alerts {
+		if isFiring {
+			firing[fp] = struct{}{}
+		} else {
+			resolved[fp] = struct{}{}
+		}
+	}
+
+	prev := s.nflog[g.key]
+	if needsNotification(prev, firing, resolved, at, g.opts.RepeatInterval) {
+		summary, ok := s.summaries[g.opts.Receiver]
+		if !ok {
+			summary = &NotificationSummary{Receiver: g.opts.Receiver}
+			s.summaries[g.opts.Receiver] = summary
+		}
+		summary.Notifications++
+		if len(firing) == 0 {
+			summary.Resolved++
+		}
+		s.nflog[g.key] = &simulatedNotification{firing: firing, resolved: resolved, sentAt: at}
+	}
+
+	// Resolved alerts are removed from the group once they have been flushed.
+	for fp := range resolved {
+		delete(g.alerts, fp)
+	}
+	if len(g.alerts) == 0 {
+		delete(s.groups, g.key)
+		return false
+	}
+	g.nextFlush = at.Add(g.opts.GroupInterval)
+	return true
+}
+
+// needsNotification mirrors the deduplication stage of the Alertmanager notification pipeline.
+func needsNotification(prev *simulatedNotification, firing, resolved map[model.Fingerprint]struct{}, now time.Time, repeat time.Duration) bool {
+	if prev == nil {
+		return len(firing) > 0
+	}
+	if !isSubset(firing, prev.firing) {
+		return true
+	}
+	if len(firing) == 0 {
+		return len(prev.firing) > 0
+	}
+	if !isSubset(resolved, prev.resolved) {
+		return true
+	}
+	return !prev.sentAt.Add(repeat).After(now)
+}
+
+func isSubset(set, of map[model.Fingerprint]struct{}) bool {
+	for fp := range set {
+		if _, ok := of[fp]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// summary returns the number of notifications per contact point, ordered by contact point.
+func (s *notificationSimulator) summary() []NotificationSummary {
+	if s.route == nil {
+		return nil
+	}
+	result := make([]NotificationSummary, 0, len(s.summaries))
+	for _, summary := range s.summaries {
+		result = append(result, *summary)
+	}
+	sort.Slice(result, func(i, j int) bool {
+		return result[i].Receiver < result[j].Receiver
+	})
+	return result
+}
@@ -0,0 +1,78 @@
+package backtesting
+
+import (
+	"testing"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/dispatch"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+)
+
+func TestNotificationSimulator(t *testing.T) {
+	newRoute := func(groupBy ...model.LabelName) *dispatch.Route {
+		groupWait := model.Duration(30 * time.Second)
+		groupInterval := model.Duration(5 * time.Minute)
+		repeatInterval := model.Duration(time.Hour)
+		return dispatch.NewRoute(&config.Route{
+			Receiver:       "default",
+			GroupBy:        groupBy,
+			GroupWait:      &groupWait,
+			GroupInterval:  &groupInterval,
+			RepeatInterval: &repeatInterval,
+		}, nil)
+	}
+	transition := func(s eval.State, lbls data.Labels) state.StateTransition {
+		return state.StateTransition{State: &state.State{State: s, Labels: lbls}}
+	}
+	start := time.Unix(0, 0)
+
+	t.Run("should repeat notifications of firing alerts", func(t *testing.T) {
+		s := newNotificationSimulator(newRoute("alertname"))
+		s.add(start, state.StateTransitions{transition(eval.Alerting, data.Labels{"alertname": "a"})})
+		s.flush(start.Add(150 * time.Minute))
+		// Sent at 30s, 60m30s and 120m30s.
+		require.Equal(t, []NotificationSummary{{Receiver: "default", Notifications: 3}}, s.summary())
+	})
+
+	t.Run("should notify about new alerts in a group after the group interval", func(t *testing.T) {
+		s := newNotificationSimulator(newRoute("alertname"))
+		s.add(start, state.StateTransitions{transition(eval.Alerting, data.Labels{"alertname": "a", "host": "1"})})
+		s.flush(start.Add(time.Minute))
+		s.add(start.Add(time.Minute), state.StateTransitions{transition(eval.Alerting, data.Labels{"alertname": "a", "host": "2"})})
+		s.add(start.Add(2*time.Minute), state.StateTransitions{transition(eval.Alerting, data.Labels{"alertname": "a", "host": "3"})})
+		s.flush(start.Add(10 * time.Minute))
+		// Sent at 30s for host 1, and at 5m30s for hosts 2 and 3.
+		require.Equal(t, []NotificationSummary{{Receiver: "default", Notifications: 2}}, s.summary())
+	})
+
+	t.Run("should notify each group separately", func(t *testing.T) {
+		s := newNotificationSimulator(newRoute("alertname", "host"))
+		s.add(start, state.StateTransitions{
+			transition(eval.Alerting, data.Labels{"alertname": "a", "host": "1"}),
+			transition(eval.Alerting, data.Labels{"alertname": "a", "host": "2"}),
+		})
+		s.flush(start.Add(time.Minute))
+		require.Equal(t, []NotificationSummary{{Receiver: "default", Notifications: 2}}, s.summary())
+	})
+
+	t.Run("should not notify about alerts resolved before the group wait", func(t *testing.T) {
+		s := newNotificationSimulator(newRoute("alertname"))
+		s.add(start, state.StateTransitions{transition(eval.Alerting, data.Labels{"alertname": "a"})})
+		s.add(start.Add(10*time.Second), state.StateTransitions{transition(eval.Normal, data.Labels{"alertname": "a"})})
+		s.flush(start.Add(time.Hour))
+		require.Empty(t, s.summary())
+	})
+
+	t.Run("should return nil without notification policies", func(t *testing.T) {
+		s := newNotificationSimulator(nil)
+		s.add(start, state.StateTransitions{transition(eval.Alerting, data.Labels{"alertname": "a"})})
+		s.flush(start.Add(time.Hour))
+		require.Nil(t, s.summary())
+	})
+}
@@ -2,6 +2,7 @@ package eval
 
 import (
 	"context"
+	"time"
 
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 
@@ -19,6 +20,8 @@ type EvaluationContext struct {
 	Ctx                   context.Context
 	User                  identity.Requester
 	AlertingResultsReader AlertingResultsReader
+	// Timeout overrides the evaluation timeout of the evaluator if it is positive.
+	Timeout time.Duration
 }
 
 func NewContext(ctx context.Context, user identity.Requester) EvaluationContext {
@@ -26,6 +26,9 @@ import (
 
 var logger = log.New("ngalert.eval")
 
+// ErrEvaluationTimeout is returned when the evaluation of a condition takes longer than the evaluation timeout.
+var ErrEvaluationTimeout = errors.New("evaluation timed out")
+
 type EvaluatorFactory interface {
 	// Create builds an evaluator pipeline ready to evaluate a rule's query
 	Create(ctx EvaluationContext, condition models.Condition) (ConditionEvaluator, error)
@@ -78,6 +81,11 @@ func (r *conditionEvaluator) EvaluateRaw(ctx context.Context, now time.Time) (re
 	logger.FromContext(ctx).Debug("Executing pipeline", "commands", strings.Join(r.pipeline.GetCommandTypes(), ","), "datasources", strings.Join(r.pipeline.GetDatasourceTypes(), ","))
 	result, err := r.expressionService.ExecutePipeline(execCtx, now, r.pipeline)
 
+	// Data sources often report that the deadline is exceeded as a query error, which is replaced by a dedicated error.
+	if r.evalTimeout > 0 && (err != nil || hasQueryErrors(result)) && errors.Is(execCtx.Err(), context.DeadlineExceeded) && ctx.Err() == nil {
+		return nil, fmt.Errorf("%w after %s: %w", ErrEvaluationTimeout, r.evalTimeout, execCtx.Err())
+	}
+
 	// Check if the result of the condition evaluation is too large
 	if err == nil && result != nil && r.evalResultLimit > 0 {
 		conditionResultLength := 0
@@ -93,6 +101,18 @@ func (r *conditionEvaluator) EvaluateRaw(ctx context.Context, now time.Time) (re
 	return result, err
 }
 
+func hasQueryErrors(resp *backend.QueryDataResponse) bool {
+	if resp == nil {
+		return false
+	}
+	for _, r := range resp.Responses {
+		if r.Error != nil {
+			return true
+		}
+	}
+	return false
+}
+
 // Evaluate evaluates the condition and converts the response to Results
 func (r *conditionEvaluator) Evaluate(ctx context.Context, now time.Time) (Results, error) {
 	response, err := r.EvaluateRaw(ctx, now)
@@ -834,10 +854,14 @@ func (e *evaluatorImpl) Create(ctx EvaluationContext, condition models.Condition
 	if err != nil {
 		return nil, err
 	}
-	return e.create(condition, req)
+	evalTimeout := e.evaluationTimeout
+	if ctx.Timeout > 0 {
+		evalTimeout = ctx.Timeout
+	}
+	return e.create(condition, req, evalTimeout)
 }
 
-func (e *evaluatorImpl) create(condition models.Condition, req *expr.Request) (ConditionEvaluator, error) {
+func (e *evaluatorImpl) create(condition models.Condition, req *expr.Request, evalTimeout time.Duration) (ConditionEvaluator, error) {
 	pipeline, err := e.expressionService.BuildPipeline(req)
 	if err != nil {
 		return nil, err
@@ -849,7 +873,7 @@ func (e *evaluatorImpl) create(condition models.Condition, req *expr.Request) (C
 				pipeline:          pipeline,
 				expressionService: e.expressionService,
 				condition:         condition,
-				evalTimeout:       e.evaluationTimeout,
+				evalTimeout:       evalTimeout,
 				evalResultLimit:   e.evaluationResultLimit,
 			}, nil
 		}
@@ -1028,6 +1028,46 @@ func TestEvaluateRaw(t *testing.T) {
 
 		_, err := e.EvaluateRaw(context.Background(), time.Now())
 		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.ErrorIs(t, err, ErrEvaluationTimeout)
+	})
+
+	t.Run("should return timeout error if data source reports the timeout as query error", func(t *testing.T) {
+		e := conditionEvaluator{
+			pipeline: nil,
+			expressionService: &fakeExpressionService{
+				hook: func(ctx context.Context, now time.Time, pipeline expr.DataPipeline) (*backend.QueryDataResponse, error) {
+					<-ctx.Done()
+					resp := backend.NewQueryDataResponse()
+					resp.Responses["A"] = backend.DataResponse{Error: ctx.Err()}
+					return resp, nil
+				},
+			},
+			condition:   models.Condition{},
+			evalTimeout: 10 * time.Millisecond,
+		}
+
+		_, err := e.EvaluateRaw(context.Background(), time.Now())
+		require.ErrorIs(t, err, ErrEvaluationTimeout)
+	})
+
+	t.Run("should not return timeout error if the parent context is canceled", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+		defer cancel()
+		e := conditionEvaluator{
+			pipeline: nil,
+			expressionService: &fakeExpressionService{
+				hook: func(ctx context.Context, now time.Time, pipeline expr.DataPipeline) (*backend.QueryDataResponse, error) {
+					<-ctx.Done()
+					return nil, ctx.Err()
+				},
+			},
+			condition:   models.Condition{},
+			evalTimeout: time.Minute,
+		}
+
+		_, err := e.EvaluateRaw(ctx, time.Now())
+		require.ErrorIs(t, err, context.DeadlineExceeded)
+		require.NotErrorIs(t, err, ErrEvaluationTimeout)
 	})
 }
 
@@ -18,6 +18,8 @@ type MultiOrgAlertmanager struct {
 	ActiveConfigurations     prometheus.Gauge
 	DiscoveredConfigurations prometheus.Gauge
 
+	NotificationDeliveriesDropped prometheus.Counter
+
 	aggregatedMetrics *AlertmanagerAggregatedMetrics
 }
 
@@ -38,6 +40,12 @@ func NewMultiOrgAlertmanagerMetrics(r prometheus.Registerer) *MultiOrgAlertmanag
 			Name:      "active_configurations",
 			Help:      "The number of active Alertmanager configurations.",
 		}),
+		NotificationDeliveriesDropped: promauto.With(r).NewCounter(prometheus.CounterOpts{
+			Namespace: Namespace,
+			Subsystem: Subsystem,
+			Name:      "notification_deliveries_dropped_total",
+			Help:      "The number of notification deliveries that were not written to the delivery log because its queue was full.",
+		}),
 		aggregatedMetrics: NewAlertmanagerAggregatedMetrics(registries),
 	}
 
@@ -32,6 +32,7 @@ type Scheduler struct {
 	Ticker                              *ticker.Metrics
 	EvaluationMissed                    *prometheus.CounterVec
 	SimplifiedEditorRules               *prometheus.GaugeVec
+	ShardReplicas                       prometheus.Gauge
 }
 
 func NewSchedulerMetrics(r prometheus.Registerer) *Scheduler {
@@ -164,6 +165,13 @@ func NewSchedulerMetrics(r prometheus.Registerer) *Scheduler {
 				Name:      "schedule_alert_rules_hash",
 				Help:      "A hash of the alert rules that could be considered for evaluation at the next tick.",
 			}),
+		ShardReplicas: promauto.With(r).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Subsystem: Subsystem,
+				Name:      "schedule_shard_replicas",
+				Help:      "The number of replicas that the alert rules are sharded across.",
+			}),
 		UpdateSchedulableAlertRulesDuration: promauto.With(r).NewHistogram(
 			prometheus.HistogramOpts{
 				Namespace: Namespace,
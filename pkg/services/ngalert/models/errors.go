@@ -16,6 +16,41 @@ var (
 	ErrAlertRuleGroupNotFound       = errutil.NotFound("alerting.alert-rule.notFound")
 	ErrInvalidRelativeTimeRangeBase = errutil.BadRequest("alerting.alert-rule.invalidRelativeTime").MustTemplate("Invalid alert rule query {{ .Public.RefID }}: invalid relative time range [From: {{ .Public.From }}, To: {{ .Public.To }}]")
 	ErrConditionNotExistBase        = errutil.BadRequest("alerting.alert-rule.conditionNotExist").MustTemplate("Condition {{ .Public.Given }} does not exist, must be one of {{ .Public.Existing }}")
+
+	ErrRuleTemplateNotFound         = errutil.NotFound("alerting.rule-template.notFound", errutil.WithPublicMessage("Rule template not found."))
+	ErrRuleTemplateExists           = errutil.BadRequest("alerting.rule-template.uidExists", errutil.WithPublicMessage("Rule template with this UID already exists. Use a different UID or update the existing one."))
+	ErrRuleTemplateInUse            = errutil.Conflict("alerting.rule-template.used", errutil.WithPublicMessage("Rule template is used by rule groups. Delete the rule groups first."))
+	ErrRuleTemplateInstanceNotFound = errutil.NotFound("alerting.rule-template.instanceNotFound", errutil.WithPublicMessage("Rule group is not created from a rule template."))
+	ErrRuleTemplateInvalidBase      = errutil.BadRequest("alerting.rule-template.invalidFormat").MustTemplate(
+		"Invalid rule template: {{ .Public.Error }}",
+		errutil.WithPublic("Invalid rule template: {{ .Public.Error }}. Correct the payload and try again."),
+	)
+
+	ErrRecurringSilenceNotFound    = errutil.NotFound("alerting.recurring-silence.notFound", errutil.WithPublicMessage("Recurring silence not found."))
+	ErrRecurringSilenceConflict    = errutil.Conflict("alerting.recurring-silence.conflict", errutil.WithPublicMessage("Recurring silence was changed in the meantime. Get its current version and try again."))
+	ErrRecurringSilenceInvalidBase = errutil.BadRequest("alerting.recurring-silence.invalidFormat").MustTemplate(
+		"Invalid recurring silence: {{ .Public.Error }}",
+		errutil.WithPublic("Invalid recurring silence: {{ .Public.Error }}. Correct the payload and try again."),
+	)
+
+	ErrSilenceExpiryNotificationNotFound    = errutil.NotFound("alerting.silence-expiry-notification.notFound", errutil.WithPublicMessage("Silence expiry notification not found."))
+	ErrSilenceExpiryNotificationInvalidBase = errutil.BadRequest("alerting.silence-expiry-notification.invalidFormat").MustTemplate(
+		"Invalid silence expiry notification: {{ .Public.Error }}",
+		errutil.WithPublic("Invalid silence expiry notification: {{ .Public.Error }}. Correct the payload and try again."),
+	)
+
+	ErrTemplateFixtureNotFound    = errutil.NotFound("alerting.template-fixture.notFound", errutil.WithPublicMessage("Template fixture not found."))
+	ErrTemplateFixtureInvalidBase = errutil.BadRequest("alerting.template-fixture.invalidFormat").MustTemplate(
+		"Invalid template fixture: {{ .Public.Error }}",
+		errutil.WithPublic("Invalid template fixture: {{ .Public.Error }}. Correct the payload and try again."),
+	)
+
+	ErrNotificationDeliveryNotFound          = errutil.NotFound("alerting.notification-delivery.notFound", errutil.WithPublicMessage("Notification delivery not found."))
+	ErrNotificationDeliveryNotReplayableBase = errutil.BadRequest("alerting.notification-delivery.notReplayable").MustTemplate(
+		"Notification delivery cannot be replayed: {{ .Public.Error }}",
+		errutil.WithPublic("Notification delivery cannot be replayed: {{ .Public.Error }}."),
+	)
+	ErrNotificationDeliveryAlreadyReplayed = errutil.Conflict("alerting.notification-delivery.alreadyReplayed", errutil.WithPublicMessage("Notification delivery was already replayed."))
 )
 
 func ErrAlertRuleConflict(rule AlertRule, underlying error) error {
@@ -45,3 +80,23 @@ func ErrInvalidRelativeTimeRange(refID string, rtr RelativeTimeRange) error {
 func ErrConditionNotExist(given string, existing []string) error {
 	return ErrConditionNotExistBase.Build(errutil.TemplateData{Public: map[string]any{"Given": given, "Existing": fmt.Sprintf("%v", existing)}})
 }
+
+func ErrRuleTemplateInvalid(err error) error {
+	return ErrRuleTemplateInvalidBase.Build(errutil.TemplateData{Public: map[string]any{"Error": err.Error()}, Error: err})
+}
+
+func ErrRecurringSilenceInvalid(err error) error {
+	return ErrRecurringSilenceInvalidBase.Build(errutil.TemplateData{Public: map[string]any{"Error": err.Error()}, Error: err})
+}
+
+func ErrSilenceExpiryNotificationInvalid(err error) error {
+	return ErrSilenceExpiryNotificationInvalidBase.Build(errutil.TemplateData{Public: map[string]any{"Error": err.Error()}, Error: err})
+}
+
+func ErrTemplateFixtureInvalid(err error) error {
+	return ErrTemplateFixtureInvalidBase.Build(errutil.TemplateData{Public: map[string]any{"Error": err.Error()}, Error: err})
+}
+
+func ErrNotificationDeliveryNotReplayable(err error) error {
+	return ErrNotificationDeliveryNotReplayableBase.Build(errutil.TemplateData{Public: map[string]any{"Error": err.Error()}, Error: err})
+}
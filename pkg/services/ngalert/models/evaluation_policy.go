@@ -0,0 +1,129 @@
+package models
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"math"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+)
+
+const (
+	// MaxEvaluationAttempts is the maximum number of attempts that an evaluation policy can set.
+	MaxEvaluationAttempts = 10
+
+	// DefaultEvaluationRetryDelay is the delay between two attempts if the policy does not set an initial backoff.
+	DefaultEvaluationRetryDelay = time.Second
+)
+
+// EvaluationPolicy overrides how the scheduler evaluates a rule. Fields with the zero value fall back
+// to the settings of the scheduler.
+type EvaluationPolicy struct {
+	// Timeout limits the duration of an evaluation attempt.
+	Timeout time.Duration `json:"timeout,omitempty"`
+	// MaxAttempts is the maximum number of attempts to evaluate the rule at every tick.
+	MaxAttempts int64 `json:"max_attempts,omitempty"`
+	// InitialBackoff is the delay before the first retry. It doubles with every further retry.
+	// If it is not set, all retries are delayed by the retry delay of the scheduler.
+	InitialBackoff time.Duration `json:"initial_backoff,omitempty"`
+	// MaxBackoff limits the delay between two attempts.
+	MaxBackoff time.Duration `json:"max_backoff,omitempty"`
+}
+
+func (p EvaluationPolicy) Validate() error {
+	if p.Timeout < 0 {
+		return errors.New("timeout cannot be negative")
+	}
+	if p.MaxAttempts < 0 {
+		return errors.New("max attempts cannot be negative")
+	}
+	if p.MaxAttempts > MaxEvaluationAttempts {
+		return fmt.Errorf("max attempts cannot be greater than %d", MaxEvaluationAttempts)
+	}
+	if p.InitialBackoff < 0 {
+		return errors.New("initial backoff cannot be negative")
+	}
+	if p.MaxBackoff < 0 {
+		return errors.New("max backoff cannot be negative")
+	}
+	if p.MaxBackoff > 0 && p.InitialBackoff > p.MaxBackoff {
+		return fmt.Errorf("initial backoff %s cannot be longer than max backoff %s", p.InitialBackoff, p.MaxBackoff)
+	}
+	return nil
+}
+
+// ValidateInterval checks that all attempts of an evaluation with the policy fit into the evaluation interval,
+// so that they do not delay the next evaluation.
+func (p EvaluationPolicy) ValidateInterval(interval time.Duration) error {
+	if p.Timeout > interval {
+		return fmt.Errorf("evaluation timeout %s cannot be longer than the evaluation interval %s", p.Timeout, interval)
+	}
+	if budget := p.RetryBudget(); budget > interval {
+		return fmt.Errorf("the attempts of an evaluation can take up to %s, which is longer than the evaluation interval %s", budget, interval)
+	}
+	return nil
+}
+
+// RetryBudget returns how long all attempts of an evaluation can take: MaxAttempts times Timeout, and the backoffs
+// between the attempts. The settings of the scheduler are not known, so an unset timeout counts as zero and unset
+// max attempts count as one attempt.
+func (p EvaluationPolicy) RetryBudget() time.Duration {
+	attempts := p.GetMaxAttempts(1)
+	budget := time.Duration(attempts) * p.Timeout
+	for attempt := int64(1); attempt < attempts; attempt++ {
+		budget += p.Backoff(attempt, DefaultEvaluationRetryDelay)
+	}
+	return budget
+}
+
+// GetTimeout returns the timeout of an evaluation attempt, or 0 if the rule uses the timeout of the evaluator.
+func (p *EvaluationPolicy) GetTimeout() time.Duration {
+	if p == nil {
+		return 0
+	}
+	return p.Timeout
+}
+
+// GetMaxAttempts returns the maximum number of evaluation attempts, or defaultMaxAttempts if the policy does not set it.
+func (p *EvaluationPolicy) GetMaxAttempts(defaultMaxAttempts int64) int64 {
+	if p == nil || p.MaxAttempts == 0 {
+		return defaultMaxAttempts
+	}
+	return p.MaxAttempts
+}
+
+// Backoff returns the delay after the given failed attempt, starting at 1.
+// defaultDelay is used for every attempt if the policy does not set an initial backoff.
+func (p *EvaluationPolicy) Backoff(attempt int64, defaultDelay time.Duration) time.Duration {
+	if p == nil || p.InitialBackoff == 0 {
+		return defaultDelay
+	}
+	delay := p.InitialBackoff
+	for i := int64(1); i < attempt && delay < math.MaxInt64/2; i++ {
+		delay *= 2
+		if p.MaxBackoff > 0 && delay >= p.MaxBackoff {
+			break
+		}
+	}
+	if p.MaxBackoff > 0 && delay > p.MaxBackoff {
+		return p.MaxBackoff
+	}
+	return delay
+}
+
+func (p *EvaluationPolicy) Fingerprint() data.Fingerprint {
+	h := fnv.New64()
+	tmp := make([]byte, 8)
+	writeInt := func(u int64) {
+		binary.LittleEndian.PutUint64(tmp, uint64(u))
+		_, _ = h.Write(tmp)
+	}
+	writeInt(int64(p.Timeout))
+	writeInt(p.MaxAttempts)
+	writeInt(int64(p.InitialBackoff))
+	writeInt(int64(p.MaxBackoff))
+	return data.Fingerprint(h.Sum64())
+}
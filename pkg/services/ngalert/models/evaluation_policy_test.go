@@ -0,0 +1,127 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestEvaluationPolicyValidate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		policy EvaluationPolicy
+		err    string
+	}{
+		{
+			name:   "empty policy is valid",
+			policy: EvaluationPolicy{},
+		},
+		{
+			name:   "complete policy is valid",
+			policy: EvaluationPolicy{Timeout: time.Second, MaxAttempts: 3, InitialBackoff: time.Second, MaxBackoff: time.Minute},
+		},
+		{
+			name:   "negative timeout",
+			policy: EvaluationPolicy{Timeout: -time.Second},
+			err:    "timeout cannot be negative",
+		},
+		{
+			name:   "negative max attempts",
+			policy: EvaluationPolicy{MaxAttempts: -1},
+			err:    "max attempts cannot be negative",
+		},
+		{
+			name:   "too many max attempts",
+			policy: EvaluationPolicy{MaxAttempts: MaxEvaluationAttempts + 1},
+			err:    "max attempts cannot be greater than 10",
+		},
+		{
+			name:   "initial backoff longer than max backoff",
+			policy: EvaluationPolicy{InitialBackoff: time.Minute, MaxBackoff: time.Second},
+			err:    "initial backoff 1m0s cannot be longer than max backoff 1s",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.policy.Validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestEvaluationPolicyValidateInterval(t *testing.T) {
+	testCases := []struct {
+		name   string
+		policy EvaluationPolicy
+		err    string
+	}{
+		{
+			name:   "empty policy is valid",
+			policy: EvaluationPolicy{},
+		},
+		{
+			name:   "attempts and backoffs within the interval",
+			policy: EvaluationPolicy{Timeout: 15 * time.Second, MaxAttempts: 3, InitialBackoff: 2 * time.Second, MaxBackoff: 10 * time.Second},
+		},
+		{
+			name:   "timeout longer than the interval",
+			policy: EvaluationPolicy{Timeout: 2 * time.Minute},
+			err:    "evaluation timeout 2m0s cannot be longer than the evaluation interval 1m0s",
+		},
+		{
+			name:   "attempts longer than the interval",
+			policy: EvaluationPolicy{Timeout: 30 * time.Second, MaxAttempts: 2},
+			err:    "the attempts of an evaluation can take up to 1m1s, which is longer than the evaluation interval 1m0s",
+		},
+		{
+			name:   "backoffs longer than the interval",
+			policy: EvaluationPolicy{MaxAttempts: 10, InitialBackoff: 10 * time.Second},
+			err:    "the attempts of an evaluation can take up to 1h25m10s, which is longer than the evaluation interval 1m0s",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.policy.ValidateInterval(time.Minute)
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.EqualError(t, err, tc.err)
+		})
+	}
+}
+
+func TestEvaluationPolicyBackoff(t *testing.T) {
+	t.Run("should use the default delay without policy", func(t *testing.T) {
+		var p *EvaluationPolicy
+		assert.Equal(t, time.Second, p.Backoff(3, time.Second))
+		assert.Equal(t, int64(3), p.GetMaxAttempts(3))
+		assert.Zero(t, p.GetTimeout())
+	})
+
+	t.Run("should use the default delay without initial backoff", func(t *testing.T) {
+		p := &EvaluationPolicy{MaxAttempts: 5}
+		assert.Equal(t, time.Second, p.Backoff(3, time.Second))
+		assert.Equal(t, int64(5), p.GetMaxAttempts(3))
+	})
+
+	t.Run("should double the delay up to the max backoff", func(t *testing.T) {
+		p := &EvaluationPolicy{InitialBackoff: time.Second, MaxBackoff: 5 * time.Second}
+		assert.Equal(t, time.Second, p.Backoff(1, 0))
+		assert.Equal(t, 2*time.Second, p.Backoff(2, 0))
+		assert.Equal(t, 4*time.Second, p.Backoff(3, 0))
+		assert.Equal(t, 5*time.Second, p.Backoff(4, 0))
+		assert.Equal(t, 5*time.Second, p.Backoff(100, 0))
+	})
+
+	t.Run("should not overflow without max backoff", func(t *testing.T) {
+		p := &EvaluationPolicy{InitialBackoff: time.Second}
+		assert.Positive(t, p.Backoff(100, 0))
+	})
+}
@@ -16,5 +16,7 @@ type HistoryQuery struct {
 	From         time.Time
 	To           time.Time
 	Limit        int
+	// Offset is the number of records to skip. Only supported by the SQL backend, the other backends reject it.
+	Offset       int
 	SignedInUser identity.Requester
 }
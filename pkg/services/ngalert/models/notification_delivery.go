@@ -0,0 +1,124 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+	"unicode/utf8"
+
+	"github.com/prometheus/common/model"
+)
+
+// NotificationDeliveryStatus is the outcome of an attempt to send a notification.
+type NotificationDeliveryStatus string
+
+const (
+	NotificationDeliveryStatusSuccess NotificationDeliveryStatus = "success"
+	NotificationDeliveryStatusFailed  NotificationDeliveryStatus = "failed"
+)
+
+// MaxNotificationDeliveryPayloadSize limits the size in bytes of the payload that is recorded with a delivery.
+const MaxNotificationDeliveryPayloadSize = 16 * 1024
+
+// NotificationDelivery is an attempt of an integration of a receiver to send a notification.
+type NotificationDelivery struct {
+	ID              int64
+	OrgID           int64
+	Receiver        string
+	IntegrationUID  string
+	IntegrationType string
+	GroupKey        string
+	// Attempt is the number of the attempt to send the notification of the group, starting at 1. The attempts after
+	// the first one are retries.
+	Attempt    int
+	Status     NotificationDeliveryStatus
+	Error      string
+	Latency    time.Duration
+	AlertCount int
+	// Payload is the notification in JSON, truncated to MaxNotificationDeliveryPayloadSize bytes.
+	Payload          string
+	PayloadTruncated bool
+	// ReplayOf is the ID of the failed delivery that this delivery replays, or 0 if it is not a replay.
+	ReplayOf int64
+	// ReplayedAt is the time the failed delivery was replayed, or nil if it was not replayed.
+	ReplayedAt *time.Time
+	Created    time.Time
+}
+
+// NotificationPayload is the notification that is recorded with a delivery.
+type NotificationPayload struct {
+	GroupLabels model.LabelSet `json:"groupLabels"`
+	Alerts      []model.Alert  `json:"alerts"`
+}
+
+// NotificationReplayResult is the outcome of sending the notification of a failed delivery again.
+type NotificationReplayResult struct {
+	Status NotificationDeliveryStatus
+	Error  string
+}
+
+// ListNotificationDeliveriesQuery is the query for the notification deliveries. Empty fields are not filtered on.
+type ListNotificationDeliveriesQuery struct {
+	OrgID          int64
+	Receiver       string
+	IntegrationUID string
+	Status         NotificationDeliveryStatus
+	From           time.Time
+	To             time.Time
+	Limit          int
+}
+
+// SetPayload records the notification as the payload of the delivery, truncated to MaxNotificationDeliveryPayloadSize
+// bytes.
+func (d *NotificationDelivery) SetPayload(p NotificationPayload) error {
+	b, err := json.Marshal(p)
+	if err != nil {
+		return fmt.Errorf("failed to marshal payload: %w", err)
+	}
+	d.Payload, d.PayloadTruncated = truncatePayload(string(b))
+	d.AlertCount = len(p.Alerts)
+	return nil
+}
+
+// NotificationPayload returns the notification recorded with the delivery. It returns an error if the payload was
+// truncated.
+func (d NotificationDelivery) NotificationPayload() (NotificationPayload, error) {
+	var p NotificationPayload
+	if d.PayloadTruncated {
+		return p, errors.New("the payload was truncated")
+	}
+	if err := json.Unmarshal([]byte(d.Payload), &p); err != nil {
+		return p, fmt.Errorf("failed to parse payload: %w", err)
+	}
+	return p, nil
+}
+
+// Replayable returns an error if the notification of the delivery cannot be sent again.
+func (d NotificationDelivery) Replayable() error {
+	if d.Status != NotificationDeliveryStatusFailed {
+		return errors.New("only failed deliveries can be replayed")
+	}
+	if d.IntegrationUID == "" {
+		return errors.New("the integration is unknown")
+	}
+	if d.PayloadTruncated {
+		return errors.New("the payload was truncated")
+	}
+	if d.ReplayedAt != nil {
+		return ErrNotificationDeliveryAlreadyReplayed
+	}
+	return nil
+}
+
+// truncatePayload truncates the payload to MaxNotificationDeliveryPayloadSize bytes without splitting a character.
+func truncatePayload(s string) (string, bool) {
+	if len(s) <= MaxNotificationDeliveryPayloadSize {
+		return s, false
+	}
+	n := MaxNotificationDeliveryPayloadSize
+	for n > 0 && !utf8.RuneStart(s[n]) {
+		n--
+	}
+	return s[:n], true
+}
@@ -0,0 +1,65 @@
+package models
+
+import (
+	"strings"
+	"testing"
+	"unicode/utf8"
+
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNotificationDeliveryPayload(t *testing.T) {
+	t.Run("round trips the notification", func(t *testing.T) {
+		p := NotificationPayload{
+			GroupLabels: model.LabelSet{"alertname": "Test"},
+			Alerts: []model.Alert{
+				{Labels: model.LabelSet{"alertname": "Test", "instance": "a"}, Annotations: model.LabelSet{"summary": "a is down"}},
+				{Labels: model.LabelSet{"alertname": "Test", "instance": "b"}},
+			},
+		}
+		var d NotificationDelivery
+		require.NoError(t, d.SetPayload(p))
+		assert.False(t, d.PayloadTruncated)
+		assert.Equal(t, 2, d.AlertCount)
+
+		actual, err := d.NotificationPayload()
+		require.NoError(t, err)
+		assert.Equal(t, p.GroupLabels, actual.GroupLabels)
+		require.Len(t, actual.Alerts, 2)
+		assert.Equal(t, p.Alerts[0].Labels, actual.Alerts[0].Labels)
+		assert.Equal(t, p.Alerts[0].Annotations, actual.Alerts[0].Annotations)
+	})
+
+	t.Run("truncates a large notification", func(t *testing.T) {
+		p := NotificationPayload{Alerts: []model.Alert{
+			{Labels: model.LabelSet{"alertname": "Test"}, Annotations: model.LabelSet{"description": model.LabelValue(strings.Repeat("é", MaxNotificationDeliveryPayloadSize))}},
+		}}
+		var d NotificationDelivery
+		require.NoError(t, d.SetPayload(p))
+		assert.True(t, d.PayloadTruncated)
+		assert.LessOrEqual(t, len(d.Payload), MaxNotificationDeliveryPayloadSize)
+		assert.True(t, utf8.ValidString(d.Payload))
+
+		_, err := d.NotificationPayload()
+		require.ErrorContains(t, err, "truncated")
+	})
+}
+
+func TestNotificationDeliveryReplayable(t *testing.T) {
+	failed := NotificationDelivery{Status: NotificationDeliveryStatusFailed, IntegrationUID: "uid", Payload: "{}"}
+	require.NoError(t, failed.Replayable())
+
+	succeeded := failed
+	succeeded.Status = NotificationDeliveryStatusSuccess
+	require.ErrorContains(t, succeeded.Replayable(), "only failed deliveries")
+
+	unknown := failed
+	unknown.IntegrationUID = ""
+	require.ErrorContains(t, unknown.Replayable(), "integration is unknown")
+
+	truncated := failed
+	truncated.PayloadTruncated = true
+	require.ErrorContains(t, truncated.Replayable(), "truncated")
+}
@@ -15,6 +15,7 @@ import (
 	alertingNotify "github.com/grafana/alerting/notify"
 
 	"github.com/grafana/grafana/pkg/services/ngalert/notifier/channels_config"
+	"github.com/grafana/grafana/pkg/services/ngalert/notifier/templatedhttp"
 )
 
 // GetReceiverQuery represents a query for a single receiver.
@@ -544,6 +545,14 @@ func ValidateIntegration(ctx context.Context, integration alertingNotify.Grafana
 		return fmt.Errorf("settings should not be empty")
 	}
 
+	// The templated HTTP integration is implemented in Grafana and is unknown to the alerting package.
+	if strings.EqualFold(integration.Type, templatedhttp.Type) {
+		if _, err := templatedhttp.NewConfigFromIntegration(ctx, &integration, decryptFunc); err != nil {
+			return alertingNotify.IntegrationValidationError{Integration: &integration, Err: err}
+		}
+		return nil
+	}
+
 	_, err := alertingNotify.BuildReceiverConfiguration(ctx, &alertingNotify.APIReceiver{
 		GrafanaIntegrations: alertingNotify.GrafanaIntegrations{
 			Integrations: []*alertingNotify.GrafanaIntegrationConfig{&integration},
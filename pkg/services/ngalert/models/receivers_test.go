@@ -112,6 +112,36 @@ func TestIntegration_Validate(t *testing.T) {
 	}
 }
 
+func TestIntegration_ValidateTemplatedHTTP(t *testing.T) {
+	config, err := IntegrationConfigFromType("templated-http")
+	require.NoError(t, err)
+
+	validIntegration := Integration{
+		UID:    "templated-http-uid",
+		Name:   "tickets",
+		Config: config,
+		Settings: map[string]any{
+			"url":                "http://localhost/{{ .CommonLabels.queue }}",
+			"hmacSecret":         "secret",
+			"secureHeaders":      "X-Api-Key: key",
+			"successStatusCodes": "200-204",
+		},
+		SecureSettings: map[string]string{},
+	}
+	assert.NoError(t, validIntegration.Encrypt(Base64Enrypt))
+	assert.Contains(t, validIntegration.SecureSettings, "hmacSecret")
+	assert.Contains(t, validIntegration.SecureSettings, "secureHeaders")
+	assert.NoErrorf(t, validIntegration.Validate(Base64Decrypt), "integration should be valid")
+
+	invalidIntegration := Integration{
+		UID:      "templated-http-uid",
+		Name:     "tickets",
+		Config:   config,
+		Settings: map[string]any{"url": "http://localhost", "successStatusCodes": "2xx"},
+	}
+	assert.Errorf(t, invalidIntegration.Validate(Base64Decrypt), "integration should be invalid")
+}
+
 func TestIntegration_WithExistingSecureFields(t *testing.T) {
 	// Test that WithExistingSecureFields will copy over the secure fields from the existing integration.
 	testCases := []struct {
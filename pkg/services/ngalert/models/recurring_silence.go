@@ -0,0 +1,207 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/robfig/cron/v3"
+)
+
+// maxTimeIntervalOccurrence limits the duration of an occurrence of a schedule of time intervals. The time intervals
+// are checked minute by minute, and a longer occurrence is continued by the next one.
+const maxTimeIntervalOccurrence = 7 * 24 * time.Hour
+
+// RecurringSilence is a silence that repeats on a schedule. For every occurrence of the schedule, a silence with the
+// matchers of the recurring silence is created in the Alertmanager of the organization.
+type RecurringSilence struct {
+	ID        int64
+	OrgID     int64
+	UID       string
+	Matchers  amv2.Matchers
+	Comment   string
+	CreatedBy string
+	// Cron is the start of the occurrences in the standard cron format. Each occurrence lasts Duration.
+	Cron     string
+	Duration time.Duration
+	// Timezone is the location of the cron schedule. The default is UTC.
+	Timezone string
+	// TimeIntervals are the occurrences as time intervals. It is an alternative to Cron, and each occurrence lasts as
+	// long as the time is in one of the intervals.
+	TimeIntervals []timeinterval.TimeInterval
+	Version       int64
+	Updated       time.Time
+	// LastOccurrence is the last occurrence for which a silence was created.
+	LastOccurrence RecurringSilenceOccurrence
+}
+
+// RecurringSilenceOccurrence is a single occurrence of the schedule of a recurring silence.
+type RecurringSilenceOccurrence struct {
+	StartsAt time.Time
+	EndsAt   time.Time
+	// SilenceID is the ID of the silence that was created for the occurrence.
+	SilenceID string
+}
+
+// Validate returns an error if the recurring silence cannot be scheduled or its silences would be rejected by the
+// Alertmanager.
+func (s RecurringSilence) Validate() error {
+	if len(s.Matchers) == 0 {
+		return errors.New("at least one matcher is required")
+	}
+	matchesEmpty := true
+	for _, m := range s.Matchers {
+		if m == nil {
+			return errors.New("matcher cannot be empty")
+		}
+		if err := m.Validate(strfmt.Default); err != nil {
+			return fmt.Errorf("invalid matcher: %w", err)
+		}
+		lm, err := labels.NewMatcher(matchType(*m), *m.Name, *m.Value)
+		if err != nil {
+			return fmt.Errorf("invalid matcher %s: %w", *m.Name, err)
+		}
+		if !lm.Matches("") {
+			matchesEmpty = false
+		}
+	}
+	if matchesEmpty {
+		return errors.New("at least one matcher must not match the empty string")
+	}
+	if s.Comment == "" {
+		return errors.New("comment is required")
+	}
+	if s.CreatedBy == "" {
+		return errors.New("creator is required")
+	}
+
+	switch {
+	case s.Cron != "" && len(s.TimeIntervals) > 0:
+		return errors.New("cron and time intervals cannot be used together")
+	case s.Cron != "":
+		if _, err := s.cronSchedule(); err != nil {
+			return err
+		}
+		if s.Duration <= 0 {
+			return errors.New("duration must be greater than zero")
+		}
+	case len(s.TimeIntervals) > 0:
+		if s.Duration != 0 {
+			return errors.New("duration cannot be used with time intervals, the occurrences last as long as the time intervals")
+		}
+		if s.Timezone != "" {
+			return errors.New("timezone cannot be used with time intervals, set the location of the time intervals instead")
+		}
+	default:
+		return errors.New("either cron or time intervals is required")
+	}
+	return nil
+}
+
+// NextOccurrence returns the first occurrence of the schedule that ends after from and starts before until. It returns
+// false if there is no such occurrence. An occurrence of time intervals starts no earlier than from.
+func (s RecurringSilence) NextOccurrence(from, until time.Time) (RecurringSilenceOccurrence, bool, error) {
+	if s.Cron == "" {
+		return s.nextTimeIntervalOccurrence(from, until)
+	}
+	sched, err := s.cronSchedule()
+	if err != nil {
+		return RecurringSilenceOccurrence{}, false, err
+	}
+	loc, err := s.location()
+	if err != nil {
+		return RecurringSilenceOccurrence{}, false, err
+	}
+	// An occurrence that starts after from-duration ends after from.
+	start := sched.Next(from.Add(-s.Duration).In(loc))
+	if start.IsZero() || !start.Before(until) {
+		return RecurringSilenceOccurrence{}, false, nil
+	}
+	return RecurringSilenceOccurrence{StartsAt: start, EndsAt: start.Add(s.Duration)}, true, nil
+}
+
+func (s RecurringSilence) nextTimeIntervalOccurrence(from, until time.Time) (RecurringSilenceOccurrence, bool, error) {
+	start := from
+	for !s.inTimeIntervals(start) {
+		start = start.Truncate(time.Minute).Add(time.Minute)
+		if !start.Before(until) {
+			return RecurringSilenceOccurrence{}, false, nil
+		}
+	}
+	limit := start.Add(maxTimeIntervalOccurrence)
+	end := start.Truncate(time.Minute).Add(time.Minute)
+	for end.Before(limit) && s.inTimeIntervals(end) {
+		end = end.Add(time.Minute)
+	}
+	if end.After(limit) {
+		end = limit
+	}
+	return RecurringSilenceOccurrence{StartsAt: start, EndsAt: end}, true, nil
+}
+
+func (s RecurringSilence) inTimeIntervals(t time.Time) bool {
+	for _, ti := range s.TimeIntervals {
+		if ti.ContainsTime(t) {
+			return true
+		}
+	}
+	return false
+}
+
+func (s RecurringSilence) cronSchedule() (cron.Schedule, error) {
+	sched, err := cron.ParseStandard(s.Cron)
+	if err != nil {
+		return nil, fmt.Errorf("invalid cron expression: %w", err)
+	}
+	if _, err := s.location(); err != nil {
+		return nil, err
+	}
+	return sched, nil
+}
+
+func (s RecurringSilence) location() (*time.Location, error) {
+	if s.Timezone == "" {
+		return time.UTC, nil
+	}
+	loc, err := time.LoadLocation(s.Timezone)
+	if err != nil {
+		return nil, fmt.Errorf("invalid timezone: %w", err)
+	}
+	return loc, nil
+}
+
+// Silence returns the silence of the occurrence.
+func (s RecurringSilence) Silence(occurrence RecurringSilenceOccurrence) Silence {
+	startsAt := strfmt.DateTime(occurrence.StartsAt)
+	endsAt := strfmt.DateTime(occurrence.EndsAt)
+	comment := s.Comment
+	createdBy := s.CreatedBy
+	return Silence{
+		Silence: amv2.Silence{
+			Matchers:  s.Matchers,
+			Comment:   &comment,
+			CreatedBy: &createdBy,
+			StartsAt:  &startsAt,
+			EndsAt:    &endsAt,
+		},
+	}
+}
+
+func matchType(m amv2.Matcher) labels.MatchType {
+	isEqual := m.IsEqual == nil || *m.IsEqual
+	isRegex := m.IsRegex != nil && *m.IsRegex
+	switch {
+	case isEqual && isRegex:
+		return labels.MatchRegexp
+	case !isEqual && isRegex:
+		return labels.MatchNotRegexp
+	case !isEqual:
+		return labels.MatchNotEqual
+	default:
+		return labels.MatchEqual
+	}
+}
@@ -0,0 +1,194 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/timeinterval"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/util"
+)
+
+func recurringSilenceMatcher(name, value string, isEqual, isRegex bool) *amv2.Matcher {
+	return &amv2.Matcher{Name: util.Pointer(name), Value: util.Pointer(value), IsEqual: util.Pointer(isEqual), IsRegex: util.Pointer(isRegex)}
+}
+
+func parseTimeIntervals(t *testing.T, raw string) []timeinterval.TimeInterval {
+	t.Helper()
+	var intervals []timeinterval.TimeInterval
+	require.NoError(t, json.Unmarshal([]byte(raw), &intervals))
+	return intervals
+}
+
+func TestRecurringSilenceValidate(t *testing.T) {
+	valid := func() RecurringSilence {
+		return RecurringSilence{
+			Matchers:  amv2.Matchers{recurringSilenceMatcher("host", "db-1", true, false)},
+			Comment:   "Weekly patching",
+			CreatedBy: "ops",
+			Cron:      "0 2 * * 0",
+			Duration:  2 * time.Hour,
+		}
+	}
+	require.NoError(t, valid().Validate())
+
+	testCases := []struct {
+		name     string
+		mutate   func(s *RecurringSilence)
+		expected string
+	}{
+		{
+			name:     "no matchers",
+			mutate:   func(s *RecurringSilence) { s.Matchers = nil },
+			expected: "at least one matcher is required",
+		},
+		{
+			name:     "invalid regular expression",
+			mutate:   func(s *RecurringSilence) { s.Matchers[0] = recurringSilenceMatcher("host", "db-(", true, true) },
+			expected: "invalid matcher host",
+		},
+		{
+			name:     "matchers that match everything",
+			mutate:   func(s *RecurringSilence) { s.Matchers[0] = recurringSilenceMatcher("host", ".*", true, true) },
+			expected: "at least one matcher must not match the empty string",
+		},
+		{
+			name:     "no comment",
+			mutate:   func(s *RecurringSilence) { s.Comment = "" },
+			expected: "comment is required",
+		},
+		{
+			name:     "no schedule",
+			mutate:   func(s *RecurringSilence) { s.Cron = "" },
+			expected: "either cron or time intervals is required",
+		},
+		{
+			name:     "invalid cron expression",
+			mutate:   func(s *RecurringSilence) { s.Cron = "0 2 * *" },
+			expected: "invalid cron expression",
+		},
+		{
+			name:     "invalid timezone",
+			mutate:   func(s *RecurringSilence) { s.Timezone = "Mars/Olympus" },
+			expected: "invalid timezone",
+		},
+		{
+			name:     "cron without duration",
+			mutate:   func(s *RecurringSilence) { s.Duration = 0 },
+			expected: "duration must be greater than zero",
+		},
+		{
+			name: "cron and time intervals",
+			mutate: func(s *RecurringSilence) {
+				s.TimeIntervals = parseTimeIntervals(t, `[{"weekdays": ["sunday"]}]`)
+			},
+			expected: "cron and time intervals cannot be used together",
+		},
+		{
+			name: "time intervals with duration",
+			mutate: func(s *RecurringSilence) {
+				s.Cron = ""
+				s.TimeIntervals = parseTimeIntervals(t, `[{"weekdays": ["sunday"]}]`)
+			},
+			expected: "duration cannot be used with time intervals",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			s := valid()
+			tc.mutate(&s)
+			require.ErrorContains(t, s.Validate(), tc.expected)
+		})
+	}
+}
+
+func TestRecurringSilenceNextOccurrence(t *testing.T) {
+	berlin, err := time.LoadLocation("Europe/Berlin")
+	require.NoError(t, err)
+	// Sunday 1 March 2026 in Berlin.
+	sunday := time.Date(2026, time.March, 1, 0, 0, 0, 0, berlin)
+
+	t.Run("cron", func(t *testing.T) {
+		s := RecurringSilence{Cron: "0 2 * * 0", Duration: 2 * time.Hour, Timezone: "Europe/Berlin"}
+		start := sunday.Add(2 * time.Hour)
+
+		testCases := []struct {
+			name     string
+			from     time.Time
+			until    time.Time
+			expected *RecurringSilenceOccurrence
+		}{
+			{
+				name:     "occurrence starts before until",
+				from:     sunday,
+				until:    sunday.Add(3 * time.Hour),
+				expected: &RecurringSilenceOccurrence{StartsAt: start, EndsAt: start.Add(2 * time.Hour)},
+			},
+			{
+				name:  "occurrence starts after until",
+				from:  sunday,
+				until: sunday.Add(time.Hour),
+			},
+			{
+				name:     "occurrence is active",
+				from:     start.Add(time.Hour),
+				until:    start.Add(2 * time.Hour),
+				expected: &RecurringSilenceOccurrence{StartsAt: start, EndsAt: start.Add(2 * time.Hour)},
+			},
+			{
+				name:     "occurrence ended",
+				from:     start.Add(2 * time.Hour),
+				until:    start.Add(8 * 24 * time.Hour),
+				expected: &RecurringSilenceOccurrence{StartsAt: start.AddDate(0, 0, 7), EndsAt: start.AddDate(0, 0, 7).Add(2 * time.Hour)},
+			},
+		}
+		for _, tc := range testCases {
+			t.Run(tc.name, func(t *testing.T) {
+				occurrence, ok, err := s.NextOccurrence(tc.from, tc.until)
+				require.NoError(t, err)
+				if tc.expected == nil {
+					require.False(t, ok)
+					return
+				}
+				require.True(t, ok)
+				require.True(t, tc.expected.StartsAt.Equal(occurrence.StartsAt), "expected start %s, got %s", tc.expected.StartsAt, occurrence.StartsAt)
+				require.True(t, tc.expected.EndsAt.Equal(occurrence.EndsAt), "expected end %s, got %s", tc.expected.EndsAt, occurrence.EndsAt)
+			})
+		}
+	})
+
+	t.Run("time intervals", func(t *testing.T) {
+		s := RecurringSilence{TimeIntervals: parseTimeIntervals(t, `[{"times": [{"start_time": "02:00", "end_time": "04:00"}], "weekdays": ["sunday"], "location": "Europe/Berlin"}]`)}
+		start := sunday.Add(2 * time.Hour)
+
+		occurrence, ok, err := s.NextOccurrence(sunday, sunday.Add(3*time.Hour))
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.True(t, start.Equal(occurrence.StartsAt))
+		require.True(t, start.Add(2*time.Hour).Equal(occurrence.EndsAt))
+
+		_, ok, err = s.NextOccurrence(sunday, sunday.Add(time.Hour))
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		// An active occurrence starts at from.
+		from := start.Add(90 * time.Minute)
+		occurrence, ok, err = s.NextOccurrence(from, from.Add(time.Minute))
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.True(t, from.Equal(occurrence.StartsAt))
+		require.True(t, start.Add(2*time.Hour).Equal(occurrence.EndsAt))
+	})
+
+	t.Run("long time intervals are split", func(t *testing.T) {
+		s := RecurringSilence{TimeIntervals: parseTimeIntervals(t, `[{"months": ["march"]}]`)}
+		from := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+		occurrence, ok, err := s.NextOccurrence(from, from.Add(time.Minute))
+		require.NoError(t, err)
+		require.True(t, ok)
+		require.Equal(t, maxTimeIntervalOccurrence, occurrence.EndsAt.Sub(occurrence.StartsAt))
+	})
+}
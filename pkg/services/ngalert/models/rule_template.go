@@ -0,0 +1,317 @@
+package models
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"hash/fnv"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	prommodel "github.com/prometheus/common/model"
+)
+
+// RuleTemplateParameterType is the type of the value of a rule template parameter.
+type RuleTemplateParameterType string
+
+const (
+	RuleTemplateParameterString   RuleTemplateParameterType = "string"
+	RuleTemplateParameterNumber   RuleTemplateParameterType = "number"
+	RuleTemplateParameterBoolean  RuleTemplateParameterType = "boolean"
+	RuleTemplateParameterDuration RuleTemplateParameterType = "duration"
+)
+
+var (
+	ruleTemplateParameterTypes = []RuleTemplateParameterType{
+		RuleTemplateParameterString,
+		RuleTemplateParameterNumber,
+		RuleTemplateParameterBoolean,
+		RuleTemplateParameterDuration,
+	}
+	ruleTemplateParameterNameRegexp = regexp.MustCompile(`^[a-zA-Z_][a-zA-Z0-9_]*$`)
+	// ruleTemplatePlaceholderRegexp matches the placeholders of the parameters in the rules of a template.
+	// A placeholder is escaped with an additional $, like $${name}.
+	ruleTemplatePlaceholderRegexp = regexp.MustCompile(`\$?\$\{([^}]*)\}`)
+)
+
+// RuleTemplateParameter is a parameter of a rule template.
+type RuleTemplateParameter struct {
+	Name        string
+	Type        RuleTemplateParameterType
+	Description string
+	// Default is the value of the parameter if an instance does not set it. Parameters without a default are required.
+	Default *string
+}
+
+// RuleTemplate is a rule group with parameters. Rule groups created from the template, its instances,
+// are updated when the template is updated.
+type RuleTemplate struct {
+	ID          int64
+	OrgID       int64
+	UID         string
+	Title       string
+	Description string
+	Parameters  []RuleTemplateParameter
+	// Rules are the rules of the instances. The UID of a rule identifies the rule in the template. Its title,
+	// the values of its labels and annotations, the data source UIDs and the string values of the models of
+	// its queries, and its receiver can refer to parameters with ${name}.
+	Rules   []AlertRule
+	Version int64
+	Updated time.Time
+}
+
+func (t *RuleTemplate) ResourceType() string {
+	return "ruleTemplate"
+}
+
+func (t *RuleTemplate) ResourceID() string {
+	return t.UID
+}
+
+// RuleTemplateInstance is a rule group that is created from a rule template.
+type RuleTemplateInstance struct {
+	OrgID           int64
+	NamespaceUID    string
+	RuleGroup       string
+	TemplateUID     string
+	IntervalSeconds int64
+	Values          map[string]string
+	// TemplateVersion is the version of the template that the rule group was last created from.
+	TemplateVersion int64
+	Updated         time.Time
+}
+
+func (i *RuleTemplateInstance) GetGroupKey() AlertRuleGroupKey {
+	return AlertRuleGroupKey{OrgID: i.OrgID, NamespaceUID: i.NamespaceUID, RuleGroup: i.RuleGroup}
+}
+
+// Validate checks the parameters of the template and that the rules refer only to its parameters.
+func (t *RuleTemplate) Validate() error {
+	if t.Title == "" {
+		return errors.New("title is required")
+	}
+	params := make(map[string]RuleTemplateParameter, len(t.Parameters))
+	for _, p := range t.Parameters {
+		if !ruleTemplateParameterNameRegexp.MatchString(p.Name) {
+			return fmt.Errorf("invalid parameter name %q: it must start with a letter or an underscore and contain only letters, digits and underscores", p.Name)
+		}
+		if _, ok := params[p.Name]; ok {
+			return fmt.Errorf("parameter %s is defined more than once", p.Name)
+		}
+		if !slices.Contains(ruleTemplateParameterTypes, p.Type) {
+			return fmt.Errorf("parameter %s has unknown type %q, it must be one of string, number, boolean or duration", p.Name, p.Type)
+		}
+		if p.Default != nil {
+			if _, err := parseRuleTemplateValue(p.Type, *p.Default); err != nil {
+				return fmt.Errorf("invalid default value of parameter %s: %w", p.Name, err)
+			}
+		}
+		params[p.Name] = p
+	}
+	if len(t.Rules) == 0 {
+		return errors.New("template must have at least one rule")
+	}
+	uids := make(map[string]struct{}, len(t.Rules))
+	for _, rule := range t.Rules {
+		if rule.UID == "" {
+			return fmt.Errorf("rule %s has no UID: the rules of a template must have a UID that identifies them in the template", rule.Title)
+		}
+		if _, ok := uids[rule.UID]; ok {
+			return fmt.Errorf("rule UID %s is used more than once", rule.UID)
+		}
+		uids[rule.UID] = struct{}{}
+		var err error
+		walkRuleTemplateStrings(CopyRule(&rule), func(s string) any {
+			if err == nil {
+				err = checkRuleTemplatePlaceholders(s, params)
+			}
+			return s
+		})
+		if err != nil {
+			return fmt.Errorf("rule %s: %w", rule.UID, err)
+		}
+	}
+	return nil
+}
+
+// parseRuleTemplateValue parses the value of a parameter of the given type.
+func parseRuleTemplateValue(typ RuleTemplateParameterType, value string) (any, error) {
+	switch typ {
+	case RuleTemplateParameterString:
+		return value, nil
+	case RuleTemplateParameterNumber:
+		f, err := strconv.ParseFloat(value, 64)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a number", value)
+		}
+		return f, nil
+	case RuleTemplateParameterBoolean:
+		b, err := strconv.ParseBool(value)
+		if err != nil {
+			return nil, fmt.Errorf("%q is not a boolean", value)
+		}
+		return b, nil
+	case RuleTemplateParameterDuration:
+		if _, err := prommodel.ParseDuration(value); err != nil {
+			return nil, fmt.Errorf("%q is not a duration", value)
+		}
+		return value, nil
+	default:
+		return nil, fmt.Errorf("unknown parameter type %q", typ)
+	}
+}
+
+func checkRuleTemplatePlaceholders(s string, params map[string]RuleTemplateParameter) error {
+	for _, m := range ruleTemplatePlaceholderRegexp.FindAllStringSubmatch(s, -1) {
+		if strings.HasPrefix(m[0], "$$") {
+			continue
+		}
+		if _, ok := params[m[1]]; !ok {
+			return fmt.Errorf("unknown parameter ${%s}", m[1])
+		}
+	}
+	return nil
+}
+
+// ResolveValues checks the values of an instance against the parameters of the template and returns
+// the values of all parameters, with the defaults of the parameters that the instance does not set.
+func (t *RuleTemplate) ResolveValues(values map[string]string) (map[string]string, error) {
+	resolved := make(map[string]string, len(t.Parameters))
+	for _, p := range t.Parameters {
+		v, ok := values[p.Name]
+		if !ok {
+			if p.Default == nil {
+				return nil, fmt.Errorf("missing value of required parameter %s", p.Name)
+			}
+			v = *p.Default
+		}
+		if _, err := parseRuleTemplateValue(p.Type, v); err != nil {
+			return nil, fmt.Errorf("parameter %s: %w", p.Name, err)
+		}
+		resolved[p.Name] = v
+	}
+	for name := range values {
+		if _, ok := resolved[name]; !ok {
+			return nil, fmt.Errorf("unknown parameter %s", name)
+		}
+	}
+	return resolved, nil
+}
+
+// Render returns the rules of the rule group of the instance. The UIDs of the rules are derived from
+// the rule group and the UIDs of the rules in the template, so that they do not change when the
+// template or the values change.
+func (t *RuleTemplate) Render(instance RuleTemplateInstance) ([]AlertRule, error) {
+	values, err := t.ResolveValues(instance.Values)
+	if err != nil {
+		return nil, err
+	}
+	typed := make(map[string]any, len(t.Parameters))
+	for _, p := range t.Parameters {
+		typed[p.Name], _ = parseRuleTemplateValue(p.Type, values[p.Name])
+	}
+
+	rules := make([]AlertRule, 0, len(t.Rules))
+	for i, tmpl := range t.Rules {
+		rule := CopyRule(&tmpl)
+		rule.IsPaused = tmpl.IsPaused
+		rule.Metadata = tmpl.Metadata
+		walkRuleTemplateStrings(rule, func(s string) any {
+			// A string that is only a placeholder is replaced with the typed value of the parameter,
+			// so that numbers and booleans can be set in the models of queries.
+			if m := ruleTemplatePlaceholderRegexp.FindStringSubmatch(s); m != nil && m[0] == s && !strings.HasPrefix(s, "$$") {
+				if v, ok := typed[m[1]]; ok {
+					return v
+				}
+			}
+			return expandRuleTemplateString(s, values)
+		})
+		rule.ID = 0
+		rule.UID = ruleTemplateInstanceRuleUID(instance.GetGroupKey(), tmpl.UID)
+		rule.OrgID = instance.OrgID
+		rule.NamespaceUID = instance.NamespaceUID
+		rule.RuleGroup = instance.RuleGroup
+		rule.RuleGroupIndex = i + 1
+		rule.IntervalSeconds = instance.IntervalSeconds
+		rule.Version = 0
+		rules = append(rules, *rule)
+	}
+	return rules, nil
+}
+
+func expandRuleTemplateString(s string, values map[string]string) string {
+	return ruleTemplatePlaceholderRegexp.ReplaceAllStringFunc(s, func(placeholder string) string {
+		if strings.HasPrefix(placeholder, "$$") {
+			return placeholder[1:]
+		}
+		if v, ok := values[placeholder[2:len(placeholder)-1]]; ok {
+			return v
+		}
+		return placeholder
+	})
+}
+
+// ruleTemplateInstanceRuleUID returns the UID of the rule of an instance.
+func ruleTemplateInstanceRuleUID(group AlertRuleGroupKey, templateRuleUID string) string {
+	h := fnv.New128a()
+	_, _ = h.Write([]byte(group.NamespaceUID))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(group.RuleGroup))
+	_, _ = h.Write([]byte{0})
+	_, _ = h.Write([]byte(templateRuleUID))
+	return fmt.Sprintf("%x", h.Sum(nil))
+}
+
+// walkRuleTemplateStrings replaces the strings of the rule that can refer to parameters with the result of fn.
+// The result is formatted as a string, except for the string values of the models of the queries.
+// The rule is modified in place.
+func walkRuleTemplateStrings(rule *AlertRule, fn func(string) any) {
+	str := func(s string) string {
+		if r, ok := fn(s).(string); ok {
+			return r
+		}
+		return fmt.Sprint(fn(s))
+	}
+	rule.Title = str(rule.Title)
+	for k, v := range rule.Labels {
+		rule.Labels[k] = str(v)
+	}
+	for k, v := range rule.Annotations {
+		rule.Annotations[k] = str(v)
+	}
+	for i := range rule.NotificationSettings {
+		rule.NotificationSettings[i].Receiver = str(rule.NotificationSettings[i].Receiver)
+	}
+	for i := range rule.Data {
+		rule.Data[i].DatasourceUID = str(rule.Data[i].DatasourceUID)
+		var model any
+		if err := json.Unmarshal(rule.Data[i].Model, &model); err != nil {
+			continue
+		}
+		if b, err := json.Marshal(walkJSONStrings(model, fn)); err == nil {
+			rule.Data[i].Model = b
+		}
+	}
+}
+
+func walkJSONStrings(v any, fn func(string) any) any {
+	switch t := v.(type) {
+	case string:
+		return fn(t)
+	case map[string]any:
+		for k, e := range t {
+			t[k] = walkJSONStrings(e, fn)
+		}
+		return t
+	case []any:
+		for i, e := range t {
+			t[i] = walkJSONStrings(e, fn)
+		}
+		return t
+	default:
+		return v
+	}
+}
@@ -0,0 +1,153 @@
+package models
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/util"
+)
+
+func ruleTemplateForTest() RuleTemplate {
+	rule := RuleGen.With(
+		RuleMuts.WithTitle("${service} latency"),
+		RuleMuts.WithLabels(map[string]string{"team": "${team}", "severity": "warning"}),
+		RuleMuts.WithAnnotations(map[string]string{"summary": "{{ $labels.instance }} of ${service} is slow, $${service} is not a parameter"}),
+	).Generate()
+	rule.UID = "latency"
+	rule.Data[0].Model = json.RawMessage(`{"expr":"histogram_quantile(0.99, rate(http_duration_seconds{service=\"${service}\"}[5m])) > ${threshold}","threshold":"${threshold}","enabled":"${enabled}"}`)
+	return RuleTemplate{
+		UID:   "service",
+		Title: "Service",
+		Parameters: []RuleTemplateParameter{
+			{Name: "service", Type: RuleTemplateParameterString},
+			{Name: "team", Type: RuleTemplateParameterString, Default: util.Pointer("platform")},
+			{Name: "threshold", Type: RuleTemplateParameterNumber, Default: util.Pointer("0.5")},
+			{Name: "enabled", Type: RuleTemplateParameterBoolean, Default: util.Pointer("true")},
+		},
+		Rules: []AlertRule{rule},
+	}
+}
+
+func TestRuleTemplateValidate(t *testing.T) {
+	testCases := []struct {
+		name   string
+		mutate func(t *RuleTemplate)
+		err    string
+	}{
+		{
+			name:   "valid template",
+			mutate: func(t *RuleTemplate) {},
+		},
+		{
+			name:   "missing title",
+			mutate: func(t *RuleTemplate) { t.Title = "" },
+			err:    "title is required",
+		},
+		{
+			name:   "invalid parameter name",
+			mutate: func(t *RuleTemplate) { t.Parameters[0].Name = "1service" },
+			err:    `invalid parameter name "1service"`,
+		},
+		{
+			name:   "duplicate parameter",
+			mutate: func(t *RuleTemplate) { t.Parameters[1].Name = "service" },
+			err:    "parameter service is defined more than once",
+		},
+		{
+			name:   "unknown parameter type",
+			mutate: func(t *RuleTemplate) { t.Parameters[0].Type = "list" },
+			err:    `parameter service has unknown type "list"`,
+		},
+		{
+			name:   "invalid default",
+			mutate: func(t *RuleTemplate) { t.Parameters[2].Default = util.Pointer("high") },
+			err:    "invalid default value of parameter threshold",
+		},
+		{
+			name:   "no rules",
+			mutate: func(t *RuleTemplate) { t.Rules = nil },
+			err:    "template must have at least one rule",
+		},
+		{
+			name:   "rule without UID",
+			mutate: func(t *RuleTemplate) { t.Rules[0].UID = "" },
+			err:    "has no UID",
+		},
+		{
+			name:   "unknown parameter",
+			mutate: func(t *RuleTemplate) { t.Rules[0].Labels["env"] = "${env}" },
+			err:    "rule latency: unknown parameter ${env}",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			tmpl := ruleTemplateForTest()
+			tc.mutate(&tmpl)
+			err := tmpl.Validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestRuleTemplateRender(t *testing.T) {
+	tmpl := ruleTemplateForTest()
+	require.NoError(t, tmpl.Validate())
+	instance := RuleTemplateInstance{
+		OrgID:           1,
+		NamespaceUID:    "folder",
+		RuleGroup:       "checkout",
+		IntervalSeconds: 30,
+		Values:          map[string]string{"service": "checkout", "threshold": "0.9"},
+	}
+
+	rules, err := tmpl.Render(instance)
+	require.NoError(t, err)
+	require.Len(t, rules, 1)
+	rule := rules[0]
+	assert.Equal(t, "checkout latency", rule.Title)
+	assert.Equal(t, map[string]string{"team": "platform", "severity": "warning"}, rule.Labels)
+	assert.Equal(t, "{{ $labels.instance }} of checkout is slow, ${service} is not a parameter", rule.Annotations["summary"])
+	assert.JSONEq(t, `{"expr":"histogram_quantile(0.99, rate(http_duration_seconds{service=\"checkout\"}[5m])) > 0.9","threshold":0.9,"enabled":true}`, string(rule.Data[0].Model))
+	assert.Equal(t, "folder", rule.NamespaceUID)
+	assert.Equal(t, "checkout", rule.RuleGroup)
+	assert.Equal(t, 1, rule.RuleGroupIndex)
+	assert.EqualValues(t, 30, rule.IntervalSeconds)
+	assert.Len(t, rule.UID, 32)
+
+	// The template is not modified.
+	assert.Equal(t, "${service} latency", tmpl.Rules[0].Title)
+	assert.Equal(t, "${team}", tmpl.Rules[0].Labels["team"])
+
+	t.Run("UIDs should depend only on the rule group and the rule of the template", func(t *testing.T) {
+		changed := instance
+		changed.Values = map[string]string{"service": "cart"}
+		again, err := tmpl.Render(changed)
+		require.NoError(t, err)
+		assert.Equal(t, rule.UID, again[0].UID)
+
+		changed.RuleGroup = "cart"
+		other, err := tmpl.Render(changed)
+		require.NoError(t, err)
+		assert.NotEqual(t, rule.UID, other[0].UID)
+	})
+
+	t.Run("should fail on invalid values", func(t *testing.T) {
+		for name, values := range map[string]map[string]string{
+			"missing value of required parameter service": {},
+			"unknown parameter env":                       {"service": "checkout", "env": "prod"},
+			`parameter threshold: "high" is not a number`: {"service": "checkout", "threshold": "high"},
+		} {
+			invalid := instance
+			invalid.Values = values
+			_, err := tmpl.Render(invalid)
+			require.ErrorContains(t, err, name)
+		}
+	})
+}
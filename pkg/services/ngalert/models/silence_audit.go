@@ -0,0 +1,133 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+)
+
+// SilenceAuditAction is the change of a silence that is recorded in the audit log.
+type SilenceAuditAction string
+
+const (
+	SilenceAuditActionCreate SilenceAuditAction = "create"
+	SilenceAuditActionUpdate SilenceAuditAction = "update"
+	SilenceAuditActionExpire SilenceAuditAction = "expire"
+)
+
+// MaxSilenceAuditAlerts limits the number of matched alerts that are recorded with a silence.
+const MaxSilenceAuditAlerts = 100
+
+// SilenceAuditEntry is a change of a silence. The actor is empty if the change is made by Grafana itself.
+type SilenceAuditEntry struct {
+	ID         int64
+	OrgID      int64
+	SilenceID  string
+	Action     SilenceAuditAction
+	ActorUID   string
+	ActorLogin string
+	Created    time.Time
+	// Silence is the silence after the change. For an expired silence, it is the silence before it expired.
+	Silence Silence
+	// Changes are the changed fields of an updated silence.
+	Changes []SilenceChange
+	// MatchedAlerts are the labels of the alerts that the silence matched when it was created.
+	MatchedAlerts []map[string]string
+}
+
+// SilenceChange is the change of a field of a silence.
+type SilenceChange struct {
+	Field string
+	Old   string
+	New   string
+}
+
+// ListSilenceAuditEntriesQuery is the query for the audit log of silences. Empty fields are not filtered on.
+type ListSilenceAuditEntriesQuery struct {
+	OrgID      int64
+	SilenceID  string
+	ActorLogin string
+	From       time.Time
+	To         time.Time
+	Limit      int
+	// Offset skips the given number of the most recent entries. It is used to read the entries in pages.
+	Offset int
+}
+
+// DiffSilences returns the fields that differ between the old and the new silence.
+func DiffSilences(old, new Silence) []SilenceChange {
+	var changes []SilenceChange
+	add := func(field, o, n string) {
+		if o != n {
+			changes = append(changes, SilenceChange{Field: field, Old: o, New: n})
+		}
+	}
+	add("id", stringValue(old.ID), stringValue(new.ID))
+	add("matchers", MatchersString(old.Matchers), MatchersString(new.Matchers))
+	add("startsAt", timeValue(old.StartsAt), timeValue(new.StartsAt))
+	add("endsAt", timeValue(old.EndsAt), timeValue(new.EndsAt))
+	add("createdBy", stringValue(old.CreatedBy), stringValue(new.CreatedBy))
+	add("comment", stringValue(old.Comment), stringValue(new.Comment))
+	return changes
+}
+
+// MatchersString returns the matchers in the format of the filter of the Alertmanager API, for example
+// {alertname="Foo", severity=~"critical|warning"}.
+func MatchersString(matchers amv2.Matchers) string {
+	return "{" + strings.Join(MatcherFilters(matchers), ", ") + "}"
+}
+
+// MatcherFilters returns every matcher in the format of the filter of the Alertmanager API.
+func MatcherFilters(matchers amv2.Matchers) []string {
+	result := make([]string, 0, len(matchers))
+	for _, m := range matchers {
+		if m == nil || m.Name == nil || m.Value == nil {
+			continue
+		}
+		result = append(result, fmt.Sprintf("%s%s%q", *m.Name, matchType(*m), *m.Value))
+	}
+	return result
+}
+
+func stringValue(s *string) string {
+	if s == nil {
+		return ""
+	}
+	return *s
+}
+
+func timeValue(t *strfmt.DateTime) string {
+	if t == nil {
+		return ""
+	}
+	return t.String()
+}
+
+// SilenceExpiryNotification is the notification that a silence expires soon. It is sent to a receiver of the
+// Alertmanager of the organization.
+type SilenceExpiryNotification struct {
+	ID        int64
+	OrgID     int64
+	SilenceID string
+	Receiver  string
+	// Before is how long before the silence expires the notification is sent.
+	Before time.Duration
+	// NotifiedEndsAt is the end of the silence when the last notification was sent. If the silence is extended, the
+	// notification is sent again.
+	NotifiedEndsAt time.Time
+}
+
+// Validate returns an error if the notification cannot be sent.
+func (n SilenceExpiryNotification) Validate() error {
+	if n.Receiver == "" {
+		return errors.New("receiver is required")
+	}
+	if n.Before <= 0 {
+		return errors.New("before must be greater than zero")
+	}
+	return nil
+}
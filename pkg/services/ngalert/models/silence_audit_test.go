@@ -0,0 +1,50 @@
+package models
+
+import (
+	"testing"
+	"time"
+
+	"github.com/go-openapi/strfmt"
+	"github.com/prometheus/alertmanager/pkg/labels"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/util"
+)
+
+func TestDiffSilences(t *testing.T) {
+	old := SilenceGen(SilenceMuts.WithMatcher("severity", "critical|warning", labels.MatchRegexp))()
+
+	t.Run("no changes", func(t *testing.T) {
+		require.Empty(t, DiffSilences(old, CopySilenceWith(old)))
+	})
+
+	t.Run("changed fields", func(t *testing.T) {
+		endsAt := strfmt.DateTime(time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC))
+		updated := CopySilenceWith(old, func(s *Silence) {
+			s.Comment = util.Pointer("Extended")
+			s.EndsAt = &endsAt
+		})
+		changes := DiffSilences(old, updated)
+		require.Equal(t, []SilenceChange{
+			{Field: "endsAt", Old: old.EndsAt.String(), New: endsAt.String()},
+			{Field: "comment", Old: *old.Comment, New: "Extended"},
+		}, changes)
+	})
+
+	t.Run("changed matchers", func(t *testing.T) {
+		updated := CopySilenceWith(old, SilenceMuts.WithMatcher("team", "db", labels.MatchNotEqual))
+		changes := DiffSilences(old, updated)
+		require.Len(t, changes, 1)
+		require.Equal(t, "matchers", changes[0].Field)
+		require.Equal(t, MatchersString(old.Matchers), changes[0].Old)
+		require.Contains(t, changes[0].New, `severity=~"critical|warning", team!="db"}`)
+	})
+}
+
+func TestMatcherFilters(t *testing.T) {
+	s := Silence{}
+	SilenceMuts.WithMatcher("alertname", "Foo", labels.MatchEqual)(&s)
+	SilenceMuts.WithMatcher("team", "d.*", labels.MatchNotRegexp)(&s)
+	require.Equal(t, []string{`alertname="Foo"`, `team!~"d.*"`}, MatcherFilters(s.Matchers))
+	require.Equal(t, `{alertname="Foo", team!~"d.*"}`, MatchersString(s.Matchers))
+}
@@ -0,0 +1,115 @@
+package models
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+)
+
+// TemplateFixture is a named set of alerts that notification templates are rendered with, together with the outputs
+// that the templates are expected to render.
+type TemplateFixture struct {
+	ID           int64
+	OrgID        int64
+	Name         string
+	Alerts       []*amv2.PostableAlert
+	Expectations []TemplateExpectation
+	Updated      time.Time
+}
+
+// TemplateExpectation is the expected output of a template definition rendered with the alerts of a fixture.
+type TemplateExpectation struct {
+	// Template is the name of the notification template.
+	Template string
+	// Definition is the name of the template defined in the notification template with {{ define }}.
+	Definition string
+	Output     string
+}
+
+func (f TemplateFixture) Validate() error {
+	if f.Name == "" {
+		return errors.New("name must not be empty")
+	}
+	if len(f.Alerts) == 0 {
+		return errors.New("fixture must have at least one alert")
+	}
+	seen := make(map[TemplateExpectation]struct{}, len(f.Expectations))
+	for i, e := range f.Expectations {
+		if e.Template == "" {
+			return fmt.Errorf("expectation %d: template must not be empty", i)
+		}
+		if e.Definition == "" {
+			return fmt.Errorf("expectation %d: definition must not be empty", i)
+		}
+		key := TemplateExpectation{Template: e.Template, Definition: e.Definition}
+		if _, ok := seen[key]; ok {
+			return fmt.Errorf("expectation %d: duplicate expectation for definition %s of template %s", i, e.Definition, e.Template)
+		}
+		seen[key] = struct{}{}
+	}
+	return nil
+}
+
+// TemplateFixtureResult is the result of rendering a template definition with the alerts of a fixture.
+type TemplateFixtureResult struct {
+	Fixture    string
+	Template   string
+	Definition string
+	Expected   string
+	Actual     string
+	// Diff is the line diff between the expected and the actual output. It is empty if they are equal.
+	Diff string
+	// Error is the error that prevented the definition from being rendered.
+	Error string
+}
+
+func (r TemplateFixtureResult) Passed() bool {
+	return r.Error == "" && r.Diff == ""
+}
+
+// DiffLines returns the line diff between the expected and the actual text. Lines only in the expected text are
+// prefixed with "-", lines only in the actual text with "+", and common lines with a space. It returns an empty
+// string if both texts are equal.
+func DiffLines(expected, actual string) string {
+	if expected == actual {
+		return ""
+	}
+	a := strings.Split(expected, "\n")
+	b := strings.Split(actual, "\n")
+
+	// lcs[i][j] is the length of the longest common subsequence of a[i:] and b[j:].
+	lcs := make([][]int, len(a)+1)
+	for i := range lcs {
+		lcs[i] = make([]int, len(b)+1)
+	}
+	for i := len(a) - 1; i >= 0; i-- {
+		for j := len(b) - 1; j >= 0; j-- {
+			if a[i] == b[j] {
+				lcs[i][j] = lcs[i+1][j+1] + 1
+			} else {
+				lcs[i][j] = max(lcs[i+1][j], lcs[i][j+1])
+			}
+		}
+	}
+
+	var sb strings.Builder
+	i, j := 0, 0
+	for i < len(a) || j < len(b) {
+		switch {
+		case i < len(a) && j < len(b) && a[i] == b[j]:
+			sb.WriteString(" " + a[i] + "\n")
+			i++
+			j++
+		case i < len(a) && (j == len(b) || lcs[i+1][j] >= lcs[i][j+1]):
+			sb.WriteString("-" + a[i] + "\n")
+			i++
+		default:
+			sb.WriteString("+" + b[j] + "\n")
+			j++
+		}
+	}
+	return sb.String()
+}
@@ -0,0 +1,63 @@
+package models
+
+import (
+	"testing"
+
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestTemplateFixtureValidate(t *testing.T) {
+	alerts := []*amv2.PostableAlert{{Alert: amv2.Alert{Labels: amv2.LabelSet{"alertname": "Test"}}}}
+	testCases := []struct {
+		name    string
+		fixture TemplateFixture
+		err     string
+	}{
+		{
+			name:    "valid fixture",
+			fixture: TemplateFixture{Name: "test", Alerts: alerts, Expectations: []TemplateExpectation{{Template: "slack", Definition: "slack.title", Output: "title"}}},
+		},
+		{
+			name:    "empty name",
+			fixture: TemplateFixture{Alerts: alerts},
+			err:     "name must not be empty",
+		},
+		{
+			name:    "no alerts",
+			fixture: TemplateFixture{Name: "test"},
+			err:     "at least one alert",
+		},
+		{
+			name:    "expectation without definition",
+			fixture: TemplateFixture{Name: "test", Alerts: alerts, Expectations: []TemplateExpectation{{Template: "slack"}}},
+			err:     "definition must not be empty",
+		},
+		{
+			name: "duplicate expectations",
+			fixture: TemplateFixture{Name: "test", Alerts: alerts, Expectations: []TemplateExpectation{
+				{Template: "slack", Definition: "slack.title", Output: "a"},
+				{Template: "slack", Definition: "slack.title", Output: "b"},
+			}},
+			err: "duplicate expectation",
+		},
+	}
+	for _, tc := range testCases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.fixture.Validate()
+			if tc.err == "" {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+}
+
+func TestDiffLines(t *testing.T) {
+	assert.Empty(t, DiffLines("a\nb", "a\nb"))
+	assert.Equal(t, " a\n-b\n+c\n d\n", DiffLines("a\nb\nd", "a\nc\nd"))
+	assert.Equal(t, " a\n+b\n", DiffLines("a", "a\nb"))
+	assert.Equal(t, "-a\n+\n", DiffLines("a", ""))
+}
@@ -320,6 +320,12 @@ func (a *AlertRuleMutators) WithFor(duration time.Duration) AlertRuleMutator {
 	}
 }
 
+func (a *AlertRuleMutators) WithKeepFiringFor(duration time.Duration) AlertRuleMutator {
+	return func(rule *AlertRule) {
+		rule.KeepFiringFor = duration
+	}
+}
+
 func (a *AlertRuleMutators) WithForNTimes(timesOfInterval int64) AlertRuleMutator {
 	return func(rule *AlertRule) {
 		rule.For = time.Duration(rule.IntervalSeconds*timesOfInterval) * time.Second
@@ -493,6 +499,12 @@ func (a *AlertRuleMutators) WithNotificationSettings(ns NotificationSettings) Al
 	}
 }
 
+func (a *AlertRuleMutators) WithEvaluationPolicy(policy *EvaluationPolicy) AlertRuleMutator {
+	return func(rule *AlertRule) {
+		rule.EvaluationPolicy = policy
+	}
+}
+
 func (a *AlertRuleMutators) WithNoNotificationSettings() AlertRuleMutator {
 	return func(rule *AlertRule) {
 		rule.NotificationSettings = nil
@@ -627,6 +639,7 @@ func CopyRule(r *AlertRule, mutators ...AlertRuleMutator) *AlertRule {
 		NoDataState:     r.NoDataState,
 		ExecErrState:    r.ExecErrState,
 		For:             r.For,
+		KeepFiringFor:   r.KeepFiringFor,
 		Record:          r.Record,
 	}
 
@@ -676,6 +689,11 @@ func CopyRule(r *AlertRule, mutators ...AlertRuleMutator) *AlertRule {
 		result.NotificationSettings = append(result.NotificationSettings, CopyNotificationSettings(s))
 	}
 
+	if r.EvaluationPolicy != nil {
+		p := *r.EvaluationPolicy
+		result.EvaluationPolicy = &p
+	}
+
 	if len(mutators) > 0 {
 		for _, mutator := range mutators {
 			mutator(&result)
@@ -1343,6 +1361,7 @@ func ConvertToRecordingRule(rule *AlertRule) {
 	rule.NoDataState = ""
 	rule.ExecErrState = ""
 	rule.For = 0
+	rule.KeepFiringFor = 0
 	rule.NotificationSettings = nil
 }
 
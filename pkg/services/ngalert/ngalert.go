@@ -7,6 +7,7 @@ import (
 	"time"
 
 	"github.com/benbjohnson/clock"
+	"github.com/google/uuid"
 	"github.com/prometheus/alertmanager/featurecontrol"
 	"github.com/prometheus/alertmanager/matchers/compat"
 	"golang.org/x/sync/errgroup"
@@ -40,6 +41,7 @@ import (
 	"github.com/grafana/grafana/pkg/services/ngalert/remote"
 	"github.com/grafana/grafana/pkg/services/ngalert/schedule"
 	"github.com/grafana/grafana/pkg/services/ngalert/sender"
+	"github.com/grafana/grafana/pkg/services/ngalert/sharding"
 	"github.com/grafana/grafana/pkg/services/ngalert/state"
 	"github.com/grafana/grafana/pkg/services/ngalert/state/historian"
 	"github.com/grafana/grafana/pkg/services/ngalert/store"
@@ -140,6 +142,8 @@ type AlertNG struct {
 	RecordingWriter     schedule.RecordingWriter
 	schedule            schedule.ScheduleService
 	stateManager        *state.Manager
+	sharder             *sharding.Sharder
+	stateHistorian      Historian
 	folderService       folder.Service
 	dashboardService    dashboards.DashboardService
 	Api                 *api.API
@@ -148,6 +152,9 @@ type AlertNG struct {
 	// Alerting notification services
 	MultiOrgAlertmanager *notifier.MultiOrgAlertmanager
 	AlertsRouter         *sender.AlertsRouter
+	recurringSilences    *notifier.RecurringSilenceService
+	silenceExpiry        *notifier.SilenceExpiryNotificationService
+	deliveryLog          *notifier.NotificationDeliveryLog
 	accesscontrol        accesscontrol.AccessControl
 	AccesscontrolService accesscontrol.Service
 	ResourcePermissions  accesscontrol.ReceiverPermissionsService
@@ -309,8 +316,13 @@ func (ng *AlertNG) init() error {
 		}
 	}
 
-	decryptFn := ng.SecretsService.GetDecryptedValue
 	multiOrgMetrics := ng.Metrics.GetMultiOrgAlertmanagerMetrics()
+
+	// Record the attempts of the integrations of the internal Alertmanagers to send notifications.
+	ng.deliveryLog = notifier.NewNotificationDeliveryLog(ng.store, ng.Cfg.UnifiedAlerting.NotificationDeliveryLogRetention, multiOrgMetrics.NotificationDeliveriesDropped, clock.New(), log.New("ngalert.notifier.delivery-log"))
+	overrides = append(overrides, notifier.WithNotificationDeliveryLog(ng.deliveryLog))
+
+	decryptFn := ng.SecretsService.GetDecryptedValue
 	moa, err := notifier.NewMultiOrgAlertmanager(
 		ng.Cfg,
 		ng.store,
@@ -367,12 +379,19 @@ func (ng *AlertNG) init() error {
 		// Force-disable the feature if the feature toggle is not on - sets us up for feature toggle removal.
 		ng.Cfg.UnifiedAlerting.RecordingRules.Enabled = false
 	}
-	recordingWriter, err := createRecordingWriter(ng.FeatureToggles, ng.Cfg.UnifiedAlerting.RecordingRules, ng.httpClientProvider, clk, ng.Metrics.GetRemoteWriterMetrics())
+	recordingWriter, err := createRecordingWriter(ng.FeatureToggles, ng.Cfg.UnifiedAlerting.RecordingRules, ng.httpClientProvider, ng.SQLStore, clk, ng.Metrics.GetRemoteWriterMetrics())
 	if err != nil {
 		return fmt.Errorf("failed to initialize recording writer: %w", err)
 	}
 	ng.RecordingWriter = recordingWriter
 
+	if ng.Cfg.UnifiedAlerting.HARuleShardingEnabled {
+		ng.sharder, err = createSharder(ng.Cfg.UnifiedAlerting, ng.store, ng.Metrics.GetSchedulerMetrics())
+		if err != nil {
+			return fmt.Errorf("failed to initialize sharding of alert rules: %w", err)
+		}
+	}
+
 	schedCfg := schedule.SchedulerCfg{
 		MaxAttempts:          ng.Cfg.UnifiedAlerting.MaxAttempts,
 		C:                    clk,
@@ -380,6 +399,7 @@ func (ng *AlertNG) init() error {
 		MinRuleInterval:      ng.Cfg.UnifiedAlerting.MinInterval,
 		DisableGrafanaFolder: ng.Cfg.UnifiedAlerting.ReservedLabels.IsReservedLabelDisabled(models.FolderTitleLabel),
 		JitterEvaluations:    schedule.JitterStrategyFrom(ng.Cfg.UnifiedAlerting, ng.FeatureToggles),
+		SequentialRuleGroups: ng.Cfg.UnifiedAlerting.SequentialRuleGroupEvaluation,
 		AppURL:               appUrl,
 		EvaluatorFactory:     evalFactory,
 		RuleStore:            ng.store,
@@ -390,14 +410,18 @@ func (ng *AlertNG) init() error {
 		Log:                  log.New("ngalert.scheduler"),
 		RecordingWriter:      ng.RecordingWriter,
 	}
+	if ng.sharder != nil {
+		schedCfg.Sharder = ng.sharder
+	}
 
 	// There are a set of feature toggles available that act as short-circuits for common configurations.
 	// If any are set, override the config accordingly.
 	ApplyStateHistoryFeatureToggles(&ng.Cfg.UnifiedAlerting.StateHistory, ng.FeatureToggles, ng.Log)
-	history, err := configureHistorianBackend(initCtx, ng.Cfg.UnifiedAlerting.StateHistory, ng.annotationsRepo, ng.dashboardService, ng.store, ng.Metrics.GetHistorianMetrics(), ng.Log, ng.tracer, ac.NewRuleService(ng.accesscontrol))
+	history, err := configureHistorianBackend(initCtx, ng.Cfg.UnifiedAlerting.StateHistory, ng.annotationsRepo, ng.dashboardService, ng.store, ng.SQLStore, ng.Metrics.GetHistorianMetrics(), ng.Log, ng.tracer, ac.NewRuleService(ng.accesscontrol))
 	if err != nil {
 		return err
 	}
+	ng.stateHistorian = history
 	cfg := state.ManagerCfg{
 		Metrics:                        ng.Metrics.GetStateMetrics(),
 		ExternalURL:                    appUrl,
@@ -418,7 +442,13 @@ func (ng *AlertNG) init() error {
 	statePersister := state.NewSyncStatePersisiter(logger, cfg)
 	if ng.FeatureToggles.IsEnabledGlobally(featuremgmt.FlagAlertingSaveStatePeriodic) {
 		ticker := clock.New().Ticker(ng.Cfg.UnifiedAlerting.StatePeriodicSaveInterval)
-		statePersister = state.NewAsyncStatePersister(logger, ticker, cfg)
+		if ng.sharder != nil {
+			// The cache of this replica only contains the state of the rules that are evaluated by this replica,
+			// so the state of the other rules must not be replaced.
+			statePersister = state.NewAsyncRuleStatePersister(logger, ticker, cfg)
+		} else {
+			statePersister = state.NewAsyncStatePersister(logger, ticker, cfg)
+		}
 	}
 	stateManager := state.NewManager(cfg, statePersister)
 	scheduler := schedule.NewScheduler(schedCfg, stateManager)
@@ -458,13 +488,19 @@ func (ng *AlertNG) init() error {
 	// Provisioning
 	policyService := provisioning.NewNotificationPolicyService(configStore, ng.store, ng.store, ng.Cfg.UnifiedAlerting, ng.Log)
 	contactPointService := provisioning.NewContactPointService(configStore, ng.SecretsService, ng.store, ng.store, provisioningReceiverService, ng.Log, ng.store, ng.ResourcePermissions)
-	templateService := provisioning.NewTemplateService(configStore, ng.store, ng.store, ng.Log)
+	templateFixtureService := provisioning.NewTemplateFixtureService(ng.store, configStore, ng.MultiOrgAlertmanager, ng.Log)
+	templateService := provisioning.NewTemplateService(configStore, ng.store, ng.store, ng.Log, templateFixtureService)
 	muteTimingService := provisioning.NewMuteTimingService(configStore, ng.store, ng.store, ng.Log, ng.store)
 	alertRuleService := provisioning.NewAlertRuleService(ng.store, ng.store, ng.folderService, ng.QuotaService, ng.store,
 		int64(ng.Cfg.UnifiedAlerting.DefaultRuleEvaluationInterval.Seconds()),
 		int64(ng.Cfg.UnifiedAlerting.BaseInterval.Seconds()),
 		ng.Cfg.UnifiedAlerting.RulesPerRuleGroupLimit, ng.Log, notifier.NewNotificationSettingsValidationService(ng.store),
 		ac.NewRuleService(ng.accesscontrol))
+	ruleTemplateService := provisioning.NewRuleTemplateService(ng.store, ng.store, ng.store, alertRuleService, ng.Log)
+	ng.silenceExpiry = notifier.NewSilenceExpiryNotificationService(ac.NewSilenceService(ng.accesscontrol, ng.store), ng.store, ng.MultiOrgAlertmanager, ng.MultiOrgAlertmanager, clk, ng.Log)
+	silenceStore := ng.silenceExpiry.TrackSilences(notifier.NewAuditedSilenceStore(ng.MultiOrgAlertmanager, ng.store, ng.MultiOrgAlertmanager, ng.Log))
+	ng.recurringSilences = notifier.NewRecurringSilenceService(ac.NewSilenceService(ng.accesscontrol, ng.store), ng.store, silenceStore, clk, ng.Log)
+	deliveryService := notifier.NewNotificationDeliveryService(ng.store, ng.MultiOrgAlertmanager, ng.Log)
 
 	ng.Api = &api.API{
 		Cfg:                  ng.Cfg,
@@ -486,8 +522,15 @@ func (ng *AlertNG) init() error {
 		ReceiverService:      receiverService,
 		ContactPointService:  contactPointService,
 		Templates:            templateService,
+		TemplateFixtures:     templateFixtureService,
 		MuteTimings:          muteTimingService,
 		AlertRules:           alertRuleService,
+		RuleTemplates:        ruleTemplateService,
+		RecurringSilences:    ng.recurringSilences,
+		SilenceStore:         silenceStore,
+		SilenceAuditStore:    ng.store,
+		SilenceExpiry:        ng.silenceExpiry,
+		Deliveries:           deliveryService,
 		AlertsRouter:         alertsRouter,
 		EvaluatorFactory:     evalFactory,
 		ConditionValidator:   conditionValidator,
@@ -541,6 +584,20 @@ func (ng *AlertNG) Run(ctx context.Context) error {
 	children.Go(func() error {
 		return ng.AlertsRouter.Run(subCtx)
 	})
+	children.Go(func() error {
+		return ng.recurringSilences.Run(subCtx)
+	})
+	children.Go(func() error {
+		return ng.silenceExpiry.Run(subCtx)
+	})
+	children.Go(func() error {
+		return ng.deliveryLog.Run(subCtx)
+	})
+	if r, ok := ng.stateHistorian.(historian.Runner); ok {
+		children.Go(func() error {
+			return r.Run(subCtx)
+		})
+	}
 
 	if ng.Cfg.UnifiedAlerting.ExecuteAlerts {
 		// Only Warm() the state manager if we are actually executing alerts.
@@ -554,12 +611,25 @@ func (ng *AlertNG) Run(ctx context.Context) error {
 		//
 		ng.stateManager.Warm(ctx, ng.store, ng.store)
 
+		if ng.sharder != nil {
+			// The replicas must be known before the first tick of the scheduler, otherwise this replica evaluates all rules.
+			ng.sharder.Sync(ctx)
+			children.Go(func() error {
+				return ng.sharder.Run(subCtx)
+			})
+		}
+
 		children.Go(func() error {
 			return ng.schedule.Run(subCtx)
 		})
 		children.Go(func() error {
 			return ng.stateManager.Run(subCtx)
 		})
+		if w, ok := ng.RecordingWriter.(*writer.SQLWriter); ok {
+			children.Go(func() error {
+				return w.Run(subCtx)
+			})
+		}
 	}
 	return children.Wait()
 }
@@ -584,7 +654,7 @@ type Historian interface {
 	state.Historian
 }
 
-func configureHistorianBackend(ctx context.Context, cfg setting.UnifiedAlertingStateHistorySettings, ar annotations.Repository, ds dashboards.DashboardService, rs historian.RuleStore, met *metrics.Historian, l log.Logger, tracer tracing.Tracer, ac historian.AccessControl) (Historian, error) {
+func configureHistorianBackend(ctx context.Context, cfg setting.UnifiedAlertingStateHistorySettings, ar annotations.Repository, ds dashboards.DashboardService, rs historian.RuleStore, sqlStore db.DB, met *metrics.Historian, l log.Logger, tracer tracing.Tracer, ac historian.AccessControl) (Historian, error) {
 	if !cfg.Enabled {
 		met.Info.WithLabelValues("noop").Set(0)
 		return historian.NewNopHistorian(), nil
@@ -599,7 +669,7 @@ func configureHistorianBackend(ctx context.Context, cfg setting.UnifiedAlertingS
 	if backend == historian.BackendTypeMultiple {
 		primaryCfg := cfg
 		primaryCfg.Backend = cfg.MultiPrimary
-		primary, err := configureHistorianBackend(ctx, primaryCfg, ar, ds, rs, met, l, tracer, ac)
+		primary, err := configureHistorianBackend(ctx, primaryCfg, ar, ds, rs, sqlStore, met, l, tracer, ac)
 		if err != nil {
 			return nil, fmt.Errorf("multi-backend target \"%s\" was misconfigured: %w", cfg.MultiPrimary, err)
 		}
@@ -608,7 +678,7 @@ func configureHistorianBackend(ctx context.Context, cfg setting.UnifiedAlertingS
 		for _, b := range cfg.MultiSecondaries {
 			secCfg := cfg
 			secCfg.Backend = b
-			sec, err := configureHistorianBackend(ctx, secCfg, ar, ds, rs, met, l, tracer, ac)
+			sec, err := configureHistorianBackend(ctx, secCfg, ar, ds, rs, sqlStore, met, l, tracer, ac)
 			if err != nil {
 				return nil, fmt.Errorf("multi-backend target \"%s\" was miconfigured: %w", b, err)
 			}
@@ -639,6 +709,10 @@ func configureHistorianBackend(ctx context.Context, cfg setting.UnifiedAlertingS
 		}
 		return backend, nil
 	}
+	if backend == historian.BackendTypeSQL {
+		sqlBackendLogger := log.New("ngalert.state.historian", "backend", "sql")
+		return historian.NewSQLBackend(sqlBackendLogger, sqlStore, cfg.SQLRetention, cfg.ExternalLabels, met, rs, ac), nil
+	}
 
 	return nil, fmt.Errorf("unrecognized state history backend: %s", backend)
 }
@@ -692,12 +766,58 @@ func createRemoteAlertmanager(cfg remote.AlertmanagerConfig, kvstore kvstore.KVS
 	return remote.NewAlertmanager(cfg, notifier.NewFileStore(cfg.OrgID, kvstore), decryptFn, autogenFn, m, tracer)
 }
 
-func createRecordingWriter(featureToggles featuremgmt.FeatureToggles, settings setting.RecordingRuleSettings, httpClientProvider httpclient.Provider, clock clock.Clock, m *metrics.RemoteWriter) (schedule.RecordingWriter, error) {
+// createSharder returns the sharder of the alert rules. The replicas are coordinated through Redis
+// if it is configured for the Alertmanager, otherwise through the database.
+func createSharder(cfg setting.UnifiedAlertingSettings, st *store.DBstore, m *metrics.Scheduler) (*sharding.Sharder, error) {
+	logger := log.New("ngalert.sharding")
+	name := "replica-" + uuid.New().String()
+	if cfg.HARedisPeerName != "" {
+		name = cfg.HARedisPeerName
+	}
+
+	var membership sharding.Membership = sharding.NewDatabaseMembership(st, cfg.HARuleShardingHeartbeatTimeout)
+	if cfg.HARedisAddr != "" {
+		redisMembership, err := sharding.NewRedisMembership(sharding.RedisConfig{
+			Addr:        cfg.HARedisAddr,
+			Username:    cfg.HARedisUsername,
+			Password:    cfg.HARedisPassword,
+			DB:          cfg.HARedisDB,
+			Prefix:      cfg.HARedisPrefix,
+			MaxConns:    cfg.HARedisMaxConns,
+			ClusterMode: cfg.HARedisClusterModeEnabled,
+			TLSEnabled:  cfg.HARedisTLSEnabled,
+			TLS:         cfg.HARedisTLSConfig,
+		}, cfg.HARuleShardingHeartbeatTimeout)
+		if err != nil {
+			return nil, err
+		}
+		membership = redisMembership
+	}
+	logger.Info("Sharding alert rules across replicas", "replica", name, "redis", cfg.HARedisAddr != "")
+	return sharding.NewSharder(name, membership, cfg.HARuleShardingHeartbeatInterval, cfg.HARuleShardingHeartbeatTimeout, m.ShardReplicas, logger), nil
+}
+
+func createRecordingWriter(featureToggles featuremgmt.FeatureToggles, settings setting.RecordingRuleSettings, httpClientProvider httpclient.Provider, sqlStore db.DB, clock clock.Clock, m *metrics.RemoteWriter) (schedule.RecordingWriter, error) {
 	logger := log.New("ngalert.writer")
 
-	if settings.Enabled {
-		return writer.NewPrometheusWriter(settings, httpClientProvider, clock, logger, m)
+	if !settings.Enabled {
+		return writer.NoopWriter{}, nil
 	}
 
-	return writer.NoopWriter{}, nil
+	backend, err := writer.ParseBackendType(settings.Backend)
+	if err != nil {
+		return nil, err
+	}
+
+	logger = logger.New("backend", backend)
+	switch backend {
+	case writer.BackendTypeInfluxDB:
+		return writer.NewInfluxDBWriter(settings, httpClientProvider, clock, logger, m)
+	case writer.BackendTypeOTLP:
+		return writer.NewOTLPWriter(settings, httpClientProvider, clock, logger, m)
+	case writer.BackendTypeSQL:
+		return writer.NewSQLWriter(sqlStore, settings.SQLRetention, clock, logger, m), nil
+	default:
+		return writer.NewPrometheusWriter(settings, httpClientProvider, clock, logger, m)
+	}
 }
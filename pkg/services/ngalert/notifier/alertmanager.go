@@ -5,14 +5,19 @@ import (
 	"crypto/md5"
 	"encoding/binary"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"strconv"
+	"strings"
 	"time"
 
 	alertingNotify "github.com/grafana/alerting/notify"
 	"github.com/grafana/alerting/receivers"
 	alertingTemplates "github.com/grafana/alerting/templates"
 	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
 
 	amv2 "github.com/prometheus/alertmanager/api/v2/models"
 
@@ -20,6 +25,7 @@ import (
 	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
 	ngmodels "github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/notifier/templatedhttp"
 	"github.com/grafana/grafana/pkg/services/ngalert/store"
 	"github.com/grafana/grafana/pkg/services/notifications"
 	"github.com/grafana/grafana/pkg/setting"
@@ -56,6 +62,9 @@ type alertmanager struct {
 	stateStore          stateStore
 	NotificationService notifications.Service
 
+	// deliveries records the attempts of the integrations to send notifications. It is nil if they are not recorded.
+	deliveries *NotificationDeliveryLog
+
 	decryptFn alertingNotify.GetDecryptedValueFn
 	orgID     int64
 
@@ -91,7 +100,7 @@ func (m maintenanceOptions) MaintenanceFunc(state alertingNotify.State) (int64,
 
 func NewAlertmanager(ctx context.Context, orgID int64, cfg *setting.Cfg, store AlertingStore, stateStore stateStore,
 	peer alertingNotify.ClusterPeer, decryptFn alertingNotify.GetDecryptedValueFn, ns notifications.Service,
-	m *metrics.Alertmanager, withAutogen bool,
+	m *metrics.Alertmanager, deliveries *NotificationDeliveryLog, withAutogen bool,
 ) (*alertmanager, error) {
 	nflog, err := stateStore.GetNotificationLog(ctx)
 	if err != nil {
@@ -146,6 +155,7 @@ func NewAlertmanager(ctx context.Context, orgID int64, cfg *setting.Cfg, store A
 		Settings:            cfg,
 		Store:               store,
 		NotificationService: ns,
+		deliveries:          deliveries,
 		orgID:               orgID,
 		decryptFn:           decryptFn,
 		stateStore:          stateStore,
@@ -385,7 +395,13 @@ func (am *alertmanager) AppURL() string {
 
 // buildReceiverIntegrations builds a list of integration notifiers off of a receiver config.
 func (am *alertmanager) buildReceiverIntegrations(receiver *alertingNotify.APIReceiver, tmpl *alertingTemplates.Template) ([]*alertingNotify.Integration, error) {
-	receiverCfg, err := alertingNotify.BuildReceiverConfiguration(context.Background(), receiver, am.decryptFn)
+	// The templated HTTP integrations are implemented in Grafana and are unknown to the alerting package.
+	builtin, templatedHTTP := splitTemplatedHTTPIntegrations(receiver)
+	receiverCfg, err := alertingNotify.BuildReceiverConfiguration(context.Background(), builtin, am.decryptFn)
+	if err != nil {
+		return nil, err
+	}
+	templatedHTTPIntegrations, err := buildTemplatedHTTPIntegrations(context.Background(), templatedHTTP, tmpl, am.decryptFn)
 	if err != nil {
 		return nil, err
 	}
@@ -408,6 +424,77 @@ func (am *alertmanager) buildReceiverIntegrations(receiver *alertingNotify.APIRe
 	if err != nil {
 		return nil, err
 	}
+	integrations = append(integrations, templatedHTTPIntegrations...)
+	if am.deliveries != nil {
+		integrations = am.deliveries.recordIntegrations(am.orgID, receiver, integrations)
+	}
+	return integrations, nil
+}
+
+// NotifyReceiver sends a notification for the alert to every integration of the receiver, the same way as the
+// notifications of the alerts that are routed to it. The notification is sent once, and recorded in the delivery log
+// with the given group key.
+func (am *alertmanager) NotifyReceiver(ctx context.Context, receiver *apimodels.PostableApiReceiver, groupKey string, alert model.Alert) error {
+	tmpl, err := am.Base.GetTemplate()
+	if err != nil {
+		return fmt.Errorf("failed to get template: %w", err)
+	}
+	integrations, err := am.buildReceiverIntegrations(PostableApiReceiverToApiReceiver(receiver), tmpl)
+	if err != nil {
+		return fmt.Errorf("failed to build integrations: %w", err)
+	}
+
+	now := time.Now()
+	ctx = notify.WithGroupKey(ctx, groupKey)
+	ctx = notify.WithGroupLabels(ctx, alert.Labels)
+	ctx = notify.WithReceiverName(ctx, receiver.Name)
+	ctx = notify.WithNow(ctx, now)
+
+	var errs []error
+	for _, integration := range integrations {
+		if _, err := integration.Notify(ctx, &types.Alert{Alert: alert, UpdatedAt: now}); err != nil {
+			errs = append(errs, fmt.Errorf("integration %s: %w", integration.String(), err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// splitTemplatedHTTPIntegrations returns a copy of the receiver without the templated HTTP integrations, and the
+// templated HTTP integrations.
+func splitTemplatedHTTPIntegrations(receiver *alertingNotify.APIReceiver) (*alertingNotify.APIReceiver, []*alertingNotify.GrafanaIntegrationConfig) {
+	var templatedHTTP []*alertingNotify.GrafanaIntegrationConfig
+	builtin := make([]*alertingNotify.GrafanaIntegrationConfig, 0, len(receiver.Integrations))
+	for _, integration := range receiver.Integrations {
+		if strings.EqualFold(integration.Type, templatedhttp.Type) {
+			templatedHTTP = append(templatedHTTP, integration)
+			continue
+		}
+		builtin = append(builtin, integration)
+	}
+	if len(templatedHTTP) == 0 {
+		return receiver, nil
+	}
+	result := *receiver
+	result.Integrations = builtin
+	return &result, templatedHTTP
+}
+
+func buildTemplatedHTTPIntegrations(ctx context.Context, configs []*alertingNotify.GrafanaIntegrationConfig, tmpl *alertingTemplates.Template, decryptFn alertingNotify.GetDecryptedValueFn) ([]*alertingNotify.Integration, error) {
+	integrations := make([]*alertingNotify.Integration, 0, len(configs))
+	for i, cfg := range configs {
+		settings, err := templatedhttp.NewConfigFromIntegration(ctx, cfg, decryptFn)
+		if err != nil {
+			return nil, alertingNotify.IntegrationValidationError{Integration: cfg, Err: err}
+		}
+		meta := receivers.Metadata{
+			UID:                   cfg.UID,
+			Name:                  cfg.Name,
+			Type:                  templatedhttp.Type,
+			DisableResolveMessage: cfg.DisableResolveMessage,
+		}
+		n := templatedhttp.New(settings, meta, tmpl, LoggerFactory("ngalert.notifier."+templatedhttp.Type, "notifierUID", cfg.UID))
+		integrations = append(integrations, alertingNotify.NewIntegration(n, n, templatedhttp.Type, i, cfg.Name))
+	}
 	return integrations, nil
 }
 
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/go-openapi/strfmt"
+	amConfig "github.com/prometheus/alertmanager/config"
 	"k8s.io/apimachinery/pkg/util/sets"
 
 	"github.com/grafana/grafana/pkg/apimachinery/errutil"
@@ -264,14 +265,20 @@ func (moa *MultiOrgAlertmanager) gettableUserConfigFromAMConfigString(ctx contex
 	return result, nil
 }
 
+// SaveAndApplyAlertmanagerConfiguration saves and applies the configuration. Inhibition rules can only be created by
+// importing the configuration of a Prometheus Alertmanager, so the configuration must keep the inhibition rules of the
+// current configuration.
 func (moa *MultiOrgAlertmanager) SaveAndApplyAlertmanagerConfiguration(ctx context.Context, org int64, config definitions.PostableUserConfig) error {
-	// We cannot add this validation to PostableUserConfig as that struct is used for both
-	// Grafana Alertmanager (where inhibition rules are not supported) and External Alertmanagers
-	// (including Mimir) where inhibition rules are supported.
-	if len(config.AlertmanagerConfig.InhibitRules) > 0 {
-		return errors.New("inhibition rules are not supported")
-	}
+	return moa.saveAndApplyAlertmanagerConfiguration(ctx, org, config, false)
+}
 
+// SaveAndApplyImportedAlertmanagerConfiguration saves and applies a configuration that is imported from a Prometheus
+// Alertmanager, whose inhibition rules replace the inhibition rules of the current configuration.
+func (moa *MultiOrgAlertmanager) SaveAndApplyImportedAlertmanagerConfiguration(ctx context.Context, org int64, config definitions.PostableUserConfig) error {
+	return moa.saveAndApplyAlertmanagerConfiguration(ctx, org, config, true)
+}
+
+func (moa *MultiOrgAlertmanager) saveAndApplyAlertmanagerConfiguration(ctx context.Context, org int64, config definitions.PostableUserConfig, imported bool) error {
 	// Get the last known working configuration
 	previousConfig, err := moa.configStore.GetLatestAlertmanagerConfiguration(ctx, org)
 	if err != nil {
@@ -282,6 +289,21 @@ func (moa *MultiOrgAlertmanager) SaveAndApplyAlertmanagerConfiguration(ctx conte
 	}
 	cleanPermissionsErr := err
 
+	// We cannot add this validation to PostableUserConfig as that struct is used for both
+	// Grafana Alertmanager (where inhibition rules can only be imported) and External Alertmanagers
+	// (including Mimir) where inhibition rules are supported.
+	if !imported && len(config.AlertmanagerConfig.InhibitRules) > 0 {
+		var previousRules []amConfig.InhibitRule
+		if previousConfig != nil {
+			if previous, err := Load([]byte(previousConfig.AlertmanagerConfiguration)); err == nil {
+				previousRules = previous.AlertmanagerConfig.InhibitRules
+			}
+		}
+		if normalizeInhibitRules(previousRules) != normalizeInhibitRules(config.AlertmanagerConfig.InhibitRules) {
+			return errors.New("inhibition rules are not supported")
+		}
+	}
+
 	if err := moa.Crypto.ProcessSecureSettings(ctx, org, config.AlertmanagerConfig.Receivers); err != nil {
 		return fmt.Errorf("failed to post process Alertmanager configuration: %w", err)
 	}
@@ -329,6 +351,19 @@ func (moa *MultiOrgAlertmanager) SaveAndApplyAlertmanagerConfiguration(ctx conte
 	return nil
 }
 
+// normalizeInhibitRules returns the inhibition rules as they are serialized, so that rules that were saved and loaded
+// again compare equal to the posted ones.
+func normalizeInhibitRules(rules []amConfig.InhibitRule) string {
+	if len(rules) == 0 {
+		return ""
+	}
+	b, err := json.Marshal(rules)
+	if err != nil {
+		return ""
+	}
+	return string(b)
+}
+
 // assignReceiverConfigsUIDs assigns missing UUIDs to receiver configs.
 func assignReceiverConfigsUIDs(c []*definitions.PostableApiReceiver) error {
 	seenUIDs := make(map[string]struct{})
@@ -2,16 +2,27 @@ package notifier
 
 import (
 	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
 	"testing"
 	"time"
 
+	"github.com/benbjohnson/clock"
+	alertingNotify "github.com/grafana/alerting/notify"
+	alertingTemplates "github.com/grafana/alerting/templates"
+	"github.com/prometheus/alertmanager/config"
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/require"
 
 	"github.com/grafana/grafana/pkg/infra/db"
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/services/dashboards"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
 	"github.com/grafana/grafana/pkg/services/ngalert/store"
 	"github.com/grafana/grafana/pkg/services/ngalert/tests/fakes"
 	"github.com/grafana/grafana/pkg/services/secrets/database"
@@ -52,7 +63,7 @@ func setupAMTest(t *testing.T) *alertmanager {
 	orgID := 1
 	stateStore := NewFileStore(int64(orgID), kvStore)
 
-	am, err := NewAlertmanager(context.Background(), 1, cfg, s, stateStore, &NilPeer{}, decryptFn, nil, m, false)
+	am, err := NewAlertmanager(context.Background(), 1, cfg, s, stateStore, &NilPeer{}, decryptFn, nil, m, nil, false)
 	require.NoError(t, err)
 	return am
 }
@@ -61,3 +72,68 @@ func TestAlertmanager_newAlertmanager(t *testing.T) {
 	am := setupAMTest(t)
 	require.False(t, am.Ready())
 }
+
+func TestAlertmanager_buildReceiverIntegrations_TemplatedHTTP(t *testing.T) {
+	am := setupAMTest(t)
+	receiver := &alertingNotify.APIReceiver{
+		ConfigReceiver: config.Receiver{Name: "tickets"},
+		GrafanaIntegrations: alertingNotify.GrafanaIntegrations{
+			Integrations: []*alertingNotify.GrafanaIntegrationConfig{
+				{UID: "webhook-uid", Name: "tickets", Type: "webhook", Settings: json.RawMessage(`{"url": "http://localhost"}`)},
+				{UID: "http-uid", Name: "tickets", Type: "templated-http", Settings: json.RawMessage(`{"url": "http://localhost/{{ .CommonLabels.queue }}"}`)},
+			},
+		},
+	}
+
+	integrations, err := am.buildReceiverIntegrations(receiver, alertingTemplates.ForTests(t))
+	require.NoError(t, err)
+	require.Len(t, integrations, 2)
+	require.Equal(t, "webhook", integrations[0].Name())
+	require.Equal(t, "templated-http", integrations[1].Name())
+	require.Equal(t, 0, integrations[1].Index())
+	require.Len(t, receiver.Integrations, 2, "the receiver must not be modified")
+
+	t.Run("returns a validation error for an invalid templated HTTP integration", func(t *testing.T) {
+		receiver.Integrations[1].Settings = json.RawMessage(`{}`)
+		_, err := am.buildReceiverIntegrations(receiver, alertingTemplates.ForTests(t))
+		var validationErr alertingNotify.IntegrationValidationError
+		require.ErrorAs(t, err, &validationErr)
+		require.Equal(t, "http-uid", validationErr.Integration.UID)
+	})
+}
+
+func TestAlertmanager_NotifyReceiver(t *testing.T) {
+	var bodies []string
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := io.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+	}))
+	t.Cleanup(srv.Close)
+
+	am := setupAMTest(t)
+	am.deliveries = NewNotificationDeliveryLog(&fakeNotificationDeliveryStore{}, 0, prometheus.NewCounter(prometheus.CounterOpts{}), clock.NewMock(), log.NewNopLogger())
+	receiver := &apimodels.PostableApiReceiver{
+		Receiver: config.Receiver{Name: "ops"},
+		PostableGrafanaReceivers: apimodels.PostableGrafanaReceivers{
+			GrafanaManagedReceivers: []*apimodels.PostableGrafanaReceiver{
+				{UID: "http-uid", Name: "ops", Type: "templated-http", Settings: apimodels.RawMessage(`{"url": "` + srv.URL + `", "body": "{{ .Status }} {{ .CommonLabels.alertname }}"}`)},
+			},
+		},
+	}
+	now := time.Now()
+	alert := model.Alert{
+		Labels:   model.LabelSet{model.AlertNameLabel: "SilenceExpiring"},
+		StartsAt: now,
+		EndsAt:   now.Add(time.Hour),
+	}
+
+	require.NoError(t, am.NotifyReceiver(context.Background(), receiver, "group-key", alert))
+
+	require.Equal(t, []string{"firing SilenceExpiring"}, bodies)
+	deliveries := recordedDeliveries(am.deliveries)
+	require.Len(t, deliveries, 1)
+	require.Equal(t, "ops", deliveries[0].Receiver)
+	require.Equal(t, "http-uid", deliveries[0].IntegrationUID)
+	require.Equal(t, "group-key", deliveries[0].GroupKey)
+	require.Equal(t, models.NotificationDeliveryStatusSuccess, deliveries[0].Status)
+}
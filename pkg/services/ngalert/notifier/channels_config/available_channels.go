@@ -1008,6 +1008,161 @@ func GetAvailableNotifiers() []*NotifierPlugin {
 				},
 			},
 		},
+		{
+			Type:        "templated-http",
+			Name:        "Templated HTTP",
+			Description: "Sends an HTTP request whose method, URL, headers and body are templates",
+			Heading:     "Templated HTTP settings",
+			Info:        "The method, URL, header values and body are templates that are executed over the alert group, like the messages of the other integrations.",
+			Options: []NotifierOption{
+				{
+					Label:        "URL",
+					Description:  "Templated URL of the request. The scheme and host can't be templated when authentication, secure headers or an HMAC secret are set.",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					PropertyName: "url",
+					Required:     true,
+				},
+				{
+					Label:        "HTTP Method",
+					Description:  "Templated HTTP method of the request. Default is POST.",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					PropertyName: "httpMethod",
+					Placeholder:  "POST",
+				},
+				{
+					Label:        "Headers",
+					Description:  "Headers of the request. The values are templates, a header whose value is empty after templating is not sent. Use Secure Headers for secret values.",
+					Element:      ElementTypeKeyValueMap,
+					InputType:    InputTypeText,
+					PropertyName: "headers",
+				},
+				{
+					Label:        "Secure Headers",
+					Description:  "Headers with secret values, such as API keys, one per line in the format Name: value. The values are not templates.",
+					Element:      ElementTypeTextArea,
+					PropertyName: "secureHeaders",
+					Secure:       true,
+				},
+				{
+					Label:        "Body",
+					Description:  "Templated body of the request.",
+					Element:      ElementTypeTextArea,
+					PropertyName: "body",
+				},
+				{
+					Label:        "Content Type",
+					Description:  "Content type of the body. Default is application/json.",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					PropertyName: "contentType",
+					Placeholder:  "application/json",
+				},
+				{
+					Label:        "Success Status Codes",
+					Description:  "Comma-separated list of the status codes and ranges of status codes of a successful request, for example 200-299,304. Default is 200-299.",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					PropertyName: "successStatusCodes",
+					Placeholder:  "200-299",
+				},
+				{
+					Label:        "HTTP Basic Authentication - Username",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					PropertyName: "username",
+				},
+				{
+					Label:        "HTTP Basic Authentication - Password",
+					Element:      ElementTypeInput,
+					InputType:    InputTypePassword,
+					PropertyName: "password",
+					Secure:       true,
+				},
+				{
+					Label:        "Authorization Header - Scheme",
+					Description:  "Optionally provide a scheme for the Authorization Request Header. Default is Bearer.",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					PropertyName: "authorization_scheme",
+					Placeholder:  "Bearer",
+				},
+				{
+					Label:        "Authorization Header - Credentials",
+					Description:  "Credentials for the Authorization Request header. Only one of HTTP Basic Authentication or Authorization Request Header can be set.",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					PropertyName: "authorization_credentials",
+					Secure:       true,
+				},
+				{
+					Label:        "HMAC Signature - Secret",
+					Description:  "Secret to sign the body of the request with HMAC-SHA256. The signature is sent as sha256=<hex signature>.",
+					Element:      ElementTypeInput,
+					InputType:    InputTypePassword,
+					PropertyName: "hmacSecret",
+					Secure:       true,
+				},
+				{
+					Label:        "HMAC Signature - Header",
+					Description:  "Header of the signature. Default is X-Grafana-Alerting-Signature.",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					PropertyName: "hmacHeader",
+					Placeholder:  "X-Grafana-Alerting-Signature",
+				},
+				{
+					Label:        "HMAC Signature - Timestamp Header",
+					Description:  "If set, the Unix timestamp of the request is sent in this header and the signature is computed over <timestamp>.<body>.",
+					Element:      ElementTypeInput,
+					InputType:    InputTypeText,
+					PropertyName: "hmacTimestampHeader",
+				},
+				{
+					Label:        "TLS",
+					PropertyName: "tlsConfig",
+					Description:  "TLS configuration options",
+					Element:      ElementTypeSubform,
+					SubformOptions: []NotifierOption{
+						{
+							Label:        "Disable certificate verification",
+							Element:      ElementTypeCheckbox,
+							Description:  "Do not verify the server's certificate chain and host name.",
+							PropertyName: "insecureSkipVerify",
+							Required:     false,
+						},
+						{
+							Label:        "CA Certificate",
+							Element:      ElementTypeTextArea,
+							Description:  "Certificate in PEM format to use when verifying the server's certificate chain.",
+							InputType:    InputTypeText,
+							PropertyName: "caCertificate",
+							Required:     false,
+							Secure:       true,
+						},
+						{
+							Label:        "Client Certificate",
+							Element:      ElementTypeTextArea,
+							Description:  "Client certificate in PEM format to use when connecting to the server.",
+							InputType:    InputTypeText,
+							PropertyName: "clientCertificate",
+							Required:     false,
+							Secure:       true,
+						},
+						{
+							Label:        "Client Key",
+							Element:      ElementTypeTextArea,
+							Description:  "Client key in PEM format to use when connecting to the server.",
+							InputType:    InputTypeText,
+							PropertyName: "clientKey",
+							Required:     false,
+							Secure:       true,
+						},
+					},
+				},
+			},
+		},
 		{
 			Type:        "wecom",
 			Name:        "WeCom",
@@ -8,6 +8,7 @@ import (
 	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
 
 	alertingCluster "github.com/grafana/alerting/cluster"
 
@@ -99,8 +100,9 @@ type MultiOrgAlertmanager struct {
 
 	decryptFn alertingNotify.GetDecryptedValueFn
 
-	metrics *metrics.MultiOrgAlertmanager
-	ns      notifications.Service
+	metrics    *metrics.MultiOrgAlertmanager
+	ns         notifications.Service
+	deliveries *NotificationDeliveryLog
 
 	receiverResourcePermissions ac.ReceiverPermissionsService
 }
@@ -115,6 +117,14 @@ func WithAlertmanagerOverride(f func(OrgAlertmanagerFactory) OrgAlertmanagerFact
 	}
 }
 
+// WithNotificationDeliveryLog records the attempts of the integrations of the internal Alertmanagers to send
+// notifications in the given delivery log.
+func WithNotificationDeliveryLog(deliveries *NotificationDeliveryLog) Option {
+	return func(moa *MultiOrgAlertmanager) {
+		moa.deliveries = deliveries
+	}
+}
+
 func NewMultiOrgAlertmanager(
 	cfg *setting.Cfg,
 	configStore AlertingStore,
@@ -160,7 +170,7 @@ func NewMultiOrgAlertmanager(
 	moa.factory = func(ctx context.Context, orgID int64) (Alertmanager, error) {
 		m := metrics.NewAlertmanagerMetrics(moa.metrics.GetOrCreateOrgRegistry(orgID), l)
 		stateStore := NewFileStore(orgID, kvStore)
-		return NewAlertmanager(ctx, orgID, moa.settings, moa.configStore, stateStore, moa.peer, moa.decryptFn, moa.ns, m, featureManager.IsEnabled(ctx, featuremgmt.FlagAlertingSimplifiedRouting))
+		return NewAlertmanager(ctx, orgID, moa.settings, moa.configStore, stateStore, moa.peer, moa.decryptFn, moa.ns, m, moa.deliveries, featureManager.IsEnabled(ctx, featuremgmt.FlagAlertingSimplifiedRouting))
 	}
 
 	for _, opt := range opts {
@@ -554,6 +564,129 @@ func (moa *MultiOrgAlertmanager) DeleteSilence(ctx context.Context, orgID int64,
 	return nil
 }
 
+// ListSilenceAlerts returns the labels of the alerts of the organization that match the matchers of the silence,
+// regardless of whether they are silenced or inhibited.
+func (moa *MultiOrgAlertmanager) ListSilenceAlerts(ctx context.Context, orgID int64, silence models.Silence) ([]map[string]string, error) {
+	moa.alertmanagersMtx.RLock()
+	defer moa.alertmanagersMtx.RUnlock()
+
+	orgAM, err := moa.alertmanagerForOrg(orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	alerts, err := orgAM.GetAlerts(ctx, true, true, true, models.MatcherFilters(silence.Matchers), "")
+	if err != nil {
+		return nil, err
+	}
+	result := make([]map[string]string, 0, len(alerts))
+	for _, alert := range alerts {
+		result = append(result, alert.Labels)
+	}
+	return result, nil
+}
+
+// HasReceiver returns true if the Alertmanager configuration of the organization has a receiver with the given name.
+func (moa *MultiOrgAlertmanager) HasReceiver(ctx context.Context, orgID int64, name string) (bool, error) {
+	receiver, err := moa.getReceiver(ctx, orgID, name)
+	if err != nil {
+		return false, err
+	}
+	return receiver != nil, nil
+}
+
+// receiverNotifier is implemented by the Alertmanagers that can send a notification to a receiver.
+type receiverNotifier interface {
+	NotifyReceiver(ctx context.Context, receiver *apimodels.PostableApiReceiver, groupKey string, alert model.Alert) error
+}
+
+// NotifyReceiver sends a notification for the alert to the receiver of the organization with the given name, through
+// the same integrations as the notifications of the alerts that are routed to it. It returns an error if any
+// integration of the receiver fails.
+func (moa *MultiOrgAlertmanager) NotifyReceiver(ctx context.Context, orgID int64, name, groupKey string, alert model.Alert) error {
+	receiver, err := moa.getReceiver(ctx, orgID, name)
+	if err != nil {
+		return err
+	}
+	if receiver == nil {
+		return fmt.Errorf("receiver %s does not exist", name)
+	}
+
+	moa.alertmanagersMtx.RLock()
+	defer moa.alertmanagersMtx.RUnlock()
+
+	orgAM, err := moa.alertmanagerForOrg(orgID)
+	if err != nil {
+		return err
+	}
+	notifier, ok := orgAM.(receiverNotifier)
+	if !ok {
+		return errors.New("the Alertmanager of the organization does not support sending notifications to a receiver")
+	}
+	return notifier.NotifyReceiver(ctx, receiver, groupKey, alert)
+}
+
+// notificationReplayer is implemented by the Alertmanagers that can send the notification of a delivery again.
+type notificationReplayer interface {
+	ReplayNotification(ctx context.Context, receiver *apimodels.PostableApiReceiver, d models.NotificationDelivery) (models.NotificationReplayResult, error)
+}
+
+// ReplayNotificationDelivery sends the notification of the failed delivery again with the same integration of the
+// receiver in the latest Alertmanager configuration of the organization.
+func (moa *MultiOrgAlertmanager) ReplayNotificationDelivery(ctx context.Context, d models.NotificationDelivery) (models.NotificationReplayResult, error) {
+	receiver, err := moa.getReceiver(ctx, d.OrgID, d.Receiver)
+	if err != nil {
+		return models.NotificationReplayResult{}, err
+	}
+	if receiver == nil {
+		return models.NotificationReplayResult{}, models.ErrNotificationDeliveryNotReplayable(fmt.Errorf("receiver %s does not exist", d.Receiver))
+	}
+
+	moa.alertmanagersMtx.RLock()
+	defer moa.alertmanagersMtx.RUnlock()
+
+	orgAM, err := moa.alertmanagerForOrg(d.OrgID)
+	if err != nil {
+		return models.NotificationReplayResult{}, err
+	}
+	replayer, ok := orgAM.(notificationReplayer)
+	if !ok {
+		return models.NotificationReplayResult{}, models.ErrNotificationDeliveryNotReplayable(errors.New("the Alertmanager of the organization does not support replays"))
+	}
+	return replayer.ReplayNotification(ctx, receiver, d)
+}
+
+// getReceiver returns the receiver of the latest Alertmanager configuration of the organization with the given name, or
+// nil if there is no such receiver. Its secure settings are encrypted.
+func (moa *MultiOrgAlertmanager) getReceiver(ctx context.Context, orgID int64, name string) (*apimodels.PostableApiReceiver, error) {
+	amConfig, err := moa.configStore.GetLatestAlertmanagerConfiguration(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get latest configuration: %w", err)
+	}
+	cfg, err := Load([]byte(amConfig.AlertmanagerConfiguration))
+	if err != nil {
+		return nil, fmt.Errorf("failed to load configuration: %w", err)
+	}
+	for _, r := range cfg.AlertmanagerConfig.Receivers {
+		if r.Name == name {
+			return r, nil
+		}
+	}
+	return nil, nil
+}
+
+// TestTemplate renders the template with the alerts in the Alertmanager of the organization.
+func (moa *MultiOrgAlertmanager) TestTemplate(ctx context.Context, orgID int64, c apimodels.TestTemplatesConfigBodyParams) (*TestTemplatesResults, error) {
+	moa.alertmanagersMtx.RLock()
+	defer moa.alertmanagersMtx.RUnlock()
+
+	orgAM, err := moa.alertmanagerForOrg(orgID)
+	if err != nil {
+		return nil, err
+	}
+	return orgAM.TestTemplate(ctx, c)
+}
+
 // updateSilenceState persists the silence state to the kvstore immediately instead of waiting for the next maintenance
 // run. This is used after Create/Delete to prevent silences from being lost when a new Alertmanager is started before
 // the state has persisted. This can happen, for example, in a rolling deployment scenario.
@@ -0,0 +1,406 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	alertingNotify "github.com/grafana/alerting/notify"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	apimodels "github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+const (
+	// notificationDeliveryQueueSize is the number of recorded deliveries that can wait to be written to the store.
+	notificationDeliveryQueueSize = 1000
+	// notificationDeliveryRecordTimeout is how long recording a delivery waits for room in a full queue before the
+	// delivery is dropped.
+	notificationDeliveryRecordTimeout = time.Second
+	// notificationDeliveryBatchSize is the maximum number of deliveries that are written to the store at once.
+	notificationDeliveryBatchSize = 100
+	// notificationDeliveryCleanupInterval is how often the deliveries older than the retention are deleted.
+	notificationDeliveryCleanupInterval = time.Hour
+	// maxTrackedDeliveryGroups limits the number of aggregation groups whose attempts are counted by an integration
+	// before the groups that were not flushed recently are forgotten.
+	maxTrackedDeliveryGroups = 1000
+)
+
+// NotificationDeliveryStore is the store of the log of notification deliveries.
+type NotificationDeliveryStore interface {
+	InsertNotificationDeliveries(ctx context.Context, deliveries []models.NotificationDelivery) error
+	GetNotificationDelivery(ctx context.Context, orgID int64, id int64) (*models.NotificationDelivery, error)
+	ListNotificationDeliveries(ctx context.Context, query models.ListNotificationDeliveriesQuery) ([]*models.NotificationDelivery, error)
+	MarkNotificationDeliveryReplayed(ctx context.Context, orgID int64, id int64, at time.Time) error
+	UnmarkNotificationDeliveryReplayed(ctx context.Context, orgID int64, id int64) error
+	DeleteNotificationDeliveriesBefore(ctx context.Context, before time.Time) (int64, error)
+}
+
+// NotificationDeliveryLog records every attempt of the integrations of the Alertmanagers to send a notification,
+// including retries. The deliveries are written to the store in the background so that recording them does not delay
+// the notifications.
+type NotificationDeliveryLog struct {
+	store         NotificationDeliveryStore
+	retention     time.Duration
+	deliveries    chan models.NotificationDelivery
+	recordTimeout time.Duration
+	dropped       prometheus.Counter
+	clock         clock.Clock
+	log           log.Logger
+}
+
+func NewNotificationDeliveryLog(store NotificationDeliveryStore, retention time.Duration, dropped prometheus.Counter, clock clock.Clock, log log.Logger) *NotificationDeliveryLog {
+	return &NotificationDeliveryLog{
+		store:         store,
+		retention:     retention,
+		deliveries:    make(chan models.NotificationDelivery, notificationDeliveryQueueSize),
+		recordTimeout: notificationDeliveryRecordTimeout,
+		dropped:       dropped,
+		clock:         clock,
+		log:           log,
+	}
+}
+
+// Record queues the delivery to be written to the store. If the queue is full, it waits for the store to catch up
+// for at most the record timeout, after which the delivery is dropped and counted as such.
+func (l *NotificationDeliveryLog) Record(d models.NotificationDelivery) {
+	select {
+	case l.deliveries <- d:
+		return
+	default:
+	}
+
+	timer := time.NewTimer(l.recordTimeout)
+	defer timer.Stop()
+	select {
+	case l.deliveries <- d:
+	case <-timer.C:
+		l.dropped.Inc()
+		l.log.Warn("Dropped notification delivery because the queue is full", "orgID", d.OrgID, "receiver", d.Receiver, "integration", d.IntegrationUID, "status", d.Status)
+	}
+}
+
+// Run writes the recorded deliveries to the store and deletes the deliveries older than the retention until the
+// context is done.
+func (l *NotificationDeliveryLog) Run(ctx context.Context) error {
+	ticker := l.clock.Ticker(notificationDeliveryCleanupInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case d := <-l.deliveries:
+			l.write(ctx, d)
+		case <-ticker.C:
+			l.cleanup(ctx)
+		case <-ctx.Done():
+			// Detached context here is to make sure that the queued deliveries are written when the service is shut down.
+			l.flush(context.Background())
+			return nil
+		}
+	}
+}
+
+// write writes the delivery to the store together with the deliveries that are queued after it.
+func (l *NotificationDeliveryLog) write(ctx context.Context, first models.NotificationDelivery) {
+	batch := []models.NotificationDelivery{first}
+	for len(batch) < notificationDeliveryBatchSize {
+		select {
+		case d := <-l.deliveries:
+			batch = append(batch, d)
+		default:
+			l.insert(ctx, batch)
+			return
+		}
+	}
+	l.insert(ctx, batch)
+}
+
+// flush writes all queued deliveries to the store.
+func (l *NotificationDeliveryLog) flush(ctx context.Context) {
+	for {
+		select {
+		case d := <-l.deliveries:
+			l.write(ctx, d)
+		default:
+			return
+		}
+	}
+}
+
+func (l *NotificationDeliveryLog) insert(ctx context.Context, batch []models.NotificationDelivery) {
+	if err := l.store.InsertNotificationDeliveries(ctx, batch); err != nil {
+		l.log.Error("Failed to write notification deliveries", "count", len(batch), "error", err)
+	}
+}
+
+func (l *NotificationDeliveryLog) cleanup(ctx context.Context) {
+	if l.retention <= 0 {
+		return
+	}
+	deleted, err := l.store.DeleteNotificationDeliveriesBefore(ctx, l.clock.Now().Add(-l.retention))
+	if err != nil {
+		l.log.Error("Failed to delete old notification deliveries", "error", err)
+		return
+	}
+	if deleted > 0 {
+		l.log.Debug("Deleted old notification deliveries", "count", deleted)
+	}
+}
+
+// recordIntegrations wraps the integrations that were built for the receiver so that their attempts to send
+// notifications are recorded.
+func (l *NotificationDeliveryLog) recordIntegrations(orgID int64, receiver *alertingNotify.APIReceiver, integrations []*alertingNotify.Integration) []*alertingNotify.Integration {
+	// The integrations are indexed among the integrations of the same type, in the order of the configuration.
+	configs := make(map[string][]*alertingNotify.GrafanaIntegrationConfig, len(receiver.Integrations))
+	for _, cfg := range receiver.Integrations {
+		t := strings.ToLower(cfg.Type)
+		configs[t] = append(configs[t], cfg)
+	}
+
+	result := make([]*alertingNotify.Integration, 0, len(integrations))
+	for _, integration := range integrations {
+		r := &deliveryRecorder{
+			deliveries:  l,
+			orgID:       orgID,
+			integration: integration,
+			receiver:    receiver.Name,
+			attempts:    make(map[string]deliveryAttempts),
+		}
+		name := receiver.Name
+		if cfgs := configs[strings.ToLower(integration.Name())]; integration.Index() < len(cfgs) {
+			r.uid = cfgs[integration.Index()].UID
+			name = cfgs[integration.Index()].Name
+		}
+		result = append(result, alertingNotify.NewIntegration(r, integration, integration.Name(), integration.Index(), name))
+	}
+	return result
+}
+
+type notificationReplayKey struct{}
+
+// notificationReplay is the replay of a failed delivery that is in progress.
+type notificationReplay struct {
+	of     int64
+	result models.NotificationReplayResult
+}
+
+func withNotificationReplay(ctx context.Context, r *notificationReplay) context.Context {
+	return context.WithValue(ctx, notificationReplayKey{}, r)
+}
+
+func notificationReplayFromContext(ctx context.Context) *notificationReplay {
+	r, _ := ctx.Value(notificationReplayKey{}).(*notificationReplay)
+	return r
+}
+
+// deliveryAttempts counts the attempts to send the notification of the last flush of an aggregation group.
+type deliveryAttempts struct {
+	flush time.Time
+	count int
+}
+
+// deliveryRecorder is a notifier that sends notifications with an integration and records every attempt in the
+// delivery log.
+type deliveryRecorder struct {
+	deliveries  *NotificationDeliveryLog
+	orgID       int64
+	integration *alertingNotify.Integration
+	uid         string
+	receiver    string
+
+	mtx      sync.Mutex
+	attempts map[string]deliveryAttempts
+}
+
+func (r *deliveryRecorder) Notify(ctx context.Context, alerts ...*types.Alert) (bool, error) {
+	start := time.Now()
+	retry, err := r.integration.Notify(ctx, alerts...)
+
+	groupKey, _ := notify.GroupKey(ctx)
+	groupLabels, _ := notify.GroupLabels(ctx)
+	receiver, ok := notify.ReceiverName(ctx)
+	if !ok {
+		receiver = r.receiver
+	}
+	d := models.NotificationDelivery{
+		OrgID:           r.orgID,
+		Receiver:        receiver,
+		IntegrationUID:  r.uid,
+		IntegrationType: r.integration.Name(),
+		GroupKey:        groupKey,
+		Attempt:         r.attempt(ctx, groupKey),
+		Status:          models.NotificationDeliveryStatusSuccess,
+		Latency:         time.Since(start),
+		Created:         start,
+	}
+	if err != nil {
+		d.Status = models.NotificationDeliveryStatusFailed
+		d.Error = err.Error()
+	}
+	payload := models.NotificationPayload{GroupLabels: groupLabels, Alerts: make([]model.Alert, 0, len(alerts))}
+	for _, a := range alerts {
+		payload.Alerts = append(payload.Alerts, a.Alert)
+	}
+	if perr := d.SetPayload(payload); perr != nil {
+		r.deliveries.log.Warn("Failed to record the payload of a notification delivery", "orgID", r.orgID, "integration", r.uid, "error", perr)
+	}
+	if replay := notificationReplayFromContext(ctx); replay != nil {
+		d.ReplayOf = replay.of
+		replay.result = models.NotificationReplayResult{Status: d.Status, Error: d.Error}
+	}
+
+	r.deliveries.Record(d)
+	return retry, err
+}
+
+// attempt returns the number of the attempt to send the notification of the aggregation group. The retries of a
+// notification share the time of the flush of the group.
+func (r *deliveryRecorder) attempt(ctx context.Context, groupKey string) int {
+	flush, ok := notify.Now(ctx)
+	if !ok {
+		return 1
+	}
+
+	r.mtx.Lock()
+	defer r.mtx.Unlock()
+	a := r.attempts[groupKey]
+	if !a.flush.Equal(flush) {
+		a = deliveryAttempts{flush: flush}
+	}
+	a.count++
+	r.attempts[groupKey] = a
+
+	if len(r.attempts) > maxTrackedDeliveryGroups {
+		for key, other := range r.attempts {
+			if other.flush.Before(flush.Add(-time.Hour)) {
+				delete(r.attempts, key)
+			}
+		}
+	}
+	return a.count
+}
+
+// ReplayNotification sends the notification of the failed delivery again with the same integration of the receiver.
+// The attempt is recorded in the delivery log as a replay of the delivery.
+func (am *alertmanager) ReplayNotification(ctx context.Context, receiver *apimodels.PostableApiReceiver, d models.NotificationDelivery) (models.NotificationReplayResult, error) {
+	payload, err := d.NotificationPayload()
+	if err != nil {
+		return models.NotificationReplayResult{}, models.ErrNotificationDeliveryNotReplayable(err)
+	}
+
+	var cfg *apimodels.PostableGrafanaReceiver
+	for _, r := range receiver.GrafanaManagedReceivers {
+		if r.UID == d.IntegrationUID {
+			cfg = r
+			break
+		}
+	}
+	if cfg == nil {
+		return models.NotificationReplayResult{}, models.ErrNotificationDeliveryNotReplayable(fmt.Errorf("integration %s of receiver %s does not exist", d.IntegrationUID, receiver.Name))
+	}
+
+	tmpl, err := am.Base.GetTemplate()
+	if err != nil {
+		return models.NotificationReplayResult{}, fmt.Errorf("failed to get template: %w", err)
+	}
+	integrations, err := am.buildReceiverIntegrations(&alertingNotify.APIReceiver{
+		ConfigReceiver: receiver.Receiver,
+		GrafanaIntegrations: alertingNotify.GrafanaIntegrations{
+			Integrations: []*alertingNotify.GrafanaIntegrationConfig{PostableGrafanaReceiverToGrafanaIntegrationConfig(cfg)},
+		},
+	}, tmpl)
+	if err != nil {
+		return models.NotificationReplayResult{}, fmt.Errorf("failed to build integration: %w", err)
+	}
+	if len(integrations) != 1 {
+		return models.NotificationReplayResult{}, errors.New("failed to build integration")
+	}
+
+	now := time.Now()
+	alerts := make([]*types.Alert, 0, len(payload.Alerts))
+	for _, a := range payload.Alerts {
+		alerts = append(alerts, &types.Alert{Alert: a, UpdatedAt: now})
+	}
+	replay := &notificationReplay{of: d.ID}
+	ctx = notify.WithGroupKey(ctx, d.GroupKey)
+	ctx = notify.WithGroupLabels(ctx, payload.GroupLabels)
+	ctx = notify.WithReceiverName(ctx, receiver.Name)
+	ctx = notify.WithNow(ctx, now)
+	ctx = withNotificationReplay(ctx, replay)
+
+	_, err = integrations[0].Notify(ctx, alerts...)
+	if am.deliveries == nil {
+		// The attempt was not recorded, so the result is taken from the integration.
+		replay.result = models.NotificationReplayResult{Status: models.NotificationDeliveryStatusSuccess}
+		if err != nil {
+			replay.result = models.NotificationReplayResult{Status: models.NotificationDeliveryStatusFailed, Error: err.Error()}
+		}
+	}
+	return replay.result, nil
+}
+
+// NotificationReplayer sends the notifications of failed deliveries again. It is implemented by
+// MultiOrgAlertmanager.
+type NotificationReplayer interface {
+	ReplayNotificationDelivery(ctx context.Context, d models.NotificationDelivery) (models.NotificationReplayResult, error)
+}
+
+// NotificationDeliveryService provides access to the log of notification deliveries and replays failed deliveries.
+type NotificationDeliveryService struct {
+	store    NotificationDeliveryStore
+	replayer NotificationReplayer
+	log      log.Logger
+}
+
+func NewNotificationDeliveryService(store NotificationDeliveryStore, replayer NotificationReplayer, log log.Logger) *NotificationDeliveryService {
+	return &NotificationDeliveryService{
+		store:    store,
+		replayer: replayer,
+		log:      log,
+	}
+}
+
+func (s *NotificationDeliveryService) GetNotificationDelivery(ctx context.Context, orgID int64, id int64) (*models.NotificationDelivery, error) {
+	return s.store.GetNotificationDelivery(ctx, orgID, id)
+}
+
+func (s *NotificationDeliveryService) ListNotificationDeliveries(ctx context.Context, query models.ListNotificationDeliveriesQuery) ([]*models.NotificationDelivery, error) {
+	return s.store.ListNotificationDeliveries(ctx, query)
+}
+
+// ReplayNotificationDelivery sends the notification of the failed delivery again with the same integration. Only
+// failed deliveries whose payload was recorded in full can be replayed, and only once. The delivery is marked as
+// replayed before the notification is sent, so that concurrent replays of the same delivery are rejected.
+func (s *NotificationDeliveryService) ReplayNotificationDelivery(ctx context.Context, orgID int64, id int64) (models.NotificationReplayResult, error) {
+	d, err := s.store.GetNotificationDelivery(ctx, orgID, id)
+	if err != nil {
+		return models.NotificationReplayResult{}, err
+	}
+	if err := d.Replayable(); err != nil {
+		if errors.Is(err, models.ErrNotificationDeliveryAlreadyReplayed) {
+			return models.NotificationReplayResult{}, err
+		}
+		return models.NotificationReplayResult{}, models.ErrNotificationDeliveryNotReplayable(err)
+	}
+	if err := s.store.MarkNotificationDeliveryReplayed(ctx, orgID, id, time.Now()); err != nil {
+		return models.NotificationReplayResult{}, err
+	}
+	result, err := s.replayer.ReplayNotificationDelivery(ctx, *d)
+	if err != nil {
+		// The notification was not sent, so the delivery can be replayed again.
+		if unmarkErr := s.store.UnmarkNotificationDeliveryReplayed(ctx, orgID, id); unmarkErr != nil {
+			s.log.Error("Failed to unmark the notification delivery as replayed", "orgID", orgID, "id", id, "error", unmarkErr)
+		}
+		return models.NotificationReplayResult{}, err
+	}
+	s.log.Info("Replayed notification delivery", "orgID", orgID, "id", id, "receiver", d.Receiver, "integration", d.IntegrationUID, "status", result.Status)
+	return result, nil
+}
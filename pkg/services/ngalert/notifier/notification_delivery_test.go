@@ -0,0 +1,329 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	alertingNotify "github.com/grafana/alerting/notify"
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+type fakeNotificationDeliveryStore struct {
+	mtx        sync.Mutex
+	deliveries []models.NotificationDelivery
+	deleted    []time.Time
+}
+
+func (f *fakeNotificationDeliveryStore) InsertNotificationDeliveries(_ context.Context, deliveries []models.NotificationDelivery) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	for _, d := range deliveries {
+		d.ID = int64(len(f.deliveries) + 1)
+		f.deliveries = append(f.deliveries, d)
+	}
+	return nil
+}
+
+func (f *fakeNotificationDeliveryStore) GetNotificationDelivery(_ context.Context, orgID int64, id int64) (*models.NotificationDelivery, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	for _, d := range f.deliveries {
+		if d.OrgID == orgID && d.ID == id {
+			return &d, nil
+		}
+	}
+	return nil, models.ErrNotificationDeliveryNotFound.Errorf("")
+}
+
+func (f *fakeNotificationDeliveryStore) ListNotificationDeliveries(_ context.Context, query models.ListNotificationDeliveriesQuery) ([]*models.NotificationDelivery, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	var result []*models.NotificationDelivery
+	for _, d := range f.deliveries {
+		if d.OrgID == query.OrgID {
+			result = append(result, &d)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeNotificationDeliveryStore) MarkNotificationDeliveryReplayed(_ context.Context, orgID int64, id int64, at time.Time) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	for i, d := range f.deliveries {
+		if d.OrgID == orgID && d.ID == id {
+			if d.ReplayedAt != nil {
+				return models.ErrNotificationDeliveryAlreadyReplayed
+			}
+			f.deliveries[i].ReplayedAt = &at
+		}
+	}
+	return nil
+}
+
+func (f *fakeNotificationDeliveryStore) UnmarkNotificationDeliveryReplayed(_ context.Context, orgID int64, id int64) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	for i, d := range f.deliveries {
+		if d.OrgID == orgID && d.ID == id {
+			f.deliveries[i].ReplayedAt = nil
+		}
+	}
+	return nil
+}
+
+func (f *fakeNotificationDeliveryStore) DeleteNotificationDeliveriesBefore(_ context.Context, before time.Time) (int64, error) {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.deleted = append(f.deleted, before)
+	return 0, nil
+}
+
+func (f *fakeNotificationDeliveryStore) count() int {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	return len(f.deliveries)
+}
+
+// fakeIntegrationNotifier fails the given number of times before it succeeds.
+type fakeIntegrationNotifier struct {
+	failures int
+	calls    int
+}
+
+func (f *fakeIntegrationNotifier) Notify(_ context.Context, _ ...*types.Alert) (bool, error) {
+	f.calls++
+	if f.calls <= f.failures {
+		return true, errors.New("503 Service Unavailable")
+	}
+	return false, nil
+}
+
+func (f *fakeIntegrationNotifier) SendResolved() bool {
+	return true
+}
+
+func recordedIntegration(t *testing.T, l *NotificationDeliveryLog, n *fakeIntegrationNotifier) *alertingNotify.Integration {
+	t.Helper()
+	receiver := &alertingNotify.APIReceiver{
+		ConfigReceiver: config.Receiver{Name: "pager"},
+		GrafanaIntegrations: alertingNotify.GrafanaIntegrations{
+			Integrations: []*alertingNotify.GrafanaIntegrationConfig{
+				{UID: "slack-uid", Name: "pager", Type: "slack"},
+				{UID: "pd-uid", Name: "pager", Type: "pagerduty"},
+			},
+		},
+	}
+	integrations := l.recordIntegrations(1, receiver, []*alertingNotify.Integration{alertingNotify.NewIntegration(n, n, "pagerduty", 0, "pager")})
+	require.Len(t, integrations, 1)
+	return integrations[0]
+}
+
+func recordedDeliveries(l *NotificationDeliveryLog) []models.NotificationDelivery {
+	var result []models.NotificationDelivery
+	for {
+		select {
+		case d := <-l.deliveries:
+			result = append(result, d)
+		default:
+			return result
+		}
+	}
+}
+
+func TestNotificationDeliveryRecorder(t *testing.T) {
+	alert := &types.Alert{Alert: model.Alert{Labels: model.LabelSet{"alertname": "Down", "instance": "a"}}}
+	flushCtx := func(now time.Time) context.Context {
+		ctx := notify.WithGroupKey(context.Background(), `{}:{alertname="Down"}`)
+		ctx = notify.WithGroupLabels(ctx, model.LabelSet{"alertname": "Down"})
+		ctx = notify.WithReceiverName(ctx, "pager")
+		return notify.WithNow(ctx, now)
+	}
+
+	t.Run("records every attempt of a notification", func(t *testing.T) {
+		l := NewNotificationDeliveryLog(&fakeNotificationDeliveryStore{}, 0, prometheus.NewCounter(prometheus.CounterOpts{}), clock.NewMock(), log.NewNopLogger())
+		integration := recordedIntegration(t, l, &fakeIntegrationNotifier{failures: 2})
+
+		ctx := flushCtx(time.Now())
+		for i := 0; i < 3; i++ {
+			_, _ = integration.Notify(ctx, alert)
+		}
+
+		deliveries := recordedDeliveries(l)
+		require.Len(t, deliveries, 3)
+		for i, d := range deliveries {
+			assert.Equal(t, i+1, d.Attempt)
+			assert.Equal(t, int64(1), d.OrgID)
+			assert.Equal(t, "pager", d.Receiver)
+			assert.Equal(t, "pd-uid", d.IntegrationUID)
+			assert.Equal(t, "pagerduty", d.IntegrationType)
+			assert.Equal(t, `{}:{alertname="Down"}`, d.GroupKey)
+			assert.Equal(t, 1, d.AlertCount)
+		}
+		assert.Equal(t, models.NotificationDeliveryStatusFailed, deliveries[0].Status)
+		assert.Equal(t, "503 Service Unavailable", deliveries[0].Error)
+		assert.Equal(t, models.NotificationDeliveryStatusSuccess, deliveries[2].Status)
+		assert.Empty(t, deliveries[2].Error)
+
+		payload, err := deliveries[0].NotificationPayload()
+		require.NoError(t, err)
+		assert.Equal(t, model.LabelSet{"alertname": "Down"}, payload.GroupLabels)
+		require.Len(t, payload.Alerts, 1)
+		assert.Equal(t, alert.Labels, payload.Alerts[0].Labels)
+	})
+
+	t.Run("counts the attempts of every flush", func(t *testing.T) {
+		l := NewNotificationDeliveryLog(&fakeNotificationDeliveryStore{}, 0, prometheus.NewCounter(prometheus.CounterOpts{}), clock.NewMock(), log.NewNopLogger())
+		integration := recordedIntegration(t, l, &fakeIntegrationNotifier{failures: 1})
+
+		now := time.Now()
+		_, _ = integration.Notify(flushCtx(now), alert)
+		_, _ = integration.Notify(flushCtx(now), alert)
+		_, _ = integration.Notify(flushCtx(now.Add(time.Minute)), alert)
+
+		deliveries := recordedDeliveries(l)
+		require.Len(t, deliveries, 3)
+		assert.Equal(t, []int{1, 2, 1}, []int{deliveries[0].Attempt, deliveries[1].Attempt, deliveries[2].Attempt})
+	})
+
+	t.Run("records the result of a replay", func(t *testing.T) {
+		l := NewNotificationDeliveryLog(&fakeNotificationDeliveryStore{}, 0, prometheus.NewCounter(prometheus.CounterOpts{}), clock.NewMock(), log.NewNopLogger())
+		integration := recordedIntegration(t, l, &fakeIntegrationNotifier{failures: 1})
+
+		replay := &notificationReplay{of: 42}
+		_, err := integration.Notify(withNotificationReplay(flushCtx(time.Now()), replay), alert)
+		require.Error(t, err)
+
+		deliveries := recordedDeliveries(l)
+		require.Len(t, deliveries, 1)
+		assert.Equal(t, int64(42), deliveries[0].ReplayOf)
+		assert.Equal(t, models.NotificationReplayResult{Status: models.NotificationDeliveryStatusFailed, Error: "503 Service Unavailable"}, replay.result)
+	})
+}
+
+func TestNotificationDeliveryLogRun(t *testing.T) {
+	store := &fakeNotificationDeliveryStore{}
+	clk := clock.NewMock()
+	l := NewNotificationDeliveryLog(store, 24*time.Hour, prometheus.NewCounter(prometheus.CounterOpts{}), clk, log.NewNopLogger())
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		require.NoError(t, l.Run(ctx))
+	}()
+
+	l.Record(models.NotificationDelivery{OrgID: 1, Receiver: "pager"})
+	l.Record(models.NotificationDelivery{OrgID: 1, Receiver: "pager"})
+	require.Eventually(t, func() bool { return store.count() == 2 }, time.Second, 10*time.Millisecond)
+
+	clk.Add(notificationDeliveryCleanupInterval)
+	require.Eventually(t, func() bool {
+		store.mtx.Lock()
+		defer store.mtx.Unlock()
+		return len(store.deleted) == 1
+	}, time.Second, 10*time.Millisecond)
+	assert.Equal(t, clk.Now().Add(-24*time.Hour), store.deleted[0])
+
+	cancel()
+	<-done
+}
+
+func TestNotificationDeliveryLogRecord(t *testing.T) {
+	t.Run("waits for room in the queue", func(t *testing.T) {
+		dropped := prometheus.NewCounter(prometheus.CounterOpts{})
+		l := NewNotificationDeliveryLog(&fakeNotificationDeliveryStore{}, 0, dropped, clock.NewMock(), log.NewNopLogger())
+		for i := 0; i < notificationDeliveryQueueSize; i++ {
+			l.Record(models.NotificationDelivery{OrgID: 1, Receiver: "pager"})
+		}
+
+		recorded := make(chan struct{})
+		go func() {
+			defer close(recorded)
+			l.Record(models.NotificationDelivery{OrgID: 1, Receiver: "overflow"})
+		}()
+		select {
+		case <-recorded:
+			t.Fatal("expected the delivery to wait while the queue is full")
+		case <-time.After(50 * time.Millisecond):
+		}
+
+		<-l.deliveries
+		<-recorded
+		assert.Len(t, l.deliveries, notificationDeliveryQueueSize)
+		assert.Zero(t, testutil.ToFloat64(dropped))
+	})
+
+	t.Run("drops the delivery after the timeout", func(t *testing.T) {
+		dropped := prometheus.NewCounter(prometheus.CounterOpts{})
+		l := NewNotificationDeliveryLog(&fakeNotificationDeliveryStore{}, 0, dropped, clock.NewMock(), log.NewNopLogger())
+		l.recordTimeout = 10 * time.Millisecond
+		for i := 0; i < notificationDeliveryQueueSize+2; i++ {
+			l.Record(models.NotificationDelivery{OrgID: 1, Receiver: "pager"})
+		}
+
+		assert.Len(t, l.deliveries, notificationDeliveryQueueSize)
+		assert.Equal(t, 2.0, testutil.ToFloat64(dropped))
+	})
+}
+
+type fakeNotificationReplayer struct {
+	replayed []models.NotificationDelivery
+}
+
+func (f *fakeNotificationReplayer) ReplayNotificationDelivery(_ context.Context, d models.NotificationDelivery) (models.NotificationReplayResult, error) {
+	f.replayed = append(f.replayed, d)
+	return models.NotificationReplayResult{Status: models.NotificationDeliveryStatusSuccess}, nil
+}
+
+func TestNotificationDeliveryServiceReplay(t *testing.T) {
+	store := &fakeNotificationDeliveryStore{}
+	require.NoError(t, store.InsertNotificationDeliveries(context.Background(), []models.NotificationDelivery{
+		{OrgID: 1, Receiver: "pager", IntegrationUID: "pd-uid", Status: models.NotificationDeliveryStatusFailed, Payload: `{"alerts":[]}`},
+		{OrgID: 1, Receiver: "pager", IntegrationUID: "pd-uid", Status: models.NotificationDeliveryStatusSuccess, Payload: `{"alerts":[]}`},
+	}))
+	replayer := &fakeNotificationReplayer{}
+	svc := NewNotificationDeliveryService(store, replayer, log.NewNopLogger())
+
+	t.Run("replays a failed delivery", func(t *testing.T) {
+		result, err := svc.ReplayNotificationDelivery(context.Background(), 1, 1)
+		require.NoError(t, err)
+		assert.Equal(t, models.NotificationDeliveryStatusSuccess, result.Status)
+		require.Len(t, replayer.replayed, 1)
+		assert.Equal(t, int64(1), replayer.replayed[0].ID)
+
+		d, err := store.GetNotificationDelivery(context.Background(), 1, 1)
+		require.NoError(t, err)
+		require.NotNil(t, d.ReplayedAt)
+	})
+
+	t.Run("rejects a delivery that was already replayed", func(t *testing.T) {
+		_, err := svc.ReplayNotificationDelivery(context.Background(), 1, 1)
+		require.ErrorIs(t, err, models.ErrNotificationDeliveryAlreadyReplayed)
+		require.Len(t, replayer.replayed, 1)
+	})
+
+	t.Run("rejects a successful delivery", func(t *testing.T) {
+		_, err := svc.ReplayNotificationDelivery(context.Background(), 1, 2)
+		require.ErrorIs(t, err, models.ErrNotificationDeliveryNotReplayableBase)
+	})
+
+	t.Run("returns not found for a delivery of another organization", func(t *testing.T) {
+		_, err := svc.ReplayNotificationDelivery(context.Background(), 2, 1)
+		require.ErrorIs(t, err, models.ErrNotificationDeliveryNotFound)
+	})
+}
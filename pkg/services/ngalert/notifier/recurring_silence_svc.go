@@ -0,0 +1,280 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/benbjohnson/clock"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+const (
+	// recurringSilenceInterval is how often the occurrences of the recurring silences are checked.
+	recurringSilenceInterval = time.Minute
+	// recurringSilenceLookahead is how long before an occurrence starts its silence is created, so that the
+	// silence is shown as pending.
+	recurringSilenceLookahead = time.Hour
+)
+
+// RecurringSilenceStore is the store of the recurring silences.
+type RecurringSilenceStore interface {
+	GetRecurringSilence(ctx context.Context, orgID int64, uid string) (*models.RecurringSilence, error)
+	ListRecurringSilences(ctx context.Context, orgID int64) ([]*models.RecurringSilence, error)
+	InsertRecurringSilence(ctx context.Context, s models.RecurringSilence) (*models.RecurringSilence, error)
+	UpdateRecurringSilence(ctx context.Context, s models.RecurringSilence) (*models.RecurringSilence, error)
+	DeleteRecurringSilence(ctx context.Context, orgID int64, uid string) error
+	SetRecurringSilenceOccurrence(ctx context.Context, s models.RecurringSilence, occurrence models.RecurringSilenceOccurrence) (bool, error)
+}
+
+// RecurringSilenceService is the authenticated service for managing recurring silences. It also creates the silence of
+// every occurrence of the recurring silences in the Alertmanager of their organization.
+type RecurringSilenceService struct {
+	authz    SilenceAccessControlService
+	store    RecurringSilenceStore
+	silences SilenceStore
+	clock    clock.Clock
+	log      log.Logger
+}
+
+func NewRecurringSilenceService(
+	authz SilenceAccessControlService,
+	store RecurringSilenceStore,
+	silences SilenceStore,
+	clock clock.Clock,
+	log log.Logger,
+) *RecurringSilenceService {
+	return &RecurringSilenceService{
+		authz:    authz,
+		store:    store,
+		silences: silences,
+		clock:    clock,
+		log:      log,
+	}
+}
+
+// GetRecurringSilence returns the recurring silence with the given UID.
+// The user needs permission to read the silences of the recurring silence.
+func (s *RecurringSilenceService) GetRecurringSilence(ctx context.Context, user identity.Requester, uid string) (*models.RecurringSilence, error) {
+	rs, err := s.store.GetRecurringSilence(ctx, user.GetOrgID(), uid)
+	if err != nil {
+		return nil, err
+	}
+
+	silence := silenceForAccessControl(*rs)
+	if err := s.authz.AuthorizeReadSilence(ctx, user, &silence); err != nil {
+		return nil, err
+	}
+
+	return rs, nil
+}
+
+// ListRecurringSilences returns the recurring silences whose silences the user can read.
+func (s *RecurringSilenceService) ListRecurringSilences(ctx context.Context, user identity.Requester) ([]*models.RecurringSilence, error) {
+	all, err := s.store.ListRecurringSilences(ctx, user.GetOrgID())
+	if err != nil {
+		return nil, err
+	}
+
+	byUID := make(map[string]*models.RecurringSilence, len(all))
+	silences := make([]*models.Silence, 0, len(all))
+	for _, rs := range all {
+		byUID[rs.UID] = rs
+		silence := silenceForAccessControl(*rs)
+		silences = append(silences, &silence)
+	}
+	allowed, err := s.authz.FilterByAccess(ctx, user, silences...)
+	if err != nil {
+		return nil, err
+	}
+
+	result := make([]*models.RecurringSilence, 0, len(allowed))
+	for _, silence := range allowed {
+		result = append(result, byUID[*silence.ID])
+	}
+	return result, nil
+}
+
+// CreateRecurringSilence creates a recurring silence with a new UID.
+// The user needs the same permissions as for creating its silences.
+func (s *RecurringSilenceService) CreateRecurringSilence(ctx context.Context, user identity.Requester, rs models.RecurringSilence) (*models.RecurringSilence, error) {
+	if err := rs.Validate(); err != nil {
+		return nil, models.ErrRecurringSilenceInvalid(err)
+	}
+
+	silence := silenceForAccessControl(rs)
+	if err := s.authz.AuthorizeCreateSilence(ctx, user, &silence); err != nil {
+		return nil, err
+	}
+
+	rs.OrgID = user.GetOrgID()
+	rs.UID = util.GenerateShortUID()
+	return s.store.InsertRecurringSilence(ctx, rs)
+}
+
+// UpdateRecurringSilence updates the recurring silence and expires the silence of its current occurrence. The silence
+// of the current occurrence of the new schedule is created with the next check.
+// The version must be the current version of the recurring silence, otherwise ErrRecurringSilenceConflict is returned.
+// The user needs the same permissions as for updating its silences.
+func (s *RecurringSilenceService) UpdateRecurringSilence(ctx context.Context, user identity.Requester, rs models.RecurringSilence) (*models.RecurringSilence, error) {
+	if err := rs.Validate(); err != nil {
+		return nil, models.ErrRecurringSilenceInvalid(err)
+	}
+
+	silence := silenceForAccessControl(rs)
+	if err := s.authz.AuthorizeUpdateSilence(ctx, user, &silence); err != nil {
+		return nil, err
+	}
+
+	existing, err := s.store.GetRecurringSilence(ctx, user.GetOrgID(), rs.UID)
+	if err != nil {
+		return nil, err
+	}
+	if rs.Version != existing.Version {
+		return nil, models.ErrRecurringSilenceConflict.Errorf("provided version %d of recurring silence %s does not match current version %d", rs.Version, rs.UID, existing.Version)
+	}
+
+	existingSilence := silenceForAccessControl(*existing)
+	if err := validateSilenceUpdate(&existingSilence, silence); err != nil {
+		return nil, err
+	}
+
+	rs.ID = existing.ID
+	rs.OrgID = existing.OrgID
+	updated, err := s.store.UpdateRecurringSilence(ctx, rs)
+	if err != nil {
+		if errors.Is(err, store.ErrOptimisticLock) {
+			return nil, models.ErrRecurringSilenceConflict.Errorf("recurring silence %s was updated concurrently: %w", rs.UID, err)
+		}
+		return nil, err
+	}
+
+	s.expireOccurrence(identity.WithRequester(ctx, user), existing.OrgID, existing.LastOccurrence)
+	return updated, nil
+}
+
+// DeleteRecurringSilence deletes the recurring silence and expires the silence of its current occurrence.
+// The user needs the same permissions as for updating its silences.
+func (s *RecurringSilenceService) DeleteRecurringSilence(ctx context.Context, user identity.Requester, uid string) error {
+	rs, err := s.GetRecurringSilence(ctx, user, uid)
+	if err != nil {
+		return err
+	}
+
+	silence := silenceForAccessControl(*rs)
+	if err := s.authz.AuthorizeUpdateSilence(ctx, user, &silence); err != nil {
+		return err
+	}
+
+	if err := s.store.DeleteRecurringSilence(ctx, rs.OrgID, uid); err != nil {
+		return err
+	}
+
+	s.expireOccurrence(identity.WithRequester(ctx, user), rs.OrgID, rs.LastOccurrence)
+	return nil
+}
+
+// Run creates the silences of the occurrences of the recurring silences until the context is done.
+func (s *RecurringSilenceService) Run(ctx context.Context) error {
+	ticker := s.clock.Ticker(recurringSilenceInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.CreateOccurrences(ctx)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// CreateOccurrences creates the silences of the occurrences of the recurring silences of all organizations that are
+// active or start soon.
+func (s *RecurringSilenceService) CreateOccurrences(ctx context.Context) {
+	all, err := s.store.ListRecurringSilences(ctx, 0)
+	if err != nil {
+		s.log.Error("Failed to list recurring silences", "error", err)
+		return
+	}
+
+	now := s.clock.Now()
+	for _, rs := range all {
+		if err := s.createOccurrence(ctx, *rs, now); err != nil {
+			s.log.Error("Failed to create the silence of a recurring silence", "orgID", rs.OrgID, "uid", rs.UID, "error", err)
+		}
+	}
+}
+
+// createOccurrence creates the silence of the next occurrence of the recurring silence. The occurrence is claimed in
+// the database first, so that only one replica creates its silence.
+func (s *RecurringSilenceService) createOccurrence(ctx context.Context, rs models.RecurringSilence, now time.Time) error {
+	from := now
+	if rs.LastOccurrence.EndsAt.After(from) {
+		from = rs.LastOccurrence.EndsAt
+	}
+	occurrence, ok, err := rs.NextOccurrence(from, now.Add(recurringSilenceLookahead))
+	if err != nil || !ok {
+		return err
+	}
+	// The silence of an active occurrence starts now, and occurrences do not overlap.
+	if occurrence.StartsAt.Before(from) {
+		occurrence.StartsAt = from
+	}
+
+	claimed, err := s.store.SetRecurringSilenceOccurrence(ctx, rs, occurrence)
+	if err != nil {
+		return fmt.Errorf("failed to claim occurrence: %w", err)
+	}
+	if !claimed {
+		return nil
+	}
+	previous := rs.LastOccurrence
+	rs.LastOccurrence = occurrence
+
+	silenceID, err := s.silences.CreateSilence(ctx, rs.OrgID, rs.Silence(occurrence))
+	if err != nil {
+		// Release the occurrence to create its silence with the next check.
+		if _, releaseErr := s.store.SetRecurringSilenceOccurrence(ctx, rs, previous); releaseErr != nil {
+			s.log.Error("Failed to release the occurrence of a recurring silence", "orgID", rs.OrgID, "uid", rs.UID, "error", releaseErr)
+		}
+		return fmt.Errorf("failed to create silence: %w", err)
+	}
+	occurrence.SilenceID = silenceID
+
+	saved, err := s.store.SetRecurringSilenceOccurrence(ctx, rs, occurrence)
+	if err != nil {
+		return fmt.Errorf("failed to save silence %s: %w", silenceID, err)
+	}
+	if !saved {
+		// The recurring silence was updated or deleted while the silence was created.
+		s.expireOccurrence(ctx, rs.OrgID, occurrence)
+		return nil
+	}
+	s.log.Info("Created the silence of a recurring silence", "orgID", rs.OrgID, "uid", rs.UID, "silenceID", silenceID, "startsAt", occurrence.StartsAt, "endsAt", occurrence.EndsAt)
+	return nil
+}
+
+// expireOccurrence expires the silence of the occurrence if it is pending or active.
+func (s *RecurringSilenceService) expireOccurrence(ctx context.Context, orgID int64, occurrence models.RecurringSilenceOccurrence) {
+	if occurrence.SilenceID == "" || !occurrence.EndsAt.After(s.clock.Now()) {
+		return
+	}
+	if err := s.silences.DeleteSilence(ctx, orgID, occurrence.SilenceID); err != nil && !errors.Is(err, ErrSilenceNotFound) {
+		s.log.Warn("Failed to expire the silence of a recurring silence", "orgID", orgID, "silenceID", occurrence.SilenceID, "error", err)
+	}
+}
+
+// silenceForAccessControl returns a silence with the matchers of the recurring silence. Access to a recurring silence
+// is the same as access to its silences.
+func silenceForAccessControl(rs models.RecurringSilence) models.Silence {
+	silence := rs.Silence(models.RecurringSilenceOccurrence{})
+	uid := rs.UID
+	silence.ID = &uid
+	return silence
+}
@@ -0,0 +1,233 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/stretchr/testify/require"
+
+	alertingmodels "github.com/grafana/alerting/models"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/log"
+	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/ngalert/accesscontrol/fakes"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	ngstore "github.com/grafana/grafana/pkg/services/ngalert/store"
+	ngfakes "github.com/grafana/grafana/pkg/services/ngalert/tests/fakes"
+	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+type fakeRecurringSilenceStore struct {
+	silences map[string]models.RecurringSilence
+	// beforeUpdate is called before a recurring silence is updated, to simulate concurrent updates.
+	beforeUpdate func()
+}
+
+func (f *fakeRecurringSilenceStore) GetRecurringSilence(_ context.Context, orgID int64, uid string) (*models.RecurringSilence, error) {
+	s, ok := f.silences[uid]
+	if !ok || s.OrgID != orgID {
+		return nil, models.ErrRecurringSilenceNotFound.Errorf("")
+	}
+	return &s, nil
+}
+
+func (f *fakeRecurringSilenceStore) ListRecurringSilences(_ context.Context, orgID int64) ([]*models.RecurringSilence, error) {
+	result := make([]*models.RecurringSilence, 0, len(f.silences))
+	for _, s := range f.silences {
+		if orgID == 0 || s.OrgID == orgID {
+			result = append(result, &s)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeRecurringSilenceStore) InsertRecurringSilence(_ context.Context, s models.RecurringSilence) (*models.RecurringSilence, error) {
+	s.Version = 1
+	f.silences[s.UID] = s
+	return &s, nil
+}
+
+func (f *fakeRecurringSilenceStore) UpdateRecurringSilence(_ context.Context, s models.RecurringSilence) (*models.RecurringSilence, error) {
+	if f.beforeUpdate != nil {
+		f.beforeUpdate()
+	}
+	if f.silences[s.UID].Version != s.Version {
+		return nil, fmt.Errorf("%w: recurring silence UID %s version %d", ngstore.ErrOptimisticLock, s.UID, s.Version)
+	}
+	s.Version++
+	s.LastOccurrence = models.RecurringSilenceOccurrence{}
+	f.silences[s.UID] = s
+	return &s, nil
+}
+
+func (f *fakeRecurringSilenceStore) DeleteRecurringSilence(_ context.Context, _ int64, uid string) error {
+	delete(f.silences, uid)
+	return nil
+}
+
+func (f *fakeRecurringSilenceStore) SetRecurringSilenceOccurrence(_ context.Context, s models.RecurringSilence, occurrence models.RecurringSilenceOccurrence) (bool, error) {
+	stored, ok := f.silences[s.UID]
+	if !ok || stored.Version != s.Version || stored.LastOccurrence != s.LastOccurrence {
+		return false, nil
+	}
+	stored.LastOccurrence = occurrence
+	f.silences[s.UID] = stored
+	return true, nil
+}
+
+func weeklyRecurringSilence() models.RecurringSilence {
+	return models.RecurringSilence{
+		OrgID: 1,
+		UID:   "patching",
+		Matchers: amv2.Matchers{
+			{Name: util.Pointer("host"), Value: util.Pointer("db-1"), IsEqual: util.Pointer(true), IsRegex: util.Pointer(false)},
+		},
+		Comment:   "Weekly patching",
+		CreatedBy: "ops",
+		Cron:      "0 2 * * 0",
+		Duration:  2 * time.Hour,
+		Version:   1,
+	}
+}
+
+func TestRecurringSilenceCreateOccurrences(t *testing.T) {
+	// Sunday 1 March 2026.
+	sunday := time.Date(2026, time.March, 1, 0, 0, 0, 0, time.UTC)
+	start := sunday.Add(2 * time.Hour)
+
+	setup := func(now time.Time) (*RecurringSilenceService, *fakeRecurringSilenceStore, *ngfakes.FakeSilenceStore) {
+		clk := clock.NewMock()
+		clk.Set(now)
+		store := &fakeRecurringSilenceStore{silences: map[string]models.RecurringSilence{"patching": weeklyRecurringSilence()}}
+		silences := &ngfakes.FakeSilenceStore{Silences: map[string]*models.Silence{}}
+		svc := NewRecurringSilenceService(&fakes.FakeSilenceService{}, store, silences, clk, log.NewNopLogger())
+		return svc, store, silences
+	}
+
+	t.Run("should not create a silence before the lookahead", func(t *testing.T) {
+		svc, _, silences := setup(start.Add(-recurringSilenceLookahead - time.Minute))
+		svc.CreateOccurrences(context.Background())
+		require.Empty(t, silences.Silences)
+	})
+
+	t.Run("should create a pending silence within the lookahead once", func(t *testing.T) {
+		svc, store, silences := setup(start.Add(-30 * time.Minute))
+		svc.CreateOccurrences(context.Background())
+		svc.CreateOccurrences(context.Background())
+		require.Len(t, silences.Silences, 1)
+
+		last := store.silences["patching"].LastOccurrence
+		silence := silences.Silences[last.SilenceID]
+		require.NotNil(t, silence)
+		require.True(t, start.Equal(time.Time(*silence.StartsAt)))
+		require.True(t, start.Add(2*time.Hour).Equal(time.Time(*silence.EndsAt)))
+		require.Equal(t, "Weekly patching", *silence.Comment)
+		require.Equal(t, weeklyRecurringSilence().Matchers, silence.Matchers)
+	})
+
+	t.Run("should create the silence of an active occurrence from now", func(t *testing.T) {
+		now := start.Add(time.Hour)
+		svc, store, silences := setup(now)
+		svc.CreateOccurrences(context.Background())
+		require.Len(t, silences.Silences, 1)
+		silence := silences.Silences[store.silences["patching"].LastOccurrence.SilenceID]
+		require.True(t, now.Equal(time.Time(*silence.StartsAt)))
+	})
+
+	t.Run("should create the silence of the next occurrence when the last one ended", func(t *testing.T) {
+		svc, store, silences := setup(start.Add(-30 * time.Minute))
+		svc.CreateOccurrences(context.Background())
+		svc.clock.(*clock.Mock).Set(start.AddDate(0, 0, 7).Add(-30 * time.Minute))
+		svc.CreateOccurrences(context.Background())
+		require.Len(t, silences.Silences, 2)
+		last := store.silences["patching"].LastOccurrence
+		require.True(t, start.AddDate(0, 0, 7).Equal(last.StartsAt))
+	})
+}
+
+func TestRecurringSilenceUpdate(t *testing.T) {
+	user := ac.BackgroundUser("test", 1, org.RoleNone, nil)
+	now := time.Date(2026, time.March, 1, 3, 1, 0, 0, time.UTC)
+
+	t.Run("should expire the silence of the current occurrence", func(t *testing.T) {
+		clk := clock.NewMock()
+		clk.Set(now)
+		store := &fakeRecurringSilenceStore{silences: map[string]models.RecurringSilence{"patching": weeklyRecurringSilence()}}
+		silences := &ngfakes.FakeSilenceStore{Silences: map[string]*models.Silence{}}
+		svc := NewRecurringSilenceService(&fakes.FakeSilenceService{}, store, silences, clk, log.NewNopLogger())
+		svc.CreateOccurrences(context.Background())
+		require.Len(t, silences.Silences, 1)
+
+		updated := weeklyRecurringSilence()
+		updated.Cron = "0 4 * * 0"
+		result, err := svc.UpdateRecurringSilence(context.Background(), user, updated)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, result.Version)
+		require.Empty(t, silences.Silences)
+
+		// The silence of the next occurrence of the new schedule is created.
+		svc.CreateOccurrences(context.Background())
+		require.Len(t, silences.Silences, 1)
+	})
+
+	t.Run("should return a conflict if the version is not current", func(t *testing.T) {
+		store := &fakeRecurringSilenceStore{silences: map[string]models.RecurringSilence{"patching": weeklyRecurringSilence()}}
+		svc := NewRecurringSilenceService(&fakes.FakeSilenceService{}, store, &ngfakes.FakeSilenceStore{}, clock.NewMock(), log.NewNopLogger())
+
+		for _, version := range []int64{0, 2} {
+			updated := weeklyRecurringSilence()
+			updated.Cron = "0 4 * * 0"
+			updated.Version = version
+			_, err := svc.UpdateRecurringSilence(context.Background(), user, updated)
+			require.ErrorIs(t, err, models.ErrRecurringSilenceConflict)
+		}
+		require.Equal(t, weeklyRecurringSilence(), store.silences["patching"])
+	})
+
+	t.Run("should return a conflict if the recurring silence is updated concurrently", func(t *testing.T) {
+		store := &fakeRecurringSilenceStore{silences: map[string]models.RecurringSilence{"patching": weeklyRecurringSilence()}}
+		svc := NewRecurringSilenceService(&fakes.FakeSilenceService{}, store, &ngfakes.FakeSilenceStore{}, clock.NewMock(), log.NewNopLogger())
+		store.beforeUpdate = func() {
+			concurrent := store.silences["patching"]
+			concurrent.Version++
+			store.silences["patching"] = concurrent
+		}
+
+		_, err := svc.UpdateRecurringSilence(context.Background(), user, weeklyRecurringSilence())
+		require.ErrorIs(t, err, models.ErrRecurringSilenceConflict)
+	})
+
+	t.Run("should not change the rule of a silence", func(t *testing.T) {
+		existing := weeklyRecurringSilence()
+		existing.Matchers = append(existing.Matchers, &amv2.Matcher{
+			Name: util.Pointer(alertingmodels.RuleUIDLabel), Value: util.Pointer("rule1"), IsEqual: util.Pointer(true), IsRegex: util.Pointer(false),
+		})
+		store := &fakeRecurringSilenceStore{silences: map[string]models.RecurringSilence{"patching": existing}}
+		svc := NewRecurringSilenceService(&fakes.FakeSilenceService{}, store, &ngfakes.FakeSilenceStore{}, clock.NewMock(), log.NewNopLogger())
+
+		_, err := svc.UpdateRecurringSilence(context.Background(), user, weeklyRecurringSilence())
+		require.ErrorContains(t, err, alertingmodels.RuleUIDLabel)
+	})
+
+	t.Run("should authorize the silences of the recurring silence", func(t *testing.T) {
+		authz := &fakes.FakeSilenceService{
+			AuthorizeUpdateSilenceFunc: func(ctx context.Context, user identity.Requester, silence *models.Silence) error {
+				return errors.New("forbidden")
+			},
+		}
+		store := &fakeRecurringSilenceStore{silences: map[string]models.RecurringSilence{"patching": weeklyRecurringSilence()}}
+		svc := NewRecurringSilenceService(authz, store, &ngfakes.FakeSilenceStore{}, clock.NewMock(), log.NewNopLogger())
+
+		_, err := svc.UpdateRecurringSilence(context.Background(), user, weeklyRecurringSilence())
+		require.ErrorContains(t, err, "forbidden")
+		require.ErrorContains(t, svc.DeleteRecurringSilence(context.Background(), user, "patching"), "forbidden")
+		require.Contains(t, store.silences, "patching")
+	})
+}
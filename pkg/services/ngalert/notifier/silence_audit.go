@@ -0,0 +1,117 @@
+package notifier
+
+import (
+	"context"
+	"time"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// AuditedSilenceStore is a SilenceStore that records every change of a silence in the audit log, regardless of
+// whether the change is made by a user or by Grafana itself, such as the silences of recurring silences. The actor of
+// a change is the requester of the context. Changes without a requester are recorded without an actor.
+type AuditedSilenceStore struct {
+	SilenceStore
+	audit  SilenceAuditStore
+	alerts SilenceAlertStore
+	log    log.Logger
+}
+
+func NewAuditedSilenceStore(store SilenceStore, audit SilenceAuditStore, alerts SilenceAlertStore, log log.Logger) *AuditedSilenceStore {
+	return &AuditedSilenceStore{
+		SilenceStore: store,
+		audit:        audit,
+		alerts:       alerts,
+		log:          log,
+	}
+}
+
+// CreateSilence creates the silence and records it with the labels of the alerts that it matches.
+func (s *AuditedSilenceStore) CreateSilence(ctx context.Context, orgID int64, ps models.Silence) (string, error) {
+	silenceID, err := s.SilenceStore.CreateSilence(ctx, orgID, ps)
+	if err != nil {
+		return "", err
+	}
+
+	ps.ID = &silenceID
+	entry := newSilenceAuditEntry(ctx, orgID, models.SilenceAuditActionCreate, ps)
+	alerts, err := s.alerts.ListSilenceAlerts(ctx, orgID, ps)
+	if err != nil {
+		s.log.Warn("Failed to get the alerts that match the silence", "silenceID", silenceID, "error", err)
+	}
+	if len(alerts) > models.MaxSilenceAuditAlerts {
+		alerts = alerts[:models.MaxSilenceAuditAlerts]
+	}
+	entry.MatchedAlerts = alerts
+	s.recordAudit(ctx, entry)
+
+	return silenceID, nil
+}
+
+// UpdateSilence updates the silence and records the changed fields.
+func (s *AuditedSilenceStore) UpdateSilence(ctx context.Context, orgID int64, ps models.Silence) (string, error) {
+	var existing *models.Silence
+	if ps.ID != nil && *ps.ID != "" {
+		var err error
+		if existing, err = s.SilenceStore.GetSilence(ctx, orgID, *ps.ID); err != nil {
+			return "", err
+		}
+	}
+
+	silenceID, err := s.SilenceStore.UpdateSilence(ctx, orgID, ps)
+	if err != nil {
+		return "", err
+	}
+
+	// The Alertmanager replaces an active silence with a new one if its matchers change.
+	ps.ID = &silenceID
+	entry := newSilenceAuditEntry(ctx, orgID, models.SilenceAuditActionUpdate, ps)
+	if existing != nil {
+		entry.Changes = models.DiffSilences(*existing, ps)
+	}
+	s.recordAudit(ctx, entry)
+
+	return silenceID, nil
+}
+
+// DeleteSilence expires the silence and records the silence before it expired.
+func (s *AuditedSilenceStore) DeleteSilence(ctx context.Context, orgID int64, silenceID string) error {
+	silence, err := s.SilenceStore.GetSilence(ctx, orgID, silenceID)
+	if err != nil {
+		return err
+	}
+
+	if err := s.SilenceStore.DeleteSilence(ctx, orgID, silenceID); err != nil {
+		return err
+	}
+
+	s.recordAudit(ctx, newSilenceAuditEntry(ctx, orgID, models.SilenceAuditActionExpire, *silence))
+	return nil
+}
+
+// recordAudit appends the entry to the audit log. The change of the silence is already applied, so a failure is only
+// logged.
+func (s *AuditedSilenceStore) recordAudit(ctx context.Context, entry models.SilenceAuditEntry) {
+	if err := s.audit.InsertSilenceAuditEntry(ctx, entry); err != nil {
+		s.log.Error("Failed to record the silence change in the audit log", "silenceID", entry.SilenceID, "action", entry.Action, "error", err)
+	}
+}
+
+func newSilenceAuditEntry(ctx context.Context, orgID int64, action models.SilenceAuditAction, silence models.Silence) models.SilenceAuditEntry {
+	entry := models.SilenceAuditEntry{
+		OrgID:   orgID,
+		Action:  action,
+		Created: time.Now(),
+		Silence: silence,
+	}
+	if user, err := identity.GetRequester(ctx); err == nil {
+		entry.ActorUID = user.GetUID()
+		entry.ActorLogin = user.GetLogin()
+	}
+	if silence.ID != nil {
+		entry.SilenceID = *silence.ID
+	}
+	return entry
+}
@@ -0,0 +1,249 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/prometheus/common/model"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+const (
+	// silenceExpiryNotificationInterval is how often the silences with an expiry notification are checked.
+	silenceExpiryNotificationInterval = time.Minute
+	// SilenceExpiringAlertName is the alert name of the notification that a silence expires soon.
+	SilenceExpiringAlertName = "SilenceExpiring"
+)
+
+// SilenceExpiryNotificationStore is the store of the silence expiry notifications.
+type SilenceExpiryNotificationStore interface {
+	GetSilenceExpiryNotification(ctx context.Context, orgID int64, silenceID string) (*models.SilenceExpiryNotification, error)
+	ListSilenceExpiryNotifications(ctx context.Context, orgID int64) ([]*models.SilenceExpiryNotification, error)
+	SaveSilenceExpiryNotification(ctx context.Context, n models.SilenceExpiryNotification) (*models.SilenceExpiryNotification, error)
+	DeleteSilenceExpiryNotification(ctx context.Context, orgID int64, silenceID string) error
+	SetSilenceExpiryNotified(ctx context.Context, n models.SilenceExpiryNotification, endsAt time.Time) (bool, error)
+	MoveSilenceExpiryNotification(ctx context.Context, orgID int64, fromSilenceID, toSilenceID string) error
+}
+
+// ReceiverNotifier sends notifications to the receivers of the Alertmanager of an organization. It is implemented by
+// MultiOrgAlertmanager.
+type ReceiverNotifier interface {
+	HasReceiver(ctx context.Context, orgID int64, name string) (bool, error)
+	NotifyReceiver(ctx context.Context, orgID int64, name, groupKey string, alert model.Alert) error
+}
+
+// SilenceExpiryNotificationService is the authenticated service for managing silence expiry notifications. It also
+// sends the notifications when the silences are about to expire.
+type SilenceExpiryNotificationService struct {
+	authz     SilenceAccessControlService
+	store     SilenceExpiryNotificationStore
+	silences  SilenceStore
+	receivers ReceiverNotifier
+	clock     clock.Clock
+	log       log.Logger
+}
+
+func NewSilenceExpiryNotificationService(
+	authz SilenceAccessControlService,
+	store SilenceExpiryNotificationStore,
+	silences SilenceStore,
+	receivers ReceiverNotifier,
+	clock clock.Clock,
+	log log.Logger,
+) *SilenceExpiryNotificationService {
+	return &SilenceExpiryNotificationService{
+		authz:     authz,
+		store:     store,
+		silences:  silences,
+		receivers: receivers,
+		clock:     clock,
+		log:       log,
+	}
+}
+
+// GetSilenceExpiryNotification returns the expiry notification of the silence.
+// The user needs permission to read the silence.
+func (s *SilenceExpiryNotificationService) GetSilenceExpiryNotification(ctx context.Context, user identity.Requester, silenceID string) (*models.SilenceExpiryNotification, error) {
+	silence, err := s.silences.GetSilence(ctx, user.GetOrgID(), silenceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authz.AuthorizeReadSilence(ctx, user, silence); err != nil {
+		return nil, err
+	}
+	return s.store.GetSilenceExpiryNotification(ctx, user.GetOrgID(), silenceID)
+}
+
+// SaveSilenceExpiryNotification creates or replaces the expiry notification of the silence.
+// The user needs permission to update the silence.
+func (s *SilenceExpiryNotificationService) SaveSilenceExpiryNotification(ctx context.Context, user identity.Requester, n models.SilenceExpiryNotification) (*models.SilenceExpiryNotification, error) {
+	if err := n.Validate(); err != nil {
+		return nil, models.ErrSilenceExpiryNotificationInvalid(err)
+	}
+
+	silence, err := s.silences.GetSilence(ctx, user.GetOrgID(), n.SilenceID)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authz.AuthorizeUpdateSilence(ctx, user, silence); err != nil {
+		return nil, err
+	}
+
+	exists, err := s.receivers.HasReceiver(ctx, user.GetOrgID(), n.Receiver)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, models.ErrSilenceExpiryNotificationInvalid(fmt.Errorf("receiver %s does not exist", n.Receiver))
+	}
+
+	n.OrgID = user.GetOrgID()
+	return s.store.SaveSilenceExpiryNotification(ctx, n)
+}
+
+// DeleteSilenceExpiryNotification deletes the expiry notification of the silence.
+// The user needs permission to update the silence.
+func (s *SilenceExpiryNotificationService) DeleteSilenceExpiryNotification(ctx context.Context, user identity.Requester, silenceID string) error {
+	silence, err := s.silences.GetSilence(ctx, user.GetOrgID(), silenceID)
+	if err != nil {
+		return err
+	}
+	if err := s.authz.AuthorizeUpdateSilence(ctx, user, silence); err != nil {
+		return err
+	}
+	return s.store.DeleteSilenceExpiryNotification(ctx, user.GetOrgID(), silenceID)
+}
+
+// Run sends the expiry notifications until the context is done.
+func (s *SilenceExpiryNotificationService) Run(ctx context.Context) error {
+	ticker := s.clock.Ticker(silenceExpiryNotificationInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.SendNotifications(ctx)
+		case <-ctx.Done():
+			return nil
+		}
+	}
+}
+
+// SendNotifications sends the expiry notifications of the silences of all organizations that expire soon. The
+// notifications of silences that no longer exist or have expired are deleted.
+func (s *SilenceExpiryNotificationService) SendNotifications(ctx context.Context) {
+	all, err := s.store.ListSilenceExpiryNotifications(ctx, 0)
+	if err != nil {
+		s.log.Error("Failed to list silence expiry notifications", "error", err)
+		return
+	}
+
+	now := s.clock.Now()
+	for _, n := range all {
+		if err := s.sendNotification(ctx, *n, now); err != nil {
+			s.log.Error("Failed to send silence expiry notification", "orgID", n.OrgID, "silenceID", n.SilenceID, "receiver", n.Receiver, "error", err)
+		}
+	}
+}
+
+// sendNotification sends the expiry notification if the silence expires within its period. The notification is claimed
+// in the database first, so that only one replica sends it.
+func (s *SilenceExpiryNotificationService) sendNotification(ctx context.Context, n models.SilenceExpiryNotification, now time.Time) error {
+	silence, err := s.silences.GetSilence(ctx, n.OrgID, n.SilenceID)
+	if err != nil && !errors.Is(err, ErrSilenceNotFound) {
+		return fmt.Errorf("failed to get silence: %w", err)
+	}
+	if silence == nil || silence.EndsAt == nil || !time.Time(*silence.EndsAt).After(now) {
+		return s.store.DeleteSilenceExpiryNotification(ctx, n.OrgID, n.SilenceID)
+	}
+
+	endsAt := time.Time(*silence.EndsAt)
+	if now.Before(endsAt.Add(-n.Before)) || n.NotifiedEndsAt.Unix() == endsAt.Unix() {
+		return nil
+	}
+
+	claimed, err := s.store.SetSilenceExpiryNotified(ctx, n, endsAt)
+	if err != nil {
+		return fmt.Errorf("failed to claim notification: %w", err)
+	}
+	if !claimed {
+		return nil
+	}
+
+	alert := silenceExpiryNotificationAlert(*silence, now)
+	if err := s.receivers.NotifyReceiver(ctx, n.OrgID, n.Receiver, silenceExpiryNotificationGroupKey(n), alert); err != nil {
+		// Release the notification to send it again with the next check.
+		claim := n
+		claim.NotifiedEndsAt = endsAt
+		if _, releaseErr := s.store.SetSilenceExpiryNotified(ctx, claim, n.NotifiedEndsAt); releaseErr != nil {
+			s.log.Error("Failed to release silence expiry notification", "orgID", n.OrgID, "silenceID", n.SilenceID, "error", releaseErr)
+		}
+		return err
+	}
+	s.log.Info("Sent silence expiry notification", "orgID", n.OrgID, "silenceID", n.SilenceID, "receiver", n.Receiver, "endsAt", endsAt)
+	return nil
+}
+
+// silenceExpiryNotificationGroupKey returns the group key of the expiry notification. It is the same for all the
+// silences that replace each other, as the notification follows the silence when it is replaced.
+func silenceExpiryNotificationGroupKey(n models.SilenceExpiryNotification) string {
+	return fmt.Sprintf("{}:{silence_expiry_notification=\"%d\"}", n.ID)
+}
+
+// silenceExpiryNotificationAlert returns the alert that is sent as the expiry notification of the silence. The alert
+// resolves when the silence expires.
+func silenceExpiryNotificationAlert(silence models.Silence, now time.Time) model.Alert {
+	var id, comment, createdBy string
+	if silence.ID != nil {
+		id = *silence.ID
+	}
+	if silence.Comment != nil {
+		comment = *silence.Comment
+	}
+	if silence.CreatedBy != nil {
+		createdBy = *silence.CreatedBy
+	}
+	endsAt := time.Time(*silence.EndsAt)
+	return model.Alert{
+		Labels: model.LabelSet{
+			model.AlertNameLabel: SilenceExpiringAlertName,
+			"silence_id":         model.LabelValue(id),
+		},
+		Annotations: model.LabelSet{
+			"summary":     model.LabelValue(fmt.Sprintf("Silence %s expires at %s", id, endsAt.UTC().Format(time.RFC3339))),
+			"description": model.LabelValue(fmt.Sprintf("Matchers: %s\nCreated by: %s\nComment: %s", models.MatchersString(silence.Matchers), createdBy, comment)),
+		},
+		StartsAt: now,
+		EndsAt:   endsAt,
+	}
+}
+
+// TrackSilences returns a SilenceStore that moves the expiry notification of a silence to the silence that replaces
+// it. The Alertmanager replaces an active silence with a new one if its matchers change.
+func (s *SilenceExpiryNotificationService) TrackSilences(store SilenceStore) SilenceStore {
+	return &expiryTrackingSilenceStore{SilenceStore: store, notifications: s.store, log: s.log}
+}
+
+type expiryTrackingSilenceStore struct {
+	SilenceStore
+	notifications SilenceExpiryNotificationStore
+	log           log.Logger
+}
+
+func (s *expiryTrackingSilenceStore) UpdateSilence(ctx context.Context, orgID int64, ps models.Silence) (string, error) {
+	silenceID, err := s.SilenceStore.UpdateSilence(ctx, orgID, ps)
+	if err != nil {
+		return "", err
+	}
+	if ps.ID != nil && *ps.ID != "" && *ps.ID != silenceID {
+		if err := s.notifications.MoveSilenceExpiryNotification(ctx, orgID, *ps.ID, silenceID); err != nil {
+			s.log.Error("Failed to move the expiry notification to the silence that replaces it", "orgID", orgID, "silenceID", *ps.ID, "newSilenceID", silenceID, "error", err)
+		}
+	}
+	return silenceID, nil
+}
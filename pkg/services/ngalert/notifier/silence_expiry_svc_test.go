@@ -0,0 +1,226 @@
+package notifier
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/go-openapi/strfmt"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
+	"github.com/grafana/grafana/pkg/services/ngalert/accesscontrol/fakes"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	ngfakes "github.com/grafana/grafana/pkg/services/ngalert/tests/fakes"
+	"github.com/grafana/grafana/pkg/services/org"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+type fakeSilenceExpiryNotificationStore struct {
+	notifications map[string]*models.SilenceExpiryNotification
+}
+
+func (f *fakeSilenceExpiryNotificationStore) GetSilenceExpiryNotification(_ context.Context, _ int64, silenceID string) (*models.SilenceExpiryNotification, error) {
+	if n, ok := f.notifications[silenceID]; ok {
+		return n, nil
+	}
+	return nil, models.ErrSilenceExpiryNotificationNotFound.Errorf("")
+}
+
+func (f *fakeSilenceExpiryNotificationStore) ListSilenceExpiryNotifications(_ context.Context, _ int64) ([]*models.SilenceExpiryNotification, error) {
+	result := make([]*models.SilenceExpiryNotification, 0, len(f.notifications))
+	for _, n := range f.notifications {
+		c := *n
+		result = append(result, &c)
+	}
+	return result, nil
+}
+
+func (f *fakeSilenceExpiryNotificationStore) SaveSilenceExpiryNotification(_ context.Context, n models.SilenceExpiryNotification) (*models.SilenceExpiryNotification, error) {
+	n.ID = int64(len(f.notifications) + 1)
+	f.notifications[n.SilenceID] = &n
+	return &n, nil
+}
+
+func (f *fakeSilenceExpiryNotificationStore) DeleteSilenceExpiryNotification(_ context.Context, _ int64, silenceID string) error {
+	delete(f.notifications, silenceID)
+	return nil
+}
+
+func (f *fakeSilenceExpiryNotificationStore) MoveSilenceExpiryNotification(_ context.Context, _ int64, fromSilenceID, toSilenceID string) error {
+	if n, ok := f.notifications[fromSilenceID]; ok {
+		delete(f.notifications, fromSilenceID)
+		n.SilenceID = toSilenceID
+		f.notifications[toSilenceID] = n
+	}
+	return nil
+}
+
+func (f *fakeSilenceExpiryNotificationStore) SetSilenceExpiryNotified(_ context.Context, n models.SilenceExpiryNotification, endsAt time.Time) (bool, error) {
+	stored, ok := f.notifications[n.SilenceID]
+	if !ok || stored.NotifiedEndsAt.Unix() != n.NotifiedEndsAt.Unix() {
+		return false, nil
+	}
+	stored.NotifiedEndsAt = endsAt
+	return true, nil
+}
+
+type fakeReceiverNotifier struct {
+	receivers []string
+	err       error
+	sent      []model.Alert
+	groupKeys []string
+}
+
+func (f *fakeReceiverNotifier) HasReceiver(_ context.Context, _ int64, name string) (bool, error) {
+	for _, r := range f.receivers {
+		if r == name {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+func (f *fakeReceiverNotifier) NotifyReceiver(_ context.Context, _ int64, _, groupKey string, alert model.Alert) error {
+	if f.err != nil {
+		return f.err
+	}
+	f.sent = append(f.sent, alert)
+	f.groupKeys = append(f.groupKeys, groupKey)
+	return nil
+}
+
+func TestSaveSilenceExpiryNotification(t *testing.T) {
+	user := ac.BackgroundUser("test", 1, org.RoleNone, nil)
+	silence := models.SilenceGen()()
+	newService := func() (*SilenceExpiryNotificationService, *fakeSilenceExpiryNotificationStore) {
+		store := &fakeSilenceExpiryNotificationStore{notifications: map[string]*models.SilenceExpiryNotification{}}
+		silences := &ngfakes.FakeSilenceStore{Silences: map[string]*models.Silence{*silence.ID: &silence}}
+		receivers := &fakeReceiverNotifier{receivers: []string{"ops"}}
+		return NewSilenceExpiryNotificationService(&fakes.FakeSilenceService{}, store, silences, receivers, clock.NewMock(), log.NewNopLogger()), store
+	}
+
+	t.Run("should save the notification", func(t *testing.T) {
+		svc, store := newService()
+		_, err := svc.SaveSilenceExpiryNotification(context.Background(), user, models.SilenceExpiryNotification{SilenceID: *silence.ID, Receiver: "ops", Before: time.Minute})
+		require.NoError(t, err)
+		require.Contains(t, store.notifications, *silence.ID)
+		assert.Equal(t, user.GetOrgID(), store.notifications[*silence.ID].OrgID)
+	})
+
+	t.Run("should fail if the receiver does not exist", func(t *testing.T) {
+		svc, _ := newService()
+		_, err := svc.SaveSilenceExpiryNotification(context.Background(), user, models.SilenceExpiryNotification{SilenceID: *silence.ID, Receiver: "unknown", Before: time.Minute})
+		require.ErrorIs(t, err, models.ErrSilenceExpiryNotificationInvalidBase)
+	})
+
+	t.Run("should fail if the notification is invalid", func(t *testing.T) {
+		svc, _ := newService()
+		_, err := svc.SaveSilenceExpiryNotification(context.Background(), user, models.SilenceExpiryNotification{SilenceID: *silence.ID, Receiver: "ops"})
+		require.ErrorIs(t, err, models.ErrSilenceExpiryNotificationInvalidBase)
+	})
+}
+
+func TestSendSilenceExpiryNotifications(t *testing.T) {
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+	endsAt := now.Add(10 * time.Minute)
+	silence := models.SilenceGen(func(s *models.Silence) {
+		s.EndsAt = util.Pointer(strfmt.DateTime(endsAt))
+	})()
+	expired := models.SilenceGen(func(s *models.Silence) {
+		s.EndsAt = util.Pointer(strfmt.DateTime(now.Add(-time.Minute)))
+	})()
+
+	newService := func(before time.Duration) (*SilenceExpiryNotificationService, *fakeSilenceExpiryNotificationStore, *fakeReceiverNotifier) {
+		store := &fakeSilenceExpiryNotificationStore{notifications: map[string]*models.SilenceExpiryNotification{
+			*silence.ID: {ID: 1, OrgID: 1, SilenceID: *silence.ID, Receiver: "ops", Before: before},
+			*expired.ID: {ID: 2, OrgID: 1, SilenceID: *expired.ID, Receiver: "ops", Before: before},
+		}}
+		silences := &ngfakes.FakeSilenceStore{Silences: map[string]*models.Silence{
+			*silence.ID: &silence,
+			*expired.ID: &expired,
+		}}
+		receivers := &fakeReceiverNotifier{receivers: []string{"ops"}}
+		clk := clock.NewMock()
+		clk.Set(now)
+		return NewSilenceExpiryNotificationService(&fakes.FakeSilenceService{}, store, silences, receivers, clk, log.NewNopLogger()), store, receivers
+	}
+
+	t.Run("should send the notification once when the silence expires soon", func(t *testing.T) {
+		svc, store, receivers := newService(15 * time.Minute)
+		svc.SendNotifications(context.Background())
+		svc.SendNotifications(context.Background())
+
+		require.Len(t, receivers.sent, 1)
+		assert.Equal(t, model.LabelValue(SilenceExpiringAlertName), receivers.sent[0].Labels[model.AlertNameLabel])
+		assert.Equal(t, model.LabelValue(*silence.ID), receivers.sent[0].Labels["silence_id"])
+		assert.Equal(t, now, receivers.sent[0].StartsAt)
+		assert.Equal(t, endsAt, receivers.sent[0].EndsAt)
+		assert.Equal(t, endsAt.Unix(), store.notifications[*silence.ID].NotifiedEndsAt.Unix())
+	})
+
+	t.Run("should not send the notification before its period", func(t *testing.T) {
+		svc, _, receivers := newService(5 * time.Minute)
+		svc.SendNotifications(context.Background())
+		require.Empty(t, receivers.sent)
+	})
+
+	t.Run("should delete the notifications of expired silences", func(t *testing.T) {
+		svc, store, _ := newService(15 * time.Minute)
+		svc.SendNotifications(context.Background())
+		require.NotContains(t, store.notifications, *expired.ID)
+	})
+
+	t.Run("should send the notification again with the next check if it failed", func(t *testing.T) {
+		svc, store, receivers := newService(15 * time.Minute)
+		receivers.err = errors.New("failed")
+		svc.SendNotifications(context.Background())
+		require.Empty(t, receivers.sent)
+		require.True(t, store.notifications[*silence.ID].NotifiedEndsAt.IsZero())
+
+		receivers.err = nil
+		svc.SendNotifications(context.Background())
+		require.Len(t, receivers.sent, 1)
+	})
+	t.Run("should follow the silence when it is replaced", func(t *testing.T) {
+		svc, store, receivers := newService(15 * time.Minute)
+		svc.SendNotifications(context.Background())
+		require.Len(t, receivers.sent, 1)
+
+		// The Alertmanager replaces the silence with a new one that ends later.
+		extended := models.CopySilenceWith(silence, func(s *models.Silence) {
+			s.EndsAt = util.Pointer(strfmt.DateTime(endsAt.Add(5 * time.Minute)))
+		})
+		silences := svc.silences.(*ngfakes.FakeSilenceStore)
+		replacing := &replacingSilenceStore{FakeSilenceStore: silences}
+		newID, err := svc.TrackSilences(replacing).UpdateSilence(context.Background(), 1, extended)
+		require.NoError(t, err)
+		require.NotEqual(t, *silence.ID, newID)
+		require.NotContains(t, store.notifications, *silence.ID)
+		require.Contains(t, store.notifications, newID)
+
+		svc.SendNotifications(context.Background())
+		require.Len(t, receivers.sent, 2)
+		assert.Equal(t, model.LabelValue(newID), receivers.sent[1].Labels["silence_id"])
+		assert.Equal(t, receivers.groupKeys[0], receivers.groupKeys[1])
+	})
+}
+
+// replacingSilenceStore replaces the silence with a new one on update, as the Alertmanager does if the silence is
+// active and its matchers change.
+type replacingSilenceStore struct {
+	*ngfakes.FakeSilenceStore
+}
+
+func (s *replacingSilenceStore) UpdateSilence(ctx context.Context, orgID int64, ps models.Silence) (string, error) {
+	if err := s.DeleteSilence(ctx, orgID, *ps.ID); err != nil {
+		return "", err
+	}
+	ps.ID = nil
+	return s.CreateSilence(ctx, orgID, ps)
+}
@@ -20,6 +20,7 @@ type SilenceService struct {
 	store     SilenceStore
 	ruleStore RuleStore
 	ruleAuthz RuleAccessControlService
+	audit     SilenceAuditStore
 }
 
 type RuleAccessControlService interface {
@@ -45,6 +46,17 @@ type SilenceStore interface {
 	DeleteSilence(ctx context.Context, orgID int64, id string) error
 }
 
+// SilenceAuditStore is the interface for storing and retrieving the audit log of silence changes.
+type SilenceAuditStore interface {
+	InsertSilenceAuditEntry(ctx context.Context, e models.SilenceAuditEntry) error
+	ListSilenceAuditEntries(ctx context.Context, query models.ListSilenceAuditEntriesQuery) ([]*models.SilenceAuditEntry, error)
+}
+
+// SilenceAlertStore returns the alerts that match a silence. It is implemented by MultiOrgAlertmanager.
+type SilenceAlertStore interface {
+	ListSilenceAlerts(ctx context.Context, orgID int64, silence models.Silence) ([]map[string]string, error)
+}
+
 type RuleStore interface {
 	ListAlertRules(ctx context.Context, query *models.ListAlertRulesQuery) (models.RulesGroup, error)
 }
@@ -56,6 +68,7 @@ func NewSilenceService(
 	store SilenceStore,
 	ruleStore RuleStore,
 	ruleAuthz RuleAccessControlService,
+	audit SilenceAuditStore,
 ) *SilenceService {
 	return &SilenceService{
 		authz:     authz,
@@ -64,6 +77,7 @@ func NewSilenceService(
 		store:     store,
 		ruleStore: ruleStore,
 		ruleAuthz: ruleAuthz,
+		audit:     audit,
 	}
 }
 
@@ -100,12 +114,7 @@ func (s *SilenceService) CreateSilence(ctx context.Context, user identity.Reques
 		return "", err
 	}
 
-	silenceId, err := s.store.CreateSilence(ctx, user.GetOrgID(), ps)
-	if err != nil {
-		return "", err
-	}
-
-	return silenceId, nil
+	return s.store.CreateSilence(identity.WithRequester(ctx, user), user.GetOrgID(), ps)
 }
 
 // UpdateSilence updates an existing silence.
@@ -125,12 +134,7 @@ func (s *SilenceService) UpdateSilence(ctx context.Context, user identity.Reques
 		return "", err
 	}
 
-	silenceId, err := s.store.UpdateSilence(ctx, user.GetOrgID(), ps)
-	if err != nil {
-		return "", err
-	}
-
-	return silenceId, nil
+	return s.store.UpdateSilence(identity.WithRequester(ctx, user), user.GetOrgID(), ps)
 }
 
 // DeleteSilence deletes a silence by its ID.
@@ -146,12 +150,57 @@ func (s *SilenceService) DeleteSilence(ctx context.Context, user identity.Reques
 		return err
 	}
 
-	err = s.store.DeleteSilence(ctx, user.GetOrgID(), silenceID)
+	return s.store.DeleteSilence(identity.WithRequester(ctx, user), user.GetOrgID(), silenceID)
+}
+
+// ListSilenceAudit returns the entries of the audit log of silences that match the query. Only the entries of silences
+// that the user can read are returned. The entries are read in pages of query.Limit entries until query.Limit entries
+// that the user can read are found, so that the entries the user cannot read do not count towards the limit.
+func (s *SilenceService) ListSilenceAudit(ctx context.Context, user identity.Requester, query models.ListSilenceAuditEntriesQuery) ([]*models.SilenceAuditEntry, error) {
+	query.OrgID = user.GetOrgID()
+	query.Offset = 0
+	result := make([]*models.SilenceAuditEntry, 0, query.Limit)
+	for {
+		entries, err := s.audit.ListSilenceAuditEntries(ctx, query)
+		if err != nil {
+			return nil, err
+		}
+		allowed, err := s.filterSilenceAuditByAccess(ctx, user, entries)
+		if err != nil {
+			return nil, err
+		}
+		result = append(result, allowed...)
+		if query.Limit <= 0 {
+			return result, nil
+		}
+		if len(result) >= query.Limit {
+			return result[:query.Limit], nil
+		}
+		if len(entries) < query.Limit {
+			return result, nil
+		}
+		query.Offset += len(entries)
+	}
+}
+
+// filterSilenceAuditByAccess returns the entries of silences that the user can read, in the same order.
+func (s *SilenceService) filterSilenceAuditByAccess(ctx context.Context, user identity.Requester, entries []*models.SilenceAuditEntry) ([]*models.SilenceAuditEntry, error) {
+	silences := make([]*models.Silence, 0, len(entries))
+	bySilence := make(map[*models.Silence]*models.SilenceAuditEntry, len(entries))
+	for _, entry := range entries {
+		silences = append(silences, &entry.Silence)
+		bySilence[&entry.Silence] = entry
+	}
+	allowed, err := s.authz.FilterByAccess(ctx, user, silences...)
 	if err != nil {
-		return err
+		return nil, err
 	}
 
-	return nil
+	result := make([]*models.SilenceAuditEntry, 0, len(allowed))
+	for _, silence := range allowed {
+		result = append(result, bySilence[silence])
+	}
+	return result, nil
 }
 
 // WithAccessControlMetadata adds access control metadata to the given SilenceWithMetadata.
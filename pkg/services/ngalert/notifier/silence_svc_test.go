@@ -14,6 +14,7 @@ import (
 	ngfakes "github.com/grafana/grafana/pkg/services/ngalert/tests/fakes"
 
 	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/log"
 	ac "github.com/grafana/grafana/pkg/services/accesscontrol"
 	"github.com/grafana/grafana/pkg/services/ngalert/accesscontrol/fakes"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
@@ -219,6 +220,7 @@ func TestUpdateSilence(t *testing.T) {
 			svc := SilenceService{
 				authz: &authz,
 				store: &silenceStore,
+				audit: &ngfakes.FakeSilenceAuditStore{},
 			}
 
 			modified := models.CopySilenceWith(silence, tc.mutators...)
@@ -232,3 +234,115 @@ func TestUpdateSilence(t *testing.T) {
 		})
 	}
 }
+
+type fakeSilenceAlertStore struct {
+	alerts []map[string]string
+}
+
+func (f fakeSilenceAlertStore) ListSilenceAlerts(_ context.Context, _ int64, _ models.Silence) ([]map[string]string, error) {
+	return f.alerts, nil
+}
+
+func TestSilenceAudit(t *testing.T) {
+	user := ac.BackgroundUser("test", 1, org.RoleNone, nil)
+	authz := fakes.FakeSilenceService{}
+	silenceStore := ngfakes.FakeSilenceStore{Silences: map[string]*models.Silence{}}
+	auditStore := ngfakes.FakeSilenceAuditStore{}
+	alerts := make([]map[string]string, models.MaxSilenceAuditAlerts+1)
+	for i := range alerts {
+		alerts[i] = map[string]string{"alertname": "Foo"}
+	}
+	store := NewAuditedSilenceStore(&silenceStore, &auditStore, fakeSilenceAlertStore{alerts: alerts}, log.NewNopLogger())
+	svc := SilenceService{
+		authz: &authz,
+		store: store,
+		log:   log.NewNopLogger(),
+		audit: &auditStore,
+	}
+	ctx := context.Background()
+
+	silence := models.SilenceGen(models.SilenceMuts.WithEmptyId())()
+	id, err := svc.CreateSilence(ctx, user, silence)
+	require.NoError(t, err)
+
+	updated := models.CopySilenceWith(*silenceStore.Silences[id], func(s *models.Silence) {
+		s.Comment = util.Pointer("new comment")
+	})
+	_, err = svc.UpdateSilence(ctx, user, updated)
+	require.NoError(t, err)
+
+	require.NoError(t, svc.DeleteSilence(ctx, user, id))
+
+	require.Len(t, auditStore.Entries, 3)
+
+	t.Run("create records the matched alerts", func(t *testing.T) {
+		entry := auditStore.Entries[0]
+		assert.Equal(t, models.SilenceAuditActionCreate, entry.Action)
+		assert.Equal(t, id, entry.SilenceID)
+		assert.Equal(t, user.GetOrgID(), entry.OrgID)
+		assert.Equal(t, user.GetLogin(), entry.ActorLogin)
+		assert.Len(t, entry.MatchedAlerts, models.MaxSilenceAuditAlerts)
+		assert.Empty(t, entry.Changes)
+	})
+
+	t.Run("update records the changes", func(t *testing.T) {
+		entry := auditStore.Entries[1]
+		assert.Equal(t, models.SilenceAuditActionUpdate, entry.Action)
+		assert.Equal(t, []models.SilenceChange{{Field: "comment", Old: *silence.Comment, New: "new comment"}}, entry.Changes)
+		assert.Empty(t, entry.MatchedAlerts)
+	})
+
+	t.Run("delete records the expired silence", func(t *testing.T) {
+		entry := auditStore.Entries[2]
+		assert.Equal(t, models.SilenceAuditActionExpire, entry.Action)
+		assert.Equal(t, "new comment", *entry.Silence.Comment)
+	})
+
+	t.Run("changes without a user are recorded without an actor", func(t *testing.T) {
+		silence := models.SilenceGen(models.SilenceMuts.WithEmptyId())()
+		id, err := store.CreateSilence(ctx, user.GetOrgID(), silence)
+		require.NoError(t, err)
+		require.NoError(t, store.DeleteSilence(ctx, user.GetOrgID(), id))
+
+		require.Len(t, auditStore.Entries, 5)
+		for _, entry := range auditStore.Entries[3:] {
+			assert.Equal(t, id, entry.SilenceID)
+			assert.Empty(t, entry.ActorUID)
+			assert.Empty(t, entry.ActorLogin)
+		}
+		assert.Equal(t, models.SilenceAuditActionCreate, auditStore.Entries[3].Action)
+		assert.Equal(t, models.SilenceAuditActionExpire, auditStore.Entries[4].Action)
+	})
+
+	t.Run("list only returns the entries of silences the user can read", func(t *testing.T) {
+		authz.FilterByAccessFunc = func(ctx context.Context, user identity.Requester, silences ...*models.Silence) ([]*models.Silence, error) {
+			return silences[:1], nil
+		}
+		result, err := svc.ListSilenceAudit(ctx, user, models.ListSilenceAuditEntriesQuery{SilenceID: id})
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		assert.Equal(t, models.SilenceAuditActionExpire, result[0].Action)
+	})
+
+	t.Run("list applies the limit after the entries the user cannot read are filtered out", func(t *testing.T) {
+		// The user can only read the entries of the first silence, which are the least recent ones.
+		authz.FilterByAccessFunc = func(ctx context.Context, user identity.Requester, silences ...*models.Silence) ([]*models.Silence, error) {
+			var allowed []*models.Silence
+			for _, s := range silences {
+				if *s.ID == id {
+					allowed = append(allowed, s)
+				}
+			}
+			return allowed, nil
+		}
+		result, err := svc.ListSilenceAudit(ctx, user, models.ListSilenceAuditEntriesQuery{Limit: 2})
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+		assert.Equal(t, models.SilenceAuditActionExpire, result[0].Action)
+		assert.Equal(t, models.SilenceAuditActionUpdate, result[1].Action)
+
+		result, err = svc.ListSilenceAudit(ctx, user, models.ListSilenceAuditEntriesQuery{Limit: 5})
+		require.NoError(t, err)
+		require.Len(t, result, 3)
+	})
+}
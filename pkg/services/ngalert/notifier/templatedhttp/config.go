@@ -0,0 +1,300 @@
+package templatedhttp
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+
+	alertingNotify "github.com/grafana/alerting/notify"
+	"github.com/grafana/alerting/receivers"
+)
+
+// Type is the type of the templated HTTP integration.
+const Type = "templated-http"
+
+const (
+	DefaultHMACHeader  = "X-Grafana-Alerting-Signature"
+	DefaultContentType = "application/json"
+)
+
+// Config is the configuration of the templated HTTP integration. The method, URL, header values and body are
+// templates that are executed over the notified alert group.
+type Config struct {
+	URL        string
+	HTTPMethod string
+	Headers    map[string]string
+	// SecureHeaders are the headers with secret values. The values are not templates.
+	SecureHeaders map[string]string
+	Body          string
+	ContentType   string
+	// HTTP Basic Authentication.
+	User     string
+	Password string
+	// Authorization Header.
+	AuthorizationScheme      string
+	AuthorizationCredentials string
+	// HMAC-SHA256 signature of the body.
+	HMACSecret          string
+	HMACHeader          string
+	HMACTimestampHeader string
+
+	SuccessStatusCodes StatusCodes
+	TLSConfig          *receivers.TLSConfig
+}
+
+func NewConfig(jsonData json.RawMessage, decryptFn receivers.DecryptFunc) (Config, error) {
+	settings := Config{}
+	rawSettings := struct {
+		URL                      string               `json:"url,omitempty" yaml:"url,omitempty"`
+		HTTPMethod               string               `json:"httpMethod,omitempty" yaml:"httpMethod,omitempty"`
+		Headers                  map[string]string    `json:"headers,omitempty" yaml:"headers,omitempty"`
+		SecureHeaders            string               `json:"secureHeaders,omitempty" yaml:"secureHeaders,omitempty"`
+		Body                     string               `json:"body,omitempty" yaml:"body,omitempty"`
+		ContentType              string               `json:"contentType,omitempty" yaml:"contentType,omitempty"`
+		User                     string               `json:"username,omitempty" yaml:"username,omitempty"`
+		Password                 string               `json:"password,omitempty" yaml:"password,omitempty"`
+		AuthorizationScheme      string               `json:"authorization_scheme,omitempty" yaml:"authorization_scheme,omitempty"`
+		AuthorizationCredentials string               `json:"authorization_credentials,omitempty" yaml:"authorization_credentials,omitempty"`
+		HMACSecret               string               `json:"hmacSecret,omitempty" yaml:"hmacSecret,omitempty"`
+		HMACHeader               string               `json:"hmacHeader,omitempty" yaml:"hmacHeader,omitempty"`
+		HMACTimestampHeader      string               `json:"hmacTimestampHeader,omitempty" yaml:"hmacTimestampHeader,omitempty"`
+		SuccessStatusCodes       string               `json:"successStatusCodes,omitempty" yaml:"successStatusCodes,omitempty"`
+		TLSConfig                *receivers.TLSConfig `json:"tlsConfig,omitempty" yaml:"tlsConfig,omitempty"`
+	}{}
+
+	err := json.Unmarshal(jsonData, &rawSettings)
+	if err != nil {
+		return settings, fmt.Errorf("failed to unmarshal settings: %w", err)
+	}
+	if strings.TrimSpace(rawSettings.URL) == "" {
+		return settings, errors.New("required field 'url' is not specified")
+	}
+	settings.URL = rawSettings.URL
+
+	settings.HTTPMethod = rawSettings.HTTPMethod
+	if strings.TrimSpace(settings.HTTPMethod) == "" {
+		settings.HTTPMethod = http.MethodPost
+	}
+	for name := range rawSettings.Headers {
+		if strings.TrimSpace(name) == "" {
+			return settings, errors.New("header name must not be empty")
+		}
+	}
+	settings.Headers = rawSettings.Headers
+	settings.SecureHeaders, err = ParseSecureHeaders(decryptFn("secureHeaders", rawSettings.SecureHeaders))
+	if err != nil {
+		return settings, err
+	}
+	for name := range settings.SecureHeaders {
+		for other := range settings.Headers {
+			if http.CanonicalHeaderKey(strings.TrimSpace(other)) == name {
+				return settings, fmt.Errorf("header %s is set in both headers and secure headers", name)
+			}
+		}
+	}
+	settings.Body = rawSettings.Body
+	settings.ContentType = rawSettings.ContentType
+	if settings.ContentType == "" {
+		settings.ContentType = DefaultContentType
+	}
+
+	settings.User = decryptFn("username", rawSettings.User)
+	settings.Password = decryptFn("password", rawSettings.Password)
+	settings.AuthorizationScheme = rawSettings.AuthorizationScheme
+	settings.AuthorizationCredentials = decryptFn("authorization_credentials", rawSettings.AuthorizationCredentials)
+	if settings.AuthorizationCredentials != "" && settings.AuthorizationScheme == "" {
+		settings.AuthorizationScheme = "Bearer"
+	}
+	if settings.User != "" && settings.Password != "" && settings.AuthorizationCredentials != "" {
+		return settings, errors.New("both HTTP Basic Authentication and Authorization Header are set, only 1 is permitted")
+	}
+	if (settings.User != "" && settings.Password != "") || settings.AuthorizationCredentials != "" {
+		if settings.hasHeader("Authorization") {
+			return settings, errors.New("header Authorization must not be set when HTTP Basic Authentication or Authorization Header is set")
+		}
+	}
+
+	settings.HMACSecret = decryptFn("hmacSecret", rawSettings.HMACSecret)
+	settings.HMACHeader = rawSettings.HMACHeader
+	if settings.HMACHeader == "" {
+		settings.HMACHeader = DefaultHMACHeader
+	}
+	settings.HMACTimestampHeader = rawSettings.HMACTimestampHeader
+
+	if settings.hasCredentials() {
+		if _, _, ok := staticOrigin(settings.URL); !ok {
+			return settings, errors.New("the scheme and host of the url must not be templated when authentication, secure headers or an HMAC secret are set")
+		}
+	}
+
+	settings.SuccessStatusCodes, err = ParseStatusCodes(rawSettings.SuccessStatusCodes)
+	if err != nil {
+		return settings, fmt.Errorf("invalid success status codes: %w", err)
+	}
+
+	if tlsConfig := rawSettings.TLSConfig; tlsConfig != nil {
+		settings.TLSConfig = &receivers.TLSConfig{
+			InsecureSkipVerify: tlsConfig.InsecureSkipVerify,
+			CACertificate:      decryptFn("tlsConfig.caCertificate", tlsConfig.CACertificate),
+			ClientCertificate:  decryptFn("tlsConfig.clientCertificate", tlsConfig.ClientCertificate),
+			ClientKey:          decryptFn("tlsConfig.clientKey", tlsConfig.ClientKey),
+		}
+	}
+
+	return settings, nil
+}
+
+// hasHeader returns true if the header is set in the headers or the secure headers.
+func (c Config) hasHeader(name string) bool {
+	name = http.CanonicalHeaderKey(name)
+	if _, ok := c.SecureHeaders[name]; ok {
+		return true
+	}
+	for h := range c.Headers {
+		if http.CanonicalHeaderKey(strings.TrimSpace(h)) == name {
+			return true
+		}
+	}
+	return false
+}
+
+// hasCredentials returns true if the requests carry secrets, which must only be sent to the host of the URL.
+func (c Config) hasCredentials() bool {
+	return len(c.SecureHeaders) > 0 || (c.User != "" && c.Password != "") || c.AuthorizationCredentials != "" || c.HMACSecret != ""
+}
+
+// staticOrigin returns the scheme and host of the URL template in lower case. It returns false if the scheme or the
+// host contain a template, as they are then only known once the template is executed.
+func staticOrigin(rawURL string) (scheme string, host string, ok bool) {
+	scheme, rest, found := strings.Cut(strings.TrimSpace(rawURL), "://")
+	if !found {
+		return "", "", false
+	}
+	authority := rest
+	if i := strings.IndexAny(rest, "/?#"); i >= 0 {
+		authority = rest[:i]
+	}
+	if authority == "" || strings.Contains(scheme, "{{") || strings.Contains(authority, "{{") {
+		return "", "", false
+	}
+	u, err := url.Parse(scheme + "://" + authority)
+	if err != nil || u.Host == "" {
+		return "", "", false
+	}
+	return strings.ToLower(u.Scheme), strings.ToLower(u.Host), true
+}
+
+// ParseSecureHeaders parses the secure headers, one header per line in the format "Name: value". Empty lines are
+// ignored. The names are returned in their canonical format. The values are not part of the errors, as they are
+// secrets.
+func ParseSecureHeaders(s string) (map[string]string, error) {
+	if strings.TrimSpace(s) == "" {
+		return nil, nil
+	}
+	headers := make(map[string]string)
+	for i, line := range strings.Split(s, "\n") {
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		name, value, ok := strings.Cut(line, ":")
+		name = http.CanonicalHeaderKey(strings.TrimSpace(name))
+		if !ok || name == "" {
+			return nil, fmt.Errorf("invalid secure header on line %d, expected 'Name: value'", i+1)
+		}
+		if _, exists := headers[name]; exists {
+			return nil, fmt.Errorf("secure header %s is set more than once", name)
+		}
+		headers[name] = strings.TrimSpace(value)
+	}
+	return headers, nil
+}
+
+// NewConfigFromIntegration parses the settings of the integration and decrypts its secure settings the same way the
+// alerting package does for the integrations it knows about.
+func NewConfigFromIntegration(ctx context.Context, integration *alertingNotify.GrafanaIntegrationConfig, decrypt alertingNotify.GetDecryptedValueFn) (Config, error) {
+	secureSettings := make(map[string][]byte, len(integration.SecureSettings))
+	for k, v := range integration.SecureSettings {
+		d, err := base64.StdEncoding.DecodeString(v)
+		if err != nil {
+			// The secure settings are not base-64 encoded.
+			secureSettings = make(map[string][]byte, len(integration.SecureSettings))
+			for k, v := range integration.SecureSettings {
+				secureSettings[k] = []byte(v)
+			}
+			break
+		}
+		secureSettings[k] = d
+	}
+	return NewConfig(integration.Settings, func(key string, fallback string) string {
+		return decrypt(ctx, secureSettings, key, fallback)
+	})
+}
+
+// StatusCodes is a set of ranges of HTTP status codes.
+type StatusCodes []statusCodeRange
+
+type statusCodeRange struct {
+	from, to int
+}
+
+// DefaultSuccessStatusCodes are the status codes of a successful request when none are configured.
+var DefaultSuccessStatusCodes = StatusCodes{{from: 200, to: 299}}
+
+// ParseStatusCodes parses a comma-separated list of HTTP status codes and ranges of status codes, such as
+// "200-299,304". It returns DefaultSuccessStatusCodes if the list is empty.
+func ParseStatusCodes(s string) (StatusCodes, error) {
+	if strings.TrimSpace(s) == "" {
+		return DefaultSuccessStatusCodes, nil
+	}
+	var result StatusCodes
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+		from, to, isRange := strings.Cut(part, "-")
+		r := statusCodeRange{}
+		var err error
+		if r.from, err = parseStatusCode(from); err != nil {
+			return nil, err
+		}
+		r.to = r.from
+		if isRange {
+			if r.to, err = parseStatusCode(to); err != nil {
+				return nil, err
+			}
+			if r.to < r.from {
+				return nil, fmt.Errorf("invalid range '%s'", part)
+			}
+		}
+		result = append(result, r)
+	}
+	if len(result) == 0 {
+		return DefaultSuccessStatusCodes, nil
+	}
+	return result, nil
+}
+
+func parseStatusCode(s string) (int, error) {
+	code, err := strconv.Atoi(strings.TrimSpace(s))
+	if err != nil || code < 100 || code > 599 {
+		return 0, fmt.Errorf("'%s' is not an HTTP status code", strings.TrimSpace(s))
+	}
+	return code, nil
+}
+
+// Contains returns true if the status code is in one of the ranges.
+func (c StatusCodes) Contains(code int) bool {
+	for _, r := range c {
+		if code >= r.from && code <= r.to {
+			return true
+		}
+	}
+	return false
+}
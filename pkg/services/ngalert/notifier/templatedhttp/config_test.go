@@ -0,0 +1,254 @@
+package templatedhttp
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"testing"
+
+	alertingNotify "github.com/grafana/alerting/notify"
+	"github.com/grafana/alerting/receivers"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewConfig(t *testing.T) {
+	cases := []struct {
+		name           string
+		settings       string
+		secureSettings map[string][]byte
+		expectedConfig Config
+		expectedErr    string
+	}{
+		{
+			name:        "error if url is missing",
+			settings:    `{}`,
+			expectedErr: "required field 'url' is not specified",
+		},
+		{
+			name:     "minimal valid configuration",
+			settings: `{"url": "http://localhost/{{ .CommonLabels.team }}"}`,
+			expectedConfig: Config{
+				URL:                "http://localhost/{{ .CommonLabels.team }}",
+				HTTPMethod:         "POST",
+				ContentType:        "application/json",
+				HMACHeader:         "X-Grafana-Alerting-Signature",
+				SuccessStatusCodes: DefaultSuccessStatusCodes,
+			},
+		},
+		{
+			name: "all fields",
+			settings: `{
+				"url": "http://localhost/tickets",
+				"httpMethod": "PATCH",
+				"headers": {"X-Ticket-Queue": "{{ .CommonLabels.queue }}"},
+				"body": "{\"summary\": {{ printf \"%q\" .CommonAnnotations.summary }}}",
+				"contentType": "application/vnd.ticket+json",
+				"authorization_scheme": "Token",
+				"hmacHeader": "X-Signature",
+				"hmacTimestampHeader": "X-Timestamp",
+				"successStatusCodes": "200, 201-204",
+				"tlsConfig": {"insecureSkipVerify": true}
+			}`,
+			secureSettings: map[string][]byte{
+				"authorization_credentials": []byte("token"),
+				"hmacSecret":                []byte("secret"),
+			},
+			expectedConfig: Config{
+				URL:                      "http://localhost/tickets",
+				HTTPMethod:               "PATCH",
+				Headers:                  map[string]string{"X-Ticket-Queue": "{{ .CommonLabels.queue }}"},
+				Body:                     `{"summary": {{ printf "%q" .CommonAnnotations.summary }}}`,
+				ContentType:              "application/vnd.ticket+json",
+				AuthorizationScheme:      "Token",
+				AuthorizationCredentials: "token",
+				HMACSecret:               "secret",
+				HMACHeader:               "X-Signature",
+				HMACTimestampHeader:      "X-Timestamp",
+				SuccessStatusCodes:       StatusCodes{{from: 200, to: 200}, {from: 201, to: 204}},
+				TLSConfig:                &receivers.TLSConfig{InsecureSkipVerify: true},
+			},
+		},
+		{
+			name:     "bearer is the default authorization scheme",
+			settings: `{"url": "http://localhost", "authorization_credentials": "token"}`,
+			expectedConfig: Config{
+				URL:                      "http://localhost",
+				HTTPMethod:               "POST",
+				ContentType:              "application/json",
+				AuthorizationScheme:      "Bearer",
+				AuthorizationCredentials: "token",
+				HMACHeader:               "X-Grafana-Alerting-Signature",
+				SuccessStatusCodes:       DefaultSuccessStatusCodes,
+			},
+		},
+		{
+			name:     "error if basic authentication and authorization header are set",
+			settings: `{"url": "http://localhost", "username": "user"}`,
+			secureSettings: map[string][]byte{
+				"password":                  []byte("password"),
+				"authorization_credentials": []byte("token"),
+			},
+			expectedErr: "both HTTP Basic Authentication and Authorization Header are set, only 1 is permitted",
+		},
+		{
+			name:        "error if a header name is empty",
+			settings:    `{"url": "http://localhost", "headers": {" ": "value"}}`,
+			expectedErr: "header name must not be empty",
+		},
+		{
+			name:     "secure headers",
+			settings: `{"url": "http://localhost", "headers": {"X-Queue": "{{ .CommonLabels.queue }}"}}`,
+			secureSettings: map[string][]byte{
+				"secureHeaders": []byte("x-api-key: key\n\nX-Tenant:  tenant  \n"),
+			},
+			expectedConfig: Config{
+				URL:                "http://localhost",
+				HTTPMethod:         "POST",
+				Headers:            map[string]string{"X-Queue": "{{ .CommonLabels.queue }}"},
+				SecureHeaders:      map[string]string{"X-Api-Key": "key", "X-Tenant": "tenant"},
+				ContentType:        "application/json",
+				HMACHeader:         "X-Grafana-Alerting-Signature",
+				SuccessStatusCodes: DefaultSuccessStatusCodes,
+			},
+		},
+		{
+			name:     "error if a secure header is invalid",
+			settings: `{"url": "http://localhost"}`,
+			secureSettings: map[string][]byte{
+				"secureHeaders": []byte("X-Api-Key: key\nsecret"),
+			},
+			expectedErr: "invalid secure header on line 2, expected 'Name: value'",
+		},
+		{
+			name:     "error if a header is set in both headers and secure headers",
+			settings: `{"url": "http://localhost", "headers": {"x-api-key": "{{ .CommonLabels.key }}"}}`,
+			secureSettings: map[string][]byte{
+				"secureHeaders": []byte("X-Api-Key: key"),
+			},
+			expectedErr: "header X-Api-Key is set in both headers and secure headers",
+		},
+		{
+			name:     "error if the authorization header is set with authorization credentials",
+			settings: `{"url": "http://localhost", "headers": {"authorization": "{{ .CommonLabels.token }}"}}`,
+			secureSettings: map[string][]byte{
+				"authorization_credentials": []byte("token"),
+			},
+			expectedErr: "header Authorization must not be set when HTTP Basic Authentication or Authorization Header is set",
+		},
+		{
+			name:     "error if the authorization header is a secure header with basic authentication",
+			settings: `{"url": "http://localhost", "username": "user"}`,
+			secureSettings: map[string][]byte{
+				"password":      []byte("password"),
+				"secureHeaders": []byte("Authorization: Token other"),
+			},
+			expectedErr: "header Authorization must not be set when HTTP Basic Authentication or Authorization Header is set",
+		},
+		{
+			name:     "error if the host is templated with secure headers",
+			settings: `{"url": "https://{{ .CommonLabels.host }}/tickets"}`,
+			secureSettings: map[string][]byte{
+				"secureHeaders": []byte("X-Api-Key: key"),
+			},
+			expectedErr: "the scheme and host of the url must not be templated when authentication, secure headers or an HMAC secret are set",
+		},
+		{
+			name:     "error if the host is extended by a template with authorization credentials",
+			settings: `{"url": "https://tickets.example.com{{ .CommonLabels.suffix }}/tickets"}`,
+			secureSettings: map[string][]byte{
+				"authorization_credentials": []byte("token"),
+			},
+			expectedErr: "the scheme and host of the url must not be templated when authentication, secure headers or an HMAC secret are set",
+		},
+		{
+			name:     "error if the scheme is templated with an HMAC secret",
+			settings: `{"url": "{{ .CommonLabels.scheme }}://tickets.example.com/tickets"}`,
+			secureSettings: map[string][]byte{
+				"hmacSecret": []byte("secret"),
+			},
+			expectedErr: "the scheme and host of the url must not be templated when authentication, secure headers or an HMAC secret are set",
+		},
+		{
+			name:     "the host can be templated without credentials",
+			settings: `{"url": "https://{{ .CommonLabels.host }}/tickets"}`,
+			expectedConfig: Config{
+				URL:                "https://{{ .CommonLabels.host }}/tickets",
+				HTTPMethod:         "POST",
+				ContentType:        "application/json",
+				HMACHeader:         "X-Grafana-Alerting-Signature",
+				SuccessStatusCodes: DefaultSuccessStatusCodes,
+			},
+		},
+		{
+			name:        "error if success status codes are invalid",
+			settings:    `{"url": "http://localhost", "successStatusCodes": "2xx"}`,
+			expectedErr: "invalid success status codes: '2xx' is not an HTTP status code",
+		},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			actual, err := NewConfig(json.RawMessage(c.settings), func(key string, fallback string) string {
+				if v, ok := c.secureSettings[key]; ok {
+					return string(v)
+				}
+				return fallback
+			})
+			if c.expectedErr != "" {
+				require.EqualError(t, err, c.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, c.expectedConfig, actual)
+		})
+	}
+}
+
+func TestNewConfigFromIntegration(t *testing.T) {
+	integration := &alertingNotify.GrafanaIntegrationConfig{
+		Type:     Type,
+		Settings: json.RawMessage(`{"url": "http://localhost"}`),
+		SecureSettings: map[string]string{
+			"hmacSecret": base64.StdEncoding.EncodeToString([]byte("secret")),
+		},
+	}
+
+	cfg, err := NewConfigFromIntegration(context.Background(), integration, alertingNotify.NoopDecrypt)
+	require.NoError(t, err)
+	assert.Equal(t, "secret", cfg.HMACSecret)
+}
+
+func TestParseStatusCodes(t *testing.T) {
+	cases := []struct {
+		input       string
+		contains    []int
+		notContains []int
+		expectedErr string
+	}{
+		{input: "", contains: []int{200, 204, 299}, notContains: []int{199, 300, 404}},
+		{input: "201", contains: []int{201}, notContains: []int{200, 202}},
+		{input: "200-204, 304", contains: []int{200, 202, 204, 304}, notContains: []int{205, 303}},
+		{input: "200,,", contains: []int{200}, notContains: []int{201}},
+		{input: "abc", expectedErr: "'abc' is not an HTTP status code"},
+		{input: "600", expectedErr: "'600' is not an HTTP status code"},
+		{input: "299-200", expectedErr: "invalid range '299-200'"},
+	}
+
+	for _, c := range cases {
+		t.Run(c.input, func(t *testing.T) {
+			codes, err := ParseStatusCodes(c.input)
+			if c.expectedErr != "" {
+				require.EqualError(t, err, c.expectedErr)
+				return
+			}
+			require.NoError(t, err)
+			for _, code := range c.contains {
+				assert.Truef(t, codes.Contains(code), "expected %d to be a success status code", code)
+			}
+			for _, code := range c.notContains {
+				assert.Falsef(t, codes.Contains(code), "expected %d not to be a success status code", code)
+			}
+		})
+	}
+}
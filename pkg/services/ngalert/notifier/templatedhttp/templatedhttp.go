@@ -0,0 +1,178 @@
+package templatedhttp
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"crypto/tls"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/grafana/alerting/logging"
+	"github.com/grafana/alerting/receivers"
+	"github.com/grafana/alerting/templates"
+	"github.com/prometheus/alertmanager/types"
+)
+
+// maxErrorBodySize is the size of the response body that is included in the error when the request fails.
+const maxErrorBodySize = 1024
+
+// Notifier sends alert notifications as HTTP requests whose method, URL, header values and body are templates.
+type Notifier struct {
+	*receivers.Base
+	log      logging.Logger
+	tmpl     *templates.Template
+	settings Config
+	now      func() time.Time
+}
+
+// New is the constructor for the templated HTTP notifier.
+func New(cfg Config, meta receivers.Metadata, template *templates.Template, logger logging.Logger) *Notifier {
+	return &Notifier{
+		Base:     receivers.NewBase(meta),
+		log:      logger,
+		tmpl:     template,
+		settings: cfg,
+		now:      time.Now,
+	}
+}
+
+// Notify implements the Notifier interface.
+func (n *Notifier) Notify(ctx context.Context, as ...*types.Alert) (bool, error) {
+	var tmplErr error
+	tmpl, _ := templates.TmplText(ctx, n.tmpl, as, n.log, &tmplErr)
+	execute := func(field, text string) (string, error) {
+		s := tmpl(text)
+		if tmplErr != nil {
+			return "", fmt.Errorf("failed to template %s: %w", field, tmplErr)
+		}
+		return s, nil
+	}
+
+	method, err := execute("method", n.settings.HTTPMethod)
+	if err != nil {
+		return false, err
+	}
+	method = strings.ToUpper(strings.TrimSpace(method))
+	rawURL, err := execute("url", n.settings.URL)
+	if err != nil {
+		return false, err
+	}
+	rawURL = strings.TrimSpace(rawURL)
+	body, err := execute("body", n.settings.Body)
+	if err != nil {
+		return false, err
+	}
+	headers := make(map[string]string, len(n.settings.Headers))
+	for name, value := range n.settings.Headers {
+		v, err := execute(fmt.Sprintf("header %s", name), value)
+		if err != nil {
+			return false, err
+		}
+		// Headers that are templated to an empty value are not sent.
+		if v = strings.TrimSpace(v); v != "" {
+			headers[name] = v
+		}
+	}
+
+	request, err := http.NewRequestWithContext(ctx, method, rawURL, strings.NewReader(body))
+	if err != nil {
+		return false, redactURL(err)
+	}
+	// The configuration only allows credentials with a URL whose scheme and host are not templated. They are checked
+	// again against the request so that the credentials are never sent to a host that was chosen by the template.
+	if n.settings.hasCredentials() {
+		scheme, host, ok := staticOrigin(n.settings.URL)
+		if !ok || !strings.EqualFold(request.URL.Scheme, scheme) || !strings.EqualFold(request.URL.Host, host) {
+			return false, errors.New("the templated url does not have the scheme and host of the configured url, the request is not sent as it carries credentials")
+		}
+	}
+	request.Header.Set("Content-Type", n.settings.ContentType)
+	request.Header.Set("User-Agent", "Grafana")
+	for name, value := range headers {
+		request.Header.Set(name, value)
+	}
+	for name, value := range n.settings.SecureHeaders {
+		request.Header.Set(name, value)
+	}
+	// The authentication is set after the headers so that they cannot replace it.
+	if n.settings.User != "" && n.settings.Password != "" {
+		request.SetBasicAuth(n.settings.User, n.settings.Password)
+	}
+	if n.settings.AuthorizationCredentials != "" {
+		request.Header.Set("Authorization", fmt.Sprintf("%s %s", n.settings.AuthorizationScheme, n.settings.AuthorizationCredentials))
+	}
+	if n.settings.HMACSecret != "" {
+		n.sign(request, body)
+	}
+
+	var tlsConfig *tls.Config
+	if n.settings.TLSConfig != nil {
+		if tlsConfig, err = n.settings.TLSConfig.ToCryptoTLSConfig(); err != nil {
+			return false, err
+		}
+	}
+
+	resp, err := receivers.NewTLSClient(tlsConfig).Do(request)
+	if err != nil {
+		return true, redactURL(err)
+	}
+	defer func() {
+		if err := resp.Body.Close(); err != nil {
+			n.log.Warn("Failed to close response body", "error", err)
+		}
+	}()
+
+	respBody, err := io.ReadAll(io.LimitReader(resp.Body, maxErrorBodySize))
+	if err != nil {
+		return true, err
+	}
+	if !n.settings.SuccessStatusCodes.Contains(resp.StatusCode) {
+		n.log.Debug("Templated HTTP request failed", "url", request.URL.Redacted(), "statusCode", resp.Status, "body", string(respBody))
+		retry := resp.StatusCode/100 == 5 || resp.StatusCode == http.StatusTooManyRequests
+		if len(respBody) > 0 {
+			return retry, fmt.Errorf("unexpected status %s: %s", resp.Status, respBody)
+		}
+		return retry, fmt.Errorf("unexpected status %s", resp.Status)
+	}
+
+	n.log.Debug("Templated HTTP request succeeded", "url", request.URL.Redacted(), "statusCode", resp.Status)
+	return false, nil
+}
+
+// sign sets the HMAC-SHA256 signature of the body in hex as "sha256=<signature>". If a timestamp header is configured,
+// the current Unix time is sent in that header and the signature is computed over "<timestamp>.<body>" so that the
+// receiver can reject replayed requests.
+func (n *Notifier) sign(request *http.Request, body string) {
+	mac := hmac.New(sha256.New, []byte(n.settings.HMACSecret))
+	if n.settings.HMACTimestampHeader != "" {
+		ts := strconv.FormatInt(n.now().Unix(), 10)
+		request.Header.Set(n.settings.HMACTimestampHeader, ts)
+		_, _ = mac.Write([]byte(ts + "."))
+	}
+	_, _ = mac.Write([]byte(body))
+	request.Header.Set(n.settings.HMACHeader, "sha256="+hex.EncodeToString(mac.Sum(nil)))
+}
+
+func (n *Notifier) SendResolved() bool {
+	return !n.GetDisableResolveMessage()
+}
+
+// redactURL removes the password of the URL, which can be part of the templated URL, from the error.
+func redactURL(err error) error {
+	var e *url.Error
+	if !errors.As(err, &e) {
+		return err
+	}
+	if u, parseErr := url.Parse(e.URL); parseErr == nil {
+		e.URL = u.Redacted()
+	}
+	return e
+}
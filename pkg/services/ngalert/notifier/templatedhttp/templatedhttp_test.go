@@ -0,0 +1,222 @@
+package templatedhttp
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/grafana/alerting/logging"
+	"github.com/grafana/alerting/receivers"
+	"github.com/grafana/alerting/templates"
+	"github.com/prometheus/alertmanager/notify"
+	"github.com/prometheus/alertmanager/types"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type receivedRequest struct {
+	method string
+	path   string
+	header http.Header
+	body   string
+}
+
+func newTestServer(t *testing.T, status int, body string) (*httptest.Server, *receivedRequest) {
+	t.Helper()
+	received := &receivedRequest{}
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		*received = receivedRequest{method: r.Method, path: r.URL.Path, header: r.Header, body: string(b)}
+		w.WriteHeader(status)
+		_, _ = w.Write([]byte(body))
+	}))
+	t.Cleanup(server.Close)
+	return server, received
+}
+
+func newTestNotifier(t *testing.T, settings Config) *Notifier {
+	t.Helper()
+	if settings.ContentType == "" {
+		settings.ContentType = DefaultContentType
+	}
+	if settings.HMACHeader == "" {
+		settings.HMACHeader = DefaultHMACHeader
+	}
+	if settings.SuccessStatusCodes == nil {
+		settings.SuccessStatusCodes = DefaultSuccessStatusCodes
+	}
+	tmpl := templates.ForTests(t)
+	externalURL, err := url.Parse("http://localhost")
+	require.NoError(t, err)
+	tmpl.ExternalURL = externalURL
+	n := New(settings, receivers.Metadata{Type: Type}, tmpl, &logging.FakeLogger{})
+	n.now = func() time.Time { return time.Unix(1700000000, 0) }
+	return n
+}
+
+func notifyCtx() context.Context {
+	ctx := notify.WithGroupKey(context.Background(), "alertname")
+	ctx = notify.WithGroupLabels(ctx, model.LabelSet{"alertname": "Down"})
+	return notify.WithReceiverName(ctx, "tickets")
+}
+
+func testAlerts() []*types.Alert {
+	return []*types.Alert{{
+		Alert: model.Alert{
+			Labels:      model.LabelSet{"alertname": "Down", "queue": "infra", "severity": "critical"},
+			Annotations: model.LabelSet{"summary": `Service "api" is down`},
+		},
+	}}
+}
+
+func TestNotify(t *testing.T) {
+	t.Run("templates the method, url, headers and body", func(t *testing.T) {
+		server, received := newTestServer(t, http.StatusCreated, "")
+		n := newTestNotifier(t, Config{
+			URL:        server.URL + "/queues/{{ .CommonLabels.queue }}/tickets",
+			HTTPMethod: `{{ if eq .Status "firing" }}put{{ else }}delete{{ end }}`,
+			Headers: map[string]string{
+				"X-Priority": `{{ if eq .CommonLabels.severity "critical" }}P1{{ end }}`,
+				"X-Team":     "{{ .CommonLabels.team }}",
+			},
+			Body: `{"title": {{ printf "%q" .CommonAnnotations.summary }}, "alerts": {{ len .Alerts }}}`,
+		})
+
+		retry, err := n.Notify(notifyCtx(), testAlerts()...)
+		require.NoError(t, err)
+		assert.False(t, retry)
+
+		assert.Equal(t, http.MethodPut, received.method)
+		assert.Equal(t, "/queues/infra/tickets", received.path)
+		assert.Equal(t, "P1", received.header.Get("X-Priority"))
+		assert.NotContains(t, received.header, "X-Team", "headers that are templated to an empty value must not be sent")
+		assert.Equal(t, "application/json", received.header.Get("Content-Type"))
+		assert.Equal(t, `{"title": "Service \"api\" is down", "alerts": 1}`, received.body)
+	})
+
+	t.Run("sends basic authentication", func(t *testing.T) {
+		server, received := newTestServer(t, http.StatusOK, "")
+		n := newTestNotifier(t, Config{URL: server.URL, HTTPMethod: "POST", User: "user", Password: "password"})
+
+		_, err := n.Notify(notifyCtx(), testAlerts()...)
+		require.NoError(t, err)
+		assert.Equal(t, "Basic dXNlcjpwYXNzd29yZA==", received.header.Get("Authorization"))
+	})
+
+	t.Run("sends authorization header", func(t *testing.T) {
+		server, received := newTestServer(t, http.StatusOK, "")
+		n := newTestNotifier(t, Config{URL: server.URL, HTTPMethod: "POST", AuthorizationScheme: "Bearer", AuthorizationCredentials: "token"})
+
+		_, err := n.Notify(notifyCtx(), testAlerts()...)
+		require.NoError(t, err)
+		assert.Equal(t, "Bearer token", received.header.Get("Authorization"))
+	})
+
+	t.Run("sends secure headers", func(t *testing.T) {
+		server, received := newTestServer(t, http.StatusOK, "")
+		n := newTestNotifier(t, Config{URL: server.URL, HTTPMethod: "POST", SecureHeaders: map[string]string{"X-Api-Key": "{{ not a template }}"}})
+
+		_, err := n.Notify(notifyCtx(), testAlerts()...)
+		require.NoError(t, err)
+		assert.Equal(t, "{{ not a template }}", received.header.Get("X-Api-Key"))
+	})
+
+	t.Run("headers do not replace the authentication", func(t *testing.T) {
+		server, received := newTestServer(t, http.StatusOK, "")
+		n := newTestNotifier(t, Config{
+			URL:                      server.URL,
+			HTTPMethod:               "POST",
+			Headers:                  map[string]string{"Authorization": "Bearer other"},
+			SecureHeaders:            map[string]string{"Authorization": "Bearer secret"},
+			AuthorizationScheme:      "Bearer",
+			AuthorizationCredentials: "token",
+		})
+
+		_, err := n.Notify(notifyCtx(), testAlerts()...)
+		require.NoError(t, err)
+		assert.Equal(t, []string{"Bearer token"}, received.header.Values("Authorization"))
+	})
+
+	t.Run("signs the body", func(t *testing.T) {
+		server, received := newTestServer(t, http.StatusOK, "")
+		n := newTestNotifier(t, Config{URL: server.URL, HTTPMethod: "POST", Body: "{{ .Status }}", HMACSecret: "secret"})
+
+		_, err := n.Notify(notifyCtx(), testAlerts()...)
+		require.NoError(t, err)
+		mac := hmac.New(sha256.New, []byte("secret"))
+		_, _ = mac.Write([]byte("firing"))
+		assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), received.header.Get(DefaultHMACHeader))
+	})
+
+	t.Run("signs the timestamp and the body", func(t *testing.T) {
+		server, received := newTestServer(t, http.StatusOK, "")
+		n := newTestNotifier(t, Config{URL: server.URL, HTTPMethod: "POST", Body: "{{ .Status }}", HMACSecret: "secret", HMACHeader: "X-Signature", HMACTimestampHeader: "X-Timestamp"})
+
+		_, err := n.Notify(notifyCtx(), testAlerts()...)
+		require.NoError(t, err)
+		mac := hmac.New(sha256.New, []byte("secret"))
+		_, _ = mac.Write([]byte("1700000000.firing"))
+		assert.Equal(t, "1700000000", received.header.Get("X-Timestamp"))
+		assert.Equal(t, "sha256="+hex.EncodeToString(mac.Sum(nil)), received.header.Get("X-Signature"))
+	})
+
+	t.Run("uses the configured success status codes", func(t *testing.T) {
+		server, _ := newTestServer(t, http.StatusFound, "")
+		n := newTestNotifier(t, Config{URL: server.URL, HTTPMethod: "POST", SuccessStatusCodes: StatusCodes{{from: 200, to: 204}, {from: 302, to: 302}}})
+
+		retry, err := n.Notify(notifyCtx(), testAlerts()...)
+		require.NoError(t, err)
+		assert.False(t, retry)
+	})
+
+	t.Run("fails without retry on client errors", func(t *testing.T) {
+		server, _ := newTestServer(t, http.StatusBadRequest, "unknown queue")
+		n := newTestNotifier(t, Config{URL: server.URL, HTTPMethod: "POST"})
+
+		retry, err := n.Notify(notifyCtx(), testAlerts()...)
+		require.EqualError(t, err, "unexpected status 400 Bad Request: unknown queue")
+		assert.False(t, retry)
+	})
+
+	t.Run("fails with retry on server errors", func(t *testing.T) {
+		server, _ := newTestServer(t, http.StatusServiceUnavailable, "")
+		n := newTestNotifier(t, Config{URL: server.URL, HTTPMethod: "POST"})
+
+		retry, err := n.Notify(notifyCtx(), testAlerts()...)
+		require.EqualError(t, err, "unexpected status 503 Service Unavailable")
+		assert.True(t, retry)
+	})
+
+	t.Run("does not send credentials to another host", func(t *testing.T) {
+		server, received := newTestServer(t, http.StatusOK, "")
+		// NewConfig rejects this URL, the notifier checks the host again before it sends the request.
+		n := newTestNotifier(t, Config{
+			URL:                      "{{ if true }}" + server.URL + "{{ end }}/{{ .CommonLabels.queue }}",
+			HTTPMethod:               "POST",
+			AuthorizationScheme:      "Bearer",
+			AuthorizationCredentials: "token",
+		})
+
+		retry, err := n.Notify(notifyCtx(), testAlerts()...)
+		require.ErrorContains(t, err, "the templated url does not have the scheme and host of the configured url")
+		assert.False(t, retry)
+		assert.Empty(t, received.method)
+	})
+
+	t.Run("fails if a template is invalid", func(t *testing.T) {
+		n := newTestNotifier(t, Config{URL: "http://localhost", HTTPMethod: "POST", Body: "{{ .Missing }"})
+
+		retry, err := n.Notify(notifyCtx(), testAlerts()...)
+		require.ErrorContains(t, err, "failed to template body")
+		assert.False(t, retry)
+	})
+}
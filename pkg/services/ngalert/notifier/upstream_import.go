@@ -0,0 +1,699 @@
+package notifier
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"path"
+	"reflect"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	alertingNotify "github.com/grafana/alerting/notify"
+	alertingTemplates "github.com/grafana/alerting/templates"
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/alertmanager/pkg/labels"
+	commoncfg "github.com/prometheus/common/config"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// ConvertUpstreamConfig converts the configuration of a Prometheus Alertmanager to a Grafana Alertmanager configuration.
+// Template files are matched by file name against the templates of the configuration. Parts of the configuration that
+// cannot be imported are dropped and reported as diagnostics with error severity, parts that are imported with changes
+// are reported with warning severity. The returned configuration is nil if the configuration cannot be parsed.
+func ConvertUpstreamConfig(ctx context.Context, raw string, templateFiles map[string]string) (*definitions.PostableUserConfig, definitions.AlertmanagerImportDiagnostics) {
+	c := &upstreamConverter{}
+	upstream, err := config.Load(raw)
+	if err != nil {
+		c.errorf("", "failed to parse configuration: %s", err)
+		return nil, c.diags
+	}
+
+	result := &definitions.PostableUserConfig{
+		TemplateFiles: c.convertTemplates(upstream.Templates, templateFiles),
+	}
+	amConfig := &result.AlertmanagerConfig
+	amConfig.Route = c.convertRoute("route", upstream.Route)
+	amConfig.MuteTimeIntervals = upstream.MuteTimeIntervals
+	amConfig.TimeIntervals = upstream.TimeIntervals
+	for i, r := range upstream.InhibitRules {
+		amConfig.InhibitRules = append(amConfig.InhibitRules, c.convertInhibitRule(fmt.Sprintf("inhibit_rules[%d]", i), r))
+	}
+
+	tmpl, err := alertingTemplates.FromContent(sortedValues(result.TemplateFiles))
+	if err != nil {
+		// The templates are validated one by one, this happens only if they define the same template more than once.
+		c.errorf("templates", "failed to parse templates: %s", err)
+		tmpl = nil
+	}
+	for _, r := range upstream.Receivers {
+		amConfig.Receivers = append(amConfig.Receivers, c.convertReceiver(ctx, r, tmpl))
+	}
+
+	// Round-trip the configuration through the API model to validate it the same way as a posted configuration.
+	b, err := json.Marshal(result)
+	if err != nil {
+		c.errorf("", "failed to encode configuration: %s", err)
+		return nil, c.diags
+	}
+	var validated definitions.PostableUserConfig
+	if err := json.Unmarshal(b, &validated); err != nil {
+		c.errorf("", "invalid configuration: %s", err)
+		return result, c.diags
+	}
+	return &validated, c.diags
+}
+
+type upstreamConverter struct {
+	diags definitions.AlertmanagerImportDiagnostics
+}
+
+func (c *upstreamConverter) errorf(path string, format string, args ...any) {
+	c.diags = append(c.diags, definitions.AlertmanagerImportDiagnostic{
+		Severity: definitions.AlertmanagerImportError,
+		Path:     path,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (c *upstreamConverter) warnf(path string, format string, args ...any) {
+	c.diags = append(c.diags, definitions.AlertmanagerImportDiagnostic{
+		Severity: definitions.AlertmanagerImportWarning,
+		Path:     path,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+func (c *upstreamConverter) convertTemplates(globs []string, files map[string]string) map[string]string {
+	result := make(map[string]string)
+	matched := make(map[string]bool, len(files))
+	for i, glob := range globs {
+		p := fmt.Sprintf("templates[%d]", i)
+		pattern := path.Base(glob)
+		found := false
+		for name := range files {
+			if ok, err := path.Match(pattern, name); err != nil {
+				c.errorf(p, "invalid pattern %q: %s", glob, err)
+				break
+			} else if ok {
+				found = true
+				matched[name] = true
+			}
+		}
+		if !found {
+			c.warnf(p, "no template file matches %q", glob)
+		}
+	}
+	for _, name := range sortedKeys(files) {
+		if !matched[name] {
+			c.warnf("templates", "template file %s is not referenced by the configuration and was ignored", name)
+			continue
+		}
+		tmpl := definitions.NotificationTemplate{Name: name, Template: files[name]}
+		if err := tmpl.Validate(); err != nil {
+			c.errorf("templates", "template file %s: %s", name, err)
+			continue
+		}
+		result[name] = files[name]
+	}
+	return result
+}
+
+func (c *upstreamConverter) convertRoute(p string, r *config.Route) *definitions.Route {
+	if r == nil {
+		return nil
+	}
+	route := &definitions.Route{
+		Receiver:            r.Receiver,
+		GroupByStr:          r.GroupByStr,
+		MuteTimeIntervals:   r.MuteTimeIntervals,
+		ActiveTimeIntervals: r.ActiveTimeIntervals,
+		Continue:            r.Continue,
+		GroupWait:           r.GroupWait,
+		GroupInterval:       r.GroupInterval,
+		RepeatInterval:      r.RepeatInterval,
+	}
+	// Matchers of all kinds are converted to object matchers, the only kind that can be edited in Grafana.
+	for _, name := range sortedKeys(r.Match) {
+		route.ObjectMatchers = c.appendMatcher(p, route.ObjectMatchers, labels.MatchEqual, name, r.Match[name])
+	}
+	for _, name := range sortedKeys(r.MatchRE) {
+		value, _ := r.MatchRE[name].MarshalYAML()
+		s, _ := value.(string)
+		route.ObjectMatchers = c.appendMatcher(p, route.ObjectMatchers, labels.MatchRegexp, name, s)
+	}
+	route.ObjectMatchers = append(route.ObjectMatchers, r.Matchers...)
+	for i, child := range r.Routes {
+		route.Routes = append(route.Routes, c.convertRoute(fmt.Sprintf("%s.routes[%d]", p, i), child))
+	}
+	return route
+}
+
+// convertInhibitRule converts the matchers of all kinds of the inhibition rule to matchers, like the matchers of routes.
+func (c *upstreamConverter) convertInhibitRule(p string, r config.InhibitRule) config.InhibitRule {
+	convert := func(match map[string]string, matchRE config.MatchRegexps, matchers config.Matchers) config.Matchers {
+		var result definitions.ObjectMatchers
+		for _, name := range sortedKeys(match) {
+			result = c.appendMatcher(p, result, labels.MatchEqual, name, match[name])
+		}
+		for _, name := range sortedKeys(matchRE) {
+			value, _ := matchRE[name].MarshalYAML()
+			s, _ := value.(string)
+			result = c.appendMatcher(p, result, labels.MatchRegexp, name, s)
+		}
+		return append(config.Matchers(result), matchers...)
+	}
+	return config.InhibitRule{
+		SourceMatchers: convert(r.SourceMatch, r.SourceMatchRE, r.SourceMatchers),
+		TargetMatchers: convert(r.TargetMatch, r.TargetMatchRE, r.TargetMatchers),
+		Equal:          r.Equal,
+	}
+}
+
+func (c *upstreamConverter) appendMatcher(p string, matchers definitions.ObjectMatchers, t labels.MatchType, name, value string) definitions.ObjectMatchers {
+	m, err := labels.NewMatcher(t, name, value)
+	if err != nil {
+		c.errorf(p, "invalid matcher %s%s%q: %s", name, t, value, err)
+		return matchers
+	}
+	return append(matchers, m)
+}
+
+func (c *upstreamConverter) convertReceiver(ctx context.Context, r config.Receiver, tmpl *alertingTemplates.Template) *definitions.PostableApiReceiver {
+	receiver := &definitions.PostableApiReceiver{
+		Receiver: config.Receiver{Name: r.Name},
+	}
+	add := func(kind string, i int, cfg any, convert func(p string) *importedIntegration) {
+		p := fmt.Sprintf("receivers[%s].%s[%d]", r.Name, kind, i)
+		if c.hasSecretFiles(p, cfg) {
+			return
+		}
+		integration := convert(p)
+		if integration == nil {
+			return
+		}
+		gr, ok := c.validateIntegration(ctx, p, r.Name, integration, tmpl)
+		if !ok {
+			return
+		}
+		receiver.GrafanaManagedReceivers = append(receiver.GrafanaManagedReceivers, gr)
+	}
+	for i, cfg := range r.EmailConfigs {
+		add("email_configs", i, cfg, func(p string) *importedIntegration { return c.convertEmail(p, cfg) })
+	}
+	for i, cfg := range r.SlackConfigs {
+		add("slack_configs", i, cfg, func(p string) *importedIntegration { return c.convertSlack(p, cfg) })
+	}
+	for i, cfg := range r.PagerdutyConfigs {
+		add("pagerduty_configs", i, cfg, func(p string) *importedIntegration { return c.convertPagerduty(p, cfg) })
+	}
+	for i, cfg := range r.WebhookConfigs {
+		add("webhook_configs", i, cfg, func(p string) *importedIntegration { return c.convertWebhook(p, cfg) })
+	}
+	for i, cfg := range r.OpsGenieConfigs {
+		add("opsgenie_configs", i, cfg, func(p string) *importedIntegration { return c.convertOpsGenie(p, cfg) })
+	}
+	for i, cfg := range r.VictorOpsConfigs {
+		add("victorops_configs", i, cfg, func(p string) *importedIntegration { return c.convertVictorOps(p, cfg) })
+	}
+	for i, cfg := range r.PushoverConfigs {
+		add("pushover_configs", i, cfg, func(p string) *importedIntegration { return c.convertPushover(p, cfg) })
+	}
+	for i, cfg := range r.TelegramConfigs {
+		add("telegram_configs", i, cfg, func(p string) *importedIntegration { return c.convertTelegram(p, cfg) })
+	}
+	for i, cfg := range r.SNSConfigs {
+		add("sns_configs", i, cfg, func(p string) *importedIntegration { return c.convertSNS(p, cfg) })
+	}
+	for i, cfg := range r.DiscordConfigs {
+		add("discord_configs", i, cfg, func(p string) *importedIntegration { return c.convertDiscord(p, cfg) })
+	}
+	for i, cfg := range r.WebexConfigs {
+		add("webex_configs", i, cfg, func(p string) *importedIntegration { return c.convertWebex(p, cfg) })
+	}
+	for i, cfg := range r.MSTeamsConfigs {
+		add("msteams_configs", i, cfg, func(p string) *importedIntegration { return c.convertMSTeams(p, cfg) })
+	}
+	for i, cfg := range r.WechatConfigs {
+		add("wechat_configs", i, cfg, func(p string) *importedIntegration { return c.convertWechat(p, cfg) })
+	}
+	return receiver
+}
+
+// importedIntegration is the Grafana integration that an upstream integration is converted to.
+type importedIntegration struct {
+	typ          string
+	sendResolved bool
+	settings     map[string]any
+	secure       map[string]string
+}
+
+func newImportedIntegration(typ string, sendResolved bool) *importedIntegration {
+	return &importedIntegration{
+		typ:          typ,
+		sendResolved: sendResolved,
+		settings:     make(map[string]any),
+		secure:       make(map[string]string),
+	}
+}
+
+// set sets the setting if value is not empty.
+func (i *importedIntegration) set(key string, value string) {
+	if value != "" {
+		i.settings[key] = value
+	}
+}
+
+// setTemplate sets the setting if value is not the default of the upstream integration, so that the Grafana
+// integration uses its own default.
+func (i *importedIntegration) setTemplate(key string, value string, upstreamDefault string) {
+	if value != upstreamDefault {
+		i.set(key, value)
+	}
+}
+
+func (i *importedIntegration) setSecret(key string, value string) {
+	if value != "" {
+		i.secure[key] = value
+	}
+}
+
+// hasSecretFiles reports an error if the integration reads secrets from files, which are not available in Grafana.
+func (c *upstreamConverter) hasSecretFiles(p string, cfg any) bool {
+	v := reflect.ValueOf(cfg).Elem()
+	t := v.Type()
+	for i := 0; i < t.NumField(); i++ {
+		name := yamlName(t.Field(i))
+		if !strings.HasSuffix(name, "_file") || v.Field(i).Kind() != reflect.String || v.Field(i).String() == "" {
+			continue
+		}
+		c.errorf(p, "%s is not supported, secrets must be set in the configuration; the integration was not imported", name)
+		return true
+	}
+	return false
+}
+
+// dropUnsupported reports the named fields of the upstream integration that are set to other than their default
+// value. Fields are named as in the Go struct.
+func (c *upstreamConverter) dropUnsupported(p string, cfg any, upstreamDefault any, fields ...string) {
+	v := reflect.ValueOf(cfg).Elem()
+	d := reflect.ValueOf(upstreamDefault)
+	for _, name := range fields {
+		f, ok := v.Type().FieldByName(name)
+		if !ok {
+			continue
+		}
+		if reflect.DeepEqual(v.FieldByName(name).Interface(), d.FieldByName(name).Interface()) {
+			continue
+		}
+		c.warnf(p, "%s is not supported by the Grafana integration and was dropped", yamlName(f))
+	}
+}
+
+func yamlName(f reflect.StructField) string {
+	name, _, _ := strings.Cut(f.Tag.Get("yaml"), ",")
+	if name == "" {
+		return f.Name
+	}
+	return name
+}
+
+// httpConfig returns a copy of the HTTP client configuration of an integration. Integrations clear the parts that
+// they import and call checkHTTPConfig to report the remaining ones.
+func httpConfig(cfg *commoncfg.HTTPClientConfig) *commoncfg.HTTPClientConfig {
+	if cfg == nil {
+		c := commoncfg.DefaultHTTPClientConfig
+		return &c
+	}
+	c := *cfg
+	return &c
+}
+
+func (c *upstreamConverter) checkHTTPConfig(p string, cfg *commoncfg.HTTPClientConfig) {
+	if cfg.BasicAuth != nil && *cfg.BasicAuth == (commoncfg.BasicAuth{}) {
+		cfg.BasicAuth = nil
+	}
+	if cfg.Authorization != nil && *cfg.Authorization == (commoncfg.Authorization{}) {
+		cfg.Authorization = nil
+	}
+	if !reflect.DeepEqual(*cfg, commoncfg.DefaultHTTPClientConfig) {
+		c.warnf(p, "http_config is not supported by the Grafana integration and was dropped")
+	}
+}
+
+func (c *upstreamConverter) convertEmail(p string, cfg *config.EmailConfig) *importedIntegration {
+	i := newImportedIntegration("email", cfg.SendResolved())
+	i.set("addresses", cfg.To)
+	// The upstream integration sends a single email to all addresses.
+	i.settings["singleEmail"] = true
+	i.set("message", cfg.Text)
+	for name, value := range cfg.Headers {
+		if name == "Subject" {
+			i.setTemplate("subject", value, config.DefaultEmailSubject)
+			continue
+		}
+		c.warnf(p, "header %s is not supported by the Grafana integration and was dropped", name)
+	}
+	c.dropUnsupported(p, cfg, config.DefaultEmailConfig, "HTML")
+	if cfg.Smarthost.String() != "" || cfg.From != "" || cfg.AuthUsername != "" {
+		c.warnf(p, "Grafana sends email with the SMTP server of its own configuration, smarthost, from, authentication and TLS settings were dropped")
+	}
+	return i
+}
+
+func (c *upstreamConverter) convertSlack(p string, cfg *config.SlackConfig) *importedIntegration {
+	i := newImportedIntegration("slack", cfg.SendResolved())
+	if cfg.APIURL != nil {
+		i.setSecret("url", cfg.APIURL.String())
+	}
+	h := httpConfig(cfg.HTTPConfig)
+	if h.Authorization != nil && (h.Authorization.Type == "" || strings.EqualFold(h.Authorization.Type, "Bearer")) {
+		// Slack apps authenticate with a bearer token to the chat.postMessage API.
+		i.setSecret("token", string(h.Authorization.Credentials))
+		h.Authorization = nil
+	}
+	c.checkHTTPConfig(p, h)
+	i.set("recipient", cfg.Channel)
+	i.setTemplate("username", cfg.Username, config.DefaultSlackConfig.Username)
+	i.setTemplate("title", cfg.Title, config.DefaultSlackConfig.Title)
+	i.setTemplate("text", cfg.Text, config.DefaultSlackConfig.Text)
+	i.setTemplate("icon_emoji", cfg.IconEmoji, config.DefaultSlackConfig.IconEmoji)
+	i.setTemplate("icon_url", cfg.IconURL, config.DefaultSlackConfig.IconURL)
+	c.dropUnsupported(p, cfg, config.DefaultSlackConfig, "Color", "TitleLink", "Pretext", "Fields", "ShortFields",
+		"Footer", "Fallback", "CallbackID", "ImageURL", "ThumbURL", "LinkNames", "MrkdwnIn", "Actions")
+	return i
+}
+
+func (c *upstreamConverter) convertPagerduty(p string, cfg *config.PagerdutyConfig) *importedIntegration {
+	i := newImportedIntegration("pagerduty", cfg.SendResolved())
+	i.setSecret("integrationKey", string(cfg.RoutingKey))
+	if cfg.RoutingKey == "" && cfg.ServiceKey != "" {
+		i.setSecret("integrationKey", string(cfg.ServiceKey))
+		c.warnf(p, "service_key was imported as the integration key, Grafana sends events with the Events API v2")
+	}
+	if cfg.URL != nil {
+		i.setTemplate("url", cfg.URL.String(), "https://events.pagerduty.com/v2/enqueue")
+	}
+	c.checkHTTPConfig(p, httpConfig(cfg.HTTPConfig))
+	i.setTemplate("client", cfg.Client, config.DefaultPagerdutyConfig.Client)
+	i.setTemplate("client_url", cfg.ClientURL, config.DefaultPagerdutyConfig.ClientURL)
+	i.setTemplate("summary", cfg.Description, config.DefaultPagerdutyConfig.Description)
+	if cfg.Source != cfg.Client {
+		i.set("source", cfg.Source)
+	}
+	i.set("severity", cfg.Severity)
+	i.set("class", cfg.Class)
+	i.set("component", cfg.Component)
+	i.set("group", cfg.Group)
+	details := make(map[string]string)
+	for k, v := range cfg.Details {
+		if config.DefaultPagerdutyDetails[k] != v {
+			details[k] = v
+		}
+	}
+	if len(details) > 0 {
+		i.settings["details"] = details
+	}
+	c.dropUnsupported(p, cfg, config.DefaultPagerdutyConfig, "Images", "Links")
+	return i
+}
+
+func (c *upstreamConverter) convertWebhook(p string, cfg *config.WebhookConfig) *importedIntegration {
+	i := newImportedIntegration("webhook", cfg.SendResolved())
+	if cfg.URL != nil {
+		i.set("url", cfg.URL.String())
+	}
+	if cfg.MaxAlerts > 0 {
+		i.settings["maxAlerts"] = cfg.MaxAlerts
+	}
+	h := httpConfig(cfg.HTTPConfig)
+	if h.BasicAuth != nil && h.BasicAuth.UsernameFile == "" && h.BasicAuth.PasswordFile == "" {
+		i.set("username", h.BasicAuth.Username)
+		i.setSecret("password", string(h.BasicAuth.Password))
+		h.BasicAuth = nil
+	}
+	if h.Authorization != nil && h.Authorization.CredentialsFile == "" {
+		i.set("authorization_scheme", h.Authorization.Type)
+		i.setSecret("authorization_credentials", string(h.Authorization.Credentials))
+		h.Authorization = nil
+	}
+	if h.TLSConfig.InsecureSkipVerify {
+		i.settings["tlsConfig"] = map[string]any{"insecureSkipVerify": true}
+		h.TLSConfig.InsecureSkipVerify = false
+	}
+	c.checkHTTPConfig(p, h)
+	return i
+}
+
+func (c *upstreamConverter) convertOpsGenie(p string, cfg *config.OpsGenieConfig) *importedIntegration {
+	i := newImportedIntegration("opsgenie", cfg.SendResolved())
+	i.setSecret("apiKey", string(cfg.APIKey))
+	if cfg.APIURL != nil {
+		i.setTemplate("apiUrl", cfg.APIURL.String()+"v2/alerts", "https://api.opsgenie.com/v2/alerts")
+	}
+	c.checkHTTPConfig(p, httpConfig(cfg.HTTPConfig))
+	i.setTemplate("message", cfg.Message, config.DefaultOpsGenieConfig.Message)
+	i.setTemplate("description", cfg.Description, config.DefaultOpsGenieConfig.Description)
+	if len(cfg.Responders) > 0 {
+		responders := make([]map[string]string, 0, len(cfg.Responders))
+		for _, r := range cfg.Responders {
+			responder := map[string]string{"type": r.Type}
+			for k, v := range map[string]string{"id": r.ID, "name": r.Name, "username": r.Username} {
+				if v != "" {
+					responder[k] = v
+				}
+			}
+			responders = append(responders, responder)
+		}
+		i.settings["responders"] = responders
+	}
+	c.dropUnsupported(p, cfg, config.DefaultOpsGenieConfig, "Source", "Details", "Entity", "Actions", "Tags", "Note",
+		"Priority", "UpdateAlerts")
+	return i
+}
+
+func (c *upstreamConverter) convertVictorOps(p string, cfg *config.VictorOpsConfig) *importedIntegration {
+	i := newImportedIntegration("victorops", cfg.SendResolved())
+	// The Grafana integration posts to a URL that contains the API key and routing key.
+	if cfg.APIURL != nil {
+		i.set("url", cfg.APIURL.String()+string(cfg.APIKey)+"/"+cfg.RoutingKey)
+	}
+	c.checkHTTPConfig(p, httpConfig(cfg.HTTPConfig))
+	i.setTemplate("messageType", cfg.MessageType, config.DefaultVictorOpsConfig.MessageType)
+	i.setTemplate("title", cfg.EntityDisplayName, config.DefaultVictorOpsConfig.EntityDisplayName)
+	i.setTemplate("description", cfg.StateMessage, config.DefaultVictorOpsConfig.StateMessage)
+	c.dropUnsupported(p, cfg, config.DefaultVictorOpsConfig, "MonitoringTool", "CustomFields")
+	return i
+}
+
+func (c *upstreamConverter) convertPushover(p string, cfg *config.PushoverConfig) *importedIntegration {
+	i := newImportedIntegration("pushover", cfg.SendResolved())
+	i.setSecret("userKey", string(cfg.UserKey))
+	i.setSecret("apiToken", string(cfg.Token))
+	c.checkHTTPConfig(p, httpConfig(cfg.HTTPConfig))
+	i.setTemplate("title", cfg.Title, config.DefaultPushoverConfig.Title)
+	i.setTemplate("message", cfg.Message, config.DefaultPushoverConfig.Message)
+	i.set("device", cfg.Device)
+	i.set("sound", cfg.Sound)
+	switch {
+	case cfg.Priority == config.DefaultPushoverConfig.Priority:
+		i.settings["priority"] = 2
+		i.settings["okPriority"] = 0
+	case cfg.Priority != "":
+		if priority, err := strconv.Atoi(cfg.Priority); err == nil {
+			i.settings["priority"] = priority
+			i.settings["okPriority"] = priority
+		} else {
+			c.warnf(p, "priority must be a number in Grafana and was dropped")
+		}
+	}
+	i.settings["retry"] = int(time.Duration(cfg.Retry).Seconds())
+	i.settings["expire"] = int(time.Duration(cfg.Expire).Seconds())
+	c.dropUnsupported(p, cfg, config.DefaultPushoverConfig, "URL", "URLTitle", "TTL", "HTML")
+	return i
+}
+
+func (c *upstreamConverter) convertTelegram(p string, cfg *config.TelegramConfig) *importedIntegration {
+	i := newImportedIntegration("telegram", cfg.SendResolved())
+	i.setSecret("bottoken", string(cfg.BotToken))
+	i.set("chatid", strconv.FormatInt(cfg.ChatID, 10))
+	if cfg.APIUrl != nil && cfg.APIUrl.String() != "https://api.telegram.org" {
+		c.warnf(p, "api_url is not supported by the Grafana integration and was dropped")
+	}
+	c.checkHTTPConfig(p, httpConfig(cfg.HTTPConfig))
+	i.setTemplate("message", cfg.Message, config.DefaultTelegramConfig.Message)
+	i.set("parse_mode", cfg.ParseMode)
+	if cfg.DisableNotifications {
+		i.settings["disable_notifications"] = true
+	}
+	return i
+}
+
+func (c *upstreamConverter) convertSNS(p string, cfg *config.SNSConfig) *importedIntegration {
+	i := newImportedIntegration("sns", cfg.SendResolved())
+	i.set("api_url", cfg.APIUrl)
+	sigv4 := make(map[string]any)
+	if cfg.Sigv4.Region != "" {
+		sigv4["region"] = cfg.Sigv4.Region
+	}
+	if cfg.Sigv4.Profile != "" {
+		sigv4["profile"] = cfg.Sigv4.Profile
+	}
+	if cfg.Sigv4.RoleARN != "" {
+		sigv4["role_arn"] = cfg.Sigv4.RoleARN
+	}
+	i.settings["sigv4"] = sigv4
+	i.setSecret("sigv4.access_key", cfg.Sigv4.AccessKey)
+	i.setSecret("sigv4.secret_key", string(cfg.Sigv4.SecretKey))
+	c.checkHTTPConfig(p, httpConfig(cfg.HTTPConfig))
+	i.set("topic_arn", cfg.TopicARN)
+	i.set("phone_number", cfg.PhoneNumber)
+	i.set("target_arn", cfg.TargetARN)
+	i.setTemplate("subject", cfg.Subject, config.DefaultSNSConfig.Subject)
+	i.setTemplate("message", cfg.Message, config.DefaultSNSConfig.Message)
+	if len(cfg.Attributes) > 0 {
+		i.settings["attributes"] = cfg.Attributes
+	}
+	return i
+}
+
+func (c *upstreamConverter) convertDiscord(p string, cfg *config.DiscordConfig) *importedIntegration {
+	i := newImportedIntegration("discord", cfg.SendResolved())
+	if cfg.WebhookURL != nil {
+		i.setSecret("url", cfg.WebhookURL.String())
+	}
+	c.checkHTTPConfig(p, httpConfig(cfg.HTTPConfig))
+	i.setTemplate("title", cfg.Title, config.DefaultDiscordConfig.Title)
+	i.setTemplate("message", cfg.Message, config.DefaultDiscordConfig.Message)
+	return i
+}
+
+func (c *upstreamConverter) convertWebex(p string, cfg *config.WebexConfig) *importedIntegration {
+	i := newImportedIntegration("webex", cfg.SendResolved())
+	i.set("room_id", cfg.RoomID)
+	if cfg.APIURL != nil {
+		i.setTemplate("api_url", cfg.APIURL.String(), "https://webexapis.com/v1/messages")
+	}
+	h := httpConfig(cfg.HTTPConfig)
+	if h.Authorization != nil && h.Authorization.CredentialsFile == "" {
+		i.setSecret("bot_token", string(h.Authorization.Credentials))
+		h.Authorization = nil
+	}
+	c.checkHTTPConfig(p, h)
+	i.setTemplate("message", cfg.Message, config.DefaultWebexConfig.Message)
+	return i
+}
+
+func (c *upstreamConverter) convertMSTeams(p string, cfg *config.MSTeamsConfig) *importedIntegration {
+	i := newImportedIntegration("teams", cfg.SendResolved())
+	if cfg.WebhookURL != nil {
+		i.set("url", cfg.WebhookURL.String())
+	}
+	c.checkHTTPConfig(p, httpConfig(cfg.HTTPConfig))
+	i.setTemplate("title", cfg.Title, config.DefaultMSTeamsConfig.Title)
+	i.setTemplate("message", cfg.Text, config.DefaultMSTeamsConfig.Text)
+	c.dropUnsupported(p, cfg, config.DefaultMSTeamsConfig, "Summary")
+	return i
+}
+
+func (c *upstreamConverter) convertWechat(p string, cfg *config.WechatConfig) *importedIntegration {
+	i := newImportedIntegration("wecom", cfg.SendResolved())
+	if cfg.APIURL != nil {
+		i.setTemplate("endpointUrl", cfg.APIURL.String(), "https://qyapi.weixin.qq.com/cgi-bin/")
+	}
+	i.setSecret("secret", string(cfg.APISecret))
+	i.set("corp_id", cfg.CorpID)
+	i.setTemplate("agent_id", cfg.AgentID, config.DefaultWechatConfig.AgentID)
+	i.setTemplate("touser", cfg.ToUser, config.DefaultWechatConfig.ToUser)
+	i.setTemplate("message", cfg.Message, config.DefaultWechatConfig.Message)
+	i.set("msgtype", cfg.MessageType)
+	c.checkHTTPConfig(p, httpConfig(cfg.HTTPConfig))
+	c.dropUnsupported(p, cfg, config.DefaultWechatConfig, "ToParty", "ToTag")
+	return i
+}
+
+// validateIntegration builds the Grafana integration and reports an error if it is invalid. Templated settings are
+// executed with sample data, and failures are reported as warnings because they can depend on the data.
+func (c *upstreamConverter) validateIntegration(ctx context.Context, p string, name string, i *importedIntegration, tmpl *alertingTemplates.Template) (*definitions.PostableGrafanaReceiver, bool) {
+	settings, err := json.Marshal(i.settings)
+	if err != nil {
+		c.errorf(p, "failed to encode settings: %s", err)
+		return nil, false
+	}
+	encoded := make(map[string]string, len(i.secure))
+	for k, v := range i.secure {
+		encoded[k] = base64.StdEncoding.EncodeToString([]byte(v))
+	}
+	err = models.ValidateIntegration(ctx, alertingNotify.GrafanaIntegrationConfig{
+		Name:                  name,
+		Type:                  i.typ,
+		DisableResolveMessage: !i.sendResolved,
+		Settings:              settings,
+		SecureSettings:        encoded,
+	}, alertingNotify.NoopDecrypt)
+	if err != nil {
+		c.errorf(p, "the integration was not imported: %s", err)
+		return nil, false
+	}
+
+	if tmpl != nil {
+		data := sampleTemplateData(name)
+		for _, key := range sortedKeys(i.settings) {
+			text, ok := i.settings[key].(string)
+			if !ok || !strings.Contains(text, "{{") {
+				continue
+			}
+			if _, err := tmpl.ExecuteTextString(text, data); err != nil {
+				c.warnf(p, "template of setting %s failed with sample data: %s", key, err)
+			}
+		}
+	}
+
+	return &definitions.PostableGrafanaReceiver{
+		Name:                  name,
+		Type:                  i.typ,
+		DisableResolveMessage: !i.sendResolved,
+		Settings:              settings,
+		SecureSettings:        i.secure,
+	}, true
+}
+
+func sampleTemplateData(receiver string) *alertingTemplates.ExtendedData {
+	alert := alertingTemplates.ExtendedAlert{
+		Status:      "firing",
+		Labels:      alertingTemplates.KV{"alertname": "TestAlert", "instance": "Grafana"},
+		Annotations: alertingTemplates.KV{"summary": "Notification test"},
+		StartsAt:    time.Now(),
+	}
+	return &alertingTemplates.ExtendedData{
+		Receiver:          receiver,
+		Status:            "firing",
+		Alerts:            alertingTemplates.ExtendedAlerts{alert},
+		GroupLabels:       alertingTemplates.KV{"alertname": "TestAlert"},
+		CommonLabels:      alert.Labels,
+		CommonAnnotations: alert.Annotations,
+		ExternalURL:       "http://localhost:3000/",
+	}
+}
+
+func sortedKeys[V any](m map[string]V) []string {
+	keys := make([]string, 0, len(m))
+	for k := range m {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedValues(m map[string]string) []string {
+	values := make([]string, 0, len(m))
+	for _, k := range sortedKeys(m) {
+		values = append(values, m[k])
+	}
+	return values
+}
@@ -0,0 +1,221 @@
+package notifier
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/prometheus/alertmanager/config"
+	"github.com/prometheus/common/model"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+)
+
+const upstreamConfigForTest = `
+global:
+  slack_api_url: https://hooks.slack.com/services/T000/B000/XXXX
+  smtp_smarthost: smtp.example.com:587
+  smtp_from: alertmanager@example.com
+route:
+  receiver: team-a
+  group_by: [alertname, cluster]
+  group_wait: 30s
+  routes:
+    - receiver: team-b
+      match:
+        team: b
+      match_re:
+        service: api|web
+      continue: true
+      routes:
+        - receiver: team-a
+          matchers:
+            - severity="critical"
+          mute_time_intervals: [weekends]
+inhibit_rules:
+  - source_matchers: [severity="critical"]
+    target_matchers: [severity="warning"]
+    equal: [alertname]
+  - source_match:
+      alertname: ClusterDown
+    target_match_re:
+      alertname: Node.*
+    target_matchers: [team="b"]
+    equal: [cluster]
+time_intervals:
+  - name: weekends
+    time_intervals:
+      - weekdays: [saturday, sunday]
+receivers:
+  - name: team-a
+    slack_configs:
+      - channel: '#alerts'
+        title: '{{ template "team.title" . }}'
+        color: good
+    email_configs:
+      - to: team-a@example.com
+  - name: team-b
+    webhook_configs:
+      - url: https://example.com/hook
+        send_resolved: false
+        http_config:
+          basic_auth:
+            username: user
+            password: secret
+    pagerduty_configs:
+      - routing_key_file: /etc/alertmanager/pagerduty
+    opsgenie_configs:
+      - api_key: key
+        responders:
+          - name: ops
+            type: team
+templates:
+  - /etc/alertmanager/templates/*.tmpl
+`
+
+func TestConvertUpstreamConfig(t *testing.T) {
+	templates := map[string]string{
+		"team.tmpl":   `{{ define "team.title" }}[{{ .Status }}] {{ .GroupLabels.alertname }}{{ end }}`,
+		"unused.txt":  `{{ define "unused" }}{{ end }}`,
+		"broken.tmpl": `{{ define "broken" }}{{ .Status }`,
+	}
+
+	cfg, diags := ConvertUpstreamConfig(context.Background(), upstreamConfigForTest, templates)
+	require.NotNil(t, cfg)
+
+	t.Run("should report the parts that cannot be imported", func(t *testing.T) {
+		assert.True(t, diags.HasErrors())
+		assert.ElementsMatch(t, []definitions.AlertmanagerImportDiagnostic{
+			{Severity: "error", Path: "templates", Message: "template file broken.tmpl: invalid template: template: broken.tmpl:1: unexpected \"}\" in operand"},
+			{Severity: "warning", Path: "templates", Message: "template file unused.txt is not referenced by the configuration and was ignored"},
+			{Severity: "warning", Path: "receivers[team-a].email_configs[0]", Message: "Grafana sends email with the SMTP server of its own configuration, smarthost, from, authentication and TLS settings were dropped"},
+			{Severity: "warning", Path: "receivers[team-a].slack_configs[0]", Message: "color is not supported by the Grafana integration and was dropped"},
+			{Severity: "error", Path: "receivers[team-b].pagerduty_configs[0]", Message: "routing_key_file is not supported, secrets must be set in the configuration; the integration was not imported"},
+		}, []definitions.AlertmanagerImportDiagnostic(diags))
+	})
+
+	t.Run("should convert routes to object matchers", func(t *testing.T) {
+		route := cfg.AlertmanagerConfig.Route
+		assert.Equal(t, "team-a", route.Receiver)
+		assert.Equal(t, []string{"alertname", "cluster"}, route.GroupByStr)
+		require.Len(t, route.Routes, 1)
+		child := route.Routes[0]
+		assert.True(t, child.Continue)
+		assert.Empty(t, child.Match)
+		assert.Empty(t, child.MatchRE)
+		matchers := make([]string, 0, len(child.ObjectMatchers))
+		for _, m := range child.ObjectMatchers {
+			matchers = append(matchers, m.String())
+		}
+		assert.ElementsMatch(t, []string{`team="b"`, `service=~"api|web"`}, matchers)
+		require.Len(t, child.Routes, 1)
+		assert.Equal(t, `severity="critical"`, child.Routes[0].ObjectMatchers[0].String())
+		assert.Equal(t, []string{"weekends"}, child.Routes[0].MuteTimeIntervals)
+		require.Len(t, cfg.AlertmanagerConfig.TimeIntervals, 1)
+	})
+
+	t.Run("should convert inhibition rules to matchers", func(t *testing.T) {
+		matchers := func(m config.Matchers) []string {
+			result := make([]string, 0, len(m))
+			for _, matcher := range m {
+				result = append(result, matcher.String())
+			}
+			return result
+		}
+		rules := cfg.AlertmanagerConfig.InhibitRules
+		require.Len(t, rules, 2)
+		assert.Equal(t, []string{`severity="critical"`}, matchers(rules[0].SourceMatchers))
+		assert.Equal(t, []string{`severity="warning"`}, matchers(rules[0].TargetMatchers))
+		assert.Equal(t, model.LabelNames{"alertname"}, rules[0].Equal)
+
+		assert.Empty(t, rules[1].SourceMatch)
+		assert.Empty(t, rules[1].TargetMatchRE)
+		assert.Equal(t, []string{`alertname="ClusterDown"`}, matchers(rules[1].SourceMatchers))
+		assert.Equal(t, []string{`alertname=~"Node.*"`, `team="b"`}, matchers(rules[1].TargetMatchers))
+		assert.Equal(t, model.LabelNames{"cluster"}, rules[1].Equal)
+	})
+
+	t.Run("should keep the referenced templates", func(t *testing.T) {
+		assert.Equal(t, map[string]string{"team.tmpl": templates["team.tmpl"]}, cfg.TemplateFiles)
+	})
+
+	t.Run("should convert integrations", func(t *testing.T) {
+		receivers := cfg.AlertmanagerConfig.Receivers
+		require.Len(t, receivers, 2)
+
+		teamA := receivers[0].GrafanaManagedReceivers
+		require.Len(t, teamA, 2)
+		assert.Equal(t, "email", teamA[0].Type)
+		assert.True(t, teamA[0].DisableResolveMessage)
+		assert.JSONEq(t, `{"addresses":"team-a@example.com","singleEmail":true}`, string(teamA[0].Settings))
+		assert.Equal(t, "slack", teamA[1].Type)
+		assert.JSONEq(t, `{"recipient":"#alerts","title":"{{ template \"team.title\" . }}"}`, string(teamA[1].Settings))
+		assert.Equal(t, map[string]string{"url": "https://hooks.slack.com/services/T000/B000/XXXX"}, teamA[1].SecureSettings)
+
+		teamB := receivers[1].GrafanaManagedReceivers
+		require.Len(t, teamB, 2)
+		assert.Equal(t, "webhook", teamB[0].Type)
+		assert.True(t, teamB[0].DisableResolveMessage)
+		assert.JSONEq(t, `{"url":"https://example.com/hook","username":"user"}`, string(teamB[0].Settings))
+		assert.Equal(t, map[string]string{"password": "secret"}, teamB[0].SecureSettings)
+		assert.Equal(t, "opsgenie", teamB[1].Type)
+		assert.False(t, teamB[1].DisableResolveMessage)
+		assert.JSONEq(t, `{"responders":[{"name":"ops","type":"team"}]}`, string(teamB[1].Settings))
+		assert.Equal(t, map[string]string{"apiKey": "key"}, teamB[1].SecureSettings)
+	})
+
+	t.Run("should be a valid configuration", func(t *testing.T) {
+		b, err := json.Marshal(cfg)
+		require.NoError(t, err)
+		var posted definitions.PostableUserConfig
+		require.NoError(t, json.Unmarshal(b, &posted))
+	})
+}
+
+func TestConvertUpstreamConfigErrors(t *testing.T) {
+	t.Run("should fail on invalid configuration", func(t *testing.T) {
+		cfg, diags := ConvertUpstreamConfig(context.Background(), "route: [", nil)
+		assert.Nil(t, cfg)
+		require.Len(t, diags, 1)
+		assert.Equal(t, definitions.AlertmanagerImportError, diags[0].Severity)
+		assert.Contains(t, diags[0].Message, "failed to parse configuration")
+	})
+
+	t.Run("should report templates that fail with sample data", func(t *testing.T) {
+		raw := `
+route:
+  receiver: default
+receivers:
+  - name: default
+    slack_configs:
+      - api_url: https://hooks.slack.com/services/T000/B000/XXXX
+        title: '{{ template "missing" . }}'
+`
+		cfg, diags := ConvertUpstreamConfig(context.Background(), raw, nil)
+		require.NotNil(t, cfg)
+		assert.False(t, diags.HasErrors())
+		require.Len(t, diags, 1)
+		assert.Equal(t, "receivers[default].slack_configs[0]", diags[0].Path)
+		assert.Contains(t, diags[0].Message, "template of setting title failed with sample data")
+	})
+
+	t.Run("should drop integrations that are invalid in Grafana", func(t *testing.T) {
+		raw := `
+route:
+  receiver: default
+receivers:
+  - name: default
+    wechat_configs:
+      - corp_id: corp
+        api_secret: secret
+`
+		cfg, diags := ConvertUpstreamConfig(context.Background(), raw, nil)
+		require.NotNil(t, cfg)
+		require.Len(t, diags, 1)
+		assert.Equal(t, definitions.AlertmanagerImportError, diags[0].Severity)
+		assert.Contains(t, diags[0].Message, "the integration was not imported")
+		assert.Empty(t, cfg.AlertmanagerConfig.Receivers[0].GrafanaManagedReceivers)
+	})
+}
@@ -2,6 +2,7 @@ package provisioning
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/grafana/grafana/pkg/apimachinery/errutil"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
@@ -25,6 +26,10 @@ var (
 	ErrTemplateNotFound = errutil.NotFound("alerting.notifications.templates.notFound")
 	ErrTemplateInvalid  = errutil.BadRequest("alerting.notifications.templates.invalidFormat").MustTemplate("Invalid format of the submitted template", errutil.WithPublic("Template is in invalid format. Correct the payload and try again."))
 	ErrTemplateExists   = errutil.BadRequest("alerting.notifications.templates.nameExists", errutil.WithPublicMessage("Template file with this name already exists. Use a different name or update existing one."))
+	ErrTemplateFixtures = errutil.BadRequest("alerting.notifications.templates.fixturesFailed").MustTemplate(
+		"Template does not render the expected output of its fixtures: {{.Public.Error}}",
+		errutil.WithPublic("Template does not render the expected output of its fixtures: {{.Public.Error}}. Correct the template or the fixtures and try again."),
+	)
 
 	ErrContactPointReferenced = errutil.Conflict("alerting.notifications.contact-points.referenced", errutil.WithPublicMessage("Contact point is currently referenced by a notification policy."))
 	ErrContactPointUsedInRule = errutil.Conflict("alerting.notifications.contact-points.used-by-rule", errutil.WithPublicMessage("Contact point is currently used in the notification settings of one or many alert rules."))
@@ -78,6 +83,35 @@ func MakeErrTemplateInvalid(err error) error {
 	return ErrTemplateInvalid.Build(data)
 }
 
+// MakeErrTemplateFixtures creates an error with the ErrTemplateFixtures template. The results of the failed fixtures
+// are returned in the public payload.
+func MakeErrTemplateFixtures(failed []models.TemplateFixtureResult) error {
+	reasons := make([]string, 0, len(failed))
+	results := make([]map[string]any, 0, len(failed))
+	for _, r := range failed {
+		reason := "output differs"
+		if r.Error != "" {
+			reason = r.Error
+		}
+		reasons = append(reasons, fmt.Sprintf("fixture %q, definition %q: %s", r.Fixture, r.Definition, reason))
+		results = append(results, map[string]any{
+			"fixture":    r.Fixture,
+			"template":   r.Template,
+			"definition": r.Definition,
+			"expected":   r.Expected,
+			"actual":     r.Actual,
+			"diff":       r.Diff,
+			"error":      r.Error,
+		})
+	}
+	return ErrTemplateFixtures.Build(errutil.TemplateData{
+		Public: map[string]any{
+			"Error":   strings.Join(reasons, "; "),
+			"Results": results,
+		},
+	})
+}
+
 func MakeErrTimeIntervalDependentResourcesProvenance(usedByRoutes bool, rules []models.AlertRuleKey) error {
 	uids := make([]string, 0, len(rules))
 	for _, key := range rules {
@@ -0,0 +1,332 @@
+package provisioning
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/provisioning/validation"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+// RuleTemplateStore represents the ability to persist and query rule templates and the rule groups created from them.
+type RuleTemplateStore interface {
+	GetRuleTemplate(ctx context.Context, orgID int64, uid string) (*models.RuleTemplate, error)
+	ListRuleTemplates(ctx context.Context, orgID int64) ([]*models.RuleTemplate, error)
+	InsertRuleTemplate(ctx context.Context, t models.RuleTemplate) (*models.RuleTemplate, error)
+	UpdateRuleTemplate(ctx context.Context, t models.RuleTemplate) (*models.RuleTemplate, error)
+	DeleteRuleTemplate(ctx context.Context, orgID int64, uid string) error
+	GetRuleTemplateVersions(ctx context.Context, orgID int64, uid string) ([]*models.RuleTemplate, error)
+	GetRuleTemplateInstance(ctx context.Context, key models.AlertRuleGroupKey) (*models.RuleTemplateInstance, error)
+	ListRuleTemplateInstances(ctx context.Context, orgID int64, templateUID string) ([]*models.RuleTemplateInstance, error)
+	UpsertRuleTemplateInstance(ctx context.Context, instance models.RuleTemplateInstance) error
+	DeleteRuleTemplateInstance(ctx context.Context, key models.AlertRuleGroupKey) error
+	DeleteOrphanedRuleTemplateInstances(ctx context.Context, orgID int64) (int64, error)
+}
+
+// ruleGroupService is the part of AlertRuleService that writes the rule groups of the instances of templates.
+type ruleGroupService interface {
+	GetAlertRule(ctx context.Context, user identity.Requester, ruleUID string) (models.AlertRule, models.Provenance, error)
+	CreateAlertRule(ctx context.Context, user identity.Requester, rule models.AlertRule, provenance models.Provenance) (models.AlertRule, error)
+	ReplaceRuleGroup(ctx context.Context, user identity.Requester, group models.AlertRuleGroup, provenance models.Provenance) error
+	DeleteRuleGroup(ctx context.Context, user identity.Requester, namespaceUID, group string, provenance models.Provenance) error
+}
+
+// RuleTemplateService manages rule templates and the rule groups created from them. The rule groups are
+// written through AlertRuleService, so that they are validated and authorized like any other rule group.
+type RuleTemplateService struct {
+	store           RuleTemplateStore
+	provenanceStore ProvisioningStore
+	xact            TransactionManager
+	rules           ruleGroupService
+	validator       validation.ProvenanceStatusTransitionValidator
+	log             log.Logger
+}
+
+func NewRuleTemplateService(store RuleTemplateStore, prov ProvisioningStore, xact TransactionManager, rules ruleGroupService, log log.Logger) *RuleTemplateService {
+	return &RuleTemplateService{
+		store:           store,
+		provenanceStore: prov,
+		xact:            xact,
+		rules:           rules,
+		validator:       validation.ValidateProvenanceRelaxed,
+		log:             log,
+	}
+}
+
+func (service *RuleTemplateService) GetRuleTemplates(ctx context.Context, orgID int64) ([]models.RuleTemplate, map[string]models.Provenance, error) {
+	templates, err := service.store.ListRuleTemplates(ctx, orgID)
+	if err != nil {
+		return nil, nil, err
+	}
+	provenances, err := service.provenanceStore.GetProvenances(ctx, orgID, (&models.RuleTemplate{}).ResourceType())
+	if err != nil {
+		return nil, nil, err
+	}
+	result := make([]models.RuleTemplate, 0, len(templates))
+	for _, t := range templates {
+		result = append(result, *t)
+	}
+	return result, provenances, nil
+}
+
+func (service *RuleTemplateService) GetRuleTemplate(ctx context.Context, orgID int64, uid string) (models.RuleTemplate, models.Provenance, error) {
+	t, err := service.store.GetRuleTemplate(ctx, orgID, uid)
+	if err != nil {
+		return models.RuleTemplate{}, models.ProvenanceNone, err
+	}
+	provenance, err := service.provenanceStore.GetProvenance(ctx, t, orgID)
+	if err != nil {
+		return models.RuleTemplate{}, models.ProvenanceNone, err
+	}
+	return *t, provenance, nil
+}
+
+// GetRuleTemplateVersions returns the history of the template, the latest version first.
+func (service *RuleTemplateService) GetRuleTemplateVersions(ctx context.Context, orgID int64, uid string) ([]models.RuleTemplate, error) {
+	if _, err := service.store.GetRuleTemplate(ctx, orgID, uid); err != nil {
+		return nil, err
+	}
+	versions, err := service.store.GetRuleTemplateVersions(ctx, orgID, uid)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.RuleTemplate, 0, len(versions))
+	for _, v := range versions {
+		result = append(result, *v)
+	}
+	return result, nil
+}
+
+// GetRuleTemplateInstances returns the rule groups created from the template.
+func (service *RuleTemplateService) GetRuleTemplateInstances(ctx context.Context, orgID int64, uid string) ([]models.RuleTemplateInstance, error) {
+	if _, err := service.store.GetRuleTemplate(ctx, orgID, uid); err != nil {
+		return nil, err
+	}
+	instances, err := service.store.ListRuleTemplateInstances(ctx, orgID, uid)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.RuleTemplateInstance, 0, len(instances))
+	for _, i := range instances {
+		result = append(result, *i)
+	}
+	return result, nil
+}
+
+func (service *RuleTemplateService) CreateRuleTemplate(ctx context.Context, orgID int64, t models.RuleTemplate, provenance models.Provenance) (models.RuleTemplate, error) {
+	if t.UID == "" {
+		t.UID = util.GenerateShortUID()
+	} else if err := util.ValidateUID(t.UID); err != nil {
+		return models.RuleTemplate{}, models.ErrRuleTemplateInvalid(fmt.Errorf("cannot create rule template with UID '%s': %w", t.UID, err))
+	}
+	t.OrgID = orgID
+	if err := t.Validate(); err != nil {
+		return models.RuleTemplate{}, models.ErrRuleTemplateInvalid(err)
+	}
+
+	var created *models.RuleTemplate
+	err := service.xact.InTransaction(ctx, func(ctx context.Context) error {
+		var err error
+		created, err = service.store.InsertRuleTemplate(ctx, t)
+		if err != nil {
+			return err
+		}
+		return service.provenanceStore.SetProvenance(ctx, created, orgID, provenance)
+	})
+	if err != nil {
+		return models.RuleTemplate{}, err
+	}
+	return *created, nil
+}
+
+// UpdateRuleTemplate saves a new version of the template and updates the rule groups created from it.
+// If the version of the template is set, it must be the current version of the template.
+func (service *RuleTemplateService) UpdateRuleTemplate(ctx context.Context, user identity.Requester, t models.RuleTemplate, provenance models.Provenance) (models.RuleTemplate, error) {
+	orgID := user.GetOrgID()
+	t.OrgID = orgID
+	if err := t.Validate(); err != nil {
+		return models.RuleTemplate{}, models.ErrRuleTemplateInvalid(err)
+	}
+
+	var updated *models.RuleTemplate
+	err := service.xact.InTransaction(ctx, func(ctx context.Context) error {
+		existing, err := service.store.GetRuleTemplate(ctx, orgID, t.UID)
+		if err != nil {
+			return err
+		}
+		storedProvenance, err := service.provenanceStore.GetProvenance(ctx, existing, orgID)
+		if err != nil {
+			return err
+		}
+		if err := service.validator(storedProvenance, provenance); err != nil {
+			return err
+		}
+		t.ID = existing.ID
+		if t.Version == 0 {
+			t.Version = existing.Version
+		}
+		updated, err = service.store.UpdateRuleTemplate(ctx, t)
+		if err != nil {
+			return err
+		}
+		if err := service.provenanceStore.SetProvenance(ctx, updated, orgID, provenance); err != nil {
+			return err
+		}
+
+		if err := service.deleteOrphanedInstances(ctx, orgID); err != nil {
+			return err
+		}
+		instances, err := service.store.ListRuleTemplateInstances(ctx, orgID, t.UID)
+		if err != nil {
+			return err
+		}
+		for _, instance := range instances {
+			if err := service.applyTemplate(ctx, user, *updated, *instance, nil); err != nil {
+				return fmt.Errorf("failed to update rule group %s in folder %s: %w", instance.RuleGroup, instance.NamespaceUID, err)
+			}
+		}
+		service.log.Debug("Updated rule template", "uid", t.UID, "version", updated.Version, "ruleGroups", len(instances))
+		return nil
+	})
+	if err != nil {
+		return models.RuleTemplate{}, err
+	}
+	return *updated, nil
+}
+
+// DeleteRuleTemplate deletes the template and its history. Templates that rule groups are created from cannot be deleted.
+func (service *RuleTemplateService) DeleteRuleTemplate(ctx context.Context, orgID int64, uid string, provenance models.Provenance) error {
+	return service.xact.InTransaction(ctx, func(ctx context.Context) error {
+		existing, err := service.store.GetRuleTemplate(ctx, orgID, uid)
+		if err != nil {
+			return err
+		}
+		storedProvenance, err := service.provenanceStore.GetProvenance(ctx, existing, orgID)
+		if err != nil {
+			return err
+		}
+		if err := service.validator(storedProvenance, provenance); err != nil {
+			return err
+		}
+		if err := service.deleteOrphanedInstances(ctx, orgID); err != nil {
+			return err
+		}
+		instances, err := service.store.ListRuleTemplateInstances(ctx, orgID, uid)
+		if err != nil {
+			return err
+		}
+		if len(instances) > 0 {
+			return models.ErrRuleTemplateInUse.Errorf("rule template %s is used by %d rule groups", uid, len(instances))
+		}
+		if err := service.store.DeleteRuleTemplate(ctx, orgID, uid); err != nil {
+			return err
+		}
+		return service.provenanceStore.DeleteProvenance(ctx, existing, orgID)
+	})
+}
+
+// GetRuleGroupTemplate returns the template and values that the rule group is created from.
+func (service *RuleTemplateService) GetRuleGroupTemplate(ctx context.Context, key models.AlertRuleGroupKey) (models.RuleTemplateInstance, error) {
+	instance, err := service.store.GetRuleTemplateInstance(ctx, key)
+	if err != nil {
+		return models.RuleTemplateInstance{}, err
+	}
+	return *instance, nil
+}
+
+// SetRuleGroupTemplate replaces the rules of the rule group with the rules of the template.
+// The rule group is updated whenever the template is updated.
+func (service *RuleTemplateService) SetRuleGroupTemplate(ctx context.Context, user identity.Requester, instance models.RuleTemplateInstance, provenance models.Provenance) (models.RuleTemplateInstance, error) {
+	instance.OrgID = user.GetOrgID()
+	err := service.xact.InTransaction(ctx, func(ctx context.Context) error {
+		t, err := service.store.GetRuleTemplate(ctx, instance.OrgID, instance.TemplateUID)
+		if err != nil {
+			return err
+		}
+		if err := service.applyTemplate(ctx, user, *t, instance, &provenance); err != nil {
+			return err
+		}
+		instance.TemplateVersion = t.Version
+		return nil
+	})
+	if err != nil {
+		return models.RuleTemplateInstance{}, err
+	}
+	return instance, nil
+}
+
+// DeleteRuleGroupTemplate deletes the rule group that is created from a template.
+func (service *RuleTemplateService) DeleteRuleGroupTemplate(ctx context.Context, user identity.Requester, key models.AlertRuleGroupKey, provenance models.Provenance) error {
+	return service.xact.InTransaction(ctx, func(ctx context.Context) error {
+		if _, err := service.store.GetRuleTemplateInstance(ctx, key); err != nil {
+			return err
+		}
+		err := service.rules.DeleteRuleGroup(ctx, user, key.NamespaceUID, key.RuleGroup, provenance)
+		if err != nil && !errors.Is(err, models.ErrAlertRuleGroupNotFound) {
+			return err
+		}
+		return service.store.DeleteRuleTemplateInstance(ctx, key)
+	})
+}
+
+// deleteOrphanedInstances deletes the instances whose rule group was deleted, renamed or moved to another folder
+// outside of the rule template API, so that the rule group is not recreated from the template.
+func (service *RuleTemplateService) deleteOrphanedInstances(ctx context.Context, orgID int64) error {
+	deleted, err := service.store.DeleteOrphanedRuleTemplateInstances(ctx, orgID)
+	if err != nil {
+		return fmt.Errorf("failed to delete the instances of rule groups that no longer exist: %w", err)
+	}
+	if deleted > 0 {
+		service.log.Info("Deleted the rule template instances of rule groups that no longer exist", "orgID", orgID, "count", deleted)
+	}
+	return nil
+}
+
+// applyTemplate replaces the rules of the rule group of the instance with the rules of the template and saves the instance.
+// If provenance is nil, the rule group keeps the provenance of its rules.
+func (service *RuleTemplateService) applyTemplate(ctx context.Context, user identity.Requester, t models.RuleTemplate, instance models.RuleTemplateInstance, provenance *models.Provenance) error {
+	rules, err := t.Render(instance)
+	if err != nil {
+		return models.ErrRuleTemplateInvalid(err)
+	}
+	groupProvenance := models.ProvenanceNone
+	if provenance != nil {
+		groupProvenance = *provenance
+	}
+	// The rules of an instance have fixed UIDs, and rule groups can be replaced only with rules that exist or
+	// have no UID. Therefore, rules that are added to the template are created before the group is replaced.
+	var missing []models.AlertRule
+	for _, rule := range rules {
+		_, stored, err := service.rules.GetAlertRule(ctx, user, rule.UID)
+		if errors.Is(err, models.ErrAlertRuleNotFound) {
+			missing = append(missing, rule)
+			continue
+		}
+		if err != nil {
+			return err
+		}
+		if provenance == nil {
+			groupProvenance = stored
+		}
+	}
+	for _, rule := range missing {
+		if _, err := service.rules.CreateAlertRule(ctx, user, rule, groupProvenance); err != nil {
+			return err
+		}
+	}
+	group := models.AlertRuleGroup{
+		Title:     instance.RuleGroup,
+		FolderUID: instance.NamespaceUID,
+		Interval:  instance.IntervalSeconds,
+		Rules:     rules,
+	}
+	if err := service.rules.ReplaceRuleGroup(ctx, user, group, groupProvenance); err != nil {
+		return err
+	}
+	instance.TemplateVersion = t.Version
+	return service.store.UpsertRuleTemplateInstance(ctx, instance)
+}
@@ -0,0 +1,371 @@
+package provisioning
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/provisioning/validation"
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
+	"github.com/grafana/grafana/pkg/services/ngalert/tests/fakes"
+	"github.com/grafana/grafana/pkg/services/user"
+)
+
+func TestRuleTemplateService(t *testing.T) {
+	orgID := int64(1)
+	u := &user.SignedInUser{OrgID: orgID}
+	ctx := context.Background()
+
+	newTemplate := func() models.RuleTemplate {
+		rule := models.RuleGen.With(models.RuleMuts.WithOrgID(orgID), models.RuleMuts.WithTitle("${service} latency")).Generate()
+		rule.UID = "latency"
+		return models.RuleTemplate{
+			UID:   "service",
+			Title: "Service",
+			Parameters: []models.RuleTemplateParameter{
+				{Name: "service", Type: models.RuleTemplateParameterString},
+			},
+			Rules: []models.AlertRule{rule},
+		}
+	}
+	instance := models.RuleTemplateInstance{
+		OrgID:           orgID,
+		NamespaceUID:    "folder",
+		RuleGroup:       "checkout",
+		TemplateUID:     "service",
+		IntervalSeconds: 60,
+		Values:          map[string]string{"service": "checkout"},
+	}
+
+	t.Run("create should validate the template", func(t *testing.T) {
+		service, _, _, _ := createRuleTemplateServiceSut()
+		tmpl := newTemplate()
+		tmpl.Rules[0].Title = "${unknown}"
+		_, err := service.CreateRuleTemplate(ctx, orgID, tmpl, models.ProvenanceAPI)
+		require.ErrorIs(t, err, models.ErrRuleTemplateInvalidBase)
+	})
+
+	t.Run("create should save the template with provenance", func(t *testing.T) {
+		service, _, _, prov := createRuleTemplateServiceSut()
+		created, err := service.CreateRuleTemplate(ctx, orgID, newTemplate(), models.ProvenanceAPI)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, created.Version)
+
+		_, provenance, err := service.GetRuleTemplate(ctx, orgID, created.UID)
+		require.NoError(t, err)
+		require.Equal(t, models.ProvenanceAPI, provenance)
+		stored, err := prov.GetProvenance(ctx, &created, orgID)
+		require.NoError(t, err)
+		require.Equal(t, models.ProvenanceAPI, stored)
+	})
+
+	t.Run("set rule group template should create the rules of the group", func(t *testing.T) {
+		service, templates, rules, _ := createRuleTemplateServiceSut()
+		_, err := service.CreateRuleTemplate(ctx, orgID, newTemplate(), models.ProvenanceAPI)
+		require.NoError(t, err)
+
+		result, err := service.SetRuleGroupTemplate(ctx, u, instance, models.ProvenanceAPI)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, result.TemplateVersion)
+
+		group := rules.groups[instance.GetGroupKey()]
+		require.Len(t, group, 1)
+		assert.Equal(t, "checkout latency", group[0].Title)
+		assert.Equal(t, []string{"CreateAlertRule", "ReplaceRuleGroup"}, rules.calls)
+
+		saved, err := templates.GetRuleTemplateInstance(ctx, instance.GetGroupKey())
+		require.NoError(t, err)
+		require.Equal(t, instance.Values, saved.Values)
+		require.EqualValues(t, 1, saved.TemplateVersion)
+	})
+
+	t.Run("set rule group template should fail if a required value is missing", func(t *testing.T) {
+		service, _, _, _ := createRuleTemplateServiceSut()
+		_, err := service.CreateRuleTemplate(ctx, orgID, newTemplate(), models.ProvenanceAPI)
+		require.NoError(t, err)
+
+		invalid := instance
+		invalid.Values = nil
+		_, err = service.SetRuleGroupTemplate(ctx, u, invalid, models.ProvenanceAPI)
+		require.ErrorIs(t, err, models.ErrRuleTemplateInvalidBase)
+	})
+
+	t.Run("update should update every rule group created from the template", func(t *testing.T) {
+		service, templates, rules, _ := createRuleTemplateServiceSut()
+		_, err := service.CreateRuleTemplate(ctx, orgID, newTemplate(), models.ProvenanceAPI)
+		require.NoError(t, err)
+		_, err = service.SetRuleGroupTemplate(ctx, u, instance, models.ProvenanceAPI)
+		require.NoError(t, err)
+		other := instance
+		other.RuleGroup = "cart"
+		other.Values = map[string]string{"service": "cart"}
+		_, err = service.SetRuleGroupTemplate(ctx, u, other, models.ProvenanceFile)
+		require.NoError(t, err)
+
+		tmpl := newTemplate()
+		tmpl.Rules[0].Title = "${service} p99 latency"
+		errorRate := tmpl.Rules[0]
+		errorRate.UID = "errors"
+		errorRate.Title = "${service} errors"
+		tmpl.Rules = append(tmpl.Rules, errorRate)
+		rules.calls = nil
+		updated, err := service.UpdateRuleTemplate(ctx, u, tmpl, models.ProvenanceAPI)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, updated.Version)
+
+		for _, key := range []models.AlertRuleGroupKey{instance.GetGroupKey(), other.GetGroupKey()} {
+			group := rules.groups[key]
+			require.Len(t, group, 2)
+			assert.Equal(t, key.RuleGroup+" p99 latency", group[0].Title)
+			assert.Equal(t, key.RuleGroup+" errors", group[1].Title)
+			saved, err := templates.GetRuleTemplateInstance(ctx, key)
+			require.NoError(t, err)
+			assert.EqualValues(t, 2, saved.TemplateVersion)
+		}
+		// The rule groups keep their provenance.
+		assert.Equal(t, models.ProvenanceAPI, rules.provenances[instance.RuleGroup])
+		assert.Equal(t, models.ProvenanceFile, rules.provenances[other.RuleGroup])
+
+		versions, err := service.GetRuleTemplateVersions(ctx, orgID, tmpl.UID)
+		require.NoError(t, err)
+		require.Len(t, versions, 2)
+		require.EqualValues(t, 2, versions[0].Version)
+	})
+
+	t.Run("update should not recreate rule groups that were deleted outside of the template api", func(t *testing.T) {
+		service, templates, rules, _ := createRuleTemplateServiceSut()
+		_, err := service.CreateRuleTemplate(ctx, orgID, newTemplate(), models.ProvenanceAPI)
+		require.NoError(t, err)
+		_, err = service.SetRuleGroupTemplate(ctx, u, instance, models.ProvenanceAPI)
+		require.NoError(t, err)
+		other := instance
+		other.RuleGroup = "cart"
+		other.Values = map[string]string{"service": "cart"}
+		_, err = service.SetRuleGroupTemplate(ctx, u, other, models.ProvenanceAPI)
+		require.NoError(t, err)
+
+		// The group is deleted through the ruler API, which does not know about templates.
+		delete(rules.groups, instance.GetGroupKey())
+
+		_, err = service.UpdateRuleTemplate(ctx, u, newTemplate(), models.ProvenanceAPI)
+		require.NoError(t, err)
+
+		assert.Empty(t, rules.groups[instance.GetGroupKey()])
+		_, err = templates.GetRuleTemplateInstance(ctx, instance.GetGroupKey())
+		require.ErrorIs(t, err, models.ErrRuleTemplateInstanceNotFound)
+		saved, err := templates.GetRuleTemplateInstance(ctx, other.GetGroupKey())
+		require.NoError(t, err)
+		assert.EqualValues(t, 2, saved.TemplateVersion)
+	})
+
+	t.Run("delete should ignore rule groups that were deleted outside of the template api", func(t *testing.T) {
+		service, _, rules, _ := createRuleTemplateServiceSut()
+		_, err := service.CreateRuleTemplate(ctx, orgID, newTemplate(), models.ProvenanceAPI)
+		require.NoError(t, err)
+		_, err = service.SetRuleGroupTemplate(ctx, u, instance, models.ProvenanceAPI)
+		require.NoError(t, err)
+
+		delete(rules.groups, instance.GetGroupKey())
+
+		require.NoError(t, service.DeleteRuleTemplate(ctx, orgID, "service", models.ProvenanceAPI))
+	})
+
+	t.Run("update should fail if the version is not the current one", func(t *testing.T) {
+		service, _, _, _ := createRuleTemplateServiceSut()
+		_, err := service.CreateRuleTemplate(ctx, orgID, newTemplate(), models.ProvenanceAPI)
+		require.NoError(t, err)
+		tmpl := newTemplate()
+		tmpl.Version = 5
+		_, err = service.UpdateRuleTemplate(ctx, u, tmpl, models.ProvenanceAPI)
+		require.ErrorIs(t, err, store.ErrOptimisticLock)
+	})
+
+	t.Run("update should not change provenance to none", func(t *testing.T) {
+		service, _, _, _ := createRuleTemplateServiceSut()
+		_, err := service.CreateRuleTemplate(ctx, orgID, newTemplate(), models.ProvenanceFile)
+		require.NoError(t, err)
+		_, err = service.UpdateRuleTemplate(ctx, u, newTemplate(), models.ProvenanceNone)
+		require.ErrorIs(t, err, validation.ErrProvenanceChangeNotAllowed)
+	})
+
+	t.Run("delete should fail if rule groups are created from the template", func(t *testing.T) {
+		service, _, rules, _ := createRuleTemplateServiceSut()
+		_, err := service.CreateRuleTemplate(ctx, orgID, newTemplate(), models.ProvenanceAPI)
+		require.NoError(t, err)
+		_, err = service.SetRuleGroupTemplate(ctx, u, instance, models.ProvenanceAPI)
+		require.NoError(t, err)
+
+		err = service.DeleteRuleTemplate(ctx, orgID, "service", models.ProvenanceAPI)
+		require.ErrorIs(t, err, models.ErrRuleTemplateInUse)
+
+		key := instance.GetGroupKey()
+		require.NoError(t, service.DeleteRuleGroupTemplate(ctx, u, key, models.ProvenanceAPI))
+		require.Empty(t, rules.groups[key])
+		_, err = service.GetRuleGroupTemplate(ctx, key)
+		require.ErrorIs(t, err, models.ErrRuleTemplateInstanceNotFound)
+
+		require.NoError(t, service.DeleteRuleTemplate(ctx, orgID, "service", models.ProvenanceAPI))
+		_, _, err = service.GetRuleTemplate(ctx, orgID, "service")
+		require.ErrorIs(t, err, models.ErrRuleTemplateNotFound)
+	})
+}
+
+func createRuleTemplateServiceSut() (*RuleTemplateService, *fakeRuleTemplateStore, *fakeRuleGroupService, *fakes.FakeProvisioningStore) {
+	templates := &fakeRuleTemplateStore{
+		templates: map[string][]models.RuleTemplate{},
+		instances: map[models.AlertRuleGroupKey]models.RuleTemplateInstance{},
+	}
+	rules := &fakeRuleGroupService{
+		groups:      map[models.AlertRuleGroupKey][]models.AlertRule{},
+		provenances: map[string]models.Provenance{},
+	}
+	templates.groupExists = func(key models.AlertRuleGroupKey) bool {
+		return len(rules.groups[key]) > 0
+	}
+	prov := fakes.NewFakeProvisioningStore()
+	return NewRuleTemplateService(templates, prov, newNopTransactionManager(), rules, log.NewNopLogger()), templates, rules, prov
+}
+
+// fakeRuleTemplateStore keeps all versions of the templates, the latest last.
+type fakeRuleTemplateStore struct {
+	templates   map[string][]models.RuleTemplate
+	instances   map[models.AlertRuleGroupKey]models.RuleTemplateInstance
+	groupExists func(key models.AlertRuleGroupKey) bool
+}
+
+func (f *fakeRuleTemplateStore) GetRuleTemplate(_ context.Context, _ int64, uid string) (*models.RuleTemplate, error) {
+	versions := f.templates[uid]
+	if len(versions) == 0 {
+		return nil, models.ErrRuleTemplateNotFound.Errorf("")
+	}
+	t := versions[len(versions)-1]
+	return &t, nil
+}
+
+func (f *fakeRuleTemplateStore) ListRuleTemplates(ctx context.Context, orgID int64) ([]*models.RuleTemplate, error) {
+	var result []*models.RuleTemplate
+	for uid := range f.templates {
+		t, _ := f.GetRuleTemplate(ctx, orgID, uid)
+		result = append(result, t)
+	}
+	return result, nil
+}
+
+func (f *fakeRuleTemplateStore) InsertRuleTemplate(_ context.Context, t models.RuleTemplate) (*models.RuleTemplate, error) {
+	if len(f.templates[t.UID]) > 0 {
+		return nil, models.ErrRuleTemplateExists.Errorf("")
+	}
+	t.Version = 1
+	f.templates[t.UID] = []models.RuleTemplate{t}
+	return &t, nil
+}
+
+func (f *fakeRuleTemplateStore) UpdateRuleTemplate(ctx context.Context, t models.RuleTemplate) (*models.RuleTemplate, error) {
+	current, err := f.GetRuleTemplate(ctx, t.OrgID, t.UID)
+	if err != nil {
+		return nil, err
+	}
+	if current.Version != t.Version {
+		return nil, store.ErrOptimisticLock
+	}
+	t.Version++
+	f.templates[t.UID] = append(f.templates[t.UID], t)
+	return &t, nil
+}
+
+func (f *fakeRuleTemplateStore) DeleteRuleTemplate(_ context.Context, _ int64, uid string) error {
+	delete(f.templates, uid)
+	return nil
+}
+
+func (f *fakeRuleTemplateStore) GetRuleTemplateVersions(_ context.Context, _ int64, uid string) ([]*models.RuleTemplate, error) {
+	versions := f.templates[uid]
+	result := make([]*models.RuleTemplate, 0, len(versions))
+	for i := len(versions) - 1; i >= 0; i-- {
+		result = append(result, &versions[i])
+	}
+	return result, nil
+}
+
+func (f *fakeRuleTemplateStore) GetRuleTemplateInstance(_ context.Context, key models.AlertRuleGroupKey) (*models.RuleTemplateInstance, error) {
+	i, ok := f.instances[key]
+	if !ok {
+		return nil, models.ErrRuleTemplateInstanceNotFound.Errorf("")
+	}
+	return &i, nil
+}
+
+func (f *fakeRuleTemplateStore) ListRuleTemplateInstances(_ context.Context, _ int64, templateUID string) ([]*models.RuleTemplateInstance, error) {
+	var result []*models.RuleTemplateInstance
+	for _, i := range f.instances {
+		if i.TemplateUID == templateUID {
+			result = append(result, &i)
+		}
+	}
+	return result, nil
+}
+
+func (f *fakeRuleTemplateStore) UpsertRuleTemplateInstance(_ context.Context, instance models.RuleTemplateInstance) error {
+	f.instances[instance.GetGroupKey()] = instance
+	return nil
+}
+
+func (f *fakeRuleTemplateStore) DeleteRuleTemplateInstance(_ context.Context, key models.AlertRuleGroupKey) error {
+	delete(f.instances, key)
+	return nil
+}
+
+func (f *fakeRuleTemplateStore) DeleteOrphanedRuleTemplateInstances(_ context.Context, orgID int64) (int64, error) {
+	var deleted int64
+	for key := range f.instances {
+		if key.OrgID == orgID && !f.groupExists(key) {
+			delete(f.instances, key)
+			deleted++
+		}
+	}
+	return deleted, nil
+}
+
+// fakeRuleGroupService stores rule groups and the provenance of each group by its name.
+type fakeRuleGroupService struct {
+	groups      map[models.AlertRuleGroupKey][]models.AlertRule
+	provenances map[string]models.Provenance
+	calls       []string
+}
+
+func (f *fakeRuleGroupService) GetAlertRule(_ context.Context, _ identity.Requester, ruleUID string) (models.AlertRule, models.Provenance, error) {
+	for _, rules := range f.groups {
+		for _, r := range rules {
+			if r.UID == ruleUID {
+				return r, f.provenances[r.RuleGroup], nil
+			}
+		}
+	}
+	return models.AlertRule{}, models.ProvenanceNone, models.ErrAlertRuleNotFound
+}
+
+func (f *fakeRuleGroupService) CreateAlertRule(_ context.Context, _ identity.Requester, rule models.AlertRule, provenance models.Provenance) (models.AlertRule, error) {
+	f.calls = append(f.calls, "CreateAlertRule")
+	f.groups[rule.GetGroupKey()] = append(f.groups[rule.GetGroupKey()], rule)
+	f.provenances[rule.RuleGroup] = provenance
+	return rule, nil
+}
+
+func (f *fakeRuleGroupService) ReplaceRuleGroup(_ context.Context, user identity.Requester, group models.AlertRuleGroup, provenance models.Provenance) error {
+	f.calls = append(f.calls, "ReplaceRuleGroup")
+	key := models.AlertRuleGroupKey{OrgID: user.GetOrgID(), NamespaceUID: group.FolderUID, RuleGroup: group.Title}
+	f.groups[key] = group.Rules
+	f.provenances[group.Title] = provenance
+	return nil
+}
+
+func (f *fakeRuleGroupService) DeleteRuleGroup(_ context.Context, user identity.Requester, namespaceUID, group string, _ models.Provenance) error {
+	f.calls = append(f.calls, "DeleteRuleGroup")
+	delete(f.groups, models.AlertRuleGroupKey{OrgID: user.GetOrgID(), NamespaceUID: namespaceUID, RuleGroup: group})
+	return nil
+}
@@ -0,0 +1,192 @@
+package provisioning
+
+import (
+	"context"
+	"fmt"
+
+	alertingNotify "github.com/grafana/alerting/notify"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// TemplateFixtureStore represents the ability to persist and query the fixtures of notification templates.
+type TemplateFixtureStore interface {
+	GetTemplateFixture(ctx context.Context, orgID int64, name string) (*models.TemplateFixture, error)
+	ListTemplateFixtures(ctx context.Context, orgID int64) ([]*models.TemplateFixture, error)
+	SaveTemplateFixture(ctx context.Context, f models.TemplateFixture) (*models.TemplateFixture, error)
+	DeleteTemplateFixture(ctx context.Context, orgID int64, name string) error
+}
+
+// TemplateRenderer renders notification templates with the Alertmanager of the organization. It is implemented by
+// MultiOrgAlertmanager.
+type TemplateRenderer interface {
+	TestTemplate(ctx context.Context, orgID int64, c definitions.TestTemplatesConfigBodyParams) (*alertingNotify.TestTemplatesResults, error)
+}
+
+// TemplateFixtureService manages the fixtures of notification templates and renders the templates with them to check
+// that the templates produce the expected outputs.
+type TemplateFixtureService struct {
+	store       TemplateFixtureStore
+	configStore alertmanagerConfigStore
+	renderer    TemplateRenderer
+	log         log.Logger
+}
+
+func NewTemplateFixtureService(store TemplateFixtureStore, config alertmanagerConfigStore, renderer TemplateRenderer, log log.Logger) *TemplateFixtureService {
+	return &TemplateFixtureService{
+		store:       store,
+		configStore: config,
+		renderer:    renderer,
+		log:         log,
+	}
+}
+
+func (s *TemplateFixtureService) GetTemplateFixtures(ctx context.Context, orgID int64) ([]models.TemplateFixture, error) {
+	fixtures, err := s.store.ListTemplateFixtures(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	result := make([]models.TemplateFixture, 0, len(fixtures))
+	for _, f := range fixtures {
+		result = append(result, *f)
+	}
+	return result, nil
+}
+
+func (s *TemplateFixtureService) GetTemplateFixture(ctx context.Context, orgID int64, name string) (models.TemplateFixture, error) {
+	f, err := s.store.GetTemplateFixture(ctx, orgID, name)
+	if err != nil {
+		return models.TemplateFixture{}, err
+	}
+	return *f, nil
+}
+
+// SaveTemplateFixture creates the template fixture, or replaces the fixture with the same name.
+func (s *TemplateFixtureService) SaveTemplateFixture(ctx context.Context, orgID int64, f models.TemplateFixture) (models.TemplateFixture, error) {
+	if err := f.Validate(); err != nil {
+		return models.TemplateFixture{}, models.ErrTemplateFixtureInvalid(err)
+	}
+	f.OrgID = orgID
+	saved, err := s.store.SaveTemplateFixture(ctx, f)
+	if err != nil {
+		return models.TemplateFixture{}, err
+	}
+	return *saved, nil
+}
+
+func (s *TemplateFixtureService) DeleteTemplateFixture(ctx context.Context, orgID int64, name string) error {
+	return s.store.DeleteTemplateFixture(ctx, orgID, name)
+}
+
+// TestTemplateFixtures renders the current templates of the organization with the alerts of every fixture and compares
+// the outputs with the expected ones.
+func (s *TemplateFixtureService) TestTemplateFixtures(ctx context.Context, orgID int64) ([]models.TemplateFixtureResult, error) {
+	revision, err := s.configStore.Get(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+	fixtures, err := s.store.ListTemplateFixtures(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []models.TemplateFixtureResult
+	for _, f := range fixtures {
+		r, err := s.testFixture(ctx, orgID, *f, revision.Config.TemplateFiles)
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, r...)
+	}
+	return results, nil
+}
+
+// TestTemplate renders the given content of the template with the alerts of every fixture that has expected outputs
+// for the template, and compares the outputs with the expected ones.
+func (s *TemplateFixtureService) TestTemplate(ctx context.Context, orgID int64, name, content string) ([]models.TemplateFixtureResult, error) {
+	fixtures, err := s.store.ListTemplateFixtures(ctx, orgID)
+	if err != nil {
+		return nil, err
+	}
+
+	var results []models.TemplateFixtureResult
+	for _, f := range fixtures {
+		expectations := make([]models.TemplateExpectation, 0, len(f.Expectations))
+		for _, e := range f.Expectations {
+			if e.Template == name {
+				expectations = append(expectations, e)
+			}
+		}
+		if len(expectations) == 0 {
+			continue
+		}
+		f.Expectations = expectations
+		r, err := s.testFixture(ctx, orgID, *f, map[string]string{name: content})
+		if err != nil {
+			return nil, err
+		}
+		results = append(results, r...)
+	}
+	return results, nil
+}
+
+// testFixture renders the templates that the fixture has expected outputs for. The templates are looked up by name
+// in the given template files.
+func (s *TemplateFixtureService) testFixture(ctx context.Context, orgID int64, f models.TemplateFixture, templates map[string]string) ([]models.TemplateFixtureResult, error) {
+	results := make([]models.TemplateFixtureResult, 0, len(f.Expectations))
+	rendered := make(map[string]*alertingNotify.TestTemplatesResults)
+	for _, e := range f.Expectations {
+		result := models.TemplateFixtureResult{
+			Fixture:    f.Name,
+			Template:   e.Template,
+			Definition: e.Definition,
+			Expected:   e.Output,
+		}
+
+		content, ok := templates[e.Template]
+		if !ok {
+			result.Error = fmt.Sprintf("template %s does not exist", e.Template)
+			results = append(results, result)
+			continue
+		}
+
+		res, ok := rendered[e.Template]
+		if !ok {
+			var err error
+			res, err = s.renderer.TestTemplate(ctx, orgID, definitions.TestTemplatesConfigBodyParams{
+				Alerts:   f.Alerts,
+				Template: content,
+				Name:     e.Template,
+			})
+			if err != nil {
+				return nil, fmt.Errorf("failed to render template %s with fixture %s: %w", e.Template, f.Name, err)
+			}
+			rendered[e.Template] = res
+		}
+
+		result.Actual, result.Error = templateDefinitionOutput(res, e.Definition)
+		if result.Error == "" {
+			result.Diff = models.DiffLines(result.Expected, result.Actual)
+		}
+		results = append(results, result)
+	}
+	return results, nil
+}
+
+// templateDefinitionOutput returns the output of the template definition, or the error that prevented it from being
+// rendered.
+func templateDefinitionOutput(res *alertingNotify.TestTemplatesResults, definition string) (string, string) {
+	for _, e := range res.Errors {
+		if e.Kind == alertingNotify.InvalidTemplate || e.Name == definition {
+			return "", e.Error
+		}
+	}
+	for _, r := range res.Results {
+		if r.Name == definition {
+			return r.Text, ""
+		}
+	}
+	return "", fmt.Sprintf("template does not define %s", definition)
+}
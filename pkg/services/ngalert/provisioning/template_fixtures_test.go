@@ -0,0 +1,148 @@
+package provisioning
+
+import (
+	"context"
+	"testing"
+
+	alertingNotify "github.com/grafana/alerting/notify"
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/notifier/legacy_storage"
+)
+
+type fakeTemplateTester struct {
+	rendered map[string][]string
+	results  []models.TemplateFixtureResult
+}
+
+func (f *fakeTemplateTester) TestTemplate(_ context.Context, _ int64, name, content string) ([]models.TemplateFixtureResult, error) {
+	if f.rendered == nil {
+		f.rendered = map[string][]string{}
+	}
+	f.rendered[name] = append(f.rendered[name], content)
+	return f.results, nil
+}
+
+type fakeTemplateFixtureStore struct {
+	fixtures []*models.TemplateFixture
+}
+
+func (f *fakeTemplateFixtureStore) GetTemplateFixture(_ context.Context, _ int64, name string) (*models.TemplateFixture, error) {
+	for _, fixture := range f.fixtures {
+		if fixture.Name == name {
+			return fixture, nil
+		}
+	}
+	return nil, models.ErrTemplateFixtureNotFound.Errorf("")
+}
+
+func (f *fakeTemplateFixtureStore) ListTemplateFixtures(_ context.Context, _ int64) ([]*models.TemplateFixture, error) {
+	return f.fixtures, nil
+}
+
+func (f *fakeTemplateFixtureStore) SaveTemplateFixture(_ context.Context, fixture models.TemplateFixture) (*models.TemplateFixture, error) {
+	f.fixtures = append(f.fixtures, &fixture)
+	return &fixture, nil
+}
+
+func (f *fakeTemplateFixtureStore) DeleteTemplateFixture(_ context.Context, _ int64, _ string) error {
+	return nil
+}
+
+// fakeTemplateRenderer returns the results configured for the content of the template.
+type fakeTemplateRenderer struct {
+	outputs map[string]alertingNotify.TestTemplatesResults
+	calls   []definitions.TestTemplatesConfigBodyParams
+}
+
+func (f *fakeTemplateRenderer) TestTemplate(_ context.Context, _ int64, c definitions.TestTemplatesConfigBodyParams) (*alertingNotify.TestTemplatesResults, error) {
+	f.calls = append(f.calls, c)
+	res := f.outputs[c.Template]
+	return &res, nil
+}
+
+func TestTemplateFixtureService(t *testing.T) {
+	orgID := int64(1)
+	alerts := []*amv2.PostableAlert{{Alert: amv2.Alert{Labels: amv2.LabelSet{"alertname": "Test"}}}}
+	renderer := &fakeTemplateRenderer{outputs: map[string]alertingNotify.TestTemplatesResults{
+		"slack content": {Results: []alertingNotify.TestTemplatesResult{
+			{Name: "slack.title", Text: "Test"},
+			{Name: "slack.text", Text: "line 1\nline 2"},
+		}},
+		"broken content": {Errors: []alertingNotify.TestTemplatesErrorResult{
+			{Kind: alertingNotify.InvalidTemplate, Error: "unexpected EOF"},
+		}},
+		"new slack content": {Results: []alertingNotify.TestTemplatesResult{
+			{Name: "slack.title", Text: "New"},
+		}},
+	}}
+	config := &legacy_storage.AlertmanagerConfigStoreFake{
+		GetFn: func(ctx context.Context, orgID int64) (*legacy_storage.ConfigRevision, error) {
+			return &legacy_storage.ConfigRevision{Config: &definitions.PostableUserConfig{
+				TemplateFiles: map[string]string{
+					"slack":  "slack content",
+					"broken": "broken content",
+				},
+			}}, nil
+		},
+	}
+	store := &fakeTemplateFixtureStore{fixtures: []*models.TemplateFixture{{
+		OrgID:  orgID,
+		Name:   "firing",
+		Alerts: alerts,
+		Expectations: []models.TemplateExpectation{
+			{Template: "slack", Definition: "slack.title", Output: "Test"},
+			{Template: "slack", Definition: "slack.text", Output: "line 1\nline 3"},
+			{Template: "slack", Definition: "slack.missing", Output: ""},
+			{Template: "broken", Definition: "broken.title", Output: ""},
+			{Template: "deleted", Definition: "deleted.title", Output: ""},
+		},
+	}}}
+	sut := NewTemplateFixtureService(store, config, renderer, log.NewNopLogger())
+
+	t.Run("TestTemplateFixtures compares the outputs of the current templates", func(t *testing.T) {
+		renderer.calls = nil
+		results, err := sut.TestTemplateFixtures(context.Background(), orgID)
+		require.NoError(t, err)
+		require.Len(t, results, 5)
+
+		assert.True(t, results[0].Passed())
+		assert.False(t, results[1].Passed())
+		assert.Equal(t, " line 1\n-line 3\n+line 2\n", results[1].Diff)
+		assert.Equal(t, "template does not define slack.missing", results[2].Error)
+		assert.Equal(t, "unexpected EOF", results[3].Error)
+		assert.Equal(t, "template deleted does not exist", results[4].Error)
+
+		// Each template is rendered once per fixture.
+		require.Len(t, renderer.calls, 2)
+		assert.Equal(t, "slack", renderer.calls[0].Name)
+		assert.Equal(t, alerts, renderer.calls[0].Alerts)
+	})
+
+	t.Run("TestTemplate only renders the given content of the template", func(t *testing.T) {
+		renderer.calls = nil
+		results, err := sut.TestTemplate(context.Background(), orgID, "slack", "new slack content")
+		require.NoError(t, err)
+		require.Len(t, results, 3)
+		for _, r := range results {
+			assert.Equal(t, "slack", r.Template)
+		}
+		assert.Equal(t, "-Test\n+New\n", results[0].Diff)
+		require.Len(t, renderer.calls, 1)
+		assert.Equal(t, "new slack content", renderer.calls[0].Template)
+	})
+
+	t.Run("SaveTemplateFixture validates the fixture", func(t *testing.T) {
+		_, err := sut.SaveTemplateFixture(context.Background(), orgID, models.TemplateFixture{Name: "empty"})
+		require.ErrorIs(t, err, models.ErrTemplateFixtureInvalidBase)
+
+		saved, err := sut.SaveTemplateFixture(context.Background(), orgID, models.TemplateFixture{Name: "resolved", Alerts: alerts})
+		require.NoError(t, err)
+		assert.Equal(t, orgID, saved.OrgID)
+	})
+}
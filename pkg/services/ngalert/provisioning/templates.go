@@ -17,21 +17,30 @@ import (
 	"github.com/grafana/grafana/pkg/services/ngalert/provisioning/validation"
 )
 
+// templateTester renders the fixtures of a template with its new content. It is implemented by TemplateFixtureService.
+type templateTester interface {
+	TestTemplate(ctx context.Context, orgID int64, name, content string) ([]models.TemplateFixtureResult, error)
+}
+
 type TemplateService struct {
 	configStore     alertmanagerConfigStore
 	provenanceStore ProvisioningStore
 	xact            TransactionManager
 	log             log.Logger
 	validator       validation.ProvenanceStatusTransitionValidator
+	fixtures        templateTester
 }
 
-func NewTemplateService(config alertmanagerConfigStore, prov ProvisioningStore, xact TransactionManager, log log.Logger) *TemplateService {
+// NewTemplateService creates a TemplateService. If fixtures is not nil, the fixtures of a template are rendered before
+// the template is created or updated, and the change is rejected if any of them fails.
+func NewTemplateService(config alertmanagerConfigStore, prov ProvisioningStore, xact TransactionManager, log log.Logger, fixtures templateTester) *TemplateService {
 	return &TemplateService{
 		configStore:     config,
 		provenanceStore: prov,
 		xact:            xact,
 		validator:       validation.ValidateProvenanceRelaxed,
 		log:             log,
+		fixtures:        fixtures,
 	}
 }
 
@@ -152,6 +161,10 @@ func (t *TemplateService) createTemplate(ctx context.Context, revision *legacy_s
 		return definitions.NotificationTemplate{}, ErrTemplateExists.Errorf("")
 	}
 
+	if err := t.testFixtures(ctx, orgID, tmpl); err != nil {
+		return definitions.NotificationTemplate{}, err
+	}
+
 	revision.Config.TemplateFiles[tmpl.Name] = tmpl.Template
 
 	err := t.xact.InTransaction(ctx, func(ctx context.Context) error {
@@ -227,6 +240,10 @@ func (t *TemplateService) updateTemplate(ctx context.Context, revision *legacy_s
 		return definitions.NotificationTemplate{}, err
 	}
 
+	if err := t.testFixtures(ctx, orgID, tmpl); err != nil {
+		return definitions.NotificationTemplate{}, err
+	}
+
 	revision.Config.TemplateFiles[tmpl.Name] = tmpl.Template
 
 	err = t.xact.InTransaction(ctx, func(ctx context.Context) error {
@@ -317,6 +334,28 @@ func (t *TemplateService) checkOptimisticConcurrency(name, currentContent string
 	return nil
 }
 
+// testFixtures renders the fixtures of the template with its new content. It returns ErrTemplateFixtures with the
+// results of the fixtures that do not render the expected output.
+func (t *TemplateService) testFixtures(ctx context.Context, orgID int64, tmpl definitions.NotificationTemplate) error {
+	if t.fixtures == nil {
+		return nil
+	}
+	results, err := t.fixtures.TestTemplate(ctx, orgID, tmpl.Name, tmpl.Template)
+	if err != nil {
+		return fmt.Errorf("failed to test template with its fixtures: %w", err)
+	}
+	var failed []models.TemplateFixtureResult
+	for _, r := range results {
+		if !r.Passed() {
+			failed = append(failed, r)
+		}
+	}
+	if len(failed) > 0 {
+		return MakeErrTemplateFixtures(failed)
+	}
+	return nil
+}
+
 func calculateTemplateFingerprint(t string) string {
 	sum := fnv.New64()
 	_, _ = sum.Write(unsafe.Slice(unsafe.StringData(t), len(t))) //nolint:gosec
@@ -10,6 +10,7 @@ import (
 	"github.com/stretchr/testify/mock"
 	"github.com/stretchr/testify/require"
 
+	"github.com/grafana/grafana/pkg/apimachinery/errutil"
 	"github.com/grafana/grafana/pkg/infra/log"
 	"github.com/grafana/grafana/pkg/services/ngalert/api/tooling/definitions"
 	"github.com/grafana/grafana/pkg/services/ngalert/models"
@@ -609,6 +610,50 @@ func TestCreateTemplate(t *testing.T) {
 		}), orgID, models.ProvenanceAPI)
 	})
 
+	t.Run("renders the fixtures of the new template", func(t *testing.T) {
+		sut, store, prov := createTemplateServiceSut()
+		tester := &fakeTemplateTester{}
+		sut.fixtures = tester
+		store.GetFn = func(ctx context.Context, org int64) (*legacy_storage.ConfigRevision, error) {
+			return revision(), nil
+		}
+		prov.EXPECT().SetProvenance(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+		_, err := sut.CreateTemplate(context.Background(), orgID, tmpl)
+
+		require.NoError(t, err)
+		require.Equal(t, []string{tmpl.Template}, tester.rendered[tmpl.Name])
+		require.Len(t, store.Calls, 2)
+		require.Equal(t, "Save", store.Calls[1].Method)
+	})
+
+	t.Run("rejects the template if a fixture fails", func(t *testing.T) {
+		sut, store, prov := createTemplateServiceSut()
+		tester := &fakeTemplateTester{results: []models.TemplateFixtureResult{
+			{Fixture: "passing", Template: tmpl.Name, Definition: "test", Expected: "test", Actual: "test"},
+			{Fixture: "failing", Template: tmpl.Name, Definition: "test", Expected: "other", Actual: "test", Diff: "-other\n+test"},
+		}}
+		sut.fixtures = tester
+		store.GetFn = func(ctx context.Context, org int64) (*legacy_storage.ConfigRevision, error) {
+			return revision(), nil
+		}
+
+		_, err := sut.CreateTemplate(context.Background(), orgID, tmpl)
+
+		require.ErrorIs(t, err, ErrTemplateFixtures)
+		require.ErrorContains(t, err, `fixture "failing"`)
+		require.NotContains(t, err.Error(), `fixture "passing"`)
+		var utilErr errutil.Error
+		require.ErrorAs(t, err, &utilErr)
+		results := utilErr.PublicPayload["Results"].([]map[string]any)
+		require.Len(t, results, 1)
+		require.Equal(t, "failing", results[0]["fixture"])
+		require.Equal(t, "-other\n+test", results[0]["diff"])
+
+		require.Len(t, store.Calls, 1)
+		prov.AssertNotCalled(t, "SetProvenance", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+	})
+
 	t.Run("returns ErrTemplateExists if template exists", func(t *testing.T) {
 		sut, store, _ := createTemplateServiceSut()
 		store.GetFn = func(ctx context.Context, org int64) (*legacy_storage.ConfigRevision, error) {
@@ -880,6 +925,48 @@ func TestUpdateTemplate(t *testing.T) {
 		prov.AssertExpectations(t)
 	})
 
+	t.Run("renders the fixtures of the template before saving", func(t *testing.T) {
+		t.Run("saves the template if all fixtures pass", func(t *testing.T) {
+			sut, store, prov := createTemplateServiceSut()
+			sut.fixtures = &fakeTemplateTester{results: []models.TemplateFixtureResult{
+				{Fixture: "passing", Template: tmpl.Name, Definition: "test", Expected: "test", Actual: "test"},
+			}}
+			store.GetFn = func(ctx context.Context, org int64) (*legacy_storage.ConfigRevision, error) {
+				return revision(), nil
+			}
+			prov.EXPECT().GetProvenance(mock.Anything, mock.Anything, mock.Anything).Return(models.ProvenanceAPI, nil)
+			prov.EXPECT().SetProvenance(mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+			tmpl := tmpl
+			tmpl.UID = ""
+			_, err := sut.UpdateTemplate(context.Background(), orgID, tmpl)
+
+			require.NoError(t, err)
+			require.Len(t, store.Calls, 2)
+			require.Equal(t, "Save", store.Calls[1].Method)
+		})
+
+		t.Run("rejects the template if a fixture fails", func(t *testing.T) {
+			sut, store, prov := createTemplateServiceSut()
+			sut.fixtures = &fakeTemplateTester{results: []models.TemplateFixtureResult{
+				{Fixture: "failing", Template: tmpl.Name, Definition: "test", Error: "template: test:1: function \"foo\" not defined"},
+			}}
+			store.GetFn = func(ctx context.Context, org int64) (*legacy_storage.ConfigRevision, error) {
+				return revision(), nil
+			}
+			prov.EXPECT().GetProvenance(mock.Anything, mock.Anything, mock.Anything).Return(models.ProvenanceAPI, nil)
+
+			tmpl := tmpl
+			tmpl.UID = ""
+			_, err := sut.UpdateTemplate(context.Background(), orgID, tmpl)
+
+			require.ErrorIs(t, err, ErrTemplateFixtures)
+			require.ErrorContains(t, err, `function "foo" not defined`)
+			require.Len(t, store.Calls, 1)
+			prov.AssertNotCalled(t, "SetProvenance", mock.Anything, mock.Anything, mock.Anything, mock.Anything)
+		})
+	})
+
 	t.Run("rejects rename operation if template with the new name exists", func(t *testing.T) {
 		sut, store, prov := createTemplateServiceSut()
 		store.GetFn = func(ctx context.Context, org int64) (*legacy_storage.ConfigRevision, error) {
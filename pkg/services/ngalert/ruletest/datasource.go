@@ -0,0 +1,145 @@
+package ruletest
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/prometheus/model/value"
+	"github.com/prometheus/prometheus/promql/parser"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/services/datasources"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// series is an input series with its samples. Omitted and stale samples are not stored.
+type series struct {
+	ruleUID string
+	labels  data.Labels
+	times   []time.Time
+	values  []float64
+}
+
+// queryHandler answers the queries of the rules with the input series of a test group.
+type queryHandler struct {
+	// series are the input series per query ref ID.
+	series map[string][]series
+}
+
+func newQueryHandler(start time.Time, interval time.Duration, input []InputSeries) (*queryHandler, error) {
+	h := &queryHandler{series: make(map[string][]series)}
+	for _, in := range input {
+		lbls, values, err := parser.ParseSeriesDesc(in.Series + " " + in.Values)
+		if err != nil {
+			return nil, fmt.Errorf("failed to parse input series %s: %w", in.Series, err)
+		}
+		s := series{
+			ruleUID: in.RuleUID,
+			labels:  lbls.Map(),
+		}
+		for i, v := range values {
+			if v.Omitted || value.IsStaleNaN(v.Value) {
+				continue
+			}
+			s.times = append(s.times, start.Add(time.Duration(i)*interval))
+			s.values = append(s.values, v.Value)
+		}
+		h.series[in.RefID] = append(h.series[in.RefID], s)
+	}
+	return h, nil
+}
+
+// QueryData returns the samples of the input series in the time range of the queries. Queries with
+// the "instant" option, like instant queries of Prometheus, return the last sample in the time range.
+func (h *queryHandler) QueryData(ctx context.Context, req *backend.QueryDataRequest) (*backend.QueryDataResponse, error) {
+	key, _ := models.RuleKeyFromContext(ctx)
+	resp := backend.NewQueryDataResponse()
+	for _, q := range req.Queries {
+		var model struct {
+			Instant bool `json:"instant"`
+			Range   bool `json:"range"`
+		}
+		if len(q.JSON) > 0 {
+			if err := json.Unmarshal(q.JSON, &model); err != nil {
+				resp.Responses[q.RefID] = backend.ErrDataResponse(backend.StatusBadRequest, fmt.Sprintf("failed to parse query model: %s", err))
+				continue
+			}
+		}
+		instant := model.Instant && !model.Range
+
+		var frames data.Frames
+		for _, s := range h.series[q.RefID] {
+			if s.ruleUID != "" && s.ruleUID != key.UID {
+				continue
+			}
+			var frame *data.Frame
+			if instant {
+				frame = s.instantFrame(q.RefID, q.TimeRange)
+			} else {
+				frame = s.rangeFrame(q.RefID, q.TimeRange)
+			}
+			if frame != nil {
+				frames = append(frames, frame)
+			}
+		}
+		resp.Responses[q.RefID] = backend.DataResponse{Frames: frames}
+	}
+	return resp, nil
+}
+
+// rangeFrame returns the samples in the time range as a dataplane time series.
+func (s series) rangeFrame(refID string, tr backend.TimeRange) *data.Frame {
+	var times []time.Time
+	var values []float64
+	for i, t := range s.times {
+		if t.Before(tr.From) || t.After(tr.To) {
+			continue
+		}
+		times = append(times, t)
+		values = append(values, s.values[i])
+	}
+	if len(times) == 0 {
+		return nil
+	}
+	frame := data.NewFrame("",
+		data.NewField(data.TimeSeriesTimeFieldName, nil, times),
+		data.NewField(data.TimeSeriesValueFieldName, s.labels.Copy(), values),
+	)
+	frame.RefID = refID
+	frame.Meta = &data.FrameMeta{Type: data.FrameTypeTimeSeriesMulti, TypeVersion: data.FrameTypeVersion{0, 1}}
+	return frame
+}
+
+// instantFrame returns the last sample in the time range as a dataplane number.
+func (s series) instantFrame(refID string, tr backend.TimeRange) *data.Frame {
+	for i := len(s.times) - 1; i >= 0; i-- {
+		t := s.times[i]
+		if t.After(tr.To) {
+			continue
+		}
+		if t.Before(tr.From) {
+			return nil
+		}
+		frame := data.NewFrame("", data.NewField(data.TimeSeriesValueFieldName, s.labels.Copy(), []float64{s.values[i]}))
+		frame.RefID = refID
+		frame.Meta = &data.FrameMeta{Type: data.FrameTypeNumericMulti, TypeVersion: data.FrameTypeVersion{0, 1}}
+		return frame
+	}
+	return nil
+}
+
+// datasourceCache resolves every data source UID, as the data sources of the rules are not needed
+// to answer their queries.
+type datasourceCache struct{}
+
+func (datasourceCache) GetDatasource(_ context.Context, id int64, _ identity.Requester, _ bool) (*datasources.DataSource, error) {
+	return &datasources.DataSource{ID: id}, nil
+}
+
+func (datasourceCache) GetDatasourceByUID(_ context.Context, uid string, _ identity.Requester, _ bool) (*datasources.DataSource, error) {
+	return &datasources.DataSource{UID: uid, Name: uid}, nil
+}
@@ -0,0 +1,345 @@
+package ruletest
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/common/model"
+	"gopkg.in/yaml.v3"
+
+	"github.com/grafana/grafana/pkg/expr"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+	"github.com/grafana/grafana/pkg/services/featuremgmt"
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/schedule"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+	"github.com/grafana/grafana/pkg/services/provisioning/alerting"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+const (
+	defaultInterval   = time.Minute
+	evaluationTimeout = 30 * time.Second
+)
+
+// Runner runs unit tests of Grafana-managed alert rules. The rules are evaluated by the same evaluator
+// and state manager as in Grafana, but the queries to data sources are answered with the input series
+// of the tests, and the time is simulated. Tests start at the Unix epoch.
+type Runner struct {
+	log    log.Logger
+	tracer tracing.Tracer
+}
+
+func NewRunner(logger log.Logger, tracer tracing.Tracer) *Runner {
+	return &Runner{
+		log:    logger,
+		tracer: tracer,
+	}
+}
+
+// RunFiles runs the tests of the given test files and writes the outcome to out.
+// It returns false if any of the tests failed or could not be run.
+func (r *Runner) RunFiles(ctx context.Context, out io.Writer, files ...string) bool {
+	passed := true
+	for _, file := range files {
+		_, _ = fmt.Fprintln(out, "Unit Testing: ", file)
+		errs := r.runFile(ctx, file)
+		if len(errs) == 0 {
+			_, _ = fmt.Fprintln(out, "  SUCCESS")
+			_, _ = fmt.Fprintln(out)
+			continue
+		}
+		passed = false
+		_, _ = fmt.Fprintln(out, "  FAILED:")
+		for _, err := range errs {
+			_, _ = fmt.Fprintln(out, indent(err.Error(), "    "))
+			_, _ = fmt.Fprintln(out)
+		}
+	}
+	return passed
+}
+
+func (r *Runner) runFile(ctx context.Context, file string) []error {
+	tf, err := LoadTestFile(file)
+	if err != nil {
+		return []error{err}
+	}
+	groups, err := loadRuleFiles(tf.RuleFiles)
+	if err != nil {
+		return []error{err}
+	}
+	var errs []error
+	for i, tg := range tf.Tests {
+		name := tg.Name
+		if name == "" {
+			name = fmt.Sprintf("#%d", i)
+		}
+		for _, err := range r.runGroup(ctx, groups, tg) {
+			errs = append(errs, fmt.Errorf("name: %s,\n%w", name, err))
+		}
+	}
+	return errs
+}
+
+// loadRuleFiles reads the rule groups of files in the file provisioning format.
+func loadRuleFiles(files []string) ([]models.AlertRuleGroupWithFolderFullpath, error) {
+	var groups []models.AlertRuleGroupWithFolderFullpath
+	for _, file := range files {
+		b, err := os.ReadFile(filepath.Clean(file))
+		if err != nil {
+			return nil, err
+		}
+		var fileV1 alerting.AlertingFileV1
+		if err := yaml.Unmarshal(b, &fileV1); err != nil {
+			return nil, fmt.Errorf("failed to parse rule file %s: %w", file, err)
+		}
+		for _, groupV1 := range fileV1.Groups {
+			group, err := groupV1.MapToModel()
+			if err != nil {
+				return nil, fmt.Errorf("failed to parse rule file %s: %w", file, err)
+			}
+			for i := range group.Rules {
+				rule := &group.Rules[i]
+				rule.OrgID = group.OrgID
+				rule.RuleGroup = group.Title
+				rule.IntervalSeconds = group.Interval
+				// Folders are not looked up, the path of the folder identifies it.
+				rule.NamespaceUID = group.FolderFullpath
+			}
+			groups = append(groups, group)
+		}
+	}
+	return groups, nil
+}
+
+// ruleUnderTest is a rule that is evaluated during a test group.
+type ruleUnderTest struct {
+	rule        *models.AlertRule
+	extraLabels data.Labels
+	interval    time.Duration
+}
+
+type evaluation struct {
+	at   time.Duration
+	rule *ruleUnderTest
+}
+
+// runGroup evaluates the rules over the input series of the test group and checks the alerts at the times of the tests.
+func (r *Runner) runGroup(ctx context.Context, groups []models.AlertRuleGroupWithFolderFullpath, tg TestGroup) []error {
+	interval := time.Duration(tg.Interval)
+	if interval <= 0 {
+		interval = defaultInterval
+	}
+	start := time.Unix(0, 0).UTC()
+
+	handler, err := newQueryHandler(start, interval, tg.InputSeries)
+	if err != nil {
+		return []error{err}
+	}
+	exprService := expr.NewOfflineService(&setting.Cfg{ExpressionsEnabled: true}, handler, featuremgmt.WithFeatures(), r.tracer)
+	evalFactory := eval.NewEvaluatorFactory(setting.UnifiedAlertingSettings{EvaluationTimeout: evaluationTimeout}, datasourceCache{}, exprService)
+	clk := clock.NewMock()
+	clk.Set(start)
+	manager := state.NewManager(state.ManagerCfg{
+		Images: &state.NoopImageService{},
+		Clock:  clk,
+		Tracer: r.tracer,
+		Log:    r.log,
+	}, state.NewNoopPersister())
+
+	tests := make([]AlertTestCase, len(tg.AlertTests))
+	copy(tests, tg.AlertTests)
+	sort.SliceStable(tests, func(i, j int) bool {
+		return tests[i].EvalTime < tests[j].EvalTime
+	})
+	var maxEvalTime time.Duration
+	if len(tests) > 0 {
+		maxEvalTime = time.Duration(tests[len(tests)-1].EvalTime)
+	}
+
+	// The rules of all groups are evaluated at multiples of the interval of their group, in the order of the rule files.
+	var rules []*ruleUnderTest
+	var evaluations []evaluation
+	for _, group := range groups {
+		for i := range group.Rules {
+			rule := &group.Rules[i]
+			if rule.IsPaused || rule.Type() == models.RuleTypeRecording {
+				continue
+			}
+			rut := &ruleUnderTest{
+				rule:        rule,
+				extraLabels: state.GetRuleExtraLabels(r.log, rule, group.FolderFullpath, true),
+				interval:    time.Duration(group.Interval) * time.Second,
+			}
+			rules = append(rules, rut)
+			if rut.interval <= 0 {
+				continue
+			}
+			for at := time.Duration(0); at <= maxEvalTime; at += rut.interval {
+				evaluations = append(evaluations, evaluation{at: at, rule: rut})
+			}
+		}
+	}
+	sort.SliceStable(evaluations, func(i, j int) bool {
+		return evaluations[i].at < evaluations[j].at
+	})
+
+	var errs []error
+	for _, tc := range tests {
+		evalTime := time.Duration(tc.EvalTime)
+		for len(evaluations) > 0 && evaluations[0].at <= evalTime {
+			e := evaluations[0]
+			evaluations = evaluations[1:]
+			now := start.Add(e.at)
+			clk.Set(now)
+			r.evaluate(ctx, evalFactory, manager, e.rule, now)
+		}
+
+		rut, err := findRule(rules, tc)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		got := firingAlerts(manager, rut.rule)
+		exp := expectedAlerts(tc.ExpAlerts)
+		if !equalAlerts(exp, got) {
+			errs = append(errs, fmt.Errorf("alertname: %s, time: %s,\n    exp:%s,\n    got:%s",
+				rut.rule.Title, model.Duration(evalTime), formatAlerts(exp), formatAlerts(got)))
+		}
+	}
+	return errs
+}
+
+// evaluate evaluates the rule and processes the results in the same way as the scheduler.
+func (r *Runner) evaluate(ctx context.Context, evalFactory eval.EvaluatorFactory, manager *state.Manager, rut *ruleUnderTest, now time.Time) {
+	ctx = models.WithRuleKey(ctx, rut.rule.GetKey())
+	evalCtx := eval.NewContextWithPreviousResults(ctx, schedule.SchedulerUserFor(rut.rule.OrgID), &schedule.AlertingResultsFromRuleState{
+		Manager: manager,
+		Rule:    rut.rule,
+	})
+	evalCtx.Timeout = rut.rule.EvaluationPolicy.GetTimeout()
+	var results eval.Results
+	evaluator, err := evalFactory.Create(evalCtx, rut.rule.GetEvalCondition())
+	if err == nil {
+		results, err = evaluator.Evaluate(ctx, now)
+	}
+	if err != nil {
+		r.log.Debug("Failed to evaluate rule", "rule_uid", rut.rule.UID, "time", now, "error", err)
+		if results == nil {
+			results = eval.Results{eval.NewResultFromError(err, now, 0)}
+		}
+	}
+	manager.ProcessEvalResults(ctx, now, rut.rule, results, rut.extraLabels, nil)
+}
+
+func findRule(rules []*ruleUnderTest, tc AlertTestCase) (*ruleUnderTest, error) {
+	for _, rut := range rules {
+		if tc.RuleUID != "" && rut.rule.UID != tc.RuleUID {
+			continue
+		}
+		if tc.Alertname != "" && rut.rule.Title != tc.Alertname {
+			continue
+		}
+		return rut, nil
+	}
+	if tc.RuleUID != "" {
+		return nil, fmt.Errorf("alert rule with UID %s not found", tc.RuleUID)
+	}
+	return nil, fmt.Errorf("alert rule %s not found", tc.Alertname)
+}
+
+type alert struct {
+	labels      data.Labels
+	annotations data.Labels
+}
+
+// firingAlerts returns the alerts of the rule that are firing in the Alertmanager, without private labels and annotations.
+func firingAlerts(manager *state.Manager, rule *models.AlertRule) []alert {
+	var alerts []alert
+	for _, s := range manager.GetStatesForRuleUID(rule.OrgID, rule.UID) {
+		switch s.State {
+		case eval.Alerting, eval.NoData, eval.Error:
+		default:
+			continue
+		}
+		postable := state.StateToPostableAlert(state.StateTransition{State: s, PreviousState: s.State}, nil)
+		alerts = append(alerts, alert{
+			labels:      withoutPrivateKeys(postable.Labels),
+			annotations: withoutPrivateKeys(postable.Annotations),
+		})
+	}
+	return alerts
+}
+
+func expectedAlerts(exp []ExpectedAlert) []alert {
+	alerts := make([]alert, 0, len(exp))
+	for _, e := range exp {
+		alerts = append(alerts, alert{
+			labels:      withoutPrivateKeys(e.ExpLabels),
+			annotations: withoutPrivateKeys(e.ExpAnnotations),
+		})
+	}
+	return alerts
+}
+
+func withoutPrivateKeys(m map[string]string) data.Labels {
+	result := make(data.Labels, len(m))
+	for k, v := range m {
+		if strings.HasPrefix(k, "__") && strings.HasSuffix(k, "__") {
+			continue
+		}
+		result[k] = v
+	}
+	return result
+}
+
+func (a alert) String() string {
+	return fmt.Sprintf("{labels: {%s}, annotations: {%s}}", a.labels, a.annotations)
+}
+
+func sortAlerts(alerts []alert) {
+	sort.Slice(alerts, func(i, j int) bool {
+		return alerts[i].String() < alerts[j].String()
+	})
+}
+
+// equalAlerts compares the alerts regardless of their order.
+func equalAlerts(exp, got []alert) bool {
+	if len(exp) != len(got) {
+		return false
+	}
+	sortAlerts(exp)
+	sortAlerts(got)
+	for i := range exp {
+		if exp[i].String() != got[i].String() {
+			return false
+		}
+	}
+	return true
+}
+
+func formatAlerts(alerts []alert) string {
+	if len(alerts) == 0 {
+		return "[]"
+	}
+	sortAlerts(alerts)
+	s := make([]string, 0, len(alerts))
+	for i, a := range alerts {
+		s = append(s, fmt.Sprintf("%d:\n        %s", i, a))
+	}
+	return "[\n    " + strings.Join(s, "\n    ") + "\n    ]"
+}
+
+func indent(s, prefix string) string {
+	return prefix + strings.ReplaceAll(s, "\n", "\n"+prefix)
+}
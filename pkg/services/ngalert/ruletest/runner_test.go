@@ -0,0 +1,44 @@
+package ruletest
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/infra/tracing"
+)
+
+func TestRunFiles(t *testing.T) {
+	runner := NewRunner(log.NewNopLogger(), tracing.InitializeTracerForTest())
+
+	t.Run("should pass when the alerts match", func(t *testing.T) {
+		var out bytes.Buffer
+		passed := runner.RunFiles(context.Background(), &out, filepath.Join("testdata", "rules_test.yaml"))
+		require.Truef(t, passed, "unexpected failure:\n%s", out.String())
+		require.Contains(t, out.String(), "SUCCESS")
+	})
+
+	t.Run("should fail and report the differences when the alerts do not match", func(t *testing.T) {
+		var out bytes.Buffer
+		passed := runner.RunFiles(context.Background(), &out, filepath.Join("testdata", "failing_test.yaml"))
+		require.False(t, passed)
+		require.Contains(t, out.String(), "FAILED")
+		require.Contains(t, out.String(), "alertname: HighCPU, time: 1m")
+		require.Contains(t, out.String(), "got:[]")
+		require.Contains(t, out.String(), "alert rule Unknown not found")
+	})
+
+	t.Run("should fail when the test file is invalid", func(t *testing.T) {
+		path := filepath.Join(t.TempDir(), "test.yaml")
+		require.NoError(t, os.WriteFile(path, []byte("rule_files: [rules.yaml]\ntests:\n  - alert_rule_test:\n      - eval_time: 1m\n"), 0600))
+		var out bytes.Buffer
+		passed := runner.RunFiles(context.Background(), &out, path)
+		require.False(t, passed)
+		require.Contains(t, out.String(), "alert rule test must have either alertname or rule_uid")
+	})
+}
@@ -0,0 +1,100 @@
+package ruletest
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+
+	"github.com/prometheus/common/model"
+	"gopkg.in/yaml.v3"
+)
+
+// TestFile is the format of a file with unit tests for Grafana-managed alert rules.
+// It follows the format of the files used by `promtool test rules`.
+type TestFile struct {
+	// RuleFiles are the files with the rules under test, in the file provisioning format.
+	// Relative paths are resolved from the directory of the test file.
+	RuleFiles []string    `yaml:"rule_files"`
+	Tests     []TestGroup `yaml:"tests"`
+}
+
+// TestGroup is a set of input series and the alerts that are expected from them.
+type TestGroup struct {
+	Name string `yaml:"name,omitempty"`
+	// Interval is the time between two samples of the input series. Defaults to 1m.
+	Interval    model.Duration  `yaml:"interval,omitempty"`
+	InputSeries []InputSeries   `yaml:"input_series"`
+	AlertTests  []AlertTestCase `yaml:"alert_rule_test"`
+}
+
+// InputSeries is a series that is returned by the query RefID of the rules.
+type InputSeries struct {
+	// RefID is the query of the rules that returns the series.
+	RefID string `yaml:"ref_id"`
+	// RuleUID limits the series to a single rule. The series is returned to all rules if empty.
+	RuleUID string `yaml:"rule_uid,omitempty"`
+	// Series is the name and the labels of the series in the Prometheus format, e.g. `cpu{instance="a"}`.
+	Series string `yaml:"series"`
+	// Values are the samples of the series in the expanding notation of promtool, e.g. `1+1x10 _ 5`.
+	Values string `yaml:"values"`
+}
+
+// AlertTestCase are the alerts that are expected from a rule at a point in time.
+type AlertTestCase struct {
+	EvalTime model.Duration `yaml:"eval_time"`
+	// Alertname is the title of the rule.
+	Alertname string `yaml:"alertname,omitempty"`
+	RuleUID   string `yaml:"rule_uid,omitempty"`
+	// ExpAlerts are the firing alerts. The order does not matter.
+	ExpAlerts []ExpectedAlert `yaml:"exp_alerts"`
+}
+
+type ExpectedAlert struct {
+	ExpLabels      map[string]string `yaml:"exp_labels"`
+	ExpAnnotations map[string]string `yaml:"exp_annotations"`
+}
+
+// LoadTestFile reads a test file.
+func LoadTestFile(path string) (*TestFile, error) {
+	b, err := os.ReadFile(filepath.Clean(path))
+	if err != nil {
+		return nil, err
+	}
+	var f TestFile
+	if err := yaml.Unmarshal(b, &f); err != nil {
+		return nil, fmt.Errorf("failed to parse test file %s: %w", path, err)
+	}
+	if len(f.RuleFiles) == 0 {
+		return nil, errors.New("test file does not contain rule files")
+	}
+	dir := filepath.Dir(path)
+	for i, ruleFile := range f.RuleFiles {
+		if !filepath.IsAbs(ruleFile) {
+			f.RuleFiles[i] = filepath.Join(dir, ruleFile)
+		}
+	}
+	for i, group := range f.Tests {
+		if err := group.validate(); err != nil {
+			return nil, fmt.Errorf("invalid test group %d: %w", i, err)
+		}
+	}
+	return &f, nil
+}
+
+func (g TestGroup) validate() error {
+	for _, s := range g.InputSeries {
+		if s.RefID == "" {
+			return fmt.Errorf("input series %s has no ref_id", s.Series)
+		}
+	}
+	for _, tc := range g.AlertTests {
+		if tc.Alertname == "" && tc.RuleUID == "" {
+			return errors.New("alert rule test must have either alertname or rule_uid")
+		}
+		if tc.EvalTime < 0 {
+			return errors.New("eval_time must not be negative")
+		}
+	}
+	return nil
+}
@@ -277,9 +277,11 @@ func (a *alertRule) Run() error {
 				defer func() {
 					evalDuration.Observe(a.clock.Now().Sub(evalStart).Seconds())
 					a.evalApplied(ctx.scheduledAt)
+					ctx.evaluated()
 				}()
 
-				for attempt := int64(1); attempt <= a.maxAttempts; attempt++ {
+				maxAttempts := ctx.rule.EvaluationPolicy.GetMaxAttempts(a.maxAttempts)
+				for attempt := int64(1); attempt <= maxAttempts; attempt++ {
 					isPaused := ctx.rule.IsPaused
 
 					// Do not clean up state if the eval loop has just started.
@@ -323,7 +325,7 @@ func (a *alertRule) Run() error {
 						logger.Error("Skip evaluation and updating the state because the context has been cancelled", "version", ctx.rule.Version, "fingerprint", f, "attempt", attempt, "now", ctx.scheduledAt)
 						return
 					}
-					retry := attempt < a.maxAttempts
+					retry := attempt < maxAttempts
 					err := a.evaluate(tracingCtx, ctx, span, retry, logger)
 					// This is extremely confusing - when we exhaust all retry attempts, or we have no retryable errors
 					// we return nil - so technically, this is meaningless to know whether the evaluation has errors or not.
@@ -333,12 +335,13 @@ func (a *alertRule) Run() error {
 						return
 					}
 
-					logger.Error("Failed to evaluate rule", "attempt", attempt, "error", err)
+					backoff := ctx.rule.EvaluationPolicy.Backoff(attempt, retryDelay)
+					logger.Error("Failed to evaluate rule", "attempt", attempt, "backoff", backoff, "error", err)
 					select {
 					case <-tracingCtx.Done():
 						logger.Error("Context has been cancelled while backing off", "attempt", attempt)
 						return
-					case <-time.After(retryDelay):
+					case <-time.After(backoff):
 						continue
 					}
 				}
@@ -372,6 +375,7 @@ func (a *alertRule) evaluate(ctx context.Context, e *Evaluation, span trace.Span
 	start := a.clock.Now()
 
 	evalCtx := eval.NewContextWithPreviousResults(ctx, SchedulerUserFor(e.rule.OrgID), a.newLoadedMetricsReader(e.rule))
+	evalCtx.Timeout = e.rule.EvaluationPolicy.GetTimeout()
 	ruleEval, err := a.evalFactory.Create(evalCtx, e.rule.GetEvalCondition().WithSource("scheduler").WithFolder(e.folderTitle))
 	var results eval.Results
 	var dur time.Duration
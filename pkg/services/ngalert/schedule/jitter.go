@@ -28,7 +28,8 @@ func JitterStrategyFrom(cfg setting.UnifiedAlertingSettings, toggles featuremgmt
 	if toggles == nil {
 		return strategy
 	}
-	if toggles.IsEnabledGlobally(featuremgmt.FlagJitterAlertRulesWithinGroups) {
+	// Rules of a group that is evaluated sequentially must be ready to run at the same tick.
+	if toggles.IsEnabledGlobally(featuremgmt.FlagJitterAlertRulesWithinGroups) && !cfg.SequentialRuleGroupEvaluation {
 		strategy = JitterByRule
 	}
 	return strategy
@@ -134,6 +134,7 @@ func (r *recordingRule) Run() error {
 			}
 			if !r.cfg.Enabled {
 				r.logger.Warn("Recording rule scheduled but subsystem is not enabled. Skipping")
+				eval.evaluated()
 				return nil
 			}
 			// TODO: Skipping the "evalRunning" guard that the alert rule routine does, because it seems to be dead code and impossible to hit.
@@ -166,6 +167,7 @@ func (r *recordingRule) doEvaluate(ctx context.Context, ev *Evaluation) {
 		r.evaluationDuration.Store(dur)
 
 		r.evaluationDoneTestHook(ev)
+		ev.evaluated()
 	}()
 
 	if ev.rule.IsPaused {
@@ -183,7 +185,8 @@ func (r *recordingRule) doEvaluate(ctx context.Context, ev *Evaluation) {
 	defer span.End()
 
 	var latestError error
-	for attempt := int64(1); attempt <= r.maxAttempts; attempt++ {
+	maxAttempts := ev.rule.EvaluationPolicy.GetMaxAttempts(r.maxAttempts)
+	for attempt := int64(1); attempt <= maxAttempts; attempt++ {
 		logger := logger.New("attempt", attempt)
 		if ctx.Err() != nil {
 			span.SetStatus(codes.Error, "rule evaluation cancelled")
@@ -205,12 +208,12 @@ func (r *recordingRule) doEvaluate(ctx context.Context, ev *Evaluation) {
 			break
 		}
 
-		if attempt < r.maxAttempts {
+		if attempt < maxAttempts {
 			select {
 			case <-ctx.Done():
 				logger.Error("Context has been cancelled while backing off", "attempt", attempt)
 				return
-			case <-time.After(retryDelay):
+			case <-time.After(ev.rule.EvaluationPolicy.Backoff(attempt, retryDelay)):
 				continue
 			}
 		}
@@ -222,7 +225,7 @@ func (r *recordingRule) doEvaluate(ctx context.Context, ev *Evaluation) {
 		span.RecordError(latestError)
 		r.lastError.Store(latestError)
 		r.health.Store("error")
-		if r.maxAttempts > 0 {
+		if maxAttempts > 0 {
 			logger.Error("Recording rule evaluation failed after all attempts", "lastError", latestError)
 		}
 		return
@@ -236,6 +239,7 @@ func (r *recordingRule) doEvaluate(ctx context.Context, ev *Evaluation) {
 func (r *recordingRule) tryEvaluation(ctx context.Context, ev *Evaluation, logger log.Logger) error {
 	evalStart := r.clock.Now()
 	evalCtx := eval.NewContext(ctx, SchedulerUserFor(ev.rule.OrgID))
+	evalCtx.Timeout = ev.rule.EvaluationPolicy.GetTimeout()
 	result, err := r.buildAndExecutePipeline(ctx, evalCtx, ev, logger)
 	evalDur := r.clock.Now().Sub(evalStart)
 	if err != nil {
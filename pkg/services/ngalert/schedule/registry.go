@@ -18,6 +18,7 @@ import (
 var (
 	errRuleDeleted   = errors.New("rule deleted")
 	errRuleRestarted = errors.New("rule restarted")
+	errRuleNotOwned  = errors.New("rule is evaluated by another replica")
 )
 
 type ruleFactory interface {
@@ -96,6 +97,15 @@ type Evaluation struct {
 	scheduledAt time.Time
 	rule        *models.AlertRule
 	folderTitle string
+	// afterEval starts the evaluation of the next rule of the sequence of the evaluation, if any.
+	afterEval func()
+}
+
+// evaluated must be called once the evaluation is done or will not happen, to continue its sequence.
+func (e *Evaluation) evaluated() {
+	if e.afterEval != nil {
+		e.afterEval()
+	}
 }
 
 func (e *Evaluation) Fingerprint() fingerprint {
@@ -317,6 +327,7 @@ func (r ruleWithFolder) Fingerprint() fingerprint {
 	writeInt(rule.ID)
 	writeInt(rule.OrgID)
 	writeInt(int64(rule.For))
+	writeInt(int64(rule.KeepFiringFor))
 	if rule.DashboardUID != nil {
 		writeString(*rule.DashboardUID)
 	}
@@ -331,6 +342,10 @@ func (r ruleWithFolder) Fingerprint() fingerprint {
 		binary.LittleEndian.PutUint64(tmp, uint64(rule.Record.Fingerprint()))
 		writeBytes(tmp)
 	}
+	if rule.EvaluationPolicy != nil {
+		binary.LittleEndian.PutUint64(tmp, uint64(rule.EvaluationPolicy.Fingerprint()))
+		writeBytes(tmp)
+	}
 
 	return fingerprint(sum.Sum64())
 }
@@ -194,6 +194,7 @@ func TestRuleWithFolderFingerprint(t *testing.T) {
 			ExecErrState:    "test-err",
 			Record:          &models.Record{Metric: "my_metric", From: "A"},
 			For:             12,
+			KeepFiringFor:   120,
 			Annotations: map[string]string{
 				"key-annotation": "value-annotation",
 			},
@@ -204,6 +205,7 @@ func TestRuleWithFolderFingerprint(t *testing.T) {
 			NotificationSettings: []models.NotificationSettings{
 				models.NotificationSettingsGen()(),
 			},
+			EvaluationPolicy: &models.EvaluationPolicy{Timeout: time.Second, MaxAttempts: 2},
 			Metadata: models.AlertRuleMetadata{
 				EditorSettings: models.EditorSettings{
 					SimplifiedQueryAndExpressionsSection: false,
@@ -239,6 +241,7 @@ func TestRuleWithFolderFingerprint(t *testing.T) {
 			ExecErrState:    "test-err2",
 			Record:          &models.Record{Metric: "my_metric2", From: "B"},
 			For:             1141,
+			KeepFiringFor:   11410,
 			Annotations: map[string]string{
 				"key-annotation2": "value-annotation",
 			},
@@ -249,6 +252,7 @@ func TestRuleWithFolderFingerprint(t *testing.T) {
 			NotificationSettings: []models.NotificationSettings{
 				models.NotificationSettingsGen()(),
 			},
+			EvaluationPolicy: &models.EvaluationPolicy{Timeout: 2 * time.Second, InitialBackoff: time.Second},
 			Metadata: models.AlertRuleMetadata{
 				EditorSettings: models.EditorSettings{
 					SimplifiedQueryAndExpressionsSection: true,
@@ -1,6 +1,7 @@
 package schedule
 
 import (
+	"cmp"
 	"context"
 	"fmt"
 	"net/url"
@@ -33,7 +34,7 @@ type ScheduleService interface {
 }
 
 // retryDelay represents how long to wait between each failed rule evaluation.
-const retryDelay = 1 * time.Second
+const retryDelay = ngmodels.DefaultEvaluationRetryDelay
 
 // AlertsSender is an interface for a service that is responsible for sending notifications to the end-user.
 //
@@ -48,6 +49,12 @@ type RulesStore interface {
 	GetAlertRulesForScheduling(ctx context.Context, query *ngmodels.GetAlertRulesForSchedulingQuery) error
 }
 
+// RuleSharder decides which alert rules are evaluated by this replica when the rules are sharded across replicas.
+type RuleSharder interface {
+	// Owns returns true if the rules with the given shard key are evaluated by this replica.
+	Owns(key string) bool
+}
+
 type RecordingWriter interface {
 	Write(ctx context.Context, name string, t time.Time, frames data.Frames, orgID int64, extraLabels map[string]string) error
 }
@@ -86,6 +93,10 @@ type schedule struct {
 	jitterEvaluations    JitterStrategy
 	rrCfg                setting.RecordingRuleSettings
 
+	// sequentialRuleGroups makes the rules of a rule group be evaluated one after the other
+	// in the order of the rules in the group.
+	sequentialRuleGroups bool
+
 	metrics *metrics.Scheduler
 
 	alertsSender    AlertsSender
@@ -100,6 +111,15 @@ type schedule struct {
 	tracer tracing.Tracer
 
 	recordingWriter RecordingWriter
+
+	// sharder decides which rules are evaluated by this replica. If it is nil, all rules are evaluated.
+	sharder RuleSharder
+	// disowned contains the rules that are evaluated by other replicas, so that their state is loaded
+	// again when they are evaluated by this replica. Each rule has a channel that is closed once the
+	// routine that evaluated the rule has exited and the state of the rule has been removed from the cache.
+	disowned map[ngmodels.AlertRuleKey]<-chan struct{}
+	// running contains a channel for each started rule routine, which is closed when the routine exits.
+	running map[ngmodels.AlertRuleKey]chan struct{}
 }
 
 // SchedulerCfg is the scheduler configuration.
@@ -112,6 +132,9 @@ type SchedulerCfg struct {
 	RecordingRulesCfg    setting.RecordingRuleSettings
 	AppURL               *url.URL
 	JitterEvaluations    JitterStrategy
+	// SequentialRuleGroups makes the scheduler evaluate the rules of a rule group one after the other, in the order
+	// of the rules in the group, so that a rule sees the results of the rules before it at the same tick.
+	SequentialRuleGroups bool
 	EvaluatorFactory     eval.EvaluatorFactory
 	RuleStore            RulesStore
 	Metrics              *metrics.Scheduler
@@ -119,6 +142,8 @@ type SchedulerCfg struct {
 	Tracer               tracing.Tracer
 	Log                  log.Logger
 	RecordingWriter      RecordingWriter
+	// Sharder makes the scheduler evaluate only the rules that are owned by this replica. If it is nil, all rules are evaluated.
+	Sharder RuleSharder
 }
 
 // NewScheduler returns a new scheduler.
@@ -141,6 +166,7 @@ func NewScheduler(cfg SchedulerCfg, stateManager *state.Manager) *schedule {
 		appURL:                cfg.AppURL,
 		disableGrafanaFolder:  cfg.DisableGrafanaFolder,
 		jitterEvaluations:     cfg.JitterEvaluations,
+		sequentialRuleGroups:  cfg.SequentialRuleGroups,
 		rrCfg:                 cfg.RecordingRulesCfg,
 		stateManager:          stateManager,
 		minRuleInterval:       cfg.MinRuleInterval,
@@ -148,6 +174,9 @@ func NewScheduler(cfg SchedulerCfg, stateManager *state.Manager) *schedule {
 		alertsSender:          cfg.AlertSender,
 		tracer:                cfg.Tracer,
 		recordingWriter:       cfg.RecordingWriter,
+		sharder:               cfg.Sharder,
+		disowned:              make(map[ngmodels.AlertRuleKey]<-chan struct{}),
+		running:               make(map[ngmodels.AlertRuleKey]chan struct{}),
 	}
 
 	return &sch
@@ -189,6 +218,7 @@ func (sch *schedule) deleteAlertRule(keys ...ngmodels.AlertRuleKey) {
 			sch.log.Info("Alert rule cannot be removed from the scheduler as it is not scheduled", key.LogContext()...)
 		}
 		// Delete the rule routine
+		delete(sch.running, key)
 		ruleRoutine, ok := sch.registry.del(key)
 		if !ok {
 			sch.log.Info("Alert rule cannot be stopped as it is not running", key.LogContext()...)
@@ -199,9 +229,93 @@ func (sch *schedule) deleteAlertRule(keys ...ngmodels.AlertRuleKey) {
 	}
 	// Our best bet at this point is that we update the metrics with what we hope to schedule in the next tick.
 	alertRules, _ := sch.schedulableAlertRules.all()
+	alertRules, _ = sch.shardRules(alertRules)
 	sch.updateRulesMetrics(alertRules)
 }
 
+// shardKey returns the key that decides which replica evaluates the rule. The rules of a rule group are
+// evaluated by the same replica if they are evaluated in sequence.
+func (sch *schedule) shardKey(rule *ngmodels.AlertRule) string {
+	if sch.sequentialRuleGroups {
+		return rule.GetGroupKey().String()
+	}
+	return rule.GetKey().String()
+}
+
+// shardRules splits the rules into the rules that are evaluated by this replica and the rules that are evaluated by other replicas.
+func (sch *schedule) shardRules(rules []*ngmodels.AlertRule) ([]*ngmodels.AlertRule, []*ngmodels.AlertRule) {
+	if sch.sharder == nil {
+		return rules, nil
+	}
+	owned := make([]*ngmodels.AlertRule, 0, len(rules))
+	var others []*ngmodels.AlertRule
+	for _, rule := range rules {
+		if sch.sharder.Owns(sch.shardKey(rule)) {
+			owned = append(owned, rule)
+		} else {
+			others = append(others, rule)
+		}
+	}
+	return owned, others
+}
+
+// disownRules stops the evaluation of the rules that are evaluated by other replicas and removes their state from the cache
+// once their routines have exited. It returns the rules that were evaluated by other replicas until now and must be loaded
+// before they are evaluated, each with a channel that is closed once the state of the rule has been removed from the cache.
+// The state must not be loaded before then, as it would be removed again.
+func (sch *schedule) disownRules(dispatcherGroup *errgroup.Group, owned, others []*ngmodels.AlertRule) map[ngmodels.AlertRuleKey]<-chan struct{} {
+	if sch.sharder == nil {
+		return nil
+	}
+	adopted := make(map[ngmodels.AlertRuleKey]<-chan struct{})
+	for _, rule := range owned {
+		key := rule.GetKey()
+		if forgotten, ok := sch.disowned[key]; ok {
+			adopted[key] = forgotten
+		}
+	}
+	disowned := make(map[ngmodels.AlertRuleKey]<-chan struct{}, len(others))
+	for _, rule := range others {
+		key := rule.GetKey()
+		if forgotten, ok := sch.disowned[key]; ok {
+			disowned[key] = forgotten
+			continue
+		}
+		sch.log.Debug("Alert rule is evaluated by another replica", key.LogContext()...)
+		if ruleRoutine, ok := sch.registry.del(key); ok {
+			ruleRoutine.Stop(errRuleNotOwned)
+		}
+		disowned[key] = sch.forgetRule(dispatcherGroup, key, sch.running[key])
+		delete(sch.running, key)
+	}
+	sch.disowned = disowned
+	return adopted
+}
+
+// forgetRule removes the state of the rule from the cache once the routine that evaluated the rule has exited,
+// so that an evaluation that is still in progress cannot add the state again. The state is saved before it is
+// removed, so that the replica that evaluates the rule from now on continues from it. If done is nil, the rule
+// was not evaluated by this replica, and its state is removed without saving it.
+// It returns a channel that is closed once the state has been removed.
+func (sch *schedule) forgetRule(dispatcherGroup *errgroup.Group, key ngmodels.AlertRuleKey, done <-chan struct{}) <-chan struct{} {
+	forgotten := make(chan struct{})
+	if done == nil {
+		sch.stateManager.ForgetRule(key)
+		close(forgotten)
+		return forgotten
+	}
+	dispatcherGroup.Go(func() error {
+		defer close(forgotten)
+		<-done
+		// The state must be saved even if the scheduler is stopping.
+		ctx, cancel := context.WithTimeout(context.Background(), time.Minute)
+		defer cancel()
+		sch.stateManager.HandOverRule(ngmodels.WithRuleKey(ctx, key), key)
+		return nil
+	})
+	return forgotten
+}
+
 func (sch *schedule) schedulePeriodic(ctx context.Context, t *ticker.T) error {
 	dispatcherGroup, ctx := errgroup.WithContext(ctx)
 	for {
@@ -247,6 +361,8 @@ func (sch *schedule) processTick(ctx context.Context, dispatcherGroup *errgroup.
 
 	// this is the new current state. rulesDiff contains the previously existing rules that were different between this state and the previous state.
 	alertRules, folderTitles := sch.schedulableAlertRules.all()
+	alertRules, otherRules := sch.shardRules(alertRules)
+	adopted := sch.disownRules(dispatcherGroup, alertRules, otherRules)
 
 	// registeredDefinitions is a map used for finding deleted alert rules
 	// initially it is assigned to all known alert rules from the previous cycle
@@ -299,7 +415,21 @@ func (sch *schedule) processTick(ctx context.Context, dispatcherGroup *errgroup.
 		}
 
 		if newRoutine && !invalidInterval {
+			forgotten, warm := adopted[key]
+			done := make(chan struct{})
+			sch.running[key] = done
 			dispatcherGroup.Go(func() error {
+				defer close(done)
+				if warm {
+					// The routine that evaluated the rule before it was evaluated by another replica
+					// must not remove the state after it is loaded.
+					select {
+					case <-forgotten:
+					case <-ctx.Done():
+						return nil
+					}
+					sch.stateManager.WarmRule(ctx, item)
+				}
 				return ruleRoutine.Run()
 			})
 		}
@@ -356,30 +486,22 @@ func (sch *schedule) processTick(ctx context.Context, dispatcherGroup *errgroup.
 		sch.log.Warn("Unable to obtain folder titles for some rules", "missingFolderUIDToRuleUID", missingFolder)
 	}
 
-	var step int64 = 0
-	if len(readyToRun) > 0 {
-		step = sch.baseInterval.Nanoseconds() / int64(len(readyToRun))
-	}
-
 	slices.SortFunc(readyToRun, func(a, b readyToRunItem) int {
 		return strings.Compare(a.rule.UID, b.rule.UID)
 	})
-	for i := range readyToRun {
-		item := readyToRun[i]
-
-		time.AfterFunc(time.Duration(int64(i)*step), func() {
-			key := item.rule.GetKey()
-			success, dropped := item.ruleRoutine.Eval(&item.Evaluation)
-			if !success {
-				sch.log.Debug("Scheduled evaluation was canceled because evaluation routine was stopped", append(key.LogContext(), "time", tick)...)
-				return
-			}
-			if dropped != nil {
-				sch.log.Warn("Tick dropped because alert rule evaluation is too slow", append(key.LogContext(), "time", tick, "droppedTick", dropped.scheduledAt)...)
-				orgID := fmt.Sprint(key.OrgID)
-				sch.metrics.EvaluationMissed.WithLabelValues(orgID, item.rule.Title).Inc()
-			}
-		})
+
+	toRun := readyToRun
+	if sch.sequentialRuleGroups {
+		toRun = sch.buildSequences(readyToRun)
+	}
+
+	var step int64 = 0
+	if len(toRun) > 0 {
+		step = sch.baseInterval.Nanoseconds() / int64(len(toRun))
+	}
+
+	for i := range toRun {
+		time.AfterFunc(time.Duration(int64(i)*step), sch.runJob(toRun[i]))
 	}
 
 	// Stop old routines for rules that got restarted.
@@ -395,3 +517,54 @@ func (sch *schedule) processTick(ctx context.Context, dispatcherGroup *errgroup.
 	sch.deleteAlertRule(toDelete...)
 	return readyToRun, registeredDefinitions, updatedRules
 }
+
+// runJob returns a function that sends the evaluation of the item to its rule routine.
+// If the evaluation cannot be sent, the evaluation of the next rule of its sequence is started instead.
+func (sch *schedule) runJob(item readyToRunItem) func() {
+	return func() {
+		key := item.rule.GetKey()
+		success, dropped := item.ruleRoutine.Eval(&item.Evaluation)
+		if !success {
+			sch.log.Debug("Scheduled evaluation was canceled because evaluation routine was stopped", append(key.LogContext(), "time", item.scheduledAt)...)
+			item.evaluated()
+			return
+		}
+		if dropped != nil {
+			sch.log.Warn("Tick dropped because alert rule evaluation is too slow", append(key.LogContext(), "time", item.scheduledAt, "droppedTick", dropped.scheduledAt)...)
+			orgID := fmt.Sprint(key.OrgID)
+			sch.metrics.EvaluationMissed.WithLabelValues(orgID, item.rule.Title).Inc()
+			dropped.evaluated()
+		}
+	}
+}
+
+// buildSequences chains the evaluations of the rules of each rule group in the order of the rules in the group,
+// so that each rule is evaluated after the rule before it is done, and returns the first evaluation of each group.
+// The items must be sorted by UID, which orders rules with the same index.
+func (sch *schedule) buildSequences(items []readyToRunItem) []readyToRunItem {
+	groups := make(map[ngmodels.AlertRuleGroupKey][]readyToRunItem)
+	keys := make([]ngmodels.AlertRuleGroupKey, 0)
+	for _, item := range items {
+		key := item.rule.GetGroupKey()
+		if _, ok := groups[key]; !ok {
+			keys = append(keys, key)
+		}
+		groups[key] = append(groups[key], item)
+	}
+
+	result := make([]readyToRunItem, 0, len(keys))
+	for _, key := range keys {
+		group := groups[key]
+		slices.SortStableFunc(group, func(a, b readyToRunItem) int {
+			return cmp.Compare(a.rule.RuleGroupIndex, b.rule.RuleGroupIndex)
+		})
+		for i := len(group) - 1; i > 0; i-- {
+			// The next evaluation is sent from another goroutine, so that the rule routine
+			// that calls it does not wait for the routine of the next rule.
+			next := sch.runJob(group[i])
+			group[i-1].afterEval = func() { go next() }
+		}
+		result = append(result, group[0])
+	}
+	return result
+}
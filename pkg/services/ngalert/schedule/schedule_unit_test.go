@@ -8,10 +8,12 @@ import (
 	"math/rand"
 	"net/url"
 	"slices"
+	"strings"
 	"testing"
 	"time"
 
 	"github.com/benbjohnson/clock"
+	"github.com/grafana/grafana-plugin-sdk-go/backend"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/testutil"
 	"github.com/stretchr/testify/assert"
@@ -935,6 +937,231 @@ func TestSchedule_deleteAlertRule(t *testing.T) {
 	})
 }
 
+func TestSchedule_sharding(t *testing.T) {
+	ruleStore := newFakeRulesStore()
+	sch := setupScheduler(t, ruleStore, nil, nil, nil, nil)
+	sharder := &fakeRuleSharder{owned: map[string]bool{}}
+	sch.sharder = sharder
+	dispatcherGroup, ctx := errgroup.WithContext(context.Background())
+
+	gen := models.RuleGen.With(models.RuleGen.WithInterval(time.Second))
+	owned := gen.GenerateRef()
+	other := gen.GenerateRef()
+	ruleStore.PutRule(ctx, owned, other)
+	sharder.owned[owned.GetKey().String()] = true
+
+	tick := time.Time{}.Add(time.Second)
+	scheduled, stopped, _ := sch.processTick(ctx, dispatcherGroup, tick)
+	require.Len(t, scheduled, 1)
+	require.Equal(t, owned.GetKey(), scheduled[0].rule.GetKey())
+	require.Empty(t, stopped)
+	require.True(t, sch.registry.exists(owned.GetKey()))
+	require.False(t, sch.registry.exists(other.GetKey()))
+
+	t.Run("should stop the rule when it is owned by another replica", func(t *testing.T) {
+		routine, ok := sch.registry.get(owned.GetKey())
+		require.True(t, ok)
+		sharder.owned[owned.GetKey().String()] = false
+
+		tick = tick.Add(time.Second)
+		scheduled, stopped, _ := sch.processTick(ctx, dispatcherGroup, tick)
+		require.Empty(t, scheduled)
+		require.Empty(t, stopped, "rules owned by other replicas are not deleted")
+		require.False(t, sch.registry.exists(owned.GetKey()))
+		require.ErrorIs(t, routine.(*alertRule).ctx.Err(), errRuleNotOwned)
+		require.Contains(t, sch.disowned, owned.GetKey())
+	})
+
+	t.Run("should schedule the rule again when it is owned by this replica", func(t *testing.T) {
+		sharder.owned[owned.GetKey().String()] = true
+
+		tick = tick.Add(time.Second)
+		scheduled, _, _ := sch.processTick(ctx, dispatcherGroup, tick)
+		require.Len(t, scheduled, 1)
+		require.Equal(t, owned.GetKey(), scheduled[0].rule.GetKey())
+		require.NotContains(t, sch.disowned, owned.GetKey())
+	})
+
+	t.Run("should use the group key when rule groups are evaluated in sequence", func(t *testing.T) {
+		sch.sequentialRuleGroups = true
+		require.Equal(t, owned.GetGroupKey().String(), sch.shardKey(owned))
+		sch.sequentialRuleGroups = false
+		require.Equal(t, owned.GetKey().String(), sch.shardKey(owned))
+	})
+}
+
+func TestSchedule_shardingHandover(t *testing.T) {
+	ruleStore := newFakeRulesStore()
+	instanceStore := &state.FakeInstanceStore{}
+	evaluator := &blockingEvaluatorFactory{started: make(chan struct{}, 1), release: make(chan struct{})}
+	sch := setupScheduler(t, ruleStore, instanceStore, nil, nil, evaluator)
+	sharder := &fakeRuleSharder{owned: map[string]bool{}}
+	sch.sharder = sharder
+	ctx, cancel := context.WithCancel(context.Background())
+	t.Cleanup(cancel)
+	dispatcherGroup, ctx := errgroup.WithContext(ctx)
+
+	rule := models.RuleGen.With(models.RuleGen.WithInterval(10 * time.Second)).GenerateRef()
+	key := rule.GetKey()
+	ruleStore.PutRule(ctx, rule)
+	instanceStore.Instances = []*models.AlertInstance{{
+		AlertInstanceKey: models.AlertInstanceKey{RuleOrgID: key.OrgID, RuleUID: key.UID, LabelsHash: "hash"},
+		Labels:           models.InstanceLabels{"instance": "a"},
+		CurrentState:     models.InstanceStateFiring,
+	}}
+	sharder.owned[key.String()] = true
+
+	// The rule is evaluated at multiples of its interval only, so the next ticks do not evaluate it.
+	tick := time.Unix(10, 0)
+	scheduled, _, _ := sch.processTick(ctx, dispatcherGroup, tick)
+	require.Len(t, scheduled, 1)
+	select {
+	case <-evaluator.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the rule was not evaluated")
+	}
+
+	// The rule is evaluated by another replica while it is evaluated by this replica,
+	sharder.owned[key.String()] = false
+	sch.processTick(ctx, dispatcherGroup, tick.Add(time.Second))
+	forgotten, ok := sch.disowned[key]
+	require.True(t, ok)
+
+	// and by this replica again before the evaluation is done.
+	sharder.owned[key.String()] = true
+	sch.processTick(ctx, dispatcherGroup, tick.Add(2*time.Second))
+	require.True(t, sch.registry.exists(key))
+	select {
+	case <-forgotten:
+		t.Fatal("the state of the rule was removed before the previous routine exited")
+	default:
+	}
+	require.Never(t, func() bool {
+		return len(sch.stateManager.GetStatesForRuleUID(key.OrgID, key.UID)) > 0
+	}, 200*time.Millisecond, 10*time.Millisecond, "the state of the rule was loaded before the previous routine exited")
+
+	close(evaluator.release)
+	select {
+	case <-forgotten:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the state of the rule was not removed after the previous routine exited")
+	}
+	require.Eventually(t, func() bool {
+		return len(sch.stateManager.GetStatesForRuleUID(key.OrgID, key.UID)) == 1
+	}, 5*time.Second, 10*time.Millisecond, "the state of the rule was not loaded after it was removed")
+}
+
+// blockingEvaluatorFactory creates evaluators that block until release is closed.
+type blockingEvaluatorFactory struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (f *blockingEvaluatorFactory) Create(_ eval.EvaluationContext, _ models.Condition) (eval.ConditionEvaluator, error) {
+	return f, nil
+}
+
+func (f *blockingEvaluatorFactory) EvaluateRaw(_ context.Context, _ time.Time) (*backend.QueryDataResponse, error) {
+	return nil, nil
+}
+
+func (f *blockingEvaluatorFactory) Evaluate(_ context.Context, _ time.Time) (eval.Results, error) {
+	select {
+	case f.started <- struct{}{}:
+	default:
+	}
+	<-f.release
+	return nil, nil
+}
+
+type fakeRuleSharder struct {
+	owned map[string]bool
+}
+
+func (s *fakeRuleSharder) Owns(key string) bool {
+	return s.owned[key]
+}
+
+func TestSchedule_buildSequences(t *testing.T) {
+	sch := setupScheduler(t, nil, nil, nil, nil, nil)
+	gen := models.RuleGen
+	groupKey := models.AlertRuleGroupKey{OrgID: 1, NamespaceUID: "folder", RuleGroup: "group"}
+	inGroup := gen.With(gen.WithGroupKey(groupKey))
+	rule1 := inGroup.With(gen.WithGroupIndex(1)).GenerateRef()
+	rule2 := inGroup.With(gen.WithGroupIndex(2)).GenerateRef()
+	rule3 := inGroup.With(gen.WithGroupIndex(3)).GenerateRef()
+	// the order of the UIDs is not the order of the rules in the group
+	rule1.UID, rule2.UID, rule3.UID = "c", "a", "b"
+	other := gen.With(gen.WithGroupKey(models.AlertRuleGroupKey{OrgID: 1, NamespaceUID: "folder", RuleGroup: "other"})).GenerateRef()
+
+	newItems := func(routine Rule, rules ...*models.AlertRule) []readyToRunItem {
+		items := make([]readyToRunItem, 0, len(rules))
+		for _, r := range rules {
+			items = append(items, readyToRunItem{ruleRoutine: routine, Evaluation: Evaluation{scheduledAt: time.Now(), rule: r}})
+		}
+		slices.SortFunc(items, func(a, b readyToRunItem) int {
+			return strings.Compare(a.rule.UID, b.rule.UID)
+		})
+		return items
+	}
+
+	t.Run("should evaluate the rules of a group in order", func(t *testing.T) {
+		routine := newSequenceRecorder()
+		sequences := sch.buildSequences(newItems(routine, rule3, rule1, other, rule2))
+		require.Len(t, sequences, 2)
+		for _, s := range sequences {
+			sch.runJob(s)()
+		}
+		order := routine.wait(t, 4)
+		require.ElementsMatch(t, []string{rule1.UID, rule2.UID, rule3.UID, other.UID}, order)
+		order = slices.DeleteFunc(order, func(uid string) bool { return uid == other.UID })
+		require.Equal(t, []string{rule1.UID, rule2.UID, rule3.UID}, order)
+	})
+
+	t.Run("should continue the sequence if a rule routine is stopped", func(t *testing.T) {
+		routine := newSequenceRecorder()
+		routine.stopped[rule2.UID] = struct{}{}
+		sequences := sch.buildSequences(newItems(routine, rule1, rule2, rule3))
+		require.Len(t, sequences, 1)
+		sch.runJob(sequences[0])()
+		require.Equal(t, []string{rule1.UID, rule3.UID}, routine.wait(t, 2))
+	})
+}
+
+// sequenceRecorder is a Rule that records the UIDs of the evaluated rules, and that is done with an evaluation as soon as it receives it.
+type sequenceRecorder struct {
+	Rule
+	stopped   map[string]struct{}
+	evaluated chan string
+}
+
+func newSequenceRecorder() *sequenceRecorder {
+	return &sequenceRecorder{stopped: map[string]struct{}{}, evaluated: make(chan string, 10)}
+}
+
+func (r *sequenceRecorder) Eval(e *Evaluation) (bool, *Evaluation) {
+	if _, ok := r.stopped[e.rule.UID]; ok {
+		return false, nil
+	}
+	r.evaluated <- e.rule.UID
+	e.evaluated()
+	return true, nil
+}
+
+func (r *sequenceRecorder) wait(t *testing.T, n int) []string {
+	t.Helper()
+	result := make([]string, 0, n)
+	for len(result) < n {
+		select {
+		case uid := <-r.evaluated:
+			result = append(result, uid)
+		case <-time.After(5 * time.Second):
+			require.FailNow(t, "timed out waiting for evaluations", "evaluated: %v", result)
+		}
+	}
+	return result
+}
+
 func setupScheduler(t *testing.T, rs *fakeRulesStore, is *state.FakeInstanceStore, registry *prometheus.Registry, senderMock *SyncAlertsSenderMock, evalMock eval.EvaluatorFactory) *schedule {
 	t.Helper()
 	testTracer := tracing.InitializeTracerForTest()
@@ -0,0 +1,46 @@
+package sharding
+
+import (
+	"context"
+	"time"
+)
+
+// ReplicaStore stores the heartbeats of the replicas in the database.
+type ReplicaStore interface {
+	HeartbeatSchedulerReplica(ctx context.Context, name string, at time.Time) error
+	ListSchedulerReplicas(ctx context.Context, since time.Time) ([]string, error)
+	DeleteSchedulerReplica(ctx context.Context, name string) error
+	DeleteExpiredSchedulerReplicas(ctx context.Context, before time.Time) error
+}
+
+// DatabaseMembership keeps track of the replicas in the database. A replica is alive until it has not
+// sent a heartbeat for the timeout.
+type DatabaseMembership struct {
+	store   ReplicaStore
+	timeout time.Duration
+	now     func() time.Time
+}
+
+func NewDatabaseMembership(store ReplicaStore, timeout time.Duration) *DatabaseMembership {
+	return &DatabaseMembership{
+		store:   store,
+		timeout: timeout,
+		now:     time.Now,
+	}
+}
+
+func (m *DatabaseMembership) Heartbeat(ctx context.Context, replica string) error {
+	return m.store.HeartbeatSchedulerReplica(ctx, replica, m.now())
+}
+
+// Members returns the replicas that are alive and deletes the replicas that expired.
+func (m *DatabaseMembership) Members(ctx context.Context) ([]string, error) {
+	since := m.now().Add(-m.timeout)
+	// Expired replicas are deleted by every replica, there is no need to retry.
+	_ = m.store.DeleteExpiredSchedulerReplicas(ctx, since)
+	return m.store.ListSchedulerReplicas(ctx, since)
+}
+
+func (m *DatabaseMembership) Leave(ctx context.Context, replica string) error {
+	return m.store.DeleteSchedulerReplica(ctx, replica)
+}
@@ -0,0 +1,75 @@
+package sharding
+
+import (
+	"context"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+type fakeReplicaStore struct {
+	heartbeats map[string]time.Time
+}
+
+func (s *fakeReplicaStore) HeartbeatSchedulerReplica(_ context.Context, name string, at time.Time) error {
+	s.heartbeats[name] = at
+	return nil
+}
+
+func (s *fakeReplicaStore) ListSchedulerReplicas(_ context.Context, since time.Time) ([]string, error) {
+	var names []string
+	for name, at := range s.heartbeats {
+		if !at.Before(since) {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+	return names, nil
+}
+
+func (s *fakeReplicaStore) DeleteSchedulerReplica(_ context.Context, name string) error {
+	delete(s.heartbeats, name)
+	return nil
+}
+
+func (s *fakeReplicaStore) DeleteExpiredSchedulerReplicas(_ context.Context, before time.Time) error {
+	for name, at := range s.heartbeats {
+		if at.Before(before) {
+			delete(s.heartbeats, name)
+		}
+	}
+	return nil
+}
+
+func TestDatabaseMembership(t *testing.T) {
+	store := &fakeReplicaStore{heartbeats: map[string]time.Time{}}
+	now := time.Unix(1000, 0)
+	m := NewDatabaseMembership(store, 30*time.Second)
+	m.now = func() time.Time { return now }
+	ctx := context.Background()
+
+	require.NoError(t, m.Heartbeat(ctx, "a"))
+	now = now.Add(20 * time.Second)
+	require.NoError(t, m.Heartbeat(ctx, "b"))
+
+	members, err := m.Members(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, members)
+
+	t.Run("should delete the replicas whose heartbeat expired", func(t *testing.T) {
+		now = now.Add(20 * time.Second)
+		members, err := m.Members(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{"b"}, members)
+		require.NotContains(t, store.heartbeats, "a")
+	})
+
+	t.Run("should remove the replica when it leaves", func(t *testing.T) {
+		require.NoError(t, m.Leave(ctx, "b"))
+		members, err := m.Members(ctx)
+		require.NoError(t, err)
+		require.Empty(t, members)
+	})
+}
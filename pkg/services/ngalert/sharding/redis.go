@@ -0,0 +1,104 @@
+package sharding
+
+import (
+	"context"
+	"crypto/tls"
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	dstls "github.com/grafana/dskit/crypto/tls"
+	"github.com/redis/go-redis/v9"
+)
+
+// replicaKeyPrefix is the prefix of the keys of the replicas in Redis, after the configured prefix.
+const replicaKeyPrefix = "scheduler:replica:"
+
+type RedisConfig struct {
+	Addr        string
+	Username    string
+	Password    string
+	DB          int
+	Prefix      string
+	MaxConns    int
+	ClusterMode bool
+
+	TLSEnabled bool
+	TLS        dstls.ClientConfig
+}
+
+// RedisMembership keeps track of the replicas in Redis. Every replica has a key that expires if it has not
+// sent a heartbeat for the timeout.
+type RedisMembership struct {
+	redis   redis.UniversalClient
+	prefix  string
+	timeout time.Duration
+}
+
+func NewRedisMembership(cfg RedisConfig, timeout time.Duration) (*RedisMembership, error) {
+	var tlsClientConfig *tls.Config
+	if cfg.TLSEnabled {
+		var err error
+		tlsClientConfig, err = cfg.TLS.GetTLSConfig()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get TLS config: %w", err)
+		}
+	}
+	opts := &redis.UniversalOptions{
+		Addrs:     strings.Split(cfg.Addr, ","),
+		Username:  cfg.Username,
+		Password:  cfg.Password,
+		DB:        cfg.DB,
+		PoolSize:  cfg.MaxConns,
+		TLSConfig: tlsClientConfig,
+	}
+	var rdb redis.UniversalClient
+	if cfg.ClusterMode {
+		rdb = redis.NewClusterClient(opts.Cluster())
+	} else {
+		rdb = redis.NewClient(opts.Simple())
+	}
+	return newRedisMembership(rdb, cfg.Prefix, timeout), nil
+}
+
+func newRedisMembership(rdb redis.UniversalClient, prefix string, timeout time.Duration) *RedisMembership {
+	// Make sure that the prefix uses a colon at the end as delimiter, like the keys of the Alertmanager peers.
+	if prefix != "" && !strings.HasSuffix(prefix, ":") {
+		prefix += ":"
+	}
+	return &RedisMembership{
+		redis:   rdb,
+		prefix:  prefix + replicaKeyPrefix,
+		timeout: timeout,
+	}
+}
+
+func (m *RedisMembership) Heartbeat(ctx context.Context, replica string) error {
+	return m.redis.Set(ctx, m.prefix+replica, time.Now().Unix(), m.timeout).Err()
+}
+
+func (m *RedisMembership) Members(ctx context.Context) ([]string, error) {
+	var members []string
+	var cursor uint64
+	for {
+		keys, next, err := m.redis.Scan(ctx, cursor, m.prefix+"*", 100).Result()
+		if err != nil {
+			return nil, err
+		}
+		for _, key := range keys {
+			members = append(members, strings.TrimPrefix(key, m.prefix))
+		}
+		cursor = next
+		if cursor == 0 {
+			break
+		}
+	}
+	// Scan may return a key more than once.
+	slices.Sort(members)
+	return slices.Compact(members), nil
+}
+
+func (m *RedisMembership) Leave(ctx context.Context, replica string) error {
+	return m.redis.Del(ctx, m.prefix+replica).Err()
+}
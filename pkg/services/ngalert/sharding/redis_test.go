@@ -0,0 +1,45 @@
+package sharding
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/redis/go-redis/v9"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRedisMembership(t *testing.T) {
+	mr, err := miniredis.Run()
+	require.NoError(t, err)
+	defer mr.Close()
+
+	rdb := redis.NewClient(&redis.Options{
+		Addr: mr.Addr(),
+	})
+	ctx := context.Background()
+	m := newRedisMembership(rdb, "grafana", 30*time.Second)
+
+	require.NoError(t, m.Heartbeat(ctx, "b"))
+	require.NoError(t, m.Heartbeat(ctx, "a"))
+	require.True(t, mr.Exists("grafana:scheduler:replica:a"))
+
+	members, err := m.Members(ctx)
+	require.NoError(t, err)
+	require.Equal(t, []string{"a", "b"}, members)
+
+	t.Run("should remove the replica when it leaves", func(t *testing.T) {
+		require.NoError(t, m.Leave(ctx, "b"))
+		members, err := m.Members(ctx)
+		require.NoError(t, err)
+		require.Equal(t, []string{"a"}, members)
+	})
+
+	t.Run("should remove the replica when its heartbeat expires", func(t *testing.T) {
+		mr.FastForward(31 * time.Second)
+		members, err := m.Members(ctx)
+		require.NoError(t, err)
+		require.Empty(t, members)
+	})
+}
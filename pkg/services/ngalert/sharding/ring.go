@@ -0,0 +1,78 @@
+package sharding
+
+import (
+	"hash/fnv"
+	"slices"
+	"sort"
+	"strconv"
+)
+
+// tokensPerReplica is the number of points of the ring owned by every replica. Many points per replica
+// spread the keys evenly across the replicas.
+const tokensPerReplica = 128
+
+type token struct {
+	hash    uint64
+	replica string
+}
+
+// Ring assigns keys to replicas by consistent hashing. A key belongs to the replica that owns the first
+// point of the ring at or after the hash of the key, so that only the keys of a replica are moved to
+// other replicas when it leaves, and a joining replica only takes over keys from the other replicas.
+type Ring struct {
+	replicas []string
+	tokens   []token
+}
+
+// NewRing returns a ring of the given replicas.
+func NewRing(replicas []string) *Ring {
+	r := &Ring{
+		replicas: slices.Compact(slices.Sorted(slices.Values(replicas))),
+	}
+	r.tokens = make([]token, 0, len(r.replicas)*tokensPerReplica)
+	for _, replica := range r.replicas {
+		for i := 0; i < tokensPerReplica; i++ {
+			r.tokens = append(r.tokens, token{hash: hash(replica + "-" + strconv.Itoa(i)), replica: replica})
+		}
+	}
+	sort.Slice(r.tokens, func(i, j int) bool {
+		if r.tokens[i].hash == r.tokens[j].hash {
+			return r.tokens[i].replica < r.tokens[j].replica
+		}
+		return r.tokens[i].hash < r.tokens[j].hash
+	})
+	return r
+}
+
+// Owner returns the replica that owns the key, or an empty string if the ring has no replicas.
+func (r *Ring) Owner(key string) string {
+	if len(r.tokens) == 0 {
+		return ""
+	}
+	h := hash(key)
+	i := sort.Search(len(r.tokens), func(i int) bool {
+		return r.tokens[i].hash >= h
+	})
+	if i == len(r.tokens) {
+		i = 0
+	}
+	return r.tokens[i].replica
+}
+
+// Replicas returns the sorted names of the replicas of the ring.
+func (r *Ring) Replicas() []string {
+	return r.replicas
+}
+
+func hash(s string) uint64 {
+	h := fnv.New64a()
+	_, _ = h.Write([]byte(s))
+	// fnv does not spread similar strings well, so the hash is mixed with the finalizer of splitmix64.
+	x := h.Sum64()
+	x ^= x >> 30
+	x *= 0xbf58476d1ce4e5b9
+	x ^= x >> 27
+	x *= 0x94d049bb133111eb
+	x ^= x >> 31
+	return x
+}
@@ -0,0 +1,65 @@
+package sharding
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRing(t *testing.T) {
+	keys := make([]string, 0, 10000)
+	for i := 0; i < cap(keys); i++ {
+		keys = append(keys, fmt.Sprintf("org=1, uid=rule-%d", i))
+	}
+
+	t.Run("empty ring should not own keys", func(t *testing.T) {
+		assert.Empty(t, NewRing(nil).Owner("key"))
+	})
+
+	t.Run("should not depend on the order of the replicas", func(t *testing.T) {
+		r1 := NewRing([]string{"a", "b", "c"})
+		r2 := NewRing([]string{"c", "a", "b", "a"})
+		require.Equal(t, []string{"a", "b", "c"}, r2.Replicas())
+		for _, key := range keys {
+			require.Equal(t, r1.Owner(key), r2.Owner(key))
+		}
+	})
+
+	t.Run("should spread keys evenly", func(t *testing.T) {
+		replicas := []string{"replica-1", "replica-2", "replica-3", "replica-4"}
+		ring := NewRing(replicas)
+		counts := map[string]int{}
+		for _, key := range keys {
+			counts[ring.Owner(key)]++
+		}
+		require.Len(t, counts, len(replicas))
+		for replica, count := range counts {
+			assert.InDeltaf(t, len(keys)/len(replicas), count, float64(len(keys))*0.1, "replica %s owns %d keys", replica, count)
+		}
+	})
+
+	t.Run("should only move the keys of the replica that leaves", func(t *testing.T) {
+		before := NewRing([]string{"replica-1", "replica-2", "replica-3"})
+		after := NewRing([]string{"replica-1", "replica-3"})
+		for _, key := range keys {
+			if owner := before.Owner(key); owner != "replica-2" {
+				require.Equal(t, owner, after.Owner(key))
+			}
+		}
+	})
+
+	t.Run("should only move keys to the replica that joins", func(t *testing.T) {
+		before := NewRing([]string{"replica-1", "replica-2"})
+		after := NewRing([]string{"replica-1", "replica-2", "replica-3"})
+		moved := 0
+		for _, key := range keys {
+			if owner := after.Owner(key); owner != before.Owner(key) {
+				require.Equal(t, "replica-3", owner)
+				moved++
+			}
+		}
+		assert.Positive(t, moved)
+	})
+}
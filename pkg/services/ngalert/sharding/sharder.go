@@ -0,0 +1,139 @@
+package sharding
+
+import (
+	"context"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+)
+
+// Membership keeps track of the replicas that the alert rules are sharded across.
+type Membership interface {
+	// Heartbeat announces that the replica is alive.
+	Heartbeat(ctx context.Context, replica string) error
+	// Members returns the names of the replicas that are alive.
+	Members(ctx context.Context) ([]string, error)
+	// Leave removes the replica, so that the other replicas take over its rules without waiting for its heartbeat to expire.
+	Leave(ctx context.Context, replica string) error
+}
+
+// Sharder decides which alert rules are evaluated by this replica. The rules are assigned to the replicas
+// that are alive by consistent hashing, so that each rule is evaluated by exactly one replica.
+type Sharder struct {
+	name       string
+	membership Membership
+	interval   time.Duration
+	timeout    time.Duration
+	replicas   prometheus.Gauge
+	log        log.Logger
+	now        func() time.Time
+
+	// lastHeartbeat is the time of the last successful heartbeat, or the time the sharder was created.
+	lastHeartbeat time.Time
+
+	mtx  sync.RWMutex
+	ring *Ring
+	// expired is true if the heartbeat of this replica has not been sent for longer than the timeout, so that
+	// the other replicas have taken over its rules.
+	expired bool
+}
+
+// NewSharder returns a sharder for the replica with the given name that sends a heartbeat and updates the replicas at every interval.
+// The replica stops owning rules if it cannot send a heartbeat for the timeout, after which the other replicas consider it gone.
+func NewSharder(name string, membership Membership, interval, timeout time.Duration, replicas prometheus.Gauge, logger log.Logger) *Sharder {
+	return &Sharder{
+		name:          name,
+		membership:    membership,
+		interval:      interval,
+		timeout:       timeout,
+		replicas:      replicas,
+		log:           logger.New("replica", name),
+		now:           time.Now,
+		lastHeartbeat: time.Now(),
+	}
+}
+
+// Run keeps the replicas up to date until the context is canceled, and then leaves the ring.
+func (s *Sharder) Run(ctx context.Context) error {
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ticker.C:
+			s.Sync(ctx)
+		case <-ctx.Done():
+			leaveCtx, cancel := context.WithTimeout(context.Background(), s.interval)
+			defer cancel()
+			if err := s.membership.Leave(leaveCtx, s.name); err != nil {
+				s.log.Warn("Failed to leave the ring of the scheduler", "error", err)
+			}
+			return nil
+		}
+	}
+}
+
+// Sync sends a heartbeat and updates the replicas of the ring. If the replicas cannot be fetched, the previous ring is kept.
+func (s *Sharder) Sync(ctx context.Context) {
+	if err := s.membership.Heartbeat(ctx, s.name); err != nil {
+		s.log.Error("Failed to send the heartbeat of the scheduler", "error", err)
+		if s.now().Sub(s.lastHeartbeat) >= s.timeout {
+			s.setExpired(true)
+		}
+	} else {
+		s.lastHeartbeat = s.now()
+		s.setExpired(false)
+	}
+	members, err := s.membership.Members(ctx)
+	if err != nil {
+		s.log.Error("Failed to fetch the replicas of the scheduler, keeping the previous replicas", "error", err)
+		return
+	}
+	// This replica evaluates rules even if its heartbeat is not visible to itself yet.
+	if !slices.Contains(members, s.name) {
+		members = append(members, s.name)
+	}
+	ring := NewRing(members)
+
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.ring != nil && slices.Equal(s.ring.Replicas(), ring.Replicas()) {
+		return
+	}
+	s.log.Info("Replicas of the scheduler changed, rebalancing the alert rules", "replicas", ring.Replicas())
+	s.ring = ring
+	if s.replicas != nil {
+		s.replicas.Set(float64(len(ring.Replicas())))
+	}
+}
+
+func (s *Sharder) setExpired(expired bool) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	if s.expired == expired {
+		return
+	}
+	if expired {
+		s.log.Warn("The heartbeat of the scheduler expired, not evaluating alert rules until it is sent again", "lastHeartbeat", s.lastHeartbeat)
+	} else {
+		s.log.Info("The heartbeat of the scheduler was sent again, evaluating alert rules")
+	}
+	s.expired = expired
+}
+
+// Owns returns true if the key belongs to this replica. All keys belong to this replica until the replicas are known,
+// and no keys belong to it while its heartbeat is expired.
+func (s *Sharder) Owns(key string) bool {
+	s.mtx.RLock()
+	defer s.mtx.RUnlock()
+	if s.expired {
+		return false
+	}
+	if s.ring == nil {
+		return true
+	}
+	return s.ring.Owner(key) == s.name
+}
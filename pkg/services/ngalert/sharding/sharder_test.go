@@ -0,0 +1,159 @@
+package sharding
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+)
+
+type fakeMembership struct {
+	mtx     sync.Mutex
+	members map[string]struct{}
+	err     error
+}
+
+func newFakeMembership(members ...string) *fakeMembership {
+	m := &fakeMembership{members: make(map[string]struct{})}
+	for _, member := range members {
+		m.members[member] = struct{}{}
+	}
+	return m
+}
+
+func (m *fakeMembership) Heartbeat(_ context.Context, replica string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if m.err != nil {
+		return m.err
+	}
+	m.members[replica] = struct{}{}
+	return nil
+}
+
+func (m *fakeMembership) Members(_ context.Context) ([]string, error) {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	if m.err != nil {
+		return nil, m.err
+	}
+	members := make([]string, 0, len(m.members))
+	for member := range m.members {
+		members = append(members, member)
+	}
+	return members, nil
+}
+
+func (m *fakeMembership) Leave(_ context.Context, replica string) error {
+	m.mtx.Lock()
+	defer m.mtx.Unlock()
+	delete(m.members, replica)
+	return nil
+}
+
+func TestSharder(t *testing.T) {
+	t.Run("should own all keys until the replicas are known", func(t *testing.T) {
+		s := NewSharder("a", newFakeMembership(), time.Second, time.Minute, nil, log.NewNopLogger())
+		require.True(t, s.Owns("key-1"))
+		require.True(t, s.Owns("key-2"))
+	})
+
+	t.Run("should own only the keys of its replica", func(t *testing.T) {
+		membership := newFakeMembership("b", "c")
+		gauge := prometheus.NewGauge(prometheus.GaugeOpts{Name: "replicas"})
+		a := NewSharder("a", membership, time.Second, time.Minute, gauge, log.NewNopLogger())
+		a.Sync(context.Background())
+		require.Equal(t, 3.0, testutil.ToFloat64(gauge))
+
+		ring := NewRing([]string{"a", "b", "c"})
+		for _, key := range []string{"key-1", "key-2", "key-3", "key-4", "key-5", "key-6"} {
+			require.Equal(t, ring.Owner(key) == "a", a.Owns(key))
+		}
+	})
+
+	t.Run("should include itself if its heartbeat failed", func(t *testing.T) {
+		membership := newFakeMembership()
+		a := NewSharder("a", membership, time.Second, time.Minute, nil, log.NewNopLogger())
+		membership.err = errors.New("unavailable")
+		a.Sync(context.Background())
+		require.True(t, a.Owns("key-1"))
+
+		membership.err = nil
+		membership.members["b"] = struct{}{}
+		a.Sync(context.Background())
+		require.Equal(t, []string{"a", "b"}, a.ring.Replicas())
+	})
+
+	t.Run("should not own keys while its heartbeat is expired", func(t *testing.T) {
+		membership := newFakeMembership("b")
+		a := NewSharder("a", membership, time.Second, time.Minute, nil, log.NewNopLogger())
+		now := time.Now()
+		a.now = func() time.Time { return now }
+		a.Sync(context.Background())
+		require.True(t, a.Owns(ownedKey(t, "a", "b")))
+
+		membership.err = errors.New("unavailable")
+		now = now.Add(30 * time.Second)
+		a.Sync(context.Background())
+		require.True(t, a.Owns(ownedKey(t, "a", "b")), "the heartbeat has not expired yet")
+
+		now = now.Add(30 * time.Second)
+		a.Sync(context.Background())
+		require.False(t, a.Owns(ownedKey(t, "a", "b")))
+
+		membership.err = nil
+		a.Sync(context.Background())
+		require.True(t, a.Owns(ownedKey(t, "a", "b")))
+	})
+
+	t.Run("should keep the replicas if they cannot be fetched", func(t *testing.T) {
+		membership := newFakeMembership("b")
+		a := NewSharder("a", membership, time.Second, time.Minute, nil, log.NewNopLogger())
+		a.Sync(context.Background())
+		membership.err = errors.New("unavailable")
+		a.Sync(context.Background())
+		require.Equal(t, []string{"a", "b"}, a.ring.Replicas())
+	})
+
+	t.Run("should leave when it stops", func(t *testing.T) {
+		membership := newFakeMembership("b")
+		a := NewSharder("a", membership, time.Millisecond, time.Minute, nil, log.NewNopLogger())
+		ctx, cancel := context.WithCancel(context.Background())
+		done := make(chan struct{})
+		go func() {
+			defer close(done)
+			require.NoError(t, a.Run(ctx))
+		}()
+		require.Eventually(t, func() bool {
+			members, _ := membership.Members(context.Background())
+			return len(members) == 2
+		}, time.Second, time.Millisecond)
+		cancel()
+		<-done
+		members, err := membership.Members(context.Background())
+		require.NoError(t, err)
+		require.Equal(t, []string{"b"}, members)
+	})
+}
+
+// ownedKey returns a key that the replica owns in the ring of the replicas.
+func ownedKey(t *testing.T, replica string, replicas ...string) string {
+	t.Helper()
+	ring := NewRing(append(replicas, replica))
+	for i := 0; i < 100; i++ {
+		key := fmt.Sprintf("key-%d", i)
+		if ring.Owner(key) == replica {
+			return key
+		}
+	}
+	t.Fatalf("no key is owned by replica %s", replica)
+	return ""
+}
@@ -165,6 +165,7 @@ func (c *cache) create(ctx context.Context, log log.Logger, alertRule *ngModels.
 		StartsAt:             result.EvaluatedAt,
 		EndsAt:               result.EvaluatedAt,
 		ResolvedAt:           nil,
+		KeepFiringSince:      nil,
 		LastSentAt:           nil,
 		LastEvaluationString: "",
 		LastEvaluationTime:   result.EvaluatedAt,
@@ -187,6 +188,7 @@ func (c *cache) create(ctx context.Context, log log.Logger, alertRule *ngModels.
 	newState.StartsAt = existingState.StartsAt
 	newState.EndsAt = existingState.EndsAt
 	newState.ResolvedAt = existingState.ResolvedAt
+	newState.KeepFiringSince = existingState.KeepFiringSince
 	newState.LastSentAt = existingState.LastSentAt
 	// Annotations can change over time, however we also want to maintain
 	// certain annotations across evaluations
@@ -363,32 +365,63 @@ func (c *cache) GetAlertInstances(skipNormalState bool) []ngModels.AlertInstance
 	defer c.mtxStates.RUnlock()
 	for _, orgStates := range c.states {
 		for _, v1 := range orgStates {
-			for _, v2 := range v1.states {
-				if skipNormalState && IsNormalStateWithNoReason(v2) {
-					continue
-				}
-				key, err := v2.GetAlertInstanceKey()
-				if err != nil {
-					continue
-				}
-				states = append(states, ngModels.AlertInstance{
-					AlertInstanceKey:  key,
-					Labels:            ngModels.InstanceLabels(v2.Labels),
-					CurrentState:      ngModels.InstanceStateType(v2.State.String()),
-					CurrentReason:     v2.StateReason,
-					LastEvalTime:      v2.LastEvaluationTime,
-					CurrentStateSince: v2.StartsAt,
-					CurrentStateEnd:   v2.EndsAt,
-					ResolvedAt:        v2.ResolvedAt,
-					LastSentAt:        v2.LastSentAt,
-					ResultFingerprint: v2.ResultFingerprint.String(),
-				})
-			}
+			states = append(states, v1.alertInstances(skipNormalState)...)
 		}
 	}
 	return states
 }
 
+// GetAlertInstancesByRule returns the whole content of the cache as AlertInstances by rule.
+// Rules without instances to save are included with no instances.
+func (c *cache) GetAlertInstancesByRule(skipNormalState bool) map[ngModels.AlertRuleKey][]ngModels.AlertInstance {
+	c.mtxStates.RLock()
+	defer c.mtxStates.RUnlock()
+	result := make(map[ngModels.AlertRuleKey][]ngModels.AlertInstance)
+	for orgID, orgStates := range c.states {
+		for ruleUID, v1 := range orgStates {
+			result[ngModels.AlertRuleKey{OrgID: orgID, UID: ruleUID}] = v1.alertInstances(skipNormalState)
+		}
+	}
+	return result
+}
+
+// getAlertInstancesForRule returns the states of the rule as AlertInstances.
+func (c *cache) getAlertInstancesForRule(orgID int64, alertRuleUID string, skipNormalState bool) []ngModels.AlertInstance {
+	c.mtxStates.RLock()
+	defer c.mtxStates.RUnlock()
+	rs, ok := c.states[orgID][alertRuleUID]
+	if !ok {
+		return nil
+	}
+	return rs.alertInstances(skipNormalState)
+}
+
+func (rs *ruleStates) alertInstances(skipNormalState bool) []ngModels.AlertInstance {
+	instances := make([]ngModels.AlertInstance, 0, len(rs.states))
+	for _, v2 := range rs.states {
+		if skipNormalState && IsNormalStateWithNoReason(v2) {
+			continue
+		}
+		key, err := v2.GetAlertInstanceKey()
+		if err != nil {
+			continue
+		}
+		instances = append(instances, ngModels.AlertInstance{
+			AlertInstanceKey:  key,
+			Labels:            ngModels.InstanceLabels(v2.Labels),
+			CurrentState:      ngModels.InstanceStateType(v2.State.String()),
+			CurrentReason:     v2.StateReason,
+			LastEvalTime:      v2.LastEvaluationTime,
+			CurrentStateSince: v2.StartsAt,
+			CurrentStateEnd:   v2.EndsAt,
+			ResolvedAt:        v2.ResolvedAt,
+			LastSentAt:        v2.LastSentAt,
+			ResultFingerprint: v2.ResultFingerprint.String(),
+		})
+	}
+	return instances
+}
+
 // if duplicate labels exist, keep the value from the first set
 func mergeLabels(a, b data.Labels) data.Labels {
 	newLbs := make(data.Labels, len(a)+len(b))
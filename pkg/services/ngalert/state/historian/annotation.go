@@ -114,6 +114,9 @@ func (h *AnnotationBackend) Query(ctx context.Context, query ngmodels.HistoryQue
 	if query.RuleUID == "" {
 		return nil, fmt.Errorf("ruleUID is required to query annotations")
 	}
+	if query.Offset > 0 {
+		return nil, NewErrOffsetNotSupported(BackendTypeAnnotations)
+	}
 
 	if query.Labels != nil {
 		logger.Warn("Annotation state history backend does not support label queries, ignoring that filter")
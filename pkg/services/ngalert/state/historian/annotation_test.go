@@ -97,6 +97,21 @@ func TestAnnotationHistorian(t *testing.T) {
 		require.Equal(t, now.Add(-10*time.Second).UnixMilli(), query.From)
 	})
 
+	t.Run("annotation queries reject offset", func(t *testing.T) {
+		store := &interceptingAnnotationStore{}
+		anns := createTestAnnotationSutWithStore(t, store)
+
+		q := models.HistoryQuery{
+			RuleUID: "my-rule",
+			OrgID:   1,
+			Offset:  10,
+		}
+		_, err := anns.Query(context.Background(), q)
+
+		require.ErrorIs(t, err, ErrOffsetNotSupported)
+		require.Nil(t, store.lastQuery)
+	})
+
 	t.Run("writing state transitions as annotations succeeds", func(t *testing.T) {
 		anns := createTestAnnotationBackendSut(t)
 		rule := createTestRule()
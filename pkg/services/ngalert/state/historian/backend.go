@@ -3,8 +3,24 @@ package historian
 import (
 	"fmt"
 	"strings"
+
+	"github.com/grafana/grafana/pkg/apimachinery/errutil"
+)
+
+// ErrOffsetNotSupported is returned by backends that cannot skip records of the history.
+var ErrOffsetNotSupported = errutil.BadRequest("alerting.stateHistory.offsetNotSupported").MustTemplate(
+	"The {{.Public.Backend}} state history backend does not support offset",
+	errutil.WithPublic("The {{.Public.Backend}} state history backend does not support offset. Only the sql backend does."),
 )
 
+func NewErrOffsetNotSupported(backend BackendType) error {
+	return ErrOffsetNotSupported.Build(errutil.TemplateData{
+		Public: map[string]any{
+			"Backend": backend.String(),
+		},
+	})
+}
+
 // BackendType identifies different kinds of state history backends.
 type BackendType string
 
@@ -18,6 +34,7 @@ const (
 	BackendTypeLoki        BackendType = "loki"
 	BackendTypeMultiple    BackendType = "multiple"
 	BackendTypeNoop        BackendType = "noop"
+	BackendTypeSQL         BackendType = "sql"
 )
 
 func ParseBackendType(s string) (BackendType, error) {
@@ -28,6 +45,7 @@ func ParseBackendType(s string) (BackendType, error) {
 		BackendTypeLoki:        {},
 		BackendTypeMultiple:    {},
 		BackendTypeNoop:        {},
+		BackendTypeSQL:         {},
 	}
 	p := BackendType(norm)
 	if _, ok := types[p]; !ok {
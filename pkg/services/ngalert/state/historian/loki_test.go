@@ -728,6 +728,16 @@ grafana_alerting_state_history_writes_total{backend="loki",org="1"} 2
 	})
 }
 
+func TestRemoteLokiBackendQueryRejectsOffset(t *testing.T) {
+	req := NewFakeRequester()
+	loki := createTestLokiBackend(t, req, metrics.NewHistorianMetrics(prometheus.NewRegistry(), metrics.Subsystem))
+
+	_, err := loki.Query(context.Background(), models.HistoryQuery{OrgID: 1, RuleUID: "rule", Offset: 10})
+
+	require.ErrorIs(t, err, ErrOffsetNotSupported)
+	require.Nil(t, req.lastRequest)
+}
+
 func TestGetFolderUIDsForFilter(t *testing.T) {
 	orgID := int64(1)
 	rule := models.RuleGen.With(models.RuleMuts.WithNamespaceUID("folder-1")).GenerateRef()
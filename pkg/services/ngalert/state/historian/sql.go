@@ -0,0 +1,454 @@
+package historian
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"go.opentelemetry.io/otel/trace"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+	history_model "github.com/grafana/grafana/pkg/services/ngalert/state/historian/model"
+	"github.com/grafana/grafana/pkg/services/sqlstore"
+)
+
+const (
+	sqlInstanceTable = "alert_state_history_instance"
+	sqlLabelTable    = "alert_state_history_label"
+	sqlEntryTable    = "alert_state_history_entry"
+
+	// sqlCompactionInterval is how often expired transitions are deleted and the tables compacted.
+	sqlCompactionInterval = 10 * time.Minute
+	// sqlCompactionBatchSize is the maximum number of rows deleted from a table in a single transaction, so that
+	// compaction does not hold locks that block the recording of new transitions for long.
+	sqlCompactionBatchSize = 1000
+)
+
+// sqlHistoryInstance is an alert instance that has state transitions recorded in the history.
+type sqlHistoryInstance struct {
+	ID          int64  `xorm:"pk autoincr 'id'"`
+	OrgID       int64  `xorm:"org_id"`
+	RuleUID     string `xorm:"rule_uid"`
+	Fingerprint string `xorm:"fingerprint"`
+	Labels      string `xorm:"labels"`
+	CreatedAt   int64  `xorm:"created_at"`
+}
+
+// sqlHistoryLabel indexes a single label of an alert instance, so that history can be queried by labels.
+type sqlHistoryLabel struct {
+	ID         int64  `xorm:"pk autoincr 'id'"`
+	OrgID      int64  `xorm:"org_id"`
+	InstanceID int64  `xorm:"instance_id"`
+	LabelHash  string `xorm:"label_hash"`
+}
+
+// sqlHistoryEntry is a single state transition of an alert instance.
+type sqlHistoryEntry struct {
+	ID           int64  `xorm:"pk autoincr 'id'"`
+	OrgID        int64  `xorm:"org_id"`
+	InstanceID   int64  `xorm:"instance_id"`
+	RuleUID      string `xorm:"rule_uid"`
+	RuleGroup    string `xorm:"rule_group"`
+	FolderUID    string `xorm:"folder_uid"`
+	DashboardUID string `xorm:"dashboard_uid"`
+	PanelID      int64  `xorm:"panel_id"`
+	EvaluatedAt  int64  `xorm:"evaluated_at"`
+	Line         string `xorm:"line"`
+}
+
+// SQLBackend is a state.Historian that records state history to dedicated tables in the Grafana database.
+type SQLBackend struct {
+	store          db.DB
+	externalLabels map[string]string
+	retention      time.Duration
+	clock          clock.Clock
+	metrics        *metrics.Historian
+	log            log.Logger
+	ac             AccessControl
+	ruleStore      RuleStore
+	batchSize      int
+}
+
+func NewSQLBackend(logger log.Logger, store db.DB, retention time.Duration, externalLabels map[string]string, metrics *metrics.Historian, ruleStore RuleStore, ac AccessControl) *SQLBackend {
+	return &SQLBackend{
+		store:          store,
+		externalLabels: externalLabels,
+		retention:      retention,
+		clock:          clock.New(),
+		metrics:        metrics,
+		log:            logger,
+		ac:             ac,
+		ruleStore:      ruleStore,
+		batchSize:      sqlCompactionBatchSize,
+	}
+}
+
+// Record writes a number of state transitions for a given rule to the Grafana database.
+func (h *SQLBackend) Record(ctx context.Context, rule history_model.RuleMeta, states []state.StateTransition) <-chan error {
+	logger := h.log.FromContext(ctx)
+
+	entries := make([]sqlHistoryEntry, 0, len(states))
+	instances := make([]LokiEntry, 0, len(states))
+	for _, st := range states {
+		if !shouldRecord(st) {
+			continue
+		}
+
+		entry := newLokiEntry(rule, st)
+		line, err := json.Marshal(entry)
+		if err != nil {
+			logger.Error("Failed to construct history record for state, skipping", "error", err)
+			continue
+		}
+		instances = append(instances, entry)
+		entries = append(entries, sqlHistoryEntry{
+			OrgID:        rule.OrgID,
+			RuleUID:      rule.UID,
+			RuleGroup:    rule.Group,
+			FolderUID:    rule.NamespaceUID,
+			DashboardUID: rule.DashboardUID,
+			PanelID:      rule.PanelID,
+			EvaluatedAt:  st.State.LastEvaluationTime.UnixMilli(),
+			Line:         string(line),
+		})
+	}
+
+	errCh := make(chan error, 1)
+	if len(entries) == 0 {
+		close(errCh)
+		return errCh
+	}
+
+	// This is a new background job, so let's create a brand new context for it.
+	// Like the other backends, we want to flush writes even if Grafana is shutting down.
+	writeCtx := context.Background()
+	writeCtx, cancel := context.WithTimeout(writeCtx, StateHistoryWriteTimeout)
+	writeCtx = history_model.WithRuleData(writeCtx, rule)
+	writeCtx = trace.ContextWithSpan(writeCtx, trace.SpanFromContext(ctx))
+
+	go func(ctx context.Context) {
+		defer cancel()
+		defer close(errCh)
+		logger := h.log.FromContext(ctx)
+		logger.Debug("Saving state history batch", "samples", len(entries))
+		org := fmt.Sprint(rule.OrgID)
+		h.metrics.WritesTotal.WithLabelValues(org, BackendTypeSQL.String()).Inc()
+		h.metrics.TransitionsTotal.WithLabelValues(org).Add(float64(len(entries)))
+
+		if err := h.write(ctx, entries, instances); err != nil {
+			logger.Error("Failed to save alert state history batch", "error", err)
+			h.metrics.WritesFailed.WithLabelValues(org, BackendTypeSQL.String()).Inc()
+			h.metrics.TransitionsFailed.WithLabelValues(org).Add(float64(len(entries)))
+			errCh <- fmt.Errorf("failed to save alert state history batch: %w", err)
+		}
+	}(writeCtx)
+	return errCh
+}
+
+// write stores the entries. Each entry is recorded for the alert instance at the same index of instances.
+func (h *SQLBackend) write(ctx context.Context, entries []sqlHistoryEntry, instances []LokiEntry) error {
+	instanceIDs := make(map[string]int64, len(entries))
+	for i := range entries {
+		labels := instances[i].InstanceLabels
+		fingerprint := labelsFingerprint(labels)
+		id, ok := instanceIDs[fingerprint]
+		if !ok {
+			var err error
+			id, err = h.getOrCreateInstance(ctx, entries[i].OrgID, entries[i].RuleUID, fingerprint, labels)
+			if err != nil {
+				return err
+			}
+			instanceIDs[fingerprint] = id
+		}
+		entries[i].InstanceID = id
+	}
+
+	return h.store.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.BulkInsert(sqlEntryTable, entries, sqlstore.NativeSettingsForDialect(h.store.GetDialect()))
+		return err
+	})
+}
+
+// getOrCreateInstance returns the ID of the alert instance, storing the instance and its labels if it is not known yet.
+func (h *SQLBackend) getOrCreateInstance(ctx context.Context, orgID int64, ruleUID string, fingerprint string, labels map[string]string) (int64, error) {
+	find := func() (int64, bool, error) {
+		var instance sqlHistoryInstance
+		var found bool
+		err := h.store.WithDbSession(ctx, func(sess *db.Session) error {
+			var err error
+			found, err = sess.Table(sqlInstanceTable).Where("org_id = ? AND rule_uid = ? AND fingerprint = ?", orgID, ruleUID, fingerprint).Get(&instance)
+			return err
+		})
+		return instance.ID, found, err
+	}
+
+	if id, found, err := find(); err != nil || found {
+		return id, err
+	}
+
+	encoded, err := json.Marshal(labels)
+	if err != nil {
+		return 0, err
+	}
+	instance := sqlHistoryInstance{
+		OrgID:       orgID,
+		RuleUID:     ruleUID,
+		Fingerprint: fingerprint,
+		Labels:      string(encoded),
+		CreatedAt:   h.clock.Now().UnixMilli(),
+	}
+	err = h.store.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		if _, err := sess.Table(sqlInstanceTable).Insert(&instance); err != nil {
+			return err
+		}
+		rows := make([]sqlHistoryLabel, 0, len(labels))
+		for k, v := range labels {
+			rows = append(rows, sqlHistoryLabel{
+				OrgID:      orgID,
+				InstanceID: instance.ID,
+				LabelHash:  labelHash(k, v),
+			})
+		}
+		if len(rows) == 0 {
+			return nil
+		}
+		_, err := sess.BulkInsert(sqlLabelTable, rows, sqlstore.NativeSettingsForDialect(h.store.GetDialect()))
+		return err
+	})
+	if err != nil {
+		// Another replica might have stored the same instance in the meantime.
+		if id, found, findErr := find(); findErr == nil && found {
+			return id, nil
+		}
+		return 0, fmt.Errorf("failed to save alert instance: %w", err)
+	}
+	return instance.ID, nil
+}
+
+// Query retrieves state history entries from the Grafana database and formats the results into a dataframe.
+// The most recent entries are selected first, so that limit and offset page backwards through the history.
+func (h *SQLBackend) Query(ctx context.Context, query models.HistoryQuery) (*data.Frame, error) {
+	uids, err := getFolderUIDsForFilter(ctx, h.ac, h.ruleStore, query)
+	if err != nil {
+		return nil, err
+	}
+
+	now := h.clock.Now().UTC()
+	if query.To.IsZero() {
+		query.To = now
+	}
+	if query.From.IsZero() {
+		query.From = now.Add(-defaultQueryRange)
+	}
+	limit := query.Limit
+	if limit < 1 {
+		limit = defaultPageSize
+	}
+	if limit > maximumPageSize {
+		limit = maximumPageSize
+	}
+	offset := query.Offset
+	if offset < 0 {
+		offset = 0
+	}
+
+	var rows []sqlHistoryEntry
+	err = h.store.WithDbSession(ctx, func(sess *db.Session) error {
+		q := sess.Table(sqlEntryTable).
+			Where("org_id = ?", query.OrgID).
+			And("evaluated_at >= ?", query.From.UnixMilli()).
+			And("evaluated_at <= ?", query.To.UnixMilli())
+		if query.RuleUID != "" {
+			q = q.And("rule_uid = ?", query.RuleUID)
+		}
+		if query.DashboardUID != "" {
+			q = q.And("dashboard_uid = ?", query.DashboardUID)
+		}
+		if query.PanelID != 0 {
+			q = q.And("panel_id = ?", query.PanelID)
+		}
+		if len(uids) > 0 {
+			q = q.In("folder_uid", uids)
+		}
+
+		keys := make([]string, 0, len(query.Labels))
+		for k := range query.Labels {
+			keys = append(keys, k)
+		}
+		sort.Strings(keys)
+		for _, k := range keys {
+			q = q.And("instance_id IN (SELECT instance_id FROM "+sqlLabelTable+" WHERE org_id = ? AND label_hash = ?)", query.OrgID, labelHash(k, query.Labels[k]))
+		}
+
+		return q.Desc("evaluated_at", "id").Limit(limit, offset).Find(&rows)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query state history: %w", err)
+	}
+
+	return h.toFrame(rows)
+}
+
+// toFrame converts the entries, sorted from newest to oldest, to the same dataframe as returned by the Loki backend.
+func (h *SQLBackend) toFrame(rows []sqlHistoryEntry) (*data.Frame, error) {
+	lbls := data.Labels(map[string]string{})
+	times := make([]time.Time, 0, len(rows))
+	lines := make([]json.RawMessage, 0, len(rows))
+	labels := make([]json.RawMessage, 0, len(rows))
+
+	for i := len(rows) - 1; i >= 0; i-- {
+		row := rows[i]
+		streamLbls := mergeLabels(make(map[string]string), h.externalLabels)
+		streamLbls[StateHistoryLabelKey] = StateHistoryLabelValue
+		streamLbls[OrgIDLabel] = fmt.Sprint(row.OrgID)
+		streamLbls[GroupLabel] = row.RuleGroup
+		streamLbls[FolderUIDLabel] = row.FolderUID
+		lblsJson, err := json.Marshal(streamLbls)
+		if err != nil {
+			return nil, fmt.Errorf("failed to serialize stream labels: %w", err)
+		}
+		line, err := jsonifyRow(row.Line)
+		if err != nil {
+			return nil, fmt.Errorf("a line was in an invalid format: %w", err)
+		}
+
+		times = append(times, time.UnixMilli(row.EvaluatedAt))
+		lines = append(lines, line)
+		labels = append(labels, lblsJson)
+	}
+
+	frame := data.NewFrame("states")
+	frame.Fields = append(frame.Fields, data.NewField(dfTime, lbls, times))
+	frame.Fields = append(frame.Fields, data.NewField(dfLine, lbls, lines))
+	frame.Fields = append(frame.Fields, data.NewField(dfLabels, lbls, labels))
+	return frame, nil
+}
+
+// Run periodically applies the retention and compacts the history tables until the context is cancelled.
+func (h *SQLBackend) Run(ctx context.Context) error {
+	if h.retention <= 0 {
+		return nil
+	}
+
+	ticker := h.clock.Ticker(sqlCompactionInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			n, err := h.Compact(ctx)
+			if err != nil {
+				h.log.Error("Failed to compact state history", "error", err)
+				continue
+			}
+			h.log.Debug("Compacted state history", "deleted", n)
+		}
+	}
+}
+
+// Compact deletes the state transitions that are older than the retention, and then the alert instances and labels
+// that are no longer used. Rows are deleted in batches ordered by ID, each in its own transaction, so that writers
+// are not blocked until the whole backlog is deleted. It returns the number of deleted transitions.
+func (h *SQLBackend) Compact(ctx context.Context) (int64, error) {
+	cutoff := h.clock.Now().Add(-h.retention).UnixMilli()
+
+	var deleted int64
+	for {
+		n, err := h.deleteBatch(ctx, func(sess *db.Session) ([]int64, error) {
+			var ids []int64
+			err := sess.Table(sqlEntryTable).Cols("id").Where("evaluated_at < ?", cutoff).Asc("id").Limit(h.batchSize).Find(&ids)
+			if err != nil || len(ids) == 0 {
+				return ids, err
+			}
+			_, err = sess.Table(sqlEntryTable).In("id", ids).Delete(&sqlHistoryEntry{})
+			return ids, err
+		})
+		if err != nil {
+			return -1, fmt.Errorf("failed to delete expired state transitions: %w", err)
+		}
+		deleted += n
+		if n < int64(h.batchSize) {
+			break
+		}
+	}
+
+	for {
+		n, err := h.deleteBatch(ctx, func(sess *db.Session) ([]int64, error) {
+			// Instances created within the retention might not have their transitions written yet.
+			var ids []int64
+			err := sess.Table(sqlInstanceTable).Cols("id").
+				Where("created_at < ? AND NOT EXISTS (SELECT 1 FROM "+sqlEntryTable+" e WHERE e.instance_id = "+sqlInstanceTable+".id)", cutoff).
+				Asc("id").Limit(h.batchSize).Find(&ids)
+			if err != nil || len(ids) == 0 {
+				return ids, err
+			}
+			// Transitions might have been written for the instances since they were selected, so the condition is checked again.
+			if _, err := sess.Table(sqlInstanceTable).In("id", ids).
+				And("NOT EXISTS (SELECT 1 FROM " + sqlEntryTable + " e WHERE e.instance_id = " + sqlInstanceTable + ".id)").
+				Delete(&sqlHistoryInstance{}); err != nil {
+				return nil, err
+			}
+			_, err = sess.Table(sqlLabelTable).In("instance_id", ids).
+				And("NOT EXISTS (SELECT 1 FROM " + sqlInstanceTable + " i WHERE i.id = " + sqlLabelTable + ".instance_id)").
+				Delete(&sqlHistoryLabel{})
+			return ids, err
+		})
+		if err != nil {
+			return -1, fmt.Errorf("failed to delete unused alert instances: %w", err)
+		}
+		if n < int64(h.batchSize) {
+			break
+		}
+	}
+	return deleted, nil
+}
+
+// deleteBatch runs a single batch of a compaction in a transaction. The batch returns the IDs of the deleted rows.
+func (h *SQLBackend) deleteBatch(ctx context.Context, batch func(sess *db.Session) ([]int64, error)) (int64, error) {
+	var n int64
+	err := h.store.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		ids, err := batch(sess)
+		n = int64(len(ids))
+		return err
+	})
+	return n, err
+}
+
+// labelHash identifies a label pair in the label table. Hashes keep the index small regardless of the length of label values.
+// A cryptographic hash is used so that different label pairs never share a hash in practice.
+func labelHash(name, value string) string {
+	h := sha256.New()
+	_, _ = h.Write([]byte(name))
+	_, _ = h.Write([]byte{0xff})
+	_, _ = h.Write([]byte(value))
+	return hex.EncodeToString(h.Sum(nil))
+}
+
+// labelsFingerprint identifies an alert instance in the instance table. Unlike the 64-bit fingerprint of the Loki backend,
+// it is a cryptographic hash of the full label set, so that instances with different labels are never stored as one.
+func labelsFingerprint(labels map[string]string) string {
+	keys := make([]string, 0, len(labels))
+	for k := range labels {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	h := sha256.New()
+	for _, k := range keys {
+		_, _ = h.Write([]byte(k))
+		_, _ = h.Write([]byte{0xff})
+		_, _ = h.Write([]byte(labels[k]))
+		_, _ = h.Write([]byte{0xff})
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
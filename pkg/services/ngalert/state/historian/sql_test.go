@@ -0,0 +1,290 @@
+package historian
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/apimachinery/identity"
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/folder"
+	acfakes "github.com/grafana/grafana/pkg/services/ngalert/accesscontrol/fakes"
+	"github.com/grafana/grafana/pkg/services/ngalert/eval"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/ngalert/state"
+	history_model "github.com/grafana/grafana/pkg/services/ngalert/state/historian/model"
+	"github.com/grafana/grafana/pkg/services/ngalert/tests/fakes"
+	"github.com/grafana/grafana/pkg/tests/testsuite"
+)
+
+func TestMain(m *testing.M) {
+	testsuite.Run(m)
+}
+
+func TestIntegrationSQLBackend(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	ctx := context.Background()
+	store := db.InitTestDB(t)
+	clk := clock.NewMock()
+	start := time.Now().Truncate(time.Millisecond)
+	clk.Set(start)
+
+	ac := &acfakes.FakeRuleService{}
+	ac.CanReadAllRulesFunc = func(ctx context.Context, user identity.Requester) (bool, error) {
+		return true, nil
+	}
+	rules := fakes.NewRuleStore(t)
+	backend := NewSQLBackend(log.NewNopLogger(), store, time.Hour, map[string]string{"externalLabelKey": "externalLabelValue"}, metrics.NewHistorianMetrics(prometheus.NewRegistry(), metrics.Subsystem), rules, ac)
+	backend.clock = clk
+
+	rule := createTestRule()
+	otherRule := createTestRule()
+	otherRule.UID = "other-rule-uid"
+	otherRule.NamespaceUID = "other-folder"
+
+	transition := func(prev, cur eval.State, at time.Time, lbls data.Labels) state.StateTransition {
+		return state.StateTransition{
+			PreviousState: prev,
+			State: &state.State{
+				State:              cur,
+				Labels:             lbls,
+				LastEvaluationTime: at,
+			},
+		}
+	}
+	record := func(t *testing.T, rule history_model.RuleMeta, states ...state.StateTransition) {
+		t.Helper()
+		require.NoError(t, <-backend.Record(ctx, rule, states))
+	}
+	entries := func(t *testing.T, frame *data.Frame) []LokiEntry {
+		t.Helper()
+		result := make([]LokiEntry, 0, frame.Rows())
+		for i := 0; i < frame.Rows(); i++ {
+			var entry LokiEntry
+			require.NoError(t, json.Unmarshal(frame.Fields[1].At(i).(json.RawMessage), &entry))
+			result = append(result, entry)
+		}
+		return result
+	}
+
+	cpu1 := data.Labels{"instance": "cpu-1", "team": "a"}
+	cpu2 := data.Labels{"instance": "cpu-2", "team": "a", "__private__": "dropped"}
+	record(t, rule,
+		transition(eval.Normal, eval.Pending, start.Add(-30*time.Minute), cpu1),
+		transition(eval.Normal, eval.Alerting, start.Add(-30*time.Minute), cpu2),
+	)
+	record(t, rule,
+		transition(eval.Pending, eval.Alerting, start.Add(-20*time.Minute), cpu1),
+		// Unchanged states are not recorded.
+		transition(eval.Alerting, eval.Alerting, start.Add(-20*time.Minute), cpu2),
+	)
+	record(t, rule, transition(eval.Alerting, eval.Normal, start.Add(-10*time.Minute), cpu1))
+	record(t, otherRule, transition(eval.Normal, eval.Alerting, start.Add(-10*time.Minute), cpu1))
+
+	t.Run("query returns all transitions in ascending order", func(t *testing.T) {
+		frame, err := backend.Query(ctx, models.HistoryQuery{OrgID: 1, From: start.Add(-time.Hour), To: start})
+		require.NoError(t, err)
+		require.Len(t, frame.Fields, 3)
+		require.Equal(t, 5, frame.Rows())
+
+		times := frame.Fields[0]
+		for i := 1; i < times.Len(); i++ {
+			require.False(t, times.At(i).(time.Time).Before(times.At(i-1).(time.Time)))
+		}
+
+		var streamLabels map[string]string
+		require.NoError(t, json.Unmarshal(frame.Fields[2].At(0).(json.RawMessage), &streamLabels))
+		require.Equal(t, map[string]string{
+			StateHistoryLabelKey: StateHistoryLabelValue,
+			OrgIDLabel:           "1",
+			GroupLabel:           rule.Group,
+			FolderUIDLabel:       rule.NamespaceUID,
+			"externalLabelKey":   "externalLabelValue",
+		}, streamLabels)
+
+		for _, entry := range entries(t, frame) {
+			require.NotContains(t, entry.InstanceLabels, "__private__")
+		}
+	})
+
+	t.Run("query filters by rule", func(t *testing.T) {
+		frame, err := backend.Query(ctx, models.HistoryQuery{OrgID: 1, RuleUID: otherRule.UID, From: start.Add(-time.Hour), To: start})
+		require.NoError(t, err)
+		require.Equal(t, 1, frame.Rows())
+		require.Equal(t, otherRule.UID, entries(t, frame)[0].RuleUID)
+	})
+
+	t.Run("query filters by labels", func(t *testing.T) {
+		frame, err := backend.Query(ctx, models.HistoryQuery{OrgID: 1, RuleUID: rule.UID, Labels: map[string]string{"instance": "cpu-1", "team": "a"}, From: start.Add(-time.Hour), To: start})
+		require.NoError(t, err)
+		require.Equal(t, 3, frame.Rows())
+		for _, entry := range entries(t, frame) {
+			require.Equal(t, "cpu-1", entry.InstanceLabels["instance"])
+		}
+
+		frame, err = backend.Query(ctx, models.HistoryQuery{OrgID: 1, Labels: map[string]string{"instance": "cpu-2", "team": "b"}, From: start.Add(-time.Hour), To: start})
+		require.NoError(t, err)
+		require.Equal(t, 0, frame.Rows())
+	})
+
+	t.Run("query filters by time range and organization", func(t *testing.T) {
+		frame, err := backend.Query(ctx, models.HistoryQuery{OrgID: 1, From: start.Add(-15 * time.Minute), To: start})
+		require.NoError(t, err)
+		require.Equal(t, 2, frame.Rows())
+
+		frame, err = backend.Query(ctx, models.HistoryQuery{OrgID: 2, From: start.Add(-time.Hour), To: start})
+		require.NoError(t, err)
+		require.Equal(t, 0, frame.Rows())
+	})
+
+	t.Run("limit and offset page backwards through the history", func(t *testing.T) {
+		query := models.HistoryQuery{OrgID: 1, RuleUID: rule.UID, From: start.Add(-time.Hour), To: start, Limit: 2}
+		frame, err := backend.Query(ctx, query)
+		require.NoError(t, err)
+		page := entries(t, frame)
+		require.Len(t, page, 2)
+		require.Equal(t, "Alerting", page[0].Current)
+		require.Equal(t, "Normal", page[1].Current)
+
+		query.Offset = 2
+		frame, err = backend.Query(ctx, query)
+		require.NoError(t, err)
+		page = entries(t, frame)
+		require.Len(t, page, 2)
+		require.Equal(t, "Pending", page[0].Current)
+		require.Equal(t, "Alerting", page[1].Current)
+		require.Equal(t, frame.Fields[0].At(0), frame.Fields[0].At(1))
+	})
+
+	t.Run("query only returns folders the user can access", func(t *testing.T) {
+		restricted := &acfakes.FakeRuleService{}
+		restricted.CanReadAllRulesFunc = func(ctx context.Context, user identity.Requester) (bool, error) {
+			return false, nil
+		}
+		restricted.HasAccessInFolderFunc = func(ctx context.Context, user identity.Requester, namespaced models.Namespaced) (bool, error) {
+			return namespaced.GetNamespaceUID() == otherRule.NamespaceUID, nil
+		}
+		rules.Folders = map[int64][]*folder.Folder{
+			1: {
+				{UID: rule.NamespaceUID, OrgID: 1},
+				{UID: otherRule.NamespaceUID, OrgID: 1},
+			},
+		}
+		rules.Rules = map[int64][]*models.AlertRule{
+			1: {models.RuleGen.With(models.RuleMuts.WithOrgID(1)).GenerateRef()},
+		}
+		backend.ac = restricted
+		t.Cleanup(func() {
+			backend.ac = ac
+		})
+
+		frame, err := backend.Query(ctx, models.HistoryQuery{OrgID: 1, From: start.Add(-time.Hour), To: start})
+		require.NoError(t, err)
+		require.Equal(t, 1, frame.Rows())
+		require.Equal(t, otherRule.UID, entries(t, frame)[0].RuleUID)
+	})
+
+	countRows := func(t *testing.T, table string) int64 {
+		t.Helper()
+		var n int64
+		err := store.WithDbSession(ctx, func(sess *db.Session) error {
+			var err error
+			n, err = sess.Table(table).Count()
+			return err
+		})
+		require.NoError(t, err)
+		return n
+	}
+
+	t.Run("compaction applies the retention and removes unused instances", func(t *testing.T) {
+		require.EqualValues(t, 3, countRows(t, sqlInstanceTable))
+		require.EqualValues(t, 6, countRows(t, sqlLabelTable))
+
+		// Only the transitions recorded 10 minutes before the start are within the retention.
+		clk.Set(start.Add(time.Hour - 15*time.Minute))
+		n, err := backend.Compact(ctx)
+		require.NoError(t, err)
+		require.EqualValues(t, 3, n)
+		// The instances were created within the retention, so they are kept.
+		require.EqualValues(t, 3, countRows(t, sqlInstanceTable))
+
+		clk.Set(start.Add(2 * time.Hour))
+		n, err = backend.Compact(ctx)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, n)
+		require.EqualValues(t, 0, countRows(t, sqlEntryTable))
+		require.EqualValues(t, 0, countRows(t, sqlInstanceTable))
+		require.EqualValues(t, 0, countRows(t, sqlLabelTable))
+	})
+
+	t.Run("compaction deletes in batches while transitions are recorded", func(t *testing.T) {
+		backend.batchSize = 2
+		t.Cleanup(func() {
+			backend.batchSize = sqlCompactionBatchSize
+		})
+
+		now := clk.Now()
+		for i := 0; i < 5; i++ {
+			lbls := data.Labels{"instance": fmt.Sprintf("expired-%d", i)}
+			record(t, rule,
+				transition(eval.Normal, eval.Alerting, now.Add(-2*time.Hour), lbls),
+				transition(eval.Alerting, eval.Normal, now.Add(-90*time.Minute), lbls),
+			)
+		}
+		clk.Add(time.Hour + time.Minute)
+
+		const writers, writes = 4, 5
+		var wg sync.WaitGroup
+		errs := make(chan error, writers*writes)
+		for w := 0; w < writers; w++ {
+			wg.Add(1)
+			go func(w int) {
+				defer wg.Done()
+				for i := 0; i < writes; i++ {
+					lbls := data.Labels{"instance": fmt.Sprintf("recent-%d", w)}
+					errs <- <-backend.Record(ctx, rule, []state.StateTransition{transition(eval.Normal, eval.Alerting, clk.Now(), lbls)})
+				}
+			}(w)
+		}
+		n, err := backend.Compact(ctx)
+		wg.Wait()
+		close(errs)
+		require.NoError(t, err)
+		for err := range errs {
+			require.NoError(t, err)
+		}
+
+		require.EqualValues(t, 10, n)
+		require.EqualValues(t, writers*writes, countRows(t, sqlEntryTable))
+		require.EqualValues(t, writers, countRows(t, sqlInstanceTable))
+		require.EqualValues(t, writers, countRows(t, sqlLabelTable))
+	})
+}
+
+func TestLabelHash(t *testing.T) {
+	require.Equal(t, labelHash("a", "b"), labelHash("a", "b"))
+	require.NotEqual(t, labelHash("a", "bc"), labelHash("ab", "c"))
+	require.NotEqual(t, labelHash("a", "b"), labelHash("b", "a"))
+	require.Len(t, labelHash("a", "b"), 64)
+}
+
+func TestLabelsFingerprint(t *testing.T) {
+	require.Equal(t, labelsFingerprint(map[string]string{"a": "1", "b": "2"}), labelsFingerprint(map[string]string{"b": "2", "a": "1"}))
+	require.NotEqual(t, labelsFingerprint(map[string]string{"a": "1b"}), labelsFingerprint(map[string]string{"a": "1", "b": ""}))
+	require.NotEqual(t, labelsFingerprint(map[string]string{"a": "1"}), labelsFingerprint(map[string]string{"a": "2"}))
+	require.NotEqual(t, labelsFingerprint(nil), labelsFingerprint(map[string]string{"a": ""}))
+	require.Len(t, labelsFingerprint(map[string]string{"a": "1"}), 64)
+}
@@ -5,6 +5,7 @@ import (
 	"net/url"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/benbjohnson/clock"
@@ -58,6 +59,11 @@ type Manager struct {
 	rulesPerRuleGroupLimit         int64
 
 	persister StatePersister
+
+	// disowned contains the UIDs of the rules by org ID that are evaluated by another replica.
+	// Their state is read from the instance store.
+	disowned    map[int64]map[string]struct{}
+	disownedMtx sync.RWMutex
 }
 
 type ManagerCfg struct {
@@ -109,6 +115,7 @@ func NewManager(cfg ManagerCfg, statePersister StatePersister) *Manager {
 		rulesPerRuleGroupLimit:         cfg.RulesPerRuleGroupLimit,
 		persister:                      statePersister,
 		tracer:                         cfg.Tracer,
+		disowned:                       make(map[int64]map[string]struct{}),
 	}
 
 	if m.applyNoDataAndErrorToAllStates {
@@ -186,39 +193,7 @@ func (st *Manager) Warm(ctx context.Context, rulesReader RuleReader, instanceRea
 				continue
 			}
 
-			// nil safety.
-			annotations := ruleForEntry.Annotations
-			if annotations == nil {
-				annotations = make(map[string]string)
-			}
-
-			lbs := map[string]string(entry.Labels)
-			cacheID := entry.Labels.Fingerprint()
-			var resultFp data.Fingerprint
-			if entry.ResultFingerprint != "" {
-				fp, err := strconv.ParseUint(entry.ResultFingerprint, 16, 64)
-				if err != nil {
-					logger.Error("Failed to parse result fingerprint of alert instance", "error", err, "ruleUID", entry.RuleUID)
-				}
-				resultFp = data.Fingerprint(fp)
-			}
-			state := &State{
-				AlertRuleUID:         entry.RuleUID,
-				OrgID:                entry.RuleOrgID,
-				CacheID:              cacheID,
-				Labels:               lbs,
-				State:                translateInstanceState(entry.CurrentState),
-				StateReason:          entry.CurrentReason,
-				LastEvaluationString: "",
-				StartsAt:             entry.CurrentStateSince,
-				EndsAt:               entry.CurrentStateEnd,
-				LastEvaluationTime:   entry.LastEvalTime,
-				Annotations:          annotations,
-				ResultFingerprint:    resultFp,
-				ResolvedAt:           entry.ResolvedAt,
-				LastSentAt:           entry.LastSentAt,
-			}
-			st.cache.set(state)
+			st.cache.set(stateFromInstance(logger, entry, ruleForEntry.Annotations))
 			statesCount++
 		}
 	}
@@ -226,6 +201,143 @@ func (st *Manager) Warm(ctx context.Context, rulesReader RuleReader, instanceRea
 	logger.Info("State cache has been initialized", "states", statesCount, "duration", time.Since(startTime))
 }
 
+// WarmRule loads the state of the rule from the instance store into the cache, replacing the cached state of the rule.
+// It is used when the rule starts to be evaluated by this replica after it was evaluated by another replica.
+func (st *Manager) WarmRule(ctx context.Context, rule *ngModels.AlertRule) {
+	st.setDisowned(rule.GetKey(), false)
+	if st.instanceStore == nil {
+		return
+	}
+	logger := st.log.FromContext(ctx).New(rule.GetKey().LogContext()...)
+	alertInstances, err := st.instanceStore.ListAlertInstances(ctx, &ngModels.ListAlertInstancesQuery{
+		RuleOrgID: rule.OrgID,
+		RuleUID:   rule.UID,
+	})
+	if err != nil {
+		logger.Error("Unable to fetch previous state", "error", err)
+		return
+	}
+	st.cache.removeByRuleUID(rule.OrgID, rule.UID)
+	for _, entry := range alertInstances {
+		st.cache.set(stateFromInstance(logger, entry, rule.Annotations))
+	}
+	logger.Debug("State of the rule has been loaded", "states", len(alertInstances))
+}
+
+// ForgetRule removes the state of the rule from the cache without deleting it from the instance store.
+// It is used when the rule is evaluated by another replica, which takes over the stored state.
+// The state of the rule is read from the instance store until the rule is warmed again.
+func (st *Manager) ForgetRule(key ngModels.AlertRuleKey) {
+	st.setDisowned(key, true)
+	st.cache.removeByRuleUID(key.OrgID, key.UID)
+}
+
+// HandOverRule saves the state of the rule to the instance store and then forgets it, so that the replica
+// that evaluates the rule from now on continues from the current state even if the state is saved periodically.
+func (st *Manager) HandOverRule(ctx context.Context, key ngModels.AlertRuleKey) {
+	if st.instanceStore != nil {
+		instances := st.cache.getAlertInstancesForRule(key.OrgID, key.UID, st.doNotSaveNormalState)
+		if err := st.instanceStore.SaveAlertInstancesForRule(ctx, ngModels.AlertRuleKeyWithGroup{AlertRuleKey: key}, instances); err != nil {
+			st.log.FromContext(ctx).Error("Failed to save the state of the rule before handing it over", append(key.LogContext(), "error", err)...)
+		}
+	}
+	st.ForgetRule(key)
+}
+
+func (st *Manager) setDisowned(key ngModels.AlertRuleKey, disowned bool) {
+	st.disownedMtx.Lock()
+	defer st.disownedMtx.Unlock()
+	if !disowned {
+		delete(st.disowned[key.OrgID], key.UID)
+		return
+	}
+	if _, ok := st.disowned[key.OrgID]; !ok {
+		st.disowned[key.OrgID] = make(map[string]struct{})
+	}
+	st.disowned[key.OrgID][key.UID] = struct{}{}
+}
+
+func (st *Manager) isDisowned(orgID int64, ruleUID string) bool {
+	st.disownedMtx.RLock()
+	defer st.disownedMtx.RUnlock()
+	_, ok := st.disowned[orgID][ruleUID]
+	return ok
+}
+
+// storedStates returns the states in the instance store of the rules of the org that are evaluated by another replica.
+// If ruleUID is set, only the states of that rule are returned. The annotations of the rules are not stored.
+func (st *Manager) storedStates(orgID int64, ruleUID string) []*State {
+	st.disownedMtx.RLock()
+	disowned := len(st.disowned[orgID]) > 0
+	st.disownedMtx.RUnlock()
+	if st.instanceStore == nil || !disowned {
+		return nil
+	}
+	ctx := context.Background()
+	alertInstances, err := st.instanceStore.ListAlertInstances(ctx, &ngModels.ListAlertInstancesQuery{
+		RuleOrgID: orgID,
+		RuleUID:   ruleUID,
+	})
+	if err != nil {
+		st.log.Error("Unable to fetch the state of rules that are evaluated by another replica", "orgID", orgID, "error", err)
+		return nil
+	}
+	states := make([]*State, 0, len(alertInstances))
+	for _, entry := range alertInstances {
+		if !st.isDisowned(entry.RuleOrgID, entry.RuleUID) {
+			continue
+		}
+		state := stateFromInstance(st.log, entry, nil)
+		if st.doNotSaveNormalState && IsNormalStateWithNoReason(state) {
+			continue
+		}
+		states = append(states, state)
+	}
+	return states
+}
+
+// stateFromInstance returns the state of the stored alert instance of the rule with the given annotations.
+func stateFromInstance(logger log.Logger, entry *ngModels.AlertInstance, annotations map[string]string) *State {
+	// nil safety.
+	if annotations == nil {
+		annotations = make(map[string]string)
+	}
+
+	lbs := map[string]string(entry.Labels)
+	cacheID := entry.Labels.Fingerprint()
+	var resultFp data.Fingerprint
+	if entry.ResultFingerprint != "" {
+		fp, err := strconv.ParseUint(entry.ResultFingerprint, 16, 64)
+		if err != nil {
+			logger.Error("Failed to parse result fingerprint of alert instance", "error", err, "ruleUID", entry.RuleUID)
+		}
+		resultFp = data.Fingerprint(fp)
+	}
+	state := &State{
+		AlertRuleUID:         entry.RuleUID,
+		OrgID:                entry.RuleOrgID,
+		CacheID:              cacheID,
+		Labels:               lbs,
+		State:                translateInstanceState(entry.CurrentState),
+		StateReason:          entry.CurrentReason,
+		LastEvaluationString: "",
+		StartsAt:             entry.CurrentStateSince,
+		EndsAt:               entry.CurrentStateEnd,
+		LastEvaluationTime:   entry.LastEvalTime,
+		Annotations:          annotations,
+		ResultFingerprint:    resultFp,
+		ResolvedAt:           entry.ResolvedAt,
+		LastSentAt:           entry.LastSentAt,
+	}
+	// The time the state started to keep firing is not persisted, so the keep firing period
+	// of a recovering state starts again from its last evaluation.
+	if state.State == eval.Alerting && strings.Contains(entry.CurrentReason, ngModels.StateReasonRecovering) {
+		keepFiringSince := entry.LastEvalTime
+		state.KeepFiringSince = &keepFiringSince
+	}
+	return state
+}
+
 func (st *Manager) Get(orgID int64, alertRuleUID string, stateId data.Fingerprint) *State {
 	return st.cache.get(orgID, alertRuleUID, stateId)
 }
@@ -500,6 +612,15 @@ func (st *Manager) setNextState(ctx context.Context, alertRule *ngModels.AlertRu
 		currentState.StateReason = resultStateReason(result, alertRule)
 	}
 
+	// The state keeps firing although the condition is no longer met
+	if currentState.KeepFiringSince != nil {
+		if currentState.StateReason == "" {
+			currentState.StateReason = ngModels.StateReasonRecovering
+		} else {
+			currentState.StateReason = ngModels.ConcatReasons(currentState.StateReason, ngModels.StateReasonRecovering)
+		}
+	}
+
 	// Set Resolved property so the scheduler knows to send a postable alert
 	// to Alertmanager.
 	newlyResolved := false
@@ -547,11 +668,19 @@ func resultStateReason(result eval.Result, rule *ngModels.AlertRule) string {
 	return result.State.String()
 }
 
+// GetAll returns the states of the rules of the org. The states of the rules that are evaluated by
+// another replica are read from the instance store.
 func (st *Manager) GetAll(orgID int64) []*State {
 	allStates := st.cache.getAll(orgID, st.doNotSaveNormalState)
-	return allStates
+	return append(allStates, st.storedStates(orgID, "")...)
 }
+
+// GetStatesForRuleUID returns the states of the rule. The states of a rule that is evaluated by
+// another replica are read from the instance store.
 func (st *Manager) GetStatesForRuleUID(orgID int64, alertRuleUID string) []*State {
+	if st.isDisowned(orgID, alertRuleUID) {
+		return st.storedStates(orgID, alertRuleUID)
+	}
 	return st.cache.getStatesForRuleUID(orgID, alertRuleUID, st.doNotSaveNormalState)
 }
 
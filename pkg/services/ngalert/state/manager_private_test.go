@@ -126,6 +126,7 @@ func TestProcessEvalResults_StateTransitions(t *testing.T) {
 	t1 := tN(1)
 	t2 := tN(2)
 	t3 := tN(3)
+	t4 := tN(4)
 
 	baseRule := &ngmodels.AlertRule{
 		OrgID: 1,
@@ -777,6 +778,107 @@ func TestProcessEvalResults_StateTransitions(t *testing.T) {
 				},
 			},
 		},
+		{
+			desc:      "t1[1:alerting] t2[1:normal] t3[1:normal] t4[1:normal] and 'keep_firing_for'=2 at t2,t3,t4",
+			alertRule: baseRuleWith(ngmodels.RuleMuts.WithKeepFiringFor(2 * evaluationInterval)),
+			results: map[time.Time]eval.Results{
+				t1: {
+					newResult(eval.WithState(eval.Alerting), eval.WithLabels(labels1)),
+				},
+				t2: {
+					newResult(eval.WithState(eval.Normal), eval.WithLabels(labels1)),
+				},
+				t3: {
+					newResult(eval.WithState(eval.Normal), eval.WithLabels(labels1)),
+				},
+				t4: {
+					newResult(eval.WithState(eval.Normal), eval.WithLabels(labels1)),
+				},
+			},
+			expectedTransitions: map[time.Time][]StateTransition{
+				t2: {
+					{
+						PreviousState: eval.Alerting,
+						State: &State{
+							Labels:             labels["system + rule + labels1"],
+							State:              eval.Alerting,
+							StateReason:        ngmodels.StateReasonRecovering,
+							LatestResult:       newEvaluation(t2, eval.Normal),
+							StartsAt:           t1,
+							EndsAt:             t2.Add(ResendDelay * 4),
+							KeepFiringSince:    &t2,
+							LastEvaluationTime: t2,
+							LastSentAt:         &t1,
+						},
+					},
+				},
+				t3: {
+					{
+						PreviousState:       eval.Alerting,
+						PreviousStateReason: ngmodels.StateReasonRecovering,
+						State: &State{
+							Labels:             labels["system + rule + labels1"],
+							State:              eval.Alerting,
+							StateReason:        ngmodels.StateReasonRecovering,
+							LatestResult:       newEvaluation(t3, eval.Normal),
+							StartsAt:           t1,
+							EndsAt:             t3.Add(ResendDelay * 4),
+							KeepFiringSince:    &t2,
+							LastEvaluationTime: t3,
+							LastSentAt:         &t1,
+						},
+					},
+				},
+				t4: {
+					{
+						PreviousState:       eval.Alerting,
+						PreviousStateReason: ngmodels.StateReasonRecovering,
+						State: &State{
+							Labels:             labels["system + rule + labels1"],
+							State:              eval.Normal,
+							LatestResult:       newEvaluation(t4, eval.Normal),
+							StartsAt:           t4,
+							EndsAt:             t4,
+							ResolvedAt:         &t4,
+							LastEvaluationTime: t4,
+							LastSentAt:         &t4,
+						},
+					},
+				},
+			},
+		},
+		{
+			desc:      "t1[1:alerting] t2[1:normal] t3[1:alerting] and 'keep_firing_for'=2 at t3",
+			alertRule: baseRuleWith(ngmodels.RuleMuts.WithKeepFiringFor(2 * evaluationInterval)),
+			results: map[time.Time]eval.Results{
+				t1: {
+					newResult(eval.WithState(eval.Alerting), eval.WithLabels(labels1)),
+				},
+				t2: {
+					newResult(eval.WithState(eval.Normal), eval.WithLabels(labels1)),
+				},
+				t3: {
+					newResult(eval.WithState(eval.Alerting), eval.WithLabels(labels1)),
+				},
+			},
+			expectedTransitions: map[time.Time][]StateTransition{
+				t3: {
+					{
+						PreviousState:       eval.Alerting,
+						PreviousStateReason: ngmodels.StateReasonRecovering,
+						State: &State{
+							Labels:             labels["system + rule + labels1"],
+							State:              eval.Alerting,
+							LatestResult:       newEvaluation(t3, eval.Alerting),
+							StartsAt:           t1,
+							EndsAt:             t3.Add(ResendDelay * 4),
+							LastEvaluationTime: t3,
+							LastSentAt:         &t1,
+						},
+					},
+				},
+			},
+		},
 	}
 
 	for _, tc := range testCases {
@@ -243,6 +243,47 @@ func TestWarmStateCache(t *testing.T) {
 			}
 		}
 	})
+
+	t.Run("ForgetRule removes the state of the rule from the cache and reads it from the store", func(t *testing.T) {
+		st.ForgetRule(rule.GetKey())
+		for _, entry := range expectedEntries {
+			require.Nil(t, st.Get(entry.OrgID, entry.AlertRuleUID, entry.CacheID))
+		}
+		require.Len(t, st.GetStatesForRuleUID(rule.OrgID, rule.UID), len(expectedEntries))
+		require.Len(t, st.GetAll(rule.OrgID), len(expectedEntries))
+	})
+
+	t.Run("WarmRule loads the state of the rule into the cache", func(t *testing.T) {
+		st.WarmRule(ctx, rule)
+		require.Len(t, st.GetStatesForRuleUID(rule.OrgID, rule.UID), len(expectedEntries))
+		for _, entry := range expectedEntries {
+			cacheEntry := st.Get(entry.OrgID, entry.AlertRuleUID, entry.CacheID)
+			if diff := cmp.Diff(entry, cacheEntry, cmpopts.IgnoreFields(state.State{}, "LatestResult")); diff != "" {
+				t.Errorf("Result mismatch (-want +got):\n%s", diff)
+			}
+		}
+	})
+
+	t.Run("HandOverRule saves the state of the rule before it removes it from the cache", func(t *testing.T) {
+		added := &state.State{
+			AlertRuleUID:       rule.UID,
+			OrgID:              rule.OrgID,
+			Labels:             data.Labels{"test7": "testValue7"},
+			State:              eval.Alerting,
+			StartsAt:           evaluationTime,
+			EndsAt:             evaluationTime.Add(1 * time.Minute),
+			LastEvaluationTime: evaluationTime,
+		}
+		setCacheID(added)
+		st.Put([]*state.State{added})
+
+		st.HandOverRule(ctx, rule.GetKey())
+		require.Nil(t, st.Get(added.OrgID, added.AlertRuleUID, added.CacheID))
+		instances, err := dbstore.ListAlertInstances(ctx, &models.ListAlertInstancesQuery{RuleOrgID: rule.OrgID, RuleUID: rule.UID})
+		require.NoError(t, err)
+		require.Len(t, instances, len(expectedEntries)+1)
+		require.Len(t, st.GetStatesForRuleUID(rule.OrgID, rule.UID), len(expectedEntries)+1)
+	})
 }
 
 func TestDashboardAnnotations(t *testing.T) {
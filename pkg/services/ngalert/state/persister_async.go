@@ -2,6 +2,8 @@ package state
 
 import (
 	"context"
+	"errors"
+	"fmt"
 	"time"
 
 	"github.com/benbjohnson/clock"
@@ -14,15 +16,18 @@ import (
 
 type AlertInstancesProvider interface {
 	GetAlertInstances(skipNormalState bool) []models.AlertInstance
+	GetAlertInstancesByRule(skipNormalState bool) map[models.AlertRuleKey][]models.AlertInstance
 }
 
 type AsyncStatePersister struct {
 	log log.Logger
 	// doNotSaveNormalState controls whether eval.Normal state is persisted to the database and returned by get methods.
 	doNotSaveNormalState bool
-	store                InstanceStore
-	ticker               *clock.Ticker
-	metrics              *metrics.State
+	// perRule saves the state of each rule separately instead of replacing the state of all rules.
+	perRule bool
+	store   InstanceStore
+	ticker  *clock.Ticker
+	metrics *metrics.State
 }
 
 func NewAsyncStatePersister(log log.Logger, ticker *clock.Ticker, cfg ManagerCfg) StatePersister {
@@ -35,6 +40,20 @@ func NewAsyncStatePersister(log log.Logger, ticker *clock.Ticker, cfg ManagerCfg
 	}
 }
 
+// NewAsyncRuleStatePersister returns a persister that periodically saves the state of each rule in the cache, and
+// keeps the stored state of the rules that are not in the cache. It is used when the alert rules are sharded across
+// replicas, as the cache only contains the state of the rules that are evaluated by this replica.
+func NewAsyncRuleStatePersister(log log.Logger, ticker *clock.Ticker, cfg ManagerCfg) StatePersister {
+	return &AsyncStatePersister{
+		log:                  log,
+		store:                cfg.InstanceStore,
+		ticker:               ticker,
+		doNotSaveNormalState: cfg.DoNotSaveNormalState,
+		perRule:              true,
+		metrics:              cfg.Metrics,
+	}
+}
+
 func (a *AsyncStatePersister) Async(ctx context.Context, instancesProvider AlertInstancesProvider) {
 	for {
 		select {
@@ -56,6 +75,9 @@ func (a *AsyncStatePersister) Async(ctx context.Context, instancesProvider Alert
 
 func (a *AsyncStatePersister) fullSync(ctx context.Context, instancesProvider AlertInstancesProvider) error {
 	startTime := time.Now()
+	if a.perRule {
+		return a.ruleSync(ctx, instancesProvider, startTime)
+	}
 	a.log.Debug("Full state sync start")
 	instances := instancesProvider.GetAlertInstances(a.doNotSaveNormalState)
 	if err := a.store.FullSync(ctx, instances); err != nil {
@@ -69,6 +91,29 @@ func (a *AsyncStatePersister) fullSync(ctx context.Context, instancesProvider Al
 	return nil
 }
 
+// ruleSync saves the state of every rule in the cache. The state of a rule is still saved if another one fails.
+func (a *AsyncStatePersister) ruleSync(ctx context.Context, instancesProvider AlertInstancesProvider, startTime time.Time) error {
+	a.log.Debug("State sync by rule start")
+	var errs []error
+	var count int
+	for key, instances := range instancesProvider.GetAlertInstancesByRule(a.doNotSaveNormalState) {
+		if err := a.store.SaveAlertInstancesForRule(ctx, models.AlertRuleKeyWithGroup{AlertRuleKey: key}, instances); err != nil {
+			errs = append(errs, fmt.Errorf("failed to save the state of rule %s: %w", key.UID, err))
+			continue
+		}
+		count += len(instances)
+	}
+	if len(errs) > 0 {
+		a.log.Error("State sync by rule failed", "duration", time.Since(startTime), "errors", len(errs))
+		return errors.Join(errs...)
+	}
+	a.log.Debug("State sync by rule done", "duration", time.Since(startTime), "instances", count)
+	if a.metrics != nil {
+		a.metrics.StateFullSyncDuration.Observe(time.Since(startTime).Seconds())
+	}
+	return nil
+}
+
 func (a *AsyncStatePersister) Sync(_ context.Context, _ trace.Span, _ models.AlertRuleKeyWithGroup, _ StateTransitions) {
 	a.log.Debug("Sync: No-Op")
 }
@@ -75,3 +75,52 @@ func TestAsyncStatePersister_Async(t *testing.T) {
 		}, time.Second*5, time.Second)
 	})
 }
+
+func TestAsyncRuleStatePersister_Async(t *testing.T) {
+	t.Run("It should save the state of each rule on tick", func(t *testing.T) {
+		mockClock := clock.NewMock()
+		store := &FakeInstanceStore{}
+		logger := log.New("async.test")
+
+		persister := NewAsyncRuleStatePersister(logger, mockClock.Ticker(1*time.Second), ManagerCfg{
+			InstanceStore: store,
+		})
+
+		ctx, cancel := context.WithCancel(context.Background())
+
+		defer func() {
+			cancel()
+		}()
+
+		cache := newCache()
+
+		go persister.Async(ctx, cache)
+
+		cache.set(&State{
+			OrgID:        1,
+			State:        eval.Alerting,
+			AlertRuleUID: "1",
+		})
+		cache.set(&State{
+			OrgID:        1,
+			State:        eval.Alerting,
+			AlertRuleUID: "2",
+		})
+		// Let one tick pass
+		mockClock.Add(1 * time.Second)
+
+		// Check if the state of each rule was saved
+		require.Eventually(t, func() bool {
+			ops := store.RecordedOps()
+			if len(ops) != 2 {
+				return false
+			}
+			for _, op := range ops {
+				if op.(FakeInstanceStoreOp).Name != "SaveAlertInstancesForRule" {
+					return false
+				}
+			}
+			return true
+		}, time.Second*5, time.Second)
+	})
+}
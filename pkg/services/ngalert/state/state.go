@@ -69,7 +69,10 @@ type State struct {
 	// ResolvedAt is set when the state is first resolved. That is to say, when the state first transitions
 	// from Alerting, NoData, or Error to Normal. It is reset to zero when the state transitions from Normal
 	// to any other state.
-	ResolvedAt           *time.Time
+	ResolvedAt *time.Time
+	// KeepFiringSince is set when the condition of an alerting state is first no longer met and the rule
+	// keeps firing for KeepFiringFor. It is reset when the condition is met again or the state changes.
+	KeepFiringSince      *time.Time
 	LastSentAt           *time.Time
 	LastEvaluationString string
 	LastEvaluationTime   time.Time
@@ -99,6 +102,7 @@ func (a *State) Copy() *State {
 		StartsAt:             a.StartsAt,
 		EndsAt:               a.EndsAt,
 		ResolvedAt:           a.ResolvedAt,
+		KeepFiringSince:      a.KeepFiringSince,
 		LastSentAt:           a.LastSentAt,
 		LastEvaluationString: a.LastEvaluationString,
 		LastEvaluationTime:   a.LastEvaluationTime,
@@ -129,6 +133,7 @@ func (a *State) SetAlerting(reason string, startsAt, endsAt time.Time) {
 	a.StartsAt = startsAt
 	a.EndsAt = endsAt
 	a.Error = nil
+	a.KeepFiringSince = nil
 }
 
 // SetPending the state to Pending. It changes both the start and end time.
@@ -138,6 +143,7 @@ func (a *State) SetPending(reason string, startsAt, endsAt time.Time) {
 	a.StartsAt = startsAt
 	a.EndsAt = endsAt
 	a.Error = nil
+	a.KeepFiringSince = nil
 }
 
 // SetNoData sets the state to NoData. It changes both the start and end time.
@@ -147,15 +153,25 @@ func (a *State) SetNoData(reason string, startsAt, endsAt time.Time) {
 	a.StartsAt = startsAt
 	a.EndsAt = endsAt
 	a.Error = nil
+	a.KeepFiringSince = nil
 }
 
 // SetError sets the state to Error. It changes both the start and end time.
 func (a *State) SetError(err error, startsAt, endsAt time.Time) {
 	a.State = eval.Error
-	a.StateReason = models.StateReasonError
+	a.StateReason = errorReason(err)
 	a.StartsAt = startsAt
 	a.EndsAt = endsAt
 	a.Error = err
+	a.KeepFiringSince = nil
+}
+
+// errorReason returns the state reason for an evaluation error.
+func errorReason(err error) string {
+	if errors.Is(err, eval.ErrEvaluationTimeout) {
+		return models.StateReasonTimeout
+	}
+	return models.StateReasonError
 }
 
 // SetNormal sets the state to Normal. It changes both the start and end time.
@@ -165,6 +181,7 @@ func (a *State) SetNormal(reason string, startsAt, endsAt time.Time) {
 	a.StartsAt = startsAt
 	a.EndsAt = endsAt
 	a.Error = nil
+	a.KeepFiringSince = nil
 }
 
 // Maintain updates the end time using the most recent evaluation.
@@ -294,7 +311,28 @@ func NewEvaluationValues(m map[string]eval.NumberValueCapture) map[string]float6
 	return result
 }
 
-func resultNormal(state *State, _ *models.AlertRule, result eval.Result, logger log.Logger, reason string) {
+func resultNormal(state *State, rule *models.AlertRule, result eval.Result, logger log.Logger, reason string) {
+	if state.State == eval.Alerting && rule.KeepFiringFor > 0 {
+		if state.KeepFiringSince == nil {
+			keepFiringSince := result.EvaluatedAt
+			state.KeepFiringSince = &keepFiringSince
+		}
+		// If the alert rule has a KeepFiringFor duration then the state should keep firing until it is observed
+		if result.EvaluatedAt.Sub(*state.KeepFiringSince) < rule.KeepFiringFor {
+			prevEndsAt := state.EndsAt
+			state.Maintain(rule.IntervalSeconds, result.EvaluatedAt)
+			logger.Debug("Keeping state",
+				"state",
+				state.State,
+				"keep_firing_since",
+				state.KeepFiringSince,
+				"previous_ends_at",
+				prevEndsAt,
+				"next_ends_at",
+				state.EndsAt)
+			return
+		}
+	}
 	if state.State == eval.Normal {
 		logger.Debug("Keeping state", "state", state.State)
 	} else {
@@ -317,6 +355,7 @@ func resultAlerting(state *State, rule *models.AlertRule, result eval.Result, lo
 	switch state.State {
 	case eval.Alerting:
 		prevEndsAt := state.EndsAt
+		state.KeepFiringSince = nil
 		state.Maintain(rule.IntervalSeconds, result.EvaluatedAt)
 		logger.Debug("Keeping state",
 			"state",
@@ -375,7 +414,7 @@ func resultError(state *State, rule *models.AlertRule, result eval.Result, logge
 	switch rule.ExecErrState {
 	case models.AlertingErrState:
 		logger.Debug("Execution error state is Alerting", "handler", "resultAlerting", "previous_handler", handlerStr)
-		resultAlerting(state, rule, result, logger, models.StateReasonError)
+		resultAlerting(state, rule, result, logger, errorReason(result.Error))
 		// This is a special case where Alerting and Pending should also have an error and reason
 		state.Error = result.Error
 		state.AddErrorInformation(result.Error, rule, false)
@@ -383,6 +422,7 @@ func resultError(state *State, rule *models.AlertRule, result eval.Result, logge
 		if state.State == eval.Error {
 			prevEndsAt := state.EndsAt
 			state.Error = result.Error
+			state.StateReason = errorReason(result.Error)
 			state.AddErrorInformation(result.Error, rule, true)
 			state.Maintain(rule.IntervalSeconds, result.EvaluatedAt)
 			logger.Debug("Keeping state",
@@ -409,7 +449,11 @@ func resultError(state *State, rule *models.AlertRule, result eval.Result, logge
 		}
 	case models.OkErrState:
 		logger.Debug("Execution error state is Normal", "handler", "resultNormal", "previous_handler", handlerStr)
-		resultNormal(state, rule, result, logger, "") // TODO: Should we add a reason?
+		reason := ""
+		if errors.Is(result.Error, eval.ErrEvaluationTimeout) {
+			reason = models.StateReasonTimeout
+		}
+		resultNormal(state, rule, result, logger, reason) // TODO: Should we add a reason for other errors?
 		state.AddErrorInformation(result.Error, rule, false)
 	case models.KeepLastErrState:
 		logger := logger.New("previous_handler", handlerStr)
@@ -469,6 +513,9 @@ func resultNoData(state *State, rule *models.AlertRule, result eval.Result, logg
 
 func resultKeepLast(state *State, rule *models.AlertRule, result eval.Result, logger log.Logger) {
 	reason := models.ConcatReasons(result.State.String(), models.StateReasonKeepLast)
+	if errors.Is(result.Error, eval.ErrEvaluationTimeout) {
+		reason = models.ConcatReasons(models.StateReasonTimeout, models.StateReasonKeepLast)
+	}
 
 	switch state.State {
 	case eval.Alerting:
@@ -3,6 +3,7 @@ package state
 import (
 	"context"
 	"errors"
+	"fmt"
 	"math"
 	"math/rand"
 	"testing"
@@ -240,6 +241,18 @@ func TestSetError(t *testing.T) {
 			StartsAt:    mock.Now(),
 			EndsAt:      mock.Now().Add(time.Minute),
 		},
+	}, {
+		name:     "reason is Timeout if the evaluation timed out",
+		startsAt: mock.Now(),
+		endsAt:   mock.Now().Add(time.Minute),
+		error:    fmt.Errorf("%w after 10s", eval.ErrEvaluationTimeout),
+		expected: State{
+			State:       eval.Error,
+			StateReason: ngmodels.StateReasonTimeout,
+			Error:       fmt.Errorf("%w after 10s", eval.ErrEvaluationTimeout),
+			StartsAt:    mock.Now(),
+			EndsAt:      mock.Now().Add(time.Minute),
+		},
 	}}
 
 	for _, test := range tests {
@@ -15,6 +15,8 @@ var _ InstanceStore = &FakeInstanceStore{}
 type FakeInstanceStore struct {
 	mtx         sync.Mutex
 	recordedOps []any
+	// Instances are returned by ListAlertInstances if they match the query.
+	Instances []*models.AlertInstance
 }
 
 type FakeInstanceStoreOp struct {
@@ -32,7 +34,13 @@ func (f *FakeInstanceStore) ListAlertInstances(_ context.Context, q *models.List
 	f.mtx.Lock()
 	defer f.mtx.Unlock()
 	f.recordedOps = append(f.recordedOps, *q)
-	return nil, nil
+	var result []*models.AlertInstance
+	for _, instance := range f.Instances {
+		if instance.RuleOrgID == q.RuleOrgID && (q.RuleUID == "" || instance.RuleUID == q.RuleUID) {
+			result = append(result, instance)
+		}
+	}
+	return result, nil
 }
 
 func (f *FakeInstanceStore) SaveAlertInstance(_ context.Context, q models.AlertInstance) error {
@@ -57,6 +65,14 @@ func (f *FakeInstanceStore) DeleteAlertInstances(ctx context.Context, q ...model
 }
 
 func (f *FakeInstanceStore) SaveAlertInstancesForRule(ctx context.Context, key models.AlertRuleKeyWithGroup, instances []models.AlertInstance) error {
+	f.mtx.Lock()
+	defer f.mtx.Unlock()
+	f.recordedOps = append(f.recordedOps, FakeInstanceStoreOp{
+		Name: "SaveAlertInstancesForRule", Args: []any{
+			key,
+			instances,
+		},
+	})
 	return nil
 }
 
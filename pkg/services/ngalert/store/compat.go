@@ -32,6 +32,7 @@ func alertRuleToModelsAlertRule(ar alertRule, l log.Logger) (models.AlertRule, e
 		RuleGroup:       ar.RuleGroup,
 		RuleGroupIndex:  ar.RuleGroupIndex,
 		For:             ar.For,
+		KeepFiringFor:   ar.KeepFiringFor,
 		IsPaused:        ar.IsPaused,
 	}
 
@@ -81,6 +82,15 @@ func alertRuleToModelsAlertRule(ar alertRule, l log.Logger) (models.AlertRule, e
 		result.NotificationSettings = ns
 	}
 
+	if ar.EvaluationPolicy != "" {
+		var policy models.EvaluationPolicy
+		err = json.Unmarshal([]byte(ar.EvaluationPolicy), &policy)
+		if err != nil {
+			return models.AlertRule{}, fmt.Errorf("failed to parse evaluation policy: %w", err)
+		}
+		result.EvaluationPolicy = &policy
+	}
+
 	if ar.Metadata != "" {
 		err = json.Unmarshal([]byte(ar.Metadata), &result.Metadata)
 		if err != nil {
@@ -117,6 +127,7 @@ func alertRuleFromModelsAlertRule(ar models.AlertRule) (alertRule, error) {
 		NoDataState:     ar.NoDataState.String(),
 		ExecErrState:    ar.ExecErrState.String(),
 		For:             ar.For,
+		KeepFiringFor:   ar.KeepFiringFor,
 		IsPaused:        ar.IsPaused,
 	}
 
@@ -159,6 +170,14 @@ func alertRuleFromModelsAlertRule(ar models.AlertRule) (alertRule, error) {
 		result.NotificationSettings = string(notificationSettingsData)
 	}
 
+	if ar.EvaluationPolicy != nil {
+		evaluationPolicyData, err := json.Marshal(ar.EvaluationPolicy)
+		if err != nil {
+			return alertRule{}, fmt.Errorf("failed to marshal evaluation policy: %w", err)
+		}
+		result.EvaluationPolicy = string(evaluationPolicyData)
+	}
+
 	metadata, err := json.Marshal(ar.Metadata)
 	if err != nil {
 		return alertRule{}, fmt.Errorf("failed to metadata: %w", err)
@@ -187,10 +206,12 @@ func alertRuleToAlertRuleVersion(rule alertRule) alertRuleVersion {
 		NoDataState:          rule.NoDataState,
 		ExecErrState:         rule.ExecErrState,
 		For:                  rule.For,
+		KeepFiringFor:        rule.KeepFiringFor,
 		Annotations:          rule.Annotations,
 		Labels:               rule.Labels,
 		IsPaused:             rule.IsPaused,
 		NotificationSettings: rule.NotificationSettings,
+		EvaluationPolicy:     rule.EvaluationPolicy,
 		Metadata:             rule.Metadata,
 	}
 }
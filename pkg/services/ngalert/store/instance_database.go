@@ -211,10 +211,19 @@ func (st DBstore) DeleteAlertInstances(ctx context.Context, keys ...models.Alert
 	return err
 }
 
-// SaveAlertInstancesForRule is not implemented for instance database store.
+// SaveAlertInstancesForRule replaces the stored instances of the rule with the given instances.
 func (st DBstore) SaveAlertInstancesForRule(ctx context.Context, key models.AlertRuleKeyWithGroup, instances []models.AlertInstance) error {
-	st.Logger.Error("SaveAlertInstancesForRule is not implemented for instance database store.")
-	return errors.New("method SaveAlertInstancesForRule is not implemented for instance database store")
+	return st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		if _, err := sess.Exec("DELETE FROM alert_instance WHERE rule_org_id = ? AND rule_uid = ?", key.OrgID, key.UID); err != nil {
+			return fmt.Errorf("failed to delete the alert instances of the rule: %w", err)
+		}
+		for _, alertInstance := range instances {
+			if err := st.insertAlertInstance(sess, alertInstance); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
 }
 
 func (st DBstore) DeleteAlertInstancesByRule(ctx context.Context, key models.AlertRuleKeyWithGroup) error {
@@ -234,32 +243,8 @@ func (st DBstore) FullSync(ctx context.Context, instances []models.AlertInstance
 			return fmt.Errorf("failed to delete alert_instance table: %w", err)
 		}
 		for _, alertInstance := range instances {
-			if err := models.ValidateAlertInstance(alertInstance); err != nil {
-				st.Logger.Warn("Failed to validate alert instance, skipping", "err", err, "rule_uid", alertInstance.RuleUID)
-				continue
-			}
-			labelTupleJSON, err := alertInstance.Labels.StringKey()
-			if err != nil {
-				st.Logger.Warn("Failed to generate alert instance labels key, skipping", "err", err, "rule_uid", alertInstance.RuleUID)
-				continue
-			}
-
-			_, err = sess.Exec(
-				"INSERT INTO alert_instance (rule_org_id, rule_uid, labels, labels_hash, current_state, current_reason, current_state_since, current_state_end, last_eval_time, resolved_at, last_sent_at) VALUES (?,?,?,?,?,?,?,?,?,?,?)",
-				alertInstance.RuleOrgID,
-				alertInstance.RuleUID,
-				labelTupleJSON,
-				alertInstance.LabelsHash,
-				alertInstance.CurrentState,
-				alertInstance.CurrentReason,
-				alertInstance.CurrentStateSince.Unix(),
-				alertInstance.CurrentStateEnd.Unix(),
-				alertInstance.LastEvalTime.Unix(),
-				nullableTimeToUnix(alertInstance.ResolvedAt),
-				nullableTimeToUnix(alertInstance.LastSentAt),
-			)
-			if err != nil {
-				return fmt.Errorf("failed to insert into alert_instance table: %w", err)
+			if err := st.insertAlertInstance(sess, alertInstance); err != nil {
+				return err
 			}
 		}
 		if err := sess.Commit(); err != nil {
@@ -269,6 +254,39 @@ func (st DBstore) FullSync(ctx context.Context, instances []models.AlertInstance
 	})
 }
 
+// insertAlertInstance inserts the alert instance. Invalid instances are skipped.
+func (st DBstore) insertAlertInstance(sess *db.Session, alertInstance models.AlertInstance) error {
+	if err := models.ValidateAlertInstance(alertInstance); err != nil {
+		st.Logger.Warn("Failed to validate alert instance, skipping", "err", err, "rule_uid", alertInstance.RuleUID)
+		return nil
+	}
+	labelTupleJSON, err := alertInstance.Labels.StringKey()
+	if err != nil {
+		st.Logger.Warn("Failed to generate alert instance labels key, skipping", "err", err, "rule_uid", alertInstance.RuleUID)
+		return nil
+	}
+
+	_, err = sess.Exec(
+		"INSERT INTO alert_instance (rule_org_id, rule_uid, labels, labels_hash, current_state, current_reason, current_state_since, current_state_end, last_eval_time, resolved_at, last_sent_at, result_fingerprint) VALUES (?,?,?,?,?,?,?,?,?,?,?,?)",
+		alertInstance.RuleOrgID,
+		alertInstance.RuleUID,
+		labelTupleJSON,
+		alertInstance.LabelsHash,
+		alertInstance.CurrentState,
+		alertInstance.CurrentReason,
+		alertInstance.CurrentStateSince.Unix(),
+		alertInstance.CurrentStateEnd.Unix(),
+		alertInstance.LastEvalTime.Unix(),
+		nullableTimeToUnix(alertInstance.ResolvedAt),
+		nullableTimeToUnix(alertInstance.LastSentAt),
+		alertInstance.ResultFingerprint,
+	)
+	if err != nil {
+		return fmt.Errorf("failed to insert into alert_instance table: %w", err)
+	}
+	return nil
+}
+
 // nullableTimeToUnix converts a nullable time.Time to nil, if it is nil, otherwise it converts the time.Time to a unix timestamp.
 func nullableTimeToUnix(t *time.Time) *int64 {
 	if t == nil {
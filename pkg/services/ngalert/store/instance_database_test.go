@@ -371,6 +371,37 @@ func TestIntegrationFullSync(t *testing.T) {
 	})
 }
 
+func TestIntegrationSaveAlertInstancesForRule(t *testing.T) {
+	ctx := context.Background()
+	_, dbstore := tests.SetupTestEnv(t, baseIntervalSeconds)
+
+	orgID := int64(1)
+	require.NoError(t, dbstore.FullSync(ctx, []models.AlertInstance{
+		generateTestAlertInstance(orgID, "a"),
+		generateTestAlertInstance(orgID, "b"),
+	}))
+
+	replacement := generateTestAlertInstance(orgID, "a")
+	replacement.LabelsHash = "def"
+	replacement.Labels = models.InstanceLabels{"test": "replaced"}
+	key := models.AlertRuleKeyWithGroup{AlertRuleKey: models.AlertRuleKey{OrgID: orgID, UID: "a"}}
+	require.NoError(t, dbstore.SaveAlertInstancesForRule(ctx, key, []models.AlertInstance{replacement}))
+
+	res, err := dbstore.ListAlertInstances(ctx, &models.ListAlertInstancesQuery{RuleOrgID: orgID, RuleUID: "a"})
+	require.NoError(t, err)
+	require.Len(t, res, 1)
+	require.Equal(t, "def", res[0].LabelsHash)
+
+	res, err = dbstore.ListAlertInstances(ctx, &models.ListAlertInstancesQuery{RuleOrgID: orgID, RuleUID: "b"})
+	require.NoError(t, err)
+	require.Len(t, res, 1, "the instances of other rules must be kept")
+
+	require.NoError(t, dbstore.SaveAlertInstancesForRule(ctx, key, nil))
+	res, err = dbstore.ListAlertInstances(ctx, &models.ListAlertInstancesQuery{RuleOrgID: orgID, RuleUID: "a"})
+	require.NoError(t, err)
+	require.Empty(t, res)
+}
+
 func generateTestAlertInstance(orgID int64, ruleID string) models.AlertInstance {
 	return models.AlertInstance{
 		AlertInstanceKey: models.AlertInstanceKey{
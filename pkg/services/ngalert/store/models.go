@@ -22,10 +22,12 @@ type alertRule struct {
 	NoDataState          string
 	ExecErrState         string
 	For                  time.Duration
+	KeepFiringFor        time.Duration
 	Annotations          string
 	Labels               string
 	IsPaused             bool
 	NotificationSettings string `xorm:"notification_settings"`
+	EvaluationPolicy     string `xorm:"evaluation_policy"`
 	Metadata             string `xorm:"metadata"`
 }
 
@@ -56,10 +58,12 @@ type alertRuleVersion struct {
 	// ideally this field should have been apimodels.ApiDuration
 	// but this is currently not possible because of circular dependencies
 	For                  time.Duration
+	KeepFiringFor        time.Duration
 	Annotations          string
 	Labels               string
 	IsPaused             bool
 	NotificationSettings string `xorm:"notification_settings"`
+	EvaluationPolicy     string `xorm:"evaluation_policy"`
 	Metadata             string `xorm:"metadata"`
 }
 
@@ -0,0 +1,196 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// notificationDelivery represents a record in alert_notification_delivery table
+type notificationDelivery struct {
+	ID               int64 `xorm:"pk autoincr 'id'"`
+	OrgID            int64 `xorm:"org_id"`
+	Receiver         string
+	IntegrationUID   string `xorm:"integration_uid"`
+	IntegrationType  string
+	GroupKey         string
+	Attempt          int
+	Status           string
+	Error            string
+	LatencyMs        int64
+	AlertCount       int
+	Payload          string
+	PayloadTruncated bool
+	ReplayOf         int64
+	ReplayedAt       int64
+	Created          int64
+}
+
+func (d notificationDelivery) TableName() string {
+	return "alert_notification_delivery"
+}
+
+func notificationDeliveryFromModel(d models.NotificationDelivery) notificationDelivery {
+	var replayedAt int64
+	if d.ReplayedAt != nil {
+		replayedAt = d.ReplayedAt.Unix()
+	}
+	return notificationDelivery{
+		ID:               d.ID,
+		OrgID:            d.OrgID,
+		Receiver:         d.Receiver,
+		IntegrationUID:   d.IntegrationUID,
+		IntegrationType:  d.IntegrationType,
+		GroupKey:         d.GroupKey,
+		Attempt:          d.Attempt,
+		Status:           string(d.Status),
+		Error:            d.Error,
+		LatencyMs:        d.Latency.Milliseconds(),
+		AlertCount:       d.AlertCount,
+		Payload:          d.Payload,
+		PayloadTruncated: d.PayloadTruncated,
+		ReplayOf:         d.ReplayOf,
+		ReplayedAt:       replayedAt,
+		Created:          d.Created.Unix(),
+	}
+}
+
+func notificationDeliveryToModel(d notificationDelivery) *models.NotificationDelivery {
+	var replayedAt *time.Time
+	if d.ReplayedAt > 0 {
+		t := time.Unix(d.ReplayedAt, 0)
+		replayedAt = &t
+	}
+	return &models.NotificationDelivery{
+		ID:               d.ID,
+		OrgID:            d.OrgID,
+		Receiver:         d.Receiver,
+		IntegrationUID:   d.IntegrationUID,
+		IntegrationType:  d.IntegrationType,
+		GroupKey:         d.GroupKey,
+		Attempt:          d.Attempt,
+		Status:           models.NotificationDeliveryStatus(d.Status),
+		Error:            d.Error,
+		Latency:          time.Duration(d.LatencyMs) * time.Millisecond,
+		AlertCount:       d.AlertCount,
+		Payload:          d.Payload,
+		PayloadTruncated: d.PayloadTruncated,
+		ReplayOf:         d.ReplayOf,
+		ReplayedAt:       replayedAt,
+		Created:          time.Unix(d.Created, 0),
+	}
+}
+
+// InsertNotificationDeliveries appends the deliveries to the log of notification deliveries.
+func (st DBstore) InsertNotificationDeliveries(ctx context.Context, deliveries []models.NotificationDelivery) error {
+	if len(deliveries) == 0 {
+		return nil
+	}
+	rows := make([]notificationDelivery, 0, len(deliveries))
+	for _, d := range deliveries {
+		rows = append(rows, notificationDeliveryFromModel(d))
+	}
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Insert(&rows)
+		return err
+	})
+}
+
+// GetNotificationDelivery returns the notification delivery with the given ID.
+// It returns models.ErrNotificationDeliveryNotFound if the organization has no such delivery.
+func (st DBstore) GetNotificationDelivery(ctx context.Context, orgID int64, id int64) (result *models.NotificationDelivery, err error) {
+	err = st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		d := notificationDelivery{}
+		has, err := sess.Where("org_id = ? AND id = ?", orgID, id).Get(&d)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return models.ErrNotificationDeliveryNotFound.Errorf("")
+		}
+		result = notificationDeliveryToModel(d)
+		return nil
+	})
+	return result, err
+}
+
+// ListNotificationDeliveries returns the notification deliveries that match the query, the most recent first.
+func (st DBstore) ListNotificationDeliveries(ctx context.Context, query models.ListNotificationDeliveriesQuery) (result []*models.NotificationDelivery, err error) {
+	err = st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		q := sess.Where("org_id = ?", query.OrgID)
+		if query.Receiver != "" {
+			q = q.And("receiver = ?", query.Receiver)
+		}
+		if query.IntegrationUID != "" {
+			q = q.And("integration_uid = ?", query.IntegrationUID)
+		}
+		if query.Status != "" {
+			q = q.And("status = ?", string(query.Status))
+		}
+		if !query.From.IsZero() {
+			q = q.And("created >= ?", query.From.Unix())
+		}
+		if !query.To.IsZero() {
+			q = q.And("created < ?", query.To.Unix())
+		}
+		q = q.Desc("created", "id")
+		if query.Limit > 0 {
+			q = q.Limit(query.Limit)
+		}
+		var deliveries []notificationDelivery
+		if err := q.Find(&deliveries); err != nil {
+			return err
+		}
+		result = make([]*models.NotificationDelivery, 0, len(deliveries))
+		for _, d := range deliveries {
+			result = append(result, notificationDeliveryToModel(d))
+		}
+		return nil
+	})
+	return result, err
+}
+
+// MarkNotificationDeliveryReplayed records that the delivery was replayed at the given time. It returns
+// models.ErrNotificationDeliveryAlreadyReplayed if the delivery was already marked, so that only one of
+// concurrent replays of the delivery is sent.
+func (st DBstore) MarkNotificationDeliveryReplayed(ctx context.Context, orgID int64, id int64, at time.Time) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		res, err := sess.Exec("UPDATE alert_notification_delivery SET replayed_at = ? WHERE org_id = ? AND id = ? AND replayed_at = 0", at.Unix(), orgID, id)
+		if err != nil {
+			return err
+		}
+		affected, err := res.RowsAffected()
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			return models.ErrNotificationDeliveryAlreadyReplayed
+		}
+		return nil
+	})
+}
+
+// UnmarkNotificationDeliveryReplayed records that the delivery was not replayed, so that it can be replayed again.
+func (st DBstore) UnmarkNotificationDeliveryReplayed(ctx context.Context, orgID int64, id int64) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Exec("UPDATE alert_notification_delivery SET replayed_at = 0 WHERE org_id = ? AND id = ?", orgID, id)
+		return err
+	})
+}
+
+// DeleteNotificationDeliveriesBefore deletes the notification deliveries of all organizations that were created before
+// the given time. It returns the number of deleted deliveries.
+func (st DBstore) DeleteNotificationDeliveriesBefore(ctx context.Context, before time.Time) (int64, error) {
+	var affected int64
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		res, err := sess.Exec("DELETE FROM alert_notification_delivery WHERE created < ?", before.Unix())
+		if err != nil {
+			return err
+		}
+		affected, err = res.RowsAffected()
+		return err
+	})
+	return affected, err
+}
@@ -0,0 +1,168 @@
+package store
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+func TestIntegrationNotificationDelivery(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	sqlStore := db.InitTestDB(t)
+	store := &DBstore{
+		SQLStore: sqlStore,
+		Logger:   log.NewNopLogger(),
+	}
+	ctx := context.Background()
+	orgID := int64(1)
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	deliveries := []models.NotificationDelivery{
+		{
+			OrgID:           orgID,
+			Receiver:        "pager",
+			IntegrationUID:  "pd",
+			IntegrationType: "pagerduty",
+			GroupKey:        `{}:{alertname="Foo"}`,
+			Attempt:         1,
+			Status:          models.NotificationDeliveryStatusFailed,
+			Error:           "503 Service Unavailable",
+			Latency:         1500 * time.Millisecond,
+			AlertCount:      1,
+			Payload:         `{"alerts":[]}`,
+			Created:         now,
+		},
+		{
+			OrgID:           orgID,
+			Receiver:        "pager",
+			IntegrationUID:  "pd",
+			IntegrationType: "pagerduty",
+			GroupKey:        `{}:{alertname="Foo"}`,
+			Attempt:         2,
+			Status:          models.NotificationDeliveryStatusSuccess,
+			Latency:         200 * time.Millisecond,
+			AlertCount:      1,
+			Payload:         `{"alerts":[]}`,
+			Created:         now.Add(time.Minute),
+		},
+		{
+			OrgID:            orgID,
+			Receiver:         "chat",
+			IntegrationUID:   "slack",
+			IntegrationType:  "slack",
+			Attempt:          1,
+			Status:           models.NotificationDeliveryStatusSuccess,
+			Payload:          `{"alerts":[`,
+			PayloadTruncated: true,
+			Created:          now.Add(time.Hour),
+		},
+		{
+			OrgID:           2,
+			Receiver:        "pager",
+			IntegrationUID:  "pd",
+			IntegrationType: "pagerduty",
+			Attempt:         1,
+			Status:          models.NotificationDeliveryStatusSuccess,
+			Payload:         `{"alerts":[]}`,
+			Created:         now,
+		},
+	}
+	require.NoError(t, store.InsertNotificationDeliveries(ctx, deliveries))
+
+	t.Run("lists the deliveries of the organization, most recent first", func(t *testing.T) {
+		result, err := store.ListNotificationDeliveries(ctx, models.ListNotificationDeliveriesQuery{OrgID: orgID})
+		require.NoError(t, err)
+		require.Len(t, result, 3)
+		require.Equal(t, "chat", result[0].Receiver)
+		require.True(t, result[0].PayloadTruncated)
+		require.Equal(t, 2, result[1].Attempt)
+		require.Equal(t, 1, result[2].Attempt)
+		require.Equal(t, "503 Service Unavailable", result[2].Error)
+		require.Equal(t, 1500*time.Millisecond, result[2].Latency)
+		require.Equal(t, now.Unix(), result[2].Created.Unix())
+	})
+
+	t.Run("filters the deliveries", func(t *testing.T) {
+		result, err := store.ListNotificationDeliveries(ctx, models.ListNotificationDeliveriesQuery{OrgID: orgID, Receiver: "pager", Status: models.NotificationDeliveryStatusFailed})
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.Equal(t, 1, result[0].Attempt)
+
+		result, err = store.ListNotificationDeliveries(ctx, models.ListNotificationDeliveriesQuery{OrgID: orgID, IntegrationUID: "pd", From: now.Add(time.Second), To: now.Add(time.Hour)})
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.Equal(t, 2, result[0].Attempt)
+
+		result, err = store.ListNotificationDeliveries(ctx, models.ListNotificationDeliveriesQuery{OrgID: orgID, Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+	})
+
+	t.Run("gets a delivery by ID", func(t *testing.T) {
+		list, err := store.ListNotificationDeliveries(ctx, models.ListNotificationDeliveriesQuery{OrgID: orgID, Receiver: "chat"})
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+
+		d, err := store.GetNotificationDelivery(ctx, orgID, list[0].ID)
+		require.NoError(t, err)
+		require.Equal(t, list[0], d)
+
+		_, err = store.GetNotificationDelivery(ctx, 2, list[0].ID)
+		require.ErrorIs(t, err, models.ErrNotificationDeliveryNotFound)
+	})
+
+	t.Run("marks a delivery as replayed only once", func(t *testing.T) {
+		list, err := store.ListNotificationDeliveries(ctx, models.ListNotificationDeliveriesQuery{OrgID: orgID, Status: models.NotificationDeliveryStatusFailed})
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		id := list[0].ID
+		require.Nil(t, list[0].ReplayedAt)
+
+		var wg sync.WaitGroup
+		var marked atomic.Int32
+		for i := 0; i < 5; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				err := store.MarkNotificationDeliveryReplayed(ctx, orgID, id, now.Add(2*time.Hour))
+				if err == nil {
+					marked.Add(1)
+					return
+				}
+				require.ErrorIs(t, err, models.ErrNotificationDeliveryAlreadyReplayed)
+			}()
+		}
+		wg.Wait()
+		require.Equal(t, int32(1), marked.Load())
+
+		d, err := store.GetNotificationDelivery(ctx, orgID, id)
+		require.NoError(t, err)
+		require.NotNil(t, d.ReplayedAt)
+		require.Equal(t, now.Add(2*time.Hour).Unix(), d.ReplayedAt.Unix())
+
+		require.NoError(t, store.UnmarkNotificationDeliveryReplayed(ctx, orgID, id))
+		d, err = store.GetNotificationDelivery(ctx, orgID, id)
+		require.NoError(t, err)
+		require.Nil(t, d.ReplayedAt)
+	})
+
+	t.Run("deletes the deliveries before the given time", func(t *testing.T) {
+		deleted, err := store.DeleteNotificationDeliveriesBefore(ctx, now.Add(time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, int64(2), deleted)
+
+		result, err := store.ListNotificationDeliveries(ctx, models.ListNotificationDeliveriesQuery{OrgID: orgID})
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+	})
+}
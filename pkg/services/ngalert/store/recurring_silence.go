@@ -0,0 +1,235 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/prometheus/alertmanager/timeinterval"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// recurringSilence represents a record in alert_recurring_silence table
+type recurringSilence struct {
+	ID              int64  `xorm:"pk autoincr 'id'"`
+	OrgID           int64  `xorm:"org_id"`
+	UID             string `xorm:"uid"`
+	Matchers        string
+	Comment         string
+	CreatedBy       string
+	Cron            string
+	DurationSeconds int64
+	Timezone        string
+	TimeIntervals   string
+	Version         int64
+	Updated         time.Time
+	LastStartsAt    int64
+	LastEndsAt      int64
+	LastSilenceID   string `xorm:"last_silence_id"`
+}
+
+func (s recurringSilence) TableName() string {
+	return "alert_recurring_silence"
+}
+
+func recurringSilenceFromModel(s models.RecurringSilence) (recurringSilence, error) {
+	matchers, err := json.Marshal(s.Matchers)
+	if err != nil {
+		return recurringSilence{}, fmt.Errorf("failed to marshal matchers: %w", err)
+	}
+	var intervals []byte
+	if len(s.TimeIntervals) > 0 {
+		if intervals, err = json.Marshal(s.TimeIntervals); err != nil {
+			return recurringSilence{}, fmt.Errorf("failed to marshal time intervals: %w", err)
+		}
+	}
+	row := recurringSilence{
+		ID:              s.ID,
+		OrgID:           s.OrgID,
+		UID:             s.UID,
+		Matchers:        string(matchers),
+		Comment:         s.Comment,
+		CreatedBy:       s.CreatedBy,
+		Cron:            s.Cron,
+		DurationSeconds: int64(s.Duration.Seconds()),
+		Timezone:        s.Timezone,
+		TimeIntervals:   string(intervals),
+		Version:         s.Version,
+		Updated:         s.Updated,
+	}
+	row.LastStartsAt, row.LastEndsAt, row.LastSilenceID = occurrenceToColumns(s.LastOccurrence)
+	return row, nil
+}
+
+func recurringSilenceToModel(s recurringSilence) (*models.RecurringSilence, error) {
+	var matchers amv2.Matchers
+	if err := json.Unmarshal([]byte(s.Matchers), &matchers); err != nil {
+		return nil, fmt.Errorf("failed to parse matchers: %w", err)
+	}
+	var intervals []timeinterval.TimeInterval
+	if s.TimeIntervals != "" {
+		if err := json.Unmarshal([]byte(s.TimeIntervals), &intervals); err != nil {
+			return nil, fmt.Errorf("failed to parse time intervals: %w", err)
+		}
+	}
+	result := &models.RecurringSilence{
+		ID:            s.ID,
+		OrgID:         s.OrgID,
+		UID:           s.UID,
+		Matchers:      matchers,
+		Comment:       s.Comment,
+		CreatedBy:     s.CreatedBy,
+		Cron:          s.Cron,
+		Duration:      time.Duration(s.DurationSeconds) * time.Second,
+		Timezone:      s.Timezone,
+		TimeIntervals: intervals,
+		Version:       s.Version,
+		Updated:       s.Updated,
+	}
+	if s.LastEndsAt != 0 {
+		result.LastOccurrence = models.RecurringSilenceOccurrence{
+			StartsAt:  time.Unix(s.LastStartsAt, 0),
+			EndsAt:    time.Unix(s.LastEndsAt, 0),
+			SilenceID: s.LastSilenceID,
+		}
+	}
+	return result, nil
+}
+
+func occurrenceToColumns(o models.RecurringSilenceOccurrence) (startsAt int64, endsAt int64, silenceID string) {
+	if o.EndsAt.IsZero() {
+		return 0, 0, o.SilenceID
+	}
+	return o.StartsAt.Unix(), o.EndsAt.Unix(), o.SilenceID
+}
+
+// GetRecurringSilence returns the recurring silence with the given UID.
+// It returns models.ErrRecurringSilenceNotFound if the recurring silence does not exist.
+func (st DBstore) GetRecurringSilence(ctx context.Context, orgID int64, uid string) (result *models.RecurringSilence, err error) {
+	err = st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		s := recurringSilence{OrgID: orgID, UID: uid}
+		has, err := sess.Get(&s)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return models.ErrRecurringSilenceNotFound.Errorf("")
+		}
+		result, err = recurringSilenceToModel(s)
+		return err
+	})
+	return result, err
+}
+
+// ListRecurringSilences returns the recurring silences of the organization. If orgID is 0, the recurring silences of
+// all organizations are returned.
+func (st DBstore) ListRecurringSilences(ctx context.Context, orgID int64) (result []*models.RecurringSilence, err error) {
+	err = st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		q := sess.Asc("org_id", "id")
+		if orgID != 0 {
+			q = q.Where("org_id = ?", orgID)
+		}
+		var silences []recurringSilence
+		if err := q.Find(&silences); err != nil {
+			return err
+		}
+		result = make([]*models.RecurringSilence, 0, len(silences))
+		for _, s := range silences {
+			m, err := recurringSilenceToModel(s)
+			if err != nil {
+				return fmt.Errorf("failed to convert recurring silence %s: %w", s.UID, err)
+			}
+			result = append(result, m)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// InsertRecurringSilence inserts the recurring silence.
+func (st DBstore) InsertRecurringSilence(ctx context.Context, s models.RecurringSilence) (*models.RecurringSilence, error) {
+	s.Version = 1
+	s.Updated = TimeNow()
+	s.LastOccurrence = models.RecurringSilenceOccurrence{}
+	row, err := recurringSilenceFromModel(s)
+	if err != nil {
+		return nil, err
+	}
+	err = st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Insert(&row)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	s.ID = row.ID
+	return &s, nil
+}
+
+// UpdateRecurringSilence updates the recurring silence and forgets its last occurrence, so that the new schedule
+// starts with the current occurrence. The version of the given recurring silence must be the current version,
+// otherwise ErrOptimisticLock is returned.
+func (st DBstore) UpdateRecurringSilence(ctx context.Context, s models.RecurringSilence) (*models.RecurringSilence, error) {
+	current := s.Version
+	s.Version++
+	s.Updated = TimeNow()
+	s.LastOccurrence = models.RecurringSilenceOccurrence{}
+	row, err := recurringSilenceFromModel(s)
+	if err != nil {
+		return nil, err
+	}
+	err = st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		affected, err := sess.Where("org_id = ? AND uid = ? AND version = ?", s.OrgID, s.UID, current).
+			Cols("matchers", "comment", "created_by", "cron", "duration_seconds", "timezone", "time_intervals", "version", "updated", "last_starts_at", "last_ends_at", "last_silence_id").
+			Update(&row)
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			exists, err := sess.Exist(&recurringSilence{OrgID: s.OrgID, UID: s.UID})
+			if err != nil {
+				return err
+			}
+			if !exists {
+				return models.ErrRecurringSilenceNotFound.Errorf("")
+			}
+			return fmt.Errorf("%w: recurring silence UID %s version %d", ErrOptimisticLock, s.UID, current)
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &s, nil
+}
+
+// DeleteRecurringSilence deletes the recurring silence. The silences of its occurrences are not deleted.
+func (st DBstore) DeleteRecurringSilence(ctx context.Context, orgID int64, uid string) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Exec("DELETE FROM alert_recurring_silence WHERE org_id = ? AND uid = ?", orgID, uid)
+		return err
+	})
+}
+
+// SetRecurringSilenceOccurrence replaces the last occurrence of the recurring silence with the given occurrence. The
+// replacement only happens if the version and the last occurrence of the given recurring silence are still the
+// stored ones, which lets a single replica claim an occurrence. It returns false if nothing was replaced.
+func (st DBstore) SetRecurringSilenceOccurrence(ctx context.Context, s models.RecurringSilence, occurrence models.RecurringSilenceOccurrence) (bool, error) {
+	lastStartsAt, lastEndsAt, lastSilenceID := occurrenceToColumns(s.LastOccurrence)
+	var row recurringSilence
+	row.LastStartsAt, row.LastEndsAt, row.LastSilenceID = occurrenceToColumns(occurrence)
+	var affected int64
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		var err error
+		affected, err = sess.Where("org_id = ? AND uid = ? AND version = ?", s.OrgID, s.UID, s.Version).
+			And("last_starts_at = ? AND last_ends_at = ? AND last_silence_id = ?", lastStartsAt, lastEndsAt, lastSilenceID).
+			Cols("last_starts_at", "last_ends_at", "last_silence_id").
+			Update(&row)
+		return err
+	})
+	return affected > 0, err
+}
@@ -0,0 +1,138 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+func TestIntegrationRecurringSilences(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	sqlStore := db.InitTestDB(t)
+	store := &DBstore{
+		SQLStore: sqlStore,
+		Logger:   log.NewNopLogger(),
+	}
+	ctx := context.Background()
+	orgID := int64(1)
+
+	silence := models.RecurringSilence{
+		OrgID: orgID,
+		UID:   "patching",
+		Matchers: amv2.Matchers{
+			{Name: util.Pointer("host"), Value: util.Pointer("db-1"), IsEqual: util.Pointer(true), IsRegex: util.Pointer(false)},
+		},
+		Comment:   "Weekly patching",
+		CreatedBy: "ops",
+		Cron:      "0 2 * * 0",
+		Duration:  2 * time.Hour,
+		Timezone:  "Europe/Berlin",
+	}
+	created, err := store.InsertRecurringSilence(ctx, silence)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, created.Version)
+
+	_, err = store.InsertRecurringSilence(ctx, models.RecurringSilence{
+		OrgID:     2,
+		UID:       "other",
+		Matchers:  silence.Matchers,
+		Comment:   "Other organization",
+		CreatedBy: "ops",
+		Cron:      "0 3 * * *",
+		Duration:  time.Hour,
+	})
+	require.NoError(t, err)
+
+	t.Run("should get the recurring silence", func(t *testing.T) {
+		got, err := store.GetRecurringSilence(ctx, orgID, "patching")
+		require.NoError(t, err)
+		require.Equal(t, silence.Matchers, got.Matchers)
+		require.Equal(t, silence.Cron, got.Cron)
+		require.Equal(t, silence.Duration, got.Duration)
+		require.Equal(t, silence.Timezone, got.Timezone)
+		require.Zero(t, got.LastOccurrence)
+	})
+
+	t.Run("should return not found if the recurring silence does not exist", func(t *testing.T) {
+		_, err := store.GetRecurringSilence(ctx, orgID, "unknown")
+		require.ErrorIs(t, err, models.ErrRecurringSilenceNotFound)
+	})
+
+	t.Run("should list the recurring silences of the organization or of all organizations", func(t *testing.T) {
+		silences, err := store.ListRecurringSilences(ctx, orgID)
+		require.NoError(t, err)
+		require.Len(t, silences, 1)
+		silences, err = store.ListRecurringSilences(ctx, 0)
+		require.NoError(t, err)
+		require.Len(t, silences, 2)
+	})
+
+	t.Run("should set the occurrence only if the last occurrence did not change", func(t *testing.T) {
+		s, err := store.GetRecurringSilence(ctx, orgID, "patching")
+		require.NoError(t, err)
+		occurrence := models.RecurringSilenceOccurrence{
+			StartsAt: time.Unix(1700000000, 0),
+			EndsAt:   time.Unix(1700007200, 0),
+		}
+		ok, err := store.SetRecurringSilenceOccurrence(ctx, *s, occurrence)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		// Another replica with the same view of the recurring silence cannot claim the occurrence again.
+		ok, err = store.SetRecurringSilenceOccurrence(ctx, *s, occurrence)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		s.LastOccurrence = occurrence
+		withSilence := occurrence
+		withSilence.SilenceID = "silence-1"
+		ok, err = store.SetRecurringSilenceOccurrence(ctx, *s, withSilence)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		got, err := store.GetRecurringSilence(ctx, orgID, "patching")
+		require.NoError(t, err)
+		require.True(t, withSilence.StartsAt.Equal(got.LastOccurrence.StartsAt))
+		require.True(t, withSilence.EndsAt.Equal(got.LastOccurrence.EndsAt))
+		require.Equal(t, "silence-1", got.LastOccurrence.SilenceID)
+	})
+
+	t.Run("should update the recurring silence and forget its last occurrence", func(t *testing.T) {
+		s, err := store.GetRecurringSilence(ctx, orgID, "patching")
+		require.NoError(t, err)
+		s.Cron = "0 4 * * 0"
+		updated, err := store.UpdateRecurringSilence(ctx, *s)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, updated.Version)
+
+		got, err := store.GetRecurringSilence(ctx, orgID, "patching")
+		require.NoError(t, err)
+		require.Equal(t, "0 4 * * 0", got.Cron)
+		require.Zero(t, got.LastOccurrence)
+
+		// The occurrence of the previous version cannot be claimed anymore.
+		ok, err := store.SetRecurringSilenceOccurrence(ctx, *s, models.RecurringSilenceOccurrence{StartsAt: time.Unix(1, 0), EndsAt: time.Unix(2, 0)})
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		_, err = store.UpdateRecurringSilence(ctx, *s)
+		require.True(t, errors.Is(err, ErrOptimisticLock))
+	})
+
+	t.Run("should delete the recurring silence", func(t *testing.T) {
+		require.NoError(t, store.DeleteRecurringSilence(ctx, orgID, "patching"))
+		_, err := store.GetRecurringSilence(ctx, orgID, "patching")
+		require.ErrorIs(t, err, models.ErrRecurringSilenceNotFound)
+	})
+}
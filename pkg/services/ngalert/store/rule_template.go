@@ -0,0 +1,476 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// ruleTemplate represents a record in alert_rule_template table
+type ruleTemplate struct {
+	ID          int64  `xorm:"pk autoincr 'id'"`
+	OrgID       int64  `xorm:"org_id"`
+	UID         string `xorm:"uid"`
+	Title       string
+	Description string
+	Parameters  string
+	Rules       string
+	Version     int64
+	Updated     time.Time
+}
+
+func (t ruleTemplate) TableName() string {
+	return "alert_rule_template"
+}
+
+// ruleTemplateVersion represents a record in alert_rule_template_version table
+type ruleTemplateVersion struct {
+	ID          int64  `xorm:"pk autoincr 'id'"`
+	OrgID       int64  `xorm:"org_id"`
+	TemplateUID string `xorm:"template_uid"`
+	Version     int64
+	Title       string
+	Description string
+	Parameters  string
+	Rules       string
+	Created     time.Time
+}
+
+func (t ruleTemplateVersion) TableName() string {
+	return "alert_rule_template_version"
+}
+
+// ruleTemplateInstance represents a record in alert_rule_template_instance table
+type ruleTemplateInstance struct {
+	ID              int64  `xorm:"pk autoincr 'id'"`
+	OrgID           int64  `xorm:"org_id"`
+	NamespaceUID    string `xorm:"namespace_uid"`
+	RuleGroup       string
+	TemplateUID     string `xorm:"template_uid"`
+	IntervalSeconds int64
+	ParameterValues string
+	TemplateVersion int64
+	Updated         time.Time
+}
+
+func (i ruleTemplateInstance) TableName() string {
+	return "alert_rule_template_instance"
+}
+
+type ruleTemplateParameter struct {
+	Name        string  `json:"name"`
+	Type        string  `json:"type"`
+	Description string  `json:"description,omitempty"`
+	Default     *string `json:"default,omitempty"`
+}
+
+// ruleTemplateRule is the stored form of a rule of a rule template.
+type ruleTemplateRule struct {
+	UID                  string                        `json:"uid"`
+	Title                string                        `json:"title"`
+	Condition            string                        `json:"condition"`
+	Data                 []models.AlertQuery           `json:"data"`
+	NoDataState          string                        `json:"no_data_state,omitempty"`
+	ExecErrState         string                        `json:"exec_err_state,omitempty"`
+	For                  time.Duration                 `json:"for,omitempty"`
+	KeepFiringFor        time.Duration                 `json:"keep_firing_for,omitempty"`
+	Annotations          map[string]string             `json:"annotations,omitempty"`
+	Labels               map[string]string             `json:"labels,omitempty"`
+	IsPaused             bool                          `json:"is_paused,omitempty"`
+	NotificationSettings []models.NotificationSettings `json:"notification_settings,omitempty"`
+	RecordMetric         string                        `json:"record_metric,omitempty"`
+	RecordFrom           string                        `json:"record_from,omitempty"`
+	EvaluationPolicy     *models.EvaluationPolicy      `json:"evaluation_policy,omitempty"`
+	Metadata             models.AlertRuleMetadata      `json:"metadata"`
+}
+
+func ruleTemplateFromModel(t models.RuleTemplate) (ruleTemplate, error) {
+	params := make([]ruleTemplateParameter, 0, len(t.Parameters))
+	for _, p := range t.Parameters {
+		params = append(params, ruleTemplateParameter{
+			Name:        p.Name,
+			Type:        string(p.Type),
+			Description: p.Description,
+			Default:     p.Default,
+		})
+	}
+	paramsJSON, err := json.Marshal(params)
+	if err != nil {
+		return ruleTemplate{}, fmt.Errorf("failed to marshal parameters: %w", err)
+	}
+	rules := make([]ruleTemplateRule, 0, len(t.Rules))
+	for _, r := range t.Rules {
+		rule := ruleTemplateRule{
+			UID:                  r.UID,
+			Title:                r.Title,
+			Condition:            r.Condition,
+			Data:                 r.Data,
+			NoDataState:          string(r.NoDataState),
+			ExecErrState:         string(r.ExecErrState),
+			For:                  r.For,
+			KeepFiringFor:        r.KeepFiringFor,
+			Annotations:          r.Annotations,
+			Labels:               r.Labels,
+			IsPaused:             r.IsPaused,
+			NotificationSettings: r.NotificationSettings,
+			EvaluationPolicy:     r.EvaluationPolicy,
+			Metadata:             r.Metadata,
+		}
+		if r.Record != nil {
+			rule.RecordMetric = r.Record.Metric
+			rule.RecordFrom = r.Record.From
+		}
+		rules = append(rules, rule)
+	}
+	rulesJSON, err := json.Marshal(rules)
+	if err != nil {
+		return ruleTemplate{}, fmt.Errorf("failed to marshal rules: %w", err)
+	}
+	return ruleTemplate{
+		ID:          t.ID,
+		OrgID:       t.OrgID,
+		UID:         t.UID,
+		Title:       t.Title,
+		Description: t.Description,
+		Parameters:  string(paramsJSON),
+		Rules:       string(rulesJSON),
+		Version:     t.Version,
+		Updated:     t.Updated,
+	}, nil
+}
+
+func ruleTemplateToModel(t ruleTemplate) (*models.RuleTemplate, error) {
+	var params []ruleTemplateParameter
+	if t.Parameters != "" {
+		if err := json.Unmarshal([]byte(t.Parameters), &params); err != nil {
+			return nil, fmt.Errorf("failed to parse parameters: %w", err)
+		}
+	}
+	var rules []ruleTemplateRule
+	if err := json.Unmarshal([]byte(t.Rules), &rules); err != nil {
+		return nil, fmt.Errorf("failed to parse rules: %w", err)
+	}
+	result := &models.RuleTemplate{
+		ID:          t.ID,
+		OrgID:       t.OrgID,
+		UID:         t.UID,
+		Title:       t.Title,
+		Description: t.Description,
+		Parameters:  make([]models.RuleTemplateParameter, 0, len(params)),
+		Rules:       make([]models.AlertRule, 0, len(rules)),
+		Version:     t.Version,
+		Updated:     t.Updated,
+	}
+	for _, p := range params {
+		result.Parameters = append(result.Parameters, models.RuleTemplateParameter{
+			Name:        p.Name,
+			Type:        models.RuleTemplateParameterType(p.Type),
+			Description: p.Description,
+			Default:     p.Default,
+		})
+	}
+	for _, r := range rules {
+		rule := models.AlertRule{
+			OrgID:                t.OrgID,
+			UID:                  r.UID,
+			Title:                r.Title,
+			Condition:            r.Condition,
+			Data:                 r.Data,
+			NoDataState:          models.NoDataState(r.NoDataState),
+			ExecErrState:         models.ExecutionErrorState(r.ExecErrState),
+			For:                  r.For,
+			KeepFiringFor:        r.KeepFiringFor,
+			Annotations:          r.Annotations,
+			Labels:               r.Labels,
+			IsPaused:             r.IsPaused,
+			NotificationSettings: r.NotificationSettings,
+			EvaluationPolicy:     r.EvaluationPolicy,
+			Metadata:             r.Metadata,
+		}
+		if r.RecordMetric != "" || r.RecordFrom != "" {
+			rule.Record = &models.Record{Metric: r.RecordMetric, From: r.RecordFrom}
+		}
+		result.Rules = append(result.Rules, rule)
+	}
+	return result, nil
+}
+
+// GetRuleTemplate returns the rule template with the given UID.
+// It returns models.ErrRuleTemplateNotFound if the template does not exist.
+func (st DBstore) GetRuleTemplate(ctx context.Context, orgID int64, uid string) (result *models.RuleTemplate, err error) {
+	err = st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		t := ruleTemplate{OrgID: orgID, UID: uid}
+		has, err := sess.Get(&t)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return models.ErrRuleTemplateNotFound.Errorf("")
+		}
+		result, err = ruleTemplateToModel(t)
+		return err
+	})
+	return result, err
+}
+
+// ListRuleTemplates returns the rule templates of the organization sorted by title.
+func (st DBstore) ListRuleTemplates(ctx context.Context, orgID int64) (result []*models.RuleTemplate, err error) {
+	err = st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		var templates []ruleTemplate
+		if err := sess.Where("org_id = ?", orgID).Asc("title", "uid").Find(&templates); err != nil {
+			return err
+		}
+		result = make([]*models.RuleTemplate, 0, len(templates))
+		for _, t := range templates {
+			m, err := ruleTemplateToModel(t)
+			if err != nil {
+				return fmt.Errorf("failed to convert rule template %s: %w", t.UID, err)
+			}
+			result = append(result, m)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// InsertRuleTemplate inserts the first version of the rule template.
+// It returns models.ErrRuleTemplateExists if a template with the same UID exists.
+func (st DBstore) InsertRuleTemplate(ctx context.Context, t models.RuleTemplate) (*models.RuleTemplate, error) {
+	t.Version = 1
+	t.Updated = TimeNow()
+	row, err := ruleTemplateFromModel(t)
+	if err != nil {
+		return nil, err
+	}
+	err = st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		exists, err := sess.Exist(&ruleTemplate{OrgID: t.OrgID, UID: t.UID})
+		if err != nil {
+			return err
+		}
+		if exists {
+			return models.ErrRuleTemplateExists.Errorf("")
+		}
+		if _, err := sess.Insert(&row); err != nil {
+			return err
+		}
+		_, err = sess.Insert(ruleTemplateToVersion(row))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	t.ID = row.ID
+	return &t, nil
+}
+
+// UpdateRuleTemplate saves a new version of the rule template. The version of the given template must be
+// the current version, otherwise ErrOptimisticLock is returned.
+func (st DBstore) UpdateRuleTemplate(ctx context.Context, t models.RuleTemplate) (*models.RuleTemplate, error) {
+	current := t.Version
+	t.Version++
+	t.Updated = TimeNow()
+	row, err := ruleTemplateFromModel(t)
+	if err != nil {
+		return nil, err
+	}
+	err = st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		affected, err := sess.Where("org_id = ? AND uid = ? AND version = ?", t.OrgID, t.UID, current).
+			Cols("title", "description", "parameters", "rules", "version", "updated").
+			Update(&row)
+		if err != nil {
+			return err
+		}
+		if affected == 0 {
+			exists, err := sess.Exist(&ruleTemplate{OrgID: t.OrgID, UID: t.UID})
+			if err != nil {
+				return err
+			}
+			if !exists {
+				return models.ErrRuleTemplateNotFound.Errorf("")
+			}
+			return fmt.Errorf("%w: rule template UID %s version %d", ErrOptimisticLock, t.UID, current)
+		}
+		_, err = sess.Insert(ruleTemplateToVersion(row))
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return &t, nil
+}
+
+// DeleteRuleTemplate deletes the rule template and its versions.
+func (st DBstore) DeleteRuleTemplate(ctx context.Context, orgID int64, uid string) error {
+	return st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		if _, err := sess.Exec("DELETE FROM alert_rule_template WHERE org_id = ? AND uid = ?", orgID, uid); err != nil {
+			return err
+		}
+		_, err := sess.Exec("DELETE FROM alert_rule_template_version WHERE org_id = ? AND template_uid = ?", orgID, uid)
+		return err
+	})
+}
+
+// GetRuleTemplateVersions returns all versions of the rule template, the latest first.
+func (st DBstore) GetRuleTemplateVersions(ctx context.Context, orgID int64, uid string) (result []*models.RuleTemplate, err error) {
+	err = st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		var versions []ruleTemplateVersion
+		if err := sess.Where("org_id = ? AND template_uid = ?", orgID, uid).Desc("version").Find(&versions); err != nil {
+			return err
+		}
+		result = make([]*models.RuleTemplate, 0, len(versions))
+		for _, v := range versions {
+			m, err := ruleTemplateToModel(ruleTemplate{
+				OrgID:       v.OrgID,
+				UID:         v.TemplateUID,
+				Title:       v.Title,
+				Description: v.Description,
+				Parameters:  v.Parameters,
+				Rules:       v.Rules,
+				Version:     v.Version,
+				Updated:     v.Created,
+			})
+			if err != nil {
+				return fmt.Errorf("failed to convert version %d of rule template %s: %w", v.Version, uid, err)
+			}
+			result = append(result, m)
+		}
+		return nil
+	})
+	return result, err
+}
+
+func ruleTemplateToVersion(t ruleTemplate) *ruleTemplateVersion {
+	return &ruleTemplateVersion{
+		OrgID:       t.OrgID,
+		TemplateUID: t.UID,
+		Version:     t.Version,
+		Title:       t.Title,
+		Description: t.Description,
+		Parameters:  t.Parameters,
+		Rules:       t.Rules,
+		Created:     t.Updated,
+	}
+}
+
+func ruleTemplateInstanceToModel(i ruleTemplateInstance) (*models.RuleTemplateInstance, error) {
+	var values map[string]string
+	if i.ParameterValues != "" {
+		if err := json.Unmarshal([]byte(i.ParameterValues), &values); err != nil {
+			return nil, fmt.Errorf("failed to parse parameter values: %w", err)
+		}
+	}
+	return &models.RuleTemplateInstance{
+		OrgID:           i.OrgID,
+		NamespaceUID:    i.NamespaceUID,
+		RuleGroup:       i.RuleGroup,
+		TemplateUID:     i.TemplateUID,
+		IntervalSeconds: i.IntervalSeconds,
+		Values:          values,
+		TemplateVersion: i.TemplateVersion,
+		Updated:         i.Updated,
+	}, nil
+}
+
+// GetRuleTemplateInstance returns the rule template instance of the rule group.
+// It returns models.ErrRuleTemplateInstanceNotFound if the rule group is not created from a template.
+func (st DBstore) GetRuleTemplateInstance(ctx context.Context, key models.AlertRuleGroupKey) (result *models.RuleTemplateInstance, err error) {
+	err = st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		i := ruleTemplateInstance{OrgID: key.OrgID, NamespaceUID: key.NamespaceUID, RuleGroup: key.RuleGroup}
+		has, err := sess.Get(&i)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return models.ErrRuleTemplateInstanceNotFound.Errorf("")
+		}
+		result, err = ruleTemplateInstanceToModel(i)
+		return err
+	})
+	return result, err
+}
+
+// ListRuleTemplateInstances returns the instances of the rule template. If templateUID is empty, the instances of all templates are returned.
+func (st DBstore) ListRuleTemplateInstances(ctx context.Context, orgID int64, templateUID string) (result []*models.RuleTemplateInstance, err error) {
+	err = st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		q := sess.Where("org_id = ?", orgID)
+		if templateUID != "" {
+			q = q.And("template_uid = ?", templateUID)
+		}
+		var instances []ruleTemplateInstance
+		if err := q.Asc("namespace_uid", "rule_group").Find(&instances); err != nil {
+			return err
+		}
+		result = make([]*models.RuleTemplateInstance, 0, len(instances))
+		for _, i := range instances {
+			m, err := ruleTemplateInstanceToModel(i)
+			if err != nil {
+				return fmt.Errorf("failed to convert rule template instance %s/%s: %w", i.NamespaceUID, i.RuleGroup, err)
+			}
+			result = append(result, m)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// UpsertRuleTemplateInstance saves the rule template instance of a rule group.
+func (st DBstore) UpsertRuleTemplateInstance(ctx context.Context, instance models.RuleTemplateInstance) error {
+	values, err := json.Marshal(instance.Values)
+	if err != nil {
+		return fmt.Errorf("failed to marshal parameter values: %w", err)
+	}
+	row := ruleTemplateInstance{
+		OrgID:           instance.OrgID,
+		NamespaceUID:    instance.NamespaceUID,
+		RuleGroup:       instance.RuleGroup,
+		TemplateUID:     instance.TemplateUID,
+		IntervalSeconds: instance.IntervalSeconds,
+		ParameterValues: string(values),
+		TemplateVersion: instance.TemplateVersion,
+		Updated:         TimeNow(),
+	}
+	return st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		affected, err := sess.Where("org_id = ? AND namespace_uid = ? AND rule_group = ?", row.OrgID, row.NamespaceUID, row.RuleGroup).
+			Cols("template_uid", "interval_seconds", "parameter_values", "template_version", "updated").
+			Update(&row)
+		if err != nil {
+			return err
+		}
+		if affected > 0 {
+			return nil
+		}
+		_, err = sess.Insert(&row)
+		return err
+	})
+}
+
+// DeleteRuleTemplateInstance deletes the rule template instance of the rule group. The rules of the group are not deleted.
+func (st DBstore) DeleteRuleTemplateInstance(ctx context.Context, key models.AlertRuleGroupKey) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Exec("DELETE FROM alert_rule_template_instance WHERE org_id = ? AND namespace_uid = ? AND rule_group = ?", key.OrgID, key.NamespaceUID, key.RuleGroup)
+		return err
+	})
+}
+
+// DeleteOrphanedRuleTemplateInstances deletes the rule template instances of the organization whose rule group no
+// longer exists, because it was deleted, renamed or moved without the rule template API, or its folder was deleted.
+func (st DBstore) DeleteOrphanedRuleTemplateInstances(ctx context.Context, orgID int64) (deleted int64, err error) {
+	err = st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		res, err := sess.Exec(`DELETE FROM alert_rule_template_instance WHERE org_id = ? AND NOT EXISTS (
+			SELECT 1 FROM alert_rule
+			WHERE alert_rule.org_id = alert_rule_template_instance.org_id
+				AND alert_rule.namespace_uid = alert_rule_template_instance.namespace_uid
+				AND alert_rule.rule_group = alert_rule_template_instance.rule_group
+		)`, orgID)
+		if err != nil {
+			return err
+		}
+		deleted, err = res.RowsAffected()
+		return err
+	})
+	return deleted, err
+}
@@ -0,0 +1,163 @@
+package store
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/util"
+)
+
+func TestIntegrationRuleTemplates(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	sqlStore := db.InitTestDB(t)
+	store := &DBstore{
+		SQLStore: sqlStore,
+		Logger:   log.NewNopLogger(),
+	}
+	ctx := context.Background()
+	orgID := int64(1)
+
+	rule := models.RuleGen.With(models.RuleMuts.WithOrgID(orgID)).GenerateRef()
+	rule.UID = "latency"
+	rule.Record = nil
+	rule.Labels = map[string]string{"service": "${service}"}
+	rule.EvaluationPolicy = &models.EvaluationPolicy{Timeout: 10 * time.Second}
+	tmpl := models.RuleTemplate{
+		OrgID: orgID,
+		UID:   "service",
+		Title: "Service",
+		Parameters: []models.RuleTemplateParameter{
+			{Name: "service", Type: models.RuleTemplateParameterString},
+			{Name: "threshold", Type: models.RuleTemplateParameterNumber, Default: util.Pointer("0.5")},
+		},
+		Rules: []models.AlertRule{*rule},
+	}
+
+	created, err := store.InsertRuleTemplate(ctx, tmpl)
+	require.NoError(t, err)
+	require.EqualValues(t, 1, created.Version)
+
+	t.Run("should not insert a template with the same UID", func(t *testing.T) {
+		_, err := store.InsertRuleTemplate(ctx, tmpl)
+		require.ErrorIs(t, err, models.ErrRuleTemplateExists)
+	})
+
+	t.Run("should get the template", func(t *testing.T) {
+		got, err := store.GetRuleTemplate(ctx, orgID, "service")
+		require.NoError(t, err)
+		require.Equal(t, tmpl.Parameters, got.Parameters)
+		require.Len(t, got.Rules, 1)
+		require.Equal(t, rule.Title, got.Rules[0].Title)
+		require.Equal(t, rule.Labels, got.Rules[0].Labels)
+		require.JSONEq(t, string(rule.Data[0].Model), string(got.Rules[0].Data[0].Model))
+		require.Equal(t, rule.EvaluationPolicy, got.Rules[0].EvaluationPolicy)
+
+		_, err = store.GetRuleTemplate(ctx, orgID, "missing")
+		require.ErrorIs(t, err, models.ErrRuleTemplateNotFound)
+	})
+
+	t.Run("update should create a new version", func(t *testing.T) {
+		update := *created
+		update.Title = "Service v2"
+		updated, err := store.UpdateRuleTemplate(ctx, update)
+		require.NoError(t, err)
+		require.EqualValues(t, 2, updated.Version)
+
+		_, err = store.UpdateRuleTemplate(ctx, update)
+		require.True(t, errors.Is(err, ErrOptimisticLock))
+
+		versions, err := store.GetRuleTemplateVersions(ctx, orgID, "service")
+		require.NoError(t, err)
+		require.Len(t, versions, 2)
+		require.EqualValues(t, 2, versions[0].Version)
+		require.Equal(t, "Service v2", versions[0].Title)
+		require.EqualValues(t, 1, versions[1].Version)
+		require.Equal(t, "Service", versions[1].Title)
+
+		list, err := store.ListRuleTemplates(ctx, orgID)
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		require.Equal(t, "Service v2", list[0].Title)
+	})
+
+	t.Run("should save instances", func(t *testing.T) {
+		instance := models.RuleTemplateInstance{
+			OrgID:           orgID,
+			NamespaceUID:    "folder",
+			RuleGroup:       "checkout",
+			TemplateUID:     "service",
+			IntervalSeconds: 60,
+			Values:          map[string]string{"service": "checkout"},
+			TemplateVersion: 1,
+		}
+		require.NoError(t, store.UpsertRuleTemplateInstance(ctx, instance))
+		instance.TemplateVersion = 2
+		instance.Values["threshold"] = "0.9"
+		require.NoError(t, store.UpsertRuleTemplateInstance(ctx, instance))
+		other := instance
+		other.RuleGroup = "cart"
+		other.Values = map[string]string{"service": "cart"}
+		require.NoError(t, store.UpsertRuleTemplateInstance(ctx, other))
+
+		got, err := store.GetRuleTemplateInstance(ctx, instance.GetGroupKey())
+		require.NoError(t, err)
+		require.EqualValues(t, 2, got.TemplateVersion)
+		require.Equal(t, map[string]string{"service": "checkout", "threshold": "0.9"}, got.Values)
+
+		list, err := store.ListRuleTemplateInstances(ctx, orgID, "service")
+		require.NoError(t, err)
+		require.Len(t, list, 2)
+		require.Equal(t, "cart", list[0].RuleGroup)
+		require.Equal(t, "checkout", list[1].RuleGroup)
+
+		require.NoError(t, store.DeleteRuleTemplateInstance(ctx, other.GetGroupKey()))
+		_, err = store.GetRuleTemplateInstance(ctx, other.GetGroupKey())
+		require.ErrorIs(t, err, models.ErrRuleTemplateInstanceNotFound)
+	})
+
+	t.Run("should delete instances whose rule group does not exist", func(t *testing.T) {
+		createRule(t, store, models.RuleGen.With(
+			models.RuleMuts.WithOrgID(orgID),
+			models.RuleMuts.WithNamespaceUID("folder"),
+			models.RuleMuts.WithGroupName("checkout"),
+		))
+		for _, group := range []string{"checkout", "deleted"} {
+			require.NoError(t, store.UpsertRuleTemplateInstance(ctx, models.RuleTemplateInstance{
+				OrgID:        orgID,
+				NamespaceUID: "folder",
+				RuleGroup:    group,
+				TemplateUID:  "service",
+				Values:       map[string]string{"service": group},
+			}))
+		}
+
+		deleted, err := store.DeleteOrphanedRuleTemplateInstances(ctx, orgID)
+		require.NoError(t, err)
+		require.EqualValues(t, 1, deleted)
+
+		list, err := store.ListRuleTemplateInstances(ctx, orgID, "service")
+		require.NoError(t, err)
+		require.Len(t, list, 1)
+		require.Equal(t, "checkout", list[0].RuleGroup)
+
+		require.NoError(t, store.DeleteRuleTemplateInstance(ctx, list[0].GetGroupKey()))
+	})
+
+	t.Run("should delete the template and its versions", func(t *testing.T) {
+		require.NoError(t, store.DeleteRuleTemplate(ctx, orgID, "service"))
+		_, err := store.GetRuleTemplate(ctx, orgID, "service")
+		require.ErrorIs(t, err, models.ErrRuleTemplateNotFound)
+		versions, err := store.GetRuleTemplateVersions(ctx, orgID, "service")
+		require.NoError(t, err)
+		require.Empty(t, versions)
+	})
+}
@@ -0,0 +1,50 @@
+package store
+
+import (
+	"context"
+	"time"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+)
+
+// HeartbeatSchedulerReplica records that the scheduler replica with the given name is alive at the given time.
+func (st DBstore) HeartbeatSchedulerReplica(ctx context.Context, name string, at time.Time) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		upsertSQL := st.SQLStore.GetDialect().UpsertSQL(
+			"alert_scheduler_replica",
+			[]string{"name"},
+			[]string{"name", "heartbeat_at"},
+		)
+		_, err := sess.SQL(upsertSQL, name, at.Unix()).Query()
+		return err
+	})
+}
+
+// ListSchedulerReplicas returns the names of the scheduler replicas that sent a heartbeat since the given time, sorted by name.
+func (st DBstore) ListSchedulerReplicas(ctx context.Context, since time.Time) ([]string, error) {
+	var names []string
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.Table("alert_scheduler_replica").
+			Where("heartbeat_at >= ?", since.Unix()).
+			Asc("name").
+			Cols("name").
+			Find(&names)
+	})
+	return names, err
+}
+
+// DeleteSchedulerReplica deletes the scheduler replica with the given name.
+func (st DBstore) DeleteSchedulerReplica(ctx context.Context, name string) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Exec("DELETE FROM alert_scheduler_replica WHERE name = ?", name)
+		return err
+	})
+}
+
+// DeleteExpiredSchedulerReplicas deletes the scheduler replicas that did not send a heartbeat since the given time.
+func (st DBstore) DeleteExpiredSchedulerReplicas(ctx context.Context, before time.Time) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Exec("DELETE FROM alert_scheduler_replica WHERE heartbeat_at < ?", before.Unix())
+		return err
+	})
+}
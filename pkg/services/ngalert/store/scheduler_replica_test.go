@@ -0,0 +1,48 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
+)
+
+func TestIntegrationSchedulerReplicas(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	sqlStore := db.InitTestDB(t)
+	store := &DBstore{
+		SQLStore: sqlStore,
+		Logger:   log.NewNopLogger(),
+	}
+	ctx := context.Background()
+	now := time.Unix(1_700_000_000, 0)
+
+	require.NoError(t, store.HeartbeatSchedulerReplica(ctx, "replica-b", now.Add(-time.Minute)))
+	require.NoError(t, store.HeartbeatSchedulerReplica(ctx, "replica-a", now.Add(-time.Minute)))
+	require.NoError(t, store.HeartbeatSchedulerReplica(ctx, "replica-c", now.Add(-time.Hour)))
+
+	names, err := store.ListSchedulerReplicas(ctx, now.Add(-2*time.Minute))
+	require.NoError(t, err)
+	require.Equal(t, []string{"replica-a", "replica-b"}, names)
+
+	t.Run("heartbeat should update the replica", func(t *testing.T) {
+		require.NoError(t, store.HeartbeatSchedulerReplica(ctx, "replica-c", now))
+		names, err := store.ListSchedulerReplicas(ctx, now.Add(-2*time.Minute))
+		require.NoError(t, err)
+		require.Equal(t, []string{"replica-a", "replica-b", "replica-c"}, names)
+	})
+
+	t.Run("should delete replicas", func(t *testing.T) {
+		require.NoError(t, store.DeleteSchedulerReplica(ctx, "replica-a"))
+		require.NoError(t, store.DeleteExpiredSchedulerReplicas(ctx, now.Add(-30*time.Second)))
+		names, err := store.ListSchedulerReplicas(ctx, time.Unix(0, 0))
+		require.NoError(t, err)
+		require.Equal(t, []string{"replica-c"}, names)
+	})
+}
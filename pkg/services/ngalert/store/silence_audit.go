@@ -0,0 +1,268 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// silenceAuditEntry represents a record in alert_silence_audit table
+type silenceAuditEntry struct {
+	ID            int64  `xorm:"pk autoincr 'id'"`
+	OrgID         int64  `xorm:"org_id"`
+	SilenceID     string `xorm:"silence_id"`
+	Action        string
+	ActorUID      string `xorm:"actor_uid"`
+	ActorLogin    string
+	Created       int64
+	Silence       string
+	Changes       string
+	MatchedAlerts string
+}
+
+func (e silenceAuditEntry) TableName() string {
+	return "alert_silence_audit"
+}
+
+func silenceAuditEntryFromModel(e models.SilenceAuditEntry) (silenceAuditEntry, error) {
+	silence, err := json.Marshal(e.Silence)
+	if err != nil {
+		return silenceAuditEntry{}, fmt.Errorf("failed to marshal silence: %w", err)
+	}
+	row := silenceAuditEntry{
+		ID:         e.ID,
+		OrgID:      e.OrgID,
+		SilenceID:  e.SilenceID,
+		Action:     string(e.Action),
+		ActorUID:   e.ActorUID,
+		ActorLogin: e.ActorLogin,
+		Created:    e.Created.Unix(),
+		Silence:    string(silence),
+	}
+	if len(e.Changes) > 0 {
+		changes, err := json.Marshal(e.Changes)
+		if err != nil {
+			return silenceAuditEntry{}, fmt.Errorf("failed to marshal changes: %w", err)
+		}
+		row.Changes = string(changes)
+	}
+	if len(e.MatchedAlerts) > 0 {
+		alerts, err := json.Marshal(e.MatchedAlerts)
+		if err != nil {
+			return silenceAuditEntry{}, fmt.Errorf("failed to marshal matched alerts: %w", err)
+		}
+		row.MatchedAlerts = string(alerts)
+	}
+	return row, nil
+}
+
+func silenceAuditEntryToModel(e silenceAuditEntry) (*models.SilenceAuditEntry, error) {
+	result := &models.SilenceAuditEntry{
+		ID:         e.ID,
+		OrgID:      e.OrgID,
+		SilenceID:  e.SilenceID,
+		Action:     models.SilenceAuditAction(e.Action),
+		ActorUID:   e.ActorUID,
+		ActorLogin: e.ActorLogin,
+		Created:    time.Unix(e.Created, 0),
+	}
+	if err := json.Unmarshal([]byte(e.Silence), &result.Silence); err != nil {
+		return nil, fmt.Errorf("failed to parse silence: %w", err)
+	}
+	if e.Changes != "" {
+		if err := json.Unmarshal([]byte(e.Changes), &result.Changes); err != nil {
+			return nil, fmt.Errorf("failed to parse changes: %w", err)
+		}
+	}
+	if e.MatchedAlerts != "" {
+		if err := json.Unmarshal([]byte(e.MatchedAlerts), &result.MatchedAlerts); err != nil {
+			return nil, fmt.Errorf("failed to parse matched alerts: %w", err)
+		}
+	}
+	return result, nil
+}
+
+// InsertSilenceAuditEntry appends the entry to the audit log of silences.
+func (st DBstore) InsertSilenceAuditEntry(ctx context.Context, e models.SilenceAuditEntry) error {
+	row, err := silenceAuditEntryFromModel(e)
+	if err != nil {
+		return err
+	}
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Insert(&row)
+		return err
+	})
+}
+
+// ListSilenceAuditEntries returns the entries of the audit log of silences that match the query, the most recent first.
+func (st DBstore) ListSilenceAuditEntries(ctx context.Context, query models.ListSilenceAuditEntriesQuery) (result []*models.SilenceAuditEntry, err error) {
+	err = st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		q := sess.Where("org_id = ?", query.OrgID)
+		if query.SilenceID != "" {
+			q = q.And("silence_id = ?", query.SilenceID)
+		}
+		if query.ActorLogin != "" {
+			q = q.And("actor_login = ?", query.ActorLogin)
+		}
+		if !query.From.IsZero() {
+			q = q.And("created >= ?", query.From.Unix())
+		}
+		if !query.To.IsZero() {
+			q = q.And("created < ?", query.To.Unix())
+		}
+		q = q.Desc("created", "id")
+		if query.Limit > 0 {
+			q = q.Limit(query.Limit, query.Offset)
+		}
+		var entries []silenceAuditEntry
+		if err := q.Find(&entries); err != nil {
+			return err
+		}
+		result = make([]*models.SilenceAuditEntry, 0, len(entries))
+		for _, e := range entries {
+			m, err := silenceAuditEntryToModel(e)
+			if err != nil {
+				return fmt.Errorf("failed to convert audit entry %d: %w", e.ID, err)
+			}
+			result = append(result, m)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// silenceExpiryNotification represents a record in alert_silence_expiry_notification table
+type silenceExpiryNotification struct {
+	ID             int64  `xorm:"pk autoincr 'id'"`
+	OrgID          int64  `xorm:"org_id"`
+	SilenceID      string `xorm:"silence_id"`
+	Receiver       string
+	BeforeSeconds  int64
+	NotifiedEndsAt int64
+}
+
+func (n silenceExpiryNotification) TableName() string {
+	return "alert_silence_expiry_notification"
+}
+
+func silenceExpiryNotificationToModel(n silenceExpiryNotification) *models.SilenceExpiryNotification {
+	result := &models.SilenceExpiryNotification{
+		ID:        n.ID,
+		OrgID:     n.OrgID,
+		SilenceID: n.SilenceID,
+		Receiver:  n.Receiver,
+		Before:    time.Duration(n.BeforeSeconds) * time.Second,
+	}
+	if n.NotifiedEndsAt != 0 {
+		result.NotifiedEndsAt = time.Unix(n.NotifiedEndsAt, 0)
+	}
+	return result
+}
+
+func unixOrZero(t time.Time) int64 {
+	if t.IsZero() {
+		return 0
+	}
+	return t.Unix()
+}
+
+// GetSilenceExpiryNotification returns the expiry notification of the silence.
+// It returns models.ErrSilenceExpiryNotificationNotFound if the silence has no expiry notification.
+func (st DBstore) GetSilenceExpiryNotification(ctx context.Context, orgID int64, silenceID string) (result *models.SilenceExpiryNotification, err error) {
+	err = st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		n := silenceExpiryNotification{OrgID: orgID, SilenceID: silenceID}
+		has, err := sess.Get(&n)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return models.ErrSilenceExpiryNotificationNotFound.Errorf("")
+		}
+		result = silenceExpiryNotificationToModel(n)
+		return nil
+	})
+	return result, err
+}
+
+// ListSilenceExpiryNotifications returns the expiry notifications of the organization. If orgID is 0, the expiry
+// notifications of all organizations are returned.
+func (st DBstore) ListSilenceExpiryNotifications(ctx context.Context, orgID int64) (result []*models.SilenceExpiryNotification, err error) {
+	err = st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		q := sess.Asc("org_id", "id")
+		if orgID != 0 {
+			q = q.Where("org_id = ?", orgID)
+		}
+		var notifications []silenceExpiryNotification
+		if err := q.Find(&notifications); err != nil {
+			return err
+		}
+		result = make([]*models.SilenceExpiryNotification, 0, len(notifications))
+		for _, n := range notifications {
+			result = append(result, silenceExpiryNotificationToModel(n))
+		}
+		return nil
+	})
+	return result, err
+}
+
+// SaveSilenceExpiryNotification creates or replaces the expiry notification of the silence. The notification is sent
+// again even if it was sent for the current end of the silence.
+func (st DBstore) SaveSilenceExpiryNotification(ctx context.Context, n models.SilenceExpiryNotification) (*models.SilenceExpiryNotification, error) {
+	row := silenceExpiryNotification{
+		OrgID:         n.OrgID,
+		SilenceID:     n.SilenceID,
+		Receiver:      n.Receiver,
+		BeforeSeconds: int64(n.Before.Seconds()),
+	}
+	err := st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		if _, err := sess.Exec("DELETE FROM alert_silence_expiry_notification WHERE org_id = ? AND silence_id = ?", n.OrgID, n.SilenceID); err != nil {
+			return err
+		}
+		_, err := sess.Insert(&row)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	return silenceExpiryNotificationToModel(row), nil
+}
+
+// DeleteSilenceExpiryNotification deletes the expiry notification of the silence.
+func (st DBstore) DeleteSilenceExpiryNotification(ctx context.Context, orgID int64, silenceID string) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Exec("DELETE FROM alert_silence_expiry_notification WHERE org_id = ? AND silence_id = ?", orgID, silenceID)
+		return err
+	})
+}
+
+// MoveSilenceExpiryNotification moves the expiry notification of a silence to the silence that replaces it. It does
+// nothing if the silence has no expiry notification.
+func (st DBstore) MoveSilenceExpiryNotification(ctx context.Context, orgID int64, fromSilenceID, toSilenceID string) error {
+	return st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		if _, err := sess.Exec("DELETE FROM alert_silence_expiry_notification WHERE org_id = ? AND silence_id = ?", orgID, toSilenceID); err != nil {
+			return err
+		}
+		_, err := sess.Exec("UPDATE alert_silence_expiry_notification SET silence_id = ? WHERE org_id = ? AND silence_id = ?", toSilenceID, orgID, fromSilenceID)
+		return err
+	})
+}
+
+// SetSilenceExpiryNotified records that the notification was sent for the given end of the silence. The record only
+// happens if the stored end is still the one of the given notification, which lets a single replica claim the
+// notification. It returns false if nothing was recorded.
+func (st DBstore) SetSilenceExpiryNotified(ctx context.Context, n models.SilenceExpiryNotification, endsAt time.Time) (bool, error) {
+	row := silenceExpiryNotification{NotifiedEndsAt: unixOrZero(endsAt)}
+	var affected int64
+	err := st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		var err error
+		affected, err = sess.Where("id = ? AND notified_ends_at = ?", n.ID, unixOrZero(n.NotifiedEndsAt)).
+			Cols("notified_ends_at").
+			Update(&row)
+		return err
+	})
+	return affected > 0, err
+}
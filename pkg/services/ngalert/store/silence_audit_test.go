@@ -0,0 +1,196 @@
+package store
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+func TestIntegrationSilenceAudit(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	sqlStore := db.InitTestDB(t)
+	store := &DBstore{
+		SQLStore: sqlStore,
+		Logger:   log.NewNopLogger(),
+	}
+	ctx := context.Background()
+	orgID := int64(1)
+	now := time.Date(2026, time.March, 1, 12, 0, 0, 0, time.UTC)
+
+	silence := models.SilenceGen()()
+	entries := []models.SilenceAuditEntry{
+		{
+			OrgID:         orgID,
+			SilenceID:     *silence.ID,
+			Action:        models.SilenceAuditActionCreate,
+			ActorUID:      "u1",
+			ActorLogin:    "alice",
+			Created:       now,
+			Silence:       silence,
+			MatchedAlerts: []map[string]string{{"alertname": "Foo"}},
+		},
+		{
+			OrgID:      orgID,
+			SilenceID:  *silence.ID,
+			Action:     models.SilenceAuditActionUpdate,
+			ActorUID:   "u2",
+			ActorLogin: "bob",
+			Created:    now.Add(time.Hour),
+			Silence:    silence,
+			Changes:    []models.SilenceChange{{Field: "comment", Old: "a", New: "b"}},
+		},
+		{
+			OrgID:      orgID,
+			SilenceID:  "other",
+			Action:     models.SilenceAuditActionExpire,
+			ActorLogin: "alice",
+			Created:    now.Add(2 * time.Hour),
+			Silence:    silence,
+		},
+		{
+			OrgID:      2,
+			SilenceID:  *silence.ID,
+			Action:     models.SilenceAuditActionCreate,
+			ActorLogin: "alice",
+			Created:    now,
+			Silence:    silence,
+		},
+	}
+	for _, e := range entries {
+		require.NoError(t, store.InsertSilenceAuditEntry(ctx, e))
+	}
+
+	t.Run("should list the entries of the organization, the most recent first", func(t *testing.T) {
+		result, err := store.ListSilenceAuditEntries(ctx, models.ListSilenceAuditEntriesQuery{OrgID: orgID})
+		require.NoError(t, err)
+		require.Len(t, result, 3)
+		require.Equal(t, models.SilenceAuditActionExpire, result[0].Action)
+		require.Equal(t, models.SilenceAuditActionCreate, result[2].Action)
+		require.Equal(t, []map[string]string{{"alertname": "Foo"}}, result[2].MatchedAlerts)
+		require.Equal(t, *silence.ID, *result[2].Silence.ID)
+		require.Equal(t, silence.Matchers, result[2].Silence.Matchers)
+		require.Equal(t, entries[1].Changes, result[1].Changes)
+	})
+
+	t.Run("should filter the entries", func(t *testing.T) {
+		result, err := store.ListSilenceAuditEntries(ctx, models.ListSilenceAuditEntriesQuery{OrgID: orgID, SilenceID: *silence.ID})
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+
+		result, err = store.ListSilenceAuditEntries(ctx, models.ListSilenceAuditEntriesQuery{OrgID: orgID, ActorLogin: "alice"})
+		require.NoError(t, err)
+		require.Len(t, result, 2)
+
+		result, err = store.ListSilenceAuditEntries(ctx, models.ListSilenceAuditEntriesQuery{OrgID: orgID, From: now.Add(time.Hour), To: now.Add(2 * time.Hour)})
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.Equal(t, "bob", result[0].ActorLogin)
+
+		result, err = store.ListSilenceAuditEntries(ctx, models.ListSilenceAuditEntriesQuery{OrgID: orgID, Limit: 1})
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+
+		all, err := store.ListSilenceAuditEntries(ctx, models.ListSilenceAuditEntriesQuery{OrgID: orgID})
+		require.NoError(t, err)
+		result, err = store.ListSilenceAuditEntries(ctx, models.ListSilenceAuditEntriesQuery{OrgID: orgID, Limit: 1, Offset: 1})
+		require.NoError(t, err)
+		require.Len(t, result, 1)
+		require.Equal(t, all[1].ID, result[0].ID)
+	})
+}
+
+func TestIntegrationSilenceExpiryNotifications(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	sqlStore := db.InitTestDB(t)
+	store := &DBstore{
+		SQLStore: sqlStore,
+		Logger:   log.NewNopLogger(),
+	}
+	ctx := context.Background()
+	orgID := int64(1)
+
+	saved, err := store.SaveSilenceExpiryNotification(ctx, models.SilenceExpiryNotification{
+		OrgID:     orgID,
+		SilenceID: "silence-1",
+		Receiver:  "ops",
+		Before:    15 * time.Minute,
+	})
+	require.NoError(t, err)
+	require.NotZero(t, saved.ID)
+
+	t.Run("should get the expiry notification", func(t *testing.T) {
+		n, err := store.GetSilenceExpiryNotification(ctx, orgID, "silence-1")
+		require.NoError(t, err)
+		require.Equal(t, "ops", n.Receiver)
+		require.Equal(t, 15*time.Minute, n.Before)
+		require.True(t, n.NotifiedEndsAt.IsZero())
+
+		_, err = store.GetSilenceExpiryNotification(ctx, orgID, "unknown")
+		require.ErrorIs(t, err, models.ErrSilenceExpiryNotificationNotFound)
+	})
+
+	t.Run("should record the notification only once", func(t *testing.T) {
+		n, err := store.GetSilenceExpiryNotification(ctx, orgID, "silence-1")
+		require.NoError(t, err)
+		endsAt := time.Unix(1700000000, 0)
+		ok, err := store.SetSilenceExpiryNotified(ctx, *n, endsAt)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		// Another replica with the same view of the notification cannot claim it again.
+		ok, err = store.SetSilenceExpiryNotified(ctx, *n, endsAt)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		all, err := store.ListSilenceExpiryNotifications(ctx, 0)
+		require.NoError(t, err)
+		require.Len(t, all, 1)
+		require.True(t, endsAt.Equal(all[0].NotifiedEndsAt))
+	})
+
+	t.Run("should replace the expiry notification and send it again", func(t *testing.T) {
+		_, err := store.SaveSilenceExpiryNotification(ctx, models.SilenceExpiryNotification{
+			OrgID:     orgID,
+			SilenceID: "silence-1",
+			Receiver:  "oncall",
+			Before:    time.Hour,
+		})
+		require.NoError(t, err)
+		n, err := store.GetSilenceExpiryNotification(ctx, orgID, "silence-1")
+		require.NoError(t, err)
+		require.Equal(t, "oncall", n.Receiver)
+		require.True(t, n.NotifiedEndsAt.IsZero())
+	})
+
+	t.Run("should move the expiry notification to the silence that replaces it", func(t *testing.T) {
+		before, err := store.GetSilenceExpiryNotification(ctx, orgID, "silence-1")
+		require.NoError(t, err)
+
+		require.NoError(t, store.MoveSilenceExpiryNotification(ctx, orgID, "silence-1", "silence-2"))
+		_, err = store.GetSilenceExpiryNotification(ctx, orgID, "silence-1")
+		require.ErrorIs(t, err, models.ErrSilenceExpiryNotificationNotFound)
+		n, err := store.GetSilenceExpiryNotification(ctx, orgID, "silence-2")
+		require.NoError(t, err)
+		require.Equal(t, before.ID, n.ID)
+		require.Equal(t, "oncall", n.Receiver)
+
+		require.NoError(t, store.MoveSilenceExpiryNotification(ctx, orgID, "silence-2", "silence-1"))
+	})
+
+	t.Run("should delete the expiry notification", func(t *testing.T) {
+		require.NoError(t, store.DeleteSilenceExpiryNotification(ctx, orgID, "silence-1"))
+		all, err := store.ListSilenceExpiryNotifications(ctx, orgID)
+		require.NoError(t, err)
+		require.Empty(t, all)
+	})
+}
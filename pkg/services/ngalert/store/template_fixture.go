@@ -0,0 +1,159 @@
+package store
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"time"
+
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+// templateFixture represents a record in alert_template_fixture table
+type templateFixture struct {
+	ID           int64 `xorm:"pk autoincr 'id'"`
+	OrgID        int64 `xorm:"org_id"`
+	Name         string
+	Alerts       string
+	Expectations string
+	Updated      time.Time
+}
+
+func (f templateFixture) TableName() string {
+	return "alert_template_fixture"
+}
+
+type templateExpectation struct {
+	Template   string `json:"template"`
+	Definition string `json:"definition"`
+	Output     string `json:"output"`
+}
+
+func templateFixtureFromModel(f models.TemplateFixture) (templateFixture, error) {
+	alerts, err := json.Marshal(f.Alerts)
+	if err != nil {
+		return templateFixture{}, fmt.Errorf("failed to marshal alerts: %w", err)
+	}
+	row := templateFixture{
+		ID:      f.ID,
+		OrgID:   f.OrgID,
+		Name:    f.Name,
+		Alerts:  string(alerts),
+		Updated: f.Updated,
+	}
+	if len(f.Expectations) > 0 {
+		expectations := make([]templateExpectation, 0, len(f.Expectations))
+		for _, e := range f.Expectations {
+			expectations = append(expectations, templateExpectation(e))
+		}
+		b, err := json.Marshal(expectations)
+		if err != nil {
+			return templateFixture{}, fmt.Errorf("failed to marshal expectations: %w", err)
+		}
+		row.Expectations = string(b)
+	}
+	return row, nil
+}
+
+func templateFixtureToModel(f templateFixture) (*models.TemplateFixture, error) {
+	result := &models.TemplateFixture{
+		ID:      f.ID,
+		OrgID:   f.OrgID,
+		Name:    f.Name,
+		Updated: f.Updated,
+	}
+	var alerts []*amv2.PostableAlert
+	if err := json.Unmarshal([]byte(f.Alerts), &alerts); err != nil {
+		return nil, fmt.Errorf("failed to parse alerts: %w", err)
+	}
+	result.Alerts = alerts
+	if f.Expectations != "" {
+		var expectations []templateExpectation
+		if err := json.Unmarshal([]byte(f.Expectations), &expectations); err != nil {
+			return nil, fmt.Errorf("failed to parse expectations: %w", err)
+		}
+		result.Expectations = make([]models.TemplateExpectation, 0, len(expectations))
+		for _, e := range expectations {
+			result.Expectations = append(result.Expectations, models.TemplateExpectation(e))
+		}
+	}
+	return result, nil
+}
+
+// GetTemplateFixture returns the template fixture with the given name.
+// It returns models.ErrTemplateFixtureNotFound if the fixture does not exist.
+func (st DBstore) GetTemplateFixture(ctx context.Context, orgID int64, name string) (result *models.TemplateFixture, err error) {
+	err = st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		f := templateFixture{OrgID: orgID, Name: name}
+		has, err := sess.Get(&f)
+		if err != nil {
+			return err
+		}
+		if !has {
+			return models.ErrTemplateFixtureNotFound.Errorf("")
+		}
+		result, err = templateFixtureToModel(f)
+		return err
+	})
+	return result, err
+}
+
+// ListTemplateFixtures returns the template fixtures of the organization sorted by name.
+func (st DBstore) ListTemplateFixtures(ctx context.Context, orgID int64) (result []*models.TemplateFixture, err error) {
+	err = st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		var fixtures []templateFixture
+		if err := sess.Where("org_id = ?", orgID).Asc("name").Find(&fixtures); err != nil {
+			return err
+		}
+		result = make([]*models.TemplateFixture, 0, len(fixtures))
+		for _, f := range fixtures {
+			m, err := templateFixtureToModel(f)
+			if err != nil {
+				return fmt.Errorf("failed to convert template fixture %s: %w", f.Name, err)
+			}
+			result = append(result, m)
+		}
+		return nil
+	})
+	return result, err
+}
+
+// SaveTemplateFixture creates the template fixture, or replaces the fixture with the same name.
+func (st DBstore) SaveTemplateFixture(ctx context.Context, f models.TemplateFixture) (*models.TemplateFixture, error) {
+	f.Updated = TimeNow()
+	row, err := templateFixtureFromModel(f)
+	if err != nil {
+		return nil, err
+	}
+	err = st.SQLStore.WithTransactionalDbSession(ctx, func(sess *db.Session) error {
+		existing := templateFixture{OrgID: f.OrgID, Name: f.Name}
+		has, err := sess.Get(&existing)
+		if err != nil {
+			return err
+		}
+		if !has {
+			row.ID = 0
+			_, err = sess.Insert(&row)
+			return err
+		}
+		row.ID = existing.ID
+		_, err = sess.ID(existing.ID).Cols("alerts", "expectations", "updated").Update(&row)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	f.ID = row.ID
+	return &f, nil
+}
+
+// DeleteTemplateFixture deletes the template fixture with the given name.
+func (st DBstore) DeleteTemplateFixture(ctx context.Context, orgID int64, name string) error {
+	return st.SQLStore.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.Exec("DELETE FROM alert_template_fixture WHERE org_id = ? AND name = ?", orgID, name)
+		return err
+	})
+}
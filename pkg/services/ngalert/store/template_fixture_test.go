@@ -0,0 +1,84 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	amv2 "github.com/prometheus/alertmanager/api/v2/models"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+)
+
+func TestIntegrationTemplateFixtures(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	sqlStore := db.InitTestDB(t)
+	store := &DBstore{
+		SQLStore: sqlStore,
+		Logger:   log.NewNopLogger(),
+	}
+	ctx := context.Background()
+	orgID := int64(1)
+
+	fixture := models.TemplateFixture{
+		OrgID: orgID,
+		Name:  "firing",
+		Alerts: []*amv2.PostableAlert{
+			{Alert: amv2.Alert{Labels: amv2.LabelSet{"alertname": "HighLatency"}}, Annotations: amv2.LabelSet{"summary": "latency is high"}},
+		},
+		Expectations: []models.TemplateExpectation{
+			{Template: "slack", Definition: "slack.title", Output: "HighLatency"},
+		},
+	}
+
+	saved, err := store.SaveTemplateFixture(ctx, fixture)
+	require.NoError(t, err)
+	require.NotZero(t, saved.ID)
+
+	t.Run("should get the fixture", func(t *testing.T) {
+		f, err := store.GetTemplateFixture(ctx, orgID, "firing")
+		require.NoError(t, err)
+		require.Equal(t, saved.ID, f.ID)
+		require.Equal(t, fixture.Alerts, f.Alerts)
+		require.Equal(t, fixture.Expectations, f.Expectations)
+
+		_, err = store.GetTemplateFixture(ctx, 2, "firing")
+		require.ErrorIs(t, err, models.ErrTemplateFixtureNotFound)
+	})
+
+	t.Run("should replace the fixture with the same name", func(t *testing.T) {
+		replaced := fixture
+		replaced.Expectations = nil
+		r, err := store.SaveTemplateFixture(ctx, replaced)
+		require.NoError(t, err)
+		require.Equal(t, saved.ID, r.ID)
+
+		f, err := store.GetTemplateFixture(ctx, orgID, "firing")
+		require.NoError(t, err)
+		require.Empty(t, f.Expectations)
+	})
+
+	t.Run("should list the fixtures sorted by name", func(t *testing.T) {
+		other := fixture
+		other.Name = "another"
+		_, err := store.SaveTemplateFixture(ctx, other)
+		require.NoError(t, err)
+
+		fixtures, err := store.ListTemplateFixtures(ctx, orgID)
+		require.NoError(t, err)
+		require.Len(t, fixtures, 2)
+		require.Equal(t, "another", fixtures[0].Name)
+		require.Equal(t, "firing", fixtures[1].Name)
+	})
+
+	t.Run("should delete the fixture", func(t *testing.T) {
+		require.NoError(t, store.DeleteTemplateFixture(ctx, orgID, "another"))
+		fixtures, err := store.ListTemplateFixtures(ctx, orgID)
+		require.NoError(t, err)
+		require.Len(t, fixtures, 1)
+	})
+}
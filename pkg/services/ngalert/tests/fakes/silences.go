@@ -60,3 +60,32 @@ func (s *FakeSilenceStore) DeleteSilence(ctx context.Context, orgID int64, id st
 	delete(s.Silences, id)
 	return nil
 }
+
+type FakeSilenceAuditStore struct {
+	Entries []models.SilenceAuditEntry
+}
+
+func (s *FakeSilenceAuditStore) InsertSilenceAuditEntry(_ context.Context, e models.SilenceAuditEntry) error {
+	e.ID = int64(len(s.Entries) + 1)
+	s.Entries = append(s.Entries, e)
+	return nil
+}
+
+func (s *FakeSilenceAuditStore) ListSilenceAuditEntries(_ context.Context, query models.ListSilenceAuditEntriesQuery) ([]*models.SilenceAuditEntry, error) {
+	result := make([]*models.SilenceAuditEntry, 0, len(s.Entries))
+	for i := len(s.Entries) - 1; i >= 0; i-- {
+		e := s.Entries[i]
+		if e.OrgID != query.OrgID || (query.SilenceID != "" && e.SilenceID != query.SilenceID) {
+			continue
+		}
+		result = append(result, &e)
+	}
+	if query.Offset >= len(result) {
+		return nil, nil
+	}
+	result = result[query.Offset:]
+	if query.Limit > 0 && len(result) > query.Limit {
+		result = result[:query.Limit]
+	}
+	return result, nil
+}
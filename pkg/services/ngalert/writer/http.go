@@ -0,0 +1,122 @@
+package writer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/benbjohnson/clock"
+	"github.com/grafana/grafana-plugin-sdk-go/backend/httpclient"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+const (
+	userAgent = "grafana-recording-rule"
+
+	// maxResponseBodySize limits how much of a response body is read for error messages and write results.
+	maxResponseBodySize = 1 << 20
+)
+
+// httpSender posts encoded series to the write endpoint of a backend that has no dedicated client library.
+type httpSender struct {
+	client  *http.Client
+	url     string
+	backend BackendType
+	clock   clock.Clock
+	logger  log.Logger
+	metrics *metrics.RemoteWriter
+}
+
+func newHTTPSender(
+	backend BackendType,
+	settings setting.RecordingRuleSettings,
+	httpClientProvider HttpClientProvider,
+	clock clock.Clock,
+	l log.Logger,
+	metrics *metrics.RemoteWriter,
+) (httpSender, error) {
+	if err := validateSettings(settings); err != nil {
+		return httpSender{}, err
+	}
+	if settings.URL == "" {
+		return httpSender{}, fmt.Errorf("url is required for the %s backend", backend)
+	}
+
+	headers := make(http.Header)
+	for k, v := range settings.CustomHeaders {
+		headers.Add(k, v)
+	}
+
+	cl, err := httpClientProvider.New(httpclient.Options{
+		BasicAuth: createAuthOpts(settings.BasicAuthUsername, settings.BasicAuthPassword),
+		Header:    headers,
+	})
+	if err != nil {
+		return httpSender{}, err
+	}
+	cl.Timeout = settings.Timeout
+
+	return httpSender{
+		client:  cl,
+		url:     settings.URL,
+		backend: backend,
+		clock:   clock,
+		logger:  l,
+		metrics: metrics,
+	}, nil
+}
+
+// send posts the body to the write endpoint and returns the body of a successful response.
+func (s httpSender) send(ctx context.Context, orgID int64, contentType string, body []byte) ([]byte, error) {
+	lvs := []string{fmt.Sprint(orgID), s.backend.String()}
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return nil, errors.Join(ErrUnexpectedWriteFailure, err)
+	}
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("User-Agent", userAgent)
+
+	writeStart := s.clock.Now()
+	res, err := s.client.Do(req)
+	s.metrics.WriteDuration.WithLabelValues(lvs...).Observe(s.clock.Now().Sub(writeStart).Seconds())
+	if err != nil {
+		s.metrics.WritesTotal.WithLabelValues(append(lvs, "0")...).Inc()
+		return nil, errors.Join(ErrUnexpectedWriteFailure, err)
+	}
+	defer func() {
+		_ = res.Body.Close()
+	}()
+	s.metrics.WritesTotal.WithLabelValues(append(lvs, fmt.Sprint(res.StatusCode))...).Inc()
+
+	resBody, err := io.ReadAll(io.LimitReader(res.Body, maxResponseBodySize))
+	if err != nil {
+		return nil, errors.Join(ErrUnexpectedWriteFailure, err)
+	}
+	if err := checkHTTPResponse(res.StatusCode, resBody); err != nil {
+		return nil, err
+	}
+	return resBody, nil
+}
+
+func checkHTTPResponse(statusCode int, body []byte) error {
+	if statusCode/100 == 2 {
+		return nil
+	}
+
+	err := fmt.Errorf("server returned HTTP status %d: %s", statusCode, strings.TrimSpace(string(body)))
+
+	// Both line protocol and OTLP receivers answer with 400 or 422 when the written data itself is invalid.
+	if statusCode == http.StatusBadRequest || statusCode == http.StatusUnprocessableEntity {
+		return errors.Join(ErrRejectedWrite, err)
+	}
+
+	return errors.Join(ErrUnexpectedWriteFailure, err)
+}
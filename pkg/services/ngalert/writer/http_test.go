@@ -0,0 +1,90 @@
+package writer
+
+import (
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/grafana/grafana-plugin-sdk-go/backend/httpclient"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckHTTPResponse(t *testing.T) {
+	for _, tc := range []struct {
+		name        string
+		statusCode  int
+		expectedErr error
+	}{
+		{name: "200 is successful", statusCode: http.StatusOK},
+		{name: "204 is successful", statusCode: http.StatusNoContent},
+		{name: "400 is a rejected write", statusCode: http.StatusBadRequest, expectedErr: ErrRejectedWrite},
+		{name: "422 is a rejected write", statusCode: http.StatusUnprocessableEntity, expectedErr: ErrRejectedWrite},
+		{name: "401 is unexpected", statusCode: http.StatusUnauthorized, expectedErr: ErrUnexpectedWriteFailure},
+		{name: "500 is unexpected", statusCode: http.StatusInternalServerError, expectedErr: ErrUnexpectedWriteFailure},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			err := checkHTTPResponse(tc.statusCode, []byte("message"))
+			if tc.expectedErr == nil {
+				require.NoError(t, err)
+				return
+			}
+			require.ErrorIs(t, err, tc.expectedErr)
+			require.ErrorContains(t, err, "message")
+		})
+	}
+}
+
+// testHTTPTarget records the requests it receives and answers with the configured status code and body.
+type testHTTPTarget struct {
+	srv *httptest.Server
+
+	mtx          sync.Mutex
+	statusCode   int
+	responseBody []byte
+	requests     []*http.Request
+	bodies       [][]byte
+}
+
+func newTestHTTPTarget(t *testing.T) *testHTTPTarget {
+	t.Helper()
+
+	target := &testHTTPTarget{statusCode: http.StatusNoContent}
+	target.srv = httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		target.mtx.Lock()
+		defer target.mtx.Unlock()
+
+		body, err := io.ReadAll(r.Body)
+		require.NoError(t, err)
+		target.requests = append(target.requests, r)
+		target.bodies = append(target.bodies, body)
+
+		w.WriteHeader(target.statusCode)
+		_, _ = w.Write(target.responseBody)
+	}))
+	t.Cleanup(target.srv.Close)
+
+	return target
+}
+
+func (s *testHTTPTarget) respond(statusCode int, body []byte) {
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	s.statusCode = statusCode
+	s.responseBody = body
+}
+
+func (s *testHTTPTarget) lastRequest(t *testing.T) (*http.Request, []byte) {
+	t.Helper()
+	s.mtx.Lock()
+	defer s.mtx.Unlock()
+	require.NotEmpty(t, s.requests)
+	return s.requests[len(s.requests)-1], s.bodies[len(s.bodies)-1]
+}
+
+type testHTTPClientProvider struct{}
+
+func (testHTTPClientProvider) New(options ...httpclient.Options) (*http.Client, error) {
+	return httpclient.New(options...)
+}
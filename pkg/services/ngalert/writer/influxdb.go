@@ -0,0 +1,91 @@
+package writer
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"math"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	protocol "github.com/influxdata/line-protocol"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+// influxDBValueField is the name of the field that holds the sample value of each written point.
+const influxDBValueField = "value"
+
+// InfluxDBWriter writes recording rule results to an InfluxDB write endpoint using the line protocol.
+// Every series becomes a point of the measurement named after the recording rule metric, labels become tags.
+type InfluxDBWriter struct {
+	sender httpSender
+	logger log.Logger
+}
+
+func NewInfluxDBWriter(
+	settings setting.RecordingRuleSettings,
+	httpClientProvider HttpClientProvider,
+	clock clock.Clock,
+	l log.Logger,
+	metrics *metrics.RemoteWriter,
+) (*InfluxDBWriter, error) {
+	sender, err := newHTTPSender(BackendTypeInfluxDB, settings, httpClientProvider, clock, l, metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	return &InfluxDBWriter{
+		sender: sender,
+		logger: l,
+	}, nil
+}
+
+// Write writes the given frames to the InfluxDB write endpoint.
+func (w InfluxDBWriter) Write(ctx context.Context, name string, t time.Time, frames data.Frames, orgID int64, extraLabels map[string]string) error {
+	l := w.logger.FromContext(ctx)
+
+	points, err := PointsFromFrames(name, t, frames, extraLabels)
+	if err != nil {
+		return errors.Join(ErrBadFrame, err)
+	}
+
+	body, err := encodeLineProtocol(points, l)
+	if err != nil {
+		return errors.Join(ErrBadFrame, err)
+	}
+	if len(body) == 0 {
+		return nil
+	}
+
+	l.Debug("Writing metric", "name", name)
+	_, err = w.sender.send(ctx, orgID, "text/plain; charset=utf-8", body)
+	return err
+}
+
+func encodeLineProtocol(points []Point, l log.Logger) ([]byte, error) {
+	var buf bytes.Buffer
+	enc := protocol.NewEncoder(&buf)
+	enc.FailOnFieldErr(true)
+
+	for _, p := range points {
+		// The line protocol has no representation for special float values.
+		if math.IsNaN(p.Metric.V) || math.IsInf(p.Metric.V, 0) {
+			l.Debug("Skipping sample that cannot be represented in line protocol", "name", p.Name, "labels", p.Labels, "value", p.Metric.V)
+			continue
+		}
+
+		m, err := protocol.New(p.Name, p.Labels, map[string]any{influxDBValueField: p.Metric.V}, p.Metric.T)
+		if err != nil {
+			return nil, err
+		}
+		if _, err := enc.Encode(m); err != nil {
+			return nil, err
+		}
+	}
+
+	return buf.Bytes(), nil
+}
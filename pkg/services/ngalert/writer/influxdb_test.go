@@ -0,0 +1,100 @@
+package writer
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestNewInfluxDBWriter(t *testing.T) {
+	t.Run("requires url", func(t *testing.T) {
+		_, err := NewInfluxDBWriter(setting.RecordingRuleSettings{Timeout: time.Second}, testHTTPClientProvider{}, clock.New(), log.NewNopLogger(), metrics.NewRemoteWriterMetrics(prometheus.NewRegistry()))
+		require.Error(t, err)
+	})
+}
+
+func TestInfluxDBWriter_Write(t *testing.T) {
+	target := newTestHTTPTarget(t)
+	settings := setting.RecordingRuleSettings{
+		URL:               target.srv.URL + "/api/v2/write?org=test&bucket=test",
+		BasicAuthUsername: "user",
+		BasicAuthPassword: "password",
+		CustomHeaders:     map[string]string{"X-Test": "value"},
+		Timeout:           time.Second,
+	}
+	writer, err := NewInfluxDBWriter(settings, testHTTPClientProvider{}, clock.New(), log.NewNopLogger(), metrics.NewRemoteWriterMetrics(prometheus.NewRegistry()))
+	require.NoError(t, err)
+
+	now := time.Now()
+	series := []map[string]string{{"foo": "1"}, {"foo": "2"}}
+	frames := frameGenFromLabels(t, data.FrameTypeNumericWide, series)
+	ctx := context.Background()
+
+	t.Run("error when frames are empty", func(t *testing.T) {
+		err := writer.Write(ctx, "test", now, data.Frames{data.NewFrame("test")}, 1, nil)
+		require.ErrorIs(t, err, ErrBadFrame)
+	})
+
+	t.Run("writes expected lines", func(t *testing.T) {
+		target.respond(http.StatusNoContent, nil)
+
+		err := writer.Write(ctx, "test_metric", now, frames, 1, map[string]string{"extra": "label"})
+		require.NoError(t, err)
+
+		req, body := target.lastRequest(t)
+		require.Equal(t, http.MethodPost, req.Method)
+		require.Equal(t, "/api/v2/write", req.URL.Path)
+		require.Equal(t, "test", req.URL.Query().Get("bucket"))
+		require.Equal(t, "value", req.Header.Get("X-Test"))
+		user, pass, ok := req.BasicAuth()
+		require.True(t, ok)
+		require.Equal(t, "user", user)
+		require.Equal(t, "password", pass)
+
+		lines := strings.Split(strings.TrimSpace(string(body)), "\n")
+		require.Len(t, lines, len(series))
+		for i, labels := range series {
+			v := extractValue(t, frames, labels, data.FrameTypeNumericWide)
+			require.Contains(t, lines, fmt.Sprintf("test_metric,extra=label,foo=%s value=%v %d", series[i]["foo"], v, now.UnixNano()))
+		}
+	})
+
+	t.Run("skips values that line protocol cannot represent", func(t *testing.T) {
+		points := []Point{
+			{Name: "test", Labels: map[string]string{"foo": "1"}, Metric: Metric{T: now, V: math.NaN()}},
+			{Name: "test", Labels: map[string]string{"foo": "2"}, Metric: Metric{T: now, V: math.Inf(1)}},
+			{Name: "test", Labels: map[string]string{"foo": "3"}, Metric: Metric{T: now, V: 1}},
+		}
+
+		body, err := encodeLineProtocol(points, log.NewNopLogger())
+		require.NoError(t, err)
+		require.Equal(t, fmt.Sprintf("test,foo=3 value=1 %d\n", now.UnixNano()), string(body))
+	})
+
+	t.Run("invalid data is a rejected write", func(t *testing.T) {
+		target.respond(http.StatusBadRequest, []byte(`{"code":"invalid","message":"unable to parse"}`))
+
+		err := writer.Write(ctx, "test_metric", now, frames, 1, nil)
+		require.ErrorIs(t, err, ErrRejectedWrite)
+	})
+
+	t.Run("server errors are unexpected", func(t *testing.T) {
+		target.respond(http.StatusServiceUnavailable, nil)
+
+		err := writer.Write(ctx, "test_metric", now, frames, 1, nil)
+		require.ErrorIs(t, err, ErrUnexpectedWriteFailure)
+	})
+}
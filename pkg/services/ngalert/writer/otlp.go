@@ -0,0 +1,113 @@
+package writer
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+const (
+	otlpServiceName = "grafana"
+	otlpScopeName   = "grafana-recording-rule"
+)
+
+// OTLPWriter writes recording rule results to an OTLP/HTTP metrics endpoint.
+// Every recording rule result is sent as a gauge, labels become data point attributes.
+type OTLPWriter struct {
+	sender httpSender
+	logger log.Logger
+}
+
+func NewOTLPWriter(
+	settings setting.RecordingRuleSettings,
+	httpClientProvider HttpClientProvider,
+	clock clock.Clock,
+	l log.Logger,
+	metrics *metrics.RemoteWriter,
+) (*OTLPWriter, error) {
+	sender, err := newHTTPSender(BackendTypeOTLP, settings, httpClientProvider, clock, l, metrics)
+	if err != nil {
+		return nil, err
+	}
+
+	return &OTLPWriter{
+		sender: sender,
+		logger: l,
+	}, nil
+}
+
+// Write writes the given frames to the OTLP metrics endpoint.
+func (w OTLPWriter) Write(ctx context.Context, name string, t time.Time, frames data.Frames, orgID int64, extraLabels map[string]string) error {
+	l := w.logger.FromContext(ctx)
+
+	points, err := PointsFromFrames(name, t, frames, extraLabels)
+	if err != nil {
+		return errors.Join(ErrBadFrame, err)
+	}
+
+	body, err := pmetricotlp.NewExportRequestFromMetrics(otlpMetricsFromPoints(name, points)).MarshalProto()
+	if err != nil {
+		return errors.Join(ErrBadFrame, err)
+	}
+
+	l.Debug("Writing metric", "name", name)
+	resBody, err := w.sender.send(ctx, orgID, "application/x-protobuf", body)
+	if err != nil {
+		return err
+	}
+
+	return checkOTLPPartialSuccess(resBody)
+}
+
+func otlpMetricsFromPoints(name string, points []Point) pmetric.Metrics {
+	md := pmetric.NewMetrics()
+	rm := md.ResourceMetrics().AppendEmpty()
+	rm.Resource().Attributes().PutStr("service.name", otlpServiceName)
+	sm := rm.ScopeMetrics().AppendEmpty()
+	sm.Scope().SetName(otlpScopeName)
+
+	m := sm.Metrics().AppendEmpty()
+	m.SetName(name)
+	dps := m.SetEmptyGauge().DataPoints()
+	dps.EnsureCapacity(len(points))
+	for _, p := range points {
+		dp := dps.AppendEmpty()
+		dp.SetTimestamp(pcommon.NewTimestampFromTime(p.Metric.T))
+		dp.SetDoubleValue(p.Metric.V)
+		for k, v := range p.Labels {
+			dp.Attributes().PutStr(k, v)
+		}
+	}
+
+	return md
+}
+
+// checkOTLPPartialSuccess inspects the export response of a successful request.
+// Receivers report data points they refused to accept in the partial success field instead of failing the request.
+func checkOTLPPartialSuccess(body []byte) error {
+	if len(body) == 0 {
+		return nil
+	}
+
+	res := pmetricotlp.NewExportResponse()
+	if err := res.UnmarshalProto(body); err != nil {
+		// The data was accepted, a response we cannot read does not change that.
+		return nil
+	}
+
+	if rejected := res.PartialSuccess().RejectedDataPoints(); rejected > 0 {
+		return errors.Join(ErrRejectedWrite, fmt.Errorf("%d data points were rejected: %s", rejected, res.PartialSuccess().ErrorMessage()))
+	}
+	return nil
+}
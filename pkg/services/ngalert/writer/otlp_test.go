@@ -0,0 +1,102 @@
+package writer
+
+import (
+	"context"
+	"net/http"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+	"go.opentelemetry.io/collector/pdata/pcommon"
+	"go.opentelemetry.io/collector/pdata/pmetric"
+	"go.opentelemetry.io/collector/pdata/pmetric/pmetricotlp"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+func TestOTLPWriter_Write(t *testing.T) {
+	target := newTestHTTPTarget(t)
+	settings := setting.RecordingRuleSettings{
+		URL:     target.srv.URL + "/v1/metrics",
+		Timeout: time.Second,
+	}
+	writer, err := NewOTLPWriter(settings, testHTTPClientProvider{}, clock.New(), log.NewNopLogger(), metrics.NewRemoteWriterMetrics(prometheus.NewRegistry()))
+	require.NoError(t, err)
+
+	now := time.Now()
+	series := []map[string]string{{"foo": "1"}, {"foo": "2"}, {"foo": "3"}}
+	frames := frameGenFromLabels(t, data.FrameTypeNumericMulti, series)
+	ctx := context.Background()
+
+	t.Run("error when frames are empty", func(t *testing.T) {
+		err := writer.Write(ctx, "test", now, data.Frames{data.NewFrame("test")}, 1, nil)
+		require.ErrorIs(t, err, ErrBadFrame)
+	})
+
+	t.Run("writes expected gauge", func(t *testing.T) {
+		target.respond(http.StatusOK, nil)
+
+		err := writer.Write(ctx, "test_metric", now, frames, 1, map[string]string{"extra": "label"})
+		require.NoError(t, err)
+
+		req, body := target.lastRequest(t)
+		require.Equal(t, "/v1/metrics", req.URL.Path)
+		require.Equal(t, "application/x-protobuf", req.Header.Get("Content-Type"))
+
+		exported := pmetricotlp.NewExportRequest()
+		require.NoError(t, exported.UnmarshalProto(body))
+		md := exported.Metrics()
+		require.Equal(t, 1, md.MetricCount())
+
+		m := md.ResourceMetrics().At(0).ScopeMetrics().At(0).Metrics().At(0)
+		require.Equal(t, "test_metric", m.Name())
+		require.Equal(t, pmetric.MetricTypeGauge, m.Type())
+		dps := m.Gauge().DataPoints()
+		require.Equal(t, len(series), dps.Len())
+		for i := 0; i < dps.Len(); i++ {
+			dp := dps.At(i)
+			labels := map[string]string{}
+			dp.Attributes().Range(func(k string, v pcommon.Value) bool {
+				labels[k] = v.Str()
+				return true
+			})
+			require.Equal(t, "label", labels["extra"])
+			delete(labels, "extra")
+			require.Contains(t, series, labels)
+			require.Equal(t, now.UnixNano(), dp.Timestamp().AsTime().UnixNano())
+			require.Equal(t, extractValue(t, frames, labels, data.FrameTypeNumericMulti), dp.DoubleValue())
+		}
+	})
+
+	t.Run("partially rejected data is a rejected write", func(t *testing.T) {
+		res := pmetricotlp.NewExportResponse()
+		res.PartialSuccess().SetRejectedDataPoints(1)
+		res.PartialSuccess().SetErrorMessage("invalid metric name")
+		body, err := res.MarshalProto()
+		require.NoError(t, err)
+		target.respond(http.StatusOK, body)
+
+		err = writer.Write(ctx, "test_metric", now, frames, 1, nil)
+		require.ErrorIs(t, err, ErrRejectedWrite)
+		require.ErrorContains(t, err, "invalid metric name")
+	})
+
+	t.Run("invalid data is a rejected write", func(t *testing.T) {
+		target.respond(http.StatusBadRequest, nil)
+
+		err := writer.Write(ctx, "test_metric", now, frames, 1, nil)
+		require.ErrorIs(t, err, ErrRejectedWrite)
+	})
+
+	t.Run("server errors are unexpected", func(t *testing.T) {
+		target.respond(http.StatusBadGateway, nil)
+
+		err := writer.Write(ctx, "test_metric", now, frames, 1, nil)
+		require.ErrorIs(t, err, ErrUnexpectedWriteFailure)
+	})
+}
@@ -20,8 +20,6 @@ import (
 	"github.com/grafana/grafana-plugin-sdk-go/data"
 )
 
-const backendType = "prometheus"
-
 const (
 	// Fixed error messages
 	MimirDuplicateTimestampError = "err-mimir-sample-duplicate-timestamp"
@@ -191,7 +189,7 @@ func createAuthOpts(username, password string) *httpclient.BasicAuthOptions {
 // Write writes the given frames to the Prometheus remote write endpoint.
 func (w PrometheusWriter) Write(ctx context.Context, name string, t time.Time, frames data.Frames, orgID int64, extraLabels map[string]string) error {
 	l := w.logger.FromContext(ctx)
-	lvs := []string{fmt.Sprint(orgID), backendType}
+	lvs := []string{fmt.Sprint(orgID), BackendTypePrometheus.String()}
 
 	points, err := PointsFromFrames(name, t, frames, extraLabels)
 	if err != nil {
@@ -0,0 +1,245 @@
+package writer
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/services/sqlstore"
+)
+
+const (
+	recordedMetricTable = "alert_recorded_metric"
+
+	// sqlRetentionInterval is how often samples older than the retention are deleted.
+	sqlRetentionInterval = 10 * time.Minute
+)
+
+// recordedMetric is a single sample stored in the alert_recorded_metric table.
+type recordedMetric struct {
+	ID         int64   `xorm:"pk autoincr 'id'"`
+	OrgID      int64   `xorm:"org_id"`
+	Name       string  `xorm:"name"`
+	Labels     string  `xorm:"labels"`
+	LabelsHash string  `xorm:"labels_hash"`
+	SampleTime int64   `xorm:"sample_time"`
+	Value      float64 `xorm:"value"`
+}
+
+// SQLWriter writes recording rule results to a table in the Grafana database.
+// The written series can be queried back through the Grafana data source, see QueryRecordedMetrics.
+type SQLWriter struct {
+	store     db.DB
+	retention time.Duration
+	clock     clock.Clock
+	logger    log.Logger
+	metrics   *metrics.RemoteWriter
+}
+
+func NewSQLWriter(store db.DB, retention time.Duration, clock clock.Clock, l log.Logger, metrics *metrics.RemoteWriter) *SQLWriter {
+	return &SQLWriter{
+		store:     store,
+		retention: retention,
+		clock:     clock,
+		logger:    l,
+		metrics:   metrics,
+	}
+}
+
+// Write stores the given frames in the Grafana database.
+func (w *SQLWriter) Write(ctx context.Context, name string, t time.Time, frames data.Frames, orgID int64, extraLabels map[string]string) error {
+	l := w.logger.FromContext(ctx)
+	lvs := []string{fmt.Sprint(orgID), BackendTypeSQL.String()}
+
+	points, err := PointsFromFrames(name, t, frames, extraLabels)
+	if err != nil {
+		return errors.Join(ErrBadFrame, err)
+	}
+
+	rows := make([]recordedMetric, 0, len(points))
+	for _, p := range points {
+		// Not every supported database can store special float values.
+		if math.IsNaN(p.Metric.V) || math.IsInf(p.Metric.V, 0) {
+			l.Debug("Skipping sample that cannot be stored", "name", p.Name, "labels", p.Labels, "value", p.Metric.V)
+			continue
+		}
+
+		lbls := data.Labels(p.Labels)
+		encoded, err := json.Marshal(lbls)
+		if err != nil {
+			return errors.Join(ErrBadFrame, err)
+		}
+		rows = append(rows, recordedMetric{
+			OrgID:      orgID,
+			Name:       p.Name,
+			Labels:     string(encoded),
+			LabelsHash: lbls.Fingerprint().String(),
+			SampleTime: p.Metric.T.UnixMilli(),
+			Value:      p.Metric.V,
+		})
+	}
+	if len(rows) == 0 {
+		return nil
+	}
+
+	l.Debug("Writing metric", "name", name)
+	writeStart := w.clock.Now()
+	err = w.store.WithDbSession(ctx, func(sess *db.Session) error {
+		_, err := sess.BulkInsert(recordedMetricTable, rows, sqlstore.NativeSettingsForDialect(w.store.GetDialect()))
+		return err
+	})
+	w.metrics.WriteDuration.WithLabelValues(lvs...).Observe(w.clock.Now().Sub(writeStart).Seconds())
+
+	// There is no status code for database writes, use the HTTP equivalents for consistency with the other backends.
+	status := "200"
+	if err != nil {
+		status = "500"
+	}
+	w.metrics.WritesTotal.WithLabelValues(append(lvs, status)...).Inc()
+
+	if err != nil {
+		return errors.Join(ErrUnexpectedWriteFailure, err)
+	}
+	return nil
+}
+
+// Run periodically deletes the samples that are older than the retention until the context is cancelled.
+func (w *SQLWriter) Run(ctx context.Context) error {
+	if w.retention <= 0 {
+		return nil
+	}
+
+	ticker := w.clock.Ticker(sqlRetentionInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+			n, err := w.DeleteExpired(ctx)
+			if err != nil {
+				w.logger.Error("Failed to delete expired recorded metrics", "error", err)
+				continue
+			}
+			w.logger.Debug("Deleted expired recorded metrics", "rows", n)
+		}
+	}
+}
+
+// DeleteExpired deletes the samples that are older than the retention and returns how many were deleted.
+func (w *SQLWriter) DeleteExpired(ctx context.Context) (int64, error) {
+	cutoff := w.clock.Now().Add(-w.retention).UnixMilli()
+
+	var n int64
+	err := w.store.WithDbSession(ctx, func(sess *db.Session) error {
+		rows, err := sess.Table(recordedMetricTable).Where("sample_time < ?", cutoff).Delete(&recordedMetric{})
+		if err != nil {
+			return fmt.Errorf("failed to delete expired recorded metrics: %w", err)
+		}
+		n = rows
+		return nil
+	})
+	if err != nil {
+		return -1, err
+	}
+	return n, nil
+}
+
+// RecordedMetricsQuery selects the samples of a metric that a recording rule wrote to the Grafana database.
+type RecordedMetricsQuery struct {
+	OrgID int64
+	Name  string
+	// Labels only selects the series that have all these labels.
+	Labels map[string]string
+	From   time.Time
+	To     time.Time
+}
+
+// RecordedMetricsReader reads the samples written by the SQLWriter for the Grafana data source.
+type RecordedMetricsReader struct {
+	store db.DB
+}
+
+func ProvideRecordedMetricsReader(store db.DB) *RecordedMetricsReader {
+	return &RecordedMetricsReader{store: store}
+}
+
+// QueryRecordedMetrics reads the samples of the metric of the org between from and to that have all the given labels.
+// Every series is returned as its own frame.
+func (r *RecordedMetricsReader) QueryRecordedMetrics(ctx context.Context, orgID int64, name string, labels map[string]string, from, to time.Time) (data.Frames, error) {
+	return QueryRecordedMetrics(ctx, r.store, RecordedMetricsQuery{
+		OrgID:  orgID,
+		Name:   name,
+		Labels: labels,
+		From:   from,
+		To:     to,
+	})
+}
+
+// QueryRecordedMetrics reads the samples selected by the query. Every series is returned as its own frame.
+func QueryRecordedMetrics(ctx context.Context, store db.DB, q RecordedMetricsQuery) (data.Frames, error) {
+	if q.Name == "" {
+		return nil, errors.New("metric name is required")
+	}
+
+	var rows []recordedMetric
+	err := store.WithDbSession(ctx, func(sess *db.Session) error {
+		return sess.Table(recordedMetricTable).
+			Where("org_id = ? AND name = ? AND sample_time >= ? AND sample_time <= ?", q.OrgID, q.Name, q.From.UnixMilli(), q.To.UnixMilli()).
+			OrderBy("labels_hash, sample_time").
+			Find(&rows)
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query recorded metrics: %w", err)
+	}
+
+	frames := data.Frames{}
+	var frame *data.Frame
+	for i, row := range rows {
+		// Rows are ordered by series, a new series starts whenever the labels change.
+		if i == 0 || row.LabelsHash != rows[i-1].LabelsHash {
+			frame = nil
+			lbls := data.Labels{}
+			if err := json.Unmarshal([]byte(row.Labels), &lbls); err != nil {
+				return nil, fmt.Errorf("failed to read labels of recorded metric %q: %w", row.Name, err)
+			}
+			if !matchesLabels(lbls, q.Labels) {
+				continue
+			}
+
+			frame = data.NewFrame(q.Name,
+				data.NewField(data.TimeSeriesTimeFieldName, nil, []time.Time{}),
+				data.NewField(data.TimeSeriesValueFieldName, lbls, []float64{}),
+			)
+			frame.SetMeta(&data.FrameMeta{
+				Type:        data.FrameTypeTimeSeriesMulti,
+				TypeVersion: data.FrameTypeVersion{0, 1},
+			})
+			frames = append(frames, frame)
+		}
+		if frame == nil {
+			continue
+		}
+		frame.AppendRow(time.UnixMilli(row.SampleTime).UTC(), row.Value)
+	}
+
+	return frames, nil
+}
+
+func matchesLabels(lbls data.Labels, matchers map[string]string) bool {
+	for k, v := range matchers {
+		if lbls[k] != v {
+			return false
+		}
+	}
+	return true
+}
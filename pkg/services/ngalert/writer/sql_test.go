@@ -0,0 +1,129 @@
+package writer
+
+import (
+	"context"
+	"math"
+	"testing"
+	"time"
+
+	"github.com/benbjohnson/clock"
+	"github.com/grafana/grafana-plugin-sdk-go/data"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/infra/db"
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/ngalert/metrics"
+	"github.com/grafana/grafana/pkg/tests/testsuite"
+)
+
+func TestMain(m *testing.M) {
+	testsuite.Run(m)
+}
+
+func TestIntegrationSQLWriter(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+
+	store := db.InitTestDB(t)
+	clk := clock.NewMock()
+	clk.Set(time.Now().Truncate(time.Millisecond))
+	writer := NewSQLWriter(store, time.Hour, clk, log.NewNopLogger(), metrics.NewRemoteWriterMetrics(prometheus.NewRegistry()))
+	ctx := context.Background()
+
+	series := []map[string]string{{"foo": "1"}, {"foo": "2"}}
+	start := clk.Now()
+	var written []data.Frames
+	for i := 0; i < 3; i++ {
+		frames := frameGenFromLabels(t, data.FrameTypeNumericWide, series)
+		require.NoError(t, writer.Write(ctx, "test_metric", start.Add(time.Duration(i)*time.Minute), frames, 1, map[string]string{"extra": "label"}))
+		written = append(written, frames)
+	}
+	// Series of other metrics and organizations must not be returned.
+	require.NoError(t, writer.Write(ctx, "other_metric", start, frameGenFromLabels(t, data.FrameTypeNumericWide, series), 1, nil))
+	require.NoError(t, writer.Write(ctx, "test_metric", start, frameGenFromLabels(t, data.FrameTypeNumericWide, series), 2, nil))
+
+	t.Run("error when frames are empty", func(t *testing.T) {
+		err := writer.Write(ctx, "test_metric", start, data.Frames{data.NewFrame("test")}, 1, nil)
+		require.ErrorIs(t, err, ErrBadFrame)
+	})
+
+	t.Run("query returns a frame per series", func(t *testing.T) {
+		frames, err := QueryRecordedMetrics(ctx, store, RecordedMetricsQuery{
+			OrgID: 1,
+			Name:  "test_metric",
+			From:  start,
+			To:    start.Add(time.Hour),
+		})
+		require.NoError(t, err)
+		require.Len(t, frames, len(series))
+
+		for _, frame := range frames {
+			require.Equal(t, "test_metric", frame.Name)
+			require.Equal(t, data.FrameTypeTimeSeriesMulti, frame.Meta.Type)
+			lbls := frame.Fields[1].Labels
+			require.Equal(t, "label", lbls["extra"])
+			require.Equal(t, 3, frame.Rows())
+
+			for i := 0; i < frame.Rows(); i++ {
+				require.Equal(t, start.Add(time.Duration(i)*time.Minute).UnixMilli(), frame.Fields[0].At(i).(time.Time).UnixMilli())
+				expected := extractValue(t, written[i], map[string]string{"foo": lbls["foo"]}, data.FrameTypeNumericWide)
+				require.InDelta(t, expected, frame.Fields[1].At(i).(float64), 1e-9)
+			}
+		}
+	})
+
+	t.Run("query filters by labels and time range", func(t *testing.T) {
+		frames, err := QueryRecordedMetrics(ctx, store, RecordedMetricsQuery{
+			OrgID:  1,
+			Name:   "test_metric",
+			Labels: map[string]string{"foo": "2"},
+			From:   start.Add(time.Minute),
+			To:     start.Add(time.Hour),
+		})
+		require.NoError(t, err)
+		require.Len(t, frames, 1)
+		require.Equal(t, "2", frames[0].Fields[1].Labels["foo"])
+		require.Equal(t, 2, frames[0].Rows())
+	})
+
+	t.Run("query requires a metric name", func(t *testing.T) {
+		_, err := QueryRecordedMetrics(ctx, store, RecordedMetricsQuery{OrgID: 1})
+		require.Error(t, err)
+	})
+
+	t.Run("skips values that cannot be stored", func(t *testing.T) {
+		frame := data.NewFrame("test",
+			data.NewField("T", nil, []time.Time{start}),
+			data.NewField("value", data.Labels{"foo": "nan"}, []float64{math.NaN()}),
+		)
+		frame.SetMeta(&data.FrameMeta{Type: data.FrameTypeNumericWide, TypeVersion: data.FrameTypeVersion{0, 1}})
+		require.NoError(t, writer.Write(ctx, "nan_metric", start, data.Frames{frame}, 1, nil))
+
+		frames, err := QueryRecordedMetrics(ctx, store, RecordedMetricsQuery{OrgID: 1, Name: "nan_metric", From: start, To: start})
+		require.NoError(t, err)
+		require.Empty(t, frames)
+	})
+
+	t.Run("deletes samples older than the retention", func(t *testing.T) {
+		clk.Set(start.Add(time.Hour + time.Minute + time.Second))
+
+		n, err := writer.DeleteExpired(ctx)
+		require.NoError(t, err)
+		// The first two samples of both series of test_metric in org 1, plus other_metric and org 2.
+		require.EqualValues(t, 8, n)
+
+		frames, err := QueryRecordedMetrics(ctx, store, RecordedMetricsQuery{
+			OrgID: 1,
+			Name:  "test_metric",
+			From:  start,
+			To:    start.Add(time.Hour),
+		})
+		require.NoError(t, err)
+		require.Len(t, frames, len(series))
+		for _, frame := range frames {
+			require.Equal(t, 1, frame.Rows())
+		}
+	})
+}
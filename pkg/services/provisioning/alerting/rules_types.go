@@ -30,6 +30,8 @@ type AlertRuleGroupV1 struct {
 	Folder   values.StringValue `json:"folder" yaml:"folder"`
 	Interval values.StringValue `json:"interval" yaml:"interval"`
 	Rules    []AlertRuleV1      `json:"rules" yaml:"rules"`
+	// EvaluationPolicy applies to the rules of the group that do not have their own evaluation policy.
+	EvaluationPolicy *EvaluationPolicyV1 `json:"evaluation_policy" yaml:"evaluation_policy"`
 }
 
 func (ruleGroupV1 *AlertRuleGroupV1) MapToModel() (models.AlertRuleGroupWithFolderFullpath, error) {
@@ -51,11 +53,23 @@ func (ruleGroupV1 *AlertRuleGroupV1) MapToModel() (models.AlertRuleGroupWithFold
 	if strings.TrimSpace(ruleGroup.FolderFullpath) == "" {
 		return models.AlertRuleGroupWithFolderFullpath{}, errors.New("rule group has no folder set")
 	}
+	var groupPolicy *models.EvaluationPolicy
+	if ruleGroupV1.EvaluationPolicy != nil {
+		policy, err := ruleGroupV1.EvaluationPolicy.mapToModel()
+		if err != nil {
+			return models.AlertRuleGroupWithFolderFullpath{}, fmt.Errorf("rule group '%s' failed to parse evaluation policy: %w", ruleGroup.Title, err)
+		}
+		groupPolicy = &policy
+	}
 	for _, ruleV1 := range ruleGroupV1.Rules {
 		rule, err := ruleV1.mapToModel(ruleGroup.OrgID)
 		if err != nil {
 			return models.AlertRuleGroupWithFolderFullpath{}, err
 		}
+		if rule.EvaluationPolicy == nil && groupPolicy != nil {
+			policy := *groupPolicy
+			rule.EvaluationPolicy = &policy
+		}
 		ruleGroup.Rules = append(ruleGroup.Rules, rule)
 	}
 	return ruleGroup, nil
@@ -72,11 +86,13 @@ type AlertRuleV1 struct {
 	NoDataState          values.StringValue      `json:"noDataState" yaml:"noDataState"`
 	ExecErrState         values.StringValue      `json:"execErrState" yaml:"execErrState"`
 	For                  values.StringValue      `json:"for" yaml:"for"`
+	KeepFiringFor        values.StringValue      `json:"keepFiringFor" yaml:"keepFiringFor"`
 	Annotations          values.StringMapValue   `json:"annotations" yaml:"annotations"`
 	Labels               values.StringMapValue   `json:"labels" yaml:"labels"`
 	IsPaused             values.BoolValue        `json:"isPaused" yaml:"isPaused"`
 	NotificationSettings *NotificationSettingsV1 `json:"notification_settings" yaml:"notification_settings"`
 	Record               *RecordV1               `json:"record" yaml:"record"`
+	EvaluationPolicy     *EvaluationPolicyV1     `json:"evaluation_policy" yaml:"evaluation_policy"`
 }
 
 func withFallback(value, fallback string) *string {
@@ -108,6 +124,16 @@ func (rule *AlertRuleV1) mapToModel(orgID int64) (models.AlertRule, error) {
 	}
 	alertRule.For = time.Duration(duration)
 
+	keepFiringFor := model.Duration(0)
+	if rule.KeepFiringFor.Value() != "" {
+		var err error
+		keepFiringFor, err = model.ParseDuration(rule.KeepFiringFor.Value())
+		if err != nil {
+			return models.AlertRule{}, fmt.Errorf("rule '%s' failed to parse 'keepFiringFor' field: %w", alertRule.Title, err)
+		}
+	}
+	alertRule.KeepFiringFor = time.Duration(keepFiringFor)
+
 	dasboardUID := rule.DasboardUID.Value()
 	dashboardUID := rule.DashboardUID.Value()
 	alertRule.DashboardUID = withFallback(dashboardUID, dasboardUID) // Use correct spelling over supported typo.
@@ -162,6 +188,13 @@ func (rule *AlertRuleV1) mapToModel(orgID int64) (models.AlertRule, error) {
 		}
 		alertRule.Record = &record
 	}
+	if rule.EvaluationPolicy != nil {
+		policy, err := rule.EvaluationPolicy.mapToModel()
+		if err != nil {
+			return models.AlertRule{}, fmt.Errorf("rule '%s' failed to parse evaluation policy: %w", alertRule.Title, err)
+		}
+		alertRule.EvaluationPolicy = &policy
+	}
 	return alertRule, nil
 }
 
@@ -281,3 +314,42 @@ func (record *RecordV1) mapToModel() (models.Record, error) {
 		From:   record.From.Value(),
 	}, nil
 }
+
+type EvaluationPolicyV1 struct {
+	Timeout        values.StringValue `json:"timeout" yaml:"timeout"`
+	MaxAttempts    values.Int64Value  `json:"max_attempts" yaml:"max_attempts"`
+	InitialBackoff values.StringValue `json:"initial_backoff" yaml:"initial_backoff"`
+	MaxBackoff     values.StringValue `json:"max_backoff" yaml:"max_backoff"`
+}
+
+func (policyV1 *EvaluationPolicyV1) mapToModel() (models.EvaluationPolicy, error) {
+	parseDuration := func(v values.StringValue, name string) (time.Duration, error) {
+		if v.Value() == "" {
+			return 0, nil
+		}
+		dur, err := model.ParseDuration(v.Value())
+		if err != nil {
+			return 0, fmt.Errorf("failed to parse %s: %w", name, err)
+		}
+		return time.Duration(dur), nil
+	}
+	timeout, err := parseDuration(policyV1.Timeout, "timeout")
+	if err != nil {
+		return models.EvaluationPolicy{}, err
+	}
+	initialBackoff, err := parseDuration(policyV1.InitialBackoff, "initial backoff")
+	if err != nil {
+		return models.EvaluationPolicy{}, err
+	}
+	maxBackoff, err := parseDuration(policyV1.MaxBackoff, "max backoff")
+	if err != nil {
+		return models.EvaluationPolicy{}, err
+	}
+	policy := models.EvaluationPolicy{
+		Timeout:        timeout,
+		MaxAttempts:    policyV1.MaxAttempts.Value(),
+		InitialBackoff: initialBackoff,
+		MaxBackoff:     maxBackoff,
+	}
+	return policy, policy.Validate()
+}
@@ -65,6 +65,18 @@ func TestRuleGroup(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, int64(48*time.Hour/time.Second), rgMapped.Interval)
 	})
+	t.Run("a rule group evaluation policy should apply to rules without evaluation policy", func(t *testing.T) {
+		rg := validRuleGroupV1(t)
+		rg.EvaluationPolicy = &EvaluationPolicyV1{Timeout: stringToStringValue("5s")}
+		withPolicy := validRuleV1(t)
+		withPolicy.UID = stringToStringValue("other_uid")
+		withPolicy.EvaluationPolicy = &EvaluationPolicyV1{Timeout: stringToStringValue("2s")}
+		rg.Rules = []AlertRuleV1{validRuleV1(t), withPolicy}
+		group, err := rg.MapToModel()
+		require.NoError(t, err)
+		require.Equal(t, &models.EvaluationPolicy{Timeout: 5 * time.Second}, group.Rules[0].EvaluationPolicy)
+		require.Equal(t, &models.EvaluationPolicy{Timeout: 2 * time.Second}, group.Rules[1].EvaluationPolicy)
+	})
 	t.Run("a rule group with an empty org id should default to 1", func(t *testing.T) {
 		rg := validRuleGroupV1(t)
 		rg.OrgID = values.Int64Value{}
@@ -128,6 +140,31 @@ func TestRules(t *testing.T) {
 		require.NoError(t, err)
 		require.Equal(t, 48*time.Hour, ruleMapped.For)
 	})
+	t.Run("a rule without a keepFiringFor duration should default to 0s", func(t *testing.T) {
+		rule := validRuleV1(t)
+		ruleMapped, err := rule.mapToModel(1)
+		require.NoError(t, err)
+		require.Equal(t, time.Duration(0), ruleMapped.KeepFiringFor)
+	})
+	t.Run("a rule with a keepFiringFor duration should work", func(t *testing.T) {
+		rule := validRuleV1(t)
+		keepFiringFor := values.StringValue{}
+		err := yaml.Unmarshal([]byte("5m"), &keepFiringFor)
+		require.NoError(t, err)
+		rule.KeepFiringFor = keepFiringFor
+		ruleMapped, err := rule.mapToModel(1)
+		require.NoError(t, err)
+		require.Equal(t, 5*time.Minute, ruleMapped.KeepFiringFor)
+	})
+	t.Run("a rule with an invalid keepFiringFor duration should error", func(t *testing.T) {
+		rule := validRuleV1(t)
+		keepFiringFor := values.StringValue{}
+		err := yaml.Unmarshal([]byte("10x"), &keepFiringFor)
+		require.NoError(t, err)
+		rule.KeepFiringFor = keepFiringFor
+		_, err = rule.mapToModel(1)
+		require.Error(t, err)
+	})
 	t.Run("a rule with out a condition should error", func(t *testing.T) {
 		rule := validRuleV1(t)
 		rule.Condition = values.StringValue{}
@@ -200,6 +237,33 @@ func TestRules(t *testing.T) {
 		require.Len(t, ruleMapped.NotificationSettings, 1)
 		require.Equal(t, models.NotificationSettings{Receiver: "test-receiver"}, ruleMapped.NotificationSettings[0])
 	})
+	t.Run("a rule with an evaluation policy should map it correctly", func(t *testing.T) {
+		rule := validRuleV1(t)
+		rule.EvaluationPolicy = &EvaluationPolicyV1{
+			Timeout:        stringToStringValue("5s"),
+			InitialBackoff: stringToStringValue("1s"),
+			MaxBackoff:     stringToStringValue("4s"),
+		}
+		err := yaml.Unmarshal([]byte("3"), &rule.EvaluationPolicy.MaxAttempts)
+		require.NoError(t, err)
+		ruleMapped, err := rule.mapToModel(1)
+		require.NoError(t, err)
+		require.Equal(t, &models.EvaluationPolicy{
+			Timeout:        5 * time.Second,
+			MaxAttempts:    3,
+			InitialBackoff: time.Second,
+			MaxBackoff:     4 * time.Second,
+		}, ruleMapped.EvaluationPolicy)
+	})
+	t.Run("a rule with an invalid evaluation policy should error", func(t *testing.T) {
+		rule := validRuleV1(t)
+		rule.EvaluationPolicy = &EvaluationPolicyV1{
+			InitialBackoff: stringToStringValue("10s"),
+			MaxBackoff:     stringToStringValue("1s"),
+		}
+		_, err := rule.mapToModel(1)
+		require.ErrorContains(t, err, "failed to parse evaluation policy")
+	})
 }
 
 func TestNotificationsSettingsV1MapToModel(t *testing.T) {
@@ -141,4 +141,24 @@ func (oss *OSSMigrations) AddMigration(mg *Migrator) {
 	externalsession.AddMigration(mg)
 
 	accesscontrol.AddReceiverCreateScopeMigration(mg)
+
+	ualert.AddKeepFiringForColumns(mg)
+
+	ualert.AddRecordedMetricMigrations(mg)
+
+	ualert.AddStateHistoryMigrations(mg)
+
+	ualert.AddRuleEvaluationPolicyColumns(mg)
+
+	ualert.AddSchedulerReplicaMigrations(mg)
+
+	ualert.AddRuleTemplateMigrations(mg)
+
+	ualert.AddRecurringSilenceMigrations(mg)
+
+	ualert.AddSilenceAuditMigrations(mg)
+
+	ualert.AddTemplateFixtureMigrations(mg)
+
+	ualert.AddNotificationDeliveryMigrations(mg)
 }
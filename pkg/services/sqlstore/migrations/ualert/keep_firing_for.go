@@ -0,0 +1,22 @@
+package ualert
+
+import "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+
+// AddKeepFiringForColumns adds column to store for how long an alert rule keeps firing after its condition stops being met.
+func AddKeepFiringForColumns(mg *migrator.Migrator) {
+	column := &migrator.Column{
+		Name:     "keep_firing_for",
+		Type:     migrator.DB_BigInt,
+		Nullable: false,
+		Default:  "0",
+	}
+
+	mg.AddMigration(
+		"add keep_firing_for column to alert_rule table",
+		migrator.NewAddColumnMigration(migrator.Table{Name: "alert_rule"}, column),
+	)
+	mg.AddMigration(
+		"add keep_firing_for column to alert_rule_version table",
+		migrator.NewAddColumnMigration(migrator.Table{Name: "alert_rule_version"}, column),
+	)
+}
@@ -0,0 +1,37 @@
+package ualert
+
+import "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+
+// AddNotificationDeliveryMigrations creates the table of the log of notification deliveries.
+func AddNotificationDeliveryMigrations(mg *migrator.Migrator) {
+	delivery := migrator.Table{
+		Name: "alert_notification_delivery",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "receiver", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "integration_uid", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "integration_type", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "group_key", Type: migrator.DB_Text, Nullable: false},
+			{Name: "attempt", Type: migrator.DB_Int, Nullable: false},
+			{Name: "status", Type: migrator.DB_NVarchar, Length: 20, Nullable: false},
+			{Name: "error", Type: migrator.DB_Text, Nullable: true},
+			{Name: "latency_ms", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "alert_count", Type: migrator.DB_Int, Nullable: false},
+			{Name: "payload", Type: migrator.DB_Text, Nullable: false},
+			{Name: "payload_truncated", Type: migrator.DB_Bool, Nullable: false, Default: "0"},
+			{Name: "replay_of", Type: migrator.DB_BigInt, Nullable: false, Default: "0"},
+			{Name: "replayed_at", Type: migrator.DB_BigInt, Nullable: false, Default: "0"},
+			{Name: "created", Type: migrator.DB_BigInt, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"org_id", "created"}, Type: migrator.IndexType},
+			{Cols: []string{"org_id", "receiver"}, Type: migrator.IndexType},
+			{Cols: []string{"created"}, Type: migrator.IndexType},
+		},
+	}
+	mg.AddMigration("create alert_notification_delivery table", migrator.NewAddTableMigration(delivery))
+	mg.AddMigration("add index on org_id and created to alert_notification_delivery table", migrator.NewAddIndexMigration(delivery, delivery.Indices[0]))
+	mg.AddMigration("add index on org_id and receiver to alert_notification_delivery table", migrator.NewAddIndexMigration(delivery, delivery.Indices[1]))
+	mg.AddMigration("add index on created to alert_notification_delivery table", migrator.NewAddIndexMigration(delivery, delivery.Indices[2]))
+}
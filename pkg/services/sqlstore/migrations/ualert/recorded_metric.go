@@ -0,0 +1,27 @@
+package ualert
+
+import "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+
+// AddRecordedMetricMigrations creates the table that stores the samples of recording rules writing to the Grafana database.
+func AddRecordedMetricMigrations(mg *migrator.Migrator) {
+	recordedMetricTable := migrator.Table{
+		Name: "alert_recorded_metric",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "name", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "labels", Type: migrator.DB_Text, Nullable: false},
+			{Name: "labels_hash", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "sample_time", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "value", Type: migrator.DB_Double, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"org_id", "name", "sample_time"}},
+			{Cols: []string{"sample_time"}},
+		},
+	}
+
+	mg.AddMigration("create alert_recorded_metric table", migrator.NewAddTableMigration(recordedMetricTable))
+	mg.AddMigration("add index on org_id, name and sample_time to alert_recorded_metric table", migrator.NewAddIndexMigration(recordedMetricTable, recordedMetricTable.Indices[0]))
+	mg.AddMigration("add index on sample_time to alert_recorded_metric table", migrator.NewAddIndexMigration(recordedMetricTable, recordedMetricTable.Indices[1]))
+}
@@ -0,0 +1,32 @@
+package ualert
+
+import "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+
+// AddRecurringSilenceMigrations creates the table of the recurring silences.
+func AddRecurringSilenceMigrations(mg *migrator.Migrator) {
+	table := migrator.Table{
+		Name: "alert_recurring_silence",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "uid", Type: migrator.DB_NVarchar, Length: UIDMaxLength, Nullable: false},
+			{Name: "matchers", Type: migrator.DB_Text, Nullable: false},
+			{Name: "comment", Type: migrator.DB_Text, Nullable: false},
+			{Name: "created_by", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "cron", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: true},
+			{Name: "duration_seconds", Type: migrator.DB_BigInt, Nullable: false, Default: "0"},
+			{Name: "timezone", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: true},
+			{Name: "time_intervals", Type: migrator.DB_Text, Nullable: true},
+			{Name: "version", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "updated", Type: migrator.DB_DateTime, Nullable: false},
+			{Name: "last_starts_at", Type: migrator.DB_BigInt, Nullable: false, Default: "0"},
+			{Name: "last_ends_at", Type: migrator.DB_BigInt, Nullable: false, Default: "0"},
+			{Name: "last_silence_id", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: true},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"org_id", "uid"}, Type: migrator.UniqueIndex},
+		},
+	}
+	mg.AddMigration("create alert_recurring_silence table", migrator.NewAddTableMigration(table))
+	mg.AddMigration("add unique index on org_id and uid to alert_recurring_silence table", migrator.NewAddIndexMigration(table, table.Indices[0]))
+}
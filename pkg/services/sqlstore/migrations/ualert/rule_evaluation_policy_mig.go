@@ -0,0 +1,20 @@
+package ualert
+
+import (
+	"github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+)
+
+// AddRuleEvaluationPolicyColumns creates a column for the evaluation policy in the alert_rule and alert_rule_version tables.
+func AddRuleEvaluationPolicyColumns(mg *migrator.Migrator) {
+	mg.AddMigration("add evaluation_policy column to alert_rule table", migrator.NewAddColumnMigration(migrator.Table{Name: "alert_rule"}, &migrator.Column{
+		Name:     "evaluation_policy",
+		Type:     migrator.DB_Text,
+		Nullable: true,
+	}))
+
+	mg.AddMigration("add evaluation_policy column to alert_rule_version table", migrator.NewAddColumnMigration(migrator.Table{Name: "alert_rule_version"}, &migrator.Column{
+		Name:     "evaluation_policy",
+		Type:     migrator.DB_Text,
+		Nullable: true,
+	}))
+}
@@ -0,0 +1,68 @@
+package ualert
+
+import "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+
+// AddRuleTemplateMigrations creates the tables of the rule templates, their versions, and the rule groups created from them.
+func AddRuleTemplateMigrations(mg *migrator.Migrator) {
+	templateTable := migrator.Table{
+		Name: "alert_rule_template",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "uid", Type: migrator.DB_NVarchar, Length: UIDMaxLength, Nullable: false},
+			{Name: "title", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "description", Type: migrator.DB_Text, Nullable: true},
+			{Name: "parameters", Type: migrator.DB_Text, Nullable: true},
+			{Name: "rules", Type: migrator.DB_MediumText, Nullable: false},
+			{Name: "version", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "updated", Type: migrator.DB_DateTime, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"org_id", "uid"}, Type: migrator.UniqueIndex},
+		},
+	}
+	mg.AddMigration("create alert_rule_template table", migrator.NewAddTableMigration(templateTable))
+	mg.AddMigration("add unique index on org_id and uid to alert_rule_template table", migrator.NewAddIndexMigration(templateTable, templateTable.Indices[0]))
+
+	versionTable := migrator.Table{
+		Name: "alert_rule_template_version",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "template_uid", Type: migrator.DB_NVarchar, Length: UIDMaxLength, Nullable: false},
+			{Name: "version", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "title", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "description", Type: migrator.DB_Text, Nullable: true},
+			{Name: "parameters", Type: migrator.DB_Text, Nullable: true},
+			{Name: "rules", Type: migrator.DB_MediumText, Nullable: false},
+			{Name: "created", Type: migrator.DB_DateTime, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"org_id", "template_uid", "version"}, Type: migrator.UniqueIndex},
+		},
+	}
+	mg.AddMigration("create alert_rule_template_version table", migrator.NewAddTableMigration(versionTable))
+	mg.AddMigration("add unique index on org_id, template_uid and version to alert_rule_template_version table", migrator.NewAddIndexMigration(versionTable, versionTable.Indices[0]))
+
+	instanceTable := migrator.Table{
+		Name: "alert_rule_template_instance",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "namespace_uid", Type: migrator.DB_NVarchar, Length: UIDMaxLength, Nullable: false},
+			{Name: "rule_group", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "template_uid", Type: migrator.DB_NVarchar, Length: UIDMaxLength, Nullable: false},
+			{Name: "interval_seconds", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "parameter_values", Type: migrator.DB_Text, Nullable: true},
+			{Name: "template_version", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "updated", Type: migrator.DB_DateTime, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"org_id", "namespace_uid", "rule_group"}, Type: migrator.UniqueIndex},
+			{Cols: []string{"org_id", "template_uid"}, Type: migrator.IndexType},
+		},
+	}
+	mg.AddMigration("create alert_rule_template_instance table", migrator.NewAddTableMigration(instanceTable))
+	mg.AddMigration("add unique index on org_id, namespace_uid and rule_group to alert_rule_template_instance table", migrator.NewAddIndexMigration(instanceTable, instanceTable.Indices[0]))
+	mg.AddMigration("add index on org_id and template_uid to alert_rule_template_instance table", migrator.NewAddIndexMigration(instanceTable, instanceTable.Indices[1]))
+}
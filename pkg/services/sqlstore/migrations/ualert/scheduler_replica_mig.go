@@ -0,0 +1,21 @@
+package ualert
+
+import "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+
+// AddSchedulerReplicaMigrations creates the table in which the Grafana instances of an HA cluster announce themselves
+// to shard the evaluation of the alert rules when they are not coordinated through Redis.
+func AddSchedulerReplicaMigrations(mg *migrator.Migrator) {
+	replicaTable := migrator.Table{
+		Name: "alert_scheduler_replica",
+		Columns: []*migrator.Column{
+			{Name: "name", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, IsPrimaryKey: true},
+			{Name: "heartbeat_at", Type: migrator.DB_BigInt, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"heartbeat_at"}},
+		},
+	}
+
+	mg.AddMigration("create alert_scheduler_replica table", migrator.NewAddTableMigration(replicaTable))
+	mg.AddMigration("add index on heartbeat_at to alert_scheduler_replica table", migrator.NewAddIndexMigration(replicaTable, replicaTable.Indices[0]))
+}
@@ -0,0 +1,46 @@
+package ualert
+
+import "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+
+// AddSilenceAuditMigrations creates the tables of the audit log of silences and of the silence expiry notifications.
+func AddSilenceAuditMigrations(mg *migrator.Migrator) {
+	audit := migrator.Table{
+		Name: "alert_silence_audit",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "silence_id", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "action", Type: migrator.DB_NVarchar, Length: 20, Nullable: false},
+			{Name: "actor_uid", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: true},
+			{Name: "actor_login", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: true},
+			{Name: "created", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "silence", Type: migrator.DB_Text, Nullable: false},
+			{Name: "changes", Type: migrator.DB_Text, Nullable: true},
+			{Name: "matched_alerts", Type: migrator.DB_Text, Nullable: true},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"org_id", "silence_id"}, Type: migrator.IndexType},
+			{Cols: []string{"org_id", "created"}, Type: migrator.IndexType},
+		},
+	}
+	mg.AddMigration("create alert_silence_audit table", migrator.NewAddTableMigration(audit))
+	mg.AddMigration("add index on org_id and silence_id to alert_silence_audit table", migrator.NewAddIndexMigration(audit, audit.Indices[0]))
+	mg.AddMigration("add index on org_id and created to alert_silence_audit table", migrator.NewAddIndexMigration(audit, audit.Indices[1]))
+
+	notification := migrator.Table{
+		Name: "alert_silence_expiry_notification",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "silence_id", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "receiver", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "before_seconds", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "notified_ends_at", Type: migrator.DB_BigInt, Nullable: false, Default: "0"},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"org_id", "silence_id"}, Type: migrator.UniqueIndex},
+		},
+	}
+	mg.AddMigration("create alert_silence_expiry_notification table", migrator.NewAddTableMigration(notification))
+	mg.AddMigration("add unique index on org_id and silence_id to alert_silence_expiry_notification table", migrator.NewAddIndexMigration(notification, notification.Indices[0]))
+}
@@ -0,0 +1,73 @@
+package ualert
+
+import "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+
+// AddStateHistoryMigrations creates the tables used by the sql state history backend.
+// Every alert instance is stored once along with hashes of its labels, and each state transition references its instance.
+func AddStateHistoryMigrations(mg *migrator.Migrator) {
+	instanceTable := migrator.Table{
+		Name: "alert_state_history_instance",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "rule_uid", Type: migrator.DB_NVarchar, Length: UIDMaxLength, Nullable: false},
+			{Name: "fingerprint", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "labels", Type: migrator.DB_Text, Nullable: false},
+			{Name: "created_at", Type: migrator.DB_BigInt, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"org_id", "rule_uid", "fingerprint"}, Type: migrator.UniqueIndex},
+			{Cols: []string{"created_at"}},
+		},
+	}
+
+	mg.AddMigration("create alert_state_history_instance table", migrator.NewAddTableMigration(instanceTable))
+	mg.AddMigration("add unique index on org_id, rule_uid and fingerprint to alert_state_history_instance table", migrator.NewAddIndexMigration(instanceTable, instanceTable.Indices[0]))
+	mg.AddMigration("add index on created_at to alert_state_history_instance table", migrator.NewAddIndexMigration(instanceTable, instanceTable.Indices[1]))
+
+	labelTable := migrator.Table{
+		Name: "alert_state_history_label",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "instance_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "label_hash", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"org_id", "label_hash"}},
+			{Cols: []string{"instance_id"}},
+		},
+	}
+
+	mg.AddMigration("create alert_state_history_label table", migrator.NewAddTableMigration(labelTable))
+	mg.AddMigration("add index on org_id and label_hash to alert_state_history_label table", migrator.NewAddIndexMigration(labelTable, labelTable.Indices[0]))
+	mg.AddMigration("add index on instance_id to alert_state_history_label table", migrator.NewAddIndexMigration(labelTable, labelTable.Indices[1]))
+
+	entryTable := migrator.Table{
+		Name: "alert_state_history_entry",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "instance_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "rule_uid", Type: migrator.DB_NVarchar, Length: UIDMaxLength, Nullable: false},
+			{Name: "rule_group", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "folder_uid", Type: migrator.DB_NVarchar, Length: UIDMaxLength, Nullable: false},
+			{Name: "dashboard_uid", Type: migrator.DB_NVarchar, Length: UIDMaxLength, Nullable: true},
+			{Name: "panel_id", Type: migrator.DB_BigInt, Nullable: true},
+			{Name: "evaluated_at", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "line", Type: migrator.DB_MediumText, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"org_id", "evaluated_at"}},
+			{Cols: []string{"org_id", "rule_uid", "evaluated_at"}},
+			{Cols: []string{"org_id", "dashboard_uid", "panel_id", "evaluated_at"}},
+			{Cols: []string{"instance_id"}},
+		},
+	}
+
+	mg.AddMigration("create alert_state_history_entry table", migrator.NewAddTableMigration(entryTable))
+	mg.AddMigration("add index on org_id and evaluated_at to alert_state_history_entry table", migrator.NewAddIndexMigration(entryTable, entryTable.Indices[0]))
+	mg.AddMigration("add index on org_id, rule_uid and evaluated_at to alert_state_history_entry table", migrator.NewAddIndexMigration(entryTable, entryTable.Indices[1]))
+	mg.AddMigration("add index on org_id, dashboard_uid, panel_id and evaluated_at to alert_state_history_entry table", migrator.NewAddIndexMigration(entryTable, entryTable.Indices[2]))
+	mg.AddMigration("add index on instance_id to alert_state_history_entry table", migrator.NewAddIndexMigration(entryTable, entryTable.Indices[3]))
+}
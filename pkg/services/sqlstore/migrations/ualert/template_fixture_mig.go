@@ -0,0 +1,23 @@
+package ualert
+
+import "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+
+// AddTemplateFixtureMigrations creates the table of the fixtures that notification templates are tested with.
+func AddTemplateFixtureMigrations(mg *migrator.Migrator) {
+	fixture := migrator.Table{
+		Name: "alert_template_fixture",
+		Columns: []*migrator.Column{
+			{Name: "id", Type: migrator.DB_BigInt, IsPrimaryKey: true, IsAutoIncrement: true},
+			{Name: "org_id", Type: migrator.DB_BigInt, Nullable: false},
+			{Name: "name", Type: migrator.DB_NVarchar, Length: DefaultFieldMaxLength, Nullable: false},
+			{Name: "alerts", Type: migrator.DB_MediumText, Nullable: false},
+			{Name: "expectations", Type: migrator.DB_MediumText, Nullable: true},
+			{Name: "updated", Type: migrator.DB_DateTime, Nullable: false},
+		},
+		Indices: []*migrator.Index{
+			{Cols: []string{"org_id", "name"}, Type: migrator.UniqueIndex},
+		},
+	}
+	mg.AddMigration("create alert_template_fixture table", migrator.NewAddTableMigration(fixture))
+	mg.AddMigration("add unique index on org_id and name to alert_template_fixture table", migrator.NewAddIndexMigration(fixture, fixture.Indices[0]))
+}
@@ -23,6 +23,8 @@ const (
 	alertmanagerDefaultPushPullInterval   = alertingCluster.DefaultPushPullInterval
 	alertmanagerDefaultConfigPollInterval = time.Minute
 	alertmanagerRedisDefaultMaxConns      = 5
+	ruleShardingDefaultHeartbeatInterval  = 5 * time.Second
+	ruleShardingDefaultHeartbeatTimeout   = 30 * time.Second
 	// To start, the alertmanager needs at least one route defined.
 	// TODO: we should move this to Grafana settings and define this as the default.
 	alertmanagerDefaultConfiguration = `{
@@ -63,8 +65,10 @@ const (
 	// DefaultRuleEvaluationInterval indicates a default interval of for how long a rule should be evaluated to change state from Pending to Alerting
 	DefaultRuleEvaluationInterval  = SchedulerBaseInterval * 6 // == 60 seconds
 	stateHistoryDefaultEnabled     = true
+	stateHistorySQLRetention       = 30 * 24 * time.Hour
 	lokiDefaultMaxQueryLength      = 721 * time.Hour // 30d1h, matches the default value in Loki
 	defaultRecordingRequestTimeout = 10 * time.Second
+	defaultRecordingSQLRetention   = 15 * 24 * time.Hour
 	lokiDefaultMaxQuerySize        = 65536 // 64kb
 )
 
@@ -91,12 +95,17 @@ type UnifiedAlertingSettings struct {
 	HARedisMaxConns                 int
 	HARedisTLSEnabled               bool
 	HARedisTLSConfig                dstls.ClientConfig
+	// HARuleShardingEnabled makes every replica evaluate only the alert rules that it owns.
+	HARuleShardingEnabled           bool
+	HARuleShardingHeartbeatInterval time.Duration
+	HARuleShardingHeartbeatTimeout  time.Duration
 	InitializationTimeout           time.Duration
 	MaxAttempts                     int64
 	MinInterval                     time.Duration
 	EvaluationTimeout               time.Duration
 	EvaluationResultLimit           int
 	DisableJitter                   bool
+	SequentialRuleGroupEvaluation   bool // evaluate the rules of a rule group one after the other in their order in the group.
 	ExecuteAlerts                   bool
 	DefaultConfiguration            string
 	Enabled                         *bool // determines whether unified alerting is enabled. If it is nil then user did not define it and therefore its value will be determined during migration. Services should not use it directly.
@@ -121,6 +130,9 @@ type UnifiedAlertingSettings struct {
 	// Retention period for Alertmanager notification log entries.
 	NotificationLogRetention time.Duration
 
+	// Retention period for the log of notification deliveries. 0 keeps the deliveries forever.
+	NotificationDeliveryLogRetention time.Duration
+
 	// Duration for which a resolved alert state transition will continue to be sent to the Alertmanager.
 	ResolvedAlertRetention time.Duration
 
@@ -131,12 +143,16 @@ type UnifiedAlertingSettings struct {
 }
 
 type RecordingRuleSettings struct {
-	Enabled           bool
+	Enabled bool
+	// Backend is the kind of target the recording rules write to: prometheus, influxdb, otlp or sql.
+	Backend           string
 	URL               string
 	BasicAuthUsername string
 	BasicAuthPassword string
 	CustomHeaders     map[string]string
 	Timeout           time.Duration
+	// SQLRetention is how long samples written to the sql backend are kept.
+	SQLRetention time.Duration
 }
 
 // RemoteAlertmanagerSettings contains the configuration needed
@@ -176,6 +192,8 @@ type UnifiedAlertingStateHistorySettings struct {
 	MultiPrimary          string
 	MultiSecondaries      []string
 	ExternalLabels        map[string]string
+	// SQLRetention is how long state transitions are kept by the sql backend.
+	SQLRetention time.Duration
 }
 
 // IsEnabled returns true if UnifiedAlertingSettings.Enabled is either nil or true.
@@ -289,6 +307,21 @@ func (cfg *Cfg) ReadUnifiedAlertingSettings(iniFile *ini.File) error {
 	uaCfg.HARedisTLSConfig.InsecureSkipVerify = ua.Key("ha_redis_tls_insecure_skip_verify").MustBool(false)
 	uaCfg.HARedisTLSConfig.CipherSuites = ua.Key("ha_redis_tls_cipher_suites").MustString("")
 	uaCfg.HARedisTLSConfig.MinVersion = ua.Key("ha_redis_tls_min_version").MustString("")
+	uaCfg.HARuleShardingEnabled = ua.Key("ha_rule_sharding_enabled").MustBool(false)
+	uaCfg.HARuleShardingHeartbeatInterval, err = gtime.ParseDuration(valueAsString(ua, "ha_rule_sharding_heartbeat_interval", ruleShardingDefaultHeartbeatInterval.String()))
+	if err != nil {
+		return err
+	}
+	uaCfg.HARuleShardingHeartbeatTimeout, err = gtime.ParseDuration(valueAsString(ua, "ha_rule_sharding_heartbeat_timeout", ruleShardingDefaultHeartbeatTimeout.String()))
+	if err != nil {
+		return err
+	}
+	if uaCfg.HARuleShardingHeartbeatInterval <= 0 {
+		return fmt.Errorf("value of setting 'ha_rule_sharding_heartbeat_interval' should be greater than 0")
+	}
+	if uaCfg.HARuleShardingHeartbeatTimeout <= uaCfg.HARuleShardingHeartbeatInterval {
+		return fmt.Errorf("value of setting 'ha_rule_sharding_heartbeat_timeout' should be greater than 'ha_rule_sharding_heartbeat_interval'")
+	}
 
 	// TODO load from ini file
 	uaCfg.DefaultConfiguration = alertmanagerDefaultConfiguration
@@ -324,6 +357,8 @@ func (cfg *Cfg) ReadUnifiedAlertingSettings(iniFile *ini.File) error {
 	// We can consider removing the knob entirely in a release after 10.4.
 	uaCfg.DisableJitter = ua.Key("disable_jitter").MustBool(false)
 
+	uaCfg.SequentialRuleGroupEvaluation = ua.Key("sequential_rule_group_evaluation").MustBool(false)
+
 	// The base interval of the scheduler for evaluating alerts.
 	// 1. It is used by the internal scheduler's timer to tick at this interval.
 	// 2. to spread evaluations of rules that need to be evaluated at the current tick T. In other words, the evaluation of rules at the tick T will be evenly spread in the interval from T to T+scheduler_tick_interval.
@@ -430,11 +465,16 @@ func (cfg *Cfg) ReadUnifiedAlertingSettings(iniFile *ini.File) error {
 		MultiSecondaries:      splitTrim(stateHistory.Key("secondaries").MustString(""), ","),
 		ExternalLabels:        stateHistoryLabels.KeysHash(),
 	}
+	uaCfgStateHistory.SQLRetention, err = gtime.ParseDuration(valueAsString(stateHistory, "sql_retention", stateHistorySQLRetention.String()))
+	if err != nil {
+		return err
+	}
 	uaCfg.StateHistory = uaCfgStateHistory
 
 	rr := iniFile.Section("recording_rules")
 	uaCfgRecordingRules := RecordingRuleSettings{
 		Enabled:           rr.Key("enabled").MustBool(false),
+		Backend:           rr.Key("backend").MustString("prometheus"),
 		URL:               rr.Key("url").MustString(""),
 		BasicAuthUsername: rr.Key("basic_auth_username").MustString(""),
 		BasicAuthPassword: rr.Key("basic_auth_password").MustString(""),
@@ -448,6 +488,11 @@ func (cfg *Cfg) ReadUnifiedAlertingSettings(iniFile *ini.File) error {
 		uaCfgRecordingRules.CustomHeaders[key.Name()] = key.Value()
 	}
 
+	uaCfgRecordingRules.SQLRetention, err = gtime.ParseDuration(valueAsString(rr, "sql_retention", defaultRecordingSQLRetention.String()))
+	if err != nil {
+		return err
+	}
+
 	uaCfg.RecordingRules = uaCfgRecordingRules
 
 	uaCfg.MaxStateSaveConcurrency = ua.Key("max_state_save_concurrency").MustInt(1)
@@ -462,6 +507,11 @@ func (cfg *Cfg) ReadUnifiedAlertingSettings(iniFile *ini.File) error {
 		return err
 	}
 
+	uaCfg.NotificationDeliveryLogRetention, err = gtime.ParseDuration(valueAsString(ua, "notification_delivery_log_retention", (30 * 24 * time.Hour).String()))
+	if err != nil {
+		return err
+	}
+
 	uaCfg.ResolvedAlertRetention, err = gtime.ParseDuration(valueAsString(ua, "resolved_alert_retention", (15 * time.Minute).String()))
 	if err != nil {
 		return err
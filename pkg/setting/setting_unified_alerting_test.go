@@ -350,3 +350,42 @@ func TestHARedisTLSSettings(t *testing.T) {
 	require.Equal(t, cipherSuites, cfg.UnifiedAlerting.HARedisTLSConfig.CipherSuites)
 	require.Equal(t, minVersion, cfg.UnifiedAlerting.HARedisTLSConfig.MinVersion)
 }
+
+func TestHARuleShardingSettings(t *testing.T) {
+	t.Run("should use the defaults", func(t *testing.T) {
+		cfg := NewCfg()
+		require.NoError(t, cfg.ReadUnifiedAlertingSettings(ini.Empty()))
+		require.False(t, cfg.UnifiedAlerting.HARuleShardingEnabled)
+		require.Equal(t, ruleShardingDefaultHeartbeatInterval, cfg.UnifiedAlerting.HARuleShardingHeartbeatInterval)
+		require.Equal(t, ruleShardingDefaultHeartbeatTimeout, cfg.UnifiedAlerting.HARuleShardingHeartbeatTimeout)
+	})
+
+	t.Run("should read the settings", func(t *testing.T) {
+		f := ini.Empty()
+		section, err := f.NewSection("unified_alerting")
+		require.NoError(t, err)
+		_, err = section.NewKey("ha_rule_sharding_enabled", "true")
+		require.NoError(t, err)
+		_, err = section.NewKey("ha_rule_sharding_heartbeat_interval", "10s")
+		require.NoError(t, err)
+		_, err = section.NewKey("ha_rule_sharding_heartbeat_timeout", "1m")
+		require.NoError(t, err)
+
+		cfg := NewCfg()
+		require.NoError(t, cfg.ReadUnifiedAlertingSettings(f))
+		require.True(t, cfg.UnifiedAlerting.HARuleShardingEnabled)
+		require.Equal(t, 10*time.Second, cfg.UnifiedAlerting.HARuleShardingHeartbeatInterval)
+		require.Equal(t, time.Minute, cfg.UnifiedAlerting.HARuleShardingHeartbeatTimeout)
+	})
+
+	t.Run("should fail if the timeout is not longer than the interval", func(t *testing.T) {
+		f := ini.Empty()
+		section, err := f.NewSection("unified_alerting")
+		require.NoError(t, err)
+		_, err = section.NewKey("ha_rule_sharding_heartbeat_interval", "30s")
+		require.NoError(t, err)
+
+		cfg := NewCfg()
+		require.ErrorContains(t, cfg.ReadUnifiedAlertingSettings(f), "ha_rule_sharding_heartbeat_timeout")
+	})
+}
@@ -79,6 +79,11 @@ func TestBacktesting(t *testing.T) {
 			require.Equal(t, http.StatusOK, status)
 			var result data.Frame
 			require.NoErrorf(t, json.Unmarshal([]byte(body), &result), "cannot parse response to data frame")
+			require.NotNil(t, result.Meta)
+			details, ok := result.Meta.Custom.(map[string]any)
+			require.Truef(t, ok, "the custom metadata of the frame should contain the details of the backtesting")
+			require.Contains(t, details, "transitions")
+			require.Contains(t, details, "notifications")
 		})
 	})
 
@@ -4,8 +4,10 @@ import (
 	"bytes"
 	"context"
 	"encoding/json"
+	"errors"
 	"fmt"
 	"path/filepath"
+	"time"
 
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/promauto"
@@ -52,16 +54,24 @@ var (
 	)
 )
 
-func ProvideService(search searchV2.SearchService, store store.StorageService, features featuremgmt.FeatureToggles) *Service {
-	return newService(search, store, features)
+// RecordedMetricsReader reads the metrics that recording rules write to the Grafana database.
+type RecordedMetricsReader interface {
+	// QueryRecordedMetrics returns the samples of the metric of the org between from and to, one frame per series.
+	// Only the series that have all the given labels are returned.
+	QueryRecordedMetrics(ctx context.Context, orgID int64, name string, labels map[string]string, from, to time.Time) (data.Frames, error)
 }
 
-func newService(search searchV2.SearchService, store store.StorageService, features featuremgmt.FeatureToggles) *Service {
+func ProvideService(search searchV2.SearchService, store store.StorageService, features featuremgmt.FeatureToggles, recordedMetrics RecordedMetricsReader) *Service {
+	return newService(search, store, features, recordedMetrics)
+}
+
+func newService(search searchV2.SearchService, store store.StorageService, features featuremgmt.FeatureToggles, recordedMetrics RecordedMetricsReader) *Service {
 	s := &Service{
-		search:   search,
-		store:    store,
-		log:      log.New("grafanads"),
-		features: features,
+		search:          search,
+		store:           store,
+		recordedMetrics: recordedMetrics,
+		log:             log.New("grafanads"),
+		features:        features,
 	}
 
 	return s
@@ -69,10 +79,11 @@ func newService(search searchV2.SearchService, store store.StorageService, featu
 
 // Service exists regardless of user settings
 type Service struct {
-	search   searchV2.SearchService
-	store    store.StorageService
-	log      log.Logger
-	features featuremgmt.FeatureToggles
+	search          searchV2.SearchService
+	store           store.StorageService
+	recordedMetrics RecordedMetricsReader
+	log             log.Logger
+	features        featuremgmt.FeatureToggles
 }
 
 func DataSourceModel(orgId int64) *datasources.DataSource {
@@ -100,6 +111,8 @@ func (s *Service) QueryData(ctx context.Context, req *backend.QueryDataRequest)
 			response.Responses[q.RefID] = s.doReadQuery(ctx, q)
 		case queryTypeSearch, queryTypeSearchNext:
 			response.Responses[q.RefID] = s.doSearchQuery(ctx, req, q)
+		case queryTypeRecordedMetrics:
+			response.Responses[q.RefID] = s.doRecordedMetricsQuery(ctx, req, q)
 		default:
 			response.Responses[q.RefID] = backend.DataResponse{
 				Error: fmt.Errorf("unknown query type"),
@@ -166,6 +179,26 @@ func (s *Service) doReadQuery(ctx context.Context, query backend.DataQuery) back
 	return response
 }
 
+func (s *Service) doRecordedMetricsQuery(ctx context.Context, req *backend.QueryDataRequest, query backend.DataQuery) backend.DataResponse {
+	q := &recordedMetricsQueryModel{}
+	response := backend.DataResponse{}
+	err := json.Unmarshal(query.JSON, &q)
+	if err != nil {
+		response.Error = err
+		return response
+	}
+
+	if s.recordedMetrics == nil {
+		response.Error = errors.New("recorded metrics are not available")
+		return response
+	}
+
+	frames, err := s.recordedMetrics.QueryRecordedMetrics(ctx, req.PluginContext.OrgID, q.Metric, q.Labels, query.TimeRange.From, query.TimeRange.To)
+	response.Error = err
+	response.Frames = frames
+	return response
+}
+
 func (s *Service) doRandomWalk(query backend.DataQuery) backend.DataResponse {
 	response := backend.DataResponse{}
 
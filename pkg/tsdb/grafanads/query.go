@@ -17,6 +17,9 @@ const (
 	// currently only .csv files are supported,
 	// other file types will eventually be supported (parquet, etc)
 	queryTypeRead = "read"
+
+	// queryTypeRecordedMetrics reads the series that recording rules wrote to the Grafana database
+	queryTypeRecordedMetrics = "recordedMetrics"
 )
 
 type listQueryModel struct {
@@ -25,3 +28,8 @@ type listQueryModel struct {
 type readQueryModel struct {
 	Path string `json:"path"`
 }
+
+type recordedMetricsQueryModel struct {
+	Metric string            `json:"metric"`
+	Labels map[string]string `json:"labels,omitempty"`
+}
@@ -20,7 +20,13 @@ export const Reduce = ({ labelWidth = 'auto', onChange, refIds, query }: Props)
   };
 
   const onSelectReducer = (value: SelectableValue<string>) => {
-    onChange({ ...query, reducer: value.value });
+    const params = value.value === 'percentile' ? [query.params?.[0] ?? 95] : undefined;
+    onChange({ ...query, reducer: value.value, params });
+  };
+
+  const onPercentileChanged = (e: React.FormEvent<HTMLInputElement>) => {
+    const value = e.currentTarget.valueAsNumber;
+    onChange({ ...query, params: [isNaN(value) ? 0 : value] });
   };
 
   const onSettingsChanged = (settings: ExpressionQuerySettings) => {
@@ -55,6 +61,24 @@ export const Reduce = ({ labelWidth = 'auto', onChange, refIds, query }: Props)
 
   const mode = query.settings?.mode ?? ReducerMode.Strict;
 
+  const percentile = () => {
+    if (query.reducer !== 'percentile') {
+      return;
+    }
+    return (
+      <InlineField label="Percentile" labelWidth={labelWidth}>
+        <Input
+          type="number"
+          min={0}
+          max={100}
+          width={10}
+          onChange={onPercentileChanged}
+          value={query.params?.[0] ?? 95}
+        />
+      </InlineField>
+    );
+  };
+
   const replaceWithNumber = () => {
     if (mode !== ReducerMode.ReplaceNonNumbers) {
       return;
@@ -77,6 +101,7 @@ export const Reduce = ({ labelWidth = 'auto', onChange, refIds, query }: Props)
         <InlineField label="Function" labelWidth={labelWidth}>
           <Select options={reducerTypes} value={reducer} onChange={onSelectReducer} width={20} />
         </InlineField>
+        {percentile()}
         <InlineField label="Mode" labelWidth={labelWidth}>
           <Select onChange={onModeChanged} options={reducerModes} value={mode} width={25} />
         </InlineField>
@@ -29,7 +29,16 @@ export const Resample = ({ labelWidth = 'auto', onChange, refIds, query }: Props
   };
 
   const onSelectUpsampler = (value: SelectableValue<string>) => {
-    onChange({ ...query, upsampler: value.value });
+    onChange({ ...query, upsampler: value.value, maxGap: value.value === 'step' ? (query.maxGap ?? 1) : undefined });
+  };
+
+  const onMaxGapChange = (event: ChangeEvent<HTMLInputElement>) => {
+    const value = event.target.valueAsNumber;
+    onChange({ ...query, maxGap: isNaN(value) ? undefined : value });
+  };
+
+  const onAlignOffsetChange = (event: ChangeEvent<HTMLInputElement>) => {
+    onChange({ ...query, alignOffset: event.target.value || undefined });
   };
 
   return (
@@ -49,6 +58,17 @@ export const Resample = ({ labelWidth = 'auto', onChange, refIds, query }: Props
         <InlineField label="Upsample">
           <Select options={upsamplingTypes} value={upsampler} onChange={onSelectUpsampler} width={25} />
         </InlineField>
+        {query.upsampler === 'step' && (
+          <InlineField label="Max gap" tooltip="Number of windows a gap can span and still be filled">
+            <Input type="number" min={1} onChange={onMaxGapChange} value={query.maxGap ?? 1} width={10} />
+          </InlineField>
+        )}
+        <InlineField
+          label="Align offset"
+          tooltip="Align samples to the wall clock, shifted by this duration, e.g. 0s or 15m"
+        >
+          <Input onChange={onAlignOffsetChange} value={query.alignOffset ?? ''} placeholder="none" width={15} />
+        </InlineField>
       </InlineFieldRow>
     </>
   );
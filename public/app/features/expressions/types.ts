@@ -83,6 +83,30 @@ export const reducerTypes: Array<SelectableValue<string>> = [
   { value: ReducerID.sum, label: 'Sum', description: 'Get the sum of all values' },
   { value: ReducerID.count, label: 'Count', description: 'Get the number of values' },
   { value: ReducerID.last, label: 'Last', description: 'Get the last value' },
+  { value: ReducerID.first, label: 'First', description: 'Get the first value' },
+  { value: 'stddev', label: 'StdDev', description: 'Get the standard deviation of the values' },
+  { value: ReducerID.variance, label: 'Variance', description: 'Get the variance of the values' },
+  { value: ReducerID.range, label: 'Range', description: 'Get the difference between the maximum and minimum values' },
+  { value: ReducerID.diff, label: 'Difference', description: 'Get the difference between the last and first values' },
+  {
+    value: 'diff_abs',
+    label: 'Difference (abs)',
+    description: 'Get the absolute difference between the last and first values',
+  },
+  {
+    value: 'percent_diff',
+    label: 'Percent difference',
+    description: 'Get the difference between the last and first values as a percentage of the first value',
+  },
+  {
+    value: 'percent_diff_abs',
+    label: 'Percent difference (abs)',
+    description: 'Get the absolute difference between the last and first values as a percentage of the first value',
+  },
+  { value: 'count_non_null', label: 'Count non-null', description: 'Get the number of non-null values' },
+  { value: ReducerID.delta, label: 'Delta', description: 'Get the total increase of a counter, handling resets' },
+  { value: 'change_count', label: 'Change count', description: 'Get the number of times the value changes' },
+  { value: 'percentile', label: 'Percentile', description: 'Get the nth percentile of the values' },
 ];
 
 export enum ReducerMode {
@@ -121,6 +145,9 @@ export const upsamplingTypes: Array<SelectableValue<string>> = [
   { value: 'pad', label: 'pad', description: 'fill with the last known value' },
   { value: 'backfilling', label: 'backfilling', description: 'fill with the next known value' },
   { value: 'fillna', label: 'fillna', description: 'Fill with NaNs' },
+  { value: 'linear', label: 'linear', description: 'Interpolate between the last and the next known value' },
+  { value: 'nearest', label: 'nearest', description: 'Fill with the closest known value' },
+  { value: 'step', label: 'step', description: 'Fill with the last known value, unless the gap is longer than max gap' },
 ];
 
 export const thresholdFunctions: Array<SelectableValue<EvalFunction>> = [
@@ -141,8 +168,11 @@ export interface ExpressionQuery extends DataQuery {
   window?: string;
   downsampler?: string;
   upsampler?: string;
+  maxGap?: number;
+  alignOffset?: string;
   conditions?: ClassicCondition[];
   settings?: ExpressionQuerySettings;
+  params?: number[];
 }
 
 export interface ThresholdExpressionQuery extends ExpressionQuery {